@@ -0,0 +1,186 @@
+// Package alertmanager converts Prometheus Alertmanager webhook payloads into Slack Manager alerts.
+//
+// Each alert in the payload becomes one types.Alert, correlated by the Alertmanager fingerprint, so that
+// resolved notifications resolve the same issue as the firing ones:
+//
+//	payload, err := alertmanager.Parse(body)
+//	alerts, err := alertmanager.Convert(payload, alertmanager.Options{RouteKeyLabel: "team"})
+package alertmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// StatusFiring is the status of a firing alert or group.
+	StatusFiring = "firing"
+
+	// StatusResolved is the status of a resolved alert or group.
+	StatusResolved = "resolved"
+
+	// DefaultSeverityLabel is the label holding the alert severity, unless Options.SeverityLabel is set.
+	DefaultSeverityLabel = "severity"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	// It is longer than the default Alertmanager repeat interval (4h), so that firing alerts are not auto-resolved
+	// between notifications.
+	DefaultAutoResolveSeconds = 12 * 60 * 60
+
+	// AnnotationsMetadataKey is the metadata key holding the alert annotations.
+	AnnotationsMetadataKey = "annotations"
+)
+
+// Payload is the Alertmanager webhook payload (version 4).
+type Payload struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []*Alert          `json:"alerts"`
+}
+
+// Alert is a single alert in an Alertmanager webhook payload.
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of all converted alerts. Takes precedence over route keys.
+	SlackChannelID string
+
+	// RouteKeyLabel is the label holding the route key of each alert. If empty, or if the label is missing,
+	// the Alertmanager receiver name is used as route key.
+	RouteKeyLabel string
+
+	// SeverityLabel is the label holding the alert severity. Defaults to DefaultSeverityLabel.
+	SeverityLabel string
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alerts. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse decodes an Alertmanager webhook payload.
+func Parse(data []byte) (*Payload, error) {
+	var payload Payload
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode alertmanager payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// Convert converts each alert in the payload into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The correlation ID is the Alertmanager fingerprint (or a hash of the labels, if the fingerprint is missing).
+//   - Resolved alerts get severity 'resolved'. Firing alerts get the severity from the severity label:
+//     names such as 'critical' and 'page' map to panic, 'warning' to warning, 'info' and 'none' to info, and anything else to error.
+//   - The header is the 'summary' annotation, or the 'alertname' label. The text is the 'description' or 'message' annotation.
+//   - The host is the 'instance' label, and the link is the generator URL.
+//   - All labels are copied to the metadata (so that they can be used with types.FromLabels), and the annotations
+//     are stored in the metadata under AnnotationsMetadataKey.
+func Convert(payload *Payload, opts Options) ([]*types.Alert, error) {
+	if payload == nil {
+		return nil, errors.New("alertmanager payload is nil")
+	}
+
+	if opts.SeverityLabel == "" {
+		opts.SeverityLabel = DefaultSeverityLabel
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	alerts := make([]*types.Alert, 0, len(payload.Alerts))
+
+	for i, src := range payload.Alerts {
+		if src == nil {
+			continue
+		}
+
+		a := convertAlert(payload, src, opts)
+		a.Clean()
+
+		if err := a.Validate(); err != nil {
+			return nil, fmt.Errorf("alerts[%d] could not be converted: %w", i, err)
+		}
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+func convertAlert(payload *Payload, src *Alert, opts Options) *types.Alert {
+	labels := convert.MergeMaps(payload.CommonLabels, src.Labels)
+	annotations := convert.MergeMaps(payload.CommonAnnotations, src.Annotations)
+
+	a := types.NewAlert(severity(src, labels[opts.SeverityLabel]))
+
+	a.CorrelationID = src.Fingerprint
+	if a.CorrelationID == "" {
+		a.CorrelationID = convert.LabelsHash(labels)
+	}
+
+	a.Timestamp = src.StartsAt
+	if a.Severity == types.AlertResolved && !src.EndsAt.IsZero() {
+		a.Timestamp = src.EndsAt
+	}
+
+	if opts.SlackChannelID != "" {
+		a.SlackChannelID = opts.SlackChannelID
+	} else if key := labels[opts.RouteKeyLabel]; opts.RouteKeyLabel != "" && key != "" {
+		a.RouteKey = key
+	} else {
+		a.RouteKey = payload.Receiver
+	}
+
+	a.Type = "alertmanager"
+	a.Source = convert.Source("alertmanager")
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+	a.Header = ":status: " + convert.FirstNonEmpty(annotations["summary"], labels["alertname"], "Alertmanager alert")
+	a.Text = convert.FirstNonEmpty(annotations["description"], annotations["message"])
+	a.Host = labels["instance"]
+	a.Link = src.GeneratorURL
+	a.Author = "Alertmanager"
+
+	for k, v := range labels {
+		a.Metadata[k] = v
+	}
+
+	if len(annotations) > 0 {
+		a.Metadata[AnnotationsMetadataKey] = annotations
+	}
+
+	return a
+}
+
+func severity(src *Alert, label string) types.AlertSeverity {
+	if strings.EqualFold(src.Status, StatusResolved) {
+		return types.AlertResolved
+	}
+
+	return convert.Severity(label)
+}
@@ -0,0 +1,118 @@
+package alertmanager_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/alertmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const payloadJSON = `{
+  "version": "4",
+  "groupKey": "{}:{alertname=\"HighLatency\"}",
+  "truncatedAlerts": 0,
+  "status": "firing",
+  "receiver": "DB-Team",
+  "groupLabels": {"alertname": "HighLatency"},
+  "commonLabels": {"alertname": "HighLatency", "team": "db"},
+  "commonAnnotations": {"summary": "High request latency"},
+  "externalURL": "http://alertmanager:9093",
+  "alerts": [
+    {
+      "status": "firing",
+      "labels": {"alertname": "HighLatency", "team": "db", "severity": "critical", "instance": "db-01:9100"},
+      "annotations": {"summary": "High request latency on db-01", "description": "p99 latency is 2.5s"},
+      "startsAt": "%s",
+      "endsAt": "0001-01-01T00:00:00Z",
+      "generatorURL": "http://prometheus:9090/graph?g0.expr=latency",
+      "fingerprint": "c6a2b5f1d3e4a7b8"
+    },
+    {
+      "status": "resolved",
+      "labels": {"alertname": "HighLatency", "team": "db", "severity": "warning", "instance": "db-02:9100"},
+      "annotations": {},
+      "startsAt": "%s",
+      "endsAt": "%s",
+      "generatorURL": "",
+      "fingerprint": ""
+    }
+  ]
+}`
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	startsAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	endsAt := startsAt.Add(30 * time.Minute)
+	ts := func(t time.Time) string { return t.Format(time.RFC3339) }
+
+	payload, err := alertmanager.Parse([]byte(fmt.Sprintf(payloadJSON, ts(startsAt), ts(startsAt), ts(endsAt))))
+	require.NoError(t, err)
+	assert.Equal(t, "DB-Team", payload.Receiver)
+
+	alerts, err := alertmanager.Convert(payload, alertmanager.Options{})
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+
+	firing := alerts[0]
+	assert.Equal(t, types.AlertPanic, firing.Severity)
+	assert.Equal(t, "c6a2b5f1d3e4a7b8", firing.CorrelationID)
+	assert.Equal(t, "db-team", firing.RouteKey)
+	assert.Equal(t, ":status: High request latency on db-01", firing.Header)
+	assert.Equal(t, "p99 latency is 2.5s", firing.Text)
+	assert.Equal(t, "db-01:9100", firing.Host)
+	assert.Equal(t, "http://prometheus:9090/graph?g0.expr=latency", firing.Link)
+	assert.Equal(t, startsAt, firing.Timestamp)
+	assert.True(t, firing.IssueFollowUpEnabled)
+	assert.Equal(t, alertmanager.DefaultAutoResolveSeconds, firing.AutoResolveSeconds)
+	assert.Equal(t, "db", firing.Metadata["team"])
+	assert.Equal(t, map[string]string{"summary": "High request latency on db-01", "description": "p99 latency is 2.5s"}, firing.Metadata[alertmanager.AnnotationsMetadataKey])
+
+	resolved := alerts[1]
+	assert.Equal(t, types.AlertResolved, resolved.Severity)
+	assert.Len(t, resolved.CorrelationID, 64, "hash of the labels when the fingerprint is missing")
+	assert.Equal(t, ":status: High request latency", resolved.Header, "common annotations are used as fallback")
+	assert.Equal(t, endsAt, resolved.Timestamp)
+}
+
+func TestConvertOptions(t *testing.T) {
+	t.Parallel()
+
+	payload := &alertmanager.Payload{
+		Receiver: "default",
+		Alerts: []*alertmanager.Alert{
+			{Status: "firing", Labels: map[string]string{"alertname": "DiskFull", "team": "Storage", "level": "warning"}, Fingerprint: "a1"},
+			{Status: "firing", Labels: map[string]string{"alertname": "DiskFull", "level": "info"}, Fingerprint: "a2"},
+		},
+	}
+
+	alerts, err := alertmanager.Convert(payload, alertmanager.Options{RouteKeyLabel: "team", SeverityLabel: "level", AutoResolveSeconds: 600})
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+
+	assert.Equal(t, "storage", alerts[0].RouteKey)
+	assert.Equal(t, types.AlertWarning, alerts[0].Severity)
+	assert.Equal(t, 600, alerts[0].AutoResolveSeconds)
+	assert.Equal(t, ":status: DiskFull", alerts[0].Header)
+	assert.Equal(t, "default", alerts[1].RouteKey, "receiver is used when the route key label is missing")
+	assert.Equal(t, types.AlertInfo, alerts[1].Severity)
+
+	alerts, err = alertmanager.Convert(payload, alertmanager.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+	assert.Equal(t, "C12345678", alerts[0].SlackChannelID)
+	assert.Empty(t, alerts[0].RouteKey)
+	assert.Equal(t, types.AlertError, alerts[0].Severity, "unknown severity label maps to error")
+
+	_, err = alertmanager.Convert(payload, alertmanager.Options{SlackChannelID: "not a channel"})
+	require.ErrorContains(t, err, "alerts[0] could not be converted")
+
+	_, err = alertmanager.Convert(nil, alertmanager.Options{})
+	require.EqualError(t, err, "alertmanager payload is nil")
+
+	_, err = alertmanager.Parse([]byte("{"))
+	require.ErrorContains(t, err, "failed to decode alertmanager payload")
+}
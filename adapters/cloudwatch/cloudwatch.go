@@ -0,0 +1,271 @@
+// Package cloudwatch converts AWS CloudWatch alarm state change notifications, delivered by SNS,
+// into Slack Manager alerts.
+//
+// The alarm ARN is used as correlation ID, so that OK notifications resolve the same issue as ALARM notifications:
+//
+//	notification, err := cloudwatch.ParseNotification(body)
+//	alarm, err := notification.Alarm()
+//	alert, err := cloudwatch.Convert(alarm, cloudwatch.Options{SlackChannelID: "C12345678"})
+package cloudwatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// NotificationTypeNotification is the SNS message type of notifications.
+	NotificationTypeNotification = "Notification"
+
+	// NotificationTypeSubscriptionConfirmation is the SNS message type sent when an HTTP(S) endpoint is subscribed to a topic.
+	NotificationTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+
+	// StateAlarm is the alarm state when the metric breaches the threshold.
+	StateAlarm = "ALARM"
+
+	// StateOK is the alarm state when the metric is within the threshold.
+	StateOK = "OK"
+
+	// StateInsufficientData is the alarm state when there is not enough data to evaluate the alarm.
+	StateInsufficientData = "INSUFFICIENT_DATA"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	// CloudWatch does not repeat ALARM notifications, so the issue is kept open until the OK notification.
+	DefaultAutoResolveSeconds = 7 * 24 * 60 * 60
+
+	// stateChangeTimeLayout is the layout of the CloudWatch StateChangeTime field.
+	stateChangeTimeLayout = "2006-01-02T15:04:05.000-0700"
+)
+
+// ErrNotAnAlarm is returned by Notification.Alarm if the SNS message is not a CloudWatch alarm notification,
+// for example a subscription confirmation. Subscription confirmations must be confirmed by visiting SubscribeURL.
+var ErrNotAnAlarm = errors.New("sns message is not a cloudwatch alarm notification")
+
+// Notification is an SNS HTTP(S) notification.
+type Notification struct {
+	Type             string    `json:"Type"`
+	MessageID        string    `json:"MessageId"`
+	TopicArn         string    `json:"TopicArn"`
+	Subject          string    `json:"Subject"`
+	Message          string    `json:"Message"`
+	Timestamp        time.Time `json:"Timestamp"`
+	SignatureVersion string    `json:"SignatureVersion"`
+	Signature        string    `json:"Signature"`
+	SigningCertURL   string    `json:"SigningCertURL"`
+	SubscribeURL     string    `json:"SubscribeURL"`
+	UnsubscribeURL   string    `json:"UnsubscribeURL"`
+}
+
+// Alarm is a CloudWatch alarm state change, as found in the SNS notification message.
+type Alarm struct {
+	AlarmName        string   `json:"AlarmName"`
+	AlarmDescription string   `json:"AlarmDescription"`
+	AWSAccountID     string   `json:"AWSAccountId"`
+	NewStateValue    string   `json:"NewStateValue"`
+	NewStateReason   string   `json:"NewStateReason"`
+	StateChangeTime  string   `json:"StateChangeTime"`
+	Region           string   `json:"Region"`
+	AlarmArn         string   `json:"AlarmArn"`
+	OldStateValue    string   `json:"OldStateValue"`
+	Trigger          *Trigger `json:"Trigger"`
+
+	// TopicArn is the ARN of the SNS topic the alarm was delivered through. It is set by Notification.Alarm.
+	TopicArn string `json:"-"`
+}
+
+// Trigger describes the metric and threshold of an alarm.
+type Trigger struct {
+	MetricName         string       `json:"MetricName"`
+	Namespace          string       `json:"Namespace"`
+	Statistic          string       `json:"Statistic"`
+	Unit               string       `json:"Unit"`
+	Dimensions         []*Dimension `json:"Dimensions"`
+	Period             int          `json:"Period"`
+	EvaluationPeriods  int          `json:"EvaluationPeriods"`
+	ComparisonOperator string       `json:"ComparisonOperator"`
+	Threshold          float64      `json:"Threshold"`
+}
+
+// Dimension is a metric dimension.
+type Dimension struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over RouteKey.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Defaults to the SNS topic name, if known.
+	RouteKey string
+
+	// AlarmSeverity is the severity of alerts in the ALARM state. Defaults to error.
+	// Alerts in the INSUFFICIENT_DATA state always get severity warning.
+	AlarmSeverity types.AlertSeverity
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// ParseNotification decodes an SNS notification.
+func ParseNotification(data []byte) (*Notification, error) {
+	var n Notification
+
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, fmt.Errorf("failed to decode sns notification: %w", err)
+	}
+
+	return &n, nil
+}
+
+// Alarm decodes the CloudWatch alarm in the notification message.
+// ErrNotAnAlarm is returned if the notification is not an alarm notification.
+func (n *Notification) Alarm() (*Alarm, error) {
+	if n.Type != NotificationTypeNotification {
+		return nil, fmt.Errorf("%w: type is '%s'", ErrNotAnAlarm, n.Type)
+	}
+
+	alarm, err := ParseAlarm([]byte(n.Message))
+	if err != nil {
+		return nil, err
+	}
+
+	alarm.TopicArn = n.TopicArn
+
+	return alarm, nil
+}
+
+// ParseAlarm decodes a CloudWatch alarm state change, for example from an SNS message delivered with raw message delivery.
+func ParseAlarm(data []byte) (*Alarm, error) {
+	var alarm Alarm
+
+	if err := json.Unmarshal(data, &alarm); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotAnAlarm, err)
+	}
+
+	if alarm.AlarmArn == "" || alarm.NewStateValue == "" {
+		return nil, fmt.Errorf("%w: AlarmArn and NewStateValue are required", ErrNotAnAlarm)
+	}
+
+	return &alarm, nil
+}
+
+// Convert converts the alarm into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The correlation ID is the alarm ARN.
+//   - The OK state maps to severity resolved, INSUFFICIENT_DATA to warning, and ALARM to Options.AlarmSeverity.
+//   - The header is the alarm name, and the text is the alarm description followed by the state change reason.
+//   - The author is the region, and the host is the AWS account ID.
+//   - The metric dimensions become alert fields, and the link points to the alarm in the CloudWatch console.
+func Convert(alarm *Alarm, opts Options) (*types.Alert, error) {
+	if alarm == nil {
+		return nil, errors.New("cloudwatch alarm is nil")
+	}
+
+	if opts.AlarmSeverity == "" {
+		opts.AlarmSeverity = types.AlertError
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	a := types.NewAlert(severity(alarm.NewStateValue, opts.AlarmSeverity))
+
+	a.CorrelationID = alarm.AlarmArn
+	a.Type = "cloudwatch"
+	a.Source = convert.Source("cloudwatch")
+	a.Source.Region = convert.FirstNonEmpty(arnRegion(alarm.AlarmArn), alarm.Region)
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+	a.Header = ":status: " + convert.FirstNonEmpty(alarm.AlarmName, "CloudWatch alarm")
+	a.Text = strings.TrimSpace(alarm.AlarmDescription + "\n\n" + alarm.NewStateReason)
+	a.Author = alarm.Region
+	a.Host = alarm.AWSAccountID
+	a.Link = consoleURL(alarm)
+
+	if t, err := time.Parse(stateChangeTimeLayout, alarm.StateChangeTime); err == nil {
+		a.Timestamp = t.UTC()
+	}
+
+	switch {
+	case opts.SlackChannelID != "":
+		a.SlackChannelID = opts.SlackChannelID
+	case opts.RouteKey != "":
+		a.RouteKey = opts.RouteKey
+	default:
+		a.RouteKey = arnResource(alarm.TopicArn)
+	}
+
+	if alarm.Trigger != nil {
+		for _, d := range alarm.Trigger.Dimensions {
+			if d != nil && len(a.Fields) < types.MaxFieldCount {
+				a.Fields = append(a.Fields, &types.Field{Title: d.Name, Value: d.Value})
+			}
+		}
+
+		a.Metadata["namespace"] = alarm.Trigger.Namespace
+		a.Metadata["metricName"] = alarm.Trigger.MetricName
+	}
+
+	a.Metadata["alarmName"] = alarm.AlarmName
+	a.Metadata["oldState"] = alarm.OldStateValue
+	a.Metadata["newState"] = alarm.NewStateValue
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("alarm could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+func severity(state string, alarmSeverity types.AlertSeverity) types.AlertSeverity {
+	switch state {
+	case StateOK:
+		return types.AlertResolved
+	case StateInsufficientData:
+		return types.AlertWarning
+	default:
+		return alarmSeverity
+	}
+}
+
+// consoleURL returns the CloudWatch console URL of the alarm, using the region in the alarm ARN.
+func consoleURL(alarm *Alarm) string {
+	region := arnRegion(alarm.AlarmArn)
+	if region == "" || alarm.AlarmName == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/cloudwatch/home?region=%s#alarmsV2:alarm/%s", region, region, url.PathEscape(alarm.AlarmName))
+}
+
+// arnRegion returns the region code of an ARN, such as 'eu-west-1', or an empty string if the ARN has no region.
+func arnRegion(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 4 {
+		return ""
+	}
+
+	return parts[3]
+}
+
+// arnResource returns the last segment of an ARN, such as the topic name of an SNS topic ARN.
+func arnResource(arn string) string {
+	if arn == "" {
+		return ""
+	}
+
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
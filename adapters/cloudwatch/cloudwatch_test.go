@@ -0,0 +1,140 @@
+package cloudwatch_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/cloudwatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func alarmJSON(t *testing.T, state string, changed time.Time) string {
+	t.Helper()
+
+	alarm := map[string]any{
+		"AlarmName":        "High CPU db-01",
+		"AlarmDescription": "CPU above 90% for 5 minutes",
+		"AWSAccountId":     "123456789012",
+		"NewStateValue":    state,
+		"NewStateReason":   "Threshold Crossed: 1 datapoint [95.0] was greater than the threshold (90.0).",
+		"StateChangeTime":  changed.Format("2006-01-02T15:04:05.000-0700"),
+		"Region":           "EU (Ireland)",
+		"AlarmArn":         "arn:aws:cloudwatch:eu-west-1:123456789012:alarm:High CPU db-01",
+		"OldStateValue":    "OK",
+		"Trigger": map[string]any{
+			"MetricName":         "CPUUtilization",
+			"Namespace":          "AWS/RDS",
+			"Statistic":          "AVERAGE",
+			"Dimensions":         []map[string]string{{"name": "DBInstanceIdentifier", "value": "db-01"}},
+			"Period":             300,
+			"EvaluationPeriods":  1,
+			"ComparisonOperator": "GreaterThanThreshold",
+			"Threshold":          90.0,
+		},
+	}
+
+	data, err := json.Marshal(alarm)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func snsJSON(t *testing.T, notificationType, message string) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]any{
+		"Type":         notificationType,
+		"MessageId":    "b3b1c2d4",
+		"TopicArn":     "arn:aws:sns:eu-west-1:123456789012:db-alarms",
+		"Subject":      "ALARM: \"High CPU db-01\" in EU (Ireland)",
+		"Message":      message,
+		"Timestamp":    "2026-03-01T10:00:00.000Z",
+		"SubscribeURL": "https://sns.eu-west-1.amazonaws.com/?Action=ConfirmSubscription",
+	})
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestConvertAlarm(t *testing.T) {
+	t.Parallel()
+
+	changed := time.Now().UTC().Add(-time.Minute).Truncate(time.Millisecond)
+
+	notification, err := cloudwatch.ParseNotification(snsJSON(t, "Notification", alarmJSON(t, cloudwatch.StateAlarm, changed)))
+	require.NoError(t, err)
+
+	alarm, err := notification.Alarm()
+	require.NoError(t, err)
+
+	alert, err := cloudwatch.Convert(alarm, cloudwatch.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertError, alert.Severity)
+	assert.Equal(t, "arn:aws:cloudwatch:eu-west-1:123456789012:alarm:High CPU db-01", alert.CorrelationID)
+	assert.Equal(t, "db-alarms", alert.RouteKey)
+	assert.Equal(t, ":status: High CPU db-01", alert.Header)
+	assert.Contains(t, alert.Text, "CPU above 90% for 5 minutes\n\nThreshold Crossed")
+	assert.Equal(t, "EU (Ireland)", alert.Author)
+	assert.Equal(t, "123456789012", alert.Host)
+	assert.Equal(t, "https://eu-west-1.console.aws.amazon.com/cloudwatch/home?region=eu-west-1#alarmsV2:alarm/High%20CPU%20db-01", alert.Link)
+	assert.Equal(t, changed, alert.Timestamp)
+	assert.Equal(t, []*types.Field{{Title: "DBInstanceIdentifier", Value: "db-01"}}, alert.Fields)
+	assert.Equal(t, "AWS/RDS", alert.Metadata["namespace"])
+	assert.Equal(t, &types.AlertSource{System: "cloudwatch", Region: "eu-west-1", IngestPath: "adapter/cloudwatch"}, alert.Source)
+}
+
+func TestConvertStates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	ok, err := cloudwatch.ParseAlarm([]byte(alarmJSON(t, cloudwatch.StateOK, now)))
+	require.NoError(t, err)
+
+	alert, err := cloudwatch.Convert(ok, cloudwatch.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertResolved, alert.Severity)
+	assert.Equal(t, "C12345678", alert.SlackChannelID)
+
+	insufficient, err := cloudwatch.ParseAlarm([]byte(alarmJSON(t, cloudwatch.StateInsufficientData, now)))
+	require.NoError(t, err)
+
+	alert, err = cloudwatch.Convert(insufficient, cloudwatch.Options{RouteKey: "aws"})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertWarning, alert.Severity)
+	assert.Equal(t, "aws", alert.RouteKey)
+
+	alarm, err := cloudwatch.ParseAlarm([]byte(alarmJSON(t, cloudwatch.StateAlarm, now)))
+	require.NoError(t, err)
+
+	alert, err = cloudwatch.Convert(alarm, cloudwatch.Options{AlarmSeverity: types.AlertPanic})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertPanic, alert.Severity)
+}
+
+func TestNotAnAlarm(t *testing.T) {
+	t.Parallel()
+
+	notification, err := cloudwatch.ParseNotification(snsJSON(t, "SubscriptionConfirmation", "You have chosen to subscribe"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, notification.SubscribeURL)
+
+	_, err = notification.Alarm()
+	require.ErrorIs(t, err, cloudwatch.ErrNotAnAlarm)
+
+	notification, err = cloudwatch.ParseNotification(snsJSON(t, "Notification", `{"hello":"world"}`))
+	require.NoError(t, err)
+
+	_, err = notification.Alarm()
+	require.ErrorIs(t, err, cloudwatch.ErrNotAnAlarm)
+
+	_, err = cloudwatch.ParseAlarm([]byte("not json"))
+	require.ErrorIs(t, err, cloudwatch.ErrNotAnAlarm)
+
+	_, err = cloudwatch.Convert(nil, cloudwatch.Options{})
+	require.EqualError(t, err, "cloudwatch alarm is nil")
+}
@@ -0,0 +1,276 @@
+// Package datadog converts Datadog monitor webhook payloads into Slack Manager alerts.
+//
+// Datadog webhooks use a custom payload template. Configure the webhook integration with the following payload,
+// which matches the Payload type:
+//
+//	{
+//	  "id": "$ID",
+//	  "title": "$EVENT_TITLE",
+//	  "message": "$EVENT_MSG",
+//	  "date": "$DATE",
+//	  "alert_id": "$ALERT_ID",
+//	  "alert_title": "$ALERT_TITLE",
+//	  "alert_transition": "$ALERT_TRANSITION",
+//	  "alert_type": "$ALERT_TYPE",
+//	  "alert_priority": "$ALERT_PRIORITY",
+//	  "alert_scope": "$ALERT_SCOPE",
+//	  "alert_query": "$ALERT_QUERY",
+//	  "hostname": "$HOSTNAME",
+//	  "link": "$LINK",
+//	  "tags": "$TAGS",
+//	  "org": {"id": "$ORG_ID", "name": "$ORG_NAME"}
+//	}
+//
+// The monitor ID and the monitor group (the alert scope) form the correlation ID, so that a flapping monitor
+// updates a single issue per group:
+//
+//	payload, err := datadog.Parse(body)
+//	alert, err := datadog.Convert(payload, datadog.Options{RouteKeyTag: "team"})
+package datadog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// TransitionTriggered is the transition of monitors entering the alert state.
+	TransitionTriggered = "Triggered"
+
+	// TransitionReTriggered is the transition of monitors re-entering the alert state, for example from warn.
+	TransitionReTriggered = "Re-Triggered"
+
+	// TransitionWarn is the transition of monitors entering the warn state.
+	TransitionWarn = "Warn"
+
+	// TransitionRecovered is the transition of monitors returning to the OK state.
+	TransitionRecovered = "Recovered"
+
+	// TransitionNoData is the transition of monitors that stopped receiving data.
+	TransitionNoData = "No Data"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 24 * 60 * 60
+
+	// DefaultRouteKey is the route key of converted alerts when neither Options nor the payload tags provide one.
+	DefaultRouteKey = "datadog"
+
+	// correlationIDPrefix is prepended to the monitor ID, to form the correlation ID.
+	correlationIDPrefix = "datadog-monitor-"
+)
+
+// Payload is a Datadog webhook payload, using the template in the package documentation.
+type Payload struct {
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+	Date            string `json:"date"`
+	AlertID         string `json:"alert_id"`
+	AlertTitle      string `json:"alert_title"`
+	AlertTransition string `json:"alert_transition"`
+	AlertType       string `json:"alert_type"`
+	AlertPriority   string `json:"alert_priority"`
+	AlertScope      string `json:"alert_scope"`
+	AlertQuery      string `json:"alert_query"`
+	Hostname        string `json:"hostname"`
+	Link            string `json:"link"`
+	Tags            string `json:"tags"`
+	Org             *Org   `json:"org"`
+}
+
+// Org is the Datadog organization of the monitor.
+type Org struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over route keys.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Takes precedence over RouteKeyTag.
+	RouteKey string
+
+	// RouteKeyTag is the monitor tag holding the route key, such as 'team'. If empty, or if the tag is missing,
+	// DefaultRouteKey is used.
+	RouteKeyTag string
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse decodes a Datadog webhook payload.
+func Parse(data []byte) (*Payload, error) {
+	var payload Payload
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode datadog payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// Convert converts the payload into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The correlation ID is formed from the monitor ID and the monitor group (the alert scope).
+//   - Recovered transitions (and alert type 'success') map to severity resolved, warn and no data transitions to warning,
+//     and other transitions to error, or panic for monitors with priority P1.
+//   - The header is the monitor title, the text is the event message, and the link is the event URL.
+//   - The tags of the monitor group become alert fields, and all tags become metadata.
+func Convert(payload *Payload, opts Options) (*types.Alert, error) {
+	if payload == nil {
+		return nil, errors.New("datadog payload is empty")
+	}
+
+	if payload.AlertID == "" {
+		return nil, errors.New("datadog monitor ID is missing")
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	tags := parseTags(payload.Tags)
+	scope := parseTags(payload.AlertScope)
+
+	a := types.NewAlert(severity(payload))
+
+	a.CorrelationID = CorrelationID(payload.AlertID, payload.AlertScope)
+	a.Type = "datadog"
+	a.Source = convert.Source("datadog")
+	a.Author = "Datadog"
+	a.Header = ":status: " + convert.FirstNonEmpty(payload.AlertTitle, trimTitlePrefix(payload.Title), "Datadog monitor "+payload.AlertID)
+	a.Text = payload.Message
+	a.Link = payload.Link
+	a.Host = payload.Hostname
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+
+	if ms, err := strconv.ParseInt(payload.Date, 10, 64); err == nil && ms > 0 {
+		a.Timestamp = time.UnixMilli(ms)
+	}
+
+	for _, t := range scope {
+		a.Fields = append(a.Fields, &types.Field{Title: t.key, Value: convert.FirstNonEmpty(t.value, t.key)})
+	}
+
+	for _, t := range tags {
+		a.Metadata[t.key] = t.value
+	}
+
+	a.Metadata["datadogMonitorId"] = payload.AlertID
+
+	if payload.AlertPriority != "" {
+		a.Metadata["datadogPriority"] = payload.AlertPriority
+	}
+
+	switch {
+	case opts.SlackChannelID != "":
+		a.SlackChannelID = opts.SlackChannelID
+	case opts.RouteKey != "":
+		a.RouteKey = opts.RouteKey
+	default:
+		a.RouteKey = DefaultRouteKey
+
+		if opts.RouteKeyTag != "" {
+			if value, ok := a.Metadata[opts.RouteKeyTag].(string); ok && value != "" {
+				a.RouteKey = value
+			}
+		}
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("datadog payload could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+// CorrelationID returns the correlation ID of alerts for the specified monitor ID and monitor group (alert scope).
+// The scope tags are sorted, so that the ID does not depend on their order.
+func CorrelationID(monitorID, scope string) string {
+	tags := parseTags(scope)
+	if len(tags) == 0 {
+		return correlationIDPrefix + monitorID
+	}
+
+	input := make([]string, 0, len(tags))
+	for _, t := range tags {
+		input = append(input, t.key+":"+t.value)
+	}
+
+	return correlationIDPrefix + monitorID + "-" + convert.Hash(input...)[:16]
+}
+
+func severity(payload *Payload) types.AlertSeverity {
+	transition := strings.ToLower(strings.TrimSpace(payload.AlertTransition))
+
+	switch {
+	case transition == strings.ToLower(TransitionRecovered) || strings.EqualFold(payload.AlertType, "success"):
+		return types.AlertResolved
+	case strings.Contains(transition, "warn") || transition == strings.ToLower(TransitionNoData):
+		return types.AlertWarning
+	case strings.EqualFold(payload.AlertPriority, "P1"):
+		return types.AlertPanic
+	case strings.EqualFold(payload.AlertType, "info"):
+		return types.AlertInfo
+	default:
+		return types.AlertError
+	}
+}
+
+type tag struct {
+	key   string
+	value string
+}
+
+// parseTags parses comma separated 'key:value' tags, sorted by key and value. Tags without a value get an empty value.
+// The catch-all scope '*' is ignored.
+func parseTags(s string) []tag {
+	var tags []tag
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" || part == "*" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(part, ":")
+		tags = append(tags, tag{key: strings.TrimSpace(key), value: strings.TrimSpace(value)})
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].key != tags[j].key {
+			return tags[i].key < tags[j].key
+		}
+
+		return tags[i].value < tags[j].value
+	})
+
+	return tags
+}
+
+// trimTitlePrefix removes the '[Triggered] ' style transition prefix of Datadog event titles.
+func trimTitlePrefix(title string) string {
+	title = strings.TrimSpace(title)
+
+	if strings.HasPrefix(title, "[") {
+		if i := strings.Index(title, "] "); i > 0 {
+			return title[i+2:]
+		}
+	}
+
+	return title
+}
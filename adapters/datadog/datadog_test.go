@@ -0,0 +1,108 @@
+package datadog_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/datadog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const payloadJSON = `{
+  "id": "7323157366290001234",
+  "title": "[%s] High CPU on web hosts",
+  "message": "CPU usage is above 90%%",
+  "date": "%d",
+  "alert_id": "12345678",
+  "alert_title": "High CPU on web hosts",
+  "alert_transition": "%s",
+  "alert_type": "%s",
+  "alert_priority": "%s",
+  "alert_scope": "host:web01,env:prod",
+  "hostname": "web01",
+  "link": "https://app.datadoghq.com/event/event?id=7323157366290001234",
+  "tags": "env:prod,host:web01,team:platform,monitor",
+  "org": {"id": "1", "name": "Acme"}
+}`
+
+func parse(t *testing.T, transition, alertType, priority string, date time.Time) *datadog.Payload {
+	t.Helper()
+
+	payload, err := datadog.Parse([]byte(fmt.Sprintf(payloadJSON, transition, date.UnixMilli(), transition, alertType, priority)))
+	require.NoError(t, err)
+
+	return payload
+}
+
+func TestConvertTriggered(t *testing.T) {
+	t.Parallel()
+
+	date := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+
+	alert, err := datadog.Convert(parse(t, "Triggered", "error", "P2", date), datadog.Options{RouteKeyTag: "team"})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertError, alert.Severity)
+	assert.Equal(t, datadog.CorrelationID("12345678", "env:prod,host:web01"), alert.CorrelationID)
+	assert.Equal(t, "platform", alert.RouteKey)
+	assert.Equal(t, ":status: High CPU on web hosts", alert.Header)
+	assert.Equal(t, "CPU usage is above 90%", alert.Text)
+	assert.Equal(t, "web01", alert.Host)
+	assert.True(t, date.Equal(alert.Timestamp))
+	assert.Equal(t, []*types.Field{{Title: "env", Value: "prod"}, {Title: "host", Value: "web01"}}, alert.Fields)
+	assert.Equal(t, "prod", alert.Metadata["env"])
+	assert.Contains(t, alert.Metadata, "monitor")
+	assert.Contains(t, alert.Link, "datadoghq.com")
+}
+
+func TestConvertTransitions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		transition string
+		alertType  string
+		priority   string
+		expected   types.AlertSeverity
+	}{
+		{"Triggered", "error", "P1", types.AlertPanic},
+		{"Re-Triggered", "error", "", types.AlertError},
+		{"Warn", "warning", "P1", types.AlertWarning},
+		{"No Data", "error", "", types.AlertWarning},
+		{"Recovered", "success", "P1", types.AlertResolved},
+	}
+
+	for _, tt := range tests {
+		alert, err := datadog.Convert(parse(t, tt.transition, tt.alertType, tt.priority, time.Now()), datadog.Options{SlackChannelID: "C12345678"})
+		require.NoError(t, err, tt.transition)
+
+		assert.Equal(t, tt.expected, alert.Severity, tt.transition)
+		assert.Equal(t, "C12345678", alert.SlackChannelID)
+		assert.Empty(t, alert.RouteKey)
+	}
+}
+
+func TestCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "datadog-monitor-1", datadog.CorrelationID("1", ""))
+	assert.Equal(t, "datadog-monitor-1", datadog.CorrelationID("1", "*"))
+	assert.Equal(t, datadog.CorrelationID("1", "env:prod,host:a"), datadog.CorrelationID("1", "host:a, env:prod"))
+	assert.NotEqual(t, datadog.CorrelationID("1", "host:a"), datadog.CorrelationID("1", "host:b"))
+	assert.NotEqual(t, datadog.CorrelationID("1", "host:a"), datadog.CorrelationID("2", "host:a"))
+}
+
+func TestConvertInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := datadog.Convert(nil, datadog.Options{})
+	require.Error(t, err)
+
+	_, err = datadog.Convert(&datadog.Payload{Title: "x"}, datadog.Options{})
+	require.Error(t, err)
+
+	_, err = datadog.Parse([]byte("{"))
+	require.Error(t, err)
+}
@@ -0,0 +1,370 @@
+// Package email converts raw RFC 822 email messages into Slack Manager alerts, for legacy systems that can only
+// send email notifications.
+//
+// The subject becomes the alert header and the body the alert text, with quoted replies and signatures stripped,
+// and HTML bodies converted to Slack mrkdwn. The message size and the size of each kept attachment are limited:
+//
+//	msg, err := email.Parse(raw)
+//	alert, err := email.Convert(msg, email.Options{RouteKey: "legacy-monitoring"})
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// MaxMessageSize is the maximum size of a raw email message accepted by Parse.
+	MaxMessageSize = 10 << 20
+
+	// MaxAttachmentSize is the maximum size of an attachment whose content is kept by Parse.
+	// Larger attachments are listed with their size, but without content.
+	MaxAttachmentSize = 1 << 20
+
+	// MaxMultipartDepth is the maximum nesting depth of multipart bodies.
+	MaxMultipartDepth = 5
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 24 * 60 * 60
+
+	// DefaultRouteKey is the route key of converted alerts, unless Options.SlackChannelID or Options.RouteKey is set.
+	DefaultRouteKey = "email"
+)
+
+// severityKeywordRegex matches the status keywords used in the subjects of monitoring emails, such as
+// '** PROBLEM Service Alert: web01/disk is CRITICAL **'.
+var severityKeywordRegex = regexp.MustCompile(`(?i)\b(problem|recovery|recovered|resolved|critical|warning|ok|alert|firing)\b`)
+
+// Message is a parsed email message.
+type Message struct {
+	From        *mail.Address
+	Subject     string
+	Date        time.Time
+	MessageID   string
+	Text        string
+	HTML        string
+	Attachments []*Attachment
+}
+
+// Attachment is an email attachment.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Size        int
+
+	// Content is the decoded attachment content, or nil if Size exceeds MaxAttachmentSize.
+	Content []byte
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over RouteKey.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Defaults to DefaultRouteKey.
+	RouteKey string
+
+	// Severity is the severity of alerts whose subject has no status keyword. Defaults to error.
+	Severity types.AlertSeverity
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse parses a raw RFC 822 email message, decoding MIME encoded headers, multipart bodies, and quoted-printable
+// and base64 transfer encodings. Messages larger than MaxMessageSize are rejected.
+func Parse(data []byte) (*Message, error) {
+	if len(data) > MaxMessageSize {
+		return nil, fmt.Errorf("email message is too large, expected size <=%d bytes", MaxMessageSize)
+	}
+
+	m, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email message: %w", err)
+	}
+
+	decoder := new(mime.WordDecoder)
+
+	msg := &Message{
+		MessageID: strings.Trim(m.Header.Get("Message-Id"), "<> "),
+	}
+
+	if msg.Subject, err = decoder.DecodeHeader(m.Header.Get("Subject")); err != nil {
+		msg.Subject = m.Header.Get("Subject")
+	}
+
+	if from := m.Header.Get("From"); from != "" {
+		if msg.From, err = mail.ParseAddress(from); err != nil {
+			msg.From = &mail.Address{Address: from}
+		}
+	}
+
+	if date, err := m.Header.Date(); err == nil {
+		msg.Date = date
+	}
+
+	if err := msg.readPart(m.Header.Get("Content-Type"), m.Header.Get("Content-Transfer-Encoding"), "", m.Body, 0); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// Body returns the plain text body with quoted replies and signatures stripped, or the HTML body converted to
+// Slack mrkdwn if there is no plain text body.
+func (m *Message) Body() string {
+	if strings.TrimSpace(m.Text) != "" {
+		return StripBody(m.Text)
+	}
+
+	return StripBody(HTMLToMrkdwn(m.HTML))
+}
+
+func (m *Message) readPart(contentType, transferEncoding, disposition string, r io.Reader, depth int) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{"charset": "utf-8"}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= MaxMultipartDepth {
+			return fmt.Errorf("email multipart nesting is too deep, expected depth <=%d", MaxMultipartDepth)
+		}
+
+		mr := multipart.NewReader(r, params["boundary"])
+
+		for {
+			part, err := mr.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			if err != nil {
+				return fmt.Errorf("failed to read email multipart body: %w", err)
+			}
+
+			if err := m.readPart(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part.Header.Get("Content-Disposition"), part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	body := decodeTransfer(transferEncoding, r)
+
+	dispositionType, dispositionParams, _ := mime.ParseMediaType(disposition)
+	filename := convert.FirstNonEmpty(dispositionParams["filename"], params["name"])
+
+	if dispositionType == "attachment" || filename != "" || (mediaType != "text/plain" && mediaType != "text/html") {
+		return m.readAttachment(filename, mediaType, body)
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read email body: %w", err)
+	}
+
+	text := decodeCharset(params["charset"], content)
+
+	switch {
+	case mediaType == "text/plain" && m.Text == "":
+		m.Text = text
+	case mediaType == "text/html" && m.HTML == "":
+		m.HTML = text
+	}
+
+	return nil
+}
+
+func (m *Message) readAttachment(filename, contentType string, r io.Reader) error {
+	content, err := io.ReadAll(io.LimitReader(r, MaxAttachmentSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read email attachment: %w", err)
+	}
+
+	attachment := &Attachment{
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        len(content),
+		Content:     content,
+	}
+
+	if len(content) > MaxAttachmentSize {
+		rest, err := io.Copy(io.Discard, r)
+		if err != nil {
+			return fmt.Errorf("failed to read email attachment: %w", err)
+		}
+
+		attachment.Size += int(rest)
+		attachment.Content = nil
+	}
+
+	m.Attachments = append(m.Attachments, attachment)
+
+	return nil
+}
+
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &newlineStripper{r: r})
+	default:
+		return r
+	}
+}
+
+// decodeCharset converts the content to UTF-8. ISO-8859-1 (and its superset Windows-1252, approximately) is converted,
+// and other charsets are assumed to be UTF-8 compatible, with invalid sequences replaced.
+func decodeCharset(charset string, content []byte) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252":
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+
+		return string(runes)
+	default:
+		if utf8.Valid(content) {
+			return string(content)
+		}
+
+		return strings.ToValidUTF8(string(content), "�")
+	}
+}
+
+// newlineStripper removes line breaks from base64 encoded content.
+type newlineStripper struct {
+	r io.Reader
+}
+
+func (s *newlineStripper) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	j := 0
+
+	for i := range n {
+		if p[i] != '\r' && p[i] != '\n' {
+			p[j] = p[i]
+			j++
+		}
+	}
+
+	return j, err
+}
+
+// Convert converts the message into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The header is the subject, the text is the stripped body, and the author is the sender name (or address).
+//   - Status keywords in the subject set the severity: 'recovery', 'recovered', 'resolved' and 'ok' map to resolved,
+//     'critical' to panic, and 'warning' to warning. Otherwise, Options.Severity is used.
+//   - The correlation ID is derived from the sender address and the normalized subject, without status keywords,
+//     so that problem and recovery emails for the same check map to the same issue.
+//   - The sender, message ID and attachment names become metadata.
+func Convert(msg *Message, opts Options) (*types.Alert, error) {
+	if msg == nil {
+		return nil, errors.New("email message is nil")
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	if opts.Severity == "" {
+		opts.Severity = types.AlertError
+	}
+
+	fingerprinter, err := types.NewFingerprinter()
+	if err != nil {
+		return nil, err
+	}
+
+	var fromName, fromAddress string
+
+	if msg.From != nil {
+		fromName, fromAddress = msg.From.Name, strings.ToLower(msg.From.Address)
+	}
+
+	subject := strings.Join(strings.Fields(msg.Subject), " ")
+	normalizedSubject := strings.Join(strings.Fields(severityKeywordRegex.ReplaceAllString(fingerprinter.Normalize(strings.ToLower(subject)), "")), " ")
+
+	a := types.NewAlert(severity(subject, opts.Severity))
+
+	a.CorrelationID = convert.Hash("email", fromAddress, normalizedSubject)
+	a.Type = "email"
+	a.Source = convert.Source("email")
+	a.Author = convert.FirstNonEmpty(fromName, fromAddress, "Email")
+	a.Header = ":status: " + convert.FirstNonEmpty(subject, "(no subject)")
+	a.Text = msg.Body()
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+
+	if !msg.Date.IsZero() {
+		a.Timestamp = msg.Date
+	}
+
+	if fromAddress != "" {
+		a.Metadata["from"] = fromAddress
+	}
+
+	if msg.MessageID != "" {
+		a.Metadata["messageId"] = msg.MessageID
+	}
+
+	if len(msg.Attachments) > 0 {
+		names := make([]string, 0, len(msg.Attachments))
+		for _, att := range msg.Attachments {
+			names = append(names, convert.FirstNonEmpty(att.Filename, att.ContentType))
+		}
+
+		a.Metadata["attachments"] = strings.Join(names, ",")
+	}
+
+	if opts.SlackChannelID != "" {
+		a.SlackChannelID = opts.SlackChannelID
+	} else {
+		a.RouteKey = convert.FirstNonEmpty(opts.RouteKey, DefaultRouteKey)
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("email message could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+func severity(subject string, defaultSeverity types.AlertSeverity) types.AlertSeverity {
+	sev := defaultSeverity
+
+	for _, keyword := range severityKeywordRegex.FindAllString(subject, -1) {
+		switch strings.ToLower(keyword) {
+		case "recovery", "recovered", "resolved", "ok":
+			return types.AlertResolved
+		case "critical":
+			sev = types.AlertPanic
+		case "warning":
+			if sev != types.AlertPanic {
+				sev = types.AlertWarning
+			}
+		}
+	}
+
+	return sev
+}
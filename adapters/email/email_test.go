@@ -0,0 +1,165 @@
+package email_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func multipartMessage(subject string, date time.Time, attachment []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(attachment)
+
+	var lines []string
+	for len(encoded) > 76 {
+		lines = append(lines, encoded[:76])
+		encoded = encoded[76:]
+	}
+
+	lines = append(lines, encoded)
+
+	return []byte(strings.ReplaceAll(fmt.Sprintf(`From: "Nagios" <Nagios@example.com>
+To: oncall@example.com
+Subject: %s
+Date: %s
+Message-ID: <1234@example.com>
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+Service: disk
+Host: web01 =E2=80=93 98%% used
+
+--=20
+Nagios
+--inner
+Content-Type: text/html; charset=utf-8
+
+<p>Service: <b>disk</b></p>
+--inner--
+--outer
+Content-Type: application/octet-stream
+Content-Disposition: attachment; filename="graph.png"
+Content-Transfer-Encoding: base64
+
+%s
+--outer--
+`, subject, date.Format(time.RFC1123Z), strings.Join(lines, "\n")), "\n", "\r\n"))
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	date := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	msg, err := email.Parse(multipartMessage("=?utf-8?q?PROBLEM_web01/disk_is_CRITICAL?=", date, []byte("png data")))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Nagios", msg.From.Name)
+	assert.Equal(t, "Nagios@example.com", msg.From.Address)
+	assert.Equal(t, "PROBLEM web01/disk is CRITICAL", msg.Subject)
+	assert.True(t, date.Equal(msg.Date))
+	assert.Equal(t, "1234@example.com", msg.MessageID)
+	assert.Equal(t, "Service: disk\r\nHost: web01 – 98% used\r\n\r\n-- \r\nNagios", msg.Text)
+	assert.Contains(t, msg.HTML, "<b>disk</b>")
+	assert.Equal(t, "Service: disk\nHost: web01 – 98% used", msg.Body())
+
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "graph.png", msg.Attachments[0].Filename)
+	assert.Equal(t, []byte("png data"), msg.Attachments[0].Content)
+}
+
+func TestParseAttachmentSizeGuard(t *testing.T) {
+	t.Parallel()
+
+	msg, err := email.Parse(multipartMessage("Large", time.Now(), make([]byte, email.MaxAttachmentSize+10)))
+	require.NoError(t, err)
+
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, email.MaxAttachmentSize+10, msg.Attachments[0].Size)
+	assert.Nil(t, msg.Attachments[0].Content)
+
+	_, err = email.Parse(make([]byte, email.MaxMessageSize+1))
+	require.Error(t, err)
+
+	_, err = email.Parse([]byte("not an email"))
+	require.Error(t, err)
+}
+
+func TestParseHTMLOnlyLatin1(t *testing.T) {
+	t.Parallel()
+
+	msg, err := email.Parse([]byte("From: alerts@example.com\r\nSubject: Backup failed\r\nContent-Type: text/html; charset=iso-8859-1\r\n\r\n<p>Caf\xe9 server</p><p>Details <a href=\"https://example.com\">here</a></p>"))
+	require.NoError(t, err)
+
+	assert.Empty(t, msg.Text)
+	assert.Equal(t, "Café server\n\nDetails <https://example.com|here>", msg.Body())
+	assert.Equal(t, "alerts@example.com", msg.From.Address)
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	date := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	problem, err := email.Parse(multipartMessage("** PROBLEM Service Alert: web01/disk is CRITICAL **", date, nil))
+	require.NoError(t, err)
+
+	alert, err := email.Convert(problem, email.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertPanic, alert.Severity)
+	assert.Equal(t, ":status: ** PROBLEM Service Alert: web01/disk is CRITICAL **", alert.Header)
+	assert.Equal(t, "Service: disk\nHost: web01 – 98% used", alert.Text)
+	assert.Equal(t, "Nagios", alert.Author)
+	assert.Equal(t, "email", alert.RouteKey)
+	assert.Equal(t, "nagios@example.com", alert.Metadata["from"])
+	assert.Equal(t, "graph.png", alert.Metadata["attachments"])
+	assert.True(t, date.Equal(alert.Timestamp))
+
+	recovery, err := email.Parse(multipartMessage("** RECOVERY Service Alert: web01/disk is OK **", date, nil))
+	require.NoError(t, err)
+
+	resolved, err := email.Convert(recovery, email.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertResolved, resolved.Severity)
+	assert.Equal(t, alert.CorrelationID, resolved.CorrelationID)
+	assert.Equal(t, "C12345678", resolved.SlackChannelID)
+	assert.Empty(t, resolved.RouteKey)
+}
+
+func TestConvertSeverityKeywords(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]types.AlertSeverity{
+		"Backup failed":                 types.AlertError,
+		"WARNING: disk at 80%":          types.AlertWarning,
+		"Warning and critical on db01":  types.AlertPanic,
+		"Job resolved":                  types.AlertResolved,
+		"Looking at the broken service": types.AlertError,
+	}
+
+	for subject, expected := range tests {
+		alert, err := email.Convert(&email.Message{Subject: subject}, email.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, alert.Severity, subject)
+	}
+
+	alert, err := email.Convert(&email.Message{}, email.Options{Severity: types.AlertInfo})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertInfo, alert.Severity)
+	assert.Equal(t, ":status: (no subject)", alert.Header)
+}
@@ -0,0 +1,213 @@
+package email
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// hrefRegex matches the href attribute of an HTML tag.
+	hrefRegex = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+
+	// whitespaceRegex matches runs of HTML whitespace, which render as a single space.
+	whitespaceRegex = regexp.MustCompile(`[ \t\r\n\f]+`)
+
+	// blankLinesRegex matches three or more consecutive line breaks, possibly with whitespace in between.
+	blankLinesRegex = regexp.MustCompile(`\n[ \t]*(\n[ \t]*){2,}`)
+
+	// replyHeaderRegex matches the line introducing a quoted reply, such as 'On Mon, 1 Jan 2024, Jane wrote:'.
+	replyHeaderRegex = regexp.MustCompile(`^On .+ wrote:$`)
+)
+
+// HTMLToMrkdwn converts an HTML email body to Slack mrkdwn. Bold, italic, strikethrough, code, preformatted text,
+// links, line breaks, paragraphs and list items are converted, script and style elements are removed,
+// and all other tags are stripped.
+func HTMLToMrkdwn(s string) string {
+	var (
+		out      strings.Builder
+		link     strings.Builder
+		href     string
+		inLink   bool
+		preDepth int
+	)
+
+	write := func(text string) {
+		if inLink {
+			link.WriteString(text)
+		} else {
+			out.WriteString(text)
+		}
+	}
+
+	for s != "" {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			i = len(s)
+		}
+
+		if i > 0 {
+			text := html.UnescapeString(s[:i])
+			if preDepth == 0 {
+				text = whitespaceRegex.ReplaceAllString(text, " ")
+			}
+
+			write(escapeMrkdwn(text))
+		}
+
+		s = s[i:]
+		if s == "" {
+			break
+		}
+
+		if strings.HasPrefix(s, "<!--") {
+			end := strings.Index(s, "-->")
+			if end < 0 {
+				break
+			}
+
+			s = s[end+3:]
+
+			continue
+		}
+
+		end := strings.IndexByte(s, '>')
+		if end < 0 || !isTagStart(s[1:]) {
+			// Not a tag, as in 'a < b'
+			write("&lt;")
+			s = s[1:]
+
+			continue
+		}
+
+		tag := s[1:end]
+		s = s[end+1:]
+
+		closing := strings.HasPrefix(tag, "/")
+		name := strings.ToLower(strings.TrimRight(strings.Fields(strings.TrimPrefix(tag, "/"))[0], "/"))
+
+		switch name {
+		case "script", "style", "head", "title":
+			if !closing {
+				if end := strings.Index(strings.ToLower(s), "</"+name); end >= 0 {
+					s = s[end:]
+				} else {
+					s = ""
+				}
+			}
+		case "br":
+			write("\n")
+		case "p", "div", "tr", "table", "ul", "ol", "blockquote", "hr":
+			write("\n")
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			if closing {
+				write("*\n")
+			} else {
+				write("\n*")
+			}
+		case "li":
+			if !closing {
+				write("\n• ")
+			}
+		case "td", "th":
+			if closing {
+				write(" ")
+			}
+		case "b", "strong":
+			write("*")
+		case "i", "em":
+			write("_")
+		case "s", "strike", "del":
+			write("~")
+		case "code", "tt":
+			if preDepth == 0 {
+				write("`")
+			}
+		case "pre":
+			if closing {
+				preDepth = max(preDepth-1, 0)
+			} else {
+				preDepth++
+			}
+
+			write("\n```\n")
+		case "a":
+			switch {
+			case !closing && !inLink:
+				if m := hrefRegex.FindStringSubmatch(tag); m != nil {
+					href = html.UnescapeString(m[1] + m[2] + m[3])
+					inLink = true
+					link.Reset()
+				}
+			case closing && inLink:
+				inLink = false
+				text := strings.TrimSpace(link.String())
+
+				switch {
+				case strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:"):
+					out.WriteString(text)
+				case text == "" || text == href:
+					out.WriteString("<" + href + ">")
+				default:
+					out.WriteString("<" + href + "|" + text + ">")
+				}
+			}
+		}
+	}
+
+	if inLink {
+		out.WriteString(link.String())
+	}
+
+	// Trim the whitespace left around block elements, except in preformatted text
+	inPre := false
+	lines := strings.Split(out.String(), "\n")
+
+	for i, line := range lines {
+		if line == "```" {
+			inPre = !inPre
+		}
+
+		if !inPre {
+			lines[i] = strings.TrimSpace(line)
+		}
+	}
+
+	return strings.TrimSpace(blankLinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// StripBody removes quoted replies, forwarded original messages and signatures from a plain text email body,
+// and collapses consecutive blank lines.
+func StripBody(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	var kept []string
+
+	for _, line := range strings.Split(s, "\n") {
+		trimmed := strings.TrimRight(line, " \t")
+
+		if trimmed == "--" || line == "-- " || strings.HasPrefix(trimmed, "-----Original Message-----") {
+			break
+		}
+
+		if strings.HasPrefix(trimmed, ">") || replyHeaderRegex.MatchString(strings.TrimSpace(trimmed)) {
+			continue
+		}
+
+		kept = append(kept, trimmed)
+	}
+
+	return strings.TrimSpace(blankLinesRegex.ReplaceAllString(strings.Join(kept, "\n"), "\n\n"))
+}
+
+// isTagStart returns true if s (following a '<') starts like an HTML start or end tag.
+func isTagStart(s string) bool {
+	s = strings.TrimPrefix(s, "/")
+
+	return s != "" && (s[0] >= 'a' && s[0] <= 'z' || s[0] >= 'A' && s[0] <= 'Z')
+}
+
+// escapeMrkdwn escapes the characters with special meaning in Slack mrkdwn text.
+func escapeMrkdwn(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,38 @@
+package email_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types/adapters/email"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToMrkdwn(t *testing.T) {
+	t.Parallel()
+
+	input := `<html><head><title>Alert</title><style>p { color: red; }</style></head><body>
+<h1>Disk   full</h1>
+<p>Host <b>db01</b> is at <i>98%</i> &amp; rising. 1 &lt; 2, and a < b.</p>
+<!-- generated -->
+<ul><li>Mount: <code>/var</code></li><li>See <a href="https://example.com/runbook?a=1&amp;b=2">the runbook</a></li></ul>
+<pre>df -h
+/var  98%</pre>
+<script>alert("x")</script><a href="https://example.com">https://example.com</a><br/>Bye</body></html>`
+
+	expected := "*Disk full*\n\nHost *db01* is at _98%_ &amp; rising. 1 &lt; 2, and a &lt; b.\n\n" +
+		"• Mount: `/var`\n• See <https://example.com/runbook?a=1&b=2|the runbook>\n\n```\ndf -h\n/var  98%\n```\n" +
+		"<https://example.com>\nBye"
+
+	assert.Equal(t, expected, email.HTMLToMrkdwn(input))
+	assert.Empty(t, email.HTMLToMrkdwn(""))
+	assert.Equal(t, "broken", email.HTMLToMrkdwn("<a href='#x'>broken</a>"))
+}
+
+func TestStripBody(t *testing.T) {
+	t.Parallel()
+
+	input := "Disk full on db01.\r\n\r\n\r\n\r\nPlease check.\r\n\r\nOn Mon, 1 Jan 2024, Jane wrote:\r\n> Earlier message\r\n>> Older\r\n\r\n-- \r\nMonitoring Team\r\nAcme Inc."
+
+	assert.Equal(t, "Disk full on db01.\n\nPlease check.", email.StripBody(input))
+	assert.Equal(t, "Hello", email.StripBody("Hello\n-----Original Message-----\nFrom: someone"))
+}
@@ -0,0 +1,264 @@
+// Package githubactions converts GitHub Actions workflow_run webhook payloads into Slack Manager alerts,
+// so that CI failure notifications flow through the same routing and escalation as other alerts.
+//
+// The repository, workflow and branch form the correlation ID. Failed runs open (or update) an issue, and the next
+// successful run of the same workflow on the same branch resolves it:
+//
+//	payload, err := githubactions.Parse(body)
+//	alert, err := githubactions.Convert(payload, githubactions.Options{Branches: []string{"main"}})
+//	if errors.Is(err, githubactions.ErrIgnoredRun) {
+//		// Acknowledge the webhook without sending an alert
+//	}
+package githubactions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// ActionCompleted is the action of workflow_run webhooks sent when a run completes.
+	ActionCompleted = "completed"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 7 * 24 * 60 * 60
+
+	// shortSHALength is the length of abbreviated commit SHAs.
+	shortSHALength = 7
+)
+
+// ErrIgnoredRun is returned by Convert for runs that do not map to an alert: runs that are not completed,
+// runs with a conclusion such as cancelled or skipped, and runs on branches excluded by Options.Branches.
+// Callers should acknowledge such webhooks without sending an alert.
+var ErrIgnoredRun = errors.New("github actions workflow run is ignored")
+
+// Payload is a GitHub workflow_run webhook payload.
+type Payload struct {
+	Action      string       `json:"action"`
+	WorkflowRun *WorkflowRun `json:"workflow_run"`
+	Workflow    *Workflow    `json:"workflow"`
+	Repository  *Repository  `json:"repository"`
+}
+
+// WorkflowRun is a GitHub Actions workflow run.
+type WorkflowRun struct {
+	ID              int64     `json:"id"`
+	Name            string    `json:"name"`
+	DisplayTitle    string    `json:"display_title"`
+	HeadBranch      string    `json:"head_branch"`
+	HeadSHA         string    `json:"head_sha"`
+	RunNumber       int       `json:"run_number"`
+	RunAttempt      int       `json:"run_attempt"`
+	Event           string    `json:"event"`
+	Status          string    `json:"status"`
+	Conclusion      string    `json:"conclusion"`
+	WorkflowID      int64     `json:"workflow_id"`
+	HTMLURL         string    `json:"html_url"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	Actor           *User     `json:"actor"`
+	TriggeringActor *User     `json:"triggering_actor"`
+}
+
+// Workflow is a GitHub Actions workflow.
+type Workflow struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Repository is a GitHub repository.
+type Repository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// User is a GitHub user.
+type User struct {
+	Login string `json:"login"`
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over RouteKey.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Defaults to the repository full name, such as 'acme/api'.
+	RouteKey string
+
+	// Branches limits the conversion to runs on the specified branches, such as the default branch.
+	// Runs on other branches return ErrIgnoredRun. If empty, runs on all branches are converted.
+	Branches []string
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse decodes a GitHub workflow_run webhook payload.
+func Parse(data []byte) (*Payload, error) {
+	var payload Payload
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode github actions payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// Convert converts the payload into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The correlation ID is formed from the repository, the workflow and the branch.
+//   - Conclusions failure, timed_out and startup_failure map to severity error, and success to resolved.
+//     The conversion is stateless, so every successful run maps to a resolved alert, which resolves the issue
+//     opened by an earlier failure (if any).
+//   - The header is the workflow name and the repository, and the text describes the run.
+//   - The repository, branch, commit and actor become alert fields, and the link is the run URL.
+//
+// ErrIgnoredRun is returned for runs that are not completed, for other conclusions (such as cancelled and skipped),
+// and for branches excluded by Options.Branches.
+func Convert(payload *Payload, opts Options) (*types.Alert, error) {
+	if payload == nil || payload.WorkflowRun == nil || payload.Repository == nil {
+		return nil, errors.New("github actions payload is empty")
+	}
+
+	run := payload.WorkflowRun
+
+	if payload.Action != ActionCompleted {
+		return nil, fmt.Errorf("%w: action is '%s'", ErrIgnoredRun, payload.Action)
+	}
+
+	if len(opts.Branches) > 0 && !slices.Contains(opts.Branches, run.HeadBranch) {
+		return nil, fmt.Errorf("%w: branch '%s' is excluded", ErrIgnoredRun, run.HeadBranch)
+	}
+
+	var sev types.AlertSeverity
+
+	switch run.Conclusion {
+	case "failure", "timed_out", "startup_failure":
+		sev = types.AlertError
+	case "success":
+		sev = types.AlertResolved
+	default:
+		return nil, fmt.Errorf("%w: conclusion is '%s'", ErrIgnoredRun, run.Conclusion)
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	workflowName := run.Name
+	workflowKey := strconv.FormatInt(run.WorkflowID, 10)
+
+	if payload.Workflow != nil {
+		workflowName = convert.FirstNonEmpty(workflowName, payload.Workflow.Name)
+		workflowKey = convert.FirstNonEmpty(payload.Workflow.Path, workflowKey)
+	}
+
+	repo := payload.Repository.FullName
+	actor := login(run.TriggeringActor, run.Actor)
+
+	a := types.NewAlert(sev)
+
+	a.CorrelationID = CorrelationID(repo, workflowKey, run.HeadBranch)
+	a.Type = "github-actions"
+	a.Source = convert.Source("github-actions")
+	a.Author = "GitHub Actions"
+	a.Header = ":status: " + convert.FirstNonEmpty(workflowName, "Workflow") + " in " + repo
+	a.Text = runText(run)
+	a.Link = run.HTMLURL
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+	a.Fields = convert.Fields(
+		"Repository", repo,
+		"Branch", run.HeadBranch,
+		"Commit", shortSHA(run.HeadSHA),
+		"Triggered by", actor,
+	)
+
+	if !run.UpdatedAt.IsZero() {
+		a.Timestamp = run.UpdatedAt
+	}
+
+	a.Metadata["repository"] = repo
+	a.Metadata["branch"] = run.HeadBranch
+	a.Metadata["workflow"] = workflowKey
+	a.Metadata["runId"] = strconv.FormatInt(run.ID, 10)
+
+	switch {
+	case opts.SlackChannelID != "":
+		a.SlackChannelID = opts.SlackChannelID
+	case opts.RouteKey != "":
+		a.RouteKey = opts.RouteKey
+	default:
+		a.RouteKey = repo
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("github actions payload could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+// CorrelationID returns the correlation ID of alerts for runs of the specified workflow (file path) on the specified branch.
+func CorrelationID(repo, workflow, branch string) string {
+	id := "github-actions/" + repo + "/" + workflow + "@" + branch
+
+	if len(id) > types.MaxCorrelationIDLength {
+		return "github-actions/" + convert.Hash(repo, workflow, branch)
+	}
+
+	return id
+}
+
+func runText(run *WorkflowRun) string {
+	outcome := "failed"
+
+	switch run.Conclusion {
+	case "success":
+		outcome = "succeeded"
+	case "timed_out":
+		outcome = "timed out"
+	case "startup_failure":
+		outcome = "failed to start"
+	}
+
+	text := fmt.Sprintf("Run #%d %s", run.RunNumber, outcome)
+
+	if run.RunAttempt > 1 {
+		text += fmt.Sprintf(" (attempt %d)", run.RunAttempt)
+	}
+
+	if run.DisplayTitle != "" {
+		text += ": " + run.DisplayTitle
+	}
+
+	return text
+}
+
+func login(users ...*User) string {
+	for _, u := range users {
+		if u != nil && u.Login != "" {
+			return u.Login
+		}
+	}
+
+	return ""
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+
+	return sha
+}
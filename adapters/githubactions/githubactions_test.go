@@ -0,0 +1,98 @@
+package githubactions_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/githubactions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const payloadJSON = `{
+  "action": "%s",
+  "workflow_run": {
+    "id": 30433642,
+    "name": "CI",
+    "display_title": "Fix flaky checkout test",
+    "head_branch": "%s",
+    "head_sha": "acb5820ced9479c074f688cc328bf03f341a511d",
+    "run_number": 562,
+    "run_attempt": 2,
+    "event": "push",
+    "status": "completed",
+    "conclusion": "%s",
+    "workflow_id": 159038,
+    "html_url": "https://github.com/acme/api/actions/runs/30433642",
+    "updated_at": "%s",
+    "actor": {"login": "octocat"},
+    "triggering_actor": {"login": "hubot"}
+  },
+  "workflow": {"id": 159038, "name": "CI", "path": ".github/workflows/ci.yml"},
+  "repository": {"full_name": "acme/api", "html_url": "https://github.com/acme/api"}
+}`
+
+func parse(t *testing.T, action, branch, conclusion string) *githubactions.Payload {
+	t.Helper()
+
+	updated := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+
+	payload, err := githubactions.Parse([]byte(fmt.Sprintf(payloadJSON, action, branch, conclusion, updated.Format(time.RFC3339))))
+	require.NoError(t, err)
+
+	return payload
+}
+
+func TestConvertFailure(t *testing.T) {
+	t.Parallel()
+
+	alert, err := githubactions.Convert(parse(t, "completed", "main", "failure"), githubactions.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertError, alert.Severity)
+	assert.Equal(t, "github-actions/acme/api/.github/workflows/ci.yml@main", alert.CorrelationID)
+	assert.Equal(t, "acme/api", alert.RouteKey)
+	assert.Equal(t, ":status: CI in acme/api", alert.Header)
+	assert.Equal(t, "Run #562 failed (attempt 2): Fix flaky checkout test", alert.Text)
+	assert.Equal(t, "https://github.com/acme/api/actions/runs/30433642", alert.Link)
+	assert.Equal(t, []*types.Field{
+		{Title: "Repository", Value: "acme/api"},
+		{Title: "Branch", Value: "main"},
+		{Title: "Commit", Value: "acb5820"},
+		{Title: "Triggered by", Value: "hubot"},
+	}, alert.Fields)
+}
+
+func TestConvertSuccessResolves(t *testing.T) {
+	t.Parallel()
+
+	failed, err := githubactions.Convert(parse(t, "completed", "main", "timed_out"), githubactions.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+
+	succeeded, err := githubactions.Convert(parse(t, "completed", "main", "success"), githubactions.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertResolved, succeeded.Severity)
+	assert.Equal(t, failed.CorrelationID, succeeded.CorrelationID)
+	assert.Equal(t, "C12345678", succeeded.SlackChannelID)
+	assert.Empty(t, succeeded.RouteKey)
+}
+
+func TestConvertIgnored(t *testing.T) {
+	t.Parallel()
+
+	_, err := githubactions.Convert(parse(t, "in_progress", "main", ""), githubactions.Options{})
+	require.ErrorIs(t, err, githubactions.ErrIgnoredRun)
+
+	_, err = githubactions.Convert(parse(t, "completed", "main", "cancelled"), githubactions.Options{})
+	require.ErrorIs(t, err, githubactions.ErrIgnoredRun)
+
+	_, err = githubactions.Convert(parse(t, "completed", "feature/x", "failure"), githubactions.Options{Branches: []string{"main"}})
+	require.ErrorIs(t, err, githubactions.ErrIgnoredRun)
+
+	_, err = githubactions.Convert(&githubactions.Payload{Action: "completed"}, githubactions.Options{})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, githubactions.ErrIgnoredRun)
+}
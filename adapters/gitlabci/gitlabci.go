@@ -0,0 +1,275 @@
+// Package gitlabci converts GitLab pipeline webhook payloads into Slack Manager alerts,
+// so that CI failure notifications flow through the same routing and escalation as other alerts.
+//
+// The project and ref form the correlation ID. Failed pipelines open (or update) an issue, and the next
+// successful pipeline for the same ref resolves it:
+//
+//	payload, err := gitlabci.Parse(body)
+//	alert, err := gitlabci.Convert(payload, gitlabci.Options{Refs: []string{"main"}})
+//	if errors.Is(err, gitlabci.ErrIgnoredPipeline) {
+//		// Acknowledge the webhook without sending an alert
+//	}
+package gitlabci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// ObjectKindPipeline is the object kind of pipeline webhooks.
+	ObjectKindPipeline = "pipeline"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 7 * 24 * 60 * 60
+
+	// shortSHALength is the length of abbreviated commit SHAs.
+	shortSHALength = 8
+
+	// timeLayout is the layout of timestamps in GitLab webhooks.
+	timeLayout = "2006-01-02 15:04:05 MST"
+)
+
+// ErrIgnoredPipeline is returned by Convert for pipelines that do not map to an alert: pipelines that are not finished,
+// canceled or skipped pipelines, and pipelines for refs excluded by Options.Refs.
+// Callers should acknowledge such webhooks without sending an alert.
+var ErrIgnoredPipeline = errors.New("gitlab ci pipeline is ignored")
+
+// Payload is a GitLab pipeline webhook payload.
+type Payload struct {
+	ObjectKind       string            `json:"object_kind"`
+	ObjectAttributes *ObjectAttributes `json:"object_attributes"`
+	User             *User             `json:"user"`
+	Project          *Project          `json:"project"`
+	Commit           *Commit           `json:"commit"`
+	Builds           []*Build          `json:"builds"`
+}
+
+// ObjectAttributes holds the pipeline attributes.
+type ObjectAttributes struct {
+	ID         int64  `json:"id"`
+	IID        int64  `json:"iid"`
+	Name       string `json:"name"`
+	Ref        string `json:"ref"`
+	Tag        bool   `json:"tag"`
+	SHA        string `json:"sha"`
+	Source     string `json:"source"`
+	Status     string `json:"status"`
+	FinishedAt string `json:"finished_at"`
+	URL        string `json:"url"`
+}
+
+// User is a GitLab user.
+type User struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+// Project is a GitLab project.
+type Project struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+}
+
+// Commit is the pipeline commit.
+type Commit struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// Build is a pipeline job.
+type Build struct {
+	ID     int64  `json:"id"`
+	Stage  string `json:"stage"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over RouteKey.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Defaults to the project path, such as 'acme/api'.
+	RouteKey string
+
+	// Refs limits the conversion to pipelines for the specified branches or tags, such as the default branch.
+	// Pipelines for other refs return ErrIgnoredPipeline. If empty, pipelines for all refs are converted.
+	Refs []string
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse decodes a GitLab pipeline webhook payload.
+func Parse(data []byte) (*Payload, error) {
+	var payload Payload
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode gitlab ci payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// Convert converts the payload into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The correlation ID is formed from the project path and the ref.
+//   - Status failed maps to severity error, and success to resolved. The conversion is stateless, so every successful
+//     pipeline maps to a resolved alert, which resolves the issue opened by an earlier failure (if any).
+//   - The header is the pipeline name (or 'Pipeline') and the project, and the text describes the pipeline and the failed jobs.
+//   - The project, ref, commit and user become alert fields, and the link is the pipeline URL.
+//
+// ErrIgnoredPipeline is returned for other statuses (such as running and canceled), and for refs excluded by Options.Refs.
+func Convert(payload *Payload, opts Options) (*types.Alert, error) {
+	if payload == nil || payload.ObjectAttributes == nil || payload.Project == nil {
+		return nil, errors.New("gitlab ci payload is empty")
+	}
+
+	if payload.ObjectKind != ObjectKindPipeline {
+		return nil, fmt.Errorf("%w: object kind is '%s'", ErrIgnoredPipeline, payload.ObjectKind)
+	}
+
+	pipeline := payload.ObjectAttributes
+
+	if len(opts.Refs) > 0 && !slices.Contains(opts.Refs, pipeline.Ref) {
+		return nil, fmt.Errorf("%w: ref '%s' is excluded", ErrIgnoredPipeline, pipeline.Ref)
+	}
+
+	var sev types.AlertSeverity
+
+	switch pipeline.Status {
+	case "failed":
+		sev = types.AlertError
+	case "success":
+		sev = types.AlertResolved
+	default:
+		return nil, fmt.Errorf("%w: status is '%s'", ErrIgnoredPipeline, pipeline.Status)
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	project := convert.FirstNonEmpty(payload.Project.PathWithNamespace, payload.Project.Name)
+
+	a := types.NewAlert(sev)
+
+	a.CorrelationID = CorrelationID(project, pipeline.Ref)
+	a.Type = "gitlab-ci"
+	a.Source = convert.Source("gitlab-ci")
+	a.Author = "GitLab CI"
+	a.Header = ":status: " + convert.FirstNonEmpty(pipeline.Name, "Pipeline") + " in " + project
+	a.Text = pipelineText(pipeline, payload.Builds)
+	a.Link = pipelineURL(payload)
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+	a.Fields = convert.Fields(
+		"Project", project,
+		"Ref", pipeline.Ref,
+		"Commit", shortSHA(pipeline.SHA),
+		"Triggered by", username(payload.User),
+	)
+
+	if finished, err := time.Parse(timeLayout, pipeline.FinishedAt); err == nil {
+		a.Timestamp = finished
+	} else if finished, err := time.Parse(time.RFC3339, pipeline.FinishedAt); err == nil {
+		a.Timestamp = finished
+	}
+
+	a.Metadata["project"] = project
+	a.Metadata["ref"] = pipeline.Ref
+	a.Metadata["pipelineId"] = strconv.FormatInt(pipeline.ID, 10)
+
+	switch {
+	case opts.SlackChannelID != "":
+		a.SlackChannelID = opts.SlackChannelID
+	case opts.RouteKey != "":
+		a.RouteKey = opts.RouteKey
+	default:
+		a.RouteKey = project
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("gitlab ci payload could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+// CorrelationID returns the correlation ID of alerts for pipelines of the specified project path and ref.
+func CorrelationID(project, ref string) string {
+	id := "gitlab-ci/" + project + "@" + ref
+
+	if len(id) > types.MaxCorrelationIDLength {
+		return "gitlab-ci/" + convert.Hash(project, ref)
+	}
+
+	return id
+}
+
+func pipelineText(pipeline *ObjectAttributes, builds []*Build) string {
+	if pipeline.Status == "success" {
+		return fmt.Sprintf("Pipeline #%d succeeded", pipeline.ID)
+	}
+
+	text := fmt.Sprintf("Pipeline #%d failed", pipeline.ID)
+
+	var failed []string
+
+	for _, b := range builds {
+		if b != nil && b.Status == "failed" {
+			failed = append(failed, b.Stage+": "+b.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		slices.Sort(failed)
+		text += "\nFailed jobs:\n• " + strings.Join(failed, "\n• ")
+	}
+
+	return text
+}
+
+// pipelineURL returns the pipeline URL, derived from the project URL for GitLab versions that do not send it.
+func pipelineURL(payload *Payload) string {
+	if payload.ObjectAttributes.URL != "" {
+		return payload.ObjectAttributes.URL
+	}
+
+	if payload.Project.WebURL != "" {
+		return payload.Project.WebURL + "/-/pipelines/" + strconv.FormatInt(payload.ObjectAttributes.ID, 10)
+	}
+
+	return ""
+}
+
+func username(u *User) string {
+	if u == nil {
+		return ""
+	}
+
+	return convert.FirstNonEmpty(u.Username, u.Name)
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+
+	return sha
+}
@@ -0,0 +1,103 @@
+package gitlabci_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/gitlabci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const payloadJSON = `{
+  "object_kind": "pipeline",
+  "object_attributes": {
+    "id": 31,
+    "iid": 3,
+    "ref": "%s",
+    "tag": false,
+    "sha": "bcbb5ec396a2c0f828686f14fac9b80b780504f2",
+    "source": "push",
+    "status": "%s",
+    "finished_at": "%s",
+    "url": "https://gitlab.example.com/acme/api/-/pipelines/31"
+  },
+  "user": {"name": "Administrator", "username": "root"},
+  "project": {"id": 1, "name": "api", "path_with_namespace": "acme/api", "web_url": "https://gitlab.example.com/acme/api"},
+  "commit": {"id": "bcbb5ec396a2c0f828686f14fac9b80b780504f2", "title": "Fix flaky test"},
+  "builds": [
+    {"id": 380, "stage": "deploy", "name": "production", "status": "skipped"},
+    {"id": 377, "stage": "test", "name": "test-image", "status": "failed"},
+    {"id": 376, "stage": "build", "name": "build-image", "status": "success"},
+    {"id": 378, "stage": "test", "name": "lint", "status": "failed"}
+  ]
+}`
+
+func parse(t *testing.T, ref, status string) *gitlabci.Payload {
+	t.Helper()
+
+	finished := time.Now().UTC().Add(-time.Minute).Format("2006-01-02 15:04:05 MST")
+
+	payload, err := gitlabci.Parse([]byte(fmt.Sprintf(payloadJSON, ref, status, finished)))
+	require.NoError(t, err)
+
+	return payload
+}
+
+func TestConvertFailed(t *testing.T) {
+	t.Parallel()
+
+	alert, err := gitlabci.Convert(parse(t, "main", "failed"), gitlabci.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertError, alert.Severity)
+	assert.Equal(t, "gitlab-ci/acme/api@main", alert.CorrelationID)
+	assert.Equal(t, "acme/api", alert.RouteKey)
+	assert.Equal(t, ":status: Pipeline in acme/api", alert.Header)
+	assert.Equal(t, "Pipeline #31 failed\nFailed jobs:\n• test: lint\n• test: test-image", alert.Text)
+	assert.Equal(t, "https://gitlab.example.com/acme/api/-/pipelines/31", alert.Link)
+	assert.WithinDuration(t, time.Now().Add(-time.Minute), alert.Timestamp, 2*time.Second)
+	assert.Equal(t, []*types.Field{
+		{Title: "Project", Value: "acme/api"},
+		{Title: "Ref", Value: "main"},
+		{Title: "Commit", Value: "bcbb5ec3"},
+		{Title: "Triggered by", Value: "root"},
+	}, alert.Fields)
+}
+
+func TestConvertSuccessResolves(t *testing.T) {
+	t.Parallel()
+
+	payload := parse(t, "main", "success")
+	payload.ObjectAttributes.URL = ""
+
+	alert, err := gitlabci.Convert(payload, gitlabci.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertResolved, alert.Severity)
+	assert.Equal(t, gitlabci.CorrelationID("acme/api", "main"), alert.CorrelationID)
+	assert.Equal(t, "Pipeline #31 succeeded", alert.Text)
+	assert.Equal(t, "https://gitlab.example.com/acme/api/-/pipelines/31", alert.Link)
+	assert.Empty(t, alert.RouteKey)
+}
+
+func TestConvertIgnored(t *testing.T) {
+	t.Parallel()
+
+	_, err := gitlabci.Convert(parse(t, "main", "running"), gitlabci.Options{})
+	require.ErrorIs(t, err, gitlabci.ErrIgnoredPipeline)
+
+	_, err = gitlabci.Convert(parse(t, "feature", "failed"), gitlabci.Options{Refs: []string{"main"}})
+	require.ErrorIs(t, err, gitlabci.ErrIgnoredPipeline)
+
+	payload := parse(t, "main", "failed")
+	payload.ObjectKind = "build"
+
+	_, err = gitlabci.Convert(payload, gitlabci.Options{})
+	require.ErrorIs(t, err, gitlabci.ErrIgnoredPipeline)
+
+	_, err = gitlabci.Convert(&gitlabci.Payload{ObjectKind: "pipeline"}, gitlabci.Options{})
+	require.Error(t, err)
+}
@@ -0,0 +1,236 @@
+// Package grafana converts Grafana unified alerting webhook payloads into Slack Manager alerts.
+//
+// Each alert in the payload becomes one types.Alert, correlated by the Grafana fingerprint, so that 'ok'
+// notifications resolve the same issue as the 'alerting' ones:
+//
+//	payload, err := grafana.Parse(body)
+//	alerts, err := grafana.Convert(payload, grafana.Options{SlackChannelID: "C12345678"})
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// StatusFiring is the status of a firing alert.
+	StatusFiring = "firing"
+
+	// StatusResolved is the status of a resolved alert.
+	StatusResolved = "resolved"
+
+	// StateAlerting is the payload state when at least one alert is firing.
+	StateAlerting = "alerting"
+
+	// StateOK is the payload state when all alerts are resolved.
+	StateOK = "ok"
+
+	// DefaultSeverityLabel is the label holding the alert severity, unless Options.SeverityLabel is set.
+	DefaultSeverityLabel = "severity"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 12 * 60 * 60
+
+	// PanelURLMetadataKey is the metadata key holding the Grafana panel URL.
+	PanelURLMetadataKey = "panelURL"
+
+	// ImageURLMetadataKey is the metadata key holding the URL of the panel screenshot, if any.
+	ImageURLMetadataKey = "imageURL"
+
+	// SilenceURLMetadataKey is the metadata key holding the URL for silencing the alert in Grafana.
+	SilenceURLMetadataKey = "silenceURL"
+)
+
+// Payload is the Grafana unified alerting webhook payload.
+type Payload struct {
+	Receiver          string            `json:"receiver"`
+	Status            string            `json:"status"`
+	State             string            `json:"state"`
+	OrgID             int64             `json:"orgId"`
+	Alerts            []*Alert          `json:"alerts"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Title             string            `json:"title"`
+	Message           string            `json:"message"`
+}
+
+// Alert is a single alert in a Grafana webhook payload.
+type Alert struct {
+	Status       string             `json:"status"`
+	Labels       map[string]string  `json:"labels"`
+	Annotations  map[string]string  `json:"annotations"`
+	StartsAt     time.Time          `json:"startsAt"`
+	EndsAt       time.Time          `json:"endsAt"`
+	GeneratorURL string             `json:"generatorURL"`
+	Fingerprint  string             `json:"fingerprint"`
+	SilenceURL   string             `json:"silenceURL"`
+	DashboardURL string             `json:"dashboardURL"`
+	PanelURL     string             `json:"panelURL"`
+	ImageURL     string             `json:"imageURL"`
+	Values       map[string]float64 `json:"values"`
+	ValueString  string             `json:"valueString"`
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of all converted alerts. Takes precedence over route keys.
+	SlackChannelID string
+
+	// RouteKeyLabel is the label holding the route key of each alert. If empty, or if the label is missing,
+	// the Grafana receiver (contact point) name is used as route key.
+	RouteKeyLabel string
+
+	// SeverityLabel is the label holding the alert severity. Defaults to DefaultSeverityLabel.
+	SeverityLabel string
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alerts. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse decodes a Grafana webhook payload.
+func Parse(data []byte) (*Payload, error) {
+	var payload Payload
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode grafana payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// Convert converts each alert in the payload into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The correlation ID is the Grafana fingerprint (or a hash of the labels, if the fingerprint is missing).
+//   - Resolved alerts (state 'ok') get severity 'resolved'. Firing alerts (state 'alerting') get the severity from the severity
+//     label, where names such as 'critical' map to panic, 'warning' to warning, 'info' to info, and anything else to error.
+//   - The header is the 'summary' annotation, or the 'alertname' label. The text is the 'description' annotation.
+//   - The link is the dashboard URL, or the panel URL or generator URL if there is no dashboard.
+//   - The evaluation values become alert fields, sorted by name.
+//   - All labels are copied to the metadata, together with the panel, image and silence URLs.
+func Convert(payload *Payload, opts Options) ([]*types.Alert, error) {
+	if payload == nil {
+		return nil, errors.New("grafana payload is nil")
+	}
+
+	if opts.SeverityLabel == "" {
+		opts.SeverityLabel = DefaultSeverityLabel
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	alerts := make([]*types.Alert, 0, len(payload.Alerts))
+
+	for i, src := range payload.Alerts {
+		if src == nil {
+			continue
+		}
+
+		a := convertAlert(payload, src, opts)
+		a.Clean()
+
+		if err := a.Validate(); err != nil {
+			return nil, fmt.Errorf("alerts[%d] could not be converted: %w", i, err)
+		}
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+func convertAlert(payload *Payload, src *Alert, opts Options) *types.Alert {
+	labels := convert.MergeMaps(payload.CommonLabels, src.Labels)
+	annotations := convert.MergeMaps(payload.CommonAnnotations, src.Annotations)
+
+	severity := convert.Severity(labels[opts.SeverityLabel])
+	if strings.EqualFold(src.Status, StatusResolved) {
+		severity = types.AlertResolved
+	}
+
+	a := types.NewAlert(severity)
+
+	a.CorrelationID = src.Fingerprint
+	if a.CorrelationID == "" {
+		a.CorrelationID = convert.LabelsHash(labels)
+	}
+
+	a.Timestamp = src.StartsAt
+	if severity == types.AlertResolved && !src.EndsAt.IsZero() {
+		a.Timestamp = src.EndsAt
+	}
+
+	if opts.SlackChannelID != "" {
+		a.SlackChannelID = opts.SlackChannelID
+	} else if key := labels[opts.RouteKeyLabel]; opts.RouteKeyLabel != "" && key != "" {
+		a.RouteKey = key
+	} else {
+		a.RouteKey = payload.Receiver
+	}
+
+	a.Type = "grafana"
+	a.Source = convert.Source("grafana")
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+	a.Header = ":status: " + convert.FirstNonEmpty(annotations["summary"], labels["alertname"], "Grafana alert")
+	a.Text = annotations["description"]
+	a.Link = convert.FirstNonEmpty(src.DashboardURL, src.PanelURL, src.GeneratorURL)
+	a.Author = "Grafana"
+	a.Fields = valueFields(src.Values)
+
+	for k, v := range labels {
+		a.Metadata[k] = v
+	}
+
+	setIfNotEmpty(a.Metadata, PanelURLMetadataKey, src.PanelURL)
+	setIfNotEmpty(a.Metadata, ImageURLMetadataKey, src.ImageURL)
+	setIfNotEmpty(a.Metadata, SilenceURLMetadataKey, src.SilenceURL)
+
+	return a
+}
+
+// valueFields returns one field per evaluation value, sorted by name, up to types.MaxFieldCount fields.
+func valueFields(values map[string]float64) []*types.Field {
+	if len(values) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fields := make([]*types.Field, 0, min(len(names), types.MaxFieldCount))
+
+	for _, name := range names[:min(len(names), types.MaxFieldCount)] {
+		fields = append(fields, &types.Field{
+			Title: name,
+			Value: strconv.FormatFloat(values[name], 'g', -1, 64),
+		})
+	}
+
+	return fields
+}
+
+func setIfNotEmpty(m map[string]any, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
@@ -0,0 +1,107 @@
+package grafana_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/grafana"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const payloadJSON = `{
+  "receiver": "db-contact-point",
+  "status": "firing",
+  "orgId": 1,
+  "alerts": [
+    {
+      "status": "firing",
+      "labels": {"alertname": "HighCPU", "grafana_folder": "DB", "severity": "warning", "team": "db"},
+      "annotations": {"summary": "CPU above 90%%", "description": "CPU usage on db-01 is high"},
+      "startsAt": "%s",
+      "endsAt": "0001-01-01T00:00:00Z",
+      "generatorURL": "https://grafana.example.com/alerting/grafana/abc/view",
+      "fingerprint": "57c6d9296de2ad39",
+      "silenceURL": "https://grafana.example.com/alerting/silence/new?matcher=alertname%%3DHighCPU",
+      "dashboardURL": "https://grafana.example.com/d/dash1",
+      "panelURL": "https://grafana.example.com/d/dash1?viewPanel=2",
+      "imageURL": "https://grafana.example.com/public/img/abc.png",
+      "values": {"B": 93.5, "A": 0.935},
+      "valueString": "[ var='A' value=0.935 ], [ var='B' value=93.5 ]"
+    },
+    {
+      "status": "resolved",
+      "labels": {"alertname": "HighMemory", "team": "db"},
+      "annotations": {},
+      "startsAt": "%s",
+      "endsAt": "%s",
+      "generatorURL": "https://grafana.example.com/alerting/grafana/def/view",
+      "fingerprint": "",
+      "values": null
+    }
+  ],
+  "groupLabels": {"alertname": "HighCPU"},
+  "commonLabels": {"team": "db"},
+  "commonAnnotations": {},
+  "externalURL": "https://grafana.example.com/",
+  "version": "1",
+  "groupKey": "{}:{alertname=\"HighCPU\"}",
+  "truncatedAlerts": 0,
+  "title": "[FIRING:1] HighCPU",
+  "state": "alerting",
+  "message": "**Firing**"
+}`
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	startsAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	endsAt := startsAt.Add(10 * time.Minute)
+	ts := func(t time.Time) string { return t.Format(time.RFC3339) }
+
+	payload, err := grafana.Parse([]byte(fmt.Sprintf(payloadJSON, ts(startsAt), ts(startsAt), ts(endsAt))))
+	require.NoError(t, err)
+	assert.Equal(t, grafana.StateAlerting, payload.State)
+
+	alerts, err := grafana.Convert(payload, grafana.Options{RouteKeyLabel: "team"})
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+
+	firing := alerts[0]
+	assert.Equal(t, types.AlertWarning, firing.Severity)
+	assert.Equal(t, "57c6d9296de2ad39", firing.CorrelationID)
+	assert.Equal(t, "db", firing.RouteKey)
+	assert.Equal(t, ":status: CPU above 90%", firing.Header)
+	assert.Equal(t, "CPU usage on db-01 is high", firing.Text)
+	assert.Equal(t, "https://grafana.example.com/d/dash1", firing.Link)
+	assert.Equal(t, startsAt, firing.Timestamp)
+	assert.Equal(t, []*types.Field{{Title: "A", Value: "0.935"}, {Title: "B", Value: "93.5"}}, firing.Fields)
+	assert.Equal(t, "https://grafana.example.com/d/dash1?viewPanel=2", firing.Metadata[grafana.PanelURLMetadataKey])
+	assert.Equal(t, "https://grafana.example.com/public/img/abc.png", firing.Metadata[grafana.ImageURLMetadataKey])
+	assert.Equal(t, "DB", firing.Metadata["grafana_folder"])
+
+	resolved := alerts[1]
+	assert.Equal(t, types.AlertResolved, resolved.Severity)
+	assert.Len(t, resolved.CorrelationID, 64)
+	assert.Equal(t, ":status: HighMemory", resolved.Header)
+	assert.Equal(t, "https://grafana.example.com/alerting/grafana/def/view", resolved.Link)
+	assert.Equal(t, endsAt, resolved.Timestamp)
+	assert.Empty(t, resolved.Fields)
+}
+
+func TestConvertErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := grafana.Convert(nil, grafana.Options{})
+	require.EqualError(t, err, "grafana payload is nil")
+
+	_, err = grafana.Parse([]byte("["))
+	require.ErrorContains(t, err, "failed to decode grafana payload")
+
+	payload := &grafana.Payload{Receiver: "x", Alerts: []*grafana.Alert{{Status: "firing", Fingerprint: "a", DashboardURL: "not a url"}}}
+
+	_, err = grafana.Convert(payload, grafana.Options{})
+	require.ErrorContains(t, err, "alerts[0] could not be converted")
+}
@@ -0,0 +1,103 @@
+// Package convert holds helpers shared by the ingestion adapters.
+package convert
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// Severity maps a severity name used by other alerting systems to an alert severity.
+// 'critical', 'page', 'panic', 'fatal' and 'emergency' map to panic, 'warning' and 'warn' to warning, 'info', 'information',
+// 'informational', 'low' and 'none' to info, and anything else to error.
+func Severity(name string) types.AlertSeverity {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "critical", "page", "panic", "fatal", "emergency":
+		return types.AlertPanic
+	case "warning", "warn":
+		return types.AlertWarning
+	case "info", "information", "informational", "low", "none":
+		return types.AlertInfo
+	default:
+		return types.AlertError
+	}
+}
+
+// Hash returns a deterministic hex encoded SHA-256 hash of the input strings.
+func Hash(input ...string) string {
+	h := sha256.New()
+
+	for _, s := range input {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LabelsHash returns a deterministic hash of the labels, independent of map ordering.
+func LabelsHash(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	input := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		input = append(input, k, labels[k])
+	}
+
+	return Hash(input...)
+}
+
+// MergeMaps returns a new map with the values of common, overridden by the values of specific.
+func MergeMaps(common, specific map[string]string) map[string]string {
+	result := make(map[string]string, len(common)+len(specific))
+
+	for k, v := range common {
+		result[k] = v
+	}
+
+	for k, v := range specific {
+		result[k] = v
+	}
+
+	return result
+}
+
+// FirstNonEmpty returns the first value that is not empty or whitespace only, or an empty string if there is none.
+func FirstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// Fields returns alert fields from title/value pairs, skipping empty values.
+func Fields(titleValues ...string) []*types.Field {
+	var result []*types.Field
+
+	for i := 0; i+1 < len(titleValues); i += 2 {
+		if titleValues[i+1] != "" {
+			result = append(result, &types.Field{Title: titleValues[i], Value: titleValues[i+1]})
+		}
+	}
+
+	return result
+}
+
+// Source returns the source of alerts converted by the named adapter, with the adapter name as the emitting system.
+func Source(adapter string) *types.AlertSource {
+	return &types.AlertSource{
+		System:     adapter,
+		IngestPath: types.IngestPathAdapterPrefix + adapter,
+	}
+}
@@ -0,0 +1,48 @@
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeverity(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, types.AlertPanic, convert.Severity(" Critical "))
+	assert.Equal(t, types.AlertWarning, convert.Severity("warn"))
+	assert.Equal(t, types.AlertInfo, convert.Severity("none"))
+	assert.Equal(t, types.AlertError, convert.Severity("high"))
+	assert.Equal(t, types.AlertError, convert.Severity(""))
+}
+
+func TestLabelsHash(t *testing.T) {
+	t.Parallel()
+
+	a := convert.LabelsHash(map[string]string{"a": "1", "b": "2"})
+	b := convert.LabelsHash(map[string]string{"b": "2", "a": "1"})
+	c := convert.LabelsHash(map[string]string{"a": "12"})
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.Len(t, a, 64)
+}
+
+func TestMergeMapsAndFirstNonEmpty(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]string{"a": "2", "b": "3"}, convert.MergeMaps(map[string]string{"a": "1", "b": "3"}, map[string]string{"a": "2"}))
+	assert.Equal(t, "x", convert.FirstNonEmpty("", " ", "x", "y"))
+	assert.Empty(t, convert.FirstNonEmpty())
+}
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	fields := convert.Fields("A", "1", "B", "", "C", "3", "D")
+	assert.Len(t, fields, 2)
+	assert.Equal(t, "C", fields[1].Title)
+	assert.Nil(t, convert.Fields())
+}
@@ -0,0 +1,284 @@
+// Package jira converts Jira issue webhook events into Slack Manager alerts.
+//
+// The Jira issue key is used as correlation ID, so that updates to the issue update the same Slack Manager issue,
+// and completing the issue in Jira resolves it in Slack:
+//
+//	event, err := jira.Parse(body)
+//	alert, err := jira.Convert(event, jira.Options{SlackChannelID: "C12345678"})
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// EventIssueCreated is the webhook event sent when an issue is created.
+	EventIssueCreated = "jira:issue_created"
+
+	// EventIssueUpdated is the webhook event sent when an issue is updated.
+	EventIssueUpdated = "jira:issue_updated"
+
+	// EventIssueDeleted is the webhook event sent when an issue is deleted.
+	EventIssueDeleted = "jira:issue_deleted"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 7 * 24 * 60 * 60
+
+	// DefaultRouteKey is the route key of converted alerts when neither Options nor the event provide one.
+	DefaultRouteKey = "jira"
+
+	// correlationIDPrefix is prepended to the issue key, to form the correlation ID.
+	correlationIDPrefix = "jira-"
+
+	// timeLayout is the layout of Jira timestamps.
+	timeLayout = "2006-01-02T15:04:05.000-0700"
+)
+
+// ErrUnsupportedEvent is returned by Convert for webhook events other than issue created, updated and deleted.
+// Callers should acknowledge such webhooks without sending an alert.
+var ErrUnsupportedEvent = errors.New("unsupported jira webhook event")
+
+// Event is a Jira issue webhook event.
+type Event struct {
+	WebhookEvent string `json:"webhookEvent"`
+	Timestamp    int64  `json:"timestamp"`
+	User         *User  `json:"user"`
+	Issue        *Issue `json:"issue"`
+}
+
+// Issue is a Jira issue.
+type Issue struct {
+	ID     string       `json:"id"`
+	Self   string       `json:"self"`
+	Key    string       `json:"key"`
+	Fields *IssueFields `json:"fields"`
+}
+
+// IssueFields holds the issue fields used by the conversion.
+type IssueFields struct {
+	Summary string `json:"summary"`
+
+	// Description is a plain string for the Jira REST API v2 representation. Rich text (Atlassian Document Format)
+	// descriptions are not converted.
+	Description json.RawMessage `json:"description"`
+	Priority    *Named          `json:"priority"`
+	Status      *Status         `json:"status"`
+	Resolution  *Named          `json:"resolution"`
+	IssueType   *Named          `json:"issuetype"`
+	Project     *Project        `json:"project"`
+	Labels      []string        `json:"labels"`
+	Assignee    *User           `json:"assignee"`
+	Updated     string          `json:"updated"`
+}
+
+// Named is a Jira entity identified by name, such as a priority or an issue type.
+type Named struct {
+	Name string `json:"name"`
+}
+
+// Status is the status of a Jira issue.
+type Status struct {
+	Name           string          `json:"name"`
+	StatusCategory *StatusCategory `json:"statusCategory"`
+}
+
+// StatusCategory is the category of a Jira status. The key is 'new', 'indeterminate' or 'done'.
+type StatusCategory struct {
+	Key string `json:"key"`
+}
+
+// Project is a Jira project.
+type Project struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// User is a Jira user.
+type User struct {
+	DisplayName string `json:"displayName"`
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over RouteKey.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Defaults to the project key if known, or DefaultRouteKey.
+	RouteKey string
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse decodes a Jira webhook event.
+func Parse(data []byte) (*Event, error) {
+	var event Event
+
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode jira event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// Convert converts the event into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The correlation ID is 'jira-<issue key>'.
+//   - Deleted issues, and issues with a resolution or in a 'done' status category, map to severity resolved.
+//     Otherwise, priority Highest and Blocker map to panic, High and Critical to error, Medium and Major to warning,
+//     and Low, Lowest, Minor and Trivial to info.
+//   - The header is the issue summary, and the text is the issue description.
+//   - The issue type, status and assignee become alert fields, and the link is the issue browse URL.
+//
+// ErrUnsupportedEvent is returned for events other than issue created, updated and deleted.
+func Convert(event *Event, opts Options) (*types.Alert, error) {
+	if event == nil || event.Issue == nil {
+		return nil, errors.New("jira event is empty")
+	}
+
+	if event.WebhookEvent != EventIssueCreated && event.WebhookEvent != EventIssueUpdated && event.WebhookEvent != EventIssueDeleted {
+		return nil, fmt.Errorf("%w '%s'", ErrUnsupportedEvent, event.WebhookEvent)
+	}
+
+	issue := event.Issue
+
+	if issue.Key == "" {
+		return nil, errors.New("jira issue key is missing")
+	}
+
+	fields := issue.Fields
+	if fields == nil {
+		fields = &IssueFields{}
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	a := types.NewAlert(severity(event.WebhookEvent, fields))
+
+	a.CorrelationID = correlationIDPrefix + issue.Key
+	a.Type = "jira"
+	a.Source = convert.Source("jira")
+	a.Author = "Jira"
+	a.Header = ":status: " + issue.Key + ": " + convert.FirstNonEmpty(fields.Summary, "Jira issue")
+	a.Text = description(fields.Description)
+	a.Link = browseURL(issue)
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+	a.Fields = convert.Fields(
+		"Type", name(fields.IssueType),
+		"Status", status(fields.Status),
+		"Priority", name(fields.Priority),
+		"Assignee", displayName(fields.Assignee),
+	)
+
+	if updated, err := time.Parse(timeLayout, fields.Updated); err == nil {
+		a.Timestamp = updated
+	}
+
+	if len(fields.Labels) > 0 {
+		a.Metadata["jiraLabels"] = strings.Join(fields.Labels, ",")
+	}
+
+	a.Metadata["jiraIssueKey"] = issue.Key
+
+	switch {
+	case opts.SlackChannelID != "":
+		a.SlackChannelID = opts.SlackChannelID
+	case opts.RouteKey != "":
+		a.RouteKey = opts.RouteKey
+	case fields.Project != nil && fields.Project.Key != "":
+		a.RouteKey = fields.Project.Key
+	default:
+		a.RouteKey = DefaultRouteKey
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("jira event could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+func severity(webhookEvent string, fields *IssueFields) types.AlertSeverity {
+	if webhookEvent == EventIssueDeleted || fields.Resolution != nil ||
+		(fields.Status != nil && fields.Status.StatusCategory != nil && fields.Status.StatusCategory.Key == "done") {
+		return types.AlertResolved
+	}
+
+	switch strings.ToLower(name(fields.Priority)) {
+	case "highest", "blocker":
+		return types.AlertPanic
+	case "medium", "major":
+		return types.AlertWarning
+	case "low", "lowest", "minor", "trivial":
+		return types.AlertInfo
+	default:
+		return types.AlertError
+	}
+}
+
+// description returns the description if it is a plain string, and an empty string otherwise.
+func description(raw json.RawMessage) string {
+	var s string
+
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return ""
+	}
+
+	return s
+}
+
+// browseURL returns the browse URL of the issue, derived from its REST API URL.
+func browseURL(issue *Issue) string {
+	u, err := url.Parse(issue.Self)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	base := u.Path
+	if i := strings.Index(base, "/rest/api/"); i >= 0 {
+		base = base[:i]
+	}
+
+	u.Path = base + "/browse/" + issue.Key
+	u.RawQuery = ""
+
+	return u.String()
+}
+
+func name(n *Named) string {
+	if n == nil {
+		return ""
+	}
+
+	return n.Name
+}
+
+func status(s *Status) string {
+	if s == nil {
+		return ""
+	}
+
+	return s.Name
+}
+
+func displayName(u *User) string {
+	if u == nil {
+		return ""
+	}
+
+	return u.DisplayName
+}
@@ -0,0 +1,111 @@
+package jira_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/jira"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eventJSON = `{
+  "timestamp": 1700000000000,
+  "webhookEvent": "%s",
+  "user": {"displayName": "Jane Doe"},
+  "issue": {
+    "id": "10002",
+    "self": "https://acme.atlassian.net/rest/api/2/issue/10002",
+    "key": "OPS-42",
+    "fields": {
+      "summary": "Checkout latency above SLO",
+      "description": "p99 latency is 4s",
+      "priority": {"name": "%s"},
+      "status": {"name": "%s", "statusCategory": {"key": "%s"}},
+      "resolution": %s,
+      "issuetype": {"name": "Incident"},
+      "project": {"key": "OPS", "name": "Operations"},
+      "labels": ["checkout", "latency"],
+      "assignee": {"displayName": "John Doe"},
+      "updated": "%s"
+    }
+  }
+}`
+
+func parse(t *testing.T, webhookEvent, priority, status, category, resolution string) *jira.Event {
+	t.Helper()
+
+	updated := time.Now().Add(-time.Minute).Format("2006-01-02T15:04:05.000-0700")
+
+	event, err := jira.Parse([]byte(fmt.Sprintf(eventJSON, webhookEvent, priority, status, category, resolution, updated)))
+	require.NoError(t, err)
+
+	return event
+}
+
+func TestConvertCreated(t *testing.T) {
+	t.Parallel()
+
+	alert, err := jira.Convert(parse(t, "jira:issue_created", "Highest", "Open", "new", "null"), jira.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertPanic, alert.Severity)
+	assert.Equal(t, "jira-OPS-42", alert.CorrelationID)
+	assert.Equal(t, "ops", alert.RouteKey)
+	assert.Equal(t, ":status: OPS-42: Checkout latency above SLO", alert.Header)
+	assert.Equal(t, "p99 latency is 4s", alert.Text)
+	assert.Equal(t, "https://acme.atlassian.net/browse/OPS-42", alert.Link)
+	assert.Equal(t, "checkout,latency", alert.Metadata["jiraLabels"])
+	assert.Equal(t, []*types.Field{
+		{Title: "Type", Value: "Incident"},
+		{Title: "Status", Value: "Open"},
+		{Title: "Priority", Value: "Highest"},
+		{Title: "Assignee", Value: "John Doe"},
+	}, alert.Fields)
+	assert.WithinDuration(t, time.Now().Add(-time.Minute), alert.Timestamp, time.Second)
+}
+
+func TestConvertSeverities(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		event      string
+		priority   string
+		category   string
+		resolution string
+		expected   types.AlertSeverity
+	}{
+		{"jira:issue_updated", "High", "indeterminate", "null", types.AlertError},
+		{"jira:issue_updated", "Medium", "indeterminate", "null", types.AlertWarning},
+		{"jira:issue_updated", "Lowest", "new", "null", types.AlertInfo},
+		{"jira:issue_updated", "Highest", "done", "null", types.AlertResolved},
+		{"jira:issue_updated", "Highest", "indeterminate", `{"name": "Fixed"}`, types.AlertResolved},
+		{"jira:issue_deleted", "Highest", "new", "null", types.AlertResolved},
+	}
+
+	for _, tt := range tests {
+		alert, err := jira.Convert(parse(t, tt.event, tt.priority, "Status", tt.category, tt.resolution), jira.Options{SlackChannelID: "C12345678"})
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, alert.Severity, tt.priority)
+		assert.Equal(t, "C12345678", alert.SlackChannelID)
+	}
+}
+
+func TestConvertUnsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := jira.Convert(parse(t, "comment_created", "High", "Open", "new", "null"), jira.Options{})
+	require.ErrorIs(t, err, jira.ErrUnsupportedEvent)
+
+	_, err = jira.Convert(&jira.Event{WebhookEvent: "jira:issue_created", Issue: &jira.Issue{}}, jira.Options{})
+	require.Error(t, err)
+
+	event := parse(t, "jira:issue_created", "High", "Open", "new", "null")
+	event.Issue.Fields.Description = []byte(`{"type": "doc", "content": []}`)
+
+	alert, err := jira.Convert(event, jira.Options{})
+	require.NoError(t, err)
+	assert.Empty(t, alert.Text)
+}
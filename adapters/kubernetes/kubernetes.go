@@ -0,0 +1,263 @@
+// Package kubernetes converts Kubernetes Event objects into Slack Manager alerts, so that cluster watchers can
+// feed the Slack Manager directly.
+//
+// The event types mirror the JSON representation of core/v1 Event objects, so that this package does not depend
+// on the Kubernetes client libraries. Watchers using client-go can marshal their events to JSON and call Parse,
+// or build an Event from the fields they need.
+//
+// Repeated events for the same object and reason update a single issue, with the event count as an alert field:
+//
+//	event, err := kubernetes.Parse(body)
+//	alert, err := kubernetes.Convert(event, kubernetes.Options{ClusterName: "prod-eu"})
+package kubernetes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// EventTypeNormal is the type of events describing normal operation.
+	EventTypeNormal = "Normal"
+
+	// EventTypeWarning is the type of events describing a potential problem.
+	EventTypeWarning = "Warning"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 60 * 60
+
+	// DefaultRouteKey is the route key of converted alerts for cluster scoped objects, unless Options sets one.
+	DefaultRouteKey = "kubernetes"
+)
+
+// ErrNormalEvent is returned by Convert for events of type Normal, unless Options.IncludeNormalEvents is set.
+var ErrNormalEvent = errors.New("kubernetes event is of type Normal")
+
+// Event is a Kubernetes core/v1 Event.
+type Event struct {
+	Metadata           ObjectMeta      `json:"metadata"`
+	InvolvedObject     ObjectReference `json:"involvedObject"`
+	Reason             string          `json:"reason"`
+	Message            string          `json:"message"`
+	Source             EventSource     `json:"source"`
+	FirstTimestamp     *time.Time      `json:"firstTimestamp"`
+	LastTimestamp      *time.Time      `json:"lastTimestamp"`
+	EventTime          *time.Time      `json:"eventTime"`
+	Count              int             `json:"count"`
+	Type               string          `json:"type"`
+	Series             *EventSeries    `json:"series"`
+	ReportingComponent string          `json:"reportingComponent"`
+	ReportingInstance  string          `json:"reportingInstance"`
+}
+
+// ObjectMeta holds the metadata of the event object.
+type ObjectMeta struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+}
+
+// ObjectReference identifies the object the event is about.
+type ObjectReference struct {
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	UID        string `json:"uid"`
+	APIVersion string `json:"apiVersion"`
+	FieldPath  string `json:"fieldPath"`
+}
+
+// EventSource is the component reporting the event.
+type EventSource struct {
+	Component string `json:"component"`
+	Host      string `json:"host"`
+}
+
+// EventSeries holds the data of an event series, for events reported with the events.k8s.io API.
+type EventSeries struct {
+	Count            int        `json:"count"`
+	LastObservedTime *time.Time `json:"lastObservedTime"`
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over RouteKey.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Defaults to the namespace of the involved object,
+	// or DefaultRouteKey for cluster scoped objects.
+	RouteKey string
+
+	// ClusterName is the name of the cluster, added as an alert field and included in the correlation ID.
+	// Set it when several clusters report to the same Slack Manager.
+	ClusterName string
+
+	// IncludeNormalEvents converts events of type Normal into info alerts, instead of returning ErrNormalEvent.
+	IncludeNormalEvents bool
+
+	// SeverityByReason overrides the severity of events with the specified reasons, such as 'OOMKilling' or 'FailedMount'.
+	// By default, Warning events map to warning, and Normal events to info.
+	SeverityByReason map[string]types.AlertSeverity
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse decodes a Kubernetes Event object.
+func Parse(data []byte) (*Event, error) {
+	var event Event
+
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode kubernetes event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// Convert converts the event into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The correlation ID is formed from the cluster name (if set), and the namespace, kind and name of the involved object
+//     and the event reason, so that repeated events update a single issue.
+//   - Warning events map to severity warning, and Normal events to info (if included). Options.SeverityByReason overrides this.
+//   - The header is the object kind, namespace, name and the event reason, and the text is the event message.
+//   - The cluster, namespace, object, reason, event count and reporting component become alert fields.
+//
+// ErrNormalEvent is returned for events of type Normal, unless Options.IncludeNormalEvents is set.
+func Convert(event *Event, opts Options) (*types.Alert, error) {
+	if event == nil {
+		return nil, errors.New("kubernetes event is nil")
+	}
+
+	obj := event.InvolvedObject
+
+	if obj.Kind == "" || obj.Name == "" {
+		return nil, errors.New("kubernetes event has no involved object")
+	}
+
+	sev := types.AlertWarning
+
+	if event.Type == EventTypeNormal {
+		if !opts.IncludeNormalEvents {
+			return nil, ErrNormalEvent
+		}
+
+		sev = types.AlertInfo
+	}
+
+	if s, ok := opts.SeverityByReason[event.Reason]; ok {
+		sev = s
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	namespace := convert.FirstNonEmpty(obj.Namespace, event.Metadata.Namespace)
+
+	objectName := obj.Name
+	if namespace != "" {
+		objectName = namespace + "/" + obj.Name
+	}
+
+	a := types.NewAlert(sev)
+
+	a.CorrelationID = CorrelationID(opts.ClusterName, namespace, obj.Kind, obj.Name, event.Reason)
+	a.Type = "kubernetes"
+	a.Source = convert.Source("kubernetes")
+	a.Author = convert.FirstNonEmpty(event.ReportingComponent, event.Source.Component, "Kubernetes")
+	a.Host = event.Source.Host
+	a.Header = ":status: " + obj.Kind + " " + objectName + ": " + convert.FirstNonEmpty(event.Reason, "Event")
+	a.Text = event.Message
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+	a.Fields = convert.Fields(
+		"Cluster", opts.ClusterName,
+		"Namespace", namespace,
+		"Object", strings.ToLower(obj.Kind)+"/"+obj.Name,
+		"Reason", event.Reason,
+		"Count", strconv.Itoa(count(event)),
+		"Component", a.Author,
+	)
+
+	if timestamp := lastSeen(event); !timestamp.IsZero() {
+		a.Timestamp = timestamp
+	}
+
+	a.Metadata["kind"] = obj.Kind
+	a.Metadata["name"] = obj.Name
+	a.Metadata["namespace"] = namespace
+	a.Metadata["reason"] = event.Reason
+
+	if obj.FieldPath != "" {
+		a.Metadata["fieldPath"] = obj.FieldPath
+	}
+
+	if opts.ClusterName != "" {
+		a.Metadata["cluster"] = opts.ClusterName
+	}
+
+	switch {
+	case opts.SlackChannelID != "":
+		a.SlackChannelID = opts.SlackChannelID
+	case opts.RouteKey != "":
+		a.RouteKey = opts.RouteKey
+	default:
+		a.RouteKey = convert.FirstNonEmpty(namespace, DefaultRouteKey)
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("kubernetes event could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+// CorrelationID returns the correlation ID of alerts for events with the specified reason, about the specified object.
+// The cluster name and namespace may be empty.
+func CorrelationID(cluster, namespace, kind, name, reason string) string {
+	id := "k8s/" + namespace + "/" + kind + "/" + name + "/" + reason
+
+	if cluster != "" {
+		id = "k8s/" + cluster + id[3:]
+	}
+
+	if len(id) > types.MaxCorrelationIDLength {
+		return "k8s/" + convert.Hash(cluster, namespace, kind, name, reason)
+	}
+
+	return id
+}
+
+// count returns the number of occurrences of the event, which is at least 1.
+func count(event *Event) int {
+	if event.Series != nil && event.Series.Count > event.Count {
+		return event.Series.Count
+	}
+
+	return max(event.Count, 1)
+}
+
+// lastSeen returns the time the event was last observed, or the zero time if it is unknown.
+func lastSeen(event *Event) time.Time {
+	if event.Series != nil && event.Series.LastObservedTime != nil {
+		return *event.Series.LastObservedTime
+	}
+
+	for _, t := range []*time.Time{event.LastTimestamp, event.EventTime, event.FirstTimestamp} {
+		if t != nil && !t.IsZero() {
+			return *t
+		}
+	}
+
+	return time.Time{}
+}
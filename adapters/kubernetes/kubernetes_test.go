@@ -0,0 +1,116 @@
+package kubernetes_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/kubernetes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eventJSON = `{
+  "apiVersion": "v1",
+  "kind": "Event",
+  "metadata": {"name": "api-7d9f8b6c5-x2k4p.17a1b2c3d4e5f6a7", "namespace": "payments", "uid": "5b1d9c1e"},
+  "involvedObject": {"kind": "Pod", "namespace": "payments", "name": "api-7d9f8b6c5-x2k4p", "apiVersion": "v1", "fieldPath": "spec.containers{api}"},
+  "reason": "BackOff",
+  "message": "Back-off restarting failed container api in pod api-7d9f8b6c5-x2k4p",
+  "source": {"component": "kubelet", "host": "node-3"},
+  "firstTimestamp": "%s",
+  "lastTimestamp": "%s",
+  "eventTime": null,
+  "count": 14,
+  "type": "%s"
+}`
+
+func parse(t *testing.T, eventType string) *kubernetes.Event {
+	t.Helper()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	event, err := kubernetes.Parse([]byte(fmt.Sprintf(eventJSON, now.Add(-time.Hour).Format(time.RFC3339), now.Format(time.RFC3339), eventType)))
+	require.NoError(t, err)
+
+	return event
+}
+
+func TestConvertWarning(t *testing.T) {
+	t.Parallel()
+
+	event := parse(t, "Warning")
+
+	alert, err := kubernetes.Convert(event, kubernetes.Options{ClusterName: "prod-eu"})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertWarning, alert.Severity)
+	assert.Equal(t, "k8s/prod-eu/payments/Pod/api-7d9f8b6c5-x2k4p/BackOff", alert.CorrelationID)
+	assert.Equal(t, "payments", alert.RouteKey)
+	assert.Equal(t, ":status: Pod payments/api-7d9f8b6c5-x2k4p: BackOff", alert.Header)
+	assert.Equal(t, "node-3", alert.Host)
+	assert.Equal(t, "kubelet", alert.Author)
+	assert.Equal(t, *event.LastTimestamp, alert.Timestamp)
+	assert.Equal(t, []*types.Field{
+		{Title: "Cluster", Value: "prod-eu"},
+		{Title: "Namespace", Value: "payments"},
+		{Title: "Object", Value: "pod/api-7d9f8b6c5-x2k4p"},
+		{Title: "Reason", Value: "BackOff"},
+		{Title: "Count", Value: "14"},
+		{Title: "Component", Value: "kubelet"},
+	}, alert.Fields)
+	assert.Equal(t, "spec.containers{api}", alert.Metadata["fieldPath"])
+}
+
+func TestConvertNormalAndSeverityByReason(t *testing.T) {
+	t.Parallel()
+
+	_, err := kubernetes.Convert(parse(t, "Normal"), kubernetes.Options{})
+	require.ErrorIs(t, err, kubernetes.ErrNormalEvent)
+
+	alert, err := kubernetes.Convert(parse(t, "Normal"), kubernetes.Options{IncludeNormalEvents: true, SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertInfo, alert.Severity)
+	assert.Equal(t, "C12345678", alert.SlackChannelID)
+	assert.Empty(t, alert.RouteKey)
+
+	alert, err = kubernetes.Convert(parse(t, "Warning"), kubernetes.Options{SeverityByReason: map[string]types.AlertSeverity{"BackOff": types.AlertError}})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertError, alert.Severity)
+	assert.Equal(t, "k8s/payments/Pod/api-7d9f8b6c5-x2k4p/BackOff", alert.CorrelationID)
+}
+
+func TestConvertClusterScopedSeries(t *testing.T) {
+	t.Parallel()
+
+	observed := time.Now().UTC().Add(-time.Minute)
+
+	event := &kubernetes.Event{
+		InvolvedObject:     kubernetes.ObjectReference{Kind: "Node", Name: "node-3"},
+		Reason:             "NodeNotReady",
+		Type:               kubernetes.EventTypeWarning,
+		ReportingComponent: "node-controller",
+		Series:             &kubernetes.EventSeries{Count: 3, LastObservedTime: &observed},
+	}
+
+	alert, err := kubernetes.Convert(event, kubernetes.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, kubernetes.DefaultRouteKey, alert.RouteKey)
+	assert.Equal(t, ":status: Node node-3: NodeNotReady", alert.Header)
+	assert.Equal(t, observed, alert.Timestamp)
+	assert.Contains(t, alert.Fields, &types.Field{Title: "Count", Value: "3"})
+
+	_, err = kubernetes.Convert(&kubernetes.Event{Type: kubernetes.EventTypeWarning}, kubernetes.Options{})
+	require.Error(t, err)
+}
+
+func TestCorrelationIDTooLong(t *testing.T) {
+	t.Parallel()
+
+	id := kubernetes.CorrelationID("c", "ns", "Pod", strings.Repeat("x", types.MaxCorrelationIDLength), "BackOff")
+	assert.LessOrEqual(t, len(id), types.MaxCorrelationIDLength)
+	assert.True(t, strings.HasPrefix(id, "k8s/"))
+}
@@ -0,0 +1,308 @@
+package mapping
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// placeholderRegex matches the '${...}' placeholders in template expressions.
+var placeholderRegex = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// expression is a compiled mapping expression. It is either a literal, a single pipeline (a path with optional filters,
+// such as '$.status | lower'), or a template with pipelines in '${...}' placeholders, such as 'Disk full on ${$.host}'.
+type expression struct {
+	literal  string
+	pipeline *pipeline
+	template []templatePart
+}
+
+// templatePart is either literal text, or a pipeline whose value is inserted.
+type templatePart struct {
+	text     string
+	pipeline *pipeline
+}
+
+// pipeline is a path, followed by filters applied in order.
+type pipeline struct {
+	path    *path
+	filters []filter
+}
+
+// filter is a named function applied to a value, with an optional argument.
+type filter struct {
+	name string
+	arg  string
+}
+
+// compileExpression compiles an expression. Expressions starting with '$' are pipelines, expressions containing
+// '${' are templates, and anything else is a literal.
+func compileExpression(s string) (*expression, error) {
+	trimmed := strings.TrimSpace(s)
+
+	switch {
+	case strings.HasPrefix(trimmed, "$") && !strings.HasPrefix(trimmed, "${"):
+		p, err := compilePipeline(trimmed)
+		if err != nil {
+			return nil, err
+		}
+
+		return &expression{pipeline: p}, nil
+	case strings.Contains(s, "${"):
+		return compileTemplate(s)
+	default:
+		return &expression{literal: s}, nil
+	}
+}
+
+func compileTemplate(s string) (*expression, error) {
+	e := &expression{}
+	last := 0
+
+	for _, loc := range placeholderRegex.FindAllStringSubmatchIndex(s, -1) {
+		if loc[0] > last {
+			e.template = append(e.template, templatePart{text: s[last:loc[0]]})
+		}
+
+		p, err := compilePipeline(s[loc[2]:loc[3]])
+		if err != nil {
+			return nil, err
+		}
+
+		e.template = append(e.template, templatePart{pipeline: p})
+		last = loc[1]
+	}
+
+	if strings.Contains(s[last:], "${") {
+		return nil, fmt.Errorf("template '%s' has an unterminated '${'", s)
+	}
+
+	if last < len(s) {
+		e.template = append(e.template, templatePart{text: s[last:]})
+	}
+
+	return e, nil
+}
+
+func compilePipeline(s string) (*pipeline, error) {
+	stages := splitOutsideQuotes(s, '|')
+
+	p, err := compilePath(strings.TrimSpace(stages[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pipeline{path: p}
+
+	for _, stage := range stages[1:] {
+		f, err := compileFilter(strings.TrimSpace(stage))
+		if err != nil {
+			return nil, fmt.Errorf("expression '%s': %w", strings.TrimSpace(s), err)
+		}
+
+		result.filters = append(result.filters, f)
+	}
+
+	return result, nil
+}
+
+// validFilters returns the names of the supported filters. Filters marked with true require an argument.
+func validFilters() map[string]bool {
+	return map[string]bool{
+		"lower":   false,
+		"upper":   false,
+		"trim":    false,
+		"first":   false,
+		"default": true,
+		"join":    true,
+	}
+}
+
+func compileFilter(s string) (filter, error) {
+	name, arg, _ := strings.Cut(s, " ")
+	arg = strings.TrimSpace(arg)
+
+	requiresArg, ok := validFilters()[name]
+	if !ok {
+		names := make([]string, 0, len(validFilters()))
+		for n := range validFilters() {
+			names = append(names, n)
+		}
+
+		sort.Strings(names)
+
+		return filter{}, fmt.Errorf("filter '%s' is not valid, expected one of [%s]", name, strings.Join(names, ", "))
+	}
+
+	if requiresArg != (arg != "") {
+		if requiresArg {
+			return filter{}, fmt.Errorf("filter '%s' requires an argument", name)
+		}
+
+		return filter{}, fmt.Errorf("filter '%s' does not take an argument", name)
+	}
+
+	if arg != "" {
+		if len(arg) < 2 || (arg[0] != '\'' && arg[0] != '"') || arg[len(arg)-1] != arg[0] {
+			return filter{}, fmt.Errorf("filter '%s' argument must be a quoted string", name)
+		}
+
+		arg = arg[1 : len(arg)-1]
+	}
+
+	return filter{name: name, arg: arg}, nil
+}
+
+// value returns the value of the expression. Pipelines without filters return the raw JSON value,
+// and all other expressions return a string.
+func (e *expression) value(root any) any {
+	switch {
+	case e.pipeline != nil:
+		return e.pipeline.evaluate(root)
+	case e.template != nil:
+		var b strings.Builder
+
+		for _, part := range e.template {
+			if part.pipeline != nil {
+				b.WriteString(stringify(part.pipeline.evaluate(root)))
+			} else {
+				b.WriteString(part.text)
+			}
+		}
+
+		return b.String()
+	default:
+		return e.literal
+	}
+}
+
+// string returns the value of the expression as a string.
+func (e *expression) string(root any) string {
+	return stringify(e.value(root))
+}
+
+func (p *pipeline) evaluate(root any) any {
+	v := p.path.evaluate(root)
+
+	for _, f := range p.filters {
+		v = f.apply(v)
+	}
+
+	return v
+}
+
+func (f filter) apply(v any) any {
+	switch f.name {
+	case "lower":
+		return strings.ToLower(stringify(v))
+	case "upper":
+		return strings.ToUpper(stringify(v))
+	case "trim":
+		return strings.TrimSpace(stringify(v))
+	case "default":
+		if stringify(v) == "" {
+			return f.arg
+		}
+
+		return v
+	case "join":
+		if list, ok := v.([]any); ok {
+			return joinValues(list, f.arg)
+		}
+
+		return stringify(v)
+	case "first":
+		if list, ok := v.([]any); ok {
+			if len(list) == 0 {
+				return nil
+			}
+
+			return list[0]
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+// stringify formats a JSON value as a string. Nil is formatted as an empty string, lists are joined with ', ',
+// and objects are formatted as JSON.
+func stringify(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case []any:
+		return joinValues(val, ", ")
+	default:
+		body, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+
+		return string(body)
+	}
+}
+
+func joinValues(list []any, sep string) string {
+	values := make([]string, 0, len(list))
+
+	for _, item := range list {
+		if s := stringify(item); s != "" {
+			values = append(values, s)
+		}
+	}
+
+	return strings.Join(values, sep)
+}
+
+// mapValues returns the values of the map, ordered by key.
+func mapValues(m map[string]any) []any {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	values := make([]any, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, m[k])
+	}
+
+	return values
+}
+
+// splitOutsideQuotes splits s at each sep that is not inside single or double quotes.
+func splitOutsideQuotes(s string, sep byte) []string {
+	var (
+		parts []string
+		quote byte
+		start int
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote == 0 && (s[i] == '\'' || s[i] == '"'):
+			quote = s[i]
+		case quote == 0 && s[i] == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
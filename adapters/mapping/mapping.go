@@ -0,0 +1,446 @@
+// Package mapping transforms arbitrary inbound JSON into Slack Manager alerts, using a small declarative mapping
+// definition instead of a dedicated Go adapter. This makes it possible to onboard one-off sources by configuration.
+//
+// Each alert property, field and metadata value is given by an expression, which is one of:
+//   - A path into the JSON document (a JSONPath subset), such as '$.alert.title', '$.tags[0]' or '$.checks[*].name',
+//     optionally followed by filters, as in '$.status | lower'.
+//   - A template with paths in '${...}' placeholders, such as 'Disk full on ${$.host | upper}'.
+//   - A literal string, for anything else.
+//
+// The supported filters are 'lower', 'upper', 'trim', 'first', 'default "value"' and 'join ", "'.
+//
+// Definitions are validated when compiled, so that invalid mappings are rejected when they are loaded,
+// rather than when the first payload arrives:
+//
+//	definition, err := mapping.ParseDefinition(yamlOrJSON)
+//	mapper, err := definition.Compile()
+//	alert, err := mapper.Map(body)
+package mapping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// MaxDefinitionNameLength is the maximum length of a mapping definition name.
+	MaxDefinitionNameLength = 100
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of mapped alerts, unless the definition sets one.
+	DefaultAutoResolveSeconds = 24 * 60 * 60
+)
+
+// Definition is a mapping from inbound JSON documents to alerts.
+type Definition struct {
+	// Name is the name of the mapping, used as the alert type unless Properties sets 'type'.
+	// This field is required.
+	// Maximum length: MaxDefinitionNameLength characters.
+	Name string `json:"name"`
+
+	// Properties maps alert properties to expressions. The keys are the JSON names of the alert properties,
+	// as listed by ValidProperties.
+	Properties map[string]string `json:"properties"`
+
+	// Fields maps alert fields to expressions, in order. Fields with an empty value are omitted.
+	Fields []*FieldMapping `json:"fields"`
+
+	// Metadata maps alert metadata keys to expressions. Expressions that are plain paths keep the JSON type of the value.
+	Metadata map[string]string `json:"metadata"`
+
+	// Severity maps the inbound document to the alert severity. If nil, all alerts have severity error.
+	Severity *SeverityMapping `json:"severity"`
+
+	// SkipWhen is an optional expression. Documents where it evaluates to a non-empty string other than 'false'
+	// are skipped, and Map returns ErrSkipped.
+	SkipWhen string `json:"skipWhen"`
+}
+
+// FieldMapping maps an alert field to an expression.
+type FieldMapping struct {
+	// Title is the field title. It may be an expression, such as '${$.labelName}'.
+	Title string `json:"title"`
+
+	// Value is the field value expression.
+	Value string `json:"value"`
+}
+
+// SeverityMapping maps the value of an expression to an alert severity.
+type SeverityMapping struct {
+	// Expression gives the source severity, such as '$.state | lower'.
+	Expression string `json:"expression"`
+
+	// Values maps source severities to alert severities. Keys are compared case-insensitively.
+	Values map[string]types.AlertSeverity `json:"values"`
+
+	// Default is the severity used when the source severity is not found in Values. Defaults to error.
+	Default types.AlertSeverity `json:"default"`
+}
+
+// ErrSkipped is returned by Mapper.Map for documents matching the SkipWhen expression of the definition.
+var ErrSkipped = errors.New("document is skipped by the mapping")
+
+// ValidProperties returns the alert properties that can be mapped in Definition.Properties.
+func ValidProperties() []string {
+	return []string{
+		"header", "headerWhenResolved", "text", "textWhenResolved", "fallbackText", "author", "host", "footer", "link",
+		"type", "slackChannelId", "routeKey", "correlationId", "globalIssueKey", "username", "iconEmoji",
+		"timestamp", "autoResolveSeconds", "autoResolveAsInconclusive", "issueFollowUpEnabled",
+	}
+}
+
+// ParseDefinition parses a mapping definition in YAML or JSON, using the same (camelCase) field names for both:
+//
+//	name: uptime-robot
+//	properties:
+//	  header: ":status: ${$.monitorFriendlyName} is ${$.alertTypeFriendlyName | lower}"
+//	  text: $.alertDetails
+//	  correlationId: uptime-${$.monitorID}
+//	  link: $.monitorURL
+//	  routeKey: uptime
+//	fields:
+//	  - title: Duration
+//	    value: ${$.alertDuration} seconds
+//	severity:
+//	  expression: $.alertType
+//	  values: {"1": error, "2": resolved}
+//
+// The definition is not validated. Call Compile to validate it.
+func ParseDefinition(data []byte) (*Definition, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping definition: %w", err)
+	}
+
+	// Convert to JSON, to reuse the JSON field names.
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert mapping definition to JSON: %w", err)
+	}
+
+	var d Definition
+
+	if err := json.Unmarshal(body, &d); err != nil {
+		return nil, fmt.Errorf("failed to decode mapping definition: %w", err)
+	}
+
+	return &d, nil
+}
+
+// Mapper maps inbound JSON documents to alerts, according to a compiled definition.
+// It is safe for concurrent use.
+type Mapper struct {
+	name            string
+	properties      map[string]*expression
+	fields          []*compiledField
+	metadata        map[string]*expression
+	severity        *expression
+	severityValues  map[string]types.AlertSeverity
+	defaultSeverity types.AlertSeverity
+	skipWhen        *expression
+}
+
+type compiledField struct {
+	title *expression
+	value *expression
+}
+
+// Compile validates the definition, and compiles it into a Mapper.
+func (d *Definition) Compile() (*Mapper, error) {
+	if d == nil {
+		return nil, errors.New("mapping definition is nil")
+	}
+
+	if d.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	if len(d.Name) > MaxDefinitionNameLength {
+		return nil, fmt.Errorf("name is too long, expected length <=%d", MaxDefinitionNameLength)
+	}
+
+	if len(d.Properties) == 0 && len(d.Fields) == 0 {
+		return nil, errors.New("properties or fields are required")
+	}
+
+	m := &Mapper{
+		name:            d.Name,
+		properties:      make(map[string]*expression, len(d.Properties)),
+		metadata:        make(map[string]*expression, len(d.Metadata)),
+		defaultSeverity: types.AlertError,
+	}
+
+	valid := ValidProperties()
+
+	for _, key := range sortedKeys(d.Properties) {
+		if !slices.Contains(valid, key) {
+			return nil, fmt.Errorf("properties.%s is not a valid alert property, expected one of [%s]", key, strings.Join(valid, ", "))
+		}
+
+		e, err := compileExpression(d.Properties[key])
+		if err != nil {
+			return nil, fmt.Errorf("properties.%s: %w", key, err)
+		}
+
+		m.properties[key] = e
+	}
+
+	for index, f := range d.Fields {
+		if f == nil || f.Title == "" {
+			return nil, fmt.Errorf("fields[%d].title is required", index)
+		}
+
+		title, err := compileExpression(f.Title)
+		if err != nil {
+			return nil, fmt.Errorf("fields[%d].title: %w", index, err)
+		}
+
+		value, err := compileExpression(f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("fields[%d].value: %w", index, err)
+		}
+
+		m.fields = append(m.fields, &compiledField{title: title, value: value})
+	}
+
+	for _, key := range sortedKeys(d.Metadata) {
+		e, err := compileExpression(d.Metadata[key])
+		if err != nil {
+			return nil, fmt.Errorf("metadata.%s: %w", key, err)
+		}
+
+		m.metadata[key] = e
+	}
+
+	if err := m.compileSeverity(d.Severity); err != nil {
+		return nil, err
+	}
+
+	if d.SkipWhen != "" {
+		e, err := compileExpression(d.SkipWhen)
+		if err != nil {
+			return nil, fmt.Errorf("skipWhen: %w", err)
+		}
+
+		m.skipWhen = e
+	}
+
+	return m, nil
+}
+
+func (m *Mapper) compileSeverity(s *SeverityMapping) error {
+	if s == nil {
+		return nil
+	}
+
+	if s.Expression == "" {
+		return errors.New("severity.expression is required")
+	}
+
+	e, err := compileExpression(s.Expression)
+	if err != nil {
+		return fmt.Errorf("severity.expression: %w", err)
+	}
+
+	m.severity = e
+	m.severityValues = make(map[string]types.AlertSeverity, len(s.Values))
+
+	for _, key := range sortedKeys(s.Values) {
+		sev := s.Values[key]
+
+		if !types.SeverityIsValid(sev) {
+			return fmt.Errorf("severity.values.%s '%s' is not valid, expected one of [%s]", key, sev, strings.Join(types.ValidSeverities(), ", "))
+		}
+
+		m.severityValues[strings.ToLower(key)] = sev
+	}
+
+	if s.Default != "" {
+		if !types.SeverityIsValid(s.Default) {
+			return fmt.Errorf("severity.default '%s' is not valid, expected one of [%s]", s.Default, strings.Join(types.ValidSeverities(), ", "))
+		}
+
+		m.defaultSeverity = s.Default
+	}
+
+	return nil
+}
+
+// Map decodes the JSON document, and maps it to a cleaned and validated types.Alert.
+// ErrSkipped is returned if the document matches the SkipWhen expression of the definition.
+func (m *Mapper) Map(data []byte) (*types.Alert, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc any
+
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode %s document: %w", m.name, err)
+	}
+
+	return m.MapDocument(doc)
+}
+
+// MapDocument maps an already decoded JSON document (as produced by encoding/json) to a cleaned and validated types.Alert.
+// ErrSkipped is returned if the document matches the SkipWhen expression of the definition.
+func (m *Mapper) MapDocument(doc any) (*types.Alert, error) {
+	if m.skipWhen != nil {
+		if skip := strings.TrimSpace(m.skipWhen.string(doc)); skip != "" && !strings.EqualFold(skip, "false") {
+			return nil, ErrSkipped
+		}
+	}
+
+	sev := m.defaultSeverity
+
+	if m.severity != nil {
+		if s, ok := m.severityValues[strings.ToLower(strings.TrimSpace(m.severity.string(doc)))]; ok {
+			sev = s
+		}
+	}
+
+	a := types.NewAlert(sev)
+	a.Type = m.name
+	a.Source = &types.AlertSource{System: m.name, IngestPath: types.IngestPathAdapterPrefix + "mapping"}
+	a.AutoResolveSeconds = DefaultAutoResolveSeconds
+
+	for _, key := range sortedKeys(m.properties) {
+		if err := setProperty(a, key, m.properties[key].string(doc)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range m.fields {
+		if value := f.value.string(doc); value != "" {
+			a.Fields = append(a.Fields, &types.Field{Title: f.title.string(doc), Value: value})
+		}
+	}
+
+	for key, e := range m.metadata {
+		if v := e.value(doc); v != nil {
+			a.Metadata[key] = v
+		}
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("%s document could not be mapped: %w", m.name, err)
+	}
+
+	return a, nil
+}
+
+func setProperty(a *types.Alert, key, value string) error {
+	switch key {
+	case "header":
+		a.Header = value
+	case "headerWhenResolved":
+		a.HeaderWhenResolved = value
+	case "text":
+		a.Text = value
+	case "textWhenResolved":
+		a.TextWhenResolved = value
+	case "fallbackText":
+		a.FallbackText = value
+	case "author":
+		a.Author = value
+	case "host":
+		a.Host = value
+	case "footer":
+		a.Footer = value
+	case "link":
+		a.Link = value
+	case "type":
+		a.Type = value
+	case "slackChannelId":
+		a.SlackChannelID = value
+	case "routeKey":
+		a.RouteKey = value
+	case "correlationId":
+		a.CorrelationID = value
+	case "globalIssueKey":
+		a.GlobalIssueKey = value
+	case "username":
+		a.Username = value
+	case "iconEmoji":
+		a.IconEmoji = value
+	case "timestamp":
+		if value == "" {
+			return nil
+		}
+
+		timestamp, err := parseTimestamp(value)
+		if err != nil {
+			return fmt.Errorf("properties.timestamp: %w", err)
+		}
+
+		a.Timestamp = timestamp
+	case "autoResolveSeconds":
+		if value == "" {
+			return nil
+		}
+
+		seconds, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("properties.autoResolveSeconds '%s' is not a valid integer", value)
+		}
+
+		a.AutoResolveSeconds = seconds
+	case "autoResolveAsInconclusive", "issueFollowUpEnabled":
+		if value == "" {
+			return nil
+		}
+
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("properties.%s '%s' is not a valid boolean", key, value)
+		}
+
+		if key == "autoResolveAsInconclusive" {
+			a.AutoResolveAsInconclusive = b
+		} else {
+			a.IssueFollowUpEnabled = b
+		}
+	}
+
+	return nil
+}
+
+// parseTimestamp parses an RFC 3339 timestamp, or a Unix timestamp in seconds or milliseconds.
+func parseTimestamp(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("'%s' is not a valid RFC 3339 or Unix timestamp", s)
+	}
+
+	// Unix timestamps in milliseconds have at least 12 digits for dates after 1973
+	if n >= 1e11 {
+		return time.UnixMilli(int64(n)), nil
+	}
+
+	return time.Unix(int64(n), 0), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,214 @@
+package mapping_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/mapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const definitionYAML = `
+name: uptime-robot
+properties:
+  header: ":status: ${$.monitor.name} is ${$.state | lower}"
+  text: $.details
+  correlationId: uptime-${$.monitor.id}
+  link: $.monitor['url']
+  routeKey: $.team | default "uptime"
+  timestamp: $.time
+  issueFollowUpEnabled: "true"
+fields:
+  - title: Duration
+    value: ${$.duration} seconds
+  - title: Tags
+    value: $.tags[*].name | join ", "
+  - title: Missing
+    value: $.missing
+metadata:
+  monitorId: $.monitor.id
+  firstTag: $.tags[0].name
+severity:
+  expression: $.state
+  values: {DOWN: error, up: resolved, Degraded: warning}
+  default: info
+skipWhen: $.paused
+`
+
+func document(state string, extra string) []byte {
+	return []byte(fmt.Sprintf(`{
+  "monitor": {"id": 777, "name": "Checkout API", "url": "https://shop.example.com/health"},
+  "state": "%s",
+  "details": "Connection timeout",
+  "duration": 120,
+  "time": %d,
+  "tags": [{"name": "prod"}, {"name": "eu"}]%s
+}`, state, time.Now().Add(-time.Minute).Unix(), extra))
+}
+
+func compile(t *testing.T) *mapping.Mapper {
+	t.Helper()
+
+	definition, err := mapping.ParseDefinition([]byte(definitionYAML))
+	require.NoError(t, err)
+
+	mapper, err := definition.Compile()
+	require.NoError(t, err)
+
+	return mapper
+}
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	alert, err := compile(t).Map(document("DOWN", ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertError, alert.Severity)
+	assert.Equal(t, ":status: Checkout API is down", alert.Header)
+	assert.Equal(t, "Connection timeout", alert.Text)
+	assert.Equal(t, "uptime-777", alert.CorrelationID)
+	assert.Equal(t, "https://shop.example.com/health", alert.Link)
+	assert.Equal(t, "uptime", alert.RouteKey)
+	assert.Equal(t, "uptime-robot", alert.Type)
+	assert.True(t, alert.IssueFollowUpEnabled)
+	assert.WithinDuration(t, time.Now().Add(-time.Minute), alert.Timestamp, 2*time.Second)
+	assert.Equal(t, []*types.Field{
+		{Title: "Duration", Value: "120 seconds"},
+		{Title: "Tags", Value: "prod, eu"},
+	}, alert.Fields)
+	assert.Equal(t, json.Number("777"), alert.Metadata["monitorId"])
+	assert.Equal(t, "prod", alert.Metadata["firstTag"])
+}
+
+func TestMapSeverityAndSkip(t *testing.T) {
+	t.Parallel()
+
+	mapper := compile(t)
+
+	alert, err := mapper.Map(document("Up", `, "team": "Payments"`))
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertResolved, alert.Severity)
+	assert.Equal(t, "payments", alert.RouteKey)
+
+	alert, err = mapper.Map(document("unknown", ""))
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertInfo, alert.Severity)
+
+	_, err = mapper.Map(document("DOWN", `, "paused": true`))
+	require.ErrorIs(t, err, mapping.ErrSkipped)
+
+	_, err = mapper.Map(document("DOWN", `, "paused": false`))
+	require.NoError(t, err)
+
+	_, err = mapper.Map([]byte("{"))
+	require.Error(t, err)
+}
+
+func TestMapDocumentInvalidValues(t *testing.T) {
+	t.Parallel()
+
+	definition := &mapping.Definition{
+		Name:       "custom",
+		Properties: map[string]string{"header": "$.title", "timestamp": "$.time", "autoResolveSeconds": "$.ttl"},
+	}
+
+	mapper, err := definition.Compile()
+	require.NoError(t, err)
+
+	_, err = mapper.MapDocument(map[string]any{"title": "x", "time": "yesterday"})
+	require.ErrorContains(t, err, "properties.timestamp")
+
+	_, err = mapper.MapDocument(map[string]any{"title": "x", "ttl": "soon"})
+	require.ErrorContains(t, err, "properties.autoResolveSeconds")
+
+	_, err = mapper.MapDocument(map[string]any{"title": ""})
+	require.ErrorContains(t, err, "could not be mapped")
+
+	alert, err := mapper.MapDocument(map[string]any{"title": "x", "time": time.Now().UnixMilli(), "ttl": 3600.0})
+	require.NoError(t, err)
+	assert.Equal(t, 3600, alert.AutoResolveSeconds)
+	assert.WithinDuration(t, time.Now(), alert.Timestamp, time.Second)
+}
+
+func TestCompileInvalidDefinitions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]*mapping.Definition{
+		"name is required":                      {Properties: map[string]string{"header": "x"}},
+		"properties or fields are required":     {Name: "x"},
+		"properties.title is not a valid":       {Name: "x", Properties: map[string]string{"title": "$.title"}},
+		"properties.header: path '$..x'":        {Name: "x", Properties: map[string]string{"header": "$..x"}},
+		"properties.text: path '$.a[1'":         {Name: "x", Properties: map[string]string{"text": "$.a[1"}},
+		"brackets must contain":                 {Name: "x", Properties: map[string]string{"text": "$.a[x]"}},
+		"filter 'shout' is not valid":           {Name: "x", Properties: map[string]string{"text": "$.a | shout"}},
+		"filter 'default' requires an argument": {Name: "x", Properties: map[string]string{"text": "Hi ${$.a | default}"}},
+		"filter 'lower' does not take":          {Name: "x", Properties: map[string]string{"text": "$.a | lower 'x'"}},
+		"must be a quoted string":               {Name: "x", Properties: map[string]string{"text": "$.a | join ,"}},
+		"unterminated '${'":                     {Name: "x", Properties: map[string]string{"text": "Hi ${$.a"}},
+		"fields[0].title is required":           {Name: "x", Fields: []*mapping.FieldMapping{{Value: "$.a"}}},
+		"metadata.k: path":                      {Name: "x", Properties: map[string]string{"header": "x"}, Metadata: map[string]string{"k": "$a"}},
+		"severity.expression is required":       {Name: "x", Properties: map[string]string{"header": "x"}, Severity: &mapping.SeverityMapping{}},
+		"severity.values.a 'bad' is not valid":  {Name: "x", Properties: map[string]string{"header": "x"}, Severity: &mapping.SeverityMapping{Expression: "$.s", Values: map[string]types.AlertSeverity{"a": "bad"}}},
+		"severity.default 'bad' is not valid":   {Name: "x", Properties: map[string]string{"header": "x"}, Severity: &mapping.SeverityMapping{Expression: "$.s", Default: "bad"}},
+		"skipWhen: path":                        {Name: "x", Properties: map[string]string{"header": "x"}, SkipWhen: "$.a[*"},
+	}
+
+	for expected, definition := range tests {
+		_, err := definition.Compile()
+		require.ErrorContains(t, err, expected)
+	}
+
+	var nilDefinition *mapping.Definition
+
+	_, err := nilDefinition.Compile()
+	require.Error(t, err)
+
+	_, err = mapping.ParseDefinition([]byte("name: [unterminated"))
+	require.Error(t, err)
+}
+
+func TestPaths(t *testing.T) {
+	t.Parallel()
+
+	doc := map[string]any{
+		"items": []any{
+			map[string]any{"name": "a", "labels": map[string]any{"zone": "z1", "app": "web"}},
+			map[string]any{"name": "b"},
+		},
+		"key with spaces": "spaced",
+	}
+
+	tests := map[string]string{
+		"$.items[0].name":           "a",
+		"$.items[-1].name":          "b",
+		"$.items[5].name":           "",
+		"$.items[*].name":           "a, b",
+		"$.items[0].labels.*":       "web, z1",
+		"$['key with spaces']":      "spaced",
+		`$.items[*].name | first`:   "a",
+		`$.nothing | default "n/a"`: "n/a",
+		"$.items[0].name | upper":   "A",
+		"$.items[0].labels":         `{"app":"web","zone":"z1"}`,
+		"$.items[0].name.deeper":    "",
+	}
+
+	for expression, expected := range tests {
+		mapper, err := (&mapping.Definition{Name: "paths", Properties: map[string]string{"header": "x"}, Fields: []*mapping.FieldMapping{{Title: "v", Value: expression}}}).Compile()
+		require.NoError(t, err, expression)
+
+		alert, err := mapper.MapDocument(doc)
+		require.NoError(t, err, expression)
+
+		if expected == "" {
+			assert.Empty(t, alert.Fields, expression)
+		} else {
+			require.Len(t, alert.Fields, 1, expression)
+			assert.Equal(t, expected, alert.Fields[0].Value, expression)
+		}
+	}
+}
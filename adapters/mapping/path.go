@@ -0,0 +1,173 @@
+package mapping
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// path is a compiled JSONPath subset expression, such as '$.alerts[0].labels.severity' or '$.items[*].name'.
+type path struct {
+	source   string
+	segments []segment
+}
+
+// segment is a single step of a path. Exactly one of key, index and wildcard is used.
+type segment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// compilePath compiles a path expression. Supported syntax is the root '$', child keys ('.key' or ['key']),
+// array indexes ('[0]', negative indexes count from the end), and wildcards ('[*]' or '.*').
+func compilePath(s string) (*path, error) {
+	p := &path{source: s}
+
+	if !strings.HasPrefix(s, "$") {
+		return nil, fmt.Errorf("path '%s' must start with '$'", s)
+	}
+
+	rest := s[1:]
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := 1
+			for end < len(rest) && isKeyChar(rest[end]) {
+				end++
+			}
+
+			key := rest[1:end]
+
+			switch {
+			case key == "" && strings.HasPrefix(rest, ".*"):
+				p.segments = append(p.segments, segment{wildcard: true})
+				end = 2
+			case key == "":
+				return nil, fmt.Errorf("path '%s' has an empty key", s)
+			default:
+				p.segments = append(p.segments, segment{key: key})
+			}
+
+			rest = rest[end:]
+		case '[':
+			end := closingBracket(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("path '%s' has an unterminated '['", s)
+			}
+
+			seg, err := parseBracket(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("path '%s': %w", s, err)
+			}
+
+			p.segments = append(p.segments, seg)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("path '%s' has an unexpected character '%c'", s, rest[0])
+		}
+	}
+
+	return p, nil
+}
+
+// evaluate returns the value at the path, or nil if it does not exist. Paths with wildcards return a []any
+// with the values matched by all wildcards, flattened.
+func (p *path) evaluate(root any) any {
+	values := []any{root}
+	multiple := false
+
+	for _, seg := range p.segments {
+		var next []any
+
+		for _, v := range values {
+			next = append(next, seg.apply(v)...)
+		}
+
+		values = next
+		multiple = multiple || seg.wildcard
+	}
+
+	if multiple {
+		return values
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values[0]
+}
+
+func (s segment) apply(v any) []any {
+	switch node := v.(type) {
+	case map[string]any:
+		if s.wildcard {
+			return mapValues(node)
+		}
+
+		if child, ok := node[s.key]; ok && !s.isIndex {
+			return []any{child}
+		}
+	case []any:
+		if s.wildcard {
+			return node
+		}
+
+		if s.isIndex {
+			i := s.index
+			if i < 0 {
+				i += len(node)
+			}
+
+			if i >= 0 && i < len(node) {
+				return []any{node[i]}
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseBracket(s string) (segment, error) {
+	s = strings.TrimSpace(s)
+
+	switch {
+	case s == "*":
+		return segment{wildcard: true}, nil
+	case len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]:
+		return segment{key: s[1 : len(s)-1]}, nil
+	default:
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return segment{}, errors.New("brackets must contain an index, '*' or a quoted key")
+		}
+
+		return segment{index: i, isIndex: true}, nil
+	}
+}
+
+// closingBracket returns the index of the ']' closing the '[' at the start of s, skipping quoted keys.
+func closingBracket(s string) int {
+	var quote byte
+
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote == 0 && (s[i] == '\'' || s[i] == '"'):
+			quote = s[i]
+		case quote == 0 && s[i] == ']':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isKeyChar(c byte) bool {
+	return c == '_' || c == '-' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,191 @@
+// Package opsgenie converts OpsGenie outgoing webhook payloads into Slack Manager alerts.
+//
+// The OpsGenie alert alias (or the alert ID, if there is no alias) is used as correlation ID, so that
+// closing the alert in OpsGenie resolves the Slack Manager issue:
+//
+//	payload, err := opsgenie.Parse(body)
+//	alert, err := opsgenie.Convert(payload, opsgenie.Options{SlackChannelID: "C12345678"})
+package opsgenie
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// ActionCreate is the action of webhooks sent when an alert is created.
+	ActionCreate = "Create"
+
+	// ActionClose is the action of webhooks sent when an alert is closed.
+	ActionClose = "Close"
+
+	// ActionUnAcknowledge is the action of webhooks sent when an alert acknowledgement is removed.
+	ActionUnAcknowledge = "UnAcknowledge"
+
+	// ActionEscalate is the action of webhooks sent when an alert is escalated.
+	ActionEscalate = "Escalate"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 24 * 60 * 60
+
+	// DefaultRouteKey is the route key of converted alerts when neither Options nor the payload provide one.
+	DefaultRouteKey = "opsgenie"
+)
+
+// ErrUnsupportedAction is returned by Convert for webhook actions that do not map to an alert,
+// such as acknowledging or adding a note. Callers should acknowledge such webhooks without sending an alert.
+var ErrUnsupportedAction = errors.New("unsupported opsgenie webhook action")
+
+// Payload is an OpsGenie outgoing webhook payload.
+type Payload struct {
+	Action          string  `json:"action"`
+	Alert           *Alert  `json:"alert"`
+	Source          *Source `json:"source"`
+	IntegrationName string  `json:"integrationName"`
+}
+
+// Alert is an OpsGenie alert.
+type Alert struct {
+	AlertID     string            `json:"alertId"`
+	TinyID      string            `json:"tinyId"`
+	Alias       string            `json:"alias"`
+	Message     string            `json:"message"`
+	Description string            `json:"description"`
+	Entity      string            `json:"entity"`
+	Source      string            `json:"source"`
+	Priority    string            `json:"priority"`
+	Team        string            `json:"team"`
+	Tags        []string          `json:"tags"`
+	Details     map[string]string `json:"details"`
+	CreatedAt   int64             `json:"createdAt"`
+	UpdatedAt   int64             `json:"updatedAt"`
+}
+
+// Source describes who or what triggered the webhook action.
+type Source struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over RouteKey.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Defaults to the OpsGenie team if set, or DefaultRouteKey.
+	RouteKey string
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse decodes an OpsGenie webhook payload.
+func Parse(data []byte) (*Payload, error) {
+	var payload Payload
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode opsgenie payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// Convert converts the payload into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The correlation ID is the alert alias, or the alert ID if there is no alias.
+//   - The Close action maps to severity resolved. Otherwise, priority P1 maps to panic, P2 to error, P3 to warning,
+//     and P4 and P5 to info.
+//   - The header is the alert message, and the text is the alert description.
+//   - The entity and source become alert fields, and the details and tags become metadata.
+//
+// ErrUnsupportedAction is returned for actions other than Create, Close, UnAcknowledge and Escalate.
+func Convert(payload *Payload, opts Options) (*types.Alert, error) {
+	if payload == nil || payload.Alert == nil {
+		return nil, errors.New("opsgenie payload is empty")
+	}
+
+	var sev types.AlertSeverity
+
+	switch payload.Action {
+	case ActionCreate, ActionUnAcknowledge, ActionEscalate:
+		sev = severity(payload.Alert.Priority)
+	case ActionClose:
+		sev = types.AlertResolved
+	default:
+		return nil, fmt.Errorf("%w '%s'", ErrUnsupportedAction, payload.Action)
+	}
+
+	src := payload.Alert
+
+	correlationID := convert.FirstNonEmpty(src.Alias, src.AlertID)
+	if correlationID == "" {
+		return nil, errors.New("opsgenie alert ID is missing")
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	a := types.NewAlert(sev)
+
+	a.CorrelationID = correlationID
+	a.Type = "opsgenie"
+	a.Source = convert.Source("opsgenie")
+	a.Author = "OpsGenie"
+	a.Header = ":status: " + convert.FirstNonEmpty(src.Message, "OpsGenie alert "+src.TinyID)
+	a.Text = src.Description
+	a.Fields = convert.Fields("Entity", src.Entity, "Source", src.Source, "Priority", src.Priority)
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+
+	if src.CreatedAt > 0 && sev != types.AlertResolved {
+		a.Timestamp = time.UnixMilli(src.CreatedAt)
+	}
+
+	for k, v := range src.Details {
+		a.Metadata[k] = v
+	}
+
+	if len(src.Tags) > 0 {
+		a.Metadata["opsgenieTags"] = strings.Join(src.Tags, ",")
+	}
+
+	a.Metadata["opsgenieAlertId"] = src.AlertID
+
+	switch {
+	case opts.SlackChannelID != "":
+		a.SlackChannelID = opts.SlackChannelID
+	case opts.RouteKey != "":
+		a.RouteKey = opts.RouteKey
+	default:
+		a.RouteKey = convert.FirstNonEmpty(src.Team, DefaultRouteKey)
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("opsgenie payload could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+func severity(priority string) types.AlertSeverity {
+	switch strings.ToUpper(strings.TrimSpace(priority)) {
+	case "P1":
+		return types.AlertPanic
+	case "P3":
+		return types.AlertWarning
+	case "P4", "P5":
+		return types.AlertInfo
+	default:
+		return types.AlertError
+	}
+}
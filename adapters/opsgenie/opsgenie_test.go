@@ -0,0 +1,87 @@
+package opsgenie_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/opsgenie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const payloadJSON = `{
+  "action": "%s",
+  "alert": {
+    "alertId": "70413a06-38d6-4c85-92b8-5ebc900d42e2",
+    "tinyId": "1791",
+    "alias": "event_573",
+    "message": "Database connections exhausted",
+    "description": "The connection pool of db01 is exhausted",
+    "entity": "db01",
+    "source": "Zabbix",
+    "priority": "%s",
+    "team": "storage",
+    "tags": ["db", "prod"],
+    "details": {"region": "eu-west-1"},
+    "createdAt": 1515405681073
+  },
+  "source": {"name": "", "type": "web"},
+  "integrationName": "Slack Manager"
+}`
+
+func parse(t *testing.T, action, priority string) *opsgenie.Payload {
+	t.Helper()
+
+	payload, err := opsgenie.Parse([]byte(fmt.Sprintf(payloadJSON, action, priority)))
+	require.NoError(t, err)
+
+	return payload
+}
+
+func TestConvertCreate(t *testing.T) {
+	t.Parallel()
+
+	alert, err := opsgenie.Convert(parse(t, "Create", "P1"), opsgenie.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertPanic, alert.Severity)
+	assert.Equal(t, "event_573", alert.CorrelationID)
+	assert.Equal(t, "storage", alert.RouteKey)
+	assert.Equal(t, ":status: Database connections exhausted", alert.Header)
+	assert.Equal(t, "The connection pool of db01 is exhausted", alert.Text)
+	assert.Equal(t, "eu-west-1", alert.Metadata["region"])
+	assert.Equal(t, "db,prod", alert.Metadata["opsgenieTags"])
+	assert.Len(t, alert.Fields, 3)
+}
+
+func TestConvertPrioritiesAndClose(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]types.AlertSeverity{"P2": types.AlertError, "P3": types.AlertWarning, "P4": types.AlertInfo, "P5": types.AlertInfo}
+
+	for priority, expected := range tests {
+		alert, err := opsgenie.Convert(parse(t, "Create", priority), opsgenie.Options{SlackChannelID: "C12345678"})
+		require.NoError(t, err)
+		assert.Equal(t, expected, alert.Severity, priority)
+		assert.Empty(t, alert.RouteKey)
+	}
+
+	alert, err := opsgenie.Convert(parse(t, "Close", "P1"), opsgenie.Options{RouteKey: "dba"})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertResolved, alert.Severity)
+	assert.Equal(t, "dba", alert.RouteKey)
+}
+
+func TestConvertUnsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := opsgenie.Convert(parse(t, "AddNote", "P1"), opsgenie.Options{})
+	require.ErrorIs(t, err, opsgenie.ErrUnsupportedAction)
+
+	_, err = opsgenie.Convert(&opsgenie.Payload{Action: "Create", Alert: &opsgenie.Alert{Message: "x"}}, opsgenie.Options{})
+	require.Error(t, err)
+
+	_, err = opsgenie.Convert(nil, opsgenie.Options{})
+	require.Error(t, err)
+}
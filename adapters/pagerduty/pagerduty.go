@@ -0,0 +1,276 @@
+// Package pagerduty maps between PagerDuty Events API v2 events and Slack Manager alerts.
+//
+// FromPagerDutyEvent lets producers already sending PagerDuty events send the same events to the Slack Manager,
+// and ToPagerDutyEvent lets teams mirror Slack Manager issues into PagerDuty during a migration.
+// The PagerDuty dedup key and the alert correlation ID are mapped to each other.
+package pagerduty
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// EventActionTrigger opens or updates an incident.
+	EventActionTrigger = "trigger"
+
+	// EventActionAcknowledge acknowledges an incident.
+	EventActionAcknowledge = "acknowledge"
+
+	// EventActionResolve resolves an incident.
+	EventActionResolve = "resolve"
+
+	// SeverityCritical is the PagerDuty severity of panic alerts.
+	SeverityCritical = "critical"
+
+	// SeverityError is the PagerDuty severity of error alerts.
+	SeverityError = "error"
+
+	// SeverityWarning is the PagerDuty severity of warning alerts.
+	SeverityWarning = "warning"
+
+	// SeverityInfo is the PagerDuty severity of info alerts.
+	SeverityInfo = "info"
+
+	// MaxSummaryLength is the maximum length of a PagerDuty event summary.
+	MaxSummaryLength = 1024
+
+	// DefaultSource is the event source used by ToPagerDutyEvent for alerts without a host.
+	DefaultSource = "slack-manager"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 24 * 60 * 60
+)
+
+// ErrAcknowledgeNotSupported is returned by FromPagerDutyEvent for acknowledge events,
+// since the Slack Manager has no equivalent of acknowledging an issue through an alert.
+var ErrAcknowledgeNotSupported = errors.New("pagerduty acknowledge events are not supported")
+
+// Event is a PagerDuty Events API v2 event.
+type Event struct {
+	RoutingKey  string   `json:"routing_key"`
+	EventAction string   `json:"event_action"`
+	DedupKey    string   `json:"dedup_key,omitempty"`
+	Payload     *Payload `json:"payload,omitempty"`
+	Client      string   `json:"client,omitempty"`
+	ClientURL   string   `json:"client_url,omitempty"`
+	Links       []*Link  `json:"links,omitempty"`
+	Images      []*Image `json:"images,omitempty"`
+}
+
+// Payload is the payload of a trigger event.
+type Payload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      string         `json:"severity"`
+	Timestamp     *time.Time     `json:"timestamp,omitempty"`
+	Component     string         `json:"component,omitempty"`
+	Group         string         `json:"group,omitempty"`
+	Class         string         `json:"class,omitempty"`
+	CustomDetails map[string]any `json:"custom_details,omitempty"`
+}
+
+// Link is a link attached to an event.
+type Link struct {
+	Href string `json:"href"`
+	Text string `json:"text,omitempty"`
+}
+
+// Image is an image attached to an event.
+type Image struct {
+	Src  string `json:"src"`
+	Href string `json:"href,omitempty"`
+	Alt  string `json:"alt,omitempty"`
+}
+
+// Options configures FromPagerDutyEvent.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over RouteKey.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Defaults to the event routing key.
+	RouteKey string
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse decodes a PagerDuty Events API v2 event.
+func Parse(data []byte) (*Event, error) {
+	var event Event
+
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode pagerduty event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// FromPagerDutyEvent converts a trigger or resolve event into a cleaned and validated types.Alert.
+//
+// The correlation ID is the dedup key. Trigger events without a dedup key get a correlation ID derived from
+// the routing key, source and summary. The severity is mapped as critical → panic, error → error,
+// warning → warning and info → info, and resolve events get severity resolved.
+//
+// ErrAcknowledgeNotSupported is returned for acknowledge events.
+func FromPagerDutyEvent(event *Event, opts Options) (*types.Alert, error) {
+	if event == nil {
+		return nil, errors.New("pagerduty event is nil")
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	var a *types.Alert
+
+	switch event.EventAction {
+	case EventActionTrigger:
+		if event.Payload == nil {
+			return nil, errors.New("payload is required for trigger events")
+		}
+
+		a = types.NewAlert(convert.Severity(event.Payload.Severity))
+		a.CorrelationID = event.DedupKey
+
+		if a.CorrelationID == "" {
+			a.CorrelationID = convert.Hash(event.RoutingKey, event.Payload.Source, event.Payload.Summary)
+		}
+	case EventActionResolve:
+		if event.DedupKey == "" {
+			return nil, errors.New("dedup_key is required for resolve events")
+		}
+
+		a = types.NewAlert(types.AlertResolved)
+		a.CorrelationID = event.DedupKey
+	case EventActionAcknowledge:
+		return nil, ErrAcknowledgeNotSupported
+	default:
+		return nil, fmt.Errorf("event_action '%s' is not valid", event.EventAction)
+	}
+
+	a.Type = "pagerduty"
+	a.Source = convert.Source("pagerduty")
+	a.Author = convert.FirstNonEmpty(event.Client, "PagerDuty")
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+	a.Link = event.ClientURL
+
+	if len(event.Links) > 0 && event.Links[0] != nil {
+		a.Link = event.Links[0].Href
+	}
+
+	if p := event.Payload; p != nil {
+		a.Header = ":status: " + p.Summary
+		a.Host = p.Source
+		a.Fields = convert.Fields("Component", p.Component, "Group", p.Group, "Class", p.Class)
+
+		if p.Timestamp != nil && !p.Timestamp.IsZero() {
+			a.Timestamp = *p.Timestamp
+		}
+
+		for k, v := range p.CustomDetails {
+			a.Metadata[k] = v
+		}
+	} else {
+		a.Header = ":status: Resolved"
+	}
+
+	switch {
+	case opts.SlackChannelID != "":
+		a.SlackChannelID = opts.SlackChannelID
+	case opts.RouteKey != "":
+		a.RouteKey = opts.RouteKey
+	default:
+		a.RouteKey = event.RoutingKey
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("pagerduty event could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+// ToPagerDutyEvent converts an alert into a PagerDuty event for the given routing key (integration key).
+//
+// Resolved alerts become resolve events, and other alerts trigger events. The dedup key is the alert correlation ID,
+// or the ID generated by the default correlation strategy if the alert has none. The summary is the header
+// (without the ':status:' placeholder) or the text, truncated at MaxSummaryLength characters.
+func ToPagerDutyEvent(a *types.Alert, routingKey string) (*Event, error) {
+	if a == nil {
+		return nil, errors.New("alert is nil")
+	}
+
+	if routingKey == "" {
+		return nil, errors.New("routing key is required")
+	}
+
+	dedupKey := a.CorrelationID
+	if dedupKey == "" {
+		dedupKey = types.DefaultCorrelationStrategy().CorrelationID(a)
+	}
+
+	event := &Event{
+		RoutingKey:  routingKey,
+		EventAction: EventActionTrigger,
+		DedupKey:    dedupKey,
+		Client:      "Slack Manager",
+	}
+
+	if a.Severity == types.AlertResolved {
+		event.EventAction = EventActionResolve
+		return event, nil
+	}
+
+	summary := strings.TrimSpace(strings.ReplaceAll(a.Header, ":status:", ""))
+	if summary == "" {
+		summary = strings.TrimSpace(a.Text)
+	}
+
+	if utf8.RuneCountInString(summary) > MaxSummaryLength {
+		summary = string([]rune(summary)[:MaxSummaryLength-3]) + "..."
+	}
+
+	timestamp := a.Timestamp
+
+	event.Payload = &Payload{
+		Summary:       summary,
+		Source:        convert.FirstNonEmpty(a.Host, DefaultSource),
+		Severity:      severity(a.Severity),
+		Class:         a.Type,
+		CustomDetails: a.Metadata,
+	}
+
+	if !timestamp.IsZero() {
+		event.Payload.Timestamp = &timestamp
+	}
+
+	if a.Link != "" {
+		event.Links = []*Link{{Href: a.Link}}
+	}
+
+	return event, nil
+}
+
+func severity(s types.AlertSeverity) string {
+	switch s {
+	case types.AlertPanic:
+		return SeverityCritical
+	case types.AlertWarning:
+		return SeverityWarning
+	case types.AlertInfo:
+		return SeverityInfo
+	default:
+		return SeverityError
+	}
+}
@@ -0,0 +1,147 @@
+package pagerduty_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/pagerduty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const triggerJSON = `{
+  "routing_key": "R0UT1NGK3Y",
+  "event_action": "trigger",
+  "dedup_key": "disk-full-db01",
+  "client": "Monitoring Service",
+  "client_url": "https://monitoring.example.com",
+  "links": [{"href": "https://runbooks.example.com/disk-full", "text": "Runbook"}],
+  "payload": {
+    "summary": "Disk full on db01",
+    "source": "db01.example.com",
+    "severity": "critical",
+    "component": "postgres",
+    "group": "prod-datapipe",
+    "class": "disk",
+    "custom_details": {"free_space": "1%"}
+  }
+}`
+
+func TestFromPagerDutyEventTrigger(t *testing.T) {
+	t.Parallel()
+
+	event, err := pagerduty.Parse([]byte(triggerJSON))
+	require.NoError(t, err)
+
+	alert, err := pagerduty.FromPagerDutyEvent(event, pagerduty.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertPanic, alert.Severity)
+	assert.Equal(t, "disk-full-db01", alert.CorrelationID)
+	assert.Equal(t, "r0ut1ngk3y", alert.RouteKey)
+	assert.Equal(t, ":status: Disk full on db01", alert.Header)
+	assert.Equal(t, "db01.example.com", alert.Host)
+	assert.Equal(t, "Monitoring Service", alert.Author)
+	assert.Equal(t, "https://runbooks.example.com/disk-full", alert.Link)
+	assert.Equal(t, "1%", alert.Metadata["free_space"])
+	assert.Len(t, alert.Fields, 3)
+	assert.True(t, alert.IssueFollowUpEnabled)
+	assert.Equal(t, pagerduty.DefaultAutoResolveSeconds, alert.AutoResolveSeconds)
+}
+
+func TestFromPagerDutyEventWithoutDedupKey(t *testing.T) {
+	t.Parallel()
+
+	event, err := pagerduty.Parse([]byte(triggerJSON))
+	require.NoError(t, err)
+
+	event.DedupKey = ""
+
+	first, err := pagerduty.FromPagerDutyEvent(event, pagerduty.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+
+	second, err := pagerduty.FromPagerDutyEvent(event, pagerduty.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, first.CorrelationID)
+	assert.Equal(t, first.CorrelationID, second.CorrelationID)
+	assert.Equal(t, "C12345678", first.SlackChannelID)
+	assert.Empty(t, first.RouteKey)
+}
+
+func TestFromPagerDutyEventResolveAndAcknowledge(t *testing.T) {
+	t.Parallel()
+
+	alert, err := pagerduty.FromPagerDutyEvent(&pagerduty.Event{RoutingKey: "key", EventAction: "resolve", DedupKey: "disk-full-db01"}, pagerduty.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertResolved, alert.Severity)
+	assert.Equal(t, "disk-full-db01", alert.CorrelationID)
+
+	_, err = pagerduty.FromPagerDutyEvent(&pagerduty.Event{RoutingKey: "key", EventAction: "resolve"}, pagerduty.Options{})
+	require.Error(t, err)
+
+	_, err = pagerduty.FromPagerDutyEvent(&pagerduty.Event{RoutingKey: "key", EventAction: "acknowledge", DedupKey: "x"}, pagerduty.Options{})
+	require.ErrorIs(t, err, pagerduty.ErrAcknowledgeNotSupported)
+
+	_, err = pagerduty.FromPagerDutyEvent(&pagerduty.Event{RoutingKey: "key", EventAction: "snooze"}, pagerduty.Options{})
+	require.Error(t, err)
+}
+
+func TestToPagerDutyEvent(t *testing.T) {
+	t.Parallel()
+
+	alert := types.NewWarningAlert()
+	alert.CorrelationID = "disk-full-db01"
+	alert.Header = ":status: Disk full on db01"
+	alert.Host = "db01.example.com"
+	alert.Link = "https://runbooks.example.com/disk-full"
+	alert.Metadata = map[string]any{"team": "storage"}
+
+	event, err := pagerduty.ToPagerDutyEvent(alert, "R0UT1NGK3Y")
+	require.NoError(t, err)
+
+	assert.Equal(t, pagerduty.EventActionTrigger, event.EventAction)
+	assert.Equal(t, "disk-full-db01", event.DedupKey)
+	assert.Equal(t, "Disk full on db01", event.Payload.Summary)
+	assert.Equal(t, "db01.example.com", event.Payload.Source)
+	assert.Equal(t, pagerduty.SeverityWarning, event.Payload.Severity)
+	assert.Equal(t, "storage", event.Payload.CustomDetails["team"])
+	require.Len(t, event.Links, 1)
+
+	alert.Header = ""
+	alert.Text = strings.Repeat("x", 2000)
+
+	event, err = pagerduty.ToPagerDutyEvent(alert, "R0UT1NGK3Y")
+	require.NoError(t, err)
+	assert.Len(t, event.Payload.Summary, pagerduty.MaxSummaryLength)
+
+	alert.Severity = types.AlertResolved
+
+	event, err = pagerduty.ToPagerDutyEvent(alert, "R0UT1NGK3Y")
+	require.NoError(t, err)
+	assert.Equal(t, pagerduty.EventActionResolve, event.EventAction)
+	assert.Nil(t, event.Payload)
+
+	_, err = pagerduty.ToPagerDutyEvent(alert, "")
+	require.Error(t, err)
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	alert := types.NewErrorAlert()
+	alert.SlackChannelID = "C12345678"
+	alert.CorrelationID = "payments-down"
+	alert.Header = "Payments are down"
+
+	event, err := pagerduty.ToPagerDutyEvent(alert, "key")
+	require.NoError(t, err)
+
+	converted, err := pagerduty.FromPagerDutyEvent(event, pagerduty.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+
+	assert.Equal(t, alert.CorrelationID, converted.CorrelationID)
+	assert.Equal(t, alert.Severity, converted.Severity)
+	assert.Equal(t, ":status: Payments are down", converted.Header)
+}
@@ -0,0 +1,253 @@
+// Package sentry converts Sentry webhook payloads into Slack Manager alerts.
+//
+// Both issue alert webhooks (resource 'event_alert') and issue webhooks (resource 'issue') are supported.
+// The Sentry issue ID is used as correlation ID, so that regressions reopen the same Slack Manager issue,
+// and resolving the issue in Sentry resolves it in Slack:
+//
+//	payload, err := sentry.Parse(body)
+//	alert, err := sentry.Convert(payload, sentry.Options{SlackChannelID: "C12345678"})
+package sentry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// ActionTriggered is the action of issue alert webhooks.
+	ActionTriggered = "triggered"
+
+	// ActionCreated is the action of issue webhooks sent when a new issue is created.
+	ActionCreated = "created"
+
+	// ActionResolved is the action of issue webhooks sent when an issue is resolved.
+	ActionResolved = "resolved"
+
+	// ActionUnresolved is the action of issue webhooks sent when a resolved issue regresses.
+	ActionUnresolved = "unresolved"
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 24 * 60 * 60
+
+	// DefaultRouteKey is the route key of converted alerts when neither Options nor the payload provide one.
+	DefaultRouteKey = "sentry"
+
+	// correlationIDPrefix is prepended to the Sentry issue ID, to form the correlation ID.
+	correlationIDPrefix = "sentry-issue-"
+)
+
+// ErrUnsupportedAction is returned by Convert for webhook actions that do not map to an alert,
+// such as issue assignment. Callers should acknowledge such webhooks without sending an alert.
+var ErrUnsupportedAction = errors.New("unsupported sentry webhook action")
+
+// Payload is a Sentry integration webhook payload.
+type Payload struct {
+	Action string `json:"action"`
+	Data   *Data  `json:"data"`
+}
+
+// Data is the resource specific part of the payload. Event is set for issue alerts, and Issue for issue webhooks.
+type Data struct {
+	Event         *Event `json:"event"`
+	TriggeredRule string `json:"triggered_rule"`
+	Issue         *Issue `json:"issue"`
+}
+
+// Event is the Sentry event that triggered an issue alert.
+type Event struct {
+	EventID     string      `json:"event_id"`
+	IssueID     string      `json:"issue_id"`
+	Project     json.Number `json:"project"`
+	Title       string      `json:"title"`
+	Culprit     string      `json:"culprit"`
+	Message     string      `json:"message"`
+	Level       string      `json:"level"`
+	Environment string      `json:"environment"`
+	Release     string      `json:"release"`
+	Platform    string      `json:"platform"`
+	Datetime    time.Time   `json:"datetime"`
+	WebURL      string      `json:"web_url"`
+	IssueURL    string      `json:"issue_url"`
+	Tags        [][]string  `json:"tags"`
+}
+
+// Issue is a Sentry issue.
+type Issue struct {
+	ID        string    `json:"id"`
+	ShortID   string    `json:"shortId"`
+	Title     string    `json:"title"`
+	Culprit   string    `json:"culprit"`
+	Level     string    `json:"level"`
+	Status    string    `json:"status"`
+	Permalink string    `json:"permalink"`
+	WebURL    string    `json:"web_url"`
+	LastSeen  time.Time `json:"lastSeen"`
+	Project   *Project  `json:"project"`
+}
+
+// Project is a Sentry project.
+type Project struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over RouteKey.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Defaults to the project slug if known, or DefaultRouteKey.
+	RouteKey string
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// Parse decodes a Sentry webhook payload.
+func Parse(data []byte) (*Payload, error) {
+	var payload Payload
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode sentry payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// Convert converts the payload into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - The correlation ID is 'sentry-issue-<issue ID>'.
+//   - The 'resolved' action maps to severity resolved. Otherwise, the event or issue level is used, where 'fatal' maps to panic,
+//     'error' to error, 'warning' to warning, and 'info' and 'debug' to info.
+//   - The header is the event or issue title, and the text is the culprit.
+//   - The project and environment become alert fields, and the link is the issue URL.
+//
+// ErrUnsupportedAction is returned for actions other than triggered, created, resolved and unresolved.
+func Convert(payload *Payload, opts Options) (*types.Alert, error) {
+	if payload == nil || payload.Data == nil {
+		return nil, errors.New("sentry payload is empty")
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	var a *types.Alert
+
+	switch {
+	case payload.Action == ActionTriggered && payload.Data.Event != nil:
+		a = convertEvent(payload.Data.Event, payload.Data.TriggeredRule)
+	case payload.Data.Issue != nil && (payload.Action == ActionCreated || payload.Action == ActionResolved || payload.Action == ActionUnresolved):
+		a = convertIssue(payload.Data.Issue, payload.Action)
+	default:
+		return nil, fmt.Errorf("%w '%s'", ErrUnsupportedAction, payload.Action)
+	}
+
+	if a.CorrelationID == correlationIDPrefix {
+		return nil, errors.New("sentry issue ID is missing")
+	}
+
+	a.Type = "sentry"
+	a.Source = convert.Source("sentry")
+	a.Author = "Sentry"
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+
+	switch {
+	case opts.SlackChannelID != "":
+		a.SlackChannelID = opts.SlackChannelID
+		a.RouteKey = ""
+	case opts.RouteKey != "":
+		a.RouteKey = opts.RouteKey
+	case a.RouteKey == "":
+		a.RouteKey = DefaultRouteKey
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("sentry payload could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+func convertEvent(e *Event, triggeredRule string) *types.Alert {
+	a := types.NewAlert(severity(e.Level))
+
+	environment := convert.FirstNonEmpty(e.Environment, tag(e.Tags, "environment"))
+
+	a.CorrelationID = correlationIDPrefix + e.IssueID
+	a.Header = ":status: " + convert.FirstNonEmpty(e.Title, e.Message, "Sentry issue")
+	a.Text = e.Culprit
+	a.Link = e.WebURL
+	a.Fields = convert.Fields("Project", e.Project.String(), "Environment", environment, "Release", e.Release)
+
+	if !e.Datetime.IsZero() {
+		a.Timestamp = e.Datetime
+	}
+
+	if triggeredRule != "" {
+		a.Footer = "Alert rule: " + triggeredRule
+	}
+
+	a.Metadata["sentryIssueId"] = e.IssueID
+	a.Metadata["sentryEventId"] = e.EventID
+	a.Metadata["environment"] = environment
+
+	return a
+}
+
+func convertIssue(issue *Issue, action string) *types.Alert {
+	sev := severity(issue.Level)
+	if action == ActionResolved {
+		sev = types.AlertResolved
+	}
+
+	a := types.NewAlert(sev)
+
+	a.CorrelationID = correlationIDPrefix + issue.ID
+	a.Header = ":status: " + convert.FirstNonEmpty(issue.Title, issue.ShortID, "Sentry issue")
+	a.Text = issue.Culprit
+	a.Link = convert.FirstNonEmpty(issue.WebURL, issue.Permalink)
+
+	if !issue.LastSeen.IsZero() && sev != types.AlertResolved {
+		a.Timestamp = issue.LastSeen
+	}
+
+	if issue.Project != nil {
+		a.RouteKey = issue.Project.Slug
+		a.Fields = convert.Fields("Project", convert.FirstNonEmpty(issue.Project.Name, issue.Project.Slug))
+	}
+
+	a.Metadata["sentryIssueId"] = issue.ID
+	a.Metadata["sentryShortId"] = issue.ShortID
+
+	return a
+}
+
+func severity(level string) types.AlertSeverity {
+	if strings.EqualFold(strings.TrimSpace(level), "debug") {
+		return types.AlertInfo
+	}
+
+	return convert.Severity(level)
+}
+
+func tag(tags [][]string, key string) string {
+	for _, t := range tags {
+		if len(t) == 2 && t[0] == key {
+			return t[1]
+		}
+	}
+
+	return ""
+}
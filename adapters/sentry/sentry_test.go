@@ -0,0 +1,117 @@
+package sentry_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/sentry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eventAlertJSON = `{
+  "action": "triggered",
+  "data": {
+    "event": {
+      "event_id": "e4874d664c3540c1a32eab185f12c5ab",
+      "issue_id": "1170820242",
+      "project": 1,
+      "title": "ZeroDivisionError: division by zero",
+      "culprit": "billing.invoice in calculate_total",
+      "level": "fatal",
+      "release": "billing@1.2.3",
+      "datetime": "%s",
+      "web_url": "https://sentry.io/organizations/acme/issues/1170820242/events/e4874d664c3540c1a32eab185f12c5ab/",
+      "tags": [["environment", "production"], ["level", "fatal"]]
+    },
+    "triggered_rule": "Notify on new errors"
+  },
+  "installation": {"uuid": "7a485448-a9e2-4c85-8a3c-4f44175783c9"}
+}`
+
+const issueJSON = `{
+  "action": "%s",
+  "data": {
+    "issue": {
+      "id": "1170820242",
+      "shortId": "BILLING-1",
+      "title": "ZeroDivisionError: division by zero",
+      "culprit": "billing.invoice in calculate_total",
+      "level": "error",
+      "status": "resolved",
+      "web_url": "https://sentry.io/organizations/acme/issues/1170820242/",
+      "project": {"id": "1", "name": "Billing", "slug": "billing"}
+    }
+  }
+}`
+
+func TestConvertEventAlert(t *testing.T) {
+	t.Parallel()
+
+	datetime := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+
+	payload, err := sentry.Parse([]byte(fmt.Sprintf(eventAlertJSON, datetime.Format(time.RFC3339))))
+	require.NoError(t, err)
+
+	alert, err := sentry.Convert(payload, sentry.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertPanic, alert.Severity)
+	assert.Equal(t, "sentry-issue-1170820242", alert.CorrelationID)
+	assert.Equal(t, "sentry", alert.RouteKey)
+	assert.Equal(t, ":status: ZeroDivisionError: division by zero", alert.Header)
+	assert.Equal(t, "billing.invoice in calculate_total", alert.Text)
+	assert.Equal(t, "Alert rule: Notify on new errors", alert.Footer)
+	assert.Equal(t, datetime, alert.Timestamp)
+	assert.Equal(t, []*types.Field{
+		{Title: "Project", Value: "1"},
+		{Title: "Environment", Value: "production"},
+		{Title: "Release", Value: "billing@1.2.3"},
+	}, alert.Fields)
+	assert.Contains(t, alert.Link, "/issues/1170820242/")
+}
+
+func TestConvertIssue(t *testing.T) {
+	t.Parallel()
+
+	payload, err := sentry.Parse([]byte(fmt.Sprintf(issueJSON, "created")))
+	require.NoError(t, err)
+
+	created, err := sentry.Convert(payload, sentry.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertError, created.Severity)
+	assert.Equal(t, "sentry-issue-1170820242", created.CorrelationID)
+	assert.Equal(t, "billing", created.RouteKey)
+	assert.Equal(t, []*types.Field{{Title: "Project", Value: "Billing"}}, created.Fields)
+
+	payload, err = sentry.Parse([]byte(fmt.Sprintf(issueJSON, "resolved")))
+	require.NoError(t, err)
+
+	resolved, err := sentry.Convert(payload, sentry.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertResolved, resolved.Severity)
+	assert.Equal(t, created.CorrelationID, resolved.CorrelationID)
+	assert.Equal(t, "C12345678", resolved.SlackChannelID)
+	assert.Empty(t, resolved.RouteKey)
+}
+
+func TestConvertErrors(t *testing.T) {
+	t.Parallel()
+
+	payload, err := sentry.Parse([]byte(fmt.Sprintf(issueJSON, "assigned")))
+	require.NoError(t, err)
+
+	_, err = sentry.Convert(payload, sentry.Options{})
+	require.ErrorIs(t, err, sentry.ErrUnsupportedAction)
+
+	_, err = sentry.Convert(&sentry.Payload{Action: "triggered", Data: &sentry.Data{Event: &sentry.Event{Title: "x"}}}, sentry.Options{})
+	require.EqualError(t, err, "sentry issue ID is missing")
+
+	_, err = sentry.Convert(&sentry.Payload{}, sentry.Options{})
+	require.EqualError(t, err, "sentry payload is empty")
+
+	_, err = sentry.Parse([]byte("{"))
+	require.ErrorContains(t, err, "failed to decode sentry payload")
+}
@@ -0,0 +1,82 @@
+package syslog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ParseJournalEntry parses a journald entry in the JSON export format, as written by 'journalctl -o json'.
+//
+// PRIORITY and SYSLOG_FACILITY map to severity and facility (defaulting to info and user),
+// _HOSTNAME to hostname, SYSLOG_IDENTIFIER (or _COMM) to app name, _PID to process ID,
+// MESSAGE_ID to message ID, _SYSTEMD_UNIT to unit, and __REALTIME_TIMESTAMP to timestamp.
+// Binary MESSAGE values (encoded as arrays of bytes) are supported.
+func ParseJournalEntry(data []byte) (*Message, error) {
+	var entry map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode journald entry: %w", err)
+	}
+
+	if _, ok := entry["MESSAGE"]; !ok {
+		return nil, errors.New("journald entry has no MESSAGE field")
+	}
+
+	msg := &Message{
+		Facility: Facility(1), // user
+		Severity: SeverityInfo,
+		Hostname: journalValue(entry, "_HOSTNAME"),
+		AppName:  journalValue(entry, "SYSLOG_IDENTIFIER"),
+		ProcID:   journalValue(entry, "_PID"),
+		MsgID:    journalValue(entry, "MESSAGE_ID"),
+		Unit:     journalValue(entry, "_SYSTEMD_UNIT"),
+		Text:     journalValue(entry, "MESSAGE"),
+	}
+
+	if msg.AppName == "" {
+		msg.AppName = journalValue(entry, "_COMM")
+	}
+
+	if priority, err := strconv.Atoi(journalValue(entry, "PRIORITY")); err == nil && priority >= 0 && priority <= int(SeverityDebug) {
+		msg.Severity = Severity(priority)
+	}
+
+	if facility, err := strconv.Atoi(journalValue(entry, "SYSLOG_FACILITY")); err == nil && facility >= 0 && facility < len(facilityNames()) {
+		msg.Facility = Facility(facility)
+	}
+
+	if usec, err := strconv.ParseInt(journalValue(entry, "__REALTIME_TIMESTAMP"), 10, 64); err == nil && usec > 0 {
+		msg.Timestamp = time.UnixMicro(usec).UTC()
+	}
+
+	return msg, nil
+}
+
+// journalValue returns the value of a journald field. Journald encodes non-UTF-8 values as arrays of bytes,
+// and fields set more than once as arrays of values, in which case the first value is returned.
+func journalValue(entry map[string]json.RawMessage, key string) string {
+	raw, ok := entry[key]
+	if !ok {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	var b []byte
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return string(b)
+	}
+
+	var values []json.RawMessage
+	if err := json.Unmarshal(raw, &values); err == nil && len(values) > 0 {
+		return journalValue(map[string]json.RawMessage{key: values[0]}, key)
+	}
+
+	return ""
+}
@@ -0,0 +1,111 @@
+package syslog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// Severity is a syslog severity. Lower values are more severe.
+type Severity int
+
+const (
+	// SeverityEmergency means that the system is unusable.
+	SeverityEmergency Severity = iota
+
+	// SeverityAlert means that action must be taken immediately.
+	SeverityAlert
+
+	// SeverityCritical is used for critical conditions.
+	SeverityCritical
+
+	// SeverityError is used for error conditions.
+	SeverityError
+
+	// SeverityWarning is used for warning conditions.
+	SeverityWarning
+
+	// SeverityNotice is used for normal but significant conditions.
+	SeverityNotice
+
+	// SeverityInfo is used for informational messages.
+	SeverityInfo
+
+	// SeverityDebug is used for debug-level messages.
+	SeverityDebug
+)
+
+// severityNames returns the syslog keywords of the severities, indexed by severity.
+func severityNames() []string {
+	return []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+}
+
+// String returns the syslog keyword of the severity, such as 'err', or the number for unknown severities.
+func (s Severity) String() string {
+	if names := severityNames(); s >= 0 && int(s) < len(names) {
+		return names[s]
+	}
+
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// ParseSeverity returns the severity with the specified syslog keyword (case insensitive).
+// The aliases 'emergency', 'panic', 'critical', 'error', 'warn' and 'informational' are also accepted.
+func ParseSeverity(name string) (Severity, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	switch name {
+	case "emergency", "panic":
+		return SeverityEmergency, nil
+	case "critical":
+		return SeverityCritical, nil
+	case "error":
+		return SeverityError, nil
+	case "warn":
+		return SeverityWarning, nil
+	case "informational":
+		return SeverityInfo, nil
+	}
+
+	for i, n := range severityNames() {
+		if n == name {
+			return Severity(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("syslog severity '%s' is not valid, expected one of [%s]", name, strings.Join(severityNames(), ", "))
+}
+
+func (s Severity) alertSeverity() types.AlertSeverity {
+	switch {
+	case s <= SeverityCritical:
+		return types.AlertPanic
+	case s == SeverityError:
+		return types.AlertError
+	case s == SeverityWarning:
+		return types.AlertWarning
+	default:
+		return types.AlertInfo
+	}
+}
+
+// Facility is a syslog facility.
+type Facility int
+
+// facilityNames returns the syslog keywords of the facilities, indexed by facility.
+func facilityNames() []string {
+	return []string{
+		"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news", "uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+		"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+	}
+}
+
+// String returns the syslog keyword of the facility, such as 'daemon', or the number for unknown facilities.
+func (f Facility) String() string {
+	if names := facilityNames(); f >= 0 && int(f) < len(names) {
+		return names[f]
+	}
+
+	return fmt.Sprintf("facility(%d)", int(f))
+}
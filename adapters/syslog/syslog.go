@@ -0,0 +1,319 @@
+// Package syslog converts syslog lines (RFC 5424 and RFC 3164) and journald JSON entries into Slack Manager alerts.
+//
+// It is intended for lightweight host-level forwarding agents. Messages below a configurable minimum severity
+// are rejected with ErrBelowMinSeverity, so that agents can forward only the lines that matter:
+//
+//	msg, err := syslog.ParseLine(line)
+//	alert, err := syslog.Convert(msg, syslog.Options{RouteKey: "hosts", MinSeverity: "err"})
+//	if errors.Is(err, syslog.ErrBelowMinSeverity) {
+//		// Skip the line
+//	}
+package syslog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/internal/convert"
+)
+
+const (
+	// DefaultMinSeverity is the minimum severity of converted messages, unless Options.MinSeverity is set.
+	DefaultMinSeverity = SeverityWarning
+
+	// DefaultAutoResolveSeconds is the auto-resolve delay of converted alerts, unless Options.AutoResolveSeconds is set.
+	DefaultAutoResolveSeconds = 60 * 60
+
+	// DefaultRouteKey is the route key of converted alerts, unless Options.SlackChannelID or Options.RouteKey is set.
+	DefaultRouteKey = "syslog"
+
+	// nilValue is the RFC 5424 NILVALUE, used for absent header fields.
+	nilValue = "-"
+
+	// bom is the UTF-8 byte order mark, which may prefix RFC 5424 messages.
+	bom = "\xef\xbb\xbf"
+)
+
+// ErrBelowMinSeverity is returned by Convert for messages less severe than the minimum severity.
+var ErrBelowMinSeverity = errors.New("syslog message is below the minimum severity")
+
+// Message is a parsed syslog message or journald entry.
+type Message struct {
+	Facility       Facility
+	Severity       Severity
+	Timestamp      time.Time
+	Hostname       string
+	AppName        string
+	ProcID         string
+	MsgID          string
+	StructuredData string
+	Text           string
+
+	// Unit is the systemd unit of journald entries.
+	Unit string
+}
+
+// Options configures the conversion.
+type Options struct {
+	// SlackChannelID is the Slack channel ID or name of the converted alert. Takes precedence over RouteKey.
+	SlackChannelID string
+
+	// RouteKey is the route key of the converted alert. Defaults to DefaultRouteKey.
+	RouteKey string
+
+	// MinSeverity is the name of the minimum severity of converted messages, such as 'warning' or 'err'.
+	// Defaults to DefaultMinSeverity.
+	MinSeverity string
+
+	// AutoResolveSeconds is the auto-resolve delay of the converted alert. Defaults to DefaultAutoResolveSeconds.
+	AutoResolveSeconds int
+}
+
+// ParseLine parses an RFC 5424 or RFC 3164 syslog line, starting with the '<PRI>' part.
+//
+// RFC 3164 timestamps have no year and no time zone. They are interpreted as UTC, in the current year,
+// or in the previous year if that would put the timestamp more than a day in the future.
+func ParseLine(line string) (*Message, error) {
+	line = strings.TrimRight(line, "\r\n")
+
+	pri, rest, err := parsePriority(line)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &Message{
+		Facility: Facility(pri / 8),
+		Severity: Severity(pri % 8),
+	}
+
+	if version, after, ok := strings.Cut(rest, " "); ok && version == "1" {
+		err = parseRFC5424(msg, after)
+	} else {
+		err = parseRFC3164(msg, rest)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// Convert converts the message into a cleaned and validated types.Alert.
+//
+// The mapping is as follows:
+//   - Severities emerg, alert and crit map to panic, err to error, warning to warning, and notice, info and debug to info.
+//   - The hostname becomes the alert host, and the app name the alert author.
+//   - The header is the app name and the first line of the message, and the text is the full message.
+//   - The facility, severity, process ID and systemd unit become alert fields.
+//   - The correlation ID is derived from the hostname, app name, message ID and the normalized message text
+//     (with timestamps, IDs and numbers replaced), so that repeated lines update a single issue.
+//
+// ErrBelowMinSeverity is returned for messages less severe than Options.MinSeverity.
+func Convert(msg *Message, opts Options) (*types.Alert, error) {
+	if msg == nil {
+		return nil, errors.New("syslog message is nil")
+	}
+
+	minSeverity := DefaultMinSeverity
+
+	if opts.MinSeverity != "" {
+		var err error
+
+		if minSeverity, err = ParseSeverity(opts.MinSeverity); err != nil {
+			return nil, err
+		}
+	}
+
+	if msg.Severity > minSeverity {
+		return nil, fmt.Errorf("%w: %s is less severe than %s", ErrBelowMinSeverity, msg.Severity, minSeverity)
+	}
+
+	if opts.AutoResolveSeconds == 0 {
+		opts.AutoResolveSeconds = DefaultAutoResolveSeconds
+	}
+
+	fingerprinter, err := types.NewFingerprinter()
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(msg.Text)
+	firstLine, _, _ := strings.Cut(text, "\n")
+	appName := convert.FirstNonEmpty(msg.AppName, msg.Facility.String())
+
+	a := types.NewAlert(msg.Severity.alertSeverity())
+
+	a.CorrelationID = convert.Hash("syslog", msg.Hostname, msg.AppName, msg.MsgID, fingerprinter.Normalize(text))
+	a.Type = "syslog"
+	a.Source = convert.Source("syslog")
+	a.Author = msg.AppName
+	a.Host = msg.Hostname
+	a.Header = ":status: " + appName + ": " + convert.FirstNonEmpty(firstLine, "(empty message)")
+	a.Text = text
+	a.Fields = convert.Fields("Facility", msg.Facility.String(), "Severity", msg.Severity.String(), "Process ID", msg.ProcID, "Unit", msg.Unit)
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = opts.AutoResolveSeconds
+
+	if !msg.Timestamp.IsZero() {
+		a.Timestamp = msg.Timestamp
+	}
+
+	if msg.MsgID != "" {
+		a.Metadata["msgId"] = msg.MsgID
+	}
+
+	if msg.StructuredData != "" {
+		a.Metadata["structuredData"] = msg.StructuredData
+	}
+
+	if opts.SlackChannelID != "" {
+		a.SlackChannelID = opts.SlackChannelID
+	} else {
+		a.RouteKey = convert.FirstNonEmpty(opts.RouteKey, DefaultRouteKey)
+	}
+
+	a.Clean()
+
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("syslog message could not be converted: %w", err)
+	}
+
+	return a, nil
+}
+
+func parsePriority(line string) (int, string, error) {
+	if !strings.HasPrefix(line, "<") {
+		return 0, "", errors.New("syslog line must start with '<PRI>'")
+	}
+
+	end := strings.IndexByte(line, '>')
+	if end < 2 || end > 4 {
+		return 0, "", errors.New("syslog line has an invalid priority")
+	}
+
+	pri, err := strconv.Atoi(line[1:end])
+	if err != nil || pri < 0 || pri > 191 {
+		return 0, "", fmt.Errorf("syslog priority '%s' is not valid", line[1:end])
+	}
+
+	return pri, line[end+1:], nil
+}
+
+func parseRFC5424(msg *Message, s string) error {
+	parts := strings.SplitN(s, " ", 6)
+	if len(parts) < 6 {
+		return errors.New("rfc5424 syslog line has too few header fields")
+	}
+
+	if parts[0] != nilValue {
+		timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+		if err != nil {
+			return fmt.Errorf("rfc5424 syslog timestamp '%s' is not valid", parts[0])
+		}
+
+		msg.Timestamp = timestamp
+	}
+
+	msg.Hostname = nilToEmpty(parts[1])
+	msg.AppName = nilToEmpty(parts[2])
+	msg.ProcID = nilToEmpty(parts[3])
+	msg.MsgID = nilToEmpty(parts[4])
+
+	sd, text, err := splitStructuredData(parts[5])
+	if err != nil {
+		return err
+	}
+
+	msg.StructuredData = nilToEmpty(sd)
+	msg.Text = strings.TrimPrefix(text, bom)
+
+	return nil
+}
+
+// splitStructuredData splits the RFC 5424 STRUCTURED-DATA part from the message that follows it.
+func splitStructuredData(s string) (string, string, error) {
+	if strings.HasPrefix(s, nilValue) {
+		return nilValue, strings.TrimPrefix(s[1:], " "), nil
+	}
+
+	inElement, inQuotes, escaped := false, false, false
+
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuotes && r == '\\':
+			escaped = true
+		case inElement && r == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && r == '[':
+			inElement = true
+		case !inQuotes && r == ']':
+			inElement = false
+		case !inElement && r == ' ':
+			return s[:i], s[i+1:], nil
+		case !inElement:
+			return "", "", errors.New("rfc5424 syslog structured data is not valid")
+		}
+	}
+
+	if inElement {
+		return "", "", errors.New("rfc5424 syslog structured data is not terminated")
+	}
+
+	return s, "", nil
+}
+
+func parseRFC3164(msg *Message, s string) error {
+	const stampLayout = "Jan _2 15:04:05"
+
+	if len(s) > len(stampLayout) {
+		if timestamp, err := time.Parse(stampLayout, s[:len(stampLayout)]); err == nil {
+			now := time.Now().UTC()
+			msg.Timestamp = timestamp.AddDate(now.Year(), 0, 0)
+
+			if msg.Timestamp.After(now.Add(24 * time.Hour)) {
+				msg.Timestamp = msg.Timestamp.AddDate(-1, 0, 0)
+			}
+
+			s = strings.TrimPrefix(s[len(stampLayout):], " ")
+
+			// The hostname is followed by the tag. Some senders omit the hostname, in which case the first token is the tag.
+			if host, rest, ok := strings.Cut(s, " "); ok && !strings.HasSuffix(host, ":") && !strings.Contains(host, "[") {
+				msg.Hostname = host
+				s = rest
+			}
+		}
+	}
+
+	if tag, text, ok := strings.Cut(s, ": "); ok && tag != "" && !strings.Contains(tag, " ") {
+		msg.AppName, msg.ProcID = splitTag(tag)
+		s = text
+	}
+
+	msg.Text = s
+
+	return nil
+}
+
+// splitTag splits an RFC 3164 tag such as 'sshd[1234]' into app name and process ID.
+func splitTag(tag string) (string, string) {
+	if name, pid, ok := strings.Cut(tag, "["); ok && strings.HasSuffix(pid, "]") {
+		return name, strings.TrimSuffix(pid, "]")
+	}
+
+	return tag, ""
+}
+
+func nilToEmpty(s string) string {
+	if s == nilValue {
+		return ""
+	}
+
+	return s
+}
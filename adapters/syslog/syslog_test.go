@@ -0,0 +1,164 @@
+package syslog_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/adapters/syslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLineRFC5424(t *testing.T) {
+	t.Parallel()
+
+	msg, err := syslog.ParseLine(`<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1234 ID47 [exampleSDID@32473 iut="3" eventSource="Application \"x\"]"] ` + "\xef\xbb\xbf" + "An application event log entry...\n")
+	require.NoError(t, err)
+
+	assert.Equal(t, "local4", msg.Facility.String())
+	assert.Equal(t, syslog.SeverityNotice, msg.Severity)
+	assert.Equal(t, time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC), msg.Timestamp)
+	assert.Equal(t, "mymachine.example.com", msg.Hostname)
+	assert.Equal(t, "evntslog", msg.AppName)
+	assert.Equal(t, "1234", msg.ProcID)
+	assert.Equal(t, "ID47", msg.MsgID)
+	assert.Equal(t, `[exampleSDID@32473 iut="3" eventSource="Application \"x\"]"]`, msg.StructuredData)
+	assert.Equal(t, "An application event log entry...", msg.Text)
+
+	msg, err = syslog.ParseLine(`<34>1 - - su - - - 'su root' failed`)
+	require.NoError(t, err)
+	assert.Equal(t, syslog.SeverityCritical, msg.Severity)
+	assert.True(t, msg.Timestamp.IsZero())
+	assert.Empty(t, msg.Hostname)
+	assert.Empty(t, msg.StructuredData)
+	assert.Equal(t, "'su root' failed", msg.Text)
+}
+
+func TestParseLineRFC3164(t *testing.T) {
+	t.Parallel()
+
+	msg, err := syslog.ParseLine(`<34>Oct 11 22:14:15 mymachine su[230]: 'su root' failed for lonvick on /dev/pts/8`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "auth", msg.Facility.String())
+	assert.Equal(t, syslog.SeverityCritical, msg.Severity)
+	assert.Equal(t, time.October, msg.Timestamp.Month())
+	assert.Equal(t, 22, msg.Timestamp.Hour())
+	assert.Equal(t, "mymachine", msg.Hostname)
+	assert.Equal(t, "su", msg.AppName)
+	assert.Equal(t, "230", msg.ProcID)
+	assert.Equal(t, "'su root' failed for lonvick on /dev/pts/8", msg.Text)
+
+	msg, err = syslog.ParseLine(`<11>Jan  2 03:04:05 kernel: Out of memory`)
+	require.NoError(t, err)
+	assert.Empty(t, msg.Hostname)
+	assert.Equal(t, "kernel", msg.AppName)
+	assert.Equal(t, "Out of memory", msg.Text)
+}
+
+func TestParseLineInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, line := range []string{"", "no priority", "<abc>1 -", "<192>x", "<13>1 not-a-time host app - - - msg", "<13>1 - host app - - [unterminated msg", "<13>1 - host"} {
+		_, err := syslog.ParseLine(line)
+		require.Error(t, err, line)
+	}
+}
+
+func TestParseJournalEntry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Microsecond)
+
+	msg, err := syslog.ParseJournalEntry([]byte(fmt.Sprintf(`{
+  "__REALTIME_TIMESTAMP": "%d",
+  "_HOSTNAME": "web01",
+  "_COMM": "nginx",
+  "_PID": "4242",
+  "_SYSTEMD_UNIT": "nginx.service",
+  "PRIORITY": "3",
+  "SYSLOG_FACILITY": "3",
+  "MESSAGE": [98, 105, 110, 100, 40, 41, 32, 102, 97, 105, 108, 101, 100]
+}`, now.UnixMicro())))
+	require.NoError(t, err)
+
+	assert.Equal(t, syslog.SeverityError, msg.Severity)
+	assert.Equal(t, "daemon", msg.Facility.String())
+	assert.True(t, now.Equal(msg.Timestamp))
+	assert.Equal(t, "web01", msg.Hostname)
+	assert.Equal(t, "nginx", msg.AppName)
+	assert.Equal(t, "4242", msg.ProcID)
+	assert.Equal(t, "nginx.service", msg.Unit)
+	assert.Equal(t, "bind() failed", msg.Text)
+
+	_, err = syslog.ParseJournalEntry([]byte(`{"PRIORITY": "3"}`))
+	require.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	msg, err := syslog.ParseLine(`<11>1 - web01 nginx 4242 - - upstream 10.0.0.12:8080 timed out after 3000ms`)
+	require.NoError(t, err)
+
+	alert, err := syslog.Convert(msg, syslog.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.AlertError, alert.Severity)
+	assert.Equal(t, "web01", alert.Host)
+	assert.Equal(t, "nginx", alert.Author)
+	assert.Equal(t, ":status: nginx: upstream 10.0.0.12:8080 timed out after 3000ms", alert.Header)
+	assert.Equal(t, "syslog", alert.RouteKey)
+	assert.Equal(t, []*types.Field{
+		{Title: "Facility", Value: "user"},
+		{Title: "Severity", Value: "err"},
+		{Title: "Process ID", Value: "4242"},
+	}, alert.Fields)
+
+	other, err := syslog.ParseLine(`<11>1 - web01 nginx 4242 - - upstream 10.0.0.13:8080 timed out after 2500ms`)
+	require.NoError(t, err)
+
+	otherAlert, err := syslog.Convert(other, syslog.Options{SlackChannelID: "C12345678"})
+	require.NoError(t, err)
+	assert.Equal(t, alert.CorrelationID, otherAlert.CorrelationID)
+	assert.Empty(t, otherAlert.RouteKey)
+}
+
+func TestConvertMinSeverity(t *testing.T) {
+	t.Parallel()
+
+	msg, err := syslog.ParseLine(`<14>1 - web01 cron - - - job finished`)
+	require.NoError(t, err)
+
+	_, err = syslog.Convert(msg, syslog.Options{})
+	require.ErrorIs(t, err, syslog.ErrBelowMinSeverity)
+
+	alert, err := syslog.Convert(msg, syslog.Options{MinSeverity: "debug"})
+	require.NoError(t, err)
+	assert.Equal(t, types.AlertInfo, alert.Severity)
+
+	msg.Severity = syslog.SeverityWarning
+
+	_, err = syslog.Convert(msg, syslog.Options{MinSeverity: "error"})
+	require.ErrorIs(t, err, syslog.ErrBelowMinSeverity)
+
+	_, err = syslog.Convert(msg, syslog.Options{MinSeverity: "loud"})
+	require.Error(t, err)
+}
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"} {
+		s, err := syslog.ParseSeverity(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, s.String())
+	}
+
+	s, err := syslog.ParseSeverity(" Critical ")
+	require.NoError(t, err)
+	assert.Equal(t, syslog.SeverityCritical, s)
+	assert.Equal(t, "severity(9)", syslog.Severity(9).String())
+}
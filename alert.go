@@ -11,6 +11,8 @@ import (
 	"strings"
 	"time"
 	"unicode/utf8"
+
+	"github.com/slackmgr/types/textlen"
 )
 
 var (
@@ -21,8 +23,10 @@ var (
 	// IconRegex matches valid Slack icon emojis, on the format ':emoji:'.
 	IconRegex = regexp.MustCompile(fmt.Sprintf(`^:[^:]{1,%d}:$`, MaxIconEmojiLength))
 
-	// SlackMentionRegex matches valid Slack mentions, such as <!here>, <!channel> and <@U12345678>.
-	SlackMentionRegex = regexp.MustCompile(fmt.Sprintf(`^((<!here>)|(<!channel>)|(<@[^>\s]{1,%d}>))$`, MaxMentionLength))
+	// SlackMentionRegex matches valid Slack mentions, such as <!here>, <!channel>, <@U12345678> and user group mentions
+	// with an optional handle, such as <!subteam^S12345678> and <!subteam^S12345678|oncall>.
+	SlackMentionRegex = regexp.MustCompile(fmt.Sprintf(`^((<!here>)|(<!channel>)|(<@[^>\s]{1,%d}>)|(<!subteam\^S[0-9A-Z]{2,%d}(\|[^>|\s]{1,%d})?>))$`,
+		MaxMentionLength, MaxMentionLength, MaxMentionHandleLength))
 )
 
 const (
@@ -33,6 +37,8 @@ const (
 	// Alert field length limits.
 	// These constants define maximum lengths for various alert fields to ensure
 	// compatibility with Slack's API limits and prevent excessive data storage.
+	// The limits of the text fields truncated by Clean are counted in UTF-16 code units, as Slack does, so that
+	// emoji and other characters outside the Basic Multilingual Plane count as two (see the textlen package).
 
 	// MaxSlackChannelIDLength is the maximum length of a Slack channel ID or name.
 	MaxSlackChannelIDLength = 80
@@ -45,6 +51,8 @@ const (
 	MaxFallbackTextLength = 150
 	// MaxTextLength is the maximum length of the alert text (body).
 	MaxTextLength = 10000
+	// MaxOverflowTextLength is the maximum length of the original text captured in OverflowText, when Text is truncated.
+	MaxOverflowTextLength = 100000
 	// MaxAuthorLength is the maximum length of the author field.
 	MaxAuthorLength = 100
 	// MaxHostLength is the maximum length of the host field.
@@ -61,6 +69,8 @@ const (
 	MaxIconEmojiLength = 50
 	// MaxMentionLength is the maximum length of a Slack mention (excluding angle brackets).
 	MaxMentionLength = 20
+	// MaxMentionHandleLength is the maximum length of the handle of a Slack user group mention, as in <!subteam^S12345678|handle>.
+	MaxMentionHandleLength = 80
 	// MaxCorrelationIDLength is the maximum length of the correlation ID.
 	MaxCorrelationIDLength = 500
 
@@ -84,8 +94,12 @@ const (
 	// Webhook limits.
 	// These constants define limits for webhook configurations.
 
-	// MaxWebhookCount is the maximum number of webhooks per alert.
-	MaxWebhookCount = 5
+	// MaxWebhookCount is the maximum number of webhooks per alert (Slack limit: 25 elements across action blocks).
+	MaxWebhookCount = 25
+	// MaxWebhookRowCount is the maximum number of button rows per alert. Each row is rendered as a separate action block.
+	MaxWebhookRowCount = 5
+	// MaxWebhooksPerRow is the maximum number of webhooks in a single button row.
+	MaxWebhooksPerRow = 5
 	// MaxWebhookIDLength is the maximum length of a webhook ID.
 	MaxWebhookIDLength = 100
 	// MaxWebhookURLLength is the maximum length of a webhook URL.
@@ -114,6 +128,20 @@ const (
 	MaxWebhookCheckboxOptionTextLength = 50
 	// MaxCheckboxOptionValueLength is the maximum length of a checkbox option value.
 	MaxCheckboxOptionValueLength = 100
+	// MaxWebhookHeaderCount is the maximum number of custom HTTP headers per webhook.
+	MaxWebhookHeaderCount = 10
+	// MaxWebhookHeaderNameLength is the maximum length of a custom HTTP header name.
+	MaxWebhookHeaderNameLength = 100
+	// MaxWebhookHeaderValueLength is the maximum length of a custom HTTP header value.
+	MaxWebhookHeaderValueLength = 1000
+	// MaxWebhookContentTypeLength is the maximum length of the webhook content type.
+	MaxWebhookContentTypeLength = 100
+	// MaxWebhookTimeoutSeconds is the maximum HTTP request timeout for a webhook.
+	MaxWebhookTimeoutSeconds = 30
+	// MaxWebhookCooldownSeconds is the maximum cooldown between clicks on a webhook button (24 hours).
+	MaxWebhookCooldownSeconds = 86400
+	// MaxWebhookClicksPerHour is the maximum value of the per-hour click limit on a webhook button.
+	MaxWebhookClicksPerHour = 1000
 
 	// Escalation limits.
 	// These constants define limits for escalation configurations.
@@ -140,51 +168,63 @@ type Alert struct {
 	// With a custom correlation ID, you can update both header and text without creating a new issue.
 	CorrelationID string `json:"correlationId"`
 
+	// GlobalIssueKey is an optional workspace-wide key, independent of the Slack channel.
+	// Issues in different channels with the same GlobalIssueKey are cross-referenced in the GlobalIssueIndex,
+	// so that the same underlying incident reported into multiple channels can be found and resolved together.
+	// Maximum length: MaxGlobalIssueKeyLength characters.
+	GlobalIssueKey string `json:"globalIssueKey"`
+
 	// Type is the type of alert, such as 'compliance', 'security' or 'metrics'.
 	// It is primarily used for routing, when the alert RouteKey field is used (rather than SlackChannelID).
 	// This field is optional, and case-insensitive.
 	Type string `json:"type"`
 
 	// Header is the main header (title) of the alert.
-	// It is automatically truncated at MaxHeaderLength characters.
+	// It is automatically truncated at MaxHeaderLength UTF-16 code units.
 	// Include :status: in the header (or text) to have it replaced with the appropriate emoji for the issue severity.
 	// This field is optional, but Header and Text cannot both be empty.
 	Header string `json:"header"`
 
 	// HeaderWhenResolved is the main header (title) of the issue when in the *resolved* state.
-	// It is automatically truncated at MaxHeaderLength characters.
+	// It is automatically truncated at MaxHeaderLength UTF-16 code units.
 	// This field is optional. If unset, the Header field is used for all issue states.
 	HeaderWhenResolved string `json:"headerWhenResolved"`
 
 	// Text is the main text (body) of the alert.
-	// It is automatically truncated at MaxTextLength characters.
+	// It is automatically truncated at MaxTextLength UTF-16 code units.
 	// Include :status: in the text (or header) to have it replaced with the appropriate emoji for the issue severity.
 	// This field is optional, but Header and Text cannot both be empty.
 	Text string `json:"text"`
 
 	// TextWhenResolved is the main text (body) of the alert when in the *resolved* state.
-	// It is automatically truncated at MaxTextLength characters.
+	// It is automatically truncated at MaxTextLength UTF-16 code units.
 	// This field is optional. If unset, the Text field is used for all issue states.
 	TextWhenResolved string `json:"textWhenResolved"`
 
+	// OverflowText is the original Text, captured by CleanWithReport when Text is truncated and CleanOptions.OverflowTextCap
+	// is set, so that the Slack Manager can attach the full content as a thread reply or file snippet instead of losing it.
+	// It is automatically truncated at MaxOverflowTextLength UTF-16 code units.
+	// This field is optional, and is normally set by CleanWithReport rather than by the producer.
+	OverflowText string `json:"overflowText"`
+
 	// FallbackText is the text displayed in Slack notifications.
 	// It should be a short, human-readable summary of the alert, without markdown or line breaks.
-	// It is automatically truncated at MaxFallbackTextLength characters.
+	// It is automatically truncated at MaxFallbackTextLength UTF-16 code units.
 	// This field is optional. If unset, Slack decides what to display in notifications (which may not always be ideal).
 	FallbackText string `json:"fallbackText"`
 
 	// Author is the 'author' of the alert (if relevant), displayed as a context block in the Slack post.
-	// It is automatically truncated at MaxAuthorLength characters.
+	// It is automatically truncated at MaxAuthorLength UTF-16 code units.
 	// This field is optional.
 	Author string `json:"author"`
 
 	// Host is the 'host' on which the alert originated (if any), displayed as a context block in the Slack post.
-	// It is automatically truncated at MaxHostLength characters.
+	// It is automatically truncated at MaxHostLength UTF-16 code units.
 	// This field is optional.
 	Host string `json:"host"`
 
 	// Footer is the 'footer' of the alert, displayed as a context block at the bottom of the Slack post.
-	// It is automatically truncated at MaxFooterLength characters.
+	// It is automatically truncated at MaxFooterLength UTF-16 code units.
 	// This field is optional.
 	Footer string `json:"footer"`
 
@@ -192,6 +232,29 @@ type Alert struct {
 	// This field is optional, but if set, it must be a valid absolute URL, starting with http:// or https://
 	Link string `json:"link"`
 
+	// Source describes the system that emitted the alert, and how it reached the Slack Manager, displayed as a context block in the Slack post.
+	// It is populated automatically by the adapters, and by the client SDK if configured with a source.
+	// This field is optional.
+	Source *AlertSource `json:"source"`
+
+	// TraceID is the W3C Trace Context (OpenTelemetry) trace ID of the operation that caused the alert, as 32 lowercase hex characters.
+	// Use SetTraceFromContext to set it from the current span, and SetTraceLink to deep-link the alert to the trace.
+	// This field is optional.
+	TraceID string `json:"traceId"`
+
+	// SpanID is the W3C Trace Context (OpenTelemetry) span ID of the operation that caused the alert, as 16 lowercase hex characters.
+	// This field is optional, but requires TraceID.
+	SpanID string `json:"spanId"`
+
+	// RunbookURL is an optional link (url) to the runbook of the alert, displayed separately from Link in the Slack post.
+	// Use this field rather than the footer, so that runbooks can be extracted for coverage reports and used in routing.
+	// This field is optional, but if set, it must be a valid absolute URL, starting with http:// or https://
+	RunbookURL string `json:"runbookUrl"`
+
+	// RunbookID is an optional identifier of the runbook of the alert, such as 'RB-123' or 'db/disk-full'.
+	// This field is optional, but if set, it must match RunbookIDRegex.
+	RunbookID string `json:"runbookId"`
+
 	// IssueFollowUpEnabled is a flag that determines if the issue should be automatically resolved after a certain time.
 	// If set to true, the issue will be resolved after AutoResolveSeconds seconds.
 	// Set to false for fire-and-forget alerts, where no follow-up is needed (i.e. no issue tracking).
@@ -212,6 +275,11 @@ type Alert struct {
 	// If unset, the severity is automatically set to 'error'.
 	Severity AlertSeverity `json:"severity"`
 
+	// Priority is the priority (urgency) of the alert, such as 'p1' (most urgent) to 'p5' (least urgent).
+	// Unlike Severity, which describes the condition, the priority drives how aggressively people are notified.
+	// This field is optional, and case-insensitive. If set, the value must be one of the predefined AlertPriority constants.
+	Priority AlertPriority `json:"priority"`
+
 	// SlackChannelID is the ID of the Slack channel where the alert should be posted.
 	// Slack channel names are also accepted, and are automatically converted to channel IDs by the API.
 	// The value must be an existing channel ID or name, and the Slack Manager integration must have been added to the channel.
@@ -228,15 +296,30 @@ type Alert struct {
 	RouteKey string `json:"routeKey"`
 
 	// Username is the username that the alert should be posted as in Slack.
+	// It is automatically truncated at MaxUsernameLength UTF-16 code units.
 	// This field is optional. If omitted, the alert is posted as the default bot user.
 	Username string `json:"username"`
 
-	// IconEmoji is the emoji that the alert should be posted with in Slack, on the format ':emoji:'.
+	// IconEmoji is the emoji that the alert should be posted with in Slack, on the format ':emoji:', or a unicode emoji such as '🔥'.
 	IconEmoji string `json:"iconEmoji"`
 
 	// Fields are rendered in a compact format that allows for 2 columns of side-by-side text.
 	Fields []*Field `json:"fields"`
 
+	// Chart is an optional chart rendered into the Slack post, based on a numeric series in the alert metadata.
+	// The chart is only rendered if the Slack Manager has a ChartRenderer registered.
+	Chart *ChartSpec `json:"chart"`
+
+	// SLOImpact describes the impact of the alert on an SLO and its error budget, such as the burn rate of SLO burn
+	// rate alerts, displayed as a context block in the Slack post.
+	// This field is optional.
+	SLOImpact *SLOImpact `json:"sloImpact"`
+
+	// Attachments are files attached to the Slack post, such as log excerpts, uploaded by the Slack Manager as file snippets.
+	// Long content belongs in an attachment rather than in the message body.
+	// Maximum of MaxAttachmentCount attachments allowed.
+	Attachments []*AlertAttachment `json:"attachments"`
+
 	// NotificationDelaySeconds is the number of seconds to wait before creating an actual Slack post.
 	// If the issue is resolved before the delay is over, no Slack post is created for the issue.
 	// This is useful for issues that may be resolved quickly, to avoid unnecessary notifications.
@@ -247,17 +330,41 @@ type Alert struct {
 	// An archived issue can never be re-opened, and any new alerts with the same CorrelationID will generate a new issue and new Slack post.
 	ArchivingDelaySeconds int `json:"archivingDelaySeconds"`
 
+	// DeliverAt is an optional time before which the alert is not evaluated at all by the Slack Manager.
+	// Unlike NotificationDelaySeconds, the alert does not create or update any issue until this time, which makes
+	// it useful for follow-up reminders and scheduled reports. A zero value or a time in the past means immediate delivery.
+	// Must be within MaxDeliverAtHorizon from the current time.
+	DeliverAt time.Time `json:"deliverAt"`
+
+	// ExpiresAfterSeconds is the optional time to live of the alert, in seconds from Timestamp (or from DeliverAt, if later).
+	// Alerts received from the queue after they expired are handled according to ExpirationAction, so that stale alerts
+	// are not posted hours late during outages. The value must be between 0 (never expires) and MaxExpiresAfterSeconds.
+	ExpiresAfterSeconds int `json:"expiresAfterSeconds"`
+
+	// ExpirationAction is the action taken on expired alerts, 'drop' or 'downgrade'. Defaults to 'drop'.
+	ExpirationAction ExpirationAction `json:"expirationAction"`
+
 	// Escalation defines a list of escalation points for this alert's issue.
 	// Each escalation can increase severity, add Slack mentions, or move the issue to a different channel after a specified delay.
 	// Escalations are sorted by DelaySeconds and triggered in order if the issue remains unresolved.
 	// Maximum of MaxEscalationCount escalations allowed.
 	Escalation []*Escalation `json:"escalation"`
 
+	// NotificationPolicy is an optional policy controlling who is mentioned when the issue is created and escalated,
+	// whether the resolution is notified, and when mentions are held back (quiet hours).
+	NotificationPolicy *NotificationPolicy `json:"notificationPolicy"`
+
 	// IgnoreIfTextContains is a list of substrings that, if found in the alert text, will cause the alert to be ignored.
 	// This is useful for filtering out known noise or false positives.
 	// Maximum of MaxIgnoreIfTextContainsCount items, each up to MaxIgnoreIfTextContainsLength characters.
 	IgnoreIfTextContains []string `json:"ignoreIfTextContains"`
 
+	// IgnoreRules is a list of rules that, if any of them matches, will cause the alert to be ignored.
+	// Unlike IgnoreIfTextContains, rules can target the header, text, field titles and values, and metadata values,
+	// using contains, equals, prefix or regex comparisons.
+	// Maximum of MaxIgnoreRuleCount rules.
+	IgnoreRules []*IgnoreRule `json:"ignoreRules"`
+
 	// Webhooks defines interactive buttons that appear on the Slack post.
 	// Each webhook triggers an HTTP POST to the specified URL when clicked.
 	// Webhooks can include confirmation dialogs, input forms, and access level restrictions.
@@ -267,18 +374,25 @@ type Alert struct {
 	// Metadata is an arbitrary key-value map for storing custom data with the alert.
 	// This data is passed through to webhook payloads and can be used for tracking or correlation purposes.
 	// The Slack Manager does not interpret this data.
+	// Maximum of MaxMetadataKeyCount keys, nested at most MaxMetadataDepth levels deep, and MaxMetadataSize bytes serialized as JSON.
+	// CleanWithReport truncates or drops the values exceeding these limits (see CleanOptions.MetadataLimits),
+	// and Validate rejects them otherwise.
 	Metadata map[string]any `json:"metadata"`
 
+	// RejectionTarget is an optional producer-owned Slack channel or callback URL, where notices are sent
+	// if the alert is rejected or dropped after being accepted (see RejectionNotice).
+	RejectionTarget *RejectionTarget `json:"rejectionTarget"`
+
 	// Deprecated: FailOnRateLimitError is no longer in use.
 	FailOnRateLimitError bool `json:"failOnRateLimitError"`
 }
 
 // Field is an alert field.
 type Field struct {
-	// Title is the title of the field. It is automatically truncated at MaxFieldTitleLength characters.
+	// Title is the title of the field. It is automatically truncated at MaxFieldTitleLength UTF-16 code units.
 	Title string `json:"title"`
 
-	// Value is the value of the field. It is automatically truncated at MaxFieldValueLength characters.
+	// Value is the value of the field. It is automatically truncated at MaxFieldValueLength UTF-16 code units.
 	Value string `json:"value"`
 }
 
@@ -292,6 +406,8 @@ type Escalation struct {
 	DelaySeconds int `json:"delaySeconds"`
 
 	// SlackMentions is a list of Slack mentions that should be added to the Slack post when the escalation is triggered.
+	// Mention aliases on the format 'alias:name' are expanded by the Slack Manager (see MentionAliasRegex).
+	// Mentions already present in a previous escalation point are removed by Clean.
 	SlackMentions []string `json:"slackMentions"`
 
 	// MoveToChannel is the ID or name of the Slack channel where the alert should be moved when the escalation is triggered.
@@ -325,6 +441,13 @@ type Webhook struct {
 	// Maximum length: MaxWebhookButtonTextLength characters.
 	ButtonText string `json:"buttonText"`
 
+	// ButtonType determines what happens when the button is clicked.
+	// Valid values are defined by WebhookButtonType constants.
+	// If empty, the button is a callback button. Link buttons open the URL in the browser, and the URL must be
+	// an HTTP(S) URL. Fields related to requests and callbacks (such as payload, inputs and confirmation text)
+	// are not allowed for link buttons.
+	ButtonType WebhookButtonType `json:"buttonType"`
+
 	// ButtonStyle determines the visual appearance of the button in Slack.
 	// Valid values are defined by WebhookButtonStyle constants.
 	// If empty, the default Slack button style is used.
@@ -354,6 +477,77 @@ type Webhook struct {
 	// Selected values are included in the webhook payload.
 	// Maximum of MaxWebhookCheckboxInputCount inputs.
 	CheckboxInput []*WebhookCheckboxInput `json:"checkboxInput"`
+
+	// Method is the HTTP method used for HTTP webhooks.
+	// Valid values are defined by WebhookMethod constants.
+	// If empty, POST is used. The field is ignored for custom webhook handlers.
+	Method WebhookMethod `json:"method"`
+
+	// Headers are custom HTTP headers sent with HTTP webhooks, such as authentication headers.
+	// Hop-by-hop headers and headers managed by the HTTP client (Host, Content-Length, Content-Type) are not allowed,
+	// nor are names differing only in case. Maximum of MaxWebhookHeaderCount headers. The field is ignored for custom webhook handlers.
+	Headers map[string]string `json:"headers"`
+
+	// ContentType is the Content-Type of the HTTP webhook request body.
+	// If empty, 'application/json' is used. The field is ignored for custom webhook handlers.
+	// Maximum length: MaxWebhookContentTypeLength characters.
+	ContentType string `json:"contentType"`
+
+	// TimeoutSeconds is the HTTP request timeout for HTTP webhooks.
+	// If 0, the Slack Manager default timeout is used.
+	// Maximum value: MaxWebhookTimeoutSeconds.
+	TimeoutSeconds int `json:"timeoutSeconds"`
+
+	// HTTPTarget is the name of an HTTP target configured in the webhook dispatcher, defining the proxy, TLS settings
+	// (such as a client certificate for mTLS) and timeout of HTTP webhook requests. If empty, requests are sent directly.
+	// Must match HTTPTargetNameRegex. The field is ignored for custom webhook handlers.
+	HTTPTarget string `json:"httpTarget"`
+
+	// RetryPolicy defines how failed HTTP webhook requests are retried.
+	// If nil, failed requests are not retried. The field is ignored for custom webhook handlers.
+	RetryPolicy *WebhookRetryPolicy `json:"retryPolicy"`
+
+	// CooldownSeconds is the minimum number of seconds between two clicks on this webhook button, in the same Slack post.
+	// Clicks within the cooldown period are rejected, which protects against accidental double-clicks.
+	// If 0, there is no cooldown. Maximum value: MaxWebhookCooldownSeconds.
+	CooldownSeconds int `json:"cooldownSeconds"`
+
+	// MaxClicksPerHour is the maximum number of clicks on this webhook button, in the same Slack post, during any one hour window.
+	// If 0, there is no limit. Maximum value: MaxWebhookClicksPerHour.
+	MaxClicksPerHour int `json:"maxClicksPerHour"`
+
+	// SingleFlight declares that the webhook action is not idempotent, and that concurrent invocations for the same
+	// lock key must not run in parallel. The Slack Manager rejects a click while a previous invocation for the same
+	// key is in progress. External handlers may use the same key to serialize or reject invocations from other sources.
+	SingleFlight bool `json:"singleFlight"`
+
+	// LockKey is the key used to guard concurrent invocations when SingleFlight is true.
+	// Webhooks in different alerts with the same lock key (such as 'rollback:payments-api') are guarded together.
+	// If empty, invocations are guarded per webhook button and Slack post. Requires SingleFlight.
+	// Maximum length: MaxWebhookLockKeyLength characters.
+	LockKey string `json:"lockKey"`
+
+	// Row is the zero-based index of the button row where this webhook button is shown.
+	// Each row is rendered as a separate action block, in increasing row order.
+	// Must be between 0 and MaxWebhookRowCount-1, with at most MaxWebhooksPerRow webhooks in each row.
+	Row int `json:"row"`
+
+	// AllowedUserIDs restricts the webhook button to the specified Slack users, in addition to AccessLevel.
+	// IDs must match SlackUserIDRegex. The mention format '<@U12345678>' is also accepted, and converted to an ID by Clean.
+	// If both AllowedUserIDs and AllowedGroupIDs are empty, there is no such restriction.
+	// Maximum of MaxWebhookAllowedUserCount items.
+	AllowedUserIDs []string `json:"allowedUserIds"`
+
+	// AllowedGroupIDs restricts the webhook button to members of the specified Slack user groups, in addition to AccessLevel.
+	// IDs must match SlackUserGroupIDRegex. The mention format '<!subteam^S12345678>' is also accepted, and converted to an ID by Clean.
+	// Maximum of MaxWebhookAllowedGroupCount items.
+	AllowedGroupIDs []string `json:"allowedGroupIds"`
+
+	// Steps defines additional modal pages, shown in order after the page with PlainTextInput and CheckboxInput.
+	// Input IDs must be unique among all inputs in the webhook, including the inputs in all steps.
+	// User-entered values are available per step in the webhook callback.
+	// Maximum of MaxWebhookFormStepCount steps.
+	Steps []*WebhookFormStep `json:"steps"`
 }
 
 // WebhookPlainTextInput represents a text input field in a webhook's modal dialog.
@@ -463,13 +657,28 @@ func (a *Alert) UniqueID() string {
 // It trims whitespace, normalizes case where appropriate, truncates fields that exceed maximum lengths,
 // and applies default values for empty or invalid fields (e.g., sets Severity to 'error' if empty).
 // This method should be called before validation to ensure consistent data.
+// Metadata exceeding the metadata limits is left as is, and fails validation. Use CleanWithReport to truncate
+// or drop it with a report of the changed keys, or to capture the original text when it is truncated.
 func (a *Alert) Clean() {
+	a.clean(CleanOptions{}, nil)
+}
+
+func (a *Alert) clean(opts CleanOptions, report *CleanReport) {
+	truncate := func(name, s string, maxLength int) string {
+		truncated := truncateText(s, maxLength)
+		if report != nil && truncated != s {
+			report.TruncatedFields = append(report.TruncatedFields, name)
+		}
+
+		return truncated
+	}
+
 	if time.Since(a.Timestamp) > MaxTimestampAge {
 		a.Timestamp = time.Now()
 	}
 
 	a.Type = strings.ToLower(strings.TrimSpace(a.Type))
-	a.SlackChannelID = strings.ToUpper(strings.TrimSpace(a.SlackChannelID))
+	a.SlackChannelID = NormalizeChannel(a.SlackChannelID)
 	a.RouteKey = strings.ToLower(strings.TrimSpace(a.RouteKey))
 	a.Header = strings.ReplaceAll(strings.TrimSpace(a.Header), "\n", " ")
 	a.HeaderWhenResolved = strings.ReplaceAll(strings.TrimSpace(a.HeaderWhenResolved), "\n", " ")
@@ -478,17 +687,21 @@ func (a *Alert) Clean() {
 	a.FallbackText = strings.TrimSpace(strings.ReplaceAll(a.FallbackText, ":status:", ""))
 	a.FallbackText = strings.ReplaceAll(a.FallbackText, "\n", " ")
 	a.CorrelationID = strings.TrimSpace(a.CorrelationID)
+	a.GlobalIssueKey = strings.TrimSpace(a.GlobalIssueKey)
 	a.Username = strings.TrimSpace(a.Username)
 	a.Author = strings.TrimSpace(a.Author)
 	a.Host = strings.TrimSpace(a.Host)
 	a.Link = strings.TrimSpace(a.Link)
+	a.TraceID = strings.ToLower(strings.TrimSpace(a.TraceID))
+	a.SpanID = strings.ToLower(strings.TrimSpace(a.SpanID))
+	a.RunbookURL = strings.TrimSpace(a.RunbookURL)
+	a.RunbookID = strings.TrimSpace(a.RunbookID)
 	a.Footer = strings.TrimSpace(a.Footer)
 	a.IconEmoji = strings.ToLower(strings.TrimSpace(a.IconEmoji))
 	a.Severity = AlertSeverity(strings.ToLower(strings.TrimSpace(string(a.Severity))))
+	a.Priority = AlertPriority(strings.ToLower(strings.TrimSpace(string(a.Priority))))
 
-	if utf8.RuneCountInString(a.FallbackText) > MaxFallbackTextLength {
-		a.FallbackText = truncateString(a.FallbackText, MaxFallbackTextLength-3) + "..."
-	}
+	a.FallbackText = truncate("fallbackText", a.FallbackText, MaxFallbackTextLength)
 
 	if a.Severity == "" || a.Severity == "critical" {
 		a.Severity = AlertError
@@ -498,54 +711,66 @@ func (a *Alert) Clean() {
 		a.ArchivingDelaySeconds = 0
 	}
 
+	if !a.DeliverAt.IsZero() {
+		a.DeliverAt = a.DeliverAt.UTC()
+	}
+
 	if a.NotificationDelaySeconds < 0 {
 		a.NotificationDelaySeconds = 0
 	}
 
-	// Max length in the Slack API is 150, see https://api.slack.com/reference/block-kit/blocks#header
-	// We also need to leave some space for the :status: emoji to be replaced with something a bit longer by the Slack Manager
-	if utf8.RuneCountInString(a.Header) > MaxHeaderLength {
-		a.Header = strings.TrimSpace(truncateString(a.Header, MaxHeaderLength-3)) + "..."
+	if a.ExpiresAfterSeconds < 0 {
+		a.ExpiresAfterSeconds = 0
 	}
 
-	if utf8.RuneCountInString(a.HeaderWhenResolved) > MaxHeaderLength {
-		a.HeaderWhenResolved = strings.TrimSpace(truncateString(a.HeaderWhenResolved, MaxHeaderLength-3)) + "..."
+	a.ExpirationAction = ExpirationAction(strings.ToLower(strings.TrimSpace(string(a.ExpirationAction))))
+
+	if a.ExpirationAction == "" && a.ExpiresAfterSeconds > 0 {
+		a.ExpirationAction = ExpirationActionDrop
 	}
 
-	a.Text = shortenAlertTextIfNeeded(a.Text)
-	a.TextWhenResolved = shortenAlertTextIfNeeded(a.TextWhenResolved)
+	// Max length in the Slack API is 150, see https://api.slack.com/reference/block-kit/blocks#header
+	// We also need to leave some space for the :status: emoji to be replaced with something a bit longer by the Slack Manager
+	a.Header = truncate("header", a.Header, MaxHeaderLength)
+	a.HeaderWhenResolved = truncate("headerWhenResolved", a.HeaderWhenResolved, MaxHeaderLength)
+	a.Author = truncate("author", a.Author, MaxAuthorLength)
+	a.Host = truncate("host", a.Host, MaxHostLength)
+	a.Username = truncate("username", a.Username, MaxUsernameLength)
+	a.Footer = truncate("footer", a.Footer, MaxFooterLength)
+
+	if text := shortenAlertTextIfNeeded(a.Text); text != a.Text {
+		if report != nil {
+			report.TruncatedFields = append(report.TruncatedFields, "text")
+		}
 
-	if utf8.RuneCountInString(a.Author) > MaxAuthorLength {
-		a.Author = strings.TrimSpace(truncateString(a.Author, MaxAuthorLength-3)) + "..."
-	}
+		if opts.OverflowTextCap > 0 {
+			a.OverflowText = textlen.Truncate(a.Text, min(opts.OverflowTextCap, MaxOverflowTextLength), textlen.UTF16)
 
-	if utf8.RuneCountInString(a.Host) > MaxHostLength {
-		a.Host = strings.TrimSpace(truncateString(a.Host, MaxHostLength-3)) + "..."
-	}
+			if report != nil {
+				report.OverflowText = a.OverflowText
+			}
+		}
 
-	if utf8.RuneCountInString(a.Username) > MaxUsernameLength {
-		a.Username = strings.TrimSpace(truncateString(a.Username, MaxUsernameLength-3)) + "..."
+		a.Text = text
 	}
 
-	if utf8.RuneCountInString(a.Footer) > MaxFooterLength {
-		a.Footer = strings.TrimSpace(truncateString(a.Footer, MaxFooterLength-3)) + "..."
+	if text := shortenAlertTextIfNeeded(a.TextWhenResolved); text != a.TextWhenResolved {
+		if report != nil {
+			report.TruncatedFields = append(report.TruncatedFields, "textWhenResolved")
+		}
+
+		a.TextWhenResolved = text
 	}
 
-	for _, field := range a.Fields {
+	a.OverflowText = textlen.Truncate(a.OverflowText, MaxOverflowTextLength, textlen.UTF16)
+
+	for i, field := range a.Fields {
 		if field == nil {
 			continue
 		}
 
-		field.Title = strings.TrimSpace(field.Title)
-		field.Value = strings.TrimSpace(field.Value)
-
-		if utf8.RuneCountInString(field.Title) > MaxFieldTitleLength {
-			field.Title = strings.TrimSpace(truncateString(field.Title, MaxFieldTitleLength-3)) + "..."
-		}
-
-		if utf8.RuneCountInString(field.Value) > MaxFieldValueLength {
-			field.Value = strings.TrimSpace(truncateString(field.Value, MaxFieldValueLength-3)) + "..."
-		}
+		field.Title = truncate(fmt.Sprintf("fields[%d].title", i), strings.TrimSpace(field.Title), MaxFieldTitleLength)
+		field.Value = truncate(fmt.Sprintf("fields[%d].value", i), strings.TrimSpace(field.Value), MaxFieldValueLength)
 	}
 
 	for _, hook := range a.Webhooks {
@@ -557,29 +782,43 @@ func (a *Alert) Clean() {
 		hook.ButtonText = strings.TrimSpace(hook.ButtonText)
 		hook.URL = strings.TrimSpace(hook.URL)
 		hook.ConfirmationText = strings.TrimSpace(hook.ConfirmationText)
+		hook.ButtonType = WebhookButtonType(strings.ToLower(strings.TrimSpace(string(hook.ButtonType))))
+		hook.Method = WebhookMethod(strings.ToUpper(strings.TrimSpace(string(hook.Method))))
+		hook.ContentType = strings.TrimSpace(hook.ContentType)
+		hook.HTTPTarget = strings.TrimSpace(hook.HTTPTarget)
+		hook.LockKey = strings.TrimSpace(hook.LockKey)
+		hook.Headers = cleanWebhookHeaders(hook.Headers)
+		hook.AllowedUserIDs = cleanWebhookAllowedIDs(hook.AllowedUserIDs)
+		hook.AllowedGroupIDs = cleanWebhookAllowedIDs(hook.AllowedGroupIDs)
 
 		if hook.ButtonStyle == "default" {
 			hook.ButtonStyle = ""
 		}
 
-		for _, input := range hook.PlainTextInput {
-			if input == nil {
-				continue
-			}
+		cleanWebhookInputs(hook.PlainTextInput, hook.CheckboxInput)
+		cleanWebhookFormSteps(hook.Steps)
+	}
 
-			input.ID = strings.TrimSpace(input.ID)
-			input.Description = strings.TrimSpace(input.Description)
-			input.InitialValue = strings.TrimSpace(input.InitialValue)
-		}
+	a.Source.Clean()
+	a.Chart.Clean()
+	a.SLOImpact.Clean()
 
-		for _, input := range hook.CheckboxInput {
-			if input == nil {
-				continue
-			}
+	for _, at := range a.Attachments {
+		at.Clean()
+	}
 
-			input.ID = strings.TrimSpace(input.ID)
-			input.Label = strings.TrimSpace(input.Label)
-		}
+	a.NotificationPolicy.Clean()
+
+	// Metadata is only cleaned with a report, so that dropped and truncated keys are never silent.
+	if report != nil {
+		a.cleanMetadata(opts.MetadataLimits, report)
+	}
+
+	cleanIgnoreRules(a.IgnoreRules)
+
+	if a.RejectionTarget != nil {
+		a.RejectionTarget.SlackChannelID = NormalizeChannel(a.RejectionTarget.SlackChannelID)
+		a.RejectionTarget.CallbackURL = strings.TrimSpace(a.RejectionTarget.CallbackURL)
 	}
 
 	if len(a.Escalation) > 0 {
@@ -599,12 +838,14 @@ func (a *Alert) Clean() {
 			}
 
 			e.Severity = AlertSeverity(strings.ToLower(strings.TrimSpace(string(e.Severity))))
-			e.MoveToChannel = strings.ToUpper(strings.TrimSpace(e.MoveToChannel))
+			e.MoveToChannel = NormalizeChannel(e.MoveToChannel)
 
 			for i, mention := range e.SlackMentions {
 				e.SlackMentions[i] = strings.TrimSpace(mention)
 			}
 		}
+
+		dedupeEscalationMentions(a.Escalation)
 	}
 }
 
@@ -630,14 +871,30 @@ func (a *Alert) Validate() error {
 		return err
 	}
 
+	if err := a.ValidateTrace(); err != nil {
+		return err
+	}
+
+	if err := a.ValidateRunbook(); err != nil {
+		return err
+	}
+
 	if err := a.ValidateSeverity(); err != nil {
 		return err
 	}
 
+	if err := a.ValidatePriority(); err != nil {
+		return err
+	}
+
 	if err := a.ValidateCorrelationID(); err != nil {
 		return err
 	}
 
+	if err := a.ValidateGlobalIssueKey(); err != nil {
+		return err
+	}
+
 	if err := a.ValidateAutoResolve(); err != nil {
 		return err
 	}
@@ -646,6 +903,26 @@ func (a *Alert) Validate() error {
 		return err
 	}
 
+	if err := a.ValidateSource(); err != nil {
+		return err
+	}
+
+	if err := a.ValidateChart(); err != nil {
+		return err
+	}
+
+	if err := a.ValidateSLOImpact(); err != nil {
+		return err
+	}
+
+	if err := a.ValidateAttachments(); err != nil {
+		return err
+	}
+
+	if err := a.ValidateMetadata(); err != nil {
+		return err
+	}
+
 	if err := a.ValidateWebhooks(); err != nil {
 		return err
 	}
@@ -654,7 +931,27 @@ func (a *Alert) Validate() error {
 		return err
 	}
 
-	return a.ValidateIgnoreIfTextContains()
+	if err := a.ValidateNotificationPolicy(); err != nil {
+		return err
+	}
+
+	if err := a.ValidateDeliverAt(); err != nil {
+		return err
+	}
+
+	if err := a.ValidateExpiration(); err != nil {
+		return err
+	}
+
+	if err := a.ValidateRejectionTarget(); err != nil {
+		return err
+	}
+
+	if err := a.ValidateIgnoreIfTextContains(); err != nil {
+		return err
+	}
+
+	return a.ValidateIgnoreRules()
 }
 
 // ValidateSlackChannelIDAndRouteKey validates that SlackChannelID and RouteKey are valid, if set.
@@ -685,13 +982,14 @@ func (a *Alert) ValidateHeaderAndText() error {
 	return nil
 }
 
-// ValidateIcon validates that IconEmoji, if set, matches the expected Slack emoji format ':emoji:'.
+// ValidateIcon validates that IconEmoji, if set, matches the expected Slack emoji format ':emoji:', or is a unicode emoji.
+// Use ValidateIconContext to verify that the emoji exists in the workspace.
 func (a *Alert) ValidateIcon() error {
 	if a.IconEmoji == "" {
 		return nil
 	}
 
-	if !IconRegex.MatchString(a.IconEmoji) {
+	if !IconRegex.MatchString(a.IconEmoji) && !IsUnicodeEmoji(a.IconEmoji) {
 		return fmt.Errorf("iconEmoji '%s' is not valid", a.IconEmoji)
 	}
 
@@ -725,6 +1023,15 @@ func (a *Alert) ValidateSeverity() error {
 	return nil
 }
 
+// ValidatePriority validates that Priority, if set, is one of the allowed AlertPriority values.
+func (a *Alert) ValidatePriority() error {
+	if a.Priority != "" && !PriorityIsValid(a.Priority) {
+		return fmt.Errorf("priority '%s' is not valid, expected one of [%s]", a.Priority, strings.Join(ValidPriorities(), ", "))
+	}
+
+	return nil
+}
+
 // ValidateCorrelationID validates that CorrelationID, if set, does not exceed MaxCorrelationIDLength.
 func (a *Alert) ValidateCorrelationID() error {
 	if a.CorrelationID == "" {
@@ -738,6 +1045,15 @@ func (a *Alert) ValidateCorrelationID() error {
 	return nil
 }
 
+// ValidateGlobalIssueKey validates that GlobalIssueKey, if set, does not exceed MaxGlobalIssueKeyLength.
+func (a *Alert) ValidateGlobalIssueKey() error {
+	if len(a.GlobalIssueKey) > MaxGlobalIssueKeyLength {
+		return fmt.Errorf("globalIssueKey is too long, expected length <=%d", MaxGlobalIssueKeyLength)
+	}
+
+	return nil
+}
+
 // ValidateAutoResolve validates that AutoResolveSeconds is within the allowed range
 // when IssueFollowUpEnabled is true.
 func (a *Alert) ValidateAutoResolve() error {
@@ -785,6 +1101,32 @@ func (a *Alert) ValidateFields() error {
 	return nil
 }
 
+// ValidateChart validates the chart spec, if set.
+// The metadata series itself is not validated, since it may be populated after the alert is created.
+func (a *Alert) ValidateChart() error {
+	if a.Chart == nil {
+		return nil
+	}
+
+	if !ChartTypeIsValid(a.Chart.Type) {
+		return fmt.Errorf("chart.type '%s' is not valid, expected one of [%s]", a.Chart.Type, strings.Join(ValidChartTypes(), ", "))
+	}
+
+	if a.Chart.SeriesFromMetadataKey == "" {
+		return errors.New("chart.seriesFromMetadataKey is required")
+	}
+
+	if len(a.Chart.SeriesFromMetadataKey) > MaxChartMetadataKeyLength {
+		return fmt.Errorf("chart.seriesFromMetadataKey is too long, expected length <=%d", MaxChartMetadataKeyLength)
+	}
+
+	if utf8.RuneCountInString(a.Chart.Title) > MaxChartTitleLength {
+		return fmt.Errorf("chart.title is too long, expected length <=%d", MaxChartTitleLength)
+	}
+
+	return nil
+}
+
 // ValidateWebhooks validates all webhooks in the alert.
 // It checks that the webhook count is within limits, all required fields are present,
 // URLs are valid, IDs are unique, and all nested inputs are properly configured.
@@ -798,6 +1140,7 @@ func (a *Alert) ValidateWebhooks() error {
 	}
 
 	webhookIDs := make(map[string]struct{})
+	rowCounts := make(map[int]int)
 
 	for index, hook := range a.Webhooks {
 		if hook == nil {
@@ -852,6 +1195,16 @@ func (a *Alert) ValidateWebhooks() error {
 			return fmt.Errorf("webhook[%d].confirmationText is too long, expected length <=%d", index, MaxWebhookConfirmationTextLength)
 		}
 
+		if hook.ButtonType != "" && !WebhookButtonTypeIsValid(hook.ButtonType) {
+			return fmt.Errorf("webhook[%d].buttonType '%s' is not valid, expected empty or one of [%s]", index, hook.ButtonType, strings.Join(ValidWebhookButtonTypes(), ", "))
+		}
+
+		if hook.ButtonType == WebhookButtonTypeLink {
+			if err := validateWebhookLinkButton(index, hook); err != nil {
+				return err
+			}
+		}
+
 		if hook.ButtonStyle != "" && !WebhookButtonStyleIsValid(hook.ButtonStyle) {
 			return fmt.Errorf("webhook[%d].buttonStyle '%s' is not valid, expected empty or one of [%s]", index, hook.ButtonStyle, strings.Join(ValidWebhookButtonStyles(), ", "))
 		}
@@ -864,123 +1217,194 @@ func (a *Alert) ValidateWebhooks() error {
 			return fmt.Errorf("webhook[%d].displayMode '%s' is not valid, expected empty or one of [%s]", index, hook.DisplayMode, strings.Join(ValidWebhookDisplayModes(), ", "))
 		}
 
+		if err := validateWebhookAllowedIDs(index, hook); err != nil {
+			return err
+		}
+
+		if err := validateWebhookHTTPRequest(index, hook); err != nil {
+			return err
+		}
+
+		if err := validateWebhookRetryPolicy(index, hook.RetryPolicy); err != nil {
+			return err
+		}
+
+		if err := validateWebhookConcurrency(index, hook); err != nil {
+			return err
+		}
+
+		if hook.Row < 0 || hook.Row >= MaxWebhookRowCount {
+			return fmt.Errorf("webhook[%d].row %d is not valid, expected value between 0 and %d", index, hook.Row, MaxWebhookRowCount-1)
+		}
+
+		rowCounts[hook.Row]++
+
+		if rowCounts[hook.Row] > MaxWebhooksPerRow {
+			return fmt.Errorf("webhook[%d].row %d has too many webhooks, expected <=%d per row", index, hook.Row, MaxWebhooksPerRow)
+		}
+
+		if hook.CooldownSeconds < 0 || hook.CooldownSeconds > MaxWebhookCooldownSeconds {
+			return fmt.Errorf("webhook[%d].cooldownSeconds %d is not valid, expected value between 0 and %d", index, hook.CooldownSeconds, MaxWebhookCooldownSeconds)
+		}
+
+		if hook.MaxClicksPerHour < 0 || hook.MaxClicksPerHour > MaxWebhookClicksPerHour {
+			return fmt.Errorf("webhook[%d].maxClicksPerHour %d is not valid, expected value between 0 and %d", index, hook.MaxClicksPerHour, MaxWebhookClicksPerHour)
+		}
+
 		if len(hook.Payload) > MaxWebhookPayloadCount {
 			return fmt.Errorf("webhook[%d].payload item count is too large, expected <=%d", index, MaxWebhookPayloadCount)
 		}
 
-		if len(hook.PlainTextInput) > MaxWebhookPlainTextInputCount {
-			return fmt.Errorf("webhook[%d].plainTextInput item count is too large, expected <=%d", index, MaxWebhookPlainTextInputCount)
+		inputIDs := make(map[string]struct{})
+
+		if err := validateWebhookInputs(fmt.Sprintf("webhook[%d]", index), hook.PlainTextInput, hook.CheckboxInput, inputIDs); err != nil {
+			return err
 		}
 
-		if len(hook.CheckboxInput) > MaxWebhookCheckboxInputCount {
-			return fmt.Errorf("webhook[%d].checkboxInput item count is too large, expected <=%d", index, MaxWebhookCheckboxInputCount)
+		if err := validateWebhookFormSteps(index, hook.Steps, inputIDs); err != nil {
+			return err
 		}
+	}
 
-		inputIDs := make(map[string]struct{})
+	return nil
+}
 
-		for inputIndex, input := range hook.PlainTextInput {
-			if input == nil {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d] is nil", index, inputIndex)
-			}
+// cleanWebhookInputs normalizes a set of webhook inputs.
+func cleanWebhookInputs(plainTextInput []*WebhookPlainTextInput, checkboxInput []*WebhookCheckboxInput) {
+	for _, input := range plainTextInput {
+		if input == nil {
+			continue
+		}
 
-			if input.ID == "" {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].id is required", index, inputIndex)
-			}
+		input.ID = strings.TrimSpace(input.ID)
+		input.Description = strings.TrimSpace(input.Description)
+		input.InitialValue = strings.TrimSpace(input.InitialValue)
+	}
 
-			if _, ok := inputIDs[input.ID]; ok {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].id must be unique among all inputs", index, inputIndex)
-			}
+	for _, input := range checkboxInput {
+		if input == nil {
+			continue
+		}
 
-			inputIDs[input.ID] = struct{}{}
+		input.ID = strings.TrimSpace(input.ID)
+		input.Label = strings.TrimSpace(input.Label)
+	}
+}
 
-			if len(input.ID) > MaxWebhookInputIDLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].id is too long, expected <=%d", index, inputIndex, MaxWebhookInputIDLength)
-			}
+// validateWebhookInputs validates a set of webhook inputs, shown in the same modal page.
+// The prefix is the JSON path of the inputs owner, such as 'webhook[0]'. Input IDs are added to inputIDs,
+// which is used to check that IDs are unique among all inputs in the webhook.
+func validateWebhookInputs(prefix string, plainTextInput []*WebhookPlainTextInput, checkboxInput []*WebhookCheckboxInput, inputIDs map[string]struct{}) error {
+	if len(plainTextInput) > MaxWebhookPlainTextInputCount {
+		return fmt.Errorf("%s.plainTextInput item count is too large, expected <=%d", prefix, MaxWebhookPlainTextInputCount)
+	}
 
-			if len(input.Description) > MaxWebhookInputDescriptionLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].description is too long, expected <=%d", index, inputIndex, MaxWebhookInputDescriptionLength)
-			}
+	if len(checkboxInput) > MaxWebhookCheckboxInputCount {
+		return fmt.Errorf("%s.checkboxInput item count is too large, expected <=%d", prefix, MaxWebhookCheckboxInputCount)
+	}
 
-			if input.MinLength < 0 {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].minLength must be >=0", index, inputIndex)
-			}
+	for inputIndex, input := range plainTextInput {
+		if input == nil {
+			return fmt.Errorf("%s.plainTextInput[%d] is nil", prefix, inputIndex)
+		}
 
-			if input.MinLength > MaxWebhookInputTextLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].minLength must be <=%d", index, inputIndex, MaxWebhookInputTextLength)
-			}
+		if input.ID == "" {
+			return fmt.Errorf("%s.plainTextInput[%d].id is required", prefix, inputIndex)
+		}
 
-			if input.MaxLength < 0 {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].maxLength must be >=0", index, inputIndex)
-			}
+		if _, ok := inputIDs[input.ID]; ok {
+			return fmt.Errorf("%s.plainTextInput[%d].id must be unique among all inputs", prefix, inputIndex)
+		}
 
-			if input.MaxLength > MaxWebhookInputTextLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].maxLength must be <=%d", index, inputIndex, MaxWebhookInputTextLength)
-			}
+		inputIDs[input.ID] = struct{}{}
 
-			if input.MaxLength < input.MinLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].maxLength cannot be smaller than minLength", index, inputIndex)
-			}
+		if len(input.ID) > MaxWebhookInputIDLength {
+			return fmt.Errorf("%s.plainTextInput[%d].id is too long, expected <=%d", prefix, inputIndex, MaxWebhookInputIDLength)
+		}
 
-			if len(input.InitialValue) > input.MaxLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].initialValue cannot be longer than maxLength", index, inputIndex)
-			}
+		if len(input.Description) > MaxWebhookInputDescriptionLength {
+			return fmt.Errorf("%s.plainTextInput[%d].description is too long, expected <=%d", prefix, inputIndex, MaxWebhookInputDescriptionLength)
+		}
 
-			if len(input.InitialValue) < input.MinLength {
-				return fmt.Errorf("webhook[%d].plainTextInput[%d].initialValue cannot be shorter than minLength", index, inputIndex)
-			}
+		if input.MinLength < 0 {
+			return fmt.Errorf("%s.plainTextInput[%d].minLength must be >=0", prefix, inputIndex)
 		}
 
-		for inputIndex, input := range hook.CheckboxInput {
-			if input == nil {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d] is nil", index, inputIndex)
-			}
+		if input.MinLength > MaxWebhookInputTextLength {
+			return fmt.Errorf("%s.plainTextInput[%d].minLength must be <=%d", prefix, inputIndex, MaxWebhookInputTextLength)
+		}
 
-			if input.ID == "" {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d].id is required", index, inputIndex)
-			}
+		if input.MaxLength < 0 {
+			return fmt.Errorf("%s.plainTextInput[%d].maxLength must be >=0", prefix, inputIndex)
+		}
 
-			if _, ok := inputIDs[input.ID]; ok {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d].id must be unique among all inputs", index, inputIndex)
-			}
+		if input.MaxLength > MaxWebhookInputTextLength {
+			return fmt.Errorf("%s.plainTextInput[%d].maxLength must be <=%d", prefix, inputIndex, MaxWebhookInputTextLength)
+		}
 
-			inputIDs[input.ID] = struct{}{}
+		if input.MaxLength < input.MinLength {
+			return fmt.Errorf("%s.plainTextInput[%d].maxLength cannot be smaller than minLength", prefix, inputIndex)
+		}
 
-			if len(input.ID) > MaxWebhookInputIDLength {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d].id is too long, expected <=%d", index, inputIndex, MaxWebhookInputIDLength)
-			}
+		if len(input.InitialValue) > input.MaxLength {
+			return fmt.Errorf("%s.plainTextInput[%d].initialValue cannot be longer than maxLength", prefix, inputIndex)
+		}
 
-			if len(input.Label) > MaxWebhookInputLabelLength {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d].label is too long, expected <=%d", index, inputIndex, MaxWebhookInputLabelLength)
-			}
+		if len(input.InitialValue) < input.MinLength {
+			return fmt.Errorf("%s.plainTextInput[%d].initialValue cannot be shorter than minLength", prefix, inputIndex)
+		}
+	}
 
-			if len(input.Options) > MaxWebhookCheckboxOptionCount {
-				return fmt.Errorf("webhook[%d].checkboxInput[%d].options item count is too large, expected <=%d", index, inputIndex, MaxWebhookCheckboxOptionCount)
-			}
+	for inputIndex, input := range checkboxInput {
+		if input == nil {
+			return fmt.Errorf("%s.checkboxInput[%d] is nil", prefix, inputIndex)
+		}
 
-			values := make(map[string]struct{})
+		if input.ID == "" {
+			return fmt.Errorf("%s.checkboxInput[%d].id is required", prefix, inputIndex)
+		}
 
-			for optionIndex, option := range input.Options {
-				if option == nil {
-					return fmt.Errorf("webhook[%d].checkboxInput[%d].options[%d] is nil", index, inputIndex, optionIndex)
-				}
+		if _, ok := inputIDs[input.ID]; ok {
+			return fmt.Errorf("%s.checkboxInput[%d].id must be unique among all inputs", prefix, inputIndex)
+		}
 
-				if option.Value == "" {
-					return fmt.Errorf("webhook[%d].checkboxInput[%d].options[%d].value is required", index, inputIndex, optionIndex)
-				}
+		inputIDs[input.ID] = struct{}{}
 
-				if len(option.Value) > MaxCheckboxOptionValueLength {
-					return fmt.Errorf("webhook[%d].checkboxInput[%d].options[%d].value is too long, expected <=%d", index, inputIndex, optionIndex, MaxCheckboxOptionValueLength)
-				}
+		if len(input.ID) > MaxWebhookInputIDLength {
+			return fmt.Errorf("%s.checkboxInput[%d].id is too long, expected <=%d", prefix, inputIndex, MaxWebhookInputIDLength)
+		}
 
-				if _, ok := values[option.Value]; ok {
-					return fmt.Errorf("webhook[%d].checkboxInput[%d].options[%d].value must be unique", index, inputIndex, optionIndex)
-				}
+		if len(input.Label) > MaxWebhookInputLabelLength {
+			return fmt.Errorf("%s.checkboxInput[%d].label is too long, expected <=%d", prefix, inputIndex, MaxWebhookInputLabelLength)
+		}
 
-				values[option.Value] = struct{}{}
+		if len(input.Options) > MaxWebhookCheckboxOptionCount {
+			return fmt.Errorf("%s.checkboxInput[%d].options item count is too large, expected <=%d", prefix, inputIndex, MaxWebhookCheckboxOptionCount)
+		}
+
+		values := make(map[string]struct{})
+
+		for optionIndex, option := range input.Options {
+			if option == nil {
+				return fmt.Errorf("%s.checkboxInput[%d].options[%d] is nil", prefix, inputIndex, optionIndex)
+			}
 
-				if len(option.Text) > MaxWebhookCheckboxOptionTextLength {
-					return fmt.Errorf("webhook[%d].checkboxInput[%d].options[%d].text is too long, expected <=%d", index, inputIndex, optionIndex, MaxWebhookCheckboxOptionTextLength)
-				}
+			if option.Value == "" {
+				return fmt.Errorf("%s.checkboxInput[%d].options[%d].value is required", prefix, inputIndex, optionIndex)
+			}
+
+			if len(option.Value) > MaxCheckboxOptionValueLength {
+				return fmt.Errorf("%s.checkboxInput[%d].options[%d].value is too long, expected <=%d", prefix, inputIndex, optionIndex, MaxCheckboxOptionValueLength)
+			}
+
+			if _, ok := values[option.Value]; ok {
+				return fmt.Errorf("%s.checkboxInput[%d].options[%d].value must be unique", prefix, inputIndex, optionIndex)
+			}
+
+			values[option.Value] = struct{}{}
+
+			if len(option.Text) > MaxWebhookCheckboxOptionTextLength {
+				return fmt.Errorf("%s.checkboxInput[%d].options[%d].text is too long, expected <=%d", prefix, inputIndex, optionIndex, MaxWebhookCheckboxOptionTextLength)
 			}
 		}
 	}
@@ -1026,7 +1450,7 @@ func (a *Alert) ValidateEscalation() error {
 		}
 
 		for j, mention := range e.SlackMentions {
-			if !SlackMentionRegex.MatchString(mention) {
+			if !SlackMentionRegex.MatchString(mention) && !MentionAliasRegex.MatchString(mention) {
 				return fmt.Errorf("escalation[%d].slackMentions[%d] is not valid", index, j)
 			}
 		}
@@ -1041,18 +1465,61 @@ func (a *Alert) ValidateEscalation() error {
 	return nil
 }
 
-func shortenAlertTextIfNeeded(text string) string {
-	if utf8.RuneCountInString(text) <= MaxTextLength {
-		return text
+// ValidateNotificationPolicy validates the NotificationPolicy, if set.
+func (a *Alert) ValidateNotificationPolicy() error {
+	if a.NotificationPolicy == nil {
+		return nil
+	}
+
+	if err := a.NotificationPolicy.Validate(); err != nil {
+		return fmt.Errorf("notificationPolicy.%w", err)
 	}
 
-	endsWithCodeBlock := strings.HasSuffix(text, "```")
+	return nil
+}
+
+// ValidateRejectionTarget validates that the rejection target, if set, has a valid Slack channel or callback URL.
+func (a *Alert) ValidateRejectionTarget() error {
+	if a.RejectionTarget == nil {
+		return nil
+	}
+
+	if a.RejectionTarget.SlackChannelID == "" && a.RejectionTarget.CallbackURL == "" {
+		return errors.New("rejectionTarget.slackChannelId or rejectionTarget.callbackUrl is required")
+	}
 
-	if endsWithCodeBlock {
-		return strings.TrimSpace(truncateString(text, MaxTextLength-6)) + "...```"
+	if a.RejectionTarget.SlackChannelID != "" && !SlackChannelIDOrNameRegex.MatchString(a.RejectionTarget.SlackChannelID) {
+		return fmt.Errorf("rejectionTarget.slackChannelId '%s' is not valid", a.RejectionTarget.SlackChannelID)
+	}
+
+	if a.RejectionTarget.CallbackURL != "" {
+		if len(a.RejectionTarget.CallbackURL) > MaxWebhookURLLength {
+			return fmt.Errorf("rejectionTarget.callbackUrl is too long, expected length <=%d", MaxWebhookURLLength)
+		}
+
+		parsedURL, err := url.ParseRequestURI(a.RejectionTarget.CallbackURL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+			return errors.New("rejectionTarget.callbackUrl is not a valid absolute http or https URL")
+		}
+	}
+
+	return nil
+}
+
+// shortenAlertTextIfNeeded truncates the text to MaxTextLength UTF-16 code units, without breaking links, code spans
+// or formatting, and closing a code block left open by the truncation.
+func shortenAlertTextIfNeeded(text string) string {
+	return textlen.TruncateMarkdown(text, MaxTextLength, textlen.MarkdownOptions{Unit: textlen.UTF16})
+}
+
+// truncateText truncates s to maxLength UTF-16 code units (the unit of the Slack API limits), including a "..." suffix,
+// without splitting emojis or other grapheme clusters.
+func truncateText(s string, maxLength int) string {
+	if textlen.Fits(s, maxLength, textlen.UTF16) {
+		return s
 	}
 
-	return strings.TrimSpace(truncateString(text, MaxTextLength-3)) + "..."
+	return strings.TrimSpace(textlen.Truncate(s, maxLength-3, textlen.UTF16)) + "..."
 }
 
 // truncateString truncates a string to maxRunes runes, safely handling multi-byte UTF-8 characters.
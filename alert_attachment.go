@@ -0,0 +1,163 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxAttachmentCount is the maximum number of attachments per alert.
+	MaxAttachmentCount = 5
+	// MaxAttachmentFilenameLength is the maximum length of an attachment filename.
+	MaxAttachmentFilenameLength = 255
+	// MaxAttachmentTitleLength is the maximum length of an attachment title.
+	MaxAttachmentTitleLength = 100
+	// MaxAttachmentMimeTypeLength is the maximum length of an attachment MIME type.
+	MaxAttachmentMimeTypeLength = 100
+	// MaxAttachmentContentSize is the maximum size of the inline content of an attachment, in bytes.
+	MaxAttachmentContentSize = 64 * 1024
+	// MaxAttachmentTotalContentSize is the maximum total size of the inline content of all attachments of an alert, in bytes.
+	// It keeps alerts with attachments within the message size limits of the queues.
+	MaxAttachmentTotalContentSize = 128 * 1024
+)
+
+// DefaultAttachmentMimeType is the MIME type of attachments with inline content and no MimeType.
+const DefaultAttachmentMimeType = "text/plain"
+
+// AlertAttachment is a file attached to the Slack post of an alert, such as a log excerpt or a stack trace.
+// The Slack Manager uploads the content as a Slack file snippet, rather than posting it in the message body.
+//
+// Exactly one of Content and URL must be set.
+type AlertAttachment struct {
+	// Filename is the name of the file in Slack, e.g. 'build.log'. It is also used by Slack to pick the snippet syntax highlighting.
+	// This field is required, and cannot contain path separators.
+	// Maximum length: MaxAttachmentFilenameLength characters.
+	Filename string `json:"filename"`
+
+	// Title is an optional title displayed with the file.
+	// It is automatically truncated at MaxAttachmentTitleLength characters.
+	Title string `json:"title"`
+
+	// MimeType is the MIME type of the content, e.g. 'text/plain' or 'application/json'.
+	// Defaults to DefaultAttachmentMimeType for inline content.
+	MimeType string `json:"mimeType"`
+
+	// Content is the inline text content of the file. It must be valid UTF-8.
+	// Maximum size: MaxAttachmentContentSize bytes, and MaxAttachmentTotalContentSize bytes for all attachments of the alert.
+	Content string `json:"content"`
+
+	// URL is the absolute http or https URL of the file content, downloaded by the Slack Manager.
+	// Maximum length: MaxWebhookURLLength characters.
+	URL string `json:"url"`
+}
+
+// Clean normalizes the attachment fields. Content is left unchanged.
+func (at *AlertAttachment) Clean() {
+	if at == nil {
+		return
+	}
+
+	at.Filename = strings.TrimSpace(at.Filename)
+	at.Title = strings.ReplaceAll(strings.TrimSpace(at.Title), "\n", " ")
+	at.MimeType = strings.ToLower(strings.TrimSpace(at.MimeType))
+	at.URL = strings.TrimSpace(at.URL)
+
+	if at.MimeType == "" && at.Content != "" {
+		at.MimeType = DefaultAttachmentMimeType
+	}
+
+	at.Title = truncateText(at.Title, MaxAttachmentTitleLength)
+}
+
+// ValidateAttachments validates that the attachment count and total content size are within limits, and that each
+// attachment has a valid, unique filename, a valid MIME type, and either valid inline content or a valid URL.
+func (a *Alert) ValidateAttachments() error {
+	if len(a.Attachments) > MaxAttachmentCount {
+		return fmt.Errorf("too many attachments, expected <=%d", MaxAttachmentCount)
+	}
+
+	filenames := make(map[string]struct{}, len(a.Attachments))
+	totalSize := 0
+
+	for index, at := range a.Attachments {
+		if at == nil {
+			return fmt.Errorf("attachments[%d] is nil", index)
+		}
+
+		if at.Filename == "" {
+			return fmt.Errorf("attachments[%d].filename is required", index)
+		}
+
+		if utf8.RuneCountInString(at.Filename) > MaxAttachmentFilenameLength {
+			return fmt.Errorf("attachments[%d].filename is too long, expected length <=%d", index, MaxAttachmentFilenameLength)
+		}
+
+		if strings.ContainsAny(at.Filename, `/\`) {
+			return fmt.Errorf("attachments[%d].filename cannot contain path separators", index)
+		}
+
+		if _, ok := filenames[at.Filename]; ok {
+			return fmt.Errorf("attachments[%d].filename must be unique", index)
+		}
+
+		filenames[at.Filename] = struct{}{}
+
+		if utf8.RuneCountInString(at.Title) > MaxAttachmentTitleLength {
+			return fmt.Errorf("attachments[%d].title is too long, expected length <=%d", index, MaxAttachmentTitleLength)
+		}
+
+		if at.MimeType != "" {
+			if len(at.MimeType) > MaxAttachmentMimeTypeLength {
+				return fmt.Errorf("attachments[%d].mimeType is too long, expected length <=%d", index, MaxAttachmentMimeTypeLength)
+			}
+
+			if _, _, err := mime.ParseMediaType(at.MimeType); err != nil {
+				return fmt.Errorf("attachments[%d].mimeType '%s' is not valid", index, at.MimeType)
+			}
+		}
+
+		switch {
+		case at.Content == "" && at.URL == "":
+			return fmt.Errorf("attachments[%d] must have either content or url", index)
+		case at.Content != "" && at.URL != "":
+			return fmt.Errorf("attachments[%d] cannot have both content and url", index)
+		case at.Content != "":
+			if len(at.Content) > MaxAttachmentContentSize {
+				return fmt.Errorf("attachments[%d].content is too large, expected size <=%d bytes", index, MaxAttachmentContentSize)
+			}
+
+			if !utf8.ValidString(at.Content) {
+				return fmt.Errorf("attachments[%d].content is not valid UTF-8", index)
+			}
+
+			totalSize += len(at.Content)
+		default:
+			if err := validateAttachmentURL(at.URL); err != nil {
+				return fmt.Errorf("attachments[%d].%w", index, err)
+			}
+		}
+	}
+
+	if totalSize > MaxAttachmentTotalContentSize {
+		return fmt.Errorf("attachments content is too large, expected total size <=%d bytes", MaxAttachmentTotalContentSize)
+	}
+
+	return nil
+}
+
+func validateAttachmentURL(s string) error {
+	if len(s) > MaxWebhookURLLength {
+		return fmt.Errorf("url is too long, expected length <=%d", MaxWebhookURLLength)
+	}
+
+	parsedURL, err := url.ParseRequestURI(s)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return errors.New("url is not a valid absolute http or https URL")
+	}
+
+	return nil
+}
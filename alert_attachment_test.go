@@ -0,0 +1,72 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertAttachments(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(attachments ...*types.AlertAttachment) *types.Alert {
+		a := types.NewErrorAlert()
+		a.SlackChannelID = "C12345678"
+		a.Header = "Build failed"
+		a.Attachments = attachments
+
+		return a
+	}
+
+	t.Run("valid attachments should be cleaned and accepted", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(
+			&types.AlertAttachment{Filename: " build.log ", Title: "Build\nlog", Content: "line 1\nline 2\n"},
+			&types.AlertAttachment{Filename: "trace.json", MimeType: " Application/JSON ", URL: " https://example.com/trace.json "},
+		)
+		a.Clean()
+		require.NoError(t, a.Validate())
+
+		assert.Equal(t, types.AlertAttachment{Filename: "build.log", Title: "Build log", MimeType: "text/plain", Content: "line 1\nline 2\n"}, *a.Attachments[0])
+		assert.Equal(t, types.AlertAttachment{Filename: "trace.json", MimeType: "application/json", URL: "https://example.com/trace.json"}, *a.Attachments[1])
+	})
+
+	t.Run("invalid attachments should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			attachments []*types.AlertAttachment
+			expected    string
+		}{
+			{[]*types.AlertAttachment{nil}, "attachments[0] is nil"},
+			{[]*types.AlertAttachment{{Content: "x"}}, "attachments[0].filename is required"},
+			{[]*types.AlertAttachment{{Filename: strings.Repeat("f", types.MaxAttachmentFilenameLength+1), Content: "x"}}, "attachments[0].filename is too long, expected length <=255"},
+			{[]*types.AlertAttachment{{Filename: "../etc/passwd", Content: "x"}}, "attachments[0].filename cannot contain path separators"},
+			{[]*types.AlertAttachment{{Filename: "a.log", Content: "x"}, {Filename: "a.log", Content: "y"}}, "attachments[1].filename must be unique"},
+			{[]*types.AlertAttachment{{Filename: "a.log", MimeType: "not a mime type", Content: "x"}}, "attachments[0].mimeType 'not a mime type' is not valid"},
+			{[]*types.AlertAttachment{{Filename: "a.log"}}, "attachments[0] must have either content or url"},
+			{[]*types.AlertAttachment{{Filename: "a.log", Content: "x", URL: "https://example.com"}}, "attachments[0] cannot have both content and url"},
+			{[]*types.AlertAttachment{{Filename: "a.log", Content: strings.Repeat("x", types.MaxAttachmentContentSize+1)}}, "attachments[0].content is too large, expected size <=65536 bytes"},
+			{[]*types.AlertAttachment{{Filename: "a.bin", Content: "\xff\xfe"}}, "attachments[0].content is not valid UTF-8"},
+			{[]*types.AlertAttachment{{Filename: "a.log", URL: "ftp://example.com/a.log"}}, "attachments[0].url is not a valid absolute http or https URL"},
+			{[]*types.AlertAttachment{{Filename: "a.log", URL: "https://example.com/" + strings.Repeat("x", types.MaxWebhookURLLength)}}, "attachments[0].url is too long, expected length <=1000"},
+			{
+				[]*types.AlertAttachment{
+					{Filename: "a.log", Content: strings.Repeat("x", types.MaxAttachmentContentSize)},
+					{Filename: "b.log", Content: strings.Repeat("x", types.MaxAttachmentContentSize)},
+					{Filename: "c.log", Content: "x"},
+				},
+				"attachments content is too large, expected total size <=131072 bytes",
+			},
+			{make([]*types.AlertAttachment, types.MaxAttachmentCount+1), "too many attachments, expected <=5"},
+		}
+
+		for _, tt := range tests {
+			require.EqualError(t, newAlert(tt.attachments...).Validate(), tt.expected)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// MaxAlertBatchCount is the maximum number of alerts in an AlertBatch.
+	MaxAlertBatchCount = 100
+
+	// MaxAlertBatchSize is the maximum total size of the alerts in an AlertBatch, in bytes of JSON.
+	MaxAlertBatchSize = 1024 * 1024
+)
+
+// AlertBatch is a container for sending many alerts in one HTTP request or queue message.
+//
+// A batch is serialized as a JSON object with an 'alerts' array. When deserializing, a plain JSON array of alerts
+// is also accepted.
+type AlertBatch struct {
+	Alerts []*Alert `json:"alerts"`
+}
+
+// AlertBatchItemResult is the validation result for a single alert in an AlertBatch.
+type AlertBatchItemResult struct {
+	// Index is the position of the alert in the batch.
+	Index int `json:"index"`
+
+	// UniqueID is the unique ID of the alert, as returned by Alert.UniqueID. Empty for nil alerts.
+	UniqueID string `json:"uniqueId"`
+
+	// Error is the validation error message, or empty if the alert is valid.
+	Error string `json:"error,omitempty"`
+
+	// FieldErrors lists the invalid fields, if the error could be attributed to a field.
+	FieldErrors []FieldError `json:"fieldErrors,omitempty"`
+}
+
+// NewAlertBatch returns a batch with the specified alerts.
+func NewAlertBatch(alerts ...*Alert) *AlertBatch {
+	return &AlertBatch{Alerts: alerts}
+}
+
+// UnmarshalJSON decodes either a JSON object with an 'alerts' array, or a plain JSON array of alerts.
+func (b *AlertBatch) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var alerts []*Alert
+
+		if err := json.Unmarshal(trimmed, &alerts); err != nil {
+			return fmt.Errorf("failed to decode alert batch: %w", err)
+		}
+
+		b.Alerts = alerts
+
+		return nil
+	}
+
+	type alertBatch AlertBatch
+
+	var batch alertBatch
+
+	if err := json.Unmarshal(trimmed, &batch); err != nil {
+		return fmt.Errorf("failed to decode alert batch: %w", err)
+	}
+
+	*b = AlertBatch(batch)
+
+	return nil
+}
+
+// Len returns the number of alerts in the batch.
+func (b *AlertBatch) Len() int {
+	if b == nil {
+		return 0
+	}
+
+	return len(b.Alerts)
+}
+
+// Size returns the total size of the alerts in the batch, in bytes of JSON.
+func (b *AlertBatch) Size() (int, error) {
+	if b == nil {
+		return 0, nil
+	}
+
+	size := 0
+
+	for i, a := range b.Alerts {
+		data, err := json.Marshal(a)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode alerts[%d]: %w", i, err)
+		}
+
+		size += len(data)
+	}
+
+	return size, nil
+}
+
+// Clean cleans all alerts in the batch. Nil alerts are kept, and reported by ValidateItems.
+func (b *AlertBatch) Clean() {
+	if b == nil {
+		return
+	}
+
+	for _, a := range b.Alerts {
+		if a != nil {
+			a.Clean()
+		}
+	}
+}
+
+// Validate returns an error if the batch as a whole is invalid, i.e. if it is empty, or exceeds MaxAlertBatchCount
+// or MaxAlertBatchSize. Use ValidateItems to validate the individual alerts.
+func (b *AlertBatch) Validate() error {
+	if b.Len() == 0 {
+		return errors.New("alerts cannot be empty")
+	}
+
+	if len(b.Alerts) > MaxAlertBatchCount {
+		return fmt.Errorf("too many alerts, expected <=%d", MaxAlertBatchCount)
+	}
+
+	size, err := b.Size()
+	if err != nil {
+		return err
+	}
+
+	if size > MaxAlertBatchSize {
+		return fmt.Errorf("alerts are too large, expected total size <=%d bytes", MaxAlertBatchSize)
+	}
+
+	return nil
+}
+
+// ValidateItems validates each alert in the batch, and returns one result per alert, in batch order.
+// Call Clean first.
+func (b *AlertBatch) ValidateItems() []AlertBatchItemResult {
+	results := make([]AlertBatchItemResult, b.Len())
+
+	for i := range results {
+		results[i].Index = i
+
+		a := b.Alerts[i]
+		if a == nil {
+			results[i].Error = "alert cannot be null"
+			continue
+		}
+
+		results[i].UniqueID = a.UniqueID()
+
+		if err := a.Validate(); err != nil {
+			results[i].Error = err.Error()
+
+			if fieldErr := NewFieldError(err); fieldErr.Field != "" {
+				results[i].FieldErrors = []FieldError{fieldErr}
+			}
+		}
+	}
+
+	return results
+}
+
+// ValidAlerts returns the alerts that passed validation in the given results, in batch order.
+func (b *AlertBatch) ValidAlerts(results []AlertBatchItemResult) []*Alert {
+	valid := make([]*Alert, 0, len(results))
+
+	for _, r := range results {
+		if r.Error == "" && r.Index >= 0 && r.Index < b.Len() {
+			valid = append(valid, b.Alerts[r.Index])
+		}
+	}
+
+	return valid
+}
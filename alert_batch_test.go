@@ -0,0 +1,92 @@
+package types_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertBatchJSON(t *testing.T) {
+	t.Parallel()
+
+	a := types.NewErrorAlert()
+	a.SlackChannelID = "C1"
+	a.Header = "Disk full"
+
+	batch := types.NewAlertBatch(a)
+
+	data, err := json.Marshal(batch)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), `{"alerts":[`))
+
+	var decoded types.AlertBatch
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, 1, decoded.Len())
+	assert.Equal(t, "Disk full", decoded.Alerts[0].Header)
+
+	var fromArray types.AlertBatch
+	require.NoError(t, json.Unmarshal([]byte(` [{"header":"a"},{"header":"b"}]`), &fromArray))
+	require.Equal(t, 2, fromArray.Len())
+	assert.Equal(t, "b", fromArray.Alerts[1].Header)
+
+	require.ErrorContains(t, json.Unmarshal([]byte(`[1]`), &fromArray), "failed to decode alert batch")
+	require.ErrorContains(t, json.Unmarshal([]byte(`{"alerts":1}`), &fromArray), "failed to decode alert batch")
+}
+
+func TestAlertBatchValidate(t *testing.T) {
+	t.Parallel()
+
+	require.EqualError(t, types.NewAlertBatch().Validate(), "alerts cannot be empty")
+
+	var nilBatch *types.AlertBatch
+	require.EqualError(t, nilBatch.Validate(), "alerts cannot be empty")
+
+	alerts := make([]*types.Alert, types.MaxAlertBatchCount+1)
+	for i := range alerts {
+		alerts[i] = &types.Alert{Header: "a"}
+	}
+
+	require.EqualError(t, types.NewAlertBatch(alerts...).Validate(), "too many alerts, expected <=100")
+	require.NoError(t, types.NewAlertBatch(alerts[1:]...).Validate())
+
+	large := make([]*types.Alert, 20)
+	for i := range large {
+		large[i] = &types.Alert{Text: strings.Repeat("x", 60000)}
+	}
+
+	require.EqualError(t, types.NewAlertBatch(large...).Validate(), "alerts are too large, expected total size <=1048576 bytes")
+}
+
+func TestAlertBatchValidateItems(t *testing.T) {
+	t.Parallel()
+
+	valid := types.NewErrorAlert()
+	valid.SlackChannelID = "C1"
+	valid.Header = "Disk full"
+
+	invalid := types.NewAlert("fatal")
+	invalid.SlackChannelID = "C1"
+	invalid.Header = "Disk full"
+
+	batch := types.NewAlertBatch(valid, nil, invalid)
+	batch.Clean()
+
+	results := batch.ValidateItems()
+	require.Len(t, results, 3)
+
+	assert.Equal(t, 0, results[0].Index)
+	assert.Equal(t, valid.UniqueID(), results[0].UniqueID)
+	assert.Empty(t, results[0].Error)
+
+	assert.Equal(t, 1, results[1].Index)
+	assert.Equal(t, "alert cannot be null", results[1].Error)
+
+	assert.Equal(t, 2, results[2].Index)
+	assert.Contains(t, results[2].Error, "severity")
+
+	assert.Equal(t, []*types.Alert{valid}, batch.ValidAlerts(results))
+}
@@ -0,0 +1,45 @@
+package types
+
+// CleanOptions holds the options of Alert.CleanWithReport.
+type CleanOptions struct {
+	// OverflowTextCap is the maximum length of the original text captured in Alert.OverflowText when Text is truncated.
+	// If zero or negative, the original text is not captured. Values above MaxOverflowTextLength are capped.
+	OverflowTextCap int
+
+	// MetadataLimits are the limits applied to the metadata. The zero value applies the Max* metadata constants.
+	MetadataLimits MetadataLimits
+}
+
+// CleanReport describes the changes made by Alert.CleanWithReport, apart from whitespace and case normalization.
+type CleanReport struct {
+	// TruncatedFields holds the JSON names of the truncated fields, e.g. 'text' or 'fields[2].value', in the order
+	// they were cleaned.
+	TruncatedFields []string `json:"truncatedFields"`
+
+	// OverflowText is the original text captured in Alert.OverflowText, if Text was truncated and
+	// CleanOptions.OverflowTextCap is set.
+	OverflowText string `json:"overflowText"`
+
+	// DroppedMetadataKeys holds the metadata keys dropped because they were invalid, too deeply nested, above the key
+	// limit, or too large to be truncated.
+	DroppedMetadataKeys []string `json:"droppedMetadataKeys"`
+
+	// TruncatedMetadataKeys holds the metadata keys with string values truncated to fit the size limit.
+	TruncatedMetadataKeys []string `json:"truncatedMetadataKeys"`
+}
+
+// CleanWithReport runs Clean, and also cleans the metadata to fit opts.MetadataLimits. It returns a report of the
+// truncated fields and metadata. If Text is truncated and opts.OverflowTextCap is set, the original text (truncated
+// at the cap) is stored in OverflowText, so that the full content can be attached as a thread reply or file snippet.
+func (a *Alert) CleanWithReport(opts CleanOptions) *CleanReport {
+	report := &CleanReport{}
+
+	a.clean(opts, report)
+
+	return report
+}
+
+// Truncated returns true if any field or metadata value was truncated or dropped.
+func (r *CleanReport) Truncated() bool {
+	return r != nil && (len(r.TruncatedFields) > 0 || len(r.DroppedMetadataKeys) > 0 || len(r.TruncatedMetadataKeys) > 0)
+}
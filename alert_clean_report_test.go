@@ -0,0 +1,68 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertCleanWithReport(t *testing.T) {
+	t.Parallel()
+
+	longText := strings.Repeat("log line\n", 2000)
+
+	t.Run("the original text should be captured when truncated", func(t *testing.T) {
+		t.Parallel()
+
+		a := types.NewErrorAlert()
+		a.SlackChannelID = "C12345678"
+		a.Header = strings.Repeat("h", types.MaxHeaderLength+1)
+		a.Text = longText
+		a.Fields = []*types.Field{{Title: "ok", Value: "ok"}, {Title: "long", Value: strings.Repeat("v", types.MaxFieldValueLength+1)}}
+
+		report := a.CleanWithReport(types.CleanOptions{OverflowTextCap: 15000})
+		require.NoError(t, a.Validate())
+
+		assert.True(t, report.Truncated())
+		assert.Equal(t, []string{"header", "text", "fields[1].value"}, report.TruncatedFields)
+		assert.Len(t, a.OverflowText, 15000)
+		assert.True(t, strings.HasPrefix(longText, a.OverflowText))
+		assert.Equal(t, a.OverflowText, report.OverflowText)
+		assert.LessOrEqual(t, len(a.Text), types.MaxTextLength)
+
+		// Cleaning again should keep the captured text.
+		report = a.CleanWithReport(types.CleanOptions{OverflowTextCap: 15000})
+		assert.False(t, report.Truncated())
+		assert.Empty(t, report.OverflowText)
+		assert.Len(t, a.OverflowText, 15000)
+	})
+
+	t.Run("the original text should not be captured by default", func(t *testing.T) {
+		t.Parallel()
+
+		a := types.Alert{Text: longText}
+		report := a.CleanWithReport(types.CleanOptions{})
+		assert.Equal(t, []string{"text"}, report.TruncatedFields)
+		assert.Empty(t, a.OverflowText)
+
+		a = types.Alert{Text: "short"}
+		report = a.CleanWithReport(types.CleanOptions{OverflowTextCap: 100})
+		assert.False(t, report.Truncated())
+		assert.Empty(t, a.OverflowText)
+	})
+
+	t.Run("the captured text should be capped", func(t *testing.T) {
+		t.Parallel()
+
+		a := types.Alert{Text: strings.Repeat("x", types.MaxOverflowTextLength+10)}
+		a.CleanWithReport(types.CleanOptions{OverflowTextCap: types.MaxOverflowTextLength * 2})
+		assert.Len(t, a.OverflowText, types.MaxOverflowTextLength)
+
+		a = types.Alert{Text: "short", OverflowText: strings.Repeat("x", types.MaxOverflowTextLength+10)}
+		a.Clean()
+		assert.Len(t, a.OverflowText, types.MaxOverflowTextLength)
+	})
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxDeliverAtHorizon is the maximum time into the future an alert can be scheduled for, using Alert.DeliverAt.
+const MaxDeliverAtHorizon = 90 * 24 * time.Hour
+
+// ValidateDeliverAt validates that DeliverAt, if set, is not more than MaxDeliverAtHorizon into the future.
+func (a *Alert) ValidateDeliverAt() error {
+	if a.DeliverAt.IsZero() {
+		return nil
+	}
+
+	if time.Until(a.DeliverAt) > MaxDeliverAtHorizon {
+		return fmt.Errorf("deliverAt %s is too far into the future, expected at most %s from now", a.DeliverAt.Format(time.RFC3339), MaxDeliverAtHorizon)
+	}
+
+	return nil
+}
+
+// IsDue returns true if the alert should be evaluated at the given time, i.e. if DeliverAt is not set, or is not after now.
+func (a *Alert) IsDue(now time.Time) bool {
+	return a.DeliverAt.IsZero() || !a.DeliverAt.After(now)
+}
+
+// DeliverAfter schedules the alert for delivery after the specified delay, by setting DeliverAt.
+func (a *Alert) DeliverAfter(delay time.Duration) {
+	a.DeliverAt = time.Now().UTC().Add(delay)
+}
@@ -0,0 +1,42 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertDeliverAt(t *testing.T) {
+	t.Parallel()
+
+	a := types.NewErrorAlert()
+	a.SlackChannelID = "C12345678"
+	a.Header = "Reminder"
+
+	now := time.Now()
+
+	assert.True(t, a.IsDue(now))
+	require.NoError(t, a.Validate())
+
+	a.DeliverAt = now.Add(time.Hour).In(time.FixedZone("CET", 3600))
+	a.Clean()
+	assert.Equal(t, time.UTC, a.DeliverAt.Location())
+	assert.False(t, a.IsDue(now))
+	assert.True(t, a.IsDue(now.Add(time.Hour)))
+	require.NoError(t, a.Validate())
+
+	a.DeliverAt = now.Add(-time.Hour)
+	assert.True(t, a.IsDue(now))
+	require.NoError(t, a.Validate())
+
+	a.DeliverAt = now.Add(types.MaxDeliverAtHorizon + time.Hour)
+	require.ErrorContains(t, a.Validate(), "deliverAt")
+	require.ErrorContains(t, a.Validate(), "is too far into the future, expected at most 2160h0m0s from now")
+
+	a.DeliverAfter(10 * time.Minute)
+	assert.False(t, a.IsDue(now))
+	assert.True(t, a.IsDue(now.Add(11*time.Minute)))
+}
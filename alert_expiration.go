@@ -0,0 +1,98 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxExpiresAfterSeconds is the maximum value of Alert.ExpiresAfterSeconds.
+const MaxExpiresAfterSeconds = int(MaxTimestampAge / time.Second)
+
+// ExpirationAction is the action taken on an alert that has expired before it was processed (see Alert.ExpiresAfterSeconds).
+type ExpirationAction string
+
+const (
+	// ExpirationActionDrop drops expired alerts. This is the default.
+	ExpirationActionDrop ExpirationAction = "drop"
+
+	// ExpirationActionDowngrade processes expired alerts with severity info, and without escalations,
+	// so that stale alerts are still recorded without paging anyone. Resolved alerts are not downgraded.
+	ExpirationActionDowngrade ExpirationAction = "downgrade"
+)
+
+// ExpirationActionIsValid returns true if the provided ExpirationAction is valid.
+func ExpirationActionIsValid(s ExpirationAction) bool {
+	switch s {
+	case ExpirationActionDrop, ExpirationActionDowngrade:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidExpirationActions returns a slice of valid ExpirationAction values.
+func ValidExpirationActions() []string {
+	return []string{
+		string(ExpirationActionDrop),
+		string(ExpirationActionDowngrade),
+	}
+}
+
+// ValidateExpiration validates that ExpiresAfterSeconds is within the allowed range, and that ExpirationAction is valid.
+func (a *Alert) ValidateExpiration() error {
+	if a.ExpiresAfterSeconds < 0 || a.ExpiresAfterSeconds > MaxExpiresAfterSeconds {
+		return fmt.Errorf("expiresAfterSeconds '%d' is not valid, expected value between 0 and %d", a.ExpiresAfterSeconds, MaxExpiresAfterSeconds)
+	}
+
+	if a.ExpirationAction != "" && !ExpirationActionIsValid(a.ExpirationAction) {
+		return fmt.Errorf("expirationAction '%s' is not valid, expected one of [%s]", a.ExpirationAction, strings.Join(ValidExpirationActions(), ", "))
+	}
+
+	return nil
+}
+
+// ExpiresAt returns the time when the alert expires: ExpiresAfterSeconds after the alert Timestamp, or after DeliverAt
+// for scheduled alerts. It returns the zero time if the alert never expires, i.e. if ExpiresAfterSeconds or the
+// Timestamp is not set.
+func (a *Alert) ExpiresAt() time.Time {
+	if a.ExpiresAfterSeconds <= 0 || a.Timestamp.IsZero() {
+		return time.Time{}
+	}
+
+	start := a.Timestamp
+
+	if a.DeliverAt.After(start) {
+		start = a.DeliverAt
+	}
+
+	return start.Add(time.Duration(a.ExpiresAfterSeconds) * time.Second)
+}
+
+// IsExpired returns true if the alert has expired at the given time, typically the time it was received from the queue.
+func (a *Alert) IsExpired(now time.Time) bool {
+	expiresAt := a.ExpiresAt()
+
+	return !expiresAt.IsZero() && now.After(expiresAt)
+}
+
+// ApplyExpiration applies the ExpirationAction if the alert has expired at the given time, and returns true
+// if the alert should be dropped. Downgraded alerts are modified in place, and false is returned.
+//
+// It should be called before Clean, since Clean replaces timestamps older than MaxTimestampAge.
+func (a *Alert) ApplyExpiration(now time.Time) bool {
+	if !a.IsExpired(now) {
+		return false
+	}
+
+	if a.ExpirationAction != ExpirationActionDowngrade {
+		return true
+	}
+
+	if a.Severity != AlertResolved {
+		a.Severity = AlertInfo
+		a.Escalation = nil
+	}
+
+	return false
+}
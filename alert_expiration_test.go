@@ -0,0 +1,96 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertExpiration(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+
+	newAlert := func() *types.Alert {
+		a := types.NewErrorAlert()
+		a.SlackChannelID = "C12345678"
+		a.Header = "Disk full"
+		a.Timestamp = now
+		a.ExpiresAfterSeconds = 600
+		a.Escalation = []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 60}}
+		return a
+	}
+
+	t.Run("alerts should expire after their time to live", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		a.Clean()
+		require.NoError(t, a.Validate())
+		assert.Equal(t, types.ExpirationActionDrop, a.ExpirationAction)
+		assert.Equal(t, now.Add(10*time.Minute), a.ExpiresAt())
+
+		assert.False(t, a.IsExpired(now.Add(10*time.Minute)))
+		assert.True(t, a.IsExpired(now.Add(10*time.Minute+time.Second)))
+
+		assert.False(t, a.ApplyExpiration(now.Add(time.Minute)))
+		assert.True(t, a.ApplyExpiration(now.Add(time.Hour)))
+	})
+
+	t.Run("scheduled alerts should expire after their delivery time", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		a.DeliverAt = now.Add(time.Hour)
+		assert.Equal(t, now.Add(70*time.Minute), a.ExpiresAt())
+		assert.False(t, a.IsExpired(now.Add(time.Hour)))
+	})
+
+	t.Run("alerts without time to live should never expire", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		a.ExpiresAfterSeconds = -1
+		a.Clean()
+		assert.Equal(t, 0, a.ExpiresAfterSeconds)
+		assert.Empty(t, a.ExpirationAction)
+		assert.True(t, a.ExpiresAt().IsZero())
+		assert.False(t, a.IsExpired(now.Add(24*time.Hour)))
+		assert.False(t, a.ApplyExpiration(now.Add(24*time.Hour)))
+	})
+
+	t.Run("expired alerts should be downgraded, unless resolved", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		a.ExpirationAction = " Downgrade "
+		a.Clean()
+		require.NoError(t, a.Validate())
+
+		assert.False(t, a.ApplyExpiration(now.Add(time.Hour)))
+		assert.Equal(t, types.AlertInfo, a.Severity)
+		assert.Nil(t, a.Escalation)
+
+		a = newAlert()
+		a.Severity = types.AlertResolved
+		a.ExpirationAction = types.ExpirationActionDowngrade
+		assert.False(t, a.ApplyExpiration(now.Add(time.Hour)))
+		assert.Equal(t, types.AlertResolved, a.Severity)
+	})
+
+	t.Run("invalid expiration should fail validation", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		a.Escalation = nil
+		a.ExpiresAfterSeconds = types.MaxExpiresAfterSeconds + 1
+		require.EqualError(t, a.Validate(), "expiresAfterSeconds '604801' is not valid, expected value between 0 and 604800")
+
+		a.ExpiresAfterSeconds = 60
+		a.ExpirationAction = "ignore"
+		require.EqualError(t, a.Validate(), "expirationAction 'ignore' is not valid, expected one of [drop, downgrade]")
+	})
+}
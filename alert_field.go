@@ -0,0 +1,80 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// alertFieldMetadataPrefix is the prefix used to refer to alert metadata values in field names, as in 'metadata.team'.
+const alertFieldMetadataPrefix = "metadata."
+
+// ValidAlertFields returns the alert field names that can be referred to by correlation strategies and other rules.
+// In addition, metadata values can be referred to as 'metadata.<key>'.
+func ValidAlertFields() []string {
+	return []string{
+		"header", "text", "author", "host", "footer", "type", "severity", "priority", "slackChannelId", "routeKey", "correlationId", "globalIssueKey",
+		"runbookUrl", "runbookId",
+	}
+}
+
+// AlertFieldIsValid returns true if the field name is one of ValidAlertFields, or on the format 'metadata.<key>'.
+func AlertFieldIsValid(field string) bool {
+	if key, ok := strings.CutPrefix(field, alertFieldMetadataPrefix); ok {
+		return key != ""
+	}
+
+	_, ok := alertFieldValue(&Alert{}, field)
+
+	return ok
+}
+
+// alertFieldValue returns the value of the named alert field, using the JSON field names.
+// Metadata values are referred to as 'metadata.<key>', and formatted with fmt.Sprint if they are not strings.
+// The second return value is false if the field name is not valid, or if the metadata key does not exist.
+func alertFieldValue(a *Alert, field string) (string, bool) {
+	if key, ok := strings.CutPrefix(field, alertFieldMetadataPrefix); ok {
+		value, ok := a.Metadata[key]
+		if !ok || value == nil {
+			return "", false
+		}
+
+		if s, ok := value.(string); ok {
+			return s, true
+		}
+
+		return fmt.Sprint(value), true
+	}
+
+	switch field {
+	case "header":
+		return a.Header, true
+	case "text":
+		return a.Text, true
+	case "author":
+		return a.Author, true
+	case "host":
+		return a.Host, true
+	case "footer":
+		return a.Footer, true
+	case "type":
+		return a.Type, true
+	case "severity":
+		return string(a.Severity), true
+	case "priority":
+		return string(a.Priority), true
+	case "slackChannelId":
+		return a.SlackChannelID, true
+	case "routeKey":
+		return a.RouteKey, true
+	case "correlationId":
+		return a.CorrelationID, true
+	case "globalIssueKey":
+		return a.GlobalIssueKey, true
+	case "runbookUrl":
+		return a.RunbookURL, true
+	case "runbookId":
+		return a.RunbookID, true
+	default:
+		return "", false
+	}
+}
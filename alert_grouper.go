@@ -0,0 +1,392 @@
+package types
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultGroupingWindow is the grouping window used when GrouperConfig.Window is zero.
+	DefaultGroupingWindow = 2 * time.Minute
+
+	// DefaultGroupingThreshold is the threshold used when GrouperConfig.Threshold is zero.
+	DefaultGroupingThreshold = 5
+
+	// DefaultGroupSummaryFieldCount is the number of fields of a summary alert used when GrouperConfig.SummaryFieldCount is zero.
+	DefaultGroupSummaryFieldCount = 5
+
+	// DefaultGroupSummaryLinkCount is the number of constituents listed in the text of a summary alert,
+	// used when GrouperConfig.SummaryLinkCount is zero.
+	DefaultGroupSummaryLinkCount = 10
+
+	// DefaultGrouperMaxGroups is the max number of open groups used when GrouperConfig.MaxGroups is zero.
+	DefaultGrouperMaxGroups = 10000
+
+	// GroupedAlertsMetric is the name of the counter incremented for each alert replaced by a summary alert of a Grouper.
+	// The counter has a single label, 'channel', with the Slack channel ID of the alert.
+	GroupedAlertsMetric = "alerts_grouped_total"
+
+	// grouperMaxAlertsPerGroup is the max number of alerts kept per group. Further alerts are only counted.
+	grouperMaxAlertsPerGroup = 1000
+)
+
+// GroupKeyFunc returns the key of the group of an alert. Alerts with an empty key are not grouped.
+type GroupKeyFunc func(a *Alert) string
+
+// GroupByFields returns a GroupKeyFunc using the values of the given alert fields as key.
+// Field names are the JSON field names of ValidAlertFields, or 'metadata.<key>' (see AlertFieldIsValid).
+func GroupByFields(fields ...string) GroupKeyFunc {
+	fields = slices.Clone(fields)
+
+	return func(a *Alert) string {
+		values := make([]string, 0, len(fields)+1)
+		values = append(values, "group")
+
+		for _, field := range fields {
+			value, _ := alertFieldValue(a, field)
+			values = append(values, value)
+		}
+
+		return hash(values...)
+	}
+}
+
+// GrouperConfig holds the configuration of a Grouper.
+type GrouperConfig struct {
+	// Window is the time during which alerts with the same group key are accumulated, from the first alert of the group.
+	// Defaults to DefaultGroupingWindow.
+	Window time.Duration
+
+	// Threshold is the number of alerts in a group from which they are replaced by a single summary alert.
+	// Defaults to DefaultGroupingThreshold.
+	Threshold int
+
+	// KeyFunc returns the group key of an alert. Defaults to grouping by Slack channel ID, route key, type and severity.
+	KeyFunc GroupKeyFunc
+
+	// SummaryFieldCount is the max number of fields of a summary alert, holding the most common fields
+	// of the grouped alerts. Defaults to DefaultGroupSummaryFieldCount, and is capped at MaxFieldCount.
+	SummaryFieldCount int
+
+	// SummaryLinkCount is the max number of grouped alerts listed (with their links) in the text of a summary alert.
+	// Defaults to DefaultGroupSummaryLinkCount.
+	SummaryLinkCount int
+
+	// MaxGroups is the max number of open groups. Alerts which would open a group beyond the limit are not grouped.
+	// Defaults to DefaultGrouperMaxGroups.
+	MaxGroups int
+
+	// Metrics receives the GroupedAlertsMetric counter. Optional.
+	Metrics Metrics
+}
+
+// Grouper replaces bursts of alerts sharing a group key with a single summary alert, such as a source emitting one
+// alert per affected host. It is intended for alert producers and the ingestion layer. It is safe for concurrent use.
+//
+// Alerts are accumulated per group during the grouping window, starting with the first alert of the group.
+// When the number of alerts in a group reaches the threshold, Add returns a summary alert (see NewGroupSummaryAlert),
+// and the following alerts of the window are absorbed. When the window has passed, Flush returns an updated summary
+// for groups that absorbed more alerts, or the accumulated alerts as is for groups below the threshold.
+// Alerts below the threshold are thus delayed by up to the grouping window.
+//
+// The grouper is in-memory only, and is thus only effective within a single process.
+type Grouper struct {
+	window            time.Duration
+	threshold         int
+	keyFunc           GroupKeyFunc
+	summaryFieldCount int
+	summaryLinkCount  int
+	maxGroups         int
+	metrics           Metrics
+
+	mu     sync.Mutex
+	groups map[string]*alertGroup
+	seq    uint64
+}
+
+type alertGroup struct {
+	key        string
+	seq        uint64
+	start      time.Time
+	alerts     []*Alert
+	count      int
+	summarized int
+}
+
+// NewGrouper creates a new Grouper with the given configuration.
+// An error is returned if the window, threshold or one of the counts is negative.
+func NewGrouper(cfg GrouperConfig) (*Grouper, error) {
+	if cfg.Window < 0 {
+		return nil, errors.New("grouping window cannot be negative")
+	}
+
+	if cfg.Threshold < 0 {
+		return nil, errors.New("grouping threshold cannot be negative")
+	}
+
+	if cfg.SummaryFieldCount < 0 || cfg.SummaryLinkCount < 0 || cfg.MaxGroups < 0 {
+		return nil, errors.New("grouping summary field count, link count and max groups cannot be negative")
+	}
+
+	g := &Grouper{
+		window:            cmp.Or(cfg.Window, DefaultGroupingWindow),
+		threshold:         cmp.Or(cfg.Threshold, DefaultGroupingThreshold),
+		keyFunc:           cfg.KeyFunc,
+		summaryFieldCount: min(cmp.Or(cfg.SummaryFieldCount, DefaultGroupSummaryFieldCount), MaxFieldCount),
+		summaryLinkCount:  cmp.Or(cfg.SummaryLinkCount, DefaultGroupSummaryLinkCount),
+		maxGroups:         cmp.Or(cfg.MaxGroups, DefaultGrouperMaxGroups),
+		metrics:           cfg.Metrics,
+		groups:            make(map[string]*alertGroup),
+	}
+
+	if g.keyFunc == nil {
+		g.keyFunc = GroupByFields("slackChannelId", "routeKey", "type", "severity")
+	}
+
+	if g.metrics == nil {
+		g.metrics = &NoopMetrics{}
+	}
+
+	g.metrics.RegisterCounter(GroupedAlertsMetric, "Number of alerts replaced by a group summary alert", "channel")
+
+	return g, nil
+}
+
+// Add adds the alert to its group at the given time, and returns the alerts to send now, if any: the summary alert
+// when the group reaches the threshold, or the alert itself if it cannot be grouped (empty group key, or too many
+// open groups). Expired groups are flushed first, and their alerts are included in the result (see Flush).
+func (g *Grouper) Add(a *Alert, now time.Time) []*Alert {
+	if a == nil {
+		return nil
+	}
+
+	key := g.keyFunc(a)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	result := g.flush(now)
+
+	if key == "" {
+		return append(result, a)
+	}
+
+	group, ok := g.groups[key]
+	if !ok {
+		if len(g.groups) >= g.maxGroups {
+			return append(result, a)
+		}
+
+		g.seq++
+		group = &alertGroup{key: key, seq: g.seq, start: now}
+		g.groups[key] = group
+	}
+
+	group.count++
+
+	if len(group.alerts) < grouperMaxAlertsPerGroup {
+		group.alerts = append(group.alerts, a)
+	}
+
+	switch {
+	case group.count < g.threshold:
+		return result
+	case group.count > g.threshold:
+		// Absorbed by the summary already returned, and updated by Flush.
+		g.metrics.Inc(GroupedAlertsMetric, a.SlackChannelID)
+		return result
+	}
+
+	// All alerts accumulated so far are replaced by the summary.
+	for _, grouped := range group.alerts {
+		g.metrics.Inc(GroupedAlertsMetric, grouped.SlackChannelID)
+	}
+
+	group.summarized = group.count
+
+	return append(result, g.summary(group))
+}
+
+// Flush returns the alerts of the groups whose window has passed at the given time, and removes the groups:
+// an updated summary alert for groups that absorbed alerts after their summary was returned by Add,
+// and the accumulated alerts in their original order for groups below the threshold.
+func (g *Grouper) Flush(now time.Time) []*Alert {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.flush(now)
+}
+
+// FlushAll returns the alerts of all groups, regardless of their window, and removes the groups. See Flush.
+// It is typically called on shutdown.
+func (g *Grouper) FlushAll() []*Alert {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.flushGroups(func(*alertGroup) bool { return true })
+}
+
+// Len returns the number of open groups.
+func (g *Grouper) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return len(g.groups)
+}
+
+func (g *Grouper) flush(now time.Time) []*Alert {
+	return g.flushGroups(func(group *alertGroup) bool { return now.Sub(group.start) >= g.window })
+}
+
+func (g *Grouper) flushGroups(expired func(group *alertGroup) bool) []*Alert {
+	var groups []*alertGroup
+
+	for key, group := range g.groups {
+		if expired(group) {
+			groups = append(groups, group)
+			delete(g.groups, key)
+		}
+	}
+
+	// Flush the groups in the order they were opened.
+	slices.SortFunc(groups, func(a, b *alertGroup) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	var result []*Alert
+
+	for _, group := range groups {
+		switch {
+		case group.summarized == 0:
+			result = append(result, group.alerts...)
+		case group.count > group.summarized:
+			result = append(result, g.summary(group))
+		}
+	}
+
+	return result
+}
+
+func (g *Grouper) summary(group *alertGroup) *Alert {
+	summary := NewGroupSummaryAlert(group.alerts, group.count, g.summaryFieldCount, g.summaryLinkCount)
+	summary.CorrelationID = hash("group", group.key, group.start.UTC().Format(time.RFC3339Nano))
+
+	return summary
+}
+
+// NewGroupSummaryAlert returns an alert summarizing a group of alerts, where count is the total number of alerts
+// in the group (which may exceed the number of alerts given). The summary has the destination, type and follow-up
+// settings of the first alert, the highest severity and priority of the alerts, up to fieldCount of the most common
+// fields (with the number of alerts having each of them), and a text listing up to linkCount alerts with their links.
+//
+// The correlation ID of the summary is derived from the first alert. It should be replaced with a stable
+// value when the summary is updated, as done by Grouper. It returns nil if there are no alerts.
+func NewGroupSummaryAlert(alerts []*Alert, count, fieldCount, linkCount int) *Alert {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	first := alerts[0]
+	count = max(count, len(alerts))
+
+	summary := NewAlert(first.Severity)
+	summary.SlackChannelID = first.SlackChannelID
+	summary.RouteKey = first.RouteKey
+	summary.Type = first.Type
+	summary.Priority = first.Priority
+	summary.CorrelationID = hash("group", first.SlackChannelID, first.RouteKey, first.CorrelationID)
+	summary.IssueFollowUpEnabled = first.IssueFollowUpEnabled
+	summary.AutoResolveSeconds = first.AutoResolveSeconds
+	summary.Header = fmt.Sprintf(":status: %d alerts: %s", count, first.Header)
+	summary.Metadata = map[string]any{"groupedAlertCount": count}
+
+	for _, a := range alerts[1:] {
+		if SeverityPriority(a.Severity) > SeverityPriority(summary.Severity) {
+			summary.Severity = a.Severity
+		}
+
+		if ComparePriorities(a.Priority, summary.Priority) > 0 {
+			summary.Priority = a.Priority
+		}
+	}
+
+	summary.Fields = groupSummaryFields(alerts, fieldCount)
+
+	var text strings.Builder
+
+	for i, a := range alerts {
+		if i == linkCount {
+			break
+		}
+
+		line := a.Header
+		if line == "" {
+			line = truncateString(strings.ReplaceAll(a.Text, "\n", " "), MaxFieldValueLength)
+		}
+
+		if a.Host != "" {
+			line = fmt.Sprintf("%s (%s)", line, a.Host)
+		}
+
+		if a.Link != "" {
+			line = fmt.Sprintf("<%s|%s>", a.Link, line)
+		}
+
+		text.WriteString("• " + line + "\n")
+	}
+
+	if remaining := count - min(len(alerts), linkCount); remaining > 0 {
+		fmt.Fprintf(&text, "and %d more", remaining)
+	}
+
+	summary.Text = strings.TrimSpace(text.String())
+
+	return summary
+}
+
+// groupSummaryFields returns the most common fields (by title and value) of the alerts, with their counts.
+// Ties are broken by first occurrence.
+func groupSummaryFields(alerts []*Alert, fieldCount int) []*Field {
+	type fieldCounter struct {
+		field *Field
+		count int
+	}
+
+	var counters []*fieldCounter
+
+	index := make(map[Field]*fieldCounter)
+
+	for _, a := range alerts {
+		for _, f := range a.Fields {
+			if f == nil {
+				continue
+			}
+
+			if c, ok := index[*f]; ok {
+				c.count++
+				continue
+			}
+
+			c := &fieldCounter{field: f, count: 1}
+			index[*f] = c
+			counters = append(counters, c)
+		}
+	}
+
+	slices.SortStableFunc(counters, func(a, b *fieldCounter) int {
+		return cmp.Compare(b.count, a.count)
+	})
+
+	fields := make([]*Field, 0, min(fieldCount, len(counters)))
+
+	for _, c := range counters[:min(fieldCount, len(counters))] {
+		fields = append(fields, &Field{Title: c.field.Title, Value: fmt.Sprintf("%s (%d)", c.field.Value, c.count)})
+	}
+
+	return fields
+}
@@ -0,0 +1,149 @@
+package types_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHostAlert(host string, severity types.AlertSeverity) *types.Alert {
+	a := types.NewAlert(severity)
+	a.SlackChannelID = "C1"
+	a.Header = "Disk full"
+	a.Host = host
+	a.Link = "https://example.com/" + host
+	a.Fields = []*types.Field{{Title: "Cluster", Value: "eu-1"}, {Title: "Disk", Value: host + ":/data"}}
+
+	return a
+}
+
+func TestNewGrouper(t *testing.T) {
+	t.Parallel()
+
+	_, err := types.NewGrouper(types.GrouperConfig{Window: -time.Second})
+	require.EqualError(t, err, "grouping window cannot be negative")
+
+	_, err = types.NewGrouper(types.GrouperConfig{Threshold: -1})
+	require.EqualError(t, err, "grouping threshold cannot be negative")
+
+	_, err = types.NewGrouper(types.GrouperConfig{MaxGroups: -1})
+	require.EqualError(t, err, "grouping summary field count, link count and max groups cannot be negative")
+
+	g, err := types.NewGrouper(types.GrouperConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, g.Len())
+}
+
+func TestGrouper(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("groups reaching the threshold should be replaced by a summary", func(t *testing.T) {
+		t.Parallel()
+
+		metrics := &countingMetrics{}
+		g, err := types.NewGrouper(types.GrouperConfig{Window: time.Minute, Threshold: 3, Metrics: metrics})
+		require.NoError(t, err)
+
+		assert.Empty(t, g.Add(newHostAlert("host-1", types.AlertWarning), now))
+		assert.Empty(t, g.Add(newHostAlert("host-2", types.AlertWarning), now.Add(time.Second)))
+
+		result := g.Add(newHostAlert("host-3", types.AlertWarning), now.Add(2*time.Second))
+		require.Len(t, result, 1)
+
+		summary := result[0]
+		assert.Equal(t, ":status: 3 alerts: Disk full", summary.Header)
+		assert.Equal(t, "C1", summary.SlackChannelID)
+		assert.Equal(t, types.AlertWarning, summary.Severity)
+		assert.Equal(t, "• <https://example.com/host-1|Disk full (host-1)>\n• <https://example.com/host-2|Disk full (host-2)>\n• <https://example.com/host-3|Disk full (host-3)>", summary.Text)
+		assert.Equal(t, 3, summary.Metadata["groupedAlertCount"])
+		require.NotEmpty(t, summary.Fields)
+		assert.Equal(t, types.Field{Title: "Cluster", Value: "eu-1 (3)"}, *summary.Fields[0])
+		assert.Equal(t, types.Field{Title: "Disk", Value: "host-1:/data (1)"}, *summary.Fields[1])
+		assert.Len(t, summary.Fields, 4)
+
+		assert.Empty(t, g.Add(newHostAlert("host-4", types.AlertWarning), now.Add(3*time.Second)))
+		assert.Empty(t, g.Flush(now.Add(59*time.Second)))
+
+		flushed := g.Flush(now.Add(time.Minute))
+		require.Len(t, flushed, 1)
+		assert.Equal(t, ":status: 4 alerts: Disk full", flushed[0].Header)
+		assert.Equal(t, summary.CorrelationID, flushed[0].CorrelationID, "the updated summary should update the same issue")
+		assert.Equal(t, 0, g.Len())
+		assert.InDelta(t, 4, metrics.counts[types.GroupedAlertsMetric+"|C1"], 0)
+	})
+
+	t.Run("groups below the threshold should be flushed as is", func(t *testing.T) {
+		t.Parallel()
+
+		g, err := types.NewGrouper(types.GrouperConfig{Window: time.Minute, Threshold: 3})
+		require.NoError(t, err)
+
+		a := newHostAlert("host-1", types.AlertWarning)
+		b := newHostAlert("host-2", types.AlertWarning)
+		c := newHostAlert("host-3", types.AlertError)
+
+		assert.Empty(t, g.Add(a, now))
+		assert.Empty(t, g.Add(b, now))
+		assert.Empty(t, g.Add(c, now), "alerts with another severity should be grouped separately")
+		assert.Equal(t, 2, g.Len())
+
+		// Expired groups are flushed by Add as well.
+		d := newHostAlert("host-4", types.AlertWarning)
+		assert.Equal(t, []*types.Alert{a, b, c}, g.Add(d, now.Add(time.Minute)))
+		assert.Equal(t, []*types.Alert{d}, g.FlushAll())
+	})
+
+	t.Run("alerts which cannot be grouped should be returned at once", func(t *testing.T) {
+		t.Parallel()
+
+		g, err := types.NewGrouper(types.GrouperConfig{
+			MaxGroups: 1,
+			KeyFunc:   types.GroupByFields("metadata.service"),
+		})
+		require.NoError(t, err)
+
+		a := newHostAlert("host-1", types.AlertWarning)
+		a.Metadata = map[string]any{"service": "db"}
+		b := newHostAlert("host-2", types.AlertWarning)
+		b.Metadata = map[string]any{"service": "web"}
+
+		assert.Empty(t, g.Add(a, now))
+		assert.Equal(t, []*types.Alert{b}, g.Add(b, now))
+
+		g, err = types.NewGrouper(types.GrouperConfig{KeyFunc: func(*types.Alert) string { return "" }})
+		require.NoError(t, err)
+		assert.Equal(t, []*types.Alert{a}, g.Add(a, now))
+		assert.Empty(t, g.Add(nil, now))
+	})
+}
+
+func TestNewGroupSummaryAlert(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, types.NewGroupSummaryAlert(nil, 0, 5, 5))
+
+	alerts := make([]*types.Alert, 0, 4)
+	for i := range 4 {
+		alerts = append(alerts, newHostAlert(fmt.Sprintf("host-%d", i+1), types.AlertWarning))
+	}
+
+	alerts[1].Severity = types.AlertPanic
+	alerts[2].Priority = types.AlertPriorityP2
+	alerts[3].Link = ""
+
+	summary := types.NewGroupSummaryAlert(alerts, 10, 1, 2)
+	assert.Equal(t, ":status: 10 alerts: Disk full", summary.Header)
+	assert.Equal(t, types.AlertPanic, summary.Severity)
+	assert.Equal(t, types.AlertPriorityP2, summary.Priority)
+	assert.Equal(t, []*types.Field{{Title: "Cluster", Value: "eu-1 (4)"}}, summary.Fields)
+	assert.Equal(t, "• <https://example.com/host-1|Disk full (host-1)>\n• <https://example.com/host-2|Disk full (host-2)>\nand 8 more", summary.Text)
+
+	summary.Clean()
+	require.NoError(t, summary.Validate())
+}
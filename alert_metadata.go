@@ -0,0 +1,332 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxMetadataKeyCount is the maximum number of top-level metadata keys.
+	MaxMetadataKeyCount = 100
+	// MaxMetadataKeyLength is the maximum length of a metadata key.
+	MaxMetadataKeyLength = 200
+	// MaxMetadataDepth is the maximum nesting depth of metadata values. Scalars have depth 1, and each level of
+	// nested objects or arrays adds 1.
+	MaxMetadataDepth = 5
+	// MaxMetadataSize is the maximum size of the metadata serialized as JSON, in bytes.
+	// It keeps alerts within the message size limits of the queues.
+	MaxMetadataSize = 32 * 1024
+)
+
+// MetadataLimits are the limits applied to the alert metadata by CleanWithReport. Zero or negative fields use the
+// corresponding Max* constant, and values above the Max* constants are capped, so that cleaned metadata always
+// passes ValidateMetadata.
+type MetadataLimits struct {
+	// MaxKeys is the maximum number of top-level keys. The alphabetically last keys are dropped.
+	MaxKeys int `json:"maxKeys"`
+
+	// MaxDepth is the maximum nesting depth of values. Keys with deeper values are dropped.
+	MaxDepth int `json:"maxDepth"`
+
+	// MaxSize is the maximum serialized size of the metadata, in bytes. The largest values are truncated (strings)
+	// or dropped (other values) until the metadata fits.
+	MaxSize int `json:"maxSize"`
+}
+
+func (l MetadataLimits) withDefaults() MetadataLimits {
+	capLimit := func(value, maxValue int) int {
+		if value <= 0 || value > maxValue {
+			return maxValue
+		}
+
+		return value
+	}
+
+	return MetadataLimits{
+		MaxKeys:  capLimit(l.MaxKeys, MaxMetadataKeyCount),
+		MaxDepth: capLimit(l.MaxDepth, MaxMetadataDepth),
+		MaxSize:  capLimit(l.MaxSize, MaxMetadataSize),
+	}
+}
+
+// ValidateMetadata validates that the metadata is within the MaxMetadataKeyCount, MaxMetadataKeyLength,
+// MaxMetadataDepth and MaxMetadataSize limits, that all values can be serialized as JSON, and that keys with the
+// ReservedMetadataPrefix are well-known keys with valid values.
+func (a *Alert) ValidateMetadata() error {
+	if len(a.Metadata) == 0 {
+		return nil
+	}
+
+	if len(a.Metadata) > MaxMetadataKeyCount {
+		return fmt.Errorf("too many metadata keys, expected <=%d", MaxMetadataKeyCount)
+	}
+
+	size := 1
+
+	for _, key := range sortedMetadataKeys(a.Metadata) {
+		if key == "" {
+			return errors.New("metadata key cannot be empty")
+		}
+
+		if utf8.RuneCountInString(key) > MaxMetadataKeyLength {
+			return fmt.Errorf("metadata key '%s' is too long, expected length <=%d", truncateString(key, 50), MaxMetadataKeyLength)
+		}
+
+		entrySize, depth, err := metadataEntrySize(key, a.Metadata[key])
+		if err != nil {
+			return fmt.Errorf("metadata['%s'] cannot be serialized: %w", key, err)
+		}
+
+		if depth > MaxMetadataDepth {
+			return fmt.Errorf("metadata['%s'] is nested too deeply, expected depth <=%d", key, MaxMetadataDepth)
+		}
+
+		size += entrySize + 1
+	}
+
+	if size > MaxMetadataSize {
+		return fmt.Errorf("metadata is too large, expected serialized size <=%d bytes", MaxMetadataSize)
+	}
+
+	return a.validateReservedMetadata()
+}
+
+// cleanMetadata drops invalid, too deeply nested and surplus keys, and truncates or drops the largest values until
+// the metadata fits the limits. The dropped and truncated keys are added to the report, if not nil.
+func (a *Alert) cleanMetadata(limits MetadataLimits, report *CleanReport) {
+	if len(a.Metadata) == 0 {
+		return
+	}
+
+	limits = limits.withDefaults()
+
+	drop := func(key string) {
+		delete(a.Metadata, key)
+
+		if report != nil {
+			report.DroppedMetadataKeys = append(report.DroppedMetadataKeys, key)
+		}
+	}
+
+	type entry struct {
+		key  string
+		size int
+	}
+
+	keys := sortedMetadataKeys(a.Metadata)
+	entries := make([]entry, 0, len(keys))
+	size := 1
+
+	for _, key := range keys {
+		entrySize, depth, err := metadataEntrySize(key, a.Metadata[key])
+
+		switch {
+		case key == "" || utf8.RuneCountInString(key) > MaxMetadataKeyLength || err != nil || depth > limits.MaxDepth:
+			drop(key)
+		case len(entries) >= limits.MaxKeys:
+			drop(key)
+		default:
+			entries = append(entries, entry{key: key, size: entrySize})
+			size += entrySize + 1
+		}
+	}
+
+	if size <= limits.MaxSize {
+		return
+	}
+
+	// Shrink the largest values first, since they are the most likely to be blobs rather than useful metadata.
+	// Strings are truncated, but kept at a fair share of the size limit when the excess is larger than the string,
+	// and other values are dropped. Values are dropped if the metadata still does not fit.
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].size > entries[j].size
+	})
+
+	fairShare := limits.MaxSize / len(entries)
+
+	for _, e := range entries {
+		if size <= limits.MaxSize {
+			return
+		}
+
+		if s, ok := a.Metadata[e.key].(string); ok {
+			if truncated, newSize, ok := truncateMetadataString(e.key, s, max(e.size-(size-limits.MaxSize), fairShare)); ok {
+				a.Metadata[e.key] = truncated
+				size -= e.size - newSize
+
+				if report != nil {
+					report.TruncatedMetadataKeys = append(report.TruncatedMetadataKeys, e.key)
+				}
+
+				continue
+			}
+		}
+
+		drop(e.key)
+		size -= e.size + 1
+	}
+
+	for _, e := range entries {
+		if size <= limits.MaxSize {
+			return
+		}
+
+		if value, ok := a.Metadata[e.key]; ok {
+			entrySize, _, _ := metadataEntrySize(e.key, value)
+			drop(e.key)
+			size -= entrySize + 1
+		}
+	}
+}
+
+// truncateMetadataString truncates s with a "..." suffix, so that the serialized entry of the key fits in maxEntrySize
+// bytes. It returns false if not even a short prefix of the string fits.
+func truncateMetadataString(key, s string, maxEntrySize int) (string, int, bool) {
+	const minLength = 10
+
+	emptySize, _, _ := metadataEntrySize(key, "")
+	n := min(len(s), maxEntrySize-emptySize-len("..."))
+
+	for n >= minLength {
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+
+		truncated := s[:n] + "..."
+
+		// Escaped characters make the serialized string larger than the string itself, in which case the cut is
+		// moved further back until the entry fits.
+		size, _, err := metadataEntrySize(key, truncated)
+		if err == nil && size <= maxEntrySize {
+			return truncated, size, true
+		}
+
+		n -= max(size-maxEntrySize, 1)
+	}
+
+	return "", 0, false
+}
+
+// metadataEntrySize returns the serialized size of a metadata entry ("key":value), and the nesting depth of the value.
+func metadataEntrySize(key string, value any) (int, int, error) {
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	encodedKey, err := json.Marshal(key)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return len(encodedKey) + 1 + len(encodedValue), jsonDepth(encodedValue), nil
+}
+
+// jsonDepth returns the nesting depth of an encoded JSON value: 1 for scalars, plus 1 per level of objects or arrays.
+func jsonDepth(data []byte) int {
+	depth, maxDepth := 0, 0
+	inString, escaped := false, false
+
+	for _, c := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{' || c == '[':
+			depth++
+			maxDepth = max(maxDepth, depth)
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+
+	return maxDepth + 1
+}
+
+func sortedMetadataKeys(metadata map[string]any) []string {
+	keys := make([]string, 0, len(metadata))
+
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// GetMetadataValue returns the metadata value of the key, or nil if the key is missing.
+func (a *Alert) GetMetadataValue(key string) any {
+	if a == nil || a.Metadata == nil {
+		return nil
+	}
+
+	return a.Metadata[key]
+}
+
+// GetMetadataString returns the metadata value as a string, or an empty string if the key is missing or not a string.
+func (a *Alert) GetMetadataString(key string) string {
+	if val, ok := a.GetMetadataValue(key).(string); ok {
+		return val
+	}
+
+	return ""
+}
+
+// GetMetadataInt returns the metadata value as an int, or defaultValue if the key is missing or not an integer.
+// Integral float64 values (as decoded by encoding/json) and numeric strings are converted.
+func (a *Alert) GetMetadataInt(key string, defaultValue int) int {
+	if val, ok := coerceTo[int](a.GetMetadataValue(key)); ok {
+		return val
+	}
+
+	return defaultValue
+}
+
+// GetMetadataFloat returns the metadata value as a float64, or defaultValue if the key is missing or not a number.
+// Numeric strings are parsed.
+func (a *Alert) GetMetadataFloat(key string, defaultValue float64) float64 {
+	if val, ok := coerceTo[float64](a.GetMetadataValue(key)); ok {
+		return val
+	}
+
+	return defaultValue
+}
+
+// GetMetadataBool returns the metadata value as a bool, or defaultValue if the key is missing or not a bool.
+func (a *Alert) GetMetadataBool(key string, defaultValue bool) bool {
+	if val, ok := a.GetMetadataValue(key).(bool); ok {
+		return val
+	}
+
+	return defaultValue
+}
+
+// GetMetadataTime returns the metadata value as a time, or defaultValue if the key is missing or not a valid time.
+// Strings are parsed as RFC3339 timestamps. Numbers are parsed as Unix epoch timestamps, in seconds,
+// or in milliseconds for values >=1e12.
+func (a *Alert) GetMetadataTime(key string, defaultValue time.Time) time.Time {
+	if t, ok := coerceTime(a.GetMetadataValue(key)); ok {
+		return t
+	}
+
+	return defaultValue
+}
+
+// GetMetadataStringSlice returns the metadata value as a string slice, or an empty slice if the key is missing,
+// or if the value is not a list of strings.
+func (a *Alert) GetMetadataStringSlice(key string) []string {
+	if val, ok := coerceStringSlice(a.GetMetadataValue(key)); ok {
+		return val
+	}
+
+	return []string{}
+}
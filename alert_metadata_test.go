@@ -0,0 +1,172 @@
+package types_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertValidateMetadata(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(metadata map[string]any) *types.Alert {
+		a := types.NewErrorAlert()
+		a.SlackChannelID = "C12345678"
+		a.Header = "Disk full"
+		a.Metadata = metadata
+
+		return a
+	}
+
+	tooManyKeys := make(map[string]any)
+	for i := range types.MaxMetadataKeyCount + 1 {
+		tooManyKeys[fmt.Sprintf("key%d", i)] = i
+	}
+
+	tests := []struct {
+		metadata map[string]any
+		expected string
+	}{
+		{nil, ""},
+		{map[string]any{"service": "db", "nested": map[string]any{"a": []any{1, 2}}}, ""},
+		{tooManyKeys, "too many metadata keys, expected <=100"},
+		{map[string]any{"": 1}, "metadata key cannot be empty"},
+		{map[string]any{strings.Repeat("k", types.MaxMetadataKeyLength+1): 1}, "metadata key 'kkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkk' is too long, expected length <=200"},
+		{map[string]any{"fn": func() {}}, "metadata['fn'] cannot be serialized: json: unsupported type: func()"},
+		{map[string]any{"deep": map[string]any{"a": map[string]any{"b": map[string]any{"c": []any{[]any{1}}}}}}, "metadata['deep'] is nested too deeply, expected depth <=5"},
+		{map[string]any{"blob": strings.Repeat("x", types.MaxMetadataSize)}, "metadata is too large, expected serialized size <=32768 bytes"},
+	}
+
+	for _, tt := range tests {
+		err := newAlert(tt.metadata).Validate()
+
+		if tt.expected == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, tt.expected)
+		}
+	}
+}
+
+func TestAlertCleanMetadata(t *testing.T) {
+	t.Parallel()
+
+	t.Run("oversized values should be truncated or dropped", func(t *testing.T) {
+		t.Parallel()
+
+		a := types.Alert{Metadata: map[string]any{
+			"service": "db",
+			"log":     strings.Repeat("line\n", 10000),
+			"samples": make([]int, 20000),
+			"deep":    map[string]any{"a": map[string]any{"b": map[string]any{"c": []any{[]any{1}}}}},
+			"fn":      func() {},
+		}}
+
+		report := a.CleanWithReport(types.CleanOptions{})
+		require.NoError(t, a.ValidateMetadata())
+
+		assert.True(t, report.Truncated())
+		assert.Equal(t, []string{"deep", "fn", "samples"}, report.DroppedMetadataKeys)
+		assert.Equal(t, []string{"log"}, report.TruncatedMetadataKeys)
+		assert.Equal(t, "db", a.Metadata["service"])
+		assert.True(t, strings.HasSuffix(a.Metadata["log"].(string), "..."))
+		assert.Greater(t, len(a.Metadata["log"].(string)), types.MaxMetadataSize/4, "the log should be kept at a fair share of the size limit")
+	})
+
+	t.Run("Clean should leave oversized metadata for validation", func(t *testing.T) {
+		t.Parallel()
+
+		log := strings.Repeat("line\n", 10000)
+		a := types.Alert{Metadata: map[string]any{"service": "db", "log": log}}
+
+		a.Clean()
+		assert.Equal(t, map[string]any{"service": "db", "log": log}, a.Metadata)
+		require.EqualError(t, a.ValidateMetadata(), "metadata is too large, expected serialized size <=32768 bytes")
+	})
+
+	t.Run("custom limits should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		a := types.Alert{Metadata: map[string]any{
+			"a": 1,
+			"b": map[string]any{"c": 1},
+			"c": "<" + strings.Repeat("x", 200) + ">",
+			"d": 2,
+		}}
+
+		report := a.CleanWithReport(types.CleanOptions{MetadataLimits: types.MetadataLimits{MaxKeys: 2, MaxDepth: 1, MaxSize: 100}})
+		assert.Equal(t, []string{"b", "d"}, report.DroppedMetadataKeys)
+		assert.Equal(t, []string{"c"}, report.TruncatedMetadataKeys)
+		assert.Len(t, a.Metadata, 2)
+		assert.True(t, strings.HasPrefix(a.Metadata["c"].(string), "<xxx"))
+	})
+
+	t.Run("metadata within the limits should be left unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		metadata := map[string]any{"service": "db", "samples": []any{1.0, 2.0}}
+		a := types.Alert{Metadata: metadata}
+
+		report := a.CleanWithReport(types.CleanOptions{})
+		assert.False(t, report.Truncated())
+		assert.Equal(t, map[string]any{"service": "db", "samples": []any{1.0, 2.0}}, a.Metadata)
+	})
+}
+
+func TestAlertMetadataAccessors(t *testing.T) {
+	t.Parallel()
+
+	def := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	var nilAlert *types.Alert
+	assert.Nil(t, nilAlert.GetMetadataValue("key"))
+	assert.Empty(t, nilAlert.GetMetadataString("key"))
+	assert.Equal(t, 7, nilAlert.GetMetadataInt("key", 7))
+	assert.Empty(t, (&types.Alert{}).GetMetadataStringSlice("key"))
+
+	// Metadata decoded from JSON holds float64 numbers and []any lists.
+	var a types.Alert
+	require.NoError(t, json.Unmarshal([]byte(`{"metadata": {
+		"service": "db",
+		"shard": 3,
+		"ratio": 0.5,
+		"retries": "2",
+		"fraction": 2.5,
+		"enabled": true,
+		"since": "2026-03-01T10:00:00Z",
+		"sinceMillis": 1772359200000,
+		"tags": ["a", "b"],
+		"mixed": ["a", 1]
+	}}`), &a))
+
+	assert.Equal(t, "db", a.GetMetadataValue("service"))
+	assert.Equal(t, "db", a.GetMetadataString("service"))
+	assert.Empty(t, a.GetMetadataString("shard"))
+
+	assert.Equal(t, 3, a.GetMetadataInt("shard", 0))
+	assert.Equal(t, 2, a.GetMetadataInt("retries", 0))
+	assert.Equal(t, -1, a.GetMetadataInt("fraction", -1), "non-integral numbers should not be converted to int")
+	assert.Equal(t, -1, a.GetMetadataInt("service", -1))
+
+	assert.InDelta(t, 0.5, a.GetMetadataFloat("ratio", 0), 0)
+	assert.InDelta(t, 3.0, a.GetMetadataFloat("shard", 0), 0)
+	assert.InDelta(t, 1.5, a.GetMetadataFloat("missing", 1.5), 0)
+
+	assert.True(t, a.GetMetadataBool("enabled", false))
+	assert.True(t, a.GetMetadataBool("service", true))
+
+	assert.True(t, ts.Equal(a.GetMetadataTime("since", def)))
+	assert.True(t, ts.Equal(a.GetMetadataTime("sinceMillis", def)))
+	assert.Equal(t, def, a.GetMetadataTime("service", def))
+
+	assert.Equal(t, []string{"a", "b"}, a.GetMetadataStringSlice("tags"))
+	assert.Empty(t, a.GetMetadataStringSlice("mixed"))
+	assert.Empty(t, a.GetMetadataStringSlice("missing"))
+}
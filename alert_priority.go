@@ -0,0 +1,82 @@
+package types
+
+// AlertPriority represents the priority (urgency) of an alert, from p1 (most urgent) to p5 (least urgent).
+// Unlike AlertSeverity, which describes the condition, the priority drives how aggressively people are notified:
+// a warning can be urgent, and an error in a test environment can wait until tomorrow.
+//
+// The priority is optional. Alerts without a priority are notified according to their severity only.
+type AlertPriority string
+
+const (
+	// AlertPriorityP1 is used for critical alerts, requiring immediate attention.
+	AlertPriorityP1 AlertPriority = "p1"
+
+	// AlertPriorityP2 is used for high priority alerts.
+	AlertPriorityP2 AlertPriority = "p2"
+
+	// AlertPriorityP3 is used for normal priority alerts.
+	AlertPriorityP3 AlertPriority = "p3"
+
+	// AlertPriorityP4 is used for low priority alerts.
+	AlertPriorityP4 AlertPriority = "p4"
+
+	// AlertPriorityP5 is used for informational alerts, which require no attention.
+	AlertPriorityP5 AlertPriority = "p5"
+)
+
+// PriorityIsValid returns true if the priority is one of the predefined AlertPriority constants.
+// An empty priority is not valid, even though the Alert Priority field is optional.
+func PriorityIsValid(p AlertPriority) bool {
+	switch p {
+	case AlertPriorityP1, AlertPriorityP2, AlertPriorityP3, AlertPriorityP4, AlertPriorityP5:
+		return true
+	}
+	return false
+}
+
+// PriorityRank returns the rank of the priority, from 5 (p1) to 1 (p5), so that more urgent priorities rank higher.
+// An empty priority ranks 0, below all priorities, and an invalid priority ranks -1.
+func PriorityRank(p AlertPriority) int {
+	switch p {
+	case AlertPriorityP1:
+		return 5
+	case AlertPriorityP2:
+		return 4
+	case AlertPriorityP3:
+		return 3
+	case AlertPriorityP4:
+		return 2
+	case AlertPriorityP5:
+		return 1
+	case "":
+		return 0
+	default:
+		return -1
+	}
+}
+
+// ComparePriorities returns -1 if a is less urgent than b, 0 if they are equally urgent, and +1 if a is more urgent than b.
+// It can be used with slices.SortFunc, to sort alerts by ascending urgency.
+func ComparePriorities(a, b AlertPriority) int {
+	ra, rb := PriorityRank(a), PriorityRank(b)
+
+	switch {
+	case ra < rb:
+		return -1
+	case ra > rb:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ValidPriorities returns the valid priorities, from the most to the least urgent.
+func ValidPriorities() []string {
+	return []string{
+		string(AlertPriorityP1),
+		string(AlertPriorityP2),
+		string(AlertPriorityP3),
+		string(AlertPriorityP4),
+		string(AlertPriorityP5),
+	}
+}
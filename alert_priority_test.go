@@ -0,0 +1,63 @@
+package types_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertPriorityValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range types.ValidPriorities() {
+		assert.True(t, types.PriorityIsValid(types.AlertPriority(p)))
+	}
+
+	assert.False(t, types.PriorityIsValid(""))
+	assert.False(t, types.PriorityIsValid("P1"))
+	assert.False(t, types.PriorityIsValid("high"))
+}
+
+func TestPriorityRank(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 5, types.PriorityRank(types.AlertPriorityP1))
+	assert.Equal(t, 4, types.PriorityRank(types.AlertPriorityP2))
+	assert.Equal(t, 3, types.PriorityRank(types.AlertPriorityP3))
+	assert.Equal(t, 2, types.PriorityRank(types.AlertPriorityP4))
+	assert.Equal(t, 1, types.PriorityRank(types.AlertPriorityP5))
+	assert.Equal(t, 0, types.PriorityRank(""))
+	assert.Equal(t, -1, types.PriorityRank("invalid"))
+}
+
+func TestComparePriorities(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1, types.ComparePriorities(types.AlertPriorityP1, types.AlertPriorityP2))
+	assert.Equal(t, -1, types.ComparePriorities(types.AlertPriorityP5, types.AlertPriorityP4))
+	assert.Equal(t, 0, types.ComparePriorities(types.AlertPriorityP3, types.AlertPriorityP3))
+	assert.Equal(t, -1, types.ComparePriorities("", types.AlertPriorityP5))
+
+	priorities := []types.AlertPriority{types.AlertPriorityP3, "", types.AlertPriorityP1, types.AlertPriorityP5}
+	slices.SortFunc(priorities, types.ComparePriorities)
+	assert.Equal(t, []types.AlertPriority{"", types.AlertPriorityP5, types.AlertPriorityP3, types.AlertPriorityP1}, priorities)
+}
+
+func TestAlertPriorityField(t *testing.T) {
+	t.Parallel()
+
+	a := types.NewErrorAlert()
+	a.Header = "header"
+	a.Priority = " P2 "
+	a.Clean()
+	assert.Equal(t, types.AlertPriorityP2, a.Priority)
+	assert.NoError(t, a.Validate())
+
+	a.Priority = ""
+	assert.NoError(t, a.Validate())
+
+	a.Priority = "high"
+	assert.EqualError(t, a.Validate(), "priority 'high' is not valid, expected one of [p1, p2, p3, p4, p5]")
+}
@@ -0,0 +1,239 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// SuppressionSummaryCorrelationIDSuffix is appended to the correlation ID of the suppressed alerts, to form the
+	// correlation ID of the summary alert returned by NewSuppressionSummaryAlert.
+	SuppressionSummaryCorrelationIDSuffix = ":suppressed"
+
+	// alertRateLimiterPruneInterval is the number of Decide calls between each removal of idle buckets.
+	alertRateLimiterPruneInterval = 1000
+
+	// alertRateLimiterSuppressedRetention is how long the suppressed count of a correlation ID is kept after its last
+	// suppressed alert, or longer if the rate limits take longer to refill.
+	alertRateLimiterSuppressedRetention = time.Hour
+)
+
+// RateLimit is a token bucket rate limit. Up to Burst alerts are allowed at once, and one token is added back
+// to the bucket every Interval. A zero RateLimit means no limit.
+type RateLimit struct {
+	Burst    int
+	Interval time.Duration
+}
+
+// AlertRateLimiterConfig holds the configuration of an AlertRateLimiter.
+type AlertRateLimiterConfig struct {
+	// PerCorrelationID limits alerts with the same Slack channel ID, route key and correlation ID.
+	PerCorrelationID RateLimit
+
+	// PerChannel limits alerts with the same Slack channel ID and route key, regardless of correlation ID.
+	PerChannel RateLimit
+}
+
+// AlertRateLimiter suppresses alert storms, using a token bucket per correlation ID and per channel.
+// An alert is allowed if both buckets have a token available. It is safe for concurrent use.
+//
+// The rate limiter is in-memory only, and is thus only effective within a single process.
+type AlertRateLimiter struct {
+	cfg AlertRateLimiterConfig
+
+	mu         sync.Mutex
+	buckets    map[string]*tokenBucket
+	suppressed map[string]*suppressedCount
+	calls      int
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type suppressedCount struct {
+	count int
+	last  time.Time
+}
+
+// NewAlertRateLimiter creates a new AlertRateLimiter with the given configuration.
+// An error is returned if a rate limit has a negative burst or interval, or if only one of them is set.
+func NewAlertRateLimiter(cfg AlertRateLimiterConfig) (*AlertRateLimiter, error) {
+	if err := cfg.PerCorrelationID.validate(); err != nil {
+		return nil, fmt.Errorf("invalid per correlation ID rate limit: %w", err)
+	}
+
+	if err := cfg.PerChannel.validate(); err != nil {
+		return nil, fmt.Errorf("invalid per channel rate limit: %w", err)
+	}
+
+	return &AlertRateLimiter{
+		cfg:        cfg,
+		buckets:    make(map[string]*tokenBucket),
+		suppressed: make(map[string]*suppressedCount),
+	}, nil
+}
+
+// Decide decides whether the alert is allowed at the current time. See DecideAt.
+func (l *AlertRateLimiter) Decide(a *Alert) (bool, int) {
+	return l.DecideAt(a, time.Now())
+}
+
+// DecideAt decides whether the alert is allowed at the given time.
+//
+// If the alert is allowed, the returned count is the number of alerts with the same correlation ID suppressed since
+// the previous allowed alert, and the count is reset. Callers should send a summary alert (see NewSuppressionSummaryAlert)
+// when the count is non-zero. If the alert is suppressed, the returned count is the number of alerts suppressed so far,
+// including this one. Suppressed counts are dropped when no alert with the same correlation ID has been suppressed
+// for an hour, or for the time needed to refill the rate limits, if longer.
+func (l *AlertRateLimiter) DecideAt(a *Alert, now time.Time) (bool, int) {
+	if a == nil {
+		return true, 0
+	}
+
+	correlationKey := hash("correlation", a.SlackChannelID, a.RouteKey, a.CorrelationID)
+	channelKey := hash("channel", a.SlackChannelID, a.RouteKey)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls++
+
+	if l.calls%alertRateLimiterPruneInterval == 0 {
+		l.prune(now)
+	}
+
+	correlationBucket := l.bucket(correlationKey, l.cfg.PerCorrelationID, now)
+	channelBucket := l.bucket(channelKey, l.cfg.PerChannel, now)
+
+	if (correlationBucket != nil && correlationBucket.tokens < 1) || (channelBucket != nil && channelBucket.tokens < 1) {
+		s, ok := l.suppressed[correlationKey]
+		if !ok {
+			s = &suppressedCount{}
+			l.suppressed[correlationKey] = s
+		}
+
+		s.count++
+		s.last = now
+
+		return false, s.count
+	}
+
+	if correlationBucket != nil {
+		correlationBucket.tokens--
+	}
+
+	if channelBucket != nil {
+		channelBucket.tokens--
+	}
+
+	var count int
+
+	if s, ok := l.suppressed[correlationKey]; ok {
+		count = s.count
+		delete(l.suppressed, correlationKey)
+	}
+
+	return true, count
+}
+
+// bucket returns the refilled bucket with the given key, or nil if the rate limit is disabled.
+func (l *AlertRateLimiter) bucket(key string, limit RateLimit, now time.Time) *tokenBucket {
+	if limit.isZero() {
+		return nil
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+		return b
+	}
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(float64(limit.Burst), b.tokens+float64(elapsed)/float64(limit.Interval))
+		b.last = now
+	}
+
+	return b
+}
+
+// prune removes suppressed counts older than the retention, and buckets that have been idle long enough to be full
+// again and have no pending suppressed count. Idle buckets are recreated as full buckets when needed, so this
+// does not change any decisions.
+func (l *AlertRateLimiter) prune(now time.Time) {
+	maxIdle := max(
+		time.Duration(l.cfg.PerCorrelationID.Burst)*l.cfg.PerCorrelationID.Interval,
+		time.Duration(l.cfg.PerChannel.Burst)*l.cfg.PerChannel.Interval,
+	)
+
+	retention := max(maxIdle, alertRateLimiterSuppressedRetention)
+
+	for key, s := range l.suppressed {
+		if now.Sub(s.last) >= retention {
+			delete(l.suppressed, key)
+		}
+	}
+
+	for key, b := range l.buckets {
+		if _, pending := l.suppressed[key]; !pending && now.Sub(b.last) >= maxIdle {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (r RateLimit) isZero() bool {
+	return r.Burst == 0 && r.Interval == 0
+}
+
+func (r RateLimit) validate() error {
+	if r.isZero() {
+		return nil
+	}
+
+	if r.Burst <= 0 {
+		return errors.New("burst must be positive")
+	}
+
+	if r.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+
+	return nil
+}
+
+// NewSuppressionSummaryAlert returns an alert summarizing the number of alerts suppressed by an AlertRateLimiter.
+// The summary has the same destination and severity as the specified alert, but a correlation ID of its own
+// (see suppressionSummaryCorrelationID), so that it is posted as a separate issue instead of overwriting the
+// header and text of the issue of the suppressed alerts.
+func NewSuppressionSummaryAlert(a *Alert, suppressedCount int) *Alert {
+	summary := NewAlert(a.Severity)
+
+	summary.SlackChannelID = a.SlackChannelID
+	summary.RouteKey = a.RouteKey
+	summary.CorrelationID = suppressionSummaryCorrelationID(a)
+	summary.IssueFollowUpEnabled = a.IssueFollowUpEnabled
+	summary.Header = fmt.Sprintf(":mute: %d similar alerts suppressed", suppressedCount)
+	summary.Text = fmt.Sprintf("%d alerts similar to '%s' were suppressed by rate limiting.", suppressedCount, a.Header)
+
+	return summary
+}
+
+// suppressionSummaryCorrelationID returns the correlation ID of the alert followed by SuppressionSummaryCorrelationIDSuffix.
+// Alerts without correlation ID use a hash of the fields of the default correlation ID instead, and IDs that would
+// exceed MaxCorrelationIDLength are hashed.
+func suppressionSummaryCorrelationID(a *Alert) string {
+	base := a.CorrelationID
+	if base == "" {
+		base = hash(a.Header, a.Text, a.Author, a.Host, a.SlackChannelID)
+	}
+
+	if len(base)+len(SuppressionSummaryCorrelationIDSuffix) > MaxCorrelationIDLength {
+		base = hash(base)
+	}
+
+	return base + SuppressionSummaryCorrelationIDSuffix
+}
@@ -0,0 +1,140 @@
+package types_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAlertRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	_, err := types.NewAlertRateLimiter(types.AlertRateLimiterConfig{PerCorrelationID: types.RateLimit{Burst: 1}})
+	require.EqualError(t, err, "invalid per correlation ID rate limit: interval must be positive")
+
+	_, err = types.NewAlertRateLimiter(types.AlertRateLimiterConfig{PerChannel: types.RateLimit{Interval: time.Second}})
+	require.EqualError(t, err, "invalid per channel rate limit: burst must be positive")
+
+	l, err := types.NewAlertRateLimiter(types.AlertRateLimiterConfig{})
+	require.NoError(t, err)
+
+	for range 100 {
+		allow, count := l.Decide(&types.Alert{SlackChannelID: "C1"})
+		assert.True(t, allow)
+		assert.Equal(t, 0, count)
+	}
+}
+
+func TestAlertRateLimiterPerCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	l, err := types.NewAlertRateLimiter(types.AlertRateLimiterConfig{
+		PerCorrelationID: types.RateLimit{Burst: 2, Interval: 10 * time.Second},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	a := &types.Alert{SlackChannelID: "C1", CorrelationID: "a"}
+	b := &types.Alert{SlackChannelID: "C1", CorrelationID: "b"}
+
+	assertDecision(t, l, a, now, true, 0)
+	assertDecision(t, l, a, now, true, 0)
+	assertDecision(t, l, a, now, false, 1)
+	assertDecision(t, l, a, now.Add(5*time.Second), false, 2)
+	assertDecision(t, l, b, now.Add(5*time.Second), true, 0)
+
+	// One token is added back after 10 seconds, and the suppressed count is reported and reset
+	assertDecision(t, l, a, now.Add(10*time.Second), true, 2)
+	assertDecision(t, l, a, now.Add(11*time.Second), false, 1)
+}
+
+func TestAlertRateLimiterPerChannel(t *testing.T) {
+	t.Parallel()
+
+	l, err := types.NewAlertRateLimiter(types.AlertRateLimiterConfig{
+		PerCorrelationID: types.RateLimit{Burst: 5, Interval: time.Second},
+		PerChannel:       types.RateLimit{Burst: 2, Interval: time.Minute},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	assertDecision(t, l, &types.Alert{SlackChannelID: "C1", CorrelationID: "a"}, now, true, 0)
+	assertDecision(t, l, &types.Alert{SlackChannelID: "C1", CorrelationID: "b"}, now, true, 0)
+	assertDecision(t, l, &types.Alert{SlackChannelID: "C1", CorrelationID: "c"}, now, false, 1)
+	assertDecision(t, l, &types.Alert{SlackChannelID: "C2", CorrelationID: "c"}, now, true, 0)
+}
+
+func TestAlertRateLimiterPrunesSuppressedCounts(t *testing.T) {
+	t.Parallel()
+
+	suppress := func(elapsed time.Duration) *types.AlertRateLimiter {
+		l, err := types.NewAlertRateLimiter(types.AlertRateLimiterConfig{
+			PerChannel: types.RateLimit{Burst: 1, Interval: time.Second},
+		})
+		require.NoError(t, err)
+
+		now := time.Now()
+
+		assertDecision(t, l, &types.Alert{SlackChannelID: "C1", CorrelationID: "x"}, now, true, 0)
+		assertDecision(t, l, &types.Alert{SlackChannelID: "C1", CorrelationID: "a"}, now, false, 1)
+
+		// Unique correlation IDs in another channel, to trigger pruning.
+		for i := range 1000 {
+			l.DecideAt(&types.Alert{SlackChannelID: "C2", CorrelationID: fmt.Sprint(i)}, now.Add(elapsed))
+		}
+
+		return l
+	}
+
+	now := time.Now()
+
+	assertDecision(t, suppress(30*time.Minute), &types.Alert{SlackChannelID: "C1", CorrelationID: "a"}, now.Add(30*time.Minute), true, 1)
+	assertDecision(t, suppress(2*time.Hour), &types.Alert{SlackChannelID: "C1", CorrelationID: "a"}, now.Add(2*time.Hour), true, 0)
+}
+
+func TestNewSuppressionSummaryAlert(t *testing.T) {
+	t.Parallel()
+
+	a := types.NewErrorAlert()
+	a.SlackChannelID = "C1"
+	a.CorrelationID = "x"
+	a.Header = "Disk full"
+
+	summary := types.NewSuppressionSummaryAlert(a, 7)
+
+	assert.Equal(t, "C1", summary.SlackChannelID)
+	assert.Equal(t, "x"+types.SuppressionSummaryCorrelationIDSuffix, summary.CorrelationID, "the issue of the suppressed alerts should not be updated")
+	assert.Equal(t, types.AlertError, summary.Severity)
+	assert.Equal(t, ":mute: 7 similar alerts suppressed", summary.Header)
+	assert.Contains(t, summary.Text, "'Disk full'")
+
+	summary.Clean()
+	require.NoError(t, summary.Validate())
+
+	// Long correlation IDs are hashed, so that the summary ID fits MaxCorrelationIDLength.
+	a.CorrelationID = strings.Repeat("x", types.MaxCorrelationIDLength)
+	summary = types.NewSuppressionSummaryAlert(a, 7)
+	assert.NotEqual(t, a.CorrelationID, summary.CorrelationID)
+	assert.LessOrEqual(t, len(summary.CorrelationID), types.MaxCorrelationIDLength)
+	assert.True(t, strings.HasSuffix(summary.CorrelationID, types.SuppressionSummaryCorrelationIDSuffix))
+
+	// Alerts without correlation ID get a summary ID derived from their content.
+	a.CorrelationID = ""
+	summary = types.NewSuppressionSummaryAlert(a, 7)
+	assert.NotEqual(t, types.SuppressionSummaryCorrelationIDSuffix, summary.CorrelationID)
+	assert.Equal(t, summary.CorrelationID, types.NewSuppressionSummaryAlert(a, 8).CorrelationID)
+}
+
+func assertDecision(t *testing.T, l *types.AlertRateLimiter, a *types.Alert, now time.Time, expectedAllow bool, expectedCount int) {
+	t.Helper()
+
+	allow, count := l.DecideAt(a, now)
+	assert.Equal(t, expectedAllow, allow)
+	assert.Equal(t, expectedCount, count)
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// MaxRunbookIDLength is the maximum length of a runbook ID.
+const MaxRunbookIDLength = 100
+
+// RunbookIDRegex matches valid runbook IDs, such as 'RB-123' or 'db/disk-full'.
+var RunbookIDRegex = regexp.MustCompile(fmt.Sprintf(`^[a-zA-Z0-9][a-zA-Z0-9._:/\-]{0,%d}$`, MaxRunbookIDLength-1))
+
+// ValidateRunbook validates that RunbookURL, if set, is a valid absolute URL with a scheme (like Link),
+// and that RunbookID, if set, matches RunbookIDRegex.
+func (a *Alert) ValidateRunbook() error {
+	if a.RunbookURL != "" {
+		if len(a.RunbookURL) > MaxWebhookURLLength {
+			return fmt.Errorf("runbookUrl is too long, expected length <=%d", MaxWebhookURLLength)
+		}
+
+		if u, err := url.ParseRequestURI(a.RunbookURL); err != nil || u.Scheme == "" {
+			return errors.New("runbookUrl is not a valid absolute URL")
+		}
+	}
+
+	if a.RunbookID != "" && !RunbookIDRegex.MatchString(a.RunbookID) {
+		return fmt.Errorf("runbookId '%s' is not valid, expected letters, digits, '.', '_', ':', '/' and '-', with length <=%d", truncateString(a.RunbookID, 50), MaxRunbookIDLength)
+	}
+
+	return nil
+}
+
+// HasRunbook returns true if the alert refers to a runbook, by URL or ID.
+func (a *Alert) HasRunbook() bool {
+	return a != nil && (a.RunbookURL != "" || a.RunbookID != "")
+}
+
+// RunbookContextText returns the runbook reference as Slack mrkdwn, suitable for a context block rendered
+// separately from Link, e.g. '<https://runbooks.example.com/db|Runbook RB-123>'. It returns an empty string
+// if the alert has no runbook.
+func (a *Alert) RunbookContextText() string {
+	if !a.HasRunbook() {
+		return ""
+	}
+
+	label := "Runbook"
+
+	if a.RunbookID != "" {
+		label += " " + a.RunbookID
+	}
+
+	if a.RunbookURL == "" {
+		return label
+	}
+
+	return "<" + a.RunbookURL + "|" + label + ">"
+}
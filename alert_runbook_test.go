@@ -0,0 +1,64 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertRunbook(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(runbookURL, runbookID string) *types.Alert {
+		a := types.NewErrorAlert()
+		a.SlackChannelID = "C12345678"
+		a.Header = "Disk full"
+		a.RunbookURL = runbookURL
+		a.RunbookID = runbookID
+
+		return a
+	}
+
+	t.Run("runbook should be cleaned and accepted", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(" https://runbooks.example.com/db/disk-full ", " db/disk-full ")
+		a.Clean()
+		require.NoError(t, a.Validate())
+
+		assert.Equal(t, "https://runbooks.example.com/db/disk-full", a.RunbookURL)
+		assert.Equal(t, "db/disk-full", a.RunbookID)
+		assert.True(t, a.HasRunbook())
+	})
+
+	t.Run("invalid runbook should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		require.EqualError(t, newAlert("runbooks/db", "").ValidateRunbook(), "runbookUrl is not a valid absolute URL")
+		require.EqualError(t, newAlert("https://example.com/"+strings.Repeat("a", 1000), "").ValidateRunbook(), "runbookUrl is too long, expected length <=1000")
+		require.EqualError(t, newAlert("", "disk full").ValidateRunbook(),
+			"runbookId 'disk full' is not valid, expected letters, digits, '.', '_', ':', '/' and '-', with length <=100")
+		require.ErrorContains(t, newAlert("", strings.Repeat("a", 101)).ValidateRunbook(), "is not valid")
+		require.NoError(t, newAlert("", strings.Repeat("a", 100)).ValidateRunbook())
+	})
+
+	t.Run("runbook context text should be rendered separately from the link", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, newAlert("", "").RunbookContextText())
+		assert.False(t, newAlert("", "").HasRunbook())
+		assert.Equal(t, "Runbook RB-1", newAlert("", "RB-1").RunbookContextText())
+		assert.Equal(t, "<https://runbooks.example.com/db|Runbook>", newAlert("https://runbooks.example.com/db", "").RunbookContextText())
+		assert.Equal(t, "<https://runbooks.example.com/db|Runbook RB-1>", newAlert("https://runbooks.example.com/db", "RB-1").RunbookContextText())
+	})
+
+	t.Run("runbook fields should be available to matchers", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, types.AlertFieldIsValid("runbookUrl"))
+		assert.True(t, types.AlertFieldIsValid("runbookId"))
+	})
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxAlertSourceFieldLength is the maximum length of each field of an alert source.
+	MaxAlertSourceFieldLength = 100
+
+	// IngestPathClient is the ingest path of alerts sent with the client SDK.
+	IngestPathClient = "client"
+	// IngestPathAdapterPrefix is the prefix of the ingest path of alerts converted by an adapter, e.g. 'adapter/alertmanager'.
+	IngestPathAdapterPrefix = "adapter/"
+)
+
+// AlertSource describes the system that emitted an alert, and how the alert reached the Slack Manager.
+// It is displayed as a context block in the Slack post, so that the origin of a post can be identified at a glance.
+type AlertSource struct {
+	// System is the name of the system that emitted the alert, e.g. 'prometheus' or 'billing-service'.
+	// This field is required if the source is set.
+	// It is automatically truncated at MaxAlertSourceFieldLength characters.
+	System string `json:"system"`
+
+	// Version is the version of the emitting system, e.g. '2.45.0'.
+	// It is automatically truncated at MaxAlertSourceFieldLength characters.
+	Version string `json:"version"`
+
+	// Region is the region or data center of the emitting system, e.g. 'eu-west-1'.
+	// It is automatically truncated at MaxAlertSourceFieldLength characters.
+	Region string `json:"region"`
+
+	// Instance is the instance of the emitting system, e.g. a host name, pod name or task ID.
+	// It is automatically truncated at MaxAlertSourceFieldLength characters.
+	Instance string `json:"instance"`
+
+	// IngestPath is the path by which the alert reached the Slack Manager, e.g. IngestPathClient, or
+	// IngestPathAdapterPrefix followed by the adapter name.
+	// It is automatically truncated at MaxAlertSourceFieldLength characters.
+	IngestPath string `json:"ingestPath"`
+}
+
+// Clean normalizes the source fields, collapsing whitespace and line breaks to single spaces and truncating long values.
+func (s *AlertSource) Clean() {
+	if s == nil {
+		return
+	}
+
+	for _, field := range s.fields() {
+		*field.value = truncateText(strings.Join(strings.Fields(*field.value), " "), MaxAlertSourceFieldLength)
+	}
+}
+
+// ContextText returns the source as a single line of text, suitable for a Slack context block,
+// e.g. 'prometheus 2.45.0 · eu-west-1 · prom-0 · via adapter/alertmanager'. Empty fields are omitted.
+func (s *AlertSource) ContextText() string {
+	if s == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, 4)
+
+	if system := strings.TrimSpace(s.System + " " + s.Version); system != "" {
+		parts = append(parts, system)
+	}
+
+	if s.Region != "" {
+		parts = append(parts, s.Region)
+	}
+
+	if s.Instance != "" {
+		parts = append(parts, s.Instance)
+	}
+
+	if s.IngestPath != "" {
+		parts = append(parts, "via "+s.IngestPath)
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// ValidateSource validates that the source, if set, has a system, and that all fields are within
+// MaxAlertSourceFieldLength characters and contain no line breaks.
+func (a *Alert) ValidateSource() error {
+	if a.Source == nil {
+		return nil
+	}
+
+	if a.Source.System == "" {
+		return errors.New("source.system is required")
+	}
+
+	for _, field := range a.Source.fields() {
+		if utf8.RuneCountInString(*field.value) > MaxAlertSourceFieldLength {
+			return fmt.Errorf("source.%s is too long, expected length <=%d", field.name, MaxAlertSourceFieldLength)
+		}
+
+		if strings.ContainsAny(*field.value, "\r\n") {
+			return fmt.Errorf("source.%s cannot contain line breaks", field.name)
+		}
+	}
+
+	return nil
+}
+
+type alertSourceField struct {
+	name  string
+	value *string
+}
+
+func (s *AlertSource) fields() []alertSourceField {
+	return []alertSourceField{
+		{name: "system", value: &s.System},
+		{name: "version", value: &s.Version},
+		{name: "region", value: &s.Region},
+		{name: "instance", value: &s.Instance},
+		{name: "ingestPath", value: &s.IngestPath},
+	}
+}
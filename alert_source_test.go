@@ -0,0 +1,78 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertSource(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(source *types.AlertSource) *types.Alert {
+		a := types.NewErrorAlert()
+		a.SlackChannelID = "C12345678"
+		a.Header = "Disk full"
+		a.Source = source
+
+		return a
+	}
+
+	t.Run("source should be cleaned and accepted", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(&types.AlertSource{
+			System:     " prometheus ",
+			Version:    "2.45.0\n",
+			Region:     "eu-west-1",
+			Instance:   "prom-0\n  eu",
+			IngestPath: "adapter/alertmanager",
+		})
+		a.Clean()
+		require.NoError(t, a.Validate())
+
+		assert.Equal(t, "prometheus", a.Source.System)
+		assert.Equal(t, "2.45.0", a.Source.Version)
+		assert.Equal(t, "prom-0 eu", a.Source.Instance)
+
+		long := newAlert(&types.AlertSource{System: strings.Repeat("a", 150)})
+		long.Clean()
+		require.NoError(t, long.Validate())
+		assert.Equal(t, strings.Repeat("a", 97)+"...", long.Source.System)
+	})
+
+	t.Run("nil source should be accepted", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(nil)
+		a.Clean()
+		require.NoError(t, a.Validate())
+		assert.Empty(t, a.Source.ContextText())
+	})
+
+	t.Run("invalid source should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(&types.AlertSource{Region: "eu-west-1"})
+		require.EqualError(t, a.ValidateSource(), "source.system is required")
+
+		a = newAlert(&types.AlertSource{System: "prometheus", Instance: strings.Repeat("a", 101)})
+		require.EqualError(t, a.ValidateSource(), "source.instance is too long, expected length <=100")
+
+		a = newAlert(&types.AlertSource{System: "prometheus", IngestPath: "a\nb"})
+		require.EqualError(t, a.ValidateSource(), "source.ingestPath cannot contain line breaks")
+	})
+
+	t.Run("context text should join the non-empty fields", func(t *testing.T) {
+		t.Parallel()
+
+		source := &types.AlertSource{System: "prometheus", Version: "2.45.0", Region: "eu-west-1", Instance: "prom-0", IngestPath: "adapter/alertmanager"}
+		assert.Equal(t, "prometheus 2.45.0 · eu-west-1 · prom-0 · via adapter/alertmanager", source.ContextText())
+
+		source = &types.AlertSource{System: "billing", IngestPath: types.IngestPathClient}
+		assert.Equal(t, "billing · via client", source.ContextText())
+	})
+}
@@ -0,0 +1,312 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// AlertTemplateVariableRegex matches valid alert template variable names.
+	AlertTemplateVariableRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// alertTemplatePlaceholderRegex matches variable placeholders on the format '${name}' in alert template string fields.
+	alertTemplatePlaceholderRegex = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+)
+
+const (
+	// MaxAlertTemplateNameLength is the maximum length of an alert template name.
+	MaxAlertTemplateNameLength = 100
+	// MaxAlertTemplateVariableCount is the maximum number of required variables per alert template.
+	MaxAlertTemplateVariableCount = 50
+)
+
+// AlertTemplate is a named, centrally managed alert shape.
+// Producers instantiate the template with a set of variables, rather than building the full alert themselves.
+//
+// Any string field in Defaults (including fields, webhooks, escalations and metadata) may contain placeholders
+// on the format '${name}', which are replaced with the corresponding variable when the template is instantiated.
+type AlertTemplate struct {
+	// Name is the unique name of the template.
+	// Maximum length: MaxAlertTemplateNameLength characters.
+	Name string `json:"name"`
+
+	// Defaults is the alert used as the base for all alerts instantiated from this template.
+	Defaults Alert `json:"defaults"`
+
+	// RequiredVariables is the list of variables that must be provided when instantiating the template.
+	// Variable names must match AlertTemplateVariableRegex.
+	// Maximum of MaxAlertTemplateVariableCount items.
+	RequiredVariables []string `json:"requiredVariables"`
+}
+
+// Validate returns an error if the template name or the required variables are invalid.
+// The template defaults are validated when an alert is instantiated, since they may contain placeholders.
+func (t *AlertTemplate) Validate() error {
+	if t == nil {
+		return errors.New("alert template is nil")
+	}
+
+	if t.Name == "" {
+		return errors.New("alert template name is required")
+	}
+
+	if len(t.Name) > MaxAlertTemplateNameLength {
+		return fmt.Errorf("alert template name is too long, expected length <=%d", MaxAlertTemplateNameLength)
+	}
+
+	if len(t.RequiredVariables) > MaxAlertTemplateVariableCount {
+		return fmt.Errorf("alert template '%s' has too many required variables, expected <=%d", t.Name, MaxAlertTemplateVariableCount)
+	}
+
+	seen := make(map[string]struct{}, len(t.RequiredVariables))
+
+	for index, v := range t.RequiredVariables {
+		if !AlertTemplateVariableRegex.MatchString(v) {
+			return fmt.Errorf("alert template '%s' requiredVariables[%d] '%s' is not a valid variable name", t.Name, index, v)
+		}
+
+		if _, ok := seen[v]; ok {
+			return fmt.Errorf("alert template '%s' requiredVariables[%d] '%s' must be unique", t.Name, index, v)
+		}
+
+		seen[v] = struct{}{}
+	}
+
+	return nil
+}
+
+// AlertTemplateRegistry is a concurrency safe registry of alert templates.
+type AlertTemplateRegistry struct {
+	mu        sync.RWMutex
+	templates map[string]*AlertTemplate
+}
+
+// NewAlertTemplateRegistry creates a new, empty AlertTemplateRegistry.
+func NewAlertTemplateRegistry() *AlertTemplateRegistry {
+	return &AlertTemplateRegistry{
+		templates: make(map[string]*AlertTemplate),
+	}
+}
+
+// Register validates and adds a template to the registry.
+// An error is returned if the template is invalid, or if a template with the same name is already registered.
+// The registry stores a copy of the template, so later changes to t do not affect the registry.
+func (r *AlertTemplateRegistry) Register(t *AlertTemplate) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	templateCopy, err := copyAlertTemplate(t)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.templates[t.Name]; ok {
+		return fmt.Errorf("alert template '%s' is already registered", t.Name)
+	}
+
+	r.templates[t.Name] = templateCopy
+
+	return nil
+}
+
+// Get returns a copy of the template with the specified name, or false if no such template is registered.
+func (r *AlertTemplateRegistry) Get(name string) (*AlertTemplate, bool) {
+	r.mu.RLock()
+	t, ok := r.templates[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	templateCopy, err := copyAlertTemplate(t)
+	if err != nil {
+		return nil, false
+	}
+
+	return templateCopy, true
+}
+
+// Names returns the sorted names of all registered templates.
+func (r *AlertTemplateRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.templates))
+	for name := range r.templates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Instantiate creates a new alert from the template with the specified name.
+// All '${name}' placeholders in the template string fields are replaced with the corresponding variable values,
+// formatted with fmt.Sprint. An error is returned if the template is not found, if a required variable is missing,
+// or if a placeholder refers to a variable that is not provided.
+//
+// The returned alert has its Timestamp set to the current time. It is not cleaned or validated.
+func (r *AlertTemplateRegistry) Instantiate(name string, vars map[string]any) (*Alert, error) {
+	r.mu.RLock()
+	t, ok := r.templates[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("alert template '%s' not found", name)
+	}
+
+	for _, v := range t.RequiredVariables {
+		if _, ok := vars[v]; !ok {
+			return nil, fmt.Errorf("alert template '%s' requires variable '%s'", name, v)
+		}
+	}
+
+	body, err := json.Marshal(&t.Defaults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alert template '%s': %w", name, err)
+	}
+
+	var tree any
+	if err := json.Unmarshal(body, &tree); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal alert template '%s': %w", name, err)
+	}
+
+	tree, err = replaceAlertTemplatePlaceholders(tree, vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate alert template '%s': %w", name, err)
+	}
+
+	body, err = json.Marshal(tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alert from template '%s': %w", name, err)
+	}
+
+	alert := &Alert{}
+	if err := json.Unmarshal(body, alert); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal alert from template '%s': %w", name, err)
+	}
+
+	alert.Timestamp = time.Now().UTC()
+
+	if alert.Metadata == nil {
+		alert.Metadata = make(map[string]any)
+	}
+
+	return alert, nil
+}
+
+// LoadYAML parses a YAML document with a top-level 'templates' list, and registers all templates in it.
+// Template fields use the same (camelCase) names as the JSON representation, for example:
+//
+//	templates:
+//	  - name: disk-full
+//	    requiredVariables: [host]
+//	    defaults:
+//	      header: ":status: Disk full on ${host}"
+//	      severity: error
+//	      routeKey: infra
+//
+// Templates are registered in order. If a template fails to register, an error is returned and
+// the templates preceding it remain registered.
+func (r *AlertTemplateRegistry) LoadYAML(data []byte) error {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse alert templates YAML: %w", err)
+	}
+
+	// Convert to JSON, to reuse the JSON field names and types of the Alert struct.
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to convert alert templates YAML to JSON: %w", err)
+	}
+
+	var parsed struct {
+		Templates []*AlertTemplate `json:"templates"`
+	}
+
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return fmt.Errorf("failed to decode alert templates: %w", err)
+	}
+
+	for index, t := range parsed.Templates {
+		if err := r.Register(t); err != nil {
+			return fmt.Errorf("templates[%d]: %w", index, err)
+		}
+	}
+
+	return nil
+}
+
+func replaceAlertTemplatePlaceholders(value any, vars map[string]any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		var missing string
+
+		result := alertTemplatePlaceholderRegex.ReplaceAllStringFunc(v, func(placeholder string) string {
+			name := placeholder[2 : len(placeholder)-1]
+
+			replacement, ok := vars[name]
+			if !ok {
+				if missing == "" {
+					missing = name
+				}
+				return placeholder
+			}
+
+			return fmt.Sprint(replacement)
+		})
+
+		if missing != "" {
+			return nil, fmt.Errorf("variable '%s' is not set", missing)
+		}
+
+		return result, nil
+	case []any:
+		for i, item := range v {
+			replaced, err := replaceAlertTemplatePlaceholders(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = replaced
+		}
+		return v, nil
+	case map[string]any:
+		for key, item := range v {
+			replaced, err := replaceAlertTemplatePlaceholders(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = replaced
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func copyAlertTemplate(t *AlertTemplate) (*AlertTemplate, error) {
+	body, err := json.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alert template: %w", err)
+	}
+
+	templateCopy := &AlertTemplate{}
+	if err := json.Unmarshal(body, templateCopy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal alert template: %w", err)
+	}
+
+	return templateCopy, nil
+}
@@ -0,0 +1,141 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertTemplateValidate(t *testing.T) {
+	t.Parallel()
+
+	var tmpl *types.AlertTemplate
+	require.Error(t, tmpl.Validate())
+
+	require.ErrorContains(t, (&types.AlertTemplate{}).Validate(), "name is required")
+	require.NoError(t, (&types.AlertTemplate{Name: "foo", RequiredVariables: []string{"host", "disk_2"}}).Validate())
+	require.ErrorContains(t, (&types.AlertTemplate{Name: "foo", RequiredVariables: []string{"1host"}}).Validate(), "not a valid variable name")
+	require.ErrorContains(t, (&types.AlertTemplate{Name: "foo", RequiredVariables: []string{"host", "host"}}).Validate(), "must be unique")
+}
+
+func TestAlertTemplateRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("templates should be registered and instantiated", func(t *testing.T) {
+		t.Parallel()
+
+		r := types.NewAlertTemplateRegistry()
+		tmpl := &types.AlertTemplate{
+			Name:              "disk-full",
+			RequiredVariables: []string{"host"},
+			Defaults: types.Alert{
+				Header:        ":status: Disk full on ${host}",
+				Text:          "Usage is ${usage}%",
+				CorrelationID: "disk-full-${host}",
+				RouteKey:      "infra",
+				Severity:      types.AlertError,
+				Fields:        []*types.Field{{Title: "Host", Value: "${host}"}},
+				Metadata:      map[string]any{"host": "${host}", "count": 1},
+			},
+		}
+
+		require.NoError(t, r.Register(tmpl))
+		require.ErrorContains(t, r.Register(tmpl), "already registered")
+
+		// The registry keeps its own copy
+		tmpl.Defaults.Header = "changed"
+
+		alert, err := r.Instantiate("disk-full", map[string]any{"host": "db-01", "usage": 97})
+		require.NoError(t, err)
+		assert.Equal(t, ":status: Disk full on db-01", alert.Header)
+		assert.Equal(t, "Usage is 97%", alert.Text)
+		assert.Equal(t, "disk-full-db-01", alert.CorrelationID)
+		assert.Equal(t, "infra", alert.RouteKey)
+		assert.Equal(t, types.AlertError, alert.Severity)
+		assert.Equal(t, "db-01", alert.Fields[0].Value)
+		assert.Equal(t, "db-01", alert.Metadata["host"])
+		assert.InDelta(t, 1, alert.Metadata["count"], 0)
+		assert.False(t, alert.Timestamp.IsZero())
+
+		alert.Clean()
+		require.NoError(t, alert.Validate())
+
+		got, ok := r.Get("disk-full")
+		require.True(t, ok)
+		assert.Equal(t, ":status: Disk full on ${host}", got.Defaults.Header)
+
+		_, ok = r.Get("unknown")
+		assert.False(t, ok)
+
+		assert.Equal(t, []string{"disk-full"}, r.Names())
+	})
+
+	t.Run("missing variables should return error", func(t *testing.T) {
+		t.Parallel()
+
+		r := types.NewAlertTemplateRegistry()
+		require.NoError(t, r.Register(&types.AlertTemplate{
+			Name:              "foo",
+			RequiredVariables: []string{"host"},
+			Defaults:          types.Alert{Header: "${host} ${other}"},
+		}))
+
+		_, err := r.Instantiate("unknown", nil)
+		require.ErrorContains(t, err, "not found")
+
+		_, err = r.Instantiate("foo", nil)
+		require.ErrorContains(t, err, "requires variable 'host'")
+
+		_, err = r.Instantiate("foo", map[string]any{"host": "a"})
+		require.ErrorContains(t, err, "variable 'other' is not set")
+
+		alert, err := r.Instantiate("foo", map[string]any{"host": "a", "other": "b"})
+		require.NoError(t, err)
+		assert.Equal(t, "a b", alert.Header)
+	})
+
+	t.Run("templates should be loaded from YAML", func(t *testing.T) {
+		t.Parallel()
+
+		r := types.NewAlertTemplateRegistry()
+		err := r.LoadYAML([]byte(`
+templates:
+  - name: disk-full
+    requiredVariables: [host]
+    defaults:
+      header: ":status: Disk full on ${host}"
+      severity: warning
+      routeKey: infra
+      issueFollowUpEnabled: true
+      autoResolveSeconds: 3600
+      fields:
+        - title: Host
+          value: ${host}
+  - name: deploy
+    defaults:
+      text: Deployment finished
+      severity: info
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"deploy", "disk-full"}, r.Names())
+
+		alert, err := r.Instantiate("disk-full", map[string]any{"host": "db-01"})
+		require.NoError(t, err)
+		assert.Equal(t, ":status: Disk full on db-01", alert.Header)
+		assert.Equal(t, types.AlertWarning, alert.Severity)
+		assert.True(t, alert.IssueFollowUpEnabled)
+		assert.Equal(t, 3600, alert.AutoResolveSeconds)
+		assert.Equal(t, "db-01", alert.Fields[0].Value)
+	})
+
+	t.Run("invalid YAML should return error", func(t *testing.T) {
+		t.Parallel()
+
+		r := types.NewAlertTemplateRegistry()
+		require.ErrorContains(t, r.LoadYAML([]byte("templates: [")), "failed to parse")
+		require.ErrorContains(t, r.LoadYAML([]byte("templates:\n  - defaults: {}")), "templates[0]: alert template name is required")
+		require.ErrorContains(t, r.LoadYAML([]byte("templates:\n  - name: foo\n    defaults:\n      autoResolveSeconds: abc")), "failed to decode")
+	})
+}
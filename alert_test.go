@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/textlen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -318,6 +319,19 @@ func TestAlertClean(t *testing.T) {
 		assert.Equal(t, s2[:types.MaxTextLength-6]+"...```", a.TextWhenResolved)
 	})
 
+	t.Run("text should be truncated without breaking links and code blocks", func(t *testing.T) {
+		t.Parallel()
+
+		prefix := strings.Repeat("a", types.MaxTextLength-20)
+		a := types.Alert{
+			Text:             prefix + " <https://example.com/runbook|runbook>",
+			TextWhenResolved: "```" + prefix + "\nmore log lines, and even more log lines",
+		}
+		a.Clean()
+		assert.Equal(t, prefix+"...", a.Text)
+		assert.Equal(t, "```"+prefix+"\nmore log l...```", a.TextWhenResolved)
+	})
+
 	t.Run("author should be truncated when too long", func(t *testing.T) {
 		t.Parallel()
 
@@ -928,7 +942,7 @@ func TestAlertValidation(t *testing.T) {
 		// Escalation mentions count must be at most MaxEscalationSlackMentionCount
 		a = &types.Alert{Header: "a", RouteKey: "b", Escalation: []*types.Escalation{{DelaySeconds: types.MinEscalationDelaySeconds, Severity: types.AlertError, SlackMentions: []string{}}}}
 		for i := 1; i <= types.MaxEscalationSlackMentionCount+1; i++ {
-			a.Escalation[0].SlackMentions = append(a.Escalation[0].SlackMentions, "<@foo>")
+			a.Escalation[0].SlackMentions = append(a.Escalation[0].SlackMentions, fmt.Sprintf("<@foo%d>", i))
 		}
 		a.Clean()
 		require.ErrorContains(t, a.Validate(), "escalation[0].slackMentions item count is too large")
@@ -1019,6 +1033,23 @@ func TestAlertCleanUnicodeTruncation(t *testing.T) {
 		assert.Len(t, []rune(a.Fields[0].Value), types.MaxFieldValueLength)
 		assert.True(t, strings.HasSuffix(a.Fields[0].Value, "..."))
 	})
+
+	t.Run("header with emojis should truncate by UTF-16 code units without splitting emojis", func(t *testing.T) {
+		t.Parallel()
+
+		// Each emoji is 2 runes and 4 UTF-16 code units, so 40 emojis fit in 160 runes, but not in MaxHeaderLength code units.
+		header := strings.Repeat("👍🏽", 40)
+		a := types.Alert{Header: header}
+		a.Clean()
+
+		assert.Equal(t, strings.Repeat("👍🏽", 31)+"...", a.Header)
+		assert.Equal(t, 127, textlen.Count(a.Header, textlen.UTF16))
+
+		// Emojis within the limit are not truncated.
+		a = types.Alert{Header: strings.Repeat("🔥", 65)}
+		a.Clean()
+		assert.Equal(t, strings.Repeat("🔥", 65), a.Header)
+	})
 }
 
 func TestAlertCleanNilElements(t *testing.T) {
@@ -1090,12 +1121,12 @@ func TestAlertCleanNilElements(t *testing.T) {
 		a := types.Alert{
 			Escalation: []*types.Escalation{
 				nil,
-				{DelaySeconds: 60, Severity: types.AlertError, MoveToChannel: "  c12345  "},
+				{DelaySeconds: 60, Severity: types.AlertError, MoveToChannel: "  c12345678  "},
 				nil,
 			},
 		}
 		assert.NotPanics(t, func() { a.Clean() })
-		assert.Equal(t, "C12345", a.Escalation[2].MoveToChannel) // nil elements sorted to front
+		assert.Equal(t, "C12345678", a.Escalation[2].MoveToChannel) // nil elements sorted to front
 	})
 }
 
@@ -1320,3 +1351,28 @@ func hash(input ...string) string {
 
 	return base64.URLEncoding.EncodeToString(bs)
 }
+
+func TestAlertWebhookRows(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(rows ...int) *types.Alert {
+		a := &types.Alert{Header: "a", RouteKey: "b", Severity: types.AlertError}
+		for i, row := range rows {
+			a.Webhooks = append(a.Webhooks, &types.Webhook{ID: fmt.Sprintf("hook%d", i), URL: "http://foo.bar", ButtonText: "press me", Row: row})
+		}
+		return a
+	}
+
+	rows := make([]int, 0, types.MaxWebhookCount)
+	for row := range types.MaxWebhookRowCount {
+		for range types.MaxWebhooksPerRow {
+			rows = append(rows, row)
+		}
+	}
+
+	require.NoError(t, newAlert(rows...).Validate())
+	require.ErrorContains(t, newAlert(0, 0, 0, 0, 0, 0).Validate(), "webhook[5].row 0 has too many webhooks, expected <=5 per row")
+	require.ErrorContains(t, newAlert(-1).Validate(), "webhook[0].row -1 is not valid")
+	require.ErrorContains(t, newAlert(types.MaxWebhookRowCount).Validate(), "webhook[0].row 5 is not valid, expected value between 0 and 4")
+	require.ErrorContains(t, newAlert(append(rows, 0)...).Validate(), "too many webhooks")
+}
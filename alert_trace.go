@@ -0,0 +1,135 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// TraceIDRegex matches valid W3C Trace Context (OpenTelemetry) trace IDs: 32 lowercase hex characters.
+	TraceIDRegex = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+	// SpanIDRegex matches valid W3C Trace Context (OpenTelemetry) span IDs: 16 lowercase hex characters.
+	SpanIDRegex = regexp.MustCompile(`^[0-9a-f]{16}$`)
+)
+
+const (
+	// TraceLinkTraceIDPlaceholder is replaced with the alert trace ID in a trace link template.
+	TraceLinkTraceIDPlaceholder = "${traceId}"
+
+	// TraceLinkSpanIDPlaceholder is replaced with the alert span ID in a trace link template.
+	// It is replaced with an empty string if the alert has no span ID.
+	TraceLinkSpanIDPlaceholder = "${spanId}"
+
+	// traceparentHeader is the W3C Trace Context header holding the trace ID and span ID.
+	traceparentHeader = "traceparent"
+)
+
+// ValidateTrace validates that TraceID and SpanID, if set, are valid non-zero W3C Trace Context IDs,
+// and that SpanID is only set with a TraceID.
+func (a *Alert) ValidateTrace() error {
+	if a.TraceID != "" && (!TraceIDRegex.MatchString(a.TraceID) || isZeroTraceID(a.TraceID)) {
+		return fmt.Errorf("traceId '%s' is not valid, expected 32 lowercase hex characters (not all zero)", truncateString(a.TraceID, 50))
+	}
+
+	if a.SpanID == "" {
+		return nil
+	}
+
+	if a.TraceID == "" {
+		return errors.New("spanId cannot be set without traceId")
+	}
+
+	if !SpanIDRegex.MatchString(a.SpanID) || isZeroTraceID(a.SpanID) {
+		return fmt.Errorf("spanId '%s' is not valid, expected 16 lowercase hex characters (not all zero)", truncateString(a.SpanID, 50))
+	}
+
+	return nil
+}
+
+// SetTraceFromContext sets TraceID and SpanID to the IDs of the current span of ctx, as propagated by the tracer in
+// the W3C Trace Context 'traceparent' format. It returns false, and leaves the alert unchanged, if the tracer
+// propagates no valid span for ctx, e.g. with the NoopTracer.
+func (a *Alert) SetTraceFromContext(ctx context.Context, tracer Tracer) bool {
+	if tracer == nil {
+		return false
+	}
+
+	carrier := make(map[string]string, 2)
+	tracer.Inject(ctx, carrier)
+
+	traceID, spanID, ok := parseTraceparent(carrier[traceparentHeader])
+	if !ok {
+		return false
+	}
+
+	a.TraceID = traceID
+	a.SpanID = spanID
+
+	return true
+}
+
+// SetTraceLink sets Link to the trace explorer URL of the alert trace, built from the template with TraceLinkURL.
+// An error is returned if the alert has no TraceID, or if the template is not valid.
+func (a *Alert) SetTraceLink(template string) error {
+	if a.TraceID == "" {
+		return errors.New("alert has no traceId")
+	}
+
+	link, err := TraceLinkURL(template, a.TraceID, a.SpanID)
+	if err != nil {
+		return err
+	}
+
+	a.Link = link
+
+	return nil
+}
+
+// TraceLinkURL returns the URL of a trace in a trace explorer, such as Jaeger, Grafana Tempo or Honeycomb, by
+// replacing the TraceLinkTraceIDPlaceholder and TraceLinkSpanIDPlaceholder of the template with the (escaped) IDs,
+// e.g. 'https://jaeger.example.com/trace/${traceId}?uiFind=${spanId}'.
+// The template must contain the trace ID placeholder, and result in an absolute http or https URL.
+func TraceLinkURL(template, traceID, spanID string) (string, error) {
+	if !strings.Contains(template, TraceLinkTraceIDPlaceholder) {
+		return "", fmt.Errorf("trace link template must contain the %s placeholder", TraceLinkTraceIDPlaceholder)
+	}
+
+	link := strings.NewReplacer(
+		TraceLinkTraceIDPlaceholder, url.QueryEscape(traceID),
+		TraceLinkSpanIDPlaceholder, url.QueryEscape(spanID),
+	).Replace(template)
+
+	parsedURL, err := url.ParseRequestURI(link)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return "", errors.New("trace link template is not a valid absolute http or https URL")
+	}
+
+	return link, nil
+}
+
+// parseTraceparent returns the trace ID and parent span ID of a W3C Trace Context traceparent header value,
+// on the format '<version>-<trace-id>-<parent-id>-<flags>'.
+func parseTraceparent(value string) (string, string, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return "", "", false
+	}
+
+	traceID, spanID := strings.ToLower(parts[1]), strings.ToLower(parts[2])
+
+	if !TraceIDRegex.MatchString(traceID) || isZeroTraceID(traceID) || !SpanIDRegex.MatchString(spanID) || isZeroTraceID(spanID) {
+		return "", "", false
+	}
+
+	return traceID, spanID, true
+}
+
+// isZeroTraceID returns true if the trace or span ID has only zeros, which is invalid in W3C Trace Context.
+func isZeroTraceID(id string) bool {
+	return strings.Trim(id, "0") == ""
+}
@@ -0,0 +1,108 @@
+package types_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+type traceparentTracer struct {
+	types.NoopTracer
+
+	traceparent string
+}
+
+func (t *traceparentTracer) Inject(_ context.Context, carrier map[string]string) {
+	carrier["traceparent"] = t.traceparent
+}
+
+func TestAlertTrace(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func() *types.Alert {
+		a := types.NewErrorAlert()
+		a.SlackChannelID = "C12345678"
+		a.Header = "Disk full"
+
+		return a
+	}
+
+	t.Run("trace IDs should be cleaned and accepted", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		a.TraceID = " 4BF92F3577B34DA6A3CE929D0E0E4736 "
+		a.SpanID = "00F067AA0BA902B7"
+		a.Clean()
+		require.NoError(t, a.Validate())
+
+		assert.Equal(t, testTraceID, a.TraceID)
+		assert.Equal(t, testSpanID, a.SpanID)
+	})
+
+	t.Run("invalid trace IDs should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		a.TraceID = "abc"
+		require.EqualError(t, a.ValidateTrace(), "traceId 'abc' is not valid, expected 32 lowercase hex characters (not all zero)")
+
+		a.TraceID = "00000000000000000000000000000000"
+		require.ErrorContains(t, a.ValidateTrace(), "traceId '00000000000000000000000000000000' is not valid")
+
+		a.TraceID = ""
+		a.SpanID = testSpanID
+		require.EqualError(t, a.ValidateTrace(), "spanId cannot be set without traceId")
+
+		a.TraceID = testTraceID
+		a.SpanID = "00f067aa0ba902b7ff"
+		require.EqualError(t, a.ValidateTrace(), "spanId '00f067aa0ba902b7ff' is not valid, expected 16 lowercase hex characters (not all zero)")
+	})
+
+	t.Run("trace should be set from the propagated span", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		tracer := &traceparentTracer{traceparent: "00-" + testTraceID + "-" + testSpanID + "-01"}
+		require.True(t, a.SetTraceFromContext(context.Background(), tracer))
+		assert.Equal(t, testTraceID, a.TraceID)
+		assert.Equal(t, testSpanID, a.SpanID)
+
+		for _, traceparent := range []string{"", "00-abc-def-01", "ff-" + testTraceID + "-" + testSpanID + "-01", "00-" + testTraceID + "-0000000000000000-01"} {
+			b := newAlert()
+			assert.False(t, b.SetTraceFromContext(context.Background(), &traceparentTracer{traceparent: traceparent}), traceparent)
+			assert.Empty(t, b.TraceID)
+		}
+
+		assert.False(t, a.SetTraceFromContext(context.Background(), &types.NoopTracer{}))
+		assert.False(t, a.SetTraceFromContext(context.Background(), nil))
+		assert.Equal(t, testTraceID, a.TraceID)
+	})
+
+	t.Run("trace link should be built from the template", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert()
+		require.EqualError(t, a.SetTraceLink("https://jaeger.example.com/trace/${traceId}"), "alert has no traceId")
+
+		a.TraceID = testTraceID
+		require.NoError(t, a.SetTraceLink("https://jaeger.example.com/trace/${traceId}?uiFind=${spanId}"))
+		assert.Equal(t, "https://jaeger.example.com/trace/"+testTraceID+"?uiFind=", a.Link)
+
+		a.SpanID = testSpanID
+		require.NoError(t, a.SetTraceLink("https://jaeger.example.com/trace/${traceId}?uiFind=${spanId}"))
+		assert.Equal(t, "https://jaeger.example.com/trace/"+testTraceID+"?uiFind="+testSpanID, a.Link)
+		require.NoError(t, a.Validate())
+
+		require.EqualError(t, a.SetTraceLink("https://jaeger.example.com/search"), "trace link template must contain the ${traceId} placeholder")
+		require.EqualError(t, a.SetTraceLink("/trace/${traceId}"), "trace link template is not a valid absolute http or https URL")
+	})
+}
@@ -0,0 +1,157 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultAlertValidatorTimeout is the timeout of each AlertValidator run by Alert.ValidateContext, when
+// ValidateOptions.Timeout is zero or negative.
+const DefaultAlertValidatorTimeout = 5 * time.Second
+
+// AlertValidator is an external check of an alert, such as verifying that the route key or Slack channel
+// exists, run by Alert.ValidateContext after the static validation has passed.
+type AlertValidator interface {
+	// ValidateAlert returns nil if the alert is valid, and an error describing the problem otherwise.
+	// It must not modify the alert, and should return promptly when the context is canceled.
+	ValidateAlert(ctx context.Context, alert *Alert) error
+}
+
+// AlertValidatorFunc is a function implementing the AlertValidator interface.
+type AlertValidatorFunc func(ctx context.Context, alert *Alert) error
+
+// ValidateAlert calls f.
+func (f AlertValidatorFunc) ValidateAlert(ctx context.Context, alert *Alert) error {
+	return f(ctx, alert)
+}
+
+// ValidateOptions holds the external checks run by Alert.ValidateContext.
+type ValidateOptions struct {
+	// Validators are run concurrently, after the static validation has passed.
+	Validators []AlertValidator
+
+	// RouteResolver, if set, is used to verify that the route key exists, when the alert is routed by RouteKey
+	// rather than SlackChannelID. It is equivalent to adding RouteKeyValidator(RouteResolver) to Validators.
+	RouteResolver RouteResolver
+
+	// EmojiCatalog, if set, is used to verify that the icon emoji exists in the workspace.
+	// It is equivalent to adding EmojiValidator(EmojiCatalog) to Validators.
+	EmojiCatalog EmojiCatalog
+
+	// Timeout is the timeout of each validator. If zero or negative, DefaultAlertValidatorTimeout is used.
+	Timeout time.Duration
+}
+
+// RouteKeyValidator returns an AlertValidator verifying that the route key of alerts without a SlackChannelID
+// resolves to a channel, so that unknown route keys are rejected when the alert is produced rather than when it is routed.
+func RouteKeyValidator(resolver RouteResolver) AlertValidator {
+	return AlertValidatorFunc(func(ctx context.Context, alert *Alert) error {
+		if alert.SlackChannelID != "" || alert.RouteKey == "" {
+			return nil
+		}
+
+		channelID, err := resolver.ResolveRoute(ctx, alert.RouteKey)
+
+		switch {
+		case errors.Is(err, ErrRouteNotFound):
+			return fmt.Errorf("routeKey '%s' is not valid: %w", alert.RouteKey, err)
+		case err != nil:
+			return fmt.Errorf("failed to resolve routeKey '%s': %w", alert.RouteKey, err)
+		case channelID == "":
+			return fmt.Errorf("routeKey '%s' is not valid: %w", alert.RouteKey, ErrRouteNotFound)
+		}
+
+		return nil
+	})
+}
+
+// ValidateContext runs the static Validate, followed by the external checks of the options. The checks are run
+// concurrently, each with the options timeout, and all failures are returned (joined with errors.Join, in the order
+// of the validators). An error is returned at once if the context is canceled. opts may be nil.
+func (a *Alert) ValidateContext(ctx context.Context, opts *ValidateOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := a.Validate(); err != nil {
+		return err
+	}
+
+	if opts == nil {
+		return nil
+	}
+
+	// Cloned, so that appending never writes into the backing array of opts.Validators, which may be shared.
+	validators := slices.Clone(opts.Validators)
+
+	if opts.RouteResolver != nil {
+		validators = append([]AlertValidator{RouteKeyValidator(opts.RouteResolver)}, validators...)
+	}
+
+	if opts.EmojiCatalog != nil {
+		validators = append(validators, EmojiValidator(opts.EmojiCatalog))
+	}
+
+	if len(validators) == 0 {
+		return nil
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultAlertValidatorTimeout
+	}
+
+	errs := make([]error, len(validators))
+
+	var wg sync.WaitGroup
+
+	for i, validator := range validators {
+		if validator == nil {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs[i] = runAlertValidator(ctx, validator, a, timeout)
+		}()
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// runAlertValidator runs a single validator with the specified timeout, converting panics to errors.
+func runAlertValidator(parent context.Context, validator AlertValidator, alert *Alert, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("alert validator panic: %v", p)
+			}
+		}()
+
+		done <- validator.ValidateAlert(ctx, alert)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if err := parent.Err(); err != nil {
+			return fmt.Errorf("alert validator canceled: %w", err)
+		}
+
+		return fmt.Errorf("alert validator timed out: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,148 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapRouteResolver map[string]string
+
+func (r mapRouteResolver) ResolveRoute(_ context.Context, routeKey string) (string, error) {
+	if channelID, ok := r[routeKey]; ok {
+		return channelID, nil
+	}
+
+	return "", fmt.Errorf("%w: %s", types.ErrRouteNotFound, routeKey)
+}
+
+func TestAlertValidateContext(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(routeKey string) *types.Alert {
+		a := types.NewErrorAlert()
+		a.RouteKey = routeKey
+		a.Header = "Disk full"
+		a.Clean()
+
+		return a
+	}
+
+	resolver := mapRouteResolver{"payments": "C12345678", "empty": ""}
+
+	t.Run("known route keys should be valid", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, newAlert("payments").ValidateContext(context.Background(), &types.ValidateOptions{RouteResolver: resolver}))
+		require.NoError(t, newAlert("unknown").ValidateContext(context.Background(), nil))
+
+		a := newAlert("unknown")
+		a.SlackChannelID = "C12345678"
+		require.NoError(t, a.ValidateContext(context.Background(), &types.ValidateOptions{RouteResolver: resolver}), "the route key is not used when the channel is set")
+	})
+
+	t.Run("unknown route keys should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		opts := &types.ValidateOptions{RouteResolver: resolver}
+
+		err := newAlert("unknown").ValidateContext(context.Background(), opts)
+		require.ErrorIs(t, err, types.ErrRouteNotFound)
+		require.EqualError(t, err, "routeKey 'unknown' is not valid: route key not found: unknown")
+
+		require.EqualError(t, newAlert("empty").ValidateContext(context.Background(), opts), "routeKey 'empty' is not valid: route key not found")
+
+		failing := types.RouteKeyValidator(routeResolverFunc(func(context.Context, string) (string, error) {
+			return "", errors.New("connection refused")
+		}))
+		require.EqualError(t, failing.ValidateAlert(context.Background(), newAlert("payments")), "failed to resolve routeKey 'payments': connection refused")
+	})
+
+	t.Run("static validation should run first", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		validator := types.AlertValidatorFunc(func(context.Context, *types.Alert) error {
+			called = true
+			return nil
+		})
+
+		a := newAlert("payments")
+		a.Header = ""
+		require.Error(t, a.ValidateContext(context.Background(), &types.ValidateOptions{Validators: []types.AlertValidator{validator}}))
+		assert.False(t, called)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, newAlert("payments").ValidateContext(ctx, nil), context.Canceled)
+	})
+
+	t.Run("all validator failures should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		opts := &types.ValidateOptions{
+			Timeout: 20 * time.Millisecond,
+			Validators: []types.AlertValidator{
+				types.AlertValidatorFunc(func(context.Context, *types.Alert) error { return errors.New("channel is archived") }),
+				types.AlertValidatorFunc(func(context.Context, *types.Alert) error {
+					time.Sleep(200 * time.Millisecond)
+					return nil
+				}),
+				types.AlertValidatorFunc(func(context.Context, *types.Alert) error { panic("boom") }),
+				types.AlertValidatorFunc(func(context.Context, *types.Alert) error { return nil }),
+				nil,
+			},
+			RouteResolver: resolver,
+		}
+
+		err := newAlert("unknown").ValidateContext(context.Background(), opts)
+		require.Error(t, err)
+		require.ErrorIs(t, err, types.ErrRouteNotFound)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, "routeKey 'unknown' is not valid: route key not found: unknown\nchannel is archived\nalert validator timed out: context deadline exceeded\nalert validator panic: boom", err.Error())
+	})
+
+	t.Run("canceled contexts should be reported as canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		opts := &types.ValidateOptions{
+			Validators: []types.AlertValidator{
+				types.AlertValidatorFunc(func(context.Context, *types.Alert) error {
+					cancel()
+					time.Sleep(200 * time.Millisecond)
+					return nil
+				}),
+			},
+		}
+
+		err := newAlert("payments").ValidateContext(ctx, opts)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, "alert validator canceled: context canceled", err.Error())
+	})
+
+	t.Run("shared options should not be modified", func(t *testing.T) {
+		t.Parallel()
+
+		validators := make([]types.AlertValidator, 1, 2)
+		validators[0] = types.AlertValidatorFunc(func(context.Context, *types.Alert) error { return nil })
+
+		opts := &types.ValidateOptions{Validators: validators, EmojiCatalog: types.NewInMemoryEmojiCatalog()}
+
+		require.NoError(t, newAlert("payments").ValidateContext(context.Background(), opts))
+		assert.Nil(t, validators[:2][1], "the emoji validator should not be appended to the caller's slice")
+	})
+}
+
+type routeResolverFunc func(ctx context.Context, routeKey string) (string, error)
+
+func (f routeResolverFunc) ResolveRoute(ctx context.Context, routeKey string) (string, error) {
+	return f(ctx, routeKey)
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultBatchAckSize is the number of collected acknowledgments that triggers a flush, when NewBatchAck is called
+// with a non-positive max size. It matches the batch limit of SQS.
+const DefaultBatchAckSize = 10
+
+// BatchAck collects acknowledgments of received queue items, and flushes them to a BatchAcker in one backend call.
+// A flush is made when the max size is reached, and when Flush is called. Consumers should call Flush periodically,
+// and before shutting down, so that acknowledgments are not held back for long (which may cause redelivery).
+// It is safe for concurrent use.
+//
+// Items without an AckToken, and all items when the acker is nil, are acknowledged immediately with their Ack function.
+// This makes it possible to use a BatchAck with any queue:
+//
+//	acker, _ := queue.(types.BatchAcker)
+//	batch := types.NewBatchAck(acker, 0)
+type BatchAck struct {
+	acker   BatchAcker
+	maxSize int
+
+	mu      sync.Mutex
+	pending []*pendingAck
+}
+
+type pendingAck struct {
+	token string
+	ack   func()
+}
+
+// NewBatchAck creates a new BatchAck flushing to the specified acker, which may be nil.
+// If maxSize is zero or negative, DefaultBatchAckSize is used.
+func NewBatchAck(acker BatchAcker, maxSize int) *BatchAck {
+	if maxSize <= 0 {
+		maxSize = DefaultBatchAckSize
+	}
+
+	return &BatchAck{
+		acker:   acker,
+		maxSize: maxSize,
+	}
+}
+
+// AddFifoItem adds the acknowledgment of a FIFO queue item, flushing if the max size is reached.
+// The error of the flush, if any, is returned.
+func (b *BatchAck) AddFifoItem(ctx context.Context, item *FifoQueueItem) error {
+	return b.add(ctx, item.AckToken, item.Ack)
+}
+
+// AddItem adds the acknowledgment of a queue item, flushing if the max size is reached.
+// The error of the flush, if any, is returned.
+func (b *BatchAck) AddItem(ctx context.Context, item *QueueItem) error {
+	return b.add(ctx, item.AckToken, item.Ack)
+}
+
+// Len returns the number of acknowledgments waiting to be flushed.
+func (b *BatchAck) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.pending)
+}
+
+// Flush acknowledges all collected items in one AckBatch call. If the call fails, the items are acknowledged
+// individually with their Ack functions instead, and the error of the AckBatch call is returned.
+func (b *BatchAck) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	return b.flush(ctx, pending)
+}
+
+func (b *BatchAck) add(ctx context.Context, token string, ack func()) error {
+	if b.acker == nil || token == "" {
+		ack()
+		return nil
+	}
+
+	b.mu.Lock()
+
+	b.pending = append(b.pending, &pendingAck{token: token, ack: ack})
+
+	var pending []*pendingAck
+
+	if len(b.pending) >= b.maxSize {
+		pending = b.pending
+		b.pending = nil
+	}
+
+	b.mu.Unlock()
+
+	return b.flush(ctx, pending)
+}
+
+func (b *BatchAck) flush(ctx context.Context, pending []*pendingAck) error {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	tokens := make([]string, len(pending))
+	for i, p := range pending {
+		tokens[i] = p.token
+	}
+
+	if err := b.acker.AckBatch(ctx, tokens); err != nil {
+		for _, p := range pending {
+			p.ack()
+		}
+
+		return fmt.Errorf("batch acknowledgment failed, %d items were acknowledged individually: %w", len(pending), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingAcker struct {
+	mu      sync.Mutex
+	batches [][]string
+	err     error
+}
+
+func (r *recordingAcker) AckBatch(_ context.Context, ackTokens []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.batches = append(r.batches, ackTokens)
+
+	return r.err
+}
+
+func TestBatchAck(t *testing.T) {
+	t.Parallel()
+
+	newItem := func(token string, acked *int) *types.QueueItem {
+		return &types.QueueItem{AckToken: token, Ack: func() { *acked++ }, Nack: func() {}}
+	}
+
+	t.Run("acks should be flushed when the max size is reached", func(t *testing.T) {
+		t.Parallel()
+
+		acker := &recordingAcker{}
+		batch := types.NewBatchAck(acker, 2)
+		acked := 0
+
+		require.NoError(t, batch.AddItem(context.Background(), newItem("a", &acked)))
+		assert.Equal(t, 1, batch.Len())
+		assert.Empty(t, acker.batches)
+
+		require.NoError(t, batch.AddItem(context.Background(), newItem("b", &acked)))
+		assert.Equal(t, 0, batch.Len())
+		assert.Equal(t, [][]string{{"a", "b"}}, acker.batches)
+
+		require.NoError(t, batch.AddFifoItem(context.Background(), &types.FifoQueueItem{AckToken: "c", Ack: func() { acked++ }}))
+		require.NoError(t, batch.Flush(context.Background()))
+		require.NoError(t, batch.Flush(context.Background()))
+		assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, acker.batches)
+		assert.Equal(t, 0, acked)
+	})
+
+	t.Run("items without ack token should be acked immediately", func(t *testing.T) {
+		t.Parallel()
+
+		acker := &recordingAcker{}
+		batch := types.NewBatchAck(acker, 0)
+		acked := 0
+
+		require.NoError(t, batch.AddItem(context.Background(), newItem("", &acked)))
+		assert.Equal(t, 1, acked)
+		assert.Equal(t, 0, batch.Len())
+	})
+
+	t.Run("nil acker should ack items immediately", func(t *testing.T) {
+		t.Parallel()
+
+		batch := types.NewBatchAck(nil, 0)
+		acked := 0
+
+		require.NoError(t, batch.AddItem(context.Background(), newItem("a", &acked)))
+		assert.Equal(t, 1, acked)
+	})
+
+	t.Run("failed batch should fall back to individual acks", func(t *testing.T) {
+		t.Parallel()
+
+		acker := &recordingAcker{err: errors.New("throttled")}
+		batch := types.NewBatchAck(acker, 0)
+		acked := 0
+
+		require.NoError(t, batch.AddItem(context.Background(), newItem("a", &acked)))
+		require.NoError(t, batch.AddItem(context.Background(), newItem("b", &acked)))
+
+		err := batch.Flush(context.Background())
+		require.ErrorContains(t, err, "2 items were acknowledged individually: throttled")
+		assert.Equal(t, 2, acked)
+	})
+
+	t.Run("in-memory queues should support batch acks", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		queue := types.NewInMemoryQueue("callbacks", 2, time.Millisecond)
+		require.NoError(t, queue.SendBatch(ctx, []string{"body_1", "body_2"}))
+
+		items, err := queue.ReceiveBatch(ctx, 2)
+		require.NoError(t, err)
+
+		acker, ok := types.Queue(queue).(types.BatchAcker)
+		require.True(t, ok)
+
+		batch := types.NewBatchAck(acker, 0)
+
+		for _, item := range items {
+			assert.Equal(t, item.MessageID, item.AckToken)
+			require.NoError(t, batch.AddItem(ctx, item))
+		}
+
+		assert.Equal(t, 2, batch.Len())
+		require.NoError(t, batch.Flush(ctx))
+	})
+}
@@ -0,0 +1,351 @@
+package types
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxBulkBatchSize is the maximum number of issues per batch of a BulkPlan. It matches MaxAlertBatchCount,
+// so that each batch of resolution alerts fits in a single AlertBatch.
+const MaxBulkBatchSize = MaxAlertBatchCount
+
+// BulkAction is the action applied to all issues of a BulkPlan.
+type BulkAction string
+
+const (
+	// BulkActionResolve resolves the issues, with ResolveIssueCommand or resolution alerts. This is the default action.
+	BulkActionResolve BulkAction = "resolve"
+
+	// BulkActionArchive archives the issues with ArchiveIssueCommand, removing them from the channel without resolving them.
+	BulkActionArchive BulkAction = "archive"
+)
+
+// BulkActionIsValid returns true if the provided BulkAction is valid.
+func BulkActionIsValid(s BulkAction) bool {
+	switch s {
+	case BulkActionResolve, BulkActionArchive:
+		return true
+	}
+	return false
+}
+
+// ValidBulkActions returns a slice of valid BulkAction values.
+func ValidBulkActions() []string {
+	return []string{
+		string(BulkActionResolve),
+		string(BulkActionArchive),
+	}
+}
+
+// BulkOptions configures a BulkPlan.
+type BulkOptions struct {
+	// Action is the action applied to the issues. If empty, BulkActionResolve is used.
+	Action BulkAction `json:"action"`
+
+	// BatchSize is the number of issues per batch. If 0, MaxBulkBatchSize is used.
+	// Maximum value: MaxBulkBatchSize.
+	BatchSize int `json:"batchSize"`
+
+	// DryRun is true if BulkPlan.Execute should only report what would be sent, without sending anything.
+	DryRun bool `json:"dryRun"`
+
+	// Actor is the name of the user or system closing the issues, for the audit trail. This field is required.
+	Actor string `json:"actor"`
+
+	// Reason is an optional description of why the issues are closed, such as 'Cleanup after INC-1234'.
+	// It is used as command reason, and as text of resolution alerts.
+	Reason string `json:"reason"`
+
+	// Resolution is the outcome of resolved issues. If empty, ResolutionResolved is used.
+	// Ignored for BulkActionArchive.
+	Resolution Resolution `json:"resolution"`
+}
+
+// BulkTarget is an issue targeted by a BulkPlan.
+type BulkTarget struct {
+	// IssueID is the unique ID of the issue, as returned by Issue.UniqueID.
+	IssueID string `json:"issueId"`
+
+	// SlackChannelID is the Slack channel ID of the issue.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// CorrelationID is the correlation ID of the issue.
+	CorrelationID string `json:"correlationId"`
+}
+
+// BulkPlan is the set of issues to resolve or archive at once, such as during cleanup after an incident, when
+// hundreds of issues need closing. Use PlanBulkAction to create a plan, inspect the targets, and then either
+// call Execute, or send the batches returned by Commands or ResolutionAlerts yourself.
+type BulkPlan struct {
+	// Action is the action applied to the targets.
+	Action BulkAction `json:"action"`
+
+	// DryRun is true if Execute does not send anything.
+	DryRun bool `json:"dryRun"`
+
+	// Targets are the matching issues, sorted by Slack channel ID and correlation ID.
+	Targets []BulkTarget `json:"targets"`
+
+	opts BulkOptions
+}
+
+// BulkResult is the outcome of BulkPlan.Execute.
+type BulkResult struct {
+	// DryRun is true if nothing was sent.
+	DryRun bool `json:"dryRun"`
+
+	// Planned is the number of targeted issues.
+	Planned int `json:"planned"`
+
+	// Batches is the number of batches of the plan.
+	Batches int `json:"batches"`
+
+	// Sent is the number of issues in batches that were sent successfully. When Execute fails, the remaining
+	// targets are Targets[Sent:].
+	Sent int `json:"sent"`
+}
+
+// PlanBulkAction returns a plan for the open issues matching the find options. Only matchers and groups are allowed
+// in the find options, and no find options match all open issues. Archived and nil issues are skipped, and issues with
+// the same Slack channel ID and correlation ID are targeted once.
+//
+// The find options are evaluated in memory, with FindExpr.MatchIssue. To narrow down the issues loaded from the
+// database, pass the same options to IssueLister.ListIssues.
+func PlanBulkAction(issues Issues, opts BulkOptions, find ...FindOption) (*BulkPlan, error) {
+	if err := opts.clean(); err != nil {
+		return nil, err
+	}
+
+	findOpts, err := NewFindOptions(find...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid find options: %w", err)
+	}
+
+	if len(findOpts.Sort) > 0 || findOpts.Limit != 0 || findOpts.Cursor != "" {
+		return nil, errors.New("invalid find options: only matchers and groups are allowed")
+	}
+
+	expr := findOpts.Expr()
+	seen := make(map[string]struct{})
+
+	plan := &BulkPlan{
+		Action:  opts.Action,
+		DryRun:  opts.DryRun,
+		Targets: []BulkTarget{},
+		opts:    opts,
+	}
+
+	for _, issue := range issues {
+		if issue == nil || !issue.IsOpen() || !expr.MatchIssue(issue) {
+			continue
+		}
+
+		key := issue.ChannelID() + "\x00" + issue.GetCorrelationID()
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		plan.Targets = append(plan.Targets, BulkTarget{
+			IssueID:        issue.UniqueID(),
+			SlackChannelID: issue.ChannelID(),
+			CorrelationID:  issue.GetCorrelationID(),
+		})
+	}
+
+	slices.SortFunc(plan.Targets, func(a, b BulkTarget) int {
+		if c := strings.Compare(a.SlackChannelID, b.SlackChannelID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.CorrelationID, b.CorrelationID)
+	})
+
+	return plan, nil
+}
+
+// clean normalizes and validates the options.
+func (o *BulkOptions) clean() error {
+	o.Action = BulkAction(strings.ToLower(strings.TrimSpace(string(o.Action))))
+	o.Actor = strings.TrimSpace(o.Actor)
+	o.Reason = strings.TrimSpace(o.Reason)
+	o.Resolution = Resolution(strings.ToLower(strings.TrimSpace(string(o.Resolution))))
+
+	if o.Action == "" {
+		o.Action = BulkActionResolve
+	}
+
+	if o.BatchSize == 0 {
+		o.BatchSize = MaxBulkBatchSize
+	}
+
+	if o.Resolution == "" {
+		o.Resolution = ResolutionResolved
+	}
+
+	if !BulkActionIsValid(o.Action) {
+		return fmt.Errorf("action '%s' is not valid, expected one of [%s]", o.Action, strings.Join(ValidBulkActions(), ", "))
+	}
+
+	if o.BatchSize < 0 {
+		return errors.New("batchSize must be >=0")
+	}
+
+	if o.BatchSize > MaxBulkBatchSize {
+		return fmt.Errorf("batchSize is too high, expected value <=%d", MaxBulkBatchSize)
+	}
+
+	if o.Actor == "" {
+		return errors.New("actor is required")
+	}
+
+	if !ResolutionIsValid(o.Resolution) {
+		return fmt.Errorf("resolution '%s' is not valid, expected one of [%s]", o.Resolution, strings.Join(ValidResolutions(), ", "))
+	}
+
+	return nil
+}
+
+// Batches returns the number of batches of the plan.
+func (p *BulkPlan) Batches() int {
+	return (len(p.Targets) + p.batchSize() - 1) / p.batchSize()
+}
+
+// batchSize returns the batch size of the options, or MaxBulkBatchSize for plans not created by PlanBulkAction.
+func (p *BulkPlan) batchSize() int {
+	if p.opts.BatchSize <= 0 {
+		return MaxBulkBatchSize
+	}
+	return p.opts.BatchSize
+}
+
+// Commands returns the commands of the plan, in batches: a ResolveIssueCommand or ArchiveIssueCommand per target.
+// The command IDs are derived from the action and issue ID, so that commands sent again when retrying a failed
+// bulk action are not applied twice. An error is returned if a command is invalid, such as for a correlation ID
+// longer than MaxCorrelationIDLength.
+func (p *BulkPlan) Commands() ([][]Command, error) {
+	batches := make([][]Command, 0, p.Batches())
+
+	for chunk := range slices.Chunk(p.Targets, p.batchSize()) {
+		batch := make([]Command, 0, len(chunk))
+
+		for _, target := range chunk {
+			cmd := p.command(target)
+			cmd.Clean()
+
+			if err := cmd.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid %s command for issue %s: %w", p.Action, target.IssueID, err)
+			}
+
+			batch = append(batch, cmd)
+		}
+
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// bulkCommandID returns 'bulk-<action>-<issueID>', or 'bulk-<action>-' followed by a hash of the action and issue ID,
+// if the issue ID is too long for MaxCommandIDLength.
+func bulkCommandID(action BulkAction, issueID string) string {
+	if id := fmt.Sprintf("bulk-%s-%s", action, issueID); len(id) <= MaxCommandIDLength {
+		return id
+	}
+
+	sum := sha256.Sum256([]byte(string(action) + "\x00" + issueID))
+
+	return fmt.Sprintf("bulk-%s-%s", action, base64.RawURLEncoding.EncodeToString(sum[:]))
+}
+
+func (p *BulkPlan) command(target BulkTarget) Command { //nolint:ireturn
+	meta := CommandMeta{
+		Actor:  p.opts.Actor,
+		Reason: p.opts.Reason,
+	}
+
+	meta.ID = bulkCommandID(p.Action, target.IssueID)
+
+	if p.Action == BulkActionArchive {
+		return &ArchiveIssueCommand{
+			CommandMeta:    meta,
+			CorrelationID:  target.CorrelationID,
+			SlackChannelID: target.SlackChannelID,
+		}
+	}
+
+	return &ResolveIssueCommand{
+		CommandMeta:    meta,
+		CorrelationID:  target.CorrelationID,
+		SlackChannelID: target.SlackChannelID,
+		Resolution:     p.opts.Resolution,
+	}
+}
+
+// ResolutionAlerts returns resolution alerts for the targets (see NewResolutionAlert), in batches, for Slack Managers
+// that receive alerts only. The reason is used as alert text. Resolution alerts cannot archive issues, so an error is
+// returned for BulkActionArchive.
+func (p *BulkPlan) ResolutionAlerts() ([]*AlertBatch, error) {
+	if p.Action != BulkActionResolve {
+		return nil, fmt.Errorf("resolution alerts are not supported for action '%s'", p.Action)
+	}
+
+	batches := make([]*AlertBatch, 0, p.Batches())
+
+	for chunk := range slices.Chunk(p.Targets, p.batchSize()) {
+		batch := NewAlertBatch()
+
+		for _, target := range chunk {
+			alert := NewResolutionAlert(target.CorrelationID, target.SlackChannelID)
+			alert.Text = p.opts.Reason
+			batch.Alerts = append(batch.Alerts, alert)
+		}
+
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// Execute sends the command batches of the plan in order, stopping at the first error. Nothing is sent for dry runs.
+// The result is returned also on errors, with the number of issues sent, so that the remaining targets can be retried.
+func (p *BulkPlan) Execute(ctx context.Context, send func(ctx context.Context, batch []Command) error) (*BulkResult, error) {
+	result := &BulkResult{
+		DryRun:  p.DryRun,
+		Planned: len(p.Targets),
+		Batches: p.Batches(),
+	}
+
+	batches, err := p.Commands()
+	if err != nil {
+		return result, err
+	}
+
+	if p.DryRun {
+		return result, nil
+	}
+
+	if send == nil {
+		return result, errors.New("send function is nil")
+	}
+
+	for i, batch := range batches {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		if err := send(ctx, batch); err != nil {
+			return result, fmt.Errorf("failed to send batch %d of %d: %w", i+1, len(batches), err)
+		}
+
+		result.Sent += len(batch)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,220 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bulkIssues() types.Issues {
+	return types.Issues{
+		&listIssue{ID: "3", Channel: "C2", CorrelationID: "disk-full", Open: true},
+		&listIssue{ID: "1", Channel: "C1", CorrelationID: "disk-full", Open: true, Details: map[string]any{"team": "db"}},
+		&listIssue{ID: "2", Channel: "C1", CorrelationID: "cpu-high", Open: true},
+		&listIssue{ID: "4", Channel: "C1", CorrelationID: "disk-full", Open: true},
+		&listIssue{ID: "5", Channel: "C1", CorrelationID: "disk-old"},
+		nil,
+	}
+}
+
+func TestPlanBulkAction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("open matching issues should be targeted once, sorted", func(t *testing.T) {
+		t.Parallel()
+
+		plan, err := types.PlanBulkAction(bulkIssues(), types.BulkOptions{Actor: "oncall"}, types.WithKeyPrefix(types.IssueFieldCorrelationID, "disk-"))
+		require.NoError(t, err)
+		assert.Equal(t, types.BulkActionResolve, plan.Action)
+		assert.Equal(t, []types.BulkTarget{
+			{IssueID: "1", SlackChannelID: "C1", CorrelationID: "disk-full"},
+			{IssueID: "3", SlackChannelID: "C2", CorrelationID: "disk-full"},
+		}, plan.Targets)
+		assert.Equal(t, 1, plan.Batches())
+
+		plan, err = types.PlanBulkAction(bulkIssues(), types.BulkOptions{Actor: "oncall"})
+		require.NoError(t, err)
+		assert.Len(t, plan.Targets, 3)
+
+		plan, err = types.PlanBulkAction(bulkIssues(), types.BulkOptions{Actor: "oncall"}, types.WithKeyEquals("body.details.team", "db"))
+		require.NoError(t, err)
+		require.Len(t, plan.Targets, 1)
+		assert.Equal(t, "1", plan.Targets[0].IssueID)
+	})
+
+	t.Run("invalid options should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			opts types.BulkOptions
+			find []types.FindOption
+			err  string
+		}{
+			{types.BulkOptions{}, nil, "actor is required"},
+			{types.BulkOptions{Actor: "oncall", Action: "delete"}, nil, "action 'delete' is not valid, expected one of [resolve, archive]"},
+			{types.BulkOptions{Actor: "oncall", BatchSize: -1}, nil, "batchSize must be >=0"},
+			{types.BulkOptions{Actor: "oncall", BatchSize: types.MaxBulkBatchSize + 1}, nil, "batchSize is too high, expected value <=100"},
+			{types.BulkOptions{Actor: "oncall", Resolution: "fixed"}, nil, "resolution 'fixed' is not valid, expected one of [resolved, inconclusive]"},
+			{types.BulkOptions{Actor: "oncall"}, []types.FindOption{types.WithKeyPrefix(types.IssueFieldCorrelationID, "")}, "invalid find options: matchers[0]: value cannot be empty for operator 'prefix'"},
+			{types.BulkOptions{Actor: "oncall"}, []types.FindOption{types.WithLimit(10)}, "invalid find options: only matchers and groups are allowed"},
+		}
+
+		for _, test := range tests {
+			_, err := types.PlanBulkAction(bulkIssues(), test.opts, test.find...)
+			require.EqualError(t, err, test.err)
+		}
+	})
+}
+
+func TestBulkPlanCommands(t *testing.T) {
+	t.Parallel()
+
+	issues := types.Issues{}
+	for i := range 5 {
+		issues = append(issues, &listIssue{ID: fmt.Sprintf("id-%d", i), Channel: "C1", CorrelationID: fmt.Sprintf("alert-%d", i), Open: true})
+	}
+
+	plan, err := types.PlanBulkAction(issues, types.BulkOptions{Actor: "oncall", Reason: "Cleanup after INC-1234", BatchSize: 2, Resolution: types.ResolutionInconclusive})
+	require.NoError(t, err)
+	assert.Equal(t, 3, plan.Batches())
+
+	batches, err := plan.Commands()
+	require.NoError(t, err)
+	require.Len(t, batches, 3)
+	assert.Len(t, batches[0], 2)
+	assert.Len(t, batches[2], 1)
+
+	cmd, ok := batches[0][0].(*types.ResolveIssueCommand)
+	require.True(t, ok)
+	assert.Equal(t, "bulk-resolve-id-0", cmd.ID)
+	assert.Equal(t, "oncall", cmd.Actor)
+	assert.Equal(t, "Cleanup after INC-1234", cmd.Reason)
+	assert.Equal(t, "C1", cmd.SlackChannelID)
+	assert.Equal(t, "alert-0", cmd.CorrelationID)
+	assert.Equal(t, types.ResolutionInconclusive, cmd.Resolution)
+
+	alerts, err := plan.ResolutionAlerts()
+	require.NoError(t, err)
+	require.Len(t, alerts, 3)
+	assert.Len(t, alerts[0].Alerts, 2)
+	assert.Equal(t, types.AlertResolved, alerts[0].Alerts[0].Severity)
+	assert.Equal(t, "alert-0", alerts[0].Alerts[0].CorrelationID)
+	assert.Equal(t, "Cleanup after INC-1234", alerts[0].Alerts[0].Text)
+
+	plan, err = types.PlanBulkAction(issues, types.BulkOptions{Actor: "oncall", Action: types.BulkActionArchive})
+	require.NoError(t, err)
+
+	batches, err = plan.Commands()
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	assert.IsType(t, &types.ArchiveIssueCommand{}, batches[0][0])
+
+	_, err = plan.ResolutionAlerts()
+	require.EqualError(t, err, "resolution alerts are not supported for action 'archive'")
+
+	// Command IDs of long issue IDs are hashed, so that they stay deterministic.
+	longIssues := types.Issues{
+		&listIssue{ID: strings.Repeat("a", types.MaxCommandIDLength), Channel: "C1", CorrelationID: "alert-a", Open: true},
+		&listIssue{ID: strings.Repeat("a", types.MaxCommandIDLength) + "b", Channel: "C1", CorrelationID: "alert-b", Open: true},
+	}
+
+	ids := func() []string {
+		plan, err := types.PlanBulkAction(longIssues, types.BulkOptions{Actor: "oncall"})
+		require.NoError(t, err)
+
+		batches, err := plan.Commands()
+		require.NoError(t, err)
+
+		var ids []string
+		for _, cmd := range batches[0] {
+			ids = append(ids, cmd.(*types.ResolveIssueCommand).ID) //nolint:forcetypeassert
+		}
+
+		return ids
+	}
+
+	first := ids()
+	require.Len(t, first, 2)
+	assert.Equal(t, first, ids())
+	assert.NotEqual(t, first[0], first[1])
+
+	for _, id := range first {
+		assert.True(t, strings.HasPrefix(id, "bulk-resolve-"))
+		assert.LessOrEqual(t, len(id), types.MaxCommandIDLength)
+	}
+}
+
+func TestBulkPlanExecute(t *testing.T) {
+	t.Parallel()
+
+	issues := types.Issues{}
+	for i := range 5 {
+		issues = append(issues, &listIssue{ID: fmt.Sprintf("id-%d", i), Channel: "C1", CorrelationID: fmt.Sprintf("alert-%d", i), Open: true})
+	}
+
+	t.Run("dry run should not send anything", func(t *testing.T) {
+		t.Parallel()
+
+		plan, err := types.PlanBulkAction(issues, types.BulkOptions{Actor: "oncall", BatchSize: 2, DryRun: true})
+		require.NoError(t, err)
+
+		result, err := plan.Execute(context.Background(), func(context.Context, []types.Command) error {
+			t.Fatal("send should not be called")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &types.BulkResult{DryRun: true, Planned: 5, Batches: 3}, result)
+	})
+
+	t.Run("batches should be sent in order", func(t *testing.T) {
+		t.Parallel()
+
+		plan, err := types.PlanBulkAction(issues, types.BulkOptions{Actor: "oncall", BatchSize: 2})
+		require.NoError(t, err)
+
+		var sizes []int
+
+		result, err := plan.Execute(context.Background(), func(_ context.Context, batch []types.Command) error {
+			sizes = append(sizes, len(batch))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 2, 1}, sizes)
+		assert.Equal(t, &types.BulkResult{Planned: 5, Batches: 3, Sent: 5}, result)
+	})
+
+	t.Run("failed batch should stop execution", func(t *testing.T) {
+		t.Parallel()
+
+		plan, err := types.PlanBulkAction(issues, types.BulkOptions{Actor: "oncall", BatchSize: 2})
+		require.NoError(t, err)
+
+		calls := 0
+
+		result, err := plan.Execute(context.Background(), func(context.Context, []types.Command) error {
+			calls++
+			if calls == 2 {
+				return errors.New("queue unavailable")
+			}
+			return nil
+		})
+		require.EqualError(t, err, "failed to send batch 2 of 3: queue unavailable")
+		assert.Equal(t, 2, result.Sent)
+		assert.Equal(t, "alert-2", plan.Targets[result.Sent].CorrelationID)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = plan.Execute(ctx, func(context.Context, []types.Command) error { return nil })
+		require.ErrorIs(t, err, context.Canceled)
+
+		_, err = plan.Execute(context.Background(), nil)
+		require.EqualError(t, err, "send function is nil")
+	})
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxChartTitleLength is the maximum length of a chart title.
+	MaxChartTitleLength = 100
+	// MaxChartMetadataKeyLength is the maximum length of the metadata key holding the chart series.
+	MaxChartMetadataKeyLength = 200
+	// MaxChartSeriesLength is the maximum number of data points in a chart series.
+	MaxChartSeriesLength = 1000
+)
+
+// ChartSpec describes a small chart that should be rendered into the Slack post, based on numeric data in the alert metadata.
+// The chart is rendered by a ChartRenderer registered in the Slack Manager. If no renderer is registered, the chart is ignored.
+type ChartSpec struct {
+	// Type is the type of chart to render.
+	// Valid values are defined by ChartType constants.
+	// If empty, a sparkline is rendered.
+	Type ChartType `json:"type"`
+
+	// SeriesFromMetadataKey is the key in the alert metadata holding the chart series, as a list of numbers.
+	// This field is required.
+	// Maximum length: MaxChartMetadataKeyLength characters.
+	SeriesFromMetadataKey string `json:"seriesFromMetadataKey"`
+
+	// Title is an optional title displayed with the chart.
+	// It is automatically truncated at MaxChartTitleLength characters.
+	Title string `json:"title"`
+}
+
+// ChartRenderer renders a chart for an alert, and returns the URL of the rendered image.
+// Implementations are provided by consumers, and registered in the Slack Manager.
+type ChartRenderer interface {
+	// RenderChart renders the chart described by spec, using the provided series, and returns an image URL.
+	// The URL must be publicly reachable by Slack.
+	RenderChart(ctx context.Context, spec *ChartSpec, series []float64) (string, error)
+}
+
+// Clean normalizes the chart spec fields.
+func (c *ChartSpec) Clean() {
+	if c == nil {
+		return
+	}
+
+	c.Type = ChartType(strings.ToLower(strings.TrimSpace(string(c.Type))))
+	c.SeriesFromMetadataKey = strings.TrimSpace(c.SeriesFromMetadataKey)
+	c.Title = strings.ReplaceAll(strings.TrimSpace(c.Title), "\n", " ")
+
+	if c.Type == "" {
+		c.Type = ChartTypeSparkline
+	}
+
+	if utf8.RuneCountInString(c.Title) > MaxChartTitleLength {
+		c.Title = strings.TrimSpace(truncateString(c.Title, MaxChartTitleLength-3)) + "..."
+	}
+}
+
+// Series returns the chart series from the provided metadata.
+// The metadata value must be a list of numbers, either as a Go slice of numbers or as a JSON-decoded []any.
+// An error is returned if the key is missing, or if the value is not a valid list of numbers.
+func (c *ChartSpec) Series(metadata map[string]any) ([]float64, error) {
+	if c == nil {
+		return nil, errors.New("chart spec is nil")
+	}
+
+	value, ok := metadata[c.SeriesFromMetadataKey]
+	if !ok {
+		return nil, fmt.Errorf("metadata key '%s' not found", c.SeriesFromMetadataKey)
+	}
+
+	var series []float64
+
+	switch v := value.(type) {
+	case []float64:
+		series = v
+	case []int:
+		series = make([]float64, len(v))
+		for i, n := range v {
+			series[i] = float64(n)
+		}
+	case []any:
+		series = make([]float64, len(v))
+		for i, item := range v {
+			f, ok := toFloat64(item)
+			if !ok {
+				return nil, fmt.Errorf("metadata key '%s' item %d is not a number", c.SeriesFromMetadataKey, i)
+			}
+			series[i] = f
+		}
+	default:
+		return nil, fmt.Errorf("metadata key '%s' is not a list of numbers", c.SeriesFromMetadataKey)
+	}
+
+	if len(series) > MaxChartSeriesLength {
+		return nil, fmt.Errorf("metadata key '%s' has too many items, expected <=%d", c.SeriesFromMetadataKey, MaxChartSeriesLength)
+	}
+
+	return series, nil
+}
+
+// toFloat64 converts the supported numeric types to float64.
+func toFloat64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
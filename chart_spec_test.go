@@ -0,0 +1,85 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartTypeValidation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.ChartTypeIsValid(types.ChartTypeSparkline))
+	assert.True(t, types.ChartTypeIsValid(types.ChartTypeLine))
+	assert.True(t, types.ChartTypeIsValid(types.ChartTypeBar))
+	assert.False(t, types.ChartTypeIsValid("invalid"))
+	assert.Len(t, types.ValidChartTypes(), 3)
+}
+
+func TestChartSpecClean(t *testing.T) {
+	t.Parallel()
+
+	var c *types.ChartSpec
+	c.Clean()
+
+	c = &types.ChartSpec{Type: " LINE ", SeriesFromMetadataKey: " latency ", Title: " p99\nlatency "}
+	c.Clean()
+	assert.Equal(t, types.ChartTypeLine, c.Type)
+	assert.Equal(t, "latency", c.SeriesFromMetadataKey)
+	assert.Equal(t, "p99 latency", c.Title)
+
+	c = &types.ChartSpec{SeriesFromMetadataKey: "latency"}
+	c.Clean()
+	assert.Equal(t, types.ChartTypeSparkline, c.Type)
+}
+
+func TestChartSpecSeries(t *testing.T) {
+	t.Parallel()
+
+	c := &types.ChartSpec{SeriesFromMetadataKey: "latency"}
+
+	series, err := c.Series(map[string]any{"latency": []float64{1.5, 2.5}})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1.5, 2.5}, series)
+
+	series, err = c.Series(map[string]any{"latency": []int{1, 2}})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 2}, series)
+
+	var metadata map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{"latency":[1,2.5,3]}`), &metadata))
+	series, err = c.Series(metadata)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 2.5, 3}, series)
+
+	_, err = c.Series(map[string]any{})
+	require.ErrorContains(t, err, "not found")
+
+	_, err = c.Series(map[string]any{"latency": "foo"})
+	require.ErrorContains(t, err, "not a list of numbers")
+
+	_, err = c.Series(map[string]any{"latency": []any{1.0, "foo"}})
+	require.ErrorContains(t, err, "item 1 is not a number")
+
+	_, err = c.Series(map[string]any{"latency": make([]float64, types.MaxChartSeriesLength+1)})
+	require.ErrorContains(t, err, "too many items")
+}
+
+func TestAlertChartValidation(t *testing.T) {
+	t.Parallel()
+
+	a := &types.Alert{Header: "a", RouteKey: "b", Chart: &types.ChartSpec{SeriesFromMetadataKey: "latency"}}
+	a.Clean()
+	require.NoError(t, a.Validate())
+
+	a = &types.Alert{Header: "a", RouteKey: "b", Chart: &types.ChartSpec{}}
+	a.Clean()
+	require.ErrorContains(t, a.Validate(), "chart.seriesFromMetadataKey is required")
+
+	a = &types.Alert{Header: "a", RouteKey: "b", Chart: &types.ChartSpec{Type: "pie", SeriesFromMetadataKey: "latency"}}
+	a.Clean()
+	require.ErrorContains(t, a.Validate(), "chart.type 'pie' is not valid")
+}
@@ -0,0 +1,33 @@
+package types
+
+// ChartType represents the type of chart rendered into a Slack post.
+type ChartType string
+
+const (
+	// ChartTypeSparkline is a small, axis-less line chart, suitable for showing a trend at a glance.
+	ChartTypeSparkline ChartType = "sparkline"
+
+	// ChartTypeLine is a line chart with axes.
+	ChartTypeLine ChartType = "line"
+
+	// ChartTypeBar is a bar chart with axes.
+	ChartTypeBar ChartType = "bar"
+)
+
+// ChartTypeIsValid returns true if the provided ChartType is valid.
+func ChartTypeIsValid(s ChartType) bool {
+	switch s {
+	case ChartTypeSparkline, ChartTypeLine, ChartTypeBar:
+		return true
+	}
+	return false
+}
+
+// ValidChartTypes returns a slice of valid ChartType values.
+func ValidChartTypes() []string {
+	return []string{
+		string(ChartTypeSparkline),
+		string(ChartTypeLine),
+		string(ChartTypeBar),
+	}
+}
@@ -0,0 +1,218 @@
+// Package client provides an HTTP client for submitting alerts to the Slack Manager API.
+//
+// The client validates alerts before sending them, retries failed requests with an httpretry.Transport
+// (exponential backoff and jitter, honoring Retry-After, with an optional circuit breaker), and sets an
+// Idempotency-Key header on every request, so that retried requests are not processed twice.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/httpretry"
+)
+
+const (
+	// AlertPath is the API path for submitting a single alert.
+	AlertPath = "/alert"
+
+	// AlertsPath is the API path for submitting a batch of alerts.
+	AlertsPath = "/alerts"
+
+	// ResolvePath is the API path for resolving an issue.
+	ResolvePath = "/resolve"
+
+	// IdempotencyKeyHeader is the HTTP header carrying the idempotency key of a request.
+	IdempotencyKeyHeader = httpretry.IdempotencyKeyHeader
+
+	// TransportName is the name of the client in the logs and metrics of its httpretry.Transport.
+	TransportName = "slackmgr-client"
+
+	// maxErrorBodyLength is the maximum number of response body bytes kept in an Error.
+	maxErrorBodyLength = 4096
+)
+
+// Client submits alerts to the Slack Manager API. It is safe for concurrent use.
+type Client struct {
+	baseURL string
+	opts    *options
+}
+
+// New creates a new Client for the Slack Manager API at baseURL, such as 'https://slack-manager.example.com/api'.
+// An error is returned if the base URL is not an absolute http(s) URL.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("base URL '%s' is not an absolute http(s) URL", baseURL)
+	}
+
+	o := newOptions()
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		opts:    o,
+	}, nil
+}
+
+// SendAlert cleans and validates the alert, and submits it to the API.
+// The source configured with WithSource is set on the alert, unless it already has one.
+// The idempotency key is the alert unique ID (see types.Alert.UniqueID).
+func (c *Client) SendAlert(ctx context.Context, alert *types.Alert) (err error) {
+	if alert == nil {
+		return errors.New("alert cannot be nil")
+	}
+
+	ctx, end := c.opts.tracer.StartSpan(ctx, "slackmgr.client.send_alert", alert.TraceAttrs())
+	defer func() { end(err) }()
+
+	c.setSource(alert)
+	alert.Clean()
+
+	if err := alert.Validate(); err != nil {
+		return fmt.Errorf("alert is not valid: %w", err)
+	}
+
+	return c.post(ctx, AlertPath, alert, alert.UniqueID())
+}
+
+// setSource sets a copy of the configured source on the alert, if the alert has no source.
+func (c *Client) setSource(alert *types.Alert) {
+	if c.opts.source == nil || alert == nil || alert.Source != nil {
+		return
+	}
+
+	source := *c.opts.source
+	alert.Source = &source
+}
+
+// SendBatch cleans and validates the batch and all alerts in it, and submits the batch to the API.
+// The idempotency key is derived from the unique IDs of the alerts.
+func (c *Client) SendBatch(ctx context.Context, batch *types.AlertBatch) (err error) {
+	if batch == nil {
+		return errors.New("batch cannot be nil")
+	}
+
+	ctx, end := c.opts.tracer.StartSpan(ctx, "slackmgr.client.send_batch", map[string]any{"slackmgr.batch_size": batch.Len()})
+	defer func() { end(err) }()
+
+	for _, alert := range batch.Alerts {
+		c.setSource(alert)
+	}
+
+	batch.Clean()
+
+	if err := batch.Validate(); err != nil {
+		return fmt.Errorf("batch is not valid: %w", err)
+	}
+
+	ids := make([]string, 0, batch.Len())
+
+	for _, result := range batch.ValidateItems() {
+		if result.Error != "" {
+			return fmt.Errorf("alerts[%d] is not valid: %s", result.Index, result.Error)
+		}
+
+		ids = append(ids, result.UniqueID)
+	}
+
+	return c.post(ctx, AlertsPath, batch, idempotencyKey(append([]string{"batch"}, ids...)...))
+}
+
+// ResolveIssue cleans and validates the resolve request, and submits it to the API.
+// The idempotency key is derived from the request fields.
+func (c *Client) ResolveIssue(ctx context.Context, req *types.ResolveRequest) (err error) {
+	if req == nil {
+		return errors.New("resolve request cannot be nil")
+	}
+
+	ctx, end := c.opts.tracer.StartSpan(ctx, "slackmgr.client.resolve_issue", map[string]any{
+		types.TraceAttrCorrelationID: req.CorrelationID,
+		types.TraceAttrChannelID:     req.SlackChannelID,
+	})
+	defer func() { end(err) }()
+
+	req.Clean()
+
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("resolve request is not valid: %w", err)
+	}
+
+	key := idempotencyKey("resolve", req.SlackChannelID, req.RouteKey, req.CorrelationID, string(req.Resolution), req.Note, req.Actor)
+
+	return c.post(ctx, ResolvePath, req, key)
+}
+
+// post sends a request, retried by the transport of the HTTP client (see options.validate).
+func (c *Client) post(ctx context.Context, path string, body any, key string) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, key)
+
+	if c.opts.userAgent != "" {
+		req.Header.Set("User-Agent", c.opts.userAgent)
+	}
+
+	for name, value := range c.opts.headers {
+		req.Header.Set(name, value)
+	}
+
+	traceHeaders := make(map[string]string)
+	c.opts.tracer.Inject(ctx, traceHeaders)
+
+	for name, value := range traceHeaders {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.opts.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+
+	return newAPIError(resp.StatusCode, string(respBody), httpretry.ParseRetryAfter(resp.Header.Get("Retry-After")))
+}
+
+func idempotencyKey(input ...string) string {
+	h := sha256.New()
+
+	for _, s := range input {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	return base64.URLEncoding.EncodeToString(h.Sum(nil))
+}
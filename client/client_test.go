@@ -0,0 +1,434 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/client"
+	"github.com/slackmgr/types/faults"
+	"github.com/slackmgr/types/httpretry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	path           string
+	idempotencyKey string
+	authorization  string
+	traceparent    string
+	body           []byte
+}
+
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []recordedRequest
+	statuses []int
+}
+
+func newTestServer(t *testing.T, statuses ...int) *testServer {
+	t.Helper()
+
+	s := &testServer{statuses: statuses}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.mu.Lock()
+		s.requests = append(s.requests, recordedRequest{
+			path:           r.URL.Path,
+			idempotencyKey: r.Header.Get(client.IdempotencyKeyHeader),
+			authorization:  r.Header.Get("Authorization"),
+			traceparent:    r.Header.Get("Traceparent"),
+			body:           body,
+		})
+
+		status := http.StatusAccepted
+		if len(s.statuses) > 0 {
+			status = s.statuses[0]
+			s.statuses = s.statuses[1:]
+		}
+		s.mu.Unlock()
+
+		w.WriteHeader(status)
+
+		if status >= 400 {
+			_, _ = w.Write([]byte("something went wrong"))
+		}
+	}))
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *testServer) recorded() []recordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]recordedRequest(nil), s.requests...)
+}
+
+func newAlert() *types.Alert {
+	a := types.NewErrorAlert()
+	a.SlackChannelID = "C12345678"
+	a.Header = "Disk full"
+
+	return a
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := client.New("not a url")
+	require.EqualError(t, err, "base URL 'not a url' is not an absolute http(s) URL")
+
+	_, err = client.New("http://localhost", client.WithMaxAttempts(0))
+	require.EqualError(t, err, "max attempts must be at least 1")
+
+	_, err = client.New("http://localhost", client.WithHTTPClient(nil))
+	require.EqualError(t, err, "http client cannot be nil")
+
+	_, err = client.New("http://localhost", client.WithBackoff(-1, 0))
+	require.EqualError(t, err, "backoff cannot be negative")
+
+	_, err = client.New("http://localhost", client.WithHTTPTarget(types.HTTPTargetConfig{ProxyURL: "proxy.internal:3128"}))
+	require.EqualError(t, err, "invalid http target: proxyUrl is not valid, expected an absolute http, https or socks5 URL")
+
+	_, err = client.New("http://localhost", client.WithHTTPTarget(types.HTTPTargetConfig{ProxyURL: "http://proxy.internal:3128", TLSMinVersion: types.TLSVersion13}))
+	require.NoError(t, err)
+}
+
+func TestSendAlert(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	c, err := client.New(server.URL+"/api/", client.WithHeader("Authorization", "Bearer secret"))
+	require.NoError(t, err)
+
+	alert := newAlert()
+	require.NoError(t, c.SendAlert(context.Background(), alert))
+
+	requests := server.recorded()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/api/alert", requests[0].path)
+	assert.Equal(t, alert.UniqueID(), requests[0].idempotencyKey)
+	assert.Equal(t, "Bearer secret", requests[0].authorization)
+
+	var decoded types.Alert
+	require.NoError(t, json.Unmarshal(requests[0].body, &decoded))
+	assert.Equal(t, "Disk full", decoded.Header)
+
+	invalid := newAlert()
+	invalid.Severity = "fatal"
+	require.ErrorContains(t, c.SendAlert(context.Background(), invalid), "alert is not valid")
+	require.EqualError(t, c.SendAlert(context.Background(), nil), "alert cannot be nil")
+	assert.Len(t, server.recorded(), 1)
+}
+
+func TestSendAlertSource(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	c, err := client.New(server.URL, client.WithSource(types.AlertSource{System: "billing", Version: "1.4.2"}))
+	require.NoError(t, err)
+
+	alert := newAlert()
+	require.NoError(t, c.SendAlert(context.Background(), alert))
+	assert.Equal(t, &types.AlertSource{System: "billing", Version: "1.4.2", IngestPath: types.IngestPathClient}, alert.Source)
+
+	own := newAlert()
+	own.Source = &types.AlertSource{System: "cron"}
+	require.NoError(t, c.SendBatch(context.Background(), types.NewAlertBatch(newAlert(), own)))
+	assert.Equal(t, "cron", own.Source.System)
+
+	var batch types.AlertBatch
+	require.NoError(t, json.Unmarshal(server.recorded()[1].body, &batch))
+	assert.Equal(t, "billing", batch.Alerts[0].Source.System)
+	assert.Equal(t, "cron", batch.Alerts[1].Source.System)
+}
+
+func TestSendAlertRetries(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+
+	c, err := client.New(server.URL, client.WithBackoff(time.Millisecond, 5*time.Millisecond))
+	require.NoError(t, err)
+
+	require.NoError(t, c.SendAlert(context.Background(), newAlert()))
+
+	requests := server.recorded()
+	require.Len(t, requests, 3)
+	assert.Equal(t, requests[0].idempotencyKey, requests[2].idempotencyKey)
+}
+
+type countingMetrics struct {
+	types.NoopMetrics
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (m *countingMetrics) Inc(name string, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.counts[name+"|"+strings.Join(labelValues, "|")]++
+}
+
+func TestSendAlertRetryMetrics(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, http.StatusServiceUnavailable)
+	logger := types.NewTestLogger()
+	metrics := &countingMetrics{counts: make(map[string]int)}
+
+	c, err := client.New(server.URL, client.WithBackoff(time.Millisecond, time.Millisecond), client.WithLogger(logger), client.WithMetrics(metrics))
+	require.NoError(t, err)
+
+	require.NoError(t, c.SendAlert(context.Background(), newAlert()))
+
+	u, _ := url.Parse(server.URL)
+	assert.Equal(t, 1, metrics.counts[httpretry.RetriesMetric+"|"+client.TransportName+"|"+u.Host+"|503"])
+	assert.True(t, logger.Contains("Retrying POST request"))
+
+	_, err = client.New(server.URL, client.WithLogger(nil))
+	require.EqualError(t, err, "logger cannot be nil")
+}
+
+func TestSendAlertWithFaults(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	c, err := client.New(server.URL, client.WithFaults(faults.FaultProfile{DuplicateRate: 1}))
+	require.NoError(t, err)
+
+	require.NoError(t, c.SendAlert(context.Background(), newAlert()))
+
+	requests := server.recorded()
+	require.Len(t, requests, 2)
+	assert.Equal(t, requests[0].idempotencyKey, requests[1].idempotencyKey)
+
+	c, err = client.New(server.URL, client.WithMaxAttempts(2), client.WithBackoff(time.Millisecond, time.Millisecond),
+		client.WithFaults(faults.FaultProfile{ErrorRate: 1}))
+	require.NoError(t, err)
+
+	require.ErrorIs(t, c.SendAlert(context.Background(), newAlert()), faults.ErrInjectedFault)
+	assert.Len(t, server.recorded(), 2)
+}
+
+func TestSendAlertGivesUp(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, 500, 500, 500)
+
+	c, err := client.New(server.URL, client.WithMaxAttempts(2), client.WithBackoff(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+
+	err = c.SendAlert(context.Background(), newAlert())
+
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 500, apiErr.StatusCode)
+	assert.Equal(t, "something went wrong", apiErr.Body)
+	assert.Len(t, server.recorded(), 2)
+}
+
+func TestSendAlertDoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, http.StatusBadRequest)
+
+	c, err := client.New(server.URL, client.WithBackoff(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+
+	require.EqualError(t, c.SendAlert(context.Background(), newAlert()), "slack manager api returned status 400: something went wrong")
+	assert.Len(t, server.recorded(), 1)
+}
+
+func TestSendAlertCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, 503, 503, 503, 503)
+
+	breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 2, OpenDuration: time.Hour})
+	require.NoError(t, err)
+
+	c, err := client.New(server.URL, client.WithCircuitBreaker(breaker), client.WithBackoff(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+
+	err = c.SendAlert(context.Background(), newAlert())
+	require.ErrorIs(t, err, httpretry.ErrCircuitOpen)
+	assert.Len(t, server.recorded(), 2)
+
+	// The open circuit is not retried.
+	require.ErrorIs(t, c.SendAlert(context.Background(), newAlert()), httpretry.ErrCircuitOpen)
+	assert.Len(t, server.recorded(), 2)
+}
+
+func TestSendAlertCancelledRequestIsNotRecorded(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+
+		if calls.Add(1) == 2 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 2, OpenDuration: time.Hour})
+	require.NoError(t, err)
+
+	c, err := client.New(server.URL, client.WithCircuitBreaker(breaker), client.WithMaxAttempts(1))
+	require.NoError(t, err)
+
+	require.Error(t, c.SendAlert(context.Background(), newAlert()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, c.SendAlert(ctx, newAlert()), context.DeadlineExceeded)
+
+	// The cancelled request did not reset the failure count, so the next failure opens the circuit.
+	require.Error(t, c.SendAlert(context.Background(), newAlert()))
+
+	u, _ := url.Parse(server.URL)
+	assert.Equal(t, httpretry.CircuitOpen, breaker.State(u.Host))
+}
+
+func TestSendAlertContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, 503, 503, 503)
+
+	c, err := client.New(server.URL, client.WithBackoff(time.Hour, time.Hour))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = c.SendAlert(ctx, newAlert())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, server.recorded(), 1)
+}
+
+func TestSendBatch(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	c, err := client.New(server.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, c.SendBatch(context.Background(), types.NewAlertBatch(newAlert(), newAlert())))
+
+	requests := server.recorded()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/alerts", requests[0].path)
+	assert.NotEmpty(t, requests[0].idempotencyKey)
+
+	var batch types.AlertBatch
+	require.NoError(t, json.Unmarshal(requests[0].body, &batch))
+	assert.Equal(t, 2, batch.Len())
+
+	invalid := newAlert()
+	invalid.Severity = "fatal"
+	require.ErrorContains(t, c.SendBatch(context.Background(), types.NewAlertBatch(newAlert(), invalid)), "alerts[1] is not valid")
+	require.ErrorContains(t, c.SendBatch(context.Background(), types.NewAlertBatch()), "batch is not valid")
+}
+
+func TestResolveIssue(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	c, err := client.New(server.URL)
+	require.NoError(t, err)
+
+	req := &types.ResolveRequest{CorrelationID: "abc", SlackChannelID: "C12345678"}
+	require.NoError(t, c.ResolveIssue(context.Background(), req))
+
+	requests := server.recorded()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/resolve", requests[0].path)
+
+	require.ErrorContains(t, c.ResolveIssue(context.Background(), &types.ResolveRequest{}), "resolve request is not valid")
+}
+
+type spanKey struct{}
+
+// recordingTracer records ended spans, and injects the name of the current span as traceparent header.
+type recordingTracer struct {
+	types.NoopTracer
+
+	mu    sync.Mutex
+	spans []string
+	errs  []error
+}
+
+func (tr *recordingTracer) StartSpan(ctx context.Context, name string, _ map[string]any) (context.Context, func(err error)) {
+	return context.WithValue(ctx, spanKey{}, name), func(err error) {
+		tr.mu.Lock()
+		defer tr.mu.Unlock()
+
+		tr.spans = append(tr.spans, name)
+		tr.errs = append(tr.errs, err)
+	}
+}
+
+func (tr *recordingTracer) Inject(ctx context.Context, carrier map[string]string) {
+	if name, ok := ctx.Value(spanKey{}).(string); ok {
+		carrier["traceparent"] = name
+	}
+}
+
+func TestTracing(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, http.StatusAccepted, http.StatusBadRequest)
+	tracer := &recordingTracer{}
+
+	c, err := client.New(server.URL, client.WithTracer(tracer))
+	require.NoError(t, err)
+
+	require.NoError(t, c.SendAlert(context.Background(), newAlert()))
+	require.Error(t, c.ResolveIssue(context.Background(), &types.ResolveRequest{SlackChannelID: "C12345678", CorrelationID: "disk-full"}))
+
+	requests := server.recorded()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "slackmgr.client.send_alert", requests[0].traceparent)
+	assert.Equal(t, "slackmgr.client.resolve_issue", requests[1].traceparent)
+
+	assert.Equal(t, []string{"slackmgr.client.send_alert", "slackmgr.client.resolve_issue"}, tracer.spans)
+	require.NoError(t, tracer.errs[0])
+	require.Error(t, tracer.errs[1])
+
+	_, err = client.New(server.URL, client.WithTracer(nil))
+	require.EqualError(t, err, "tracer cannot be nil")
+}
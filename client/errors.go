@@ -0,0 +1,235 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// Error codes of ErrorResponse, used by the API to identify the specific error types.
+const (
+	// ErrorCodeValidationFailed is the code of responses for alerts and requests failing validation (ValidationRejectedError).
+	ErrorCodeValidationFailed = "validation_failed"
+
+	// ErrorCodeRouteNotFound is the code of responses for unknown route keys (RouteNotFoundError).
+	ErrorCodeRouteNotFound = "route_not_found"
+
+	// ErrorCodeChannelNotFound is the code of responses for unknown Slack channels (ChannelNotFoundError).
+	ErrorCodeChannelNotFound = "channel_not_found"
+
+	// ErrorCodeRateLimited is the code of responses for rate limited requests (RateLimitedError).
+	ErrorCodeRateLimited = "rate_limited"
+
+	// ErrorCodeUnauthorized is the code of responses for requests with missing or invalid credentials (UnauthorizedError).
+	ErrorCodeUnauthorized = "unauthorized"
+)
+
+// ErrorResponse is the JSON body of API error responses. The API sets Code, so that the client returns
+// the specific error type; responses with another body are classified by status code only.
+type ErrorResponse struct {
+	// Code identifies the error, as one of the ErrorCode* constants.
+	Code string `json:"code"`
+
+	// Message is a human-readable description of the error.
+	Message string `json:"message"`
+
+	// FieldErrors lists the invalid fields, for ErrorCodeValidationFailed.
+	FieldErrors []types.FieldError `json:"fieldErrors,omitempty"`
+
+	// RouteKey is the unknown route key, for ErrorCodeRouteNotFound.
+	RouteKey string `json:"routeKey,omitempty"`
+
+	// Channel is the unknown Slack channel ID or name, for ErrorCodeChannelNotFound.
+	Channel string `json:"channel,omitempty"`
+}
+
+// APIError is implemented by all errors returned for non-2xx API responses: *Error, and the specific error types
+// wrapping it (ValidationRejectedError, RouteNotFoundError, ChannelNotFoundError, RateLimitedError and UnauthorizedError).
+// Use errors.As with the specific types to branch on the kind of error, or with APIError or *Error to read the response.
+type APIError interface {
+	error
+
+	// Response returns the response of the error.
+	Response() *Error
+}
+
+// Error is returned when the API responds with a non-2xx status code. Responses identified as a specific error
+// are returned as the specific error type, which wraps the Error.
+type Error struct {
+	// StatusCode is the HTTP response status code.
+	StatusCode int
+
+	// Body is the response body, truncated at 4096 bytes.
+	Body string
+
+	// Code is the error code of a JSON ErrorResponse body, or empty.
+	Code string
+
+	// Message is the message of a JSON ErrorResponse body, or empty.
+	Message string
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Message != "" && e.Code != "":
+		return fmt.Sprintf("slack manager api returned status %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	case e.Message != "":
+		return fmt.Sprintf("slack manager api returned status %d: %s", e.StatusCode, e.Message)
+	case e.Body == "":
+		return fmt.Sprintf("slack manager api returned status %d", e.StatusCode)
+	default:
+		return fmt.Sprintf("slack manager api returned status %d: %s", e.StatusCode, e.Body)
+	}
+}
+
+// Response returns the error itself.
+func (e *Error) Response() *Error {
+	return e
+}
+
+// ValidationRejectedError is returned when the API rejects an alert or request as invalid,
+// with ErrorCodeValidationFailed, or a 400 or 422 status code.
+type ValidationRejectedError struct {
+	Err *Error
+
+	// FieldErrors lists the invalid fields, if reported by the API.
+	FieldErrors []types.FieldError
+}
+
+func (e *ValidationRejectedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationRejectedError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ValidationRejectedError) Response() *Error {
+	return e.Err
+}
+
+// RouteNotFoundError is returned when the API has no route for the route key of an alert, with ErrorCodeRouteNotFound.
+// It matches types.ErrRouteNotFound with errors.Is.
+type RouteNotFoundError struct {
+	Err *Error
+
+	// RouteKey is the unknown route key, if reported by the API.
+	RouteKey string
+}
+
+func (e *RouteNotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RouteNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RouteNotFoundError) Response() *Error {
+	return e.Err
+}
+
+// Is returns true for types.ErrRouteNotFound.
+func (e *RouteNotFoundError) Is(target error) bool {
+	return target == types.ErrRouteNotFound
+}
+
+// ChannelNotFoundError is returned when the API cannot find the Slack channel of an alert, with ErrorCodeChannelNotFound.
+// It matches types.ErrChannelNotFound with errors.Is.
+type ChannelNotFoundError struct {
+	Err *Error
+
+	// Channel is the unknown Slack channel ID or name, if reported by the API.
+	Channel string
+}
+
+func (e *ChannelNotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ChannelNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ChannelNotFoundError) Response() *Error {
+	return e.Err
+}
+
+// Is returns true for types.ErrChannelNotFound.
+func (e *ChannelNotFoundError) Is(target error) bool {
+	return target == types.ErrChannelNotFound
+}
+
+// RateLimitedError is returned when the API rate limits the request, with ErrorCodeRateLimited or a 429 status code,
+// after all retries are exhausted.
+type RateLimitedError struct {
+	Err *Error
+
+	// RetryAfter is the delay requested by the API in the Retry-After header, or zero.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RateLimitedError) Response() *Error {
+	return e.Err
+}
+
+// UnauthorizedError is returned when the API rejects the credentials of the request, with ErrorCodeUnauthorized,
+// or a 401 or 403 status code.
+type UnauthorizedError struct {
+	Err *Error
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UnauthorizedError) Unwrap() error {
+	return e.Err
+}
+
+func (e *UnauthorizedError) Response() *Error {
+	return e.Err
+}
+
+// newAPIError returns the error of a non-2xx response: the specific error type identified by the ErrorResponse code,
+// or else by the status code, or a plain *Error.
+func newAPIError(statusCode int, body string, retryAfter time.Duration) error {
+	apiErr := &Error{
+		StatusCode: statusCode,
+		Body:       strings.TrimSpace(body),
+	}
+
+	var resp ErrorResponse
+
+	if err := json.Unmarshal([]byte(apiErr.Body), &resp); err == nil {
+		apiErr.Code = resp.Code
+		apiErr.Message = resp.Message
+	}
+
+	switch {
+	case resp.Code == ErrorCodeRouteNotFound:
+		return &RouteNotFoundError{Err: apiErr, RouteKey: resp.RouteKey}
+	case resp.Code == ErrorCodeChannelNotFound:
+		return &ChannelNotFoundError{Err: apiErr, Channel: resp.Channel}
+	case resp.Code == ErrorCodeRateLimited, resp.Code == "" && statusCode == http.StatusTooManyRequests:
+		return &RateLimitedError{Err: apiErr, RetryAfter: retryAfter}
+	case resp.Code == ErrorCodeUnauthorized, resp.Code == "" && (statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden):
+		return &UnauthorizedError{Err: apiErr}
+	case resp.Code == ErrorCodeValidationFailed, resp.Code == "" && (statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity):
+		return &ValidationRejectedError{Err: apiErr, FieldErrors: resp.FieldErrors}
+	default:
+		return apiErr
+	}
+}
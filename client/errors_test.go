@@ -0,0 +1,106 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendWithResponse(t *testing.T, status int, body string, header http.Header) error {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		for name, values := range header {
+			w.Header()[name] = values
+		}
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(server.URL, client.WithMaxAttempts(1))
+	require.NoError(t, err)
+
+	return c.SendAlert(context.Background(), newAlert())
+}
+
+func TestAPIErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validation errors should include the field errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := sendWithResponse(t, http.StatusUnprocessableEntity,
+			`{"code": "validation_failed", "message": "header is required", "fieldErrors": [{"field": "header", "message": "header is required"}]}`, nil)
+
+		var validationErr *client.ValidationRejectedError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []types.FieldError{{Field: "header", Message: "header is required"}}, validationErr.FieldErrors)
+		require.EqualError(t, err, "slack manager api returned status 422 (validation_failed): header is required")
+
+		var apiErr *client.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+		assert.Equal(t, client.ErrorCodeValidationFailed, apiErr.Code)
+	})
+
+	t.Run("not found errors should match the resolver errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := sendWithResponse(t, http.StatusNotFound, `{"code": "route_not_found", "message": "no route for 'payments'", "routeKey": "payments"}`, nil)
+
+		var routeErr *client.RouteNotFoundError
+		require.ErrorAs(t, err, &routeErr)
+		assert.Equal(t, "payments", routeErr.RouteKey)
+		require.ErrorIs(t, err, types.ErrRouteNotFound)
+		require.NotErrorIs(t, err, types.ErrChannelNotFound)
+
+		err = sendWithResponse(t, http.StatusNotFound, `{"code": "channel_not_found", "message": "channel not found", "channel": "alerts"}`, nil)
+
+		var channelErr *client.ChannelNotFoundError
+		require.ErrorAs(t, err, &channelErr)
+		assert.Equal(t, "alerts", channelErr.Channel)
+		require.ErrorIs(t, err, types.ErrChannelNotFound)
+	})
+
+	t.Run("rate limited errors should include the retry after delay", func(t *testing.T) {
+		t.Parallel()
+
+		err := sendWithResponse(t, http.StatusTooManyRequests, "slow down", http.Header{"Retry-After": []string{"30"}})
+
+		var rateLimitedErr *client.RateLimitedError
+		require.ErrorAs(t, err, &rateLimitedErr)
+		assert.Equal(t, 30*time.Second, rateLimitedErr.RetryAfter)
+		require.EqualError(t, err, "slack manager api returned status 429: slow down")
+	})
+
+	t.Run("errors should be classified by status code without error code", func(t *testing.T) {
+		t.Parallel()
+
+		var unauthorizedErr *client.UnauthorizedError
+		require.ErrorAs(t, sendWithResponse(t, http.StatusUnauthorized, "", nil), &unauthorizedErr)
+		require.ErrorAs(t, sendWithResponse(t, http.StatusForbidden, `{"message": "forbidden"}`, nil), &unauthorizedErr)
+		require.ErrorAs(t, sendWithResponse(t, http.StatusBadRequest, `{"code": "unauthorized", "message": "invalid token"}`, nil), &unauthorizedErr)
+
+		var validationErr *client.ValidationRejectedError
+		require.ErrorAs(t, sendWithResponse(t, http.StatusBadRequest, "bad request", nil), &validationErr)
+		assert.Empty(t, validationErr.FieldErrors)
+
+		err := sendWithResponse(t, http.StatusNotFound, "not found", nil)
+
+		var apiErr client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.Response().StatusCode)
+		assert.False(t, errors.As(err, &validationErr))
+		require.NotErrorIs(t, err, types.ErrRouteNotFound)
+	})
+}
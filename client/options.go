@@ -0,0 +1,237 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/faults"
+	"github.com/slackmgr/types/httpretry"
+)
+
+const (
+	// DefaultMaxAttempts is the default maximum number of attempts per request, including the first one.
+	DefaultMaxAttempts = 4
+
+	// DefaultInitialBackoff is the default upper bound of the delay before the first retry.
+	DefaultInitialBackoff = 200 * time.Millisecond
+
+	// DefaultMaxBackoff is the default upper bound of the delay between retries.
+	DefaultMaxBackoff = 10 * time.Second
+
+	// DefaultTimeout is the default timeout of each attempt, when no HTTP client is provided.
+	DefaultTimeout = 30 * time.Second
+)
+
+// Option configures a Client.
+type Option func(*options)
+
+type options struct {
+	httpClient     *http.Client
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	userAgent      string
+	headers        map[string]string
+	tracer         types.Tracer
+	logger         types.Logger
+	metrics        types.Metrics
+	source         *types.AlertSource
+	breaker        *httpretry.CircuitBreaker
+	httpTarget     *types.HTTPTargetConfig
+	faults         *faults.FaultProfile
+}
+
+func newOptions() *options {
+	return &options{
+		httpClient:     &http.Client{Timeout: DefaultTimeout},
+		maxAttempts:    DefaultMaxAttempts,
+		initialBackoff: DefaultInitialBackoff,
+		maxBackoff:     DefaultMaxBackoff,
+		userAgent:      "slackmgr-go-client",
+		headers:        make(map[string]string),
+		tracer:         &types.NoopTracer{},
+		logger:         &types.NoopLogger{},
+		metrics:        &types.NoopMetrics{},
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests. Its transport is wrapped by the retrying
+// httpretry.Transport, and its timeout applies to each attempt rather than to all attempts of a request.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithHTTPTarget sets the proxy, TLS settings (such as a client certificate for mTLS) and timeout of requests,
+// replacing the HTTP client set with WithHTTPClient. The target timeout defaults to DefaultTimeout.
+func WithHTTPTarget(target types.HTTPTargetConfig) Option {
+	return func(o *options) {
+		o.httpTarget = &target
+	}
+}
+
+// WithMaxAttempts sets the maximum number of attempts per request, including the first one.
+// A value of 1 disables retries.
+func WithMaxAttempts(maxAttempts int) Option {
+	return func(o *options) {
+		o.maxAttempts = maxAttempts
+	}
+}
+
+// WithBackoff sets the upper bound of the delay before the first retry, and of the delay between any two attempts.
+// The bound is doubled for each retry, and the actual delay is random between zero and the bound (full jitter).
+// Zero values use DefaultInitialBackoff and DefaultMaxBackoff.
+func WithBackoff(initial, maxBackoff time.Duration) Option {
+	return func(o *options) {
+		o.initialBackoff = initial
+		o.maxBackoff = maxBackoff
+	}
+}
+
+// WithUserAgent sets the User-Agent header of requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithHeader sets an additional header on all requests, such as an Authorization header.
+func WithHeader(name, value string) Option {
+	return func(o *options) {
+		o.headers[name] = value
+	}
+}
+
+// WithTracer sets the tracer used to create a span per API call, and to propagate the trace context
+// to the API in the request headers.
+func WithTracer(tracer types.Tracer) Option {
+	return func(o *options) {
+		o.tracer = tracer
+	}
+}
+
+// WithLogger sets the logger receiving the retried requests and the circuit state changes.
+func WithLogger(logger types.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithMetrics sets the metrics receiving the httpretry.RetriesMetric and httpretry.CircuitRejectionsMetric counters,
+// with the client label TransportName.
+func WithMetrics(metrics types.Metrics) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// WithSource sets the source of alerts sent without one, identifying the sending system in the Slack posts.
+// The ingest path defaults to types.IngestPathClient.
+func WithSource(source types.AlertSource) Option {
+	return func(o *options) {
+		if source.IngestPath == "" {
+			source.IngestPath = types.IngestPathClient
+		}
+
+		o.source = &source
+	}
+}
+
+// WithCircuitBreaker sets the circuit breaker checked before each request. Requests to a host with an open circuit
+// fail immediately with an error wrapping httpretry.ErrCircuitOpen, and are not retried.
+// Share the breaker with other clients of the same host, so that they all stop calling it while it is failing.
+func WithCircuitBreaker(breaker *httpretry.CircuitBreaker) Option {
+	return func(o *options) {
+		o.breaker = breaker
+	}
+}
+
+// WithFaults injects faults according to p in each attempt, with faults.WrapTransport, so that producers
+// can be soak-tested against a failing API. Injected errors are retried like network errors.
+//
+// For TEST purposes only! Do not use in production!
+func WithFaults(p faults.FaultProfile) Option {
+	return func(o *options) {
+		o.faults = &p
+	}
+}
+
+func (o *options) validate() error {
+	if o.httpTarget != nil {
+		o.httpTarget.Clean()
+
+		transport, err := o.httpTarget.Transport()
+		if err != nil {
+			return fmt.Errorf("invalid http target: %w", err)
+		}
+
+		o.httpClient = &http.Client{Transport: transport, Timeout: o.httpTarget.Timeout(DefaultTimeout)}
+	}
+
+	if o.httpClient == nil {
+		return errors.New("http client cannot be nil")
+	}
+
+	if o.tracer == nil {
+		return errors.New("tracer cannot be nil")
+	}
+
+	if o.logger == nil {
+		return errors.New("logger cannot be nil")
+	}
+
+	if o.metrics == nil {
+		return errors.New("metrics cannot be nil")
+	}
+
+	if o.maxAttempts < 1 {
+		return errors.New("max attempts must be at least 1")
+	}
+
+	if o.initialBackoff < 0 || o.maxBackoff < 0 {
+		return errors.New("backoff cannot be negative")
+	}
+
+	inner := o.httpClient.Transport
+	if o.faults != nil {
+		inner = faults.WrapTransport(inner, *o.faults)
+	}
+
+	transport, err := httpretry.NewTransport(inner, httpretry.Config{
+		Name:               TransportName,
+		MaxAttempts:        o.maxAttempts,
+		InitialBackoff:     o.initialBackoff,
+		MaxBackoff:         o.maxBackoff,
+		RetryOnStatusCodes: retryStatusCodes(),
+		AttemptTimeout:     o.httpClient.Timeout,
+		Breaker:            o.breaker,
+		Logger:             o.logger,
+		Metrics:            o.metrics,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid retry transport: %w", err)
+	}
+
+	// The timeout moves to the transport, so that it applies to each attempt.
+	httpClient := *o.httpClient
+	httpClient.Transport = transport
+	httpClient.Timeout = 0
+	o.httpClient = &httpClient
+
+	return nil
+}
+
+// retryStatusCodes returns the status codes retried by the client: 429 Too Many Requests and all 5xx responses.
+func retryStatusCodes() []int {
+	codes := []int{http.StatusTooManyRequests}
+
+	for code := 500; code <= 599; code++ {
+		codes = append(codes, code)
+	}
+
+	return codes
+}
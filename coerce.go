@@ -0,0 +1,247 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// decodeMapIntoStruct maps the values in m onto the fields of the struct pointed to by v, using the json field tags
+// (or the field names, for fields without tags). Keys without a matching field are ignored, and fields without
+// a matching key are left unchanged.
+//
+// Values are coerced to the field types where this is lossless, for example float64 (as produced by encoding/json)
+// to int, and numeric or boolean strings to numbers and bools. Other field types are decoded with encoding/json.
+func decodeMapIntoStruct(m map[string]any, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("target must be a non-nil pointer to a struct")
+	}
+
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		name := field.Name
+
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+
+			if tagName == "-" {
+				continue
+			}
+
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		value, ok := m[name]
+		if !ok || value == nil {
+			continue
+		}
+
+		if err := coerceValue(value, rv.Field(i)); err != nil {
+			return fmt.Errorf("key '%s': %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// coerceTo converts value to T, with the same conversion rules as decodeMapIntoStruct.
+func coerceTo[T any](value any) (T, bool) {
+	var result T
+
+	if value == nil {
+		return result, false
+	}
+
+	if err := coerceValue(value, reflect.ValueOf(&result).Elem()); err != nil {
+		return result, false
+	}
+
+	return result, true
+}
+
+// coerceValue assigns value to target, converting it to the target type if needed.
+func coerceValue(value any, target reflect.Value) error {
+	if target.Kind() == reflect.Pointer {
+		ptr := reflect.New(target.Type().Elem())
+
+		if err := coerceValue(value, ptr.Elem()); err != nil {
+			return err
+		}
+
+		target.Set(ptr)
+
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		switch v := value.(type) {
+		case string:
+			target.SetString(v)
+		case float64, int, bool:
+			target.SetString(fmt.Sprint(v))
+		default:
+			return fmt.Errorf("cannot convert %T to string", value)
+		}
+	case reflect.Bool:
+		switch v := value.(type) {
+		case bool:
+			target.SetBool(v)
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return fmt.Errorf("cannot convert string '%s' to bool", v)
+			}
+			target.SetBool(b)
+		default:
+			return fmt.Errorf("cannot convert %T to bool", value)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, err := coerceNumber(value)
+		if err != nil {
+			return err
+		}
+
+		if !floatFitsInt(f, target.Type().Bits(), true) {
+			return fmt.Errorf("cannot convert %v to %s", value, target.Type())
+		}
+
+		target.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, err := coerceNumber(value)
+		if err != nil {
+			return err
+		}
+
+		if !floatFitsInt(f, target.Type().Bits(), false) {
+			return fmt.Errorf("cannot convert %v to %s", value, target.Type())
+		}
+
+		target.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		f, err := coerceNumber(value)
+		if err != nil {
+			return err
+		}
+
+		if target.OverflowFloat(f) {
+			return fmt.Errorf("cannot convert %v to %s", value, target.Type())
+		}
+
+		target.SetFloat(f)
+	default:
+		body, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal value: %w", err)
+		}
+
+		if err := json.Unmarshal(body, target.Addr().Interface()); err != nil {
+			return fmt.Errorf("cannot convert %T to %s: %w", value, target.Type(), err)
+		}
+	}
+
+	return nil
+}
+
+// coerceNumber converts numbers and numeric strings to float64.
+func coerceNumber(value any) (float64, error) {
+	if f, ok := toFloat64(value); ok {
+		return f, nil
+	}
+
+	if s, ok := value.(string); ok {
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert string '%s' to a number", s)
+		}
+		return f, nil
+	}
+
+	return 0, fmt.Errorf("cannot convert %T to a number", value)
+}
+
+// floatFitsInt reports whether f is a whole number within the range of a signed or unsigned integer of the given
+// bit size. The check is done on the float, since converting an out-of-range float to an integer type gives an
+// implementation-defined value.
+func floatFitsInt(f float64, bits int, signed bool) bool {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return false
+	}
+
+	if signed {
+		limit := math.Ldexp(1, bits-1)
+		return f >= -limit && f < limit
+	}
+
+	return f >= 0 && f < math.Ldexp(1, bits)
+}
+
+// coerceTime converts value to a time. Strings are parsed as RFC3339 timestamps. Numbers are parsed as Unix epoch
+// timestamps, in seconds, or in milliseconds for values >=1e12. Numbers outside the int64 range, NaN and ±Inf are
+// rejected.
+func coerceTime(value any) (time.Time, bool) {
+	switch val := value.(type) {
+	case time.Time:
+		return val, true
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(val)); err == nil {
+			return t, true
+		}
+	default:
+		if f, ok := toFloat64(val); ok {
+			if math.Abs(f) >= 1e12 {
+				ms := math.Trunc(f)
+				if !floatFitsInt(ms, 64, true) {
+					return time.Time{}, false
+				}
+
+				return time.UnixMilli(int64(ms)).UTC(), true
+			}
+
+			sec, frac := math.Modf(f)
+			if !floatFitsInt(sec, 64, true) {
+				return time.Time{}, false
+			}
+
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// coerceStringSlice converts value to a string slice, if it is a []string, or a []any holding only strings.
+func coerceStringSlice(value any) ([]string, bool) {
+	switch val := value.(type) {
+	case []string:
+		return val, true
+	case []any:
+		result := make([]string, len(val))
+		for i, item := range val {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			result[i] = s
+		}
+		return result, true
+	}
+
+	return nil, false
+}
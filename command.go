@@ -0,0 +1,486 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxCommandIDLength is the maximum length of a command ID.
+	MaxCommandIDLength = 200
+	// MaxCommandActorLength is the maximum length of the actor of a command.
+	MaxCommandActorLength = 200
+	// MaxCommandReasonLength is the maximum length of the reason of a command.
+	MaxCommandReasonLength = 1000
+)
+
+// CommandType represents the type of a command for the Slack Manager.
+type CommandType string
+
+const (
+	// CommandTypeResolveIssue resolves an issue (ResolveIssueCommand).
+	CommandTypeResolveIssue CommandType = "resolve_issue"
+
+	// CommandTypeArchiveIssue archives an issue, removing it from the channel without resolving it (ArchiveIssueCommand).
+	CommandTypeArchiveIssue CommandType = "archive_issue"
+
+	// CommandTypeMoveIssue moves an issue to another channel (MoveIssueCommand).
+	CommandTypeMoveIssue CommandType = "move_issue"
+
+	// CommandTypeSilenceChannel silences the alerts of a channel for a period of time (SilenceChannelCommand).
+	CommandTypeSilenceChannel CommandType = "silence_channel"
+)
+
+// CommandTypeIsValid returns true if the provided CommandType is valid.
+func CommandTypeIsValid(s CommandType) bool {
+	switch s {
+	case CommandTypeResolveIssue, CommandTypeArchiveIssue, CommandTypeMoveIssue, CommandTypeSilenceChannel:
+		return true
+	}
+	return false
+}
+
+// ValidCommandTypes returns a slice of valid CommandType values.
+func ValidCommandTypes() []string {
+	return []string{
+		string(CommandTypeResolveIssue),
+		string(CommandTypeArchiveIssue),
+		string(CommandTypeMoveIssue),
+		string(CommandTypeSilenceChannel),
+	}
+}
+
+// Command is a control message for the Slack Manager, sent by operational tooling through the same queues as alerts,
+// in envelopes of kind QueueEnvelopeKindCommand. Use EncodeCommand to create a message body, and QueueEnvelope.Command
+// to decode it.
+type Command interface {
+	// CommandType returns the type of the command.
+	CommandType() CommandType
+
+	// Meta returns the fields common to all commands.
+	Meta() *CommandMeta
+
+	// Clean normalizes the command fields.
+	Clean()
+
+	// Validate returns an error if the command is invalid. Call Clean first.
+	Validate() error
+}
+
+// CommandMeta holds the fields common to all commands.
+type CommandMeta struct {
+	// ID is an optional unique identifier of the command, used as the idempotency key of its queue envelope,
+	// so that retried commands are not applied twice. Maximum length: MaxCommandIDLength characters.
+	ID string `json:"id"`
+
+	// Actor is the name of the user or system issuing the command, for the audit trail.
+	// This field is required. It is automatically truncated at MaxCommandActorLength characters.
+	Actor string `json:"actor"`
+
+	// Reason is an optional description of why the command was issued.
+	// It is automatically truncated at MaxCommandReasonLength characters.
+	Reason string `json:"reason"`
+
+	// IssuedAt is the time the command was issued. Defaults to the current time.
+	IssuedAt time.Time `json:"issuedAt"`
+}
+
+// Meta returns the command meta fields.
+func (m *CommandMeta) Meta() *CommandMeta {
+	return m
+}
+
+func (m *CommandMeta) clean() {
+	m.ID = strings.TrimSpace(m.ID)
+	m.Actor = strings.ReplaceAll(strings.TrimSpace(m.Actor), "\n", " ")
+	m.Reason = strings.TrimSpace(m.Reason)
+
+	if m.IssuedAt.IsZero() {
+		m.IssuedAt = time.Now().UTC()
+	}
+
+	if utf8.RuneCountInString(m.Actor) > MaxCommandActorLength {
+		m.Actor = strings.TrimSpace(truncateString(m.Actor, MaxCommandActorLength-3)) + "..."
+	}
+
+	if utf8.RuneCountInString(m.Reason) > MaxCommandReasonLength {
+		m.Reason = strings.TrimSpace(truncateString(m.Reason, MaxCommandReasonLength-3)) + "..."
+	}
+}
+
+func (m *CommandMeta) validate() error {
+	if len(m.ID) > MaxCommandIDLength {
+		return fmt.Errorf("id is too long, expected length <=%d", MaxCommandIDLength)
+	}
+
+	if m.Actor == "" {
+		return errors.New("actor is required")
+	}
+
+	if utf8.RuneCountInString(m.Actor) > MaxCommandActorLength {
+		return fmt.Errorf("actor is too long, expected length <=%d", MaxCommandActorLength)
+	}
+
+	if utf8.RuneCountInString(m.Reason) > MaxCommandReasonLength {
+		return fmt.Errorf("reason is too long, expected length <=%d", MaxCommandReasonLength)
+	}
+
+	return nil
+}
+
+// validateCommandIssue validates the fields identifying the issue of a command, like ResolveRequest.Validate.
+func validateCommandIssue(correlationID, slackChannelID, routeKey string) error {
+	if correlationID == "" {
+		return errors.New("correlationId is required")
+	}
+
+	if len(correlationID) > MaxCorrelationIDLength {
+		return fmt.Errorf("correlationId is too long, expected length <=%d", MaxCorrelationIDLength)
+	}
+
+	if slackChannelID != "" {
+		if !SlackChannelIDOrNameRegex.MatchString(slackChannelID) {
+			return fmt.Errorf("slackChannelId '%s' is not valid", slackChannelID)
+		}
+	} else if len(routeKey) > MaxRouteKeyLength {
+		return fmt.Errorf("routeKey is too long, expected length <=%d", MaxRouteKeyLength)
+	}
+
+	return nil
+}
+
+// ResolveIssueCommand resolves an issue, like a ResolveRequest. The issue is found by correlation ID,
+// in the channel specified directly by SlackChannelID, or indirectly by RouteKey.
+type ResolveIssueCommand struct {
+	CommandMeta
+
+	// CorrelationID is the correlation ID of the issue. This field is required.
+	CorrelationID string `json:"correlationId"`
+
+	// SlackChannelID is the ID or name of the Slack channel of the issue. Takes precedence over RouteKey.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// RouteKey is used to find the Slack channel of the issue, via the routes configured in the Slack Manager.
+	RouteKey string `json:"routeKey"`
+
+	// Resolution is the outcome of the issue. If empty, the issue is resolved as ResolutionResolved.
+	Resolution Resolution `json:"resolution"`
+}
+
+// CommandType returns CommandTypeResolveIssue.
+func (c *ResolveIssueCommand) CommandType() CommandType {
+	return CommandTypeResolveIssue
+}
+
+// Clean normalizes the command fields.
+func (c *ResolveIssueCommand) Clean() {
+	if c == nil {
+		return
+	}
+
+	c.clean()
+	c.CorrelationID = strings.TrimSpace(c.CorrelationID)
+	c.SlackChannelID = NormalizeChannel(c.SlackChannelID)
+	c.RouteKey = strings.ToLower(strings.TrimSpace(c.RouteKey))
+	c.Resolution = Resolution(strings.ToLower(strings.TrimSpace(string(c.Resolution))))
+
+	if c.Resolution == "" {
+		c.Resolution = ResolutionResolved
+	}
+}
+
+// Validate returns an error if the command is invalid. Call Clean first.
+func (c *ResolveIssueCommand) Validate() error {
+	if c == nil {
+		return errors.New("resolve issue command is nil")
+	}
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	if err := validateCommandIssue(c.CorrelationID, c.SlackChannelID, c.RouteKey); err != nil {
+		return err
+	}
+
+	if !ResolutionIsValid(c.Resolution) {
+		return fmt.Errorf("resolution '%s' is not valid, expected one of [%s]", c.Resolution, strings.Join(ValidResolutions(), ", "))
+	}
+
+	return nil
+}
+
+// ResolveRequest returns the command as a ResolveRequest, with the reason as note.
+func (c *ResolveIssueCommand) ResolveRequest() *ResolveRequest {
+	return &ResolveRequest{
+		CorrelationID:  c.CorrelationID,
+		SlackChannelID: c.SlackChannelID,
+		RouteKey:       c.RouteKey,
+		Resolution:     c.Resolution,
+		Note:           c.Reason,
+		Actor:          c.Actor,
+	}
+}
+
+// ArchiveIssueCommand archives an issue, removing it from the channel without resolving it, e.g. for issues
+// that are no longer relevant. The issue is found like for ResolveIssueCommand.
+type ArchiveIssueCommand struct {
+	CommandMeta
+
+	// CorrelationID is the correlation ID of the issue. This field is required.
+	CorrelationID string `json:"correlationId"`
+
+	// SlackChannelID is the ID or name of the Slack channel of the issue. Takes precedence over RouteKey.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// RouteKey is used to find the Slack channel of the issue, via the routes configured in the Slack Manager.
+	RouteKey string `json:"routeKey"`
+}
+
+// CommandType returns CommandTypeArchiveIssue.
+func (c *ArchiveIssueCommand) CommandType() CommandType {
+	return CommandTypeArchiveIssue
+}
+
+// Clean normalizes the command fields.
+func (c *ArchiveIssueCommand) Clean() {
+	if c == nil {
+		return
+	}
+
+	c.clean()
+	c.CorrelationID = strings.TrimSpace(c.CorrelationID)
+	c.SlackChannelID = NormalizeChannel(c.SlackChannelID)
+	c.RouteKey = strings.ToLower(strings.TrimSpace(c.RouteKey))
+}
+
+// Validate returns an error if the command is invalid. Call Clean first.
+func (c *ArchiveIssueCommand) Validate() error {
+	if c == nil {
+		return errors.New("archive issue command is nil")
+	}
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	return validateCommandIssue(c.CorrelationID, c.SlackChannelID, c.RouteKey)
+}
+
+// MoveIssueCommand moves an issue from one channel to another. New alerts with the same correlation ID
+// are then processed in the target channel (see MoveMapping).
+type MoveIssueCommand struct {
+	CommandMeta
+
+	// CorrelationID is the correlation ID of the issue. This field is required.
+	CorrelationID string `json:"correlationId"`
+
+	// SlackChannelID is the ID or name of the current Slack channel of the issue. This field is required.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// TargetChannelID is the ID or name of the Slack channel to move the issue to. This field is required,
+	// and must differ from SlackChannelID.
+	TargetChannelID string `json:"targetChannelId"`
+}
+
+// CommandType returns CommandTypeMoveIssue.
+func (c *MoveIssueCommand) CommandType() CommandType {
+	return CommandTypeMoveIssue
+}
+
+// Clean normalizes the command fields.
+func (c *MoveIssueCommand) Clean() {
+	if c == nil {
+		return
+	}
+
+	c.clean()
+	c.CorrelationID = strings.TrimSpace(c.CorrelationID)
+	c.SlackChannelID = NormalizeChannel(c.SlackChannelID)
+	c.TargetChannelID = NormalizeChannel(c.TargetChannelID)
+}
+
+// Validate returns an error if the command is invalid. Call Clean first.
+func (c *MoveIssueCommand) Validate() error {
+	if c == nil {
+		return errors.New("move issue command is nil")
+	}
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	if c.SlackChannelID == "" {
+		return errors.New("slackChannelId is required")
+	}
+
+	if err := validateCommandIssue(c.CorrelationID, c.SlackChannelID, ""); err != nil {
+		return err
+	}
+
+	if c.TargetChannelID == "" {
+		return errors.New("targetChannelId is required")
+	}
+
+	if !SlackChannelIDOrNameRegex.MatchString(c.TargetChannelID) {
+		return fmt.Errorf("targetChannelId '%s' is not valid", c.TargetChannelID)
+	}
+
+	if c.TargetChannelID == c.SlackChannelID {
+		return errors.New("targetChannelId must differ from slackChannelId")
+	}
+
+	return nil
+}
+
+// SilenceChannelCommand silences the alerts of a channel between StartsAt and EndsAt, optionally restricted
+// by additional matchers. It is applied as the Silence returned by the Silence method.
+type SilenceChannelCommand struct {
+	CommandMeta
+
+	// SlackChannelID is the ID or name of the Slack channel to silence. This field is required.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// Matchers are optional additional conditions, restricting the silence to matching alerts in the channel.
+	Matchers []*SilenceMatcher `json:"matchers"`
+
+	// StartsAt is the start of the silence. Defaults to the current time.
+	StartsAt time.Time `json:"startsAt"`
+
+	// EndsAt is the expiration of the silence, exclusive. Required, and at most MaxSilenceDuration after StartsAt.
+	EndsAt time.Time `json:"endsAt"`
+}
+
+// CommandType returns CommandTypeSilenceChannel.
+func (c *SilenceChannelCommand) CommandType() CommandType {
+	return CommandTypeSilenceChannel
+}
+
+// Clean normalizes the command fields, including the matchers.
+func (c *SilenceChannelCommand) Clean() {
+	if c == nil {
+		return
+	}
+
+	c.clean()
+	c.SlackChannelID = NormalizeChannel(c.SlackChannelID)
+
+	if c.StartsAt.IsZero() {
+		c.StartsAt = c.IssuedAt
+	}
+
+	(&Silence{Matchers: c.Matchers}).Clean()
+}
+
+// Validate returns an error if the command is invalid. Call Clean first.
+func (c *SilenceChannelCommand) Validate() error {
+	if c == nil {
+		return errors.New("silence channel command is nil")
+	}
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	if c.SlackChannelID == "" {
+		return errors.New("slackChannelId is required")
+	}
+
+	if !SlackChannelIDOrNameRegex.MatchString(c.SlackChannelID) {
+		return fmt.Errorf("slackChannelId '%s' is not valid", c.SlackChannelID)
+	}
+
+	if len(c.Matchers) >= MaxSilenceMatcherCount {
+		return fmt.Errorf("too many matchers, expected <=%d", MaxSilenceMatcherCount-1)
+	}
+
+	return c.Silence().Validate()
+}
+
+// Silence returns the silence applying the command: a Silence with the command ID, a matcher on the channel followed
+// by the command matchers, the command period, the reason as comment, and the actor as creator.
+func (c *SilenceChannelCommand) Silence() *Silence {
+	matchers := make([]*SilenceMatcher, 0, len(c.Matchers)+1)
+	matchers = append(matchers, &SilenceMatcher{Field: "slackChannelId", Operator: SilenceMatchEqual, Value: c.SlackChannelID})
+	matchers = append(matchers, c.Matchers...)
+
+	return &Silence{
+		ID:        c.ID,
+		Matchers:  matchers,
+		StartsAt:  c.StartsAt,
+		EndsAt:    c.EndsAt,
+		Comment:   c.Reason,
+		CreatedBy: c.Actor,
+	}
+}
+
+// commandPayload is the payload of command envelopes: the command type, and the command.
+type commandPayload struct {
+	Type    CommandType     `json:"type"`
+	Command json.RawMessage `json:"command"`
+}
+
+// EncodeCommand cleans and validates the command, and encodes it in a queue envelope of kind QueueEnvelopeKindCommand,
+// for use as queue message body. The idempotency key defaults to the command ID.
+func EncodeCommand(cmd Command, opts QueueEnvelopeOptions) (string, error) {
+	if cmd == nil {
+		return "", errors.New("command is nil")
+	}
+
+	cmd.Clean()
+
+	if err := cmd.Validate(); err != nil {
+		return "", fmt.Errorf("invalid %s command: %w", cmd.CommandType(), err)
+	}
+
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s command: %w", cmd.CommandType(), err)
+	}
+
+	if opts.IdempotencyKey == "" {
+		opts.IdempotencyKey = cmd.Meta().ID
+	}
+
+	return EncodeQueueEnvelopeWithOptions(QueueEnvelopeKindCommand, &commandPayload{Type: cmd.CommandType(), Command: data}, opts)
+}
+
+// Command decodes the payload as a Command: a *ResolveIssueCommand, *ArchiveIssueCommand, *MoveIssueCommand or
+// *SilenceChannelCommand, which consumers select with a type switch. An error is returned if the kind is not
+// QueueEnvelopeKindCommand, or if the command type is unknown. The command is not cleaned or validated.
+func (e *QueueEnvelope) Command() (Command, error) { //nolint:ireturn
+	if e.Kind != QueueEnvelopeKindCommand {
+		return nil, fmt.Errorf("envelope kind is '%s', expected '%s'", e.Kind, QueueEnvelopeKindCommand)
+	}
+
+	var payload commandPayload
+
+	if err := e.Unmarshal(&payload); err != nil {
+		return nil, err
+	}
+
+	var cmd Command
+
+	switch payload.Type {
+	case CommandTypeResolveIssue:
+		cmd = &ResolveIssueCommand{}
+	case CommandTypeArchiveIssue:
+		cmd = &ArchiveIssueCommand{}
+	case CommandTypeMoveIssue:
+		cmd = &MoveIssueCommand{}
+	case CommandTypeSilenceChannel:
+		cmd = &SilenceChannelCommand{}
+	default:
+		return nil, fmt.Errorf("command type '%s' is not valid, expected one of [%s]", payload.Type, strings.Join(ValidCommandTypes(), ", "))
+	}
+
+	if err := json.Unmarshal(payload.Command, cmd); err != nil {
+		return nil, fmt.Errorf("failed to decode %s command: %w", payload.Type, err)
+	}
+
+	return cmd, nil
+}
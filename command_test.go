@@ -0,0 +1,156 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandClean(t *testing.T) {
+	t.Parallel()
+
+	cmd := &types.ResolveIssueCommand{
+		CommandMeta:    types.CommandMeta{ID: " cmd-1 ", Actor: " ops\ncli ", Reason: strings.Repeat("x", types.MaxCommandReasonLength+10)},
+		CorrelationID:  " abc ",
+		SlackChannelID: " c12345678 ",
+		RouteKey:       " Payments ",
+	}
+	cmd.Clean()
+
+	assert.Equal(t, "cmd-1", cmd.ID)
+	assert.Equal(t, "ops cli", cmd.Actor)
+	assert.Len(t, cmd.Reason, types.MaxCommandReasonLength)
+	assert.False(t, cmd.IssuedAt.IsZero())
+	assert.Equal(t, "abc", cmd.CorrelationID)
+	assert.Equal(t, "C12345678", cmd.SlackChannelID)
+	assert.Equal(t, "payments", cmd.RouteKey)
+	assert.Equal(t, types.ResolutionResolved, cmd.Resolution)
+	require.NoError(t, cmd.Validate())
+
+	req := cmd.ResolveRequest()
+	assert.Equal(t, "abc", req.CorrelationID)
+	assert.Equal(t, cmd.Reason, req.Note)
+	assert.Equal(t, "ops cli", req.Actor)
+
+	var nilCmd *types.MoveIssueCommand
+	nilCmd.Clean()
+	require.EqualError(t, nilCmd.Validate(), "move issue command is nil")
+}
+
+func TestCommandValidate(t *testing.T) {
+	t.Parallel()
+
+	meta := types.CommandMeta{Actor: "ops-cli"}
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		cmd  types.Command
+		err  string
+	}{
+		{"valid resolve", &types.ResolveIssueCommand{CommandMeta: meta, CorrelationID: "abc", RouteKey: "payments"}, ""},
+		{"missing actor", &types.ResolveIssueCommand{CorrelationID: "abc"}, "actor is required"},
+		{"too long id", &types.ArchiveIssueCommand{CommandMeta: types.CommandMeta{ID: strings.Repeat("x", types.MaxCommandIDLength+1), Actor: "a"}, CorrelationID: "abc"}, "id is too long"},
+		{"missing correlation id", &types.ArchiveIssueCommand{CommandMeta: meta}, "correlationId is required"},
+		{"invalid channel", &types.ArchiveIssueCommand{CommandMeta: meta, CorrelationID: "abc", SlackChannelID: "not a channel"}, "slackChannelId 'not a channel' is not valid"},
+		{"invalid resolution", &types.ResolveIssueCommand{CommandMeta: meta, CorrelationID: "abc", Resolution: "fixed"}, "resolution 'fixed' is not valid"},
+		{"valid move", &types.MoveIssueCommand{CommandMeta: meta, CorrelationID: "abc", SlackChannelID: "C12345678", TargetChannelID: "C87654321"}, ""},
+		{"move without channel", &types.MoveIssueCommand{CommandMeta: meta, CorrelationID: "abc", TargetChannelID: "C87654321"}, "slackChannelId is required"},
+		{"move without target", &types.MoveIssueCommand{CommandMeta: meta, CorrelationID: "abc", SlackChannelID: "C12345678"}, "targetChannelId is required"},
+		{"move to same channel", &types.MoveIssueCommand{CommandMeta: meta, CorrelationID: "abc", SlackChannelID: "C12345678", TargetChannelID: "c12345678"}, "targetChannelId must differ from slackChannelId"},
+		{"valid silence", &types.SilenceChannelCommand{CommandMeta: meta, SlackChannelID: "C12345678", EndsAt: now.Add(time.Hour)}, ""},
+		{"silence without channel", &types.SilenceChannelCommand{CommandMeta: meta, EndsAt: now.Add(time.Hour)}, "slackChannelId is required"},
+		{"silence without end", &types.SilenceChannelCommand{CommandMeta: meta, SlackChannelID: "C12345678"}, "startsAt and endsAt are required"},
+		{"silence with invalid matcher", &types.SilenceChannelCommand{
+			CommandMeta:    meta,
+			SlackChannelID: "C12345678",
+			Matchers:       []*types.SilenceMatcher{{Field: "unknown", Value: "x"}},
+			EndsAt:         now.Add(time.Hour),
+		}, "matchers[1].field 'unknown' is not valid"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			test.cmd.Clean()
+			err := test.cmd.Validate()
+
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestSilenceChannelCommandSilence(t *testing.T) {
+	t.Parallel()
+
+	cmd := &types.SilenceChannelCommand{
+		CommandMeta:    types.CommandMeta{ID: "cmd-1", Actor: "ops-cli", Reason: "Maintenance"},
+		SlackChannelID: "C12345678",
+		Matchers:       []*types.SilenceMatcher{{Field: "severity", Value: "warning"}},
+		EndsAt:         time.Now().Add(time.Hour),
+	}
+	cmd.Clean()
+	require.NoError(t, cmd.Validate())
+
+	silence := cmd.Silence()
+	assert.Equal(t, "cmd-1", silence.ID)
+	assert.Equal(t, "Maintenance", silence.Comment)
+	assert.Equal(t, "ops-cli", silence.CreatedBy)
+	assert.Equal(t, cmd.IssuedAt, silence.StartsAt)
+	require.Len(t, silence.Matchers, 2)
+
+	assert.True(t, silence.Evaluate(&types.Alert{SlackChannelID: "C12345678", Severity: types.AlertWarning}))
+	assert.False(t, silence.Evaluate(&types.Alert{SlackChannelID: "C12345678", Severity: types.AlertError}))
+	assert.False(t, silence.Evaluate(&types.Alert{SlackChannelID: "C87654321", Severity: types.AlertWarning}))
+}
+
+func TestCommandEnvelope(t *testing.T) {
+	t.Parallel()
+
+	body, err := types.EncodeCommand(&types.MoveIssueCommand{
+		CommandMeta:     types.CommandMeta{ID: "cmd-1", Actor: "ops-cli"},
+		CorrelationID:   "abc",
+		SlackChannelID:  "c12345678",
+		TargetChannelID: "C87654321",
+	}, types.QueueEnvelopeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "cmd-1", types.QueueEnvelopeIdempotencyKey(body))
+
+	envelope, err := types.DecodeQueueEnvelope(body)
+	require.NoError(t, err)
+	assert.Equal(t, types.QueueEnvelopeKindCommand, envelope.Kind)
+
+	cmd, err := envelope.Command()
+	require.NoError(t, err)
+
+	move, ok := cmd.(*types.MoveIssueCommand)
+	require.True(t, ok)
+	assert.Equal(t, types.CommandTypeMoveIssue, move.CommandType())
+	assert.Equal(t, "C12345678", move.SlackChannelID)
+	assert.Equal(t, "ops-cli", move.Actor)
+	assert.False(t, move.IssuedAt.IsZero())
+
+	_, err = envelope.Alert()
+	require.EqualError(t, err, "envelope kind is 'command', expected 'alert'")
+
+	_, err = types.EncodeCommand(&types.MoveIssueCommand{CommandMeta: types.CommandMeta{Actor: "ops-cli"}}, types.QueueEnvelopeOptions{})
+	require.EqualError(t, err, "invalid move_issue command: slackChannelId is required")
+
+	unknown, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindCommand, map[string]any{"type": "restart", "command": map[string]any{}})
+	require.NoError(t, err)
+
+	envelope, err = types.DecodeQueueEnvelope(unknown)
+	require.NoError(t, err)
+
+	_, err = envelope.Command()
+	require.EqualError(t, err, "command type 'restart' is not valid, expected one of [resolve_issue, archive_issue, move_issue, silence_channel]")
+}
@@ -0,0 +1,39 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// AlertHandler processes an alert decoded from a message. The message is acked if the handler returns nil,
+// and nacked otherwise.
+type AlertHandler func(ctx context.Context, msg *Message, alert *types.Alert) error
+
+// HandleAlerts returns a Handler decoding the alert of each message (see Message.Alert), and applying its expiration
+// at the receive time of the message (see types.Alert.ApplyExpiration), before calling the handler.
+//
+// Expired alerts to be dropped are acked without calling the handler, and logged with the handler context logger.
+// Messages that cannot be decoded are nacked, with an error.
+func HandleAlerts(handler AlertHandler) Handler {
+	return func(ctx context.Context, msg *Message) error {
+		alert, err := msg.Alert()
+		if err != nil {
+			return fmt.Errorf("failed to decode alert of message %s: %w", msg.ID, err)
+		}
+
+		receivedAt := msg.ReceiveTimestamp
+		if receivedAt.IsZero() {
+			receivedAt = time.Now()
+		}
+
+		if alert.ApplyExpiration(receivedAt) {
+			types.LoggerFromContext(ctx).WithFields(alert.LogFields()).Infof("Dropped alert expired at %s", alert.ExpiresAt().Format(time.RFC3339))
+			return nil
+		}
+
+		return handler(ctx, msg, alert)
+	}
+}
@@ -0,0 +1,78 @@
+package consumer_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/consumer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleAlerts(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+
+	alert := types.NewErrorAlert()
+	alert.SlackChannelID = "C1"
+	alert.Header = "Disk full"
+	alert.Timestamp = now
+	alert.ExpiresAfterSeconds = 60
+
+	envelope, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindAlert, alert)
+	require.NoError(t, err)
+
+	plain, err := json.Marshal(alert)
+	require.NoError(t, err)
+
+	var handled []*types.Alert
+
+	handler := consumer.HandleAlerts(func(_ context.Context, _ *consumer.Message, a *types.Alert) error {
+		handled = append(handled, a)
+		return nil
+	})
+
+	logger := types.NewTestLogger()
+	ctx := types.ContextWithLogger(context.Background(), logger)
+
+	require.NoError(t, handler(ctx, &consumer.Message{ID: "1", Body: envelope, ReceiveTimestamp: now.Add(time.Second)}))
+	require.NoError(t, handler(ctx, &consumer.Message{ID: "2", Body: string(plain), ReceiveTimestamp: now.Add(time.Second)}))
+	require.Len(t, handled, 2)
+	assert.Equal(t, "Disk full", handled[0].Header)
+	assert.Equal(t, "Disk full", handled[1].Header)
+
+	require.NoError(t, handler(ctx, &consumer.Message{ID: "3", Body: envelope, ReceiveTimestamp: now.Add(time.Hour)}))
+	assert.Len(t, handled, 2, "expired alerts should not be handled")
+	assert.True(t, logger.Contains("Dropped alert expired at"))
+
+	require.ErrorContains(t, handler(ctx, &consumer.Message{ID: "4", Body: "not json"}), "failed to decode alert of message 4")
+
+	assert.Equal(t, alert.UniqueID(), (&consumer.Message{Body: envelope}).IdempotencyKey())
+	assert.Empty(t, (&consumer.Message{Body: string(plain)}).IdempotencyKey())
+}
+
+func TestMessageCommand(t *testing.T) {
+	t.Parallel()
+
+	body, err := types.EncodeCommand(&types.ArchiveIssueCommand{
+		CommandMeta:   types.CommandMeta{ID: "cmd-1", Actor: "ops-cli"},
+		CorrelationID: "abc",
+		RouteKey:      "payments",
+	}, types.QueueEnvelopeOptions{})
+	require.NoError(t, err)
+
+	msg := &consumer.Message{Body: body}
+
+	cmd, err := msg.Command()
+	require.NoError(t, err)
+	require.IsType(t, &types.ArchiveIssueCommand{}, cmd)
+	assert.Equal(t, "abc", cmd.(*types.ArchiveIssueCommand).CorrelationID)
+	assert.Equal(t, "cmd-1", msg.IdempotencyKey())
+
+	_, err = (&consumer.Message{Body: `{"actor": "ops-cli"}`}).Command()
+	require.ErrorIs(t, err, types.ErrNotQueueEnvelope)
+}
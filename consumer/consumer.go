@@ -0,0 +1,491 @@
+// Package consumer provides a Runner that receives items from a queue, and processes them with a bounded
+// pool of workers, so that services do not have to re-implement the receive loop.
+//
+// The runner acknowledges items automatically around the handler: an item is acked when the handler returns nil,
+// and nacked (with a delay, if Config.RetryDelay is set and supported by the queue) when it returns an error or panics.
+// While the handler runs, the visibility timeout of the item is extended periodically, if supported by the queue:
+//
+//	runner, err := consumer.NewFifoQueueRunner(queue, func(ctx context.Context, msg *consumer.Message) error {
+//	    return processAlert(ctx, msg.Body)
+//	}, consumer.Config{Workers: 20, Logger: logger, Metrics: metrics})
+//
+//	err = runner.Run(ctx)
+//
+// Items with the same Message.GroupID (i.e. the same Slack channel) are processed serially, in the order received,
+// so that updates to the same Slack post never race. Items of different groups are processed in parallel.
+// Set Config.DisableOrdering to process all items in parallel.
+//
+// Run stops receiving when the context is canceled, waits for the running handlers to complete, and returns.
+// Handlers are called with a context that is not canceled with the Run context, so that in-flight items
+// can complete during a graceful shutdown. Use Config.HandlerTimeout to bound the handler duration.
+// The handler context holds a logger with the queue name, message ID and channel ID fields,
+// returned by types.LoggerFromContext.
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	// DefaultWorkers is the number of concurrent handlers, when Config.Workers is zero.
+	DefaultWorkers = 10
+
+	// DefaultBatchSize is the max number of items received per ReceiveBatch call, when Config.BatchSize is zero.
+	DefaultBatchSize = 10
+
+	// DefaultVisibilityExtension is the visibility timeout set by each extension, when Config.VisibilityExtension is zero.
+	// Items are extended every half of this duration while the handler runs.
+	DefaultVisibilityExtension = time.Minute
+
+	// DefaultReceiveErrorDelay is the delay before receiving again after a failed ReceiveBatch call,
+	// when Config.ReceiveErrorDelay is zero.
+	DefaultReceiveErrorDelay = time.Second
+
+	// MessagesMetric is the name of the counter incremented for each processed item.
+	// The counter has two labels: 'queue', with the runner name, and 'result', with 'ack' or 'nack'.
+	MessagesMetric = "consumer_messages_total"
+
+	// HandlerDurationMetric is the name of the histogram observing the handler duration, in seconds.
+	// The histogram has a single label, 'queue', with the runner name.
+	HandlerDurationMetric = "consumer_handler_duration_seconds"
+
+	// BusyWorkersMetric is the name of the gauge with the number of running handlers.
+	// The gauge has a single label, 'queue', with the runner name.
+	BusyWorkersMetric = "consumer_busy_workers"
+
+	// ReceiveErrorsMetric is the name of the counter incremented for each failed ReceiveBatch call.
+	// The counter has a single label, 'queue', with the runner name.
+	ReceiveErrorsMetric = "consumer_receive_errors_total"
+
+	// GroupQueueDepthMetric is the name of the gauge with the number of items held for a group, including the running item.
+	// The gauge has two labels: 'queue', with the runner name, and 'group', with the group ID.
+	// It is only updated for items with a group ID, when ordering is enabled.
+	GroupQueueDepthMetric = "consumer_group_queue_depth"
+)
+
+// Handler processes a message. The message is acked if the handler returns nil, and nacked otherwise.
+type Handler func(ctx context.Context, msg *Message) error
+
+// Config holds the configuration of a Runner.
+type Config struct {
+	// Name is the runner name, used as 'queue' label of the metrics and in log messages. Defaults to the queue name.
+	Name string
+
+	// Workers is the max number of concurrent handlers. Defaults to DefaultWorkers.
+	Workers int
+
+	// BatchSize is the max number of items received per ReceiveBatch call. Defaults to DefaultBatchSize.
+	BatchSize int
+
+	// HandlerTimeout is the max duration of a handler call, after which its context is canceled.
+	// Defaults to no timeout.
+	HandlerTimeout time.Duration
+
+	// VisibilityExtension is the visibility timeout set each time an item is extended, while the handler runs.
+	// Defaults to DefaultVisibilityExtension. Set it to a negative value to disable extension.
+	VisibilityExtension time.Duration
+
+	// RetryDelay returns the redelivery delay of failed items, based on the receive count.
+	// It is used if the queue supports NackWithDelay. Defaults to immediate redelivery.
+	RetryDelay func(receiveCount int) time.Duration
+
+	// ReceiveErrorDelay is the delay before receiving again after a failed ReceiveBatch call.
+	// Defaults to DefaultReceiveErrorDelay.
+	ReceiveErrorDelay time.Duration
+
+	// DisableOrdering disables serial processing of items with the same group ID.
+	// By default, at most one item per group is processed at a time, in the order received.
+	DisableOrdering bool
+
+	// Logger receives handler errors and receive errors. Optional.
+	Logger types.Logger
+
+	// Metrics receives the consumer metrics. Optional.
+	Metrics types.Metrics
+
+	// Tracer creates a span per processed item, as a child of the producer span if the item body is a
+	// types.QueueEnvelope with a trace context. The handler context holds the span. Optional.
+	Tracer types.Tracer
+}
+
+// Runner receives items from a queue, and processes them with a bounded pool of workers.
+type Runner struct {
+	receive func(ctx context.Context, maxItems int) ([]*Message, error)
+	handler Handler
+	cfg     Config
+
+	leaseOpts types.LeaseKeeperOptions
+
+	busy sync.WaitGroup
+
+	mu          sync.Mutex
+	busyWorkers int
+	groups      map[string][]*Message
+}
+
+// NewQueueRunner creates a new Runner processing items from an unordered queue.
+// An error is returned if the configuration is invalid.
+func NewQueueRunner(queue types.Queue, handler Handler, cfg Config) (*Runner, error) {
+	if queue == nil {
+		return nil, errors.New("queue cannot be nil")
+	}
+
+	receive := func(ctx context.Context, maxItems int) ([]*Message, error) {
+		items, err := queue.ReceiveBatch(ctx, maxItems)
+		if err != nil {
+			return nil, err
+		}
+
+		messages := make([]*Message, len(items))
+		for i, item := range items {
+			messages[i] = queueMessage(item)
+		}
+
+		return messages, nil
+	}
+
+	return newRunner(queue.Name(), receive, handler, cfg)
+}
+
+// NewFifoQueueRunner creates a new Runner processing items from a FIFO queue.
+// An error is returned if the configuration is invalid.
+func NewFifoQueueRunner(queue types.FifoQueue, handler Handler, cfg Config) (*Runner, error) {
+	if queue == nil {
+		return nil, errors.New("queue cannot be nil")
+	}
+
+	receive := func(ctx context.Context, maxItems int) ([]*Message, error) {
+		items, err := queue.ReceiveBatch(ctx, maxItems)
+		if err != nil {
+			return nil, err
+		}
+
+		messages := make([]*Message, len(items))
+		for i, item := range items {
+			messages[i] = fifoMessage(item)
+		}
+
+		return messages, nil
+	}
+
+	return newRunner(queue.Name(), receive, handler, cfg)
+}
+
+func newRunner(name string, receive func(context.Context, int) ([]*Message, error), handler Handler, cfg Config) (*Runner, error) {
+	if handler == nil {
+		return nil, errors.New("handler cannot be nil")
+	}
+
+	if cfg.Workers < 0 {
+		return nil, errors.New("workers cannot be negative")
+	}
+
+	if cfg.BatchSize < 0 {
+		return nil, errors.New("batch size cannot be negative")
+	}
+
+	if cfg.HandlerTimeout < 0 {
+		return nil, errors.New("handler timeout cannot be negative")
+	}
+
+	if cfg.Name == "" {
+		cfg.Name = name
+	}
+
+	if cfg.Workers == 0 {
+		cfg.Workers = DefaultWorkers
+	}
+
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+
+	if cfg.VisibilityExtension == 0 {
+		cfg.VisibilityExtension = DefaultVisibilityExtension
+	}
+
+	if cfg.ReceiveErrorDelay <= 0 {
+		cfg.ReceiveErrorDelay = DefaultReceiveErrorDelay
+	}
+
+	if cfg.Logger == nil {
+		cfg.Logger = &types.NoopLogger{}
+	}
+
+	if cfg.Metrics == nil {
+		cfg.Metrics = &types.NoopMetrics{}
+	}
+
+	cfg.Logger = cfg.Logger.WithField(types.LogFieldQueue, cfg.Name)
+
+	var leaseOpts types.LeaseKeeperOptions
+
+	if cfg.VisibilityExtension > 0 {
+		leaseOpts.Timeout = cfg.VisibilityExtension
+
+		if _, err := types.NewLeaseKeeper(nil, leaseOpts); err != nil {
+			return nil, fmt.Errorf("visibility extension is not valid: %w", err)
+		}
+	}
+
+	cfg.Metrics.RegisterCounter(MessagesMetric, "Number of queue items processed by the consumer", "queue", "result")
+	cfg.Metrics.RegisterHistogram(HandlerDurationMetric, "Duration of consumer handler calls, in seconds", nil, "queue")
+	cfg.Metrics.RegisterGauge(BusyWorkersMetric, "Number of running consumer handlers", "queue")
+	cfg.Metrics.RegisterCounter(ReceiveErrorsMetric, "Number of failed consumer receive calls", "queue")
+	cfg.Metrics.RegisterGauge(GroupQueueDepthMetric, "Number of queue items held per consumer group", "queue", "group")
+
+	return &Runner{
+		receive:   receive,
+		handler:   handler,
+		cfg:       cfg,
+		leaseOpts: leaseOpts,
+		groups:    make(map[string][]*Message),
+	}, nil
+}
+
+// Run receives and processes items until the context is canceled. It then waits for the running handlers
+// to complete, and returns nil. Failed receive calls are logged and retried after Config.ReceiveErrorDelay.
+func (r *Runner) Run(ctx context.Context) error {
+	workers := make(chan struct{}, r.cfg.Workers)
+	release := func() { <-workers }
+
+	defer r.busy.Wait()
+
+	for {
+		// Wait for a free worker before receiving, so that items are not held while all workers are busy.
+		select {
+		case <-ctx.Done():
+			return nil
+		case workers <- struct{}{}:
+		}
+
+		free := r.cfg.Workers - len(workers) + 1
+
+		messages, err := r.receive(ctx, min(r.cfg.BatchSize, free))
+		if err != nil {
+			<-workers
+
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			r.cfg.Metrics.Inc(ReceiveErrorsMetric, r.cfg.Name)
+			r.cfg.Logger.Errorf("Failed to receive from queue: %s", err)
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(r.cfg.ReceiveErrorDelay):
+			}
+
+			continue
+		}
+
+		for i, msg := range messages {
+			// The first worker was acquired before receiving.
+			// Items waiting for an earlier item of the same group also hold a worker, which bounds the held items.
+			if i > 0 {
+				select {
+				case <-ctx.Done():
+					r.nackAll(messages[i:])
+					return nil
+				case workers <- struct{}{}:
+				}
+			}
+
+			r.dispatch(context.WithoutCancel(ctx), msg, release)
+		}
+
+		if len(messages) == 0 {
+			<-workers
+		}
+	}
+}
+
+// dispatch processes the item in a new goroutine, and calls release when it completes.
+// With ordering enabled, an item of a group with an item in progress is queued instead,
+// and processed by the goroutine of that group when the earlier items complete.
+// The lease of a queued item is extended while it waits, so that it is not redelivered before its turn.
+func (r *Runner) dispatch(ctx context.Context, msg *Message, release func()) {
+	if !r.cfg.DisableOrdering && msg.GroupID != "" {
+		r.mu.Lock()
+		held := append(r.groups[msg.GroupID], msg)
+		r.groups[msg.GroupID] = held
+		r.cfg.Metrics.Set(GroupQueueDepthMetric, float64(len(held)), r.cfg.Name, msg.GroupID)
+
+		if len(held) > 1 {
+			msgCtx := r.messageContext(ctx, msg)
+			msg.heldLease = r.keepLease(msgCtx, msg, types.LoggerFromContext(msgCtx))
+		}
+
+		r.mu.Unlock()
+
+		if len(held) > 1 {
+			return
+		}
+	}
+
+	r.busy.Add(1)
+
+	go func() {
+		defer r.busy.Done()
+
+		for next := msg; next != nil; next = r.nextInGroup(next) {
+			r.setBusy(1)
+			r.process(ctx, next)
+			r.setBusy(-1)
+			release()
+		}
+	}()
+}
+
+// nextInGroup removes the completed item from its group, and returns the next held item of the group, if any.
+// The lease keeper of the returned item is stopped, since process extends the item from then on.
+func (r *Runner) nextInGroup(completed *Message) *Message {
+	if r.cfg.DisableOrdering || completed.GroupID == "" {
+		return nil
+	}
+
+	r.mu.Lock()
+
+	held := r.groups[completed.GroupID][1:]
+	r.cfg.Metrics.Set(GroupQueueDepthMetric, float64(len(held)), r.cfg.Name, completed.GroupID)
+
+	if len(held) == 0 {
+		delete(r.groups, completed.GroupID)
+		r.mu.Unlock()
+
+		return nil
+	}
+
+	r.groups[completed.GroupID] = held
+	next := held[0]
+	lease := next.heldLease
+	next.heldLease = nil
+
+	r.mu.Unlock()
+
+	if lease != nil {
+		_ = lease.Stop()
+	}
+
+	return next
+}
+
+func (r *Runner) setBusy(delta int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.busyWorkers += delta
+	r.cfg.Metrics.Set(BusyWorkersMetric, float64(r.busyWorkers), r.cfg.Name)
+}
+
+// process calls the handler, extending the item while the handler runs, and acks or nacks the item.
+func (r *Runner) process(ctx context.Context, msg *Message) {
+	ctx = r.messageContext(ctx, msg)
+	logger := types.LoggerFromContext(ctx)
+
+	ctx, endSpan := r.startSpan(ctx, msg)
+
+	lease := r.keepLease(ctx, msg, logger)
+
+	started := time.Now()
+	err := r.handle(ctx, msg)
+	endSpan(err)
+
+	_ = lease.Stop()
+	r.cfg.Metrics.Observe(HandlerDurationMetric, time.Since(started).Seconds(), r.cfg.Name)
+
+	if err == nil {
+		msg.ack()
+		r.cfg.Metrics.Inc(MessagesMetric, r.cfg.Name, "ack")
+
+		return
+	}
+
+	logger.Errorf("Failed to process message: %s", err)
+	r.cfg.Metrics.Inc(MessagesMetric, r.cfg.Name, "nack")
+
+	if r.cfg.RetryDelay != nil && msg.nackWithDelay != nil {
+		msg.nackWithDelay(r.cfg.RetryDelay(msg.ReceiveCount))
+	} else {
+		msg.nack()
+	}
+}
+
+// messageContext returns a context holding a logger with the queue name, message ID and channel ID fields.
+func (r *Runner) messageContext(ctx context.Context, msg *Message) context.Context {
+	ctx = types.ContextWithLogger(ctx, r.cfg.Logger)
+	return types.ContextWithLogFields(ctx, map[string]any{types.LogFieldMessageID: msg.ID, types.LogFieldChannelID: msg.GroupID})
+}
+
+// startSpan starts the span of the item, as a child of the producer span propagated in the item body, if any.
+func (r *Runner) startSpan(ctx context.Context, msg *Message) (context.Context, func(err error)) {
+	if r.cfg.Tracer == nil {
+		return ctx, func(error) {}
+	}
+
+	if carrier := types.QueueEnvelopeTraceContext(msg.Body); carrier != nil {
+		ctx = r.cfg.Tracer.Extract(ctx, carrier)
+	}
+
+	attrs := map[string]any{
+		types.TraceAttrQueue:     r.cfg.Name,
+		types.TraceAttrMessageID: msg.ID,
+	}
+
+	if msg.GroupID != "" {
+		attrs[types.TraceAttrChannelID] = msg.GroupID
+	}
+
+	return r.cfg.Tracer.StartSpan(ctx, "slackmgr.consumer.process", attrs)
+}
+
+// handle calls the handler with the handler timeout, converting panics to errors.
+func (r *Runner) handle(ctx context.Context, msg *Message) (err error) {
+	if r.cfg.HandlerTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.HandlerTimeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("handler panic: %v", p)
+		}
+	}()
+
+	return r.handler(ctx, msg)
+}
+
+// keepLease starts extending the item while the handler runs, or while it is held behind an earlier item of its group,
+// if supported by the queue and not disabled.
+// Failed extensions are logged by the lease keeper.
+func (r *Runner) keepLease(ctx context.Context, msg *Message, logger types.Logger) *types.LeaseKeeper {
+	extend := msg.extend
+	if r.cfg.VisibilityExtension < 0 {
+		extend = nil
+	}
+
+	opts := r.leaseOpts
+	opts.Logger = logger
+
+	// The options are validated by newRunner.
+	lease, _ := types.NewLeaseKeeper(extend, opts)
+	lease.Start(ctx)
+
+	return lease
+}
+
+func (r *Runner) nackAll(messages []*Message) {
+	for _, msg := range messages {
+		msg.nack()
+	}
+}
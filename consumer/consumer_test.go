@@ -0,0 +1,634 @@
+package consumer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/consumer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQueue is a types.FifoQueue recording acks, nacks and extensions of the received items.
+type fakeQueue struct {
+	items chan *types.FifoQueueItem
+
+	mu         sync.Mutex
+	acked      []string
+	nacked     []string
+	delays     map[string]time.Duration
+	extended   map[string]int
+	receiveErr error
+}
+
+func newFakeQueue(bufferSize int) *fakeQueue {
+	return &fakeQueue{
+		items:    make(chan *types.FifoQueueItem, bufferSize),
+		delays:   map[string]time.Duration{},
+		extended: map[string]int{},
+	}
+}
+
+func (q *fakeQueue) add(id, channelID, body string, receiveCount int) {
+	q.items <- &types.FifoQueueItem{
+		MessageID:      id,
+		SlackChannelID: channelID,
+		Body:           body,
+		ReceiveCount:   receiveCount,
+		Ack: func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.acked = append(q.acked, id)
+		},
+		Nack: func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.nacked = append(q.nacked, id)
+		},
+		NackWithDelay: func(delay time.Duration) {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.nacked = append(q.nacked, id)
+			q.delays[id] = delay
+		},
+		Extend: func(time.Duration) error {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.extended[id]++
+			return nil
+		},
+	}
+}
+
+func (q *fakeQueue) Name() string { return "fake" }
+
+func (q *fakeQueue) Send(context.Context, string, string, string) error { return nil }
+
+func (q *fakeQueue) SendBatch(context.Context, []*types.FifoQueueMessage) error { return nil }
+
+func (q *fakeQueue) Receive(context.Context, chan<- *types.FifoQueueItem) error { return nil }
+
+func (q *fakeQueue) DeadLetter() (types.FifoQueue, error) {
+	return nil, types.ErrDeadLetterNotSupported
+}
+
+func (q *fakeQueue) ReceiveBatch(ctx context.Context, maxItems int) ([]*types.FifoQueueItem, error) {
+	q.mu.Lock()
+	err := q.receiveErr
+	q.receiveErr = nil
+	q.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*types.FifoQueueItem
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case item := <-q.items:
+		items = append(items, item)
+	}
+
+	for len(items) < maxItems {
+		select {
+		case item := <-q.items:
+			items = append(items, item)
+		default:
+			return items, nil
+		}
+	}
+
+	return items, nil
+}
+
+func (q *fakeQueue) results() ([]string, []string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]string(nil), q.acked...), append([]string(nil), q.nacked...)
+}
+
+type (
+	spanKey   struct{}
+	parentKey struct{}
+)
+
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]any
+	err    error
+}
+
+// recordingTracer records ended spans, with the parent extracted from the 'traceparent' carrier key.
+type recordingTracer struct {
+	types.NoopTracer
+
+	mu    sync.Mutex
+	spans []recordedSpan
+}
+
+func (tr *recordingTracer) StartSpan(ctx context.Context, name string, attrs map[string]any) (context.Context, func(err error)) {
+	parent, _ := ctx.Value(parentKey{}).(string)
+
+	return context.WithValue(ctx, spanKey{}, name), func(err error) {
+		tr.mu.Lock()
+		defer tr.mu.Unlock()
+
+		tr.spans = append(tr.spans, recordedSpan{name: name, parent: parent, attrs: attrs, err: err})
+	}
+}
+
+func (tr *recordingTracer) Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return context.WithValue(ctx, parentKey{}, carrier["traceparent"])
+}
+
+func (tr *recordingTracer) ended() []recordedSpan {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	return append([]recordedSpan(nil), tr.spans...)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	require.Eventually(t, condition, 2*time.Second, time.Millisecond)
+}
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("handler results should ack and nack items", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newFakeQueue(10)
+		queue.add("1", "C1", "ok", 1)
+		queue.add("2", "C1", "fail", 3)
+		queue.add("3", "C2", "panic", 1)
+
+		runner, err := consumer.NewFifoQueueRunner(queue, func(_ context.Context, msg *consumer.Message) error {
+			switch msg.Body {
+			case "fail":
+				return errors.New("failed")
+			case "panic":
+				panic("boom")
+			}
+			return nil
+		}, consumer.Config{
+			RetryDelay: func(receiveCount int) time.Duration { return time.Duration(receiveCount) * time.Second },
+		})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		waitFor(t, func() bool {
+			acked, nacked := queue.results()
+			return len(acked)+len(nacked) == 3
+		})
+
+		cancel()
+		require.NoError(t, <-done)
+
+		acked, nacked := queue.results()
+		assert.Equal(t, []string{"1"}, acked)
+		assert.ElementsMatch(t, []string{"2", "3"}, nacked)
+		assert.Equal(t, 3*time.Second, queue.delays["2"])
+	})
+
+	t.Run("workers should bound the concurrency", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newFakeQueue(20)
+		for i := range 20 {
+			queue.add(string(rune('a'+i)), string(rune('A'+i)), "", 1)
+		}
+
+		var running, maxRunning atomic.Int32
+
+		runner, err := consumer.NewFifoQueueRunner(queue, func(context.Context, *consumer.Message) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+
+			return nil
+		}, consumer.Config{Workers: 3, BatchSize: 10})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		waitFor(t, func() bool {
+			acked, _ := queue.results()
+			return len(acked) == 20
+		})
+
+		cancel()
+		require.NoError(t, <-done)
+		assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+	})
+
+	t.Run("items of the same group should be processed serially and in order", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newFakeQueue(30)
+		for i := range 30 {
+			queue.add(string(rune('a'+i)), string(rune('A'+i%3)), "", 1)
+		}
+
+		var mu sync.Mutex
+
+		running := map[string]bool{}
+		order := map[string][]string{}
+		maxGroups := 0
+
+		runner, err := consumer.NewFifoQueueRunner(queue, func(_ context.Context, msg *consumer.Message) error {
+			mu.Lock()
+			assert.False(t, running[msg.GroupID], "items of group %s overlap", msg.GroupID)
+			running[msg.GroupID] = true
+			order[msg.GroupID] = append(order[msg.GroupID], msg.ID)
+			maxGroups = max(maxGroups, len(running))
+			mu.Unlock()
+
+			time.Sleep(2 * time.Millisecond)
+
+			mu.Lock()
+			delete(running, msg.GroupID)
+			mu.Unlock()
+
+			return nil
+		}, consumer.Config{Workers: 6, BatchSize: 10})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		waitFor(t, func() bool {
+			acked, _ := queue.results()
+			return len(acked) == 30
+		})
+
+		cancel()
+		require.NoError(t, <-done)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for g := range 3 {
+			var expected []string
+			for i := g; i < 30; i += 3 {
+				expected = append(expected, string(rune('a'+i)))
+			}
+
+			assert.Equal(t, expected, order[string(rune('A'+g))])
+		}
+
+		assert.Greater(t, maxGroups, 1, "groups should be processed in parallel")
+	})
+
+	t.Run("items held behind an item of the same group should be extended", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newFakeQueue(2)
+		queue.add("1", "C1", "slow", 1)
+		queue.add("2", "C1", "", 1)
+
+		var heldExtensions int
+
+		runner, err := consumer.NewFifoQueueRunner(queue, func(_ context.Context, msg *consumer.Message) error {
+			if msg.Body == "slow" {
+				time.Sleep(100 * time.Millisecond)
+				return nil
+			}
+
+			queue.mu.Lock()
+			heldExtensions = queue.extended["2"]
+			queue.mu.Unlock()
+
+			return nil
+		}, consumer.Config{Workers: 2, BatchSize: 2, VisibilityExtension: 20 * time.Millisecond})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		waitFor(t, func() bool {
+			acked, _ := queue.results()
+			return len(acked) == 2
+		})
+
+		cancel()
+		require.NoError(t, <-done)
+
+		acked, nacked := queue.results()
+		assert.Equal(t, []string{"1", "2"}, acked)
+		assert.Empty(t, nacked)
+		assert.Positive(t, heldExtensions, "the held item should be extended before its turn")
+	})
+
+	t.Run("disabled ordering should process items of the same group in parallel", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newFakeQueue(4)
+		for i := range 4 {
+			queue.add(string(rune('a'+i)), "C1", "", 1)
+		}
+
+		var running, maxRunning atomic.Int32
+
+		runner, err := consumer.NewFifoQueueRunner(queue, func(context.Context, *consumer.Message) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+
+			return nil
+		}, consumer.Config{Workers: 4, DisableOrdering: true})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		waitFor(t, func() bool {
+			acked, _ := queue.results()
+			return len(acked) == 4
+		})
+
+		cancel()
+		require.NoError(t, <-done)
+		assert.Greater(t, maxRunning.Load(), int32(1))
+	})
+
+	t.Run("shutdown should wait for running handlers", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newFakeQueue(1)
+		queue.add("1", "C1", "", 1)
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		runner, err := consumer.NewFifoQueueRunner(queue, func(ctx context.Context, _ *consumer.Message) error {
+			close(started)
+			<-release
+			return ctx.Err()
+		}, consumer.Config{VisibilityExtension: 10 * time.Millisecond})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		<-started
+		cancel()
+		time.Sleep(20 * time.Millisecond)
+
+		select {
+		case <-done:
+			t.Fatal("Run returned before the handler completed")
+		default:
+		}
+
+		close(release)
+		require.NoError(t, <-done)
+
+		acked, _ := queue.results()
+		assert.Equal(t, []string{"1"}, acked, "the handler context should not be canceled by the Run context")
+		assert.Positive(t, queue.extended["1"])
+	})
+
+	t.Run("handler timeout should cancel the handler context", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newFakeQueue(1)
+		queue.add("1", "C1", "", 1)
+
+		runner, err := consumer.NewFifoQueueRunner(queue, func(ctx context.Context, _ *consumer.Message) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, consumer.Config{HandlerTimeout: 10 * time.Millisecond})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		waitFor(t, func() bool {
+			_, nacked := queue.results()
+			return len(nacked) == 1
+		})
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	t.Run("receive errors should be retried", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newFakeQueue(1)
+		queue.receiveErr = errors.New("throttled")
+		queue.add("1", "C1", "", 1)
+
+		runner, err := consumer.NewFifoQueueRunner(queue, func(context.Context, *consumer.Message) error {
+			return nil
+		}, consumer.Config{ReceiveErrorDelay: time.Millisecond})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		waitFor(t, func() bool {
+			acked, _ := queue.results()
+			return len(acked) == 1
+		})
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	t.Run("handler context should hold a logger with message fields", func(t *testing.T) {
+		t.Parallel()
+
+		queue := newFakeQueue(1)
+		queue.add("1", "C1", "", 1)
+
+		var buf bytes.Buffer
+
+		runner, err := consumer.NewFifoQueueRunner(queue, func(ctx context.Context, _ *consumer.Message) error {
+			types.LoggerFromContext(ctx).Info("handled")
+			return nil
+		}, consumer.Config{Logger: types.NewStdLogger(&buf, types.LogLevelInfo)})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		waitFor(t, func() bool {
+			acked, _ := queue.results()
+			return len(acked) == 1
+		})
+
+		cancel()
+		require.NoError(t, <-done)
+		assert.Contains(t, buf.String(), `"msg":"handled","channelId":"C1","messageId":"1","queue":"fake"}`)
+	})
+
+	t.Run("tracer should create spans as children of the producer span", func(t *testing.T) {
+		t.Parallel()
+
+		body, err := types.EncodeQueueEnvelopeWithOptions(types.QueueEnvelopeKindAlert, types.NewErrorAlert(), types.QueueEnvelopeOptions{
+			TraceContext: map[string]string{"traceparent": "producer"},
+		})
+		require.NoError(t, err)
+
+		queue := newFakeQueue(2)
+		queue.add("1", "C1", body, 1)
+		queue.add("2", "C2", "raw", 1)
+
+		tracer := &recordingTracer{}
+
+		runner, err := consumer.NewFifoQueueRunner(queue, func(ctx context.Context, msg *consumer.Message) error {
+			assert.NotNil(t, ctx.Value(spanKey{}), "the handler context should hold the span")
+
+			if msg.Body == "raw" {
+				return errors.New("failed")
+			}
+
+			return nil
+		}, consumer.Config{Tracer: tracer})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		waitFor(t, func() bool {
+			acked, nacked := queue.results()
+			return len(acked)+len(nacked) == 2
+		})
+
+		cancel()
+		require.NoError(t, <-done)
+
+		spans := tracer.ended()
+		require.Len(t, spans, 2)
+
+		for _, span := range spans {
+			assert.Equal(t, "slackmgr.consumer.process", span.name)
+
+			switch span.attrs[types.TraceAttrMessageID] {
+			case "1":
+				assert.Equal(t, "producer", span.parent)
+				assert.Equal(t, "C1", span.attrs[types.TraceAttrChannelID])
+				require.NoError(t, span.err)
+			case "2":
+				assert.Empty(t, span.parent)
+				require.EqualError(t, span.err, "failed")
+			default:
+				t.Fatalf("unexpected span %v", span.attrs)
+			}
+		}
+	})
+
+	t.Run("unordered queues should be supported", func(t *testing.T) {
+		t.Parallel()
+
+		queue := types.NewInMemoryQueue("callbacks", 3, time.Millisecond)
+		require.NoError(t, queue.SendBatch(context.Background(), []string{"a", "b", "c"}))
+
+		var mu sync.Mutex
+
+		var bodies []string
+
+		runner, err := consumer.NewQueueRunner(queue, func(_ context.Context, msg *consumer.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			assert.Empty(t, msg.GroupID)
+			bodies = append(bodies, msg.Body)
+
+			return nil
+		}, consumer.Config{})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- runner.Run(ctx) }()
+
+		waitFor(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(bodies) == 3
+		})
+
+		cancel()
+		require.NoError(t, <-done)
+		assert.ElementsMatch(t, []string{"a", "b", "c"}, bodies)
+	})
+
+	t.Run("invalid config should return error", func(t *testing.T) {
+		t.Parallel()
+
+		handler := func(context.Context, *consumer.Message) error { return nil }
+		queue := newFakeQueue(1)
+
+		_, err := consumer.NewFifoQueueRunner(nil, handler, consumer.Config{})
+		require.ErrorContains(t, err, "queue cannot be nil")
+
+		_, err = consumer.NewQueueRunner(nil, handler, consumer.Config{})
+		require.ErrorContains(t, err, "queue cannot be nil")
+
+		_, err = consumer.NewFifoQueueRunner(queue, nil, consumer.Config{})
+		require.ErrorContains(t, err, "handler cannot be nil")
+
+		_, err = consumer.NewFifoQueueRunner(queue, handler, consumer.Config{Workers: -1})
+		require.ErrorContains(t, err, "workers cannot be negative")
+
+		_, err = consumer.NewFifoQueueRunner(queue, handler, consumer.Config{BatchSize: -1})
+		require.ErrorContains(t, err, "batch size cannot be negative")
+
+		_, err = consumer.NewFifoQueueRunner(queue, handler, consumer.Config{HandlerTimeout: -1})
+		require.ErrorContains(t, err, "handler timeout cannot be negative")
+	})
+}
@@ -0,0 +1,107 @@
+package consumer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// Message is a queue item passed to a Handler. The Runner acknowledges the item based on the handler result,
+// so handlers never call Ack or Nack themselves.
+type Message struct {
+	// ID is the message ID, as defined by the queue implementation.
+	ID string
+
+	// GroupID is the Slack channel ID of items received from a FIFO queue, and empty for unordered queues.
+	GroupID string
+
+	// Body is the body of the message.
+	Body string
+
+	// ReceiveCount is the number of times the message has been received, including this time.
+	// It is 0 if the queue implementation does not track deliveries.
+	ReceiveCount int
+
+	// ReceiveTimestamp is the time when the message was received from the queue.
+	ReceiveTimestamp time.Time
+
+	// Priority is the priority of items received from a types.PriorityQueue, and 0 otherwise.
+	Priority int
+
+	ack           func()
+	nack          func()
+	nackWithDelay func(delay time.Duration)
+	extend        func(timeout time.Duration) error
+
+	// heldLease extends the item while it waits for an earlier item of the same group. Guarded by Runner.mu.
+	heldLease *types.LeaseKeeper
+}
+
+func fifoMessage(item *types.FifoQueueItem) *Message {
+	return &Message{
+		ID:               item.MessageID,
+		GroupID:          item.SlackChannelID,
+		Body:             item.Body,
+		ReceiveCount:     item.ReceiveCount,
+		ReceiveTimestamp: item.ReceiveTimestamp,
+		ack:              item.Ack,
+		nack:             item.Nack,
+		nackWithDelay:    item.NackWithDelay,
+		extend:           item.Extend,
+	}
+}
+
+func queueMessage(item *types.QueueItem) *Message {
+	return &Message{
+		ID:               item.MessageID,
+		Body:             item.Body,
+		ReceiveCount:     item.ReceiveCount,
+		ReceiveTimestamp: item.ReceiveTimestamp,
+		Priority:         item.Priority,
+		ack:              item.Ack,
+		nack:             item.Nack,
+		nackWithDelay:    item.NackWithDelay,
+		extend:           item.Extend,
+	}
+}
+
+// Alert decodes the message body as an Alert: either a types.QueueEnvelope of kind alert, or a plain JSON alert.
+func (m *Message) Alert() (*types.Alert, error) {
+	envelope, err := types.DecodeQueueEnvelope(m.Body)
+	if err == nil {
+		return envelope.Alert()
+	}
+
+	if !errors.Is(err, types.ErrNotQueueEnvelope) {
+		return nil, err
+	}
+
+	var alert types.Alert
+
+	if err := json.Unmarshal([]byte(m.Body), &alert); err != nil {
+		return nil, fmt.Errorf("failed to decode alert: %w", err)
+	}
+
+	return &alert, nil
+}
+
+// Command decodes the message body as a types.Command. Commands are always sent in a types.QueueEnvelope
+// of kind command (see types.EncodeCommand), so an error is returned for plain JSON bodies.
+func (m *Message) Command() (types.Command, error) { //nolint:ireturn
+	envelope, err := types.DecodeQueueEnvelope(m.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return envelope.Command()
+}
+
+// IdempotencyKey returns the idempotency key of a types.QueueEnvelope body (see types.QueueEnvelopeIdempotencyKey),
+// so that handlers can detect duplicate deliveries, e.g. with a types.ReplayGuard. It returns an empty string
+// for plain JSON bodies, and envelopes without idempotency key.
+func (m *Message) IdempotencyKey() string {
+	return types.QueueEnvelopeIdempotencyKey(m.Body)
+}
@@ -0,0 +1,80 @@
+package types
+
+// CorrelationStrategy computes the correlation ID of alerts without an explicit CorrelationID.
+// Alerts with the same correlation ID are grouped together in issues.
+type CorrelationStrategy interface {
+	// CorrelationID returns the correlation ID for the alert.
+	CorrelationID(a *Alert) string
+}
+
+// CorrelationStrategyFunc is an adapter allowing an ordinary function to be used as a CorrelationStrategy.
+type CorrelationStrategyFunc func(a *Alert) string
+
+// CorrelationID calls f(a).
+func (f CorrelationStrategyFunc) CorrelationID(a *Alert) string {
+	return f(a)
+}
+
+// DefaultCorrelationStrategy returns the strategy used for alerts without an explicit CorrelationID,
+// which hashes the header, text, author, host and Slack channel ID.
+func DefaultCorrelationStrategy() CorrelationStrategyFunc {
+	return HashFields("header", "text", "author", "host", "slackChannelId")
+}
+
+// HashFields returns a strategy hashing the named alert fields, in order. Field names are the JSON field names
+// (see ValidAlertFields), and metadata values can be included as 'metadata.<key>'. Unknown fields and missing
+// metadata keys contribute empty values to the hash; use AlertFieldIsValid to check configured field names.
+func HashFields(fields ...string) CorrelationStrategyFunc {
+	fields = append([]string(nil), fields...)
+
+	return CorrelationStrategyFunc(func(a *Alert) string {
+		values := make([]string, len(fields))
+
+		for i, field := range fields {
+			values[i], _ = alertFieldValue(a, field)
+		}
+
+		return hash(values...)
+	})
+}
+
+// FromLabels returns a strategy hashing the alert metadata values with the specified keys (labels), in order.
+// Alerts with the same label values are correlated, regardless of their header and text.
+func FromLabels(keys ...string) CorrelationStrategyFunc {
+	fields := make([]string, len(keys))
+
+	for i, key := range keys {
+		fields[i] = alertFieldMetadataPrefix + key
+	}
+
+	return HashFields(fields...)
+}
+
+// Static returns a strategy using the same correlation ID for all alerts, which groups all alerts in a channel
+// into a single issue.
+func Static(correlationID string) CorrelationStrategyFunc {
+	return CorrelationStrategyFunc(func(*Alert) string {
+		return correlationID
+	})
+}
+
+// PerHost returns a strategy correlating all alerts from the same host, regardless of their header and text.
+func PerHost() CorrelationStrategyFunc {
+	return HashFields("host")
+}
+
+// ResolveCorrelationID sets the alert CorrelationID using the strategy, if it is not already set, and returns it.
+// If strategy is nil, DefaultCorrelationStrategy is used.
+func (a *Alert) ResolveCorrelationID(strategy CorrelationStrategy) string {
+	if a.CorrelationID != "" {
+		return a.CorrelationID
+	}
+
+	if strategy == nil {
+		strategy = DefaultCorrelationStrategy()
+	}
+
+	a.CorrelationID = strategy.CorrelationID(a)
+
+	return a.CorrelationID
+}
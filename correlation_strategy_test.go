@@ -0,0 +1,84 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelationStrategies(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(header, text, host string, metadata map[string]any) *types.Alert {
+		return &types.Alert{Header: header, Text: text, Host: host, SlackChannelID: "C1", Metadata: metadata}
+	}
+
+	a1 := newAlert("Disk full", "93%", "host-1", map[string]any{"service": "db", "shard": 1})
+	a2 := newAlert("Disk full", "95%", "host-1", map[string]any{"service": "db", "shard": 1})
+	a3 := newAlert("CPU high", "99%", "host-2", map[string]any{"service": "db", "shard": 2})
+
+	t.Run("default strategy hashes header, text, author, host and channel", func(t *testing.T) {
+		t.Parallel()
+
+		s := types.DefaultCorrelationStrategy()
+		assert.NotEqual(t, s.CorrelationID(a1), s.CorrelationID(a2))
+		assert.Equal(t, s.CorrelationID(a1), s.CorrelationID(newAlert("Disk full", "93%", "host-1", nil)))
+		assert.LessOrEqual(t, len(s.CorrelationID(a1)), types.MaxCorrelationIDLength)
+	})
+
+	t.Run("hash fields ignores other fields", func(t *testing.T) {
+		t.Parallel()
+
+		s := types.HashFields("header", "host")
+		assert.Equal(t, s.CorrelationID(a1), s.CorrelationID(a2))
+		assert.NotEqual(t, s.CorrelationID(a1), s.CorrelationID(a3))
+		assert.NotEqual(t, s.CorrelationID(a1), types.HashFields("host", "header").CorrelationID(a1))
+	})
+
+	t.Run("from labels uses metadata values", func(t *testing.T) {
+		t.Parallel()
+
+		s := types.FromLabels("service")
+		assert.Equal(t, s.CorrelationID(a1), s.CorrelationID(a3))
+
+		s = types.FromLabels("service", "shard")
+		assert.Equal(t, s.CorrelationID(a1), s.CorrelationID(a2))
+		assert.NotEqual(t, s.CorrelationID(a1), s.CorrelationID(a3))
+	})
+
+	t.Run("static and per host", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "all", types.Static("all").CorrelationID(a1))
+		assert.Equal(t, types.PerHost().CorrelationID(a1), types.PerHost().CorrelationID(a2))
+		assert.NotEqual(t, types.PerHost().CorrelationID(a1), types.PerHost().CorrelationID(a3))
+	})
+}
+
+func TestAlertResolveCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	a := &types.Alert{Header: "a", CorrelationID: "explicit"}
+	assert.Equal(t, "explicit", a.ResolveCorrelationID(types.Static("static")))
+
+	a = &types.Alert{Header: "a"}
+	assert.Equal(t, "static", a.ResolveCorrelationID(types.Static("static")))
+	assert.Equal(t, "static", a.CorrelationID)
+
+	a = &types.Alert{Header: "a"}
+	id := a.ResolveCorrelationID(nil)
+	assert.Equal(t, types.DefaultCorrelationStrategy().CorrelationID(&types.Alert{Header: "a"}), id)
+}
+
+func TestAlertFieldIsValid(t *testing.T) {
+	t.Parallel()
+
+	for _, field := range types.ValidAlertFields() {
+		assert.True(t, types.AlertFieldIsValid(field), field)
+	}
+
+	assert.True(t, types.AlertFieldIsValid("metadata.team"))
+	assert.False(t, types.AlertFieldIsValid("metadata."))
+	assert.False(t, types.AlertFieldIsValid("Header"))
+}
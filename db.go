@@ -72,3 +72,20 @@ type DB interface {
 	// It should be used with caution, as it will remove all alerts, issues, move mappings, and processing states.
 	DropAllData(ctx context.Context) error
 }
+
+// IssueRecord is an issue as stored in the database: its unique ID and JSON body.
+type IssueRecord struct {
+	ID   string          `json:"id"`
+	Body json.RawMessage `json:"body"`
+}
+
+// IssueLister is an optional interface for DB implementations that can list issues with sorting and cursor pagination,
+// so that listing endpoints don't have to load all issues and paginate in memory.
+// Use a type assertion on the DB to check if it is supported.
+type IssueLister interface {
+	// ListIssues returns a page of issues (open and archived), sorted and paginated according to the options.
+	//
+	// The database implementation should return an error if the options are not valid (see NewFindOptions),
+	// or if the cursor was not returned by the same implementation.
+	ListIssues(ctx context.Context, opts ...FindOption) (*PageResult[IssueRecord], error)
+}
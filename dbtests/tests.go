@@ -902,6 +902,94 @@ func TestContextCancellation(t *testing.T, client types.DB) {
 	})
 }
 
+// TestListIssues tests listing issues with sorting and cursor pagination.
+// It is skipped if the database does not implement the optional types.IssueLister interface.
+func TestListIssues(t *testing.T, client types.DB) {
+	lister, ok := client.(types.IssueLister)
+	if !ok {
+		t.Skip("database does not implement types.IssueLister")
+	}
+
+	ctx := context.Background()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	// Ensure clean state
+	err := client.DropAllData(ctx)
+	require.NoError(err)
+	err = client.Init(ctx, true)
+	require.NoError(err)
+
+	const issueCount = 25
+	channels := []string{"C0ABABABA1", "C0ABABABA2", "C0ABABABA3"}
+	expectedIDs := make(map[string]bool)
+
+	for i := range issueCount {
+		issue := newTestIssue(newTestAlert(channels[i%len(channels)], uuid.New().String()), uuid.New().String())
+		issue.Archived = i%5 == 0
+		err = client.SaveIssue(ctx, issue)
+		require.NoError(err)
+		expectedIDs[issue.UniqueID()] = true
+	}
+
+	// Page through all issues, sorted by channel ID (descending)
+	seenIDs := make(map[string]bool)
+	var previousChannel string
+	cursor := ""
+	pages := 0
+
+	for {
+		page, err := lister.ListIssues(ctx, types.WithSort(types.IssueFieldChannelID, types.SortDescending), types.WithLimit(10), types.WithCursor(cursor))
+		require.NoError(err)
+		require.LessOrEqual(len(page.Items), 10, "page should not exceed the limit")
+		pages++
+
+		for _, item := range page.Items {
+			assert.False(seenIDs[item.ID], "issue should not be returned twice")
+			seenIDs[item.ID] = true
+
+			issue := testIssueFromJSON(item.Body)
+			if previousChannel != "" {
+				assert.LessOrEqual(issue.ChannelID(), previousChannel, "issues should be sorted by channel ID (descending)")
+			}
+			previousChannel = issue.ChannelID()
+		}
+
+		if !page.HasMore() {
+			break
+		}
+
+		cursor = page.NextCursor
+		require.Less(pages, issueCount, "pagination should terminate")
+	}
+
+	assert.Equal(expectedIDs, seenIDs, "all issues should be listed exactly once")
+
+	// Without limit, all issues should be returned in a single page
+	page, err := lister.ListIssues(ctx)
+	require.NoError(err)
+	assert.Len(page.Items, issueCount)
+	assert.False(page.HasMore())
+
+	// Matchers should be applied, if supported by the database
+	prefixMatcher := types.FindMatcher{Field: types.IssueFieldChannelID, Operator: types.FindMatchPrefix, Value: "C0ABABABA1"}
+	if types.IssueListerCapabilities(lister).Supports(prefixMatcher) {
+		page, err = lister.ListIssues(ctx, types.WithMatchers(prefixMatcher))
+		require.NoError(err)
+		assert.Len(page.Items, (issueCount+len(channels)-1)/len(channels), "prefix matcher should return the issues in the first channel")
+	}
+
+	// Invalid options should fail
+	_, err = lister.ListIssues(ctx, types.WithLimit(-1))
+	require.Error(err, "negative limit should fail")
+
+	_, err = lister.ListIssues(ctx, types.WithSort("foo", types.SortAscending))
+	require.Error(err, "invalid sort field should fail")
+
+	_, err = lister.ListIssues(ctx, types.WithCursor("not a cursor!"))
+	require.Error(err, "unknown cursor should fail")
+}
+
 // RunAllTests runs all database compliance tests.
 // This is a convenience function for plugin implementations.
 func RunAllTests(t *testing.T, client types.DB) {
@@ -924,6 +1012,7 @@ func RunAllTests(t *testing.T, client types.DB) {
 	t.Run("CreatingAndFindingMoveMappings", func(t *testing.T) { TestCreatingAndFindingMoveMappings(t, client) })
 	t.Run("DeletingMoveMappings", func(t *testing.T) { TestDeletingMoveMappings(t, client) })
 	t.Run("CreatingAndFindingChannelProcessingState", func(t *testing.T) { TestCreatingAndFindingChannelProcessingState(t, client) })
+	t.Run("ListIssues", func(t *testing.T) { TestListIssues(t, client) })
 
 	// Initialization tests
 	t.Run("Init", func(t *testing.T) { TestInit(t, client) })
@@ -0,0 +1,178 @@
+package types
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDeduplicationWindow is the deduplication window used when DeduplicatorConfig.Window is zero.
+	DefaultDeduplicationWindow = 60 * time.Second
+
+	// DefaultDeduplicationCacheSize is the max number of keys used when DeduplicatorConfig.MaxSize is zero.
+	DefaultDeduplicationCacheSize = 10000
+
+	// DeduplicatedAlertsMetric is the name of the counter incremented for each alert dropped by a Deduplicator.
+	// The counter has a single label, 'channel', with the Slack channel ID of the alert.
+	DeduplicatedAlertsMetric = "alerts_deduplicated_total"
+)
+
+// DeduplicationKeyFunc returns the key used to detect duplicate alerts.
+type DeduplicationKeyFunc func(a *Alert) string
+
+// DeduplicateByContentHash is a DeduplicationKeyFunc that uses Alert.ContentHash as key.
+func DeduplicateByContentHash(a *Alert) string {
+	return a.ContentHash()
+}
+
+// DeduplicateByCorrelationID is a DeduplicationKeyFunc that uses the Slack channel ID, route key and correlation ID as key.
+func DeduplicateByCorrelationID(a *Alert) string {
+	return hash("correlation", a.SlackChannelID, a.RouteKey, a.CorrelationID)
+}
+
+// ContentHash returns a hash of the alert destination, correlation ID and content (severity, header, text, author
+// and host). Two alerts with the same content hash render the same Slack post, and are typically duplicates.
+func (a *Alert) ContentHash() string {
+	return hash("content", a.SlackChannelID, a.RouteKey, a.CorrelationID, string(a.Severity), a.Header, a.Text, a.Author, a.Host)
+}
+
+// DeduplicatorConfig holds the configuration of a Deduplicator.
+type DeduplicatorConfig struct {
+	// Window is the time within which an alert with the same key as a previous alert is considered a duplicate.
+	// The window slides: each duplicate extends it. Defaults to DefaultDeduplicationWindow.
+	Window time.Duration
+
+	// MaxSize is the max number of keys kept in memory. The least recently seen key is evicted when the limit is
+	// reached. Defaults to DefaultDeduplicationCacheSize.
+	MaxSize int
+
+	// KeyFunc returns the deduplication key of an alert. Defaults to DeduplicateByContentHash.
+	KeyFunc DeduplicationKeyFunc
+
+	// Metrics receives the DeduplicatedAlertsMetric counter. Optional.
+	Metrics Metrics
+}
+
+// Deduplicator drops alerts that are identical to an alert seen within the deduplication window.
+// It is intended for alert producers and the ingestion layer, to avoid processing bursts of identical alerts.
+// It is safe for concurrent use.
+//
+// The deduplicator is in-memory only, and is thus only effective within a single process.
+type Deduplicator struct {
+	window  time.Duration
+	maxSize int
+	keyFunc DeduplicationKeyFunc
+	metrics Metrics
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type deduplicationEntry struct {
+	key      string
+	lastSeen time.Time
+}
+
+// NewDeduplicator creates a new Deduplicator with the given configuration.
+// An error is returned if the window or max size is negative.
+func NewDeduplicator(cfg DeduplicatorConfig) (*Deduplicator, error) {
+	if cfg.Window < 0 {
+		return nil, errors.New("deduplication window cannot be negative")
+	}
+
+	if cfg.MaxSize < 0 {
+		return nil, errors.New("deduplication max size cannot be negative")
+	}
+
+	d := &Deduplicator{
+		window:  cfg.Window,
+		maxSize: cfg.MaxSize,
+		keyFunc: cfg.KeyFunc,
+		metrics: cfg.Metrics,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+
+	if d.window == 0 {
+		d.window = DefaultDeduplicationWindow
+	}
+
+	if d.maxSize == 0 {
+		d.maxSize = DefaultDeduplicationCacheSize
+	}
+
+	if d.keyFunc == nil {
+		d.keyFunc = DeduplicateByContentHash
+	}
+
+	if d.metrics == nil {
+		d.metrics = &NoopMetrics{}
+	}
+
+	d.metrics.RegisterCounter(DeduplicatedAlertsMetric, "Number of alerts dropped as duplicates", "channel")
+
+	return d, nil
+}
+
+// Allow returns false if the alert is a duplicate of an alert seen within the deduplication window before now.
+// Otherwise, it returns true. In both cases, the alert key is recorded as seen at the given time.
+func (d *Deduplicator) Allow(a *Alert, now time.Time) bool {
+	if a == nil {
+		return true
+	}
+
+	key := d.keyFunc(a)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if elem, ok := d.entries[key]; ok {
+		entry, _ := elem.Value.(*deduplicationEntry)
+		duplicate := now.Sub(entry.lastSeen) < d.window
+
+		entry.lastSeen = now
+		d.order.MoveToFront(elem)
+
+		if duplicate {
+			d.metrics.Inc(DeduplicatedAlertsMetric, a.SlackChannelID)
+			return false
+		}
+
+		return true
+	}
+
+	d.entries[key] = d.order.PushFront(&deduplicationEntry{key: key, lastSeen: now})
+
+	for d.order.Len() > d.maxSize {
+		oldest := d.order.Back()
+		entry, _ := oldest.Value.(*deduplicationEntry)
+		d.order.Remove(oldest)
+		delete(d.entries, entry.key)
+	}
+
+	return true
+}
+
+// Filter returns the alerts that are not duplicates, in their original order. See Allow.
+func (d *Deduplicator) Filter(alerts []*Alert, now time.Time) []*Alert {
+	result := make([]*Alert, 0, len(alerts))
+
+	for _, a := range alerts {
+		if d.Allow(a, now) {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+// Len returns the number of keys currently kept in memory, including keys whose window has expired.
+func (d *Deduplicator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.order.Len()
+}
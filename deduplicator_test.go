@@ -0,0 +1,117 @@
+package types_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingMetrics struct {
+	types.NoopMetrics
+
+	mu     sync.Mutex
+	counts map[string]float64
+}
+
+func (m *countingMetrics) Inc(name string, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.counts == nil {
+		m.counts = make(map[string]float64)
+	}
+
+	key := name
+	for _, v := range labelValues {
+		key += "|" + v
+	}
+
+	m.counts[key]++
+}
+
+func TestNewDeduplicator(t *testing.T) {
+	t.Parallel()
+
+	_, err := types.NewDeduplicator(types.DeduplicatorConfig{Window: -time.Second})
+	require.EqualError(t, err, "deduplication window cannot be negative")
+
+	_, err = types.NewDeduplicator(types.DeduplicatorConfig{MaxSize: -1})
+	require.EqualError(t, err, "deduplication max size cannot be negative")
+
+	d, err := types.NewDeduplicator(types.DeduplicatorConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, d.Len())
+}
+
+func TestDeduplicatorAllow(t *testing.T) {
+	t.Parallel()
+
+	metrics := &countingMetrics{}
+	d, err := types.NewDeduplicator(types.DeduplicatorConfig{Window: 10 * time.Second, Metrics: metrics})
+	require.NoError(t, err)
+
+	now := time.Now()
+	a := &types.Alert{SlackChannelID: "C1", Header: "Disk full"}
+	b := &types.Alert{SlackChannelID: "C1", Header: "Disk almost full"}
+
+	assert.True(t, d.Allow(a, now))
+	assert.True(t, d.Allow(b, now))
+	assert.False(t, d.Allow(a, now.Add(5*time.Second)))
+
+	// The window slides, so the duplicate above extends it
+	assert.False(t, d.Allow(a, now.Add(14*time.Second)))
+	assert.True(t, d.Allow(a, now.Add(30*time.Second)))
+	assert.True(t, d.Allow(nil, now))
+
+	assert.InDelta(t, 2.0, metrics.counts[types.DeduplicatedAlertsMetric+"|C1"], 0)
+}
+
+func TestDeduplicatorByCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	d, err := types.NewDeduplicator(types.DeduplicatorConfig{KeyFunc: types.DeduplicateByCorrelationID})
+	require.NoError(t, err)
+
+	now := time.Now()
+	a := &types.Alert{SlackChannelID: "C1", CorrelationID: "x", Header: "Disk full"}
+	b := &types.Alert{SlackChannelID: "C1", CorrelationID: "x", Header: "Disk almost full"}
+	c := &types.Alert{SlackChannelID: "C2", CorrelationID: "x", Header: "Disk almost full"}
+
+	assert.Equal(t, []*types.Alert{a, c}, d.Filter([]*types.Alert{a, b, c}, now))
+}
+
+func TestDeduplicatorMaxSize(t *testing.T) {
+	t.Parallel()
+
+	d, err := types.NewDeduplicator(types.DeduplicatorConfig{MaxSize: 2})
+	require.NoError(t, err)
+
+	now := time.Now()
+	a := &types.Alert{Header: "a"}
+	b := &types.Alert{Header: "b"}
+	c := &types.Alert{Header: "c"}
+
+	assert.True(t, d.Allow(a, now))
+	assert.True(t, d.Allow(b, now))
+	assert.True(t, d.Allow(c, now))
+	assert.Equal(t, 2, d.Len())
+
+	// a was evicted as the least recently seen key
+	assert.True(t, d.Allow(a, now))
+	assert.False(t, d.Allow(c, now))
+}
+
+func TestAlertContentHash(t *testing.T) {
+	t.Parallel()
+
+	a := &types.Alert{SlackChannelID: "C1", Header: "Disk full", Text: "on host1"}
+	b := &types.Alert{SlackChannelID: "C1", Header: "Disk full", Text: "on host1", IconEmoji: ":fire:"}
+	c := &types.Alert{SlackChannelID: "C1", Header: "Disk full", Text: "on host2"}
+
+	assert.Equal(t, a.ContentHash(), b.ContentHash())
+	assert.NotEqual(t, a.ContentHash(), c.ContentHash())
+}
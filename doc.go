@@ -9,7 +9,7 @@
 // DB - Database abstraction for persisting alerts, issues, move mappings, and channel processing state.
 // Implementations must handle storage as opaque JSON to allow flexibility.
 //
-// Logger - Structured logging interface with Debug/Info/Error levels and field support.
+// Logger - Structured logging interface with Debug/Info/Warn/Error levels and field support.
 // Supports method chaining with WithField and WithFields.
 //
 // Metrics - Prometheus-style metrics interface supporting counters, gauges, and histograms.
@@ -56,7 +56,7 @@
 // to ensure compliance with the interface contract.
 //
 // No-op implementations (NoopLogger, NoopMetrics) are provided for testing purposes.
-// InMemoryFifoQueue is provided for testing but should not be used in production.
+// InMemoryFifoQueue and InMemoryQueue are provided for testing but should not be used in production.
 //
 // # Usage Example
 //
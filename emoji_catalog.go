@@ -0,0 +1,161 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxUnicodeEmojiRunes is the maximum number of runes of a unicode emoji accepted by IsUnicodeEmoji. The longest
+// standard sequences, ZWJ sequences with skin tone modifiers, have about 10 runes.
+const maxUnicodeEmojiRunes = 16
+
+// EmojiCatalog reports which emojis exist in a Slack workspace, so that Alert.IconEmoji can be verified before
+// the alert is posted. Invalid icon emojis otherwise fail at post time, with a generic Slack error.
+type EmojiCatalog interface {
+	// EmojiExists returns true if the emoji (without colons, e.g. 'my-custom-emoji') is a standard Slack emoji
+	// or a custom emoji of the workspace. It should return promptly when the context is canceled.
+	EmojiExists(ctx context.Context, name string) (bool, error)
+}
+
+// InMemoryEmojiCatalog is an in-memory implementation of the EmojiCatalog interface, holding a fixed set of emoji
+// names. It is safe for concurrent use.
+type InMemoryEmojiCatalog struct {
+	mu     sync.RWMutex
+	emojis map[string]struct{}
+}
+
+// NewInMemoryEmojiCatalog creates a new InMemoryEmojiCatalog with the specified emoji names, with or without colons.
+func NewInMemoryEmojiCatalog(names ...string) *InMemoryEmojiCatalog {
+	c := &InMemoryEmojiCatalog{
+		emojis: make(map[string]struct{}, len(names)),
+	}
+
+	c.Add(names...)
+
+	return c
+}
+
+// Add adds the specified emoji names, with or without colons, to the catalog.
+func (c *InMemoryEmojiCatalog) Add(names ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, name := range names {
+		if name = normalizeEmojiName(name); name != "" {
+			c.emojis[name] = struct{}{}
+		}
+	}
+}
+
+// EmojiExists returns true if the emoji name, with or without colons, is in the catalog.
+func (c *InMemoryEmojiCatalog) EmojiExists(_ context.Context, name string) (bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.emojis[normalizeEmojiName(name)]
+
+	return ok, nil
+}
+
+// EmojiValidator returns an AlertValidator verifying that the IconEmoji of alerts exists in the catalog (see
+// Alert.ValidateIconContext).
+func EmojiValidator(catalog EmojiCatalog) AlertValidator {
+	return AlertValidatorFunc(func(ctx context.Context, alert *Alert) error {
+		return alert.ValidateIconContext(ctx, catalog)
+	})
+}
+
+// ValidateIconContext runs ValidateIcon, and then verifies that an IconEmoji on the format ':emoji:' exists in the
+// catalog. Unicode emojis are passed through without a lookup. The catalog may be nil, in which case only ValidateIcon is run.
+func (a *Alert) ValidateIconContext(ctx context.Context, catalog EmojiCatalog) error {
+	if err := a.ValidateIcon(); err != nil {
+		return err
+	}
+
+	if a.IconEmoji == "" || catalog == nil || !IconRegex.MatchString(a.IconEmoji) {
+		return nil
+	}
+
+	exists, err := catalog.EmojiExists(ctx, strings.Trim(a.IconEmoji, ":"))
+	if err != nil {
+		return fmt.Errorf("failed to look up iconEmoji '%s': %w", a.IconEmoji, err)
+	}
+
+	if !exists {
+		return fmt.Errorf("iconEmoji '%s' does not exist in the workspace", a.IconEmoji)
+	}
+
+	return nil
+}
+
+// IsUnicodeEmoji returns true if s is a single unicode emoji, such as '🔥', '👍🏽', '🇳🇴' or a ZWJ sequence such as
+// '👩‍💻'. It is a structural check of the emoji code point ranges, modifiers and joiners, not a lookup in the
+// unicode emoji list.
+func IsUnicodeEmoji(s string) bool {
+	if s == "" || !utf8.ValidString(s) || utf8.RuneCountInString(s) > maxUnicodeEmojiRunes {
+		return false
+	}
+
+	pictographic := false
+
+	for _, r := range s {
+		switch {
+		case isEmojiPictographic(r):
+			pictographic = true
+		case isEmojiComponent(r):
+		default:
+			return false
+		}
+	}
+
+	return pictographic
+}
+
+// isEmojiPictographic returns true if r is in one of the unicode blocks of pictographic emojis.
+func isEmojiPictographic(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // Mahjong tiles through Symbols and Pictographs Extended-A, incl. regional indicators
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous Symbols, Dingbats
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // Miscellaneous Technical, e.g. ⌚ and ⏰
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // Miscellaneous Symbols and Arrows, e.g. ⭐ and ⬛
+		return true
+	case r == 0x20E3: // Combining enclosing keycap, e.g. 1️⃣
+		return true
+	case r == 0x00A9, r == 0x00AE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139, r == 0x3030, r == 0x303D,
+		r == 0x3297, r == 0x3299:
+		return true
+	case r >= 0x2194 && r <= 0x21AA: // Arrows
+		return true
+	case r >= 0x25AA && r <= 0x25FE: // Geometric Shapes
+		return true
+	case r >= 0x2934 && r <= 0x2935:
+		return true
+	}
+
+	return false
+}
+
+// isEmojiComponent returns true if r can be part of an emoji sequence: joiners, variation selectors, skin tone
+// modifiers (which are also pictographic), tags, and the digits and symbols of keycap sequences.
+func isEmojiComponent(r rune) bool {
+	switch {
+	case r == 0x200D, r == 0xFE0F, r == 0xFE0E:
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // Tags, used by subdivision flags
+		return true
+	case r == '#', r == '*', r >= '0' && r <= '9':
+		return true
+	}
+
+	return false
+}
+
+func normalizeEmojiName(name string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(name), ":"))
+}
@@ -0,0 +1,86 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type emojiCatalogFunc func(ctx context.Context, name string) (bool, error)
+
+func (f emojiCatalogFunc) EmojiExists(ctx context.Context, name string) (bool, error) {
+	return f(ctx, name)
+}
+
+func TestIsUnicodeEmoji(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"🔥", "👍🏽", "🇳🇴", "👩‍💻", "❤️", "⭐", "1️⃣", "🏴󠁧󠁢󠁳󠁣󠁴󠁿"} {
+		assert.True(t, types.IsUnicodeEmoji(s), s)
+	}
+
+	for _, s := range []string{"", "fire", ":fire:", "1", "#", "🔥 fire", "日本", "\xff", "🔥🔥🔥🔥🔥🔥🔥🔥🔥🔥🔥🔥🔥🔥🔥🔥🔥"} {
+		assert.False(t, types.IsUnicodeEmoji(s), s)
+	}
+}
+
+func TestAlertValidateIconContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	catalog := types.NewInMemoryEmojiCatalog("fire", ":My-Custom-Emoji:")
+
+	newAlert := func(icon string) *types.Alert {
+		a := types.NewErrorAlert()
+		a.SlackChannelID = "C12345678"
+		a.Header = "Disk full"
+		a.IconEmoji = icon
+		a.Clean()
+
+		return a
+	}
+
+	t.Run("known and unicode emojis should be valid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, icon := range []string{"", ":fire:", ":my-custom-emoji:", "🔥", "👩‍💻"} {
+			a := newAlert(icon)
+			require.NoError(t, a.Validate(), icon)
+			require.NoError(t, a.ValidateIconContext(ctx, catalog), icon)
+			require.NoError(t, a.ValidateIconContext(ctx, nil), icon)
+		}
+	})
+
+	t.Run("unknown emojis should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(":typo:")
+		require.NoError(t, a.Validate())
+		require.EqualError(t, a.ValidateIconContext(ctx, catalog), "iconEmoji ':typo:' does not exist in the workspace")
+		require.EqualError(t, a.ValidateContext(ctx, &types.ValidateOptions{EmojiCatalog: catalog}), "iconEmoji ':typo:' does not exist in the workspace")
+
+		require.EqualError(t, newAlert("fire").ValidateIconContext(ctx, catalog), "iconEmoji 'fire' is not valid")
+
+		failing := emojiCatalogFunc(func(context.Context, string) (bool, error) { return false, errors.New("rate limited") })
+		require.EqualError(t, types.EmojiValidator(failing).ValidateAlert(ctx, newAlert(":fire:")), "failed to look up iconEmoji ':fire:': rate limited")
+	})
+
+	t.Run("catalog lookups should be case-insensitive", func(t *testing.T) {
+		t.Parallel()
+
+		exists, err := catalog.EmojiExists(ctx, "MY-CUSTOM-EMOJI")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		catalog := types.NewInMemoryEmojiCatalog()
+		catalog.Add(" :new: ", "")
+
+		exists, err = catalog.EmojiExists(ctx, "new")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
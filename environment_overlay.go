@@ -0,0 +1,305 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxEnvironmentOverlayRewriteCount is the maximum number of channel rewrites, and of route key rewrites, of an overlay.
+const MaxEnvironmentOverlayRewriteCount = 500
+
+// EnvironmentOverlay rewrites the Slack channels and route keys of alerts for a single environment,
+// so that the same alert definitions can be pointed at e.g. staging channels in non-production deployments.
+// Use Alert.ApplyOverlay to apply it, typically just before sending the alert.
+//
+// Channel IDs (and names, with or without a leading '#') and route keys are matched case-insensitively. DefaultChannel and RouteKeyPrefix do not apply
+// to the targets of Channels and RouteKeys, so that applying an overlay to an already rewritten alert is harmless.
+type EnvironmentOverlay struct {
+	// Channels maps Slack channel IDs or names to the channel to use instead.
+	// Maximum of MaxEnvironmentOverlayRewriteCount entries.
+	Channels map[string]string `json:"channels"`
+
+	// DefaultChannel is an optional Slack channel ID or name used instead of any channel not found in Channels.
+	// This makes sure that no alert reaches a production channel by accident.
+	DefaultChannel string `json:"defaultChannel"`
+
+	// RouteKeys maps route keys to the route key to use instead.
+	// Maximum of MaxEnvironmentOverlayRewriteCount entries.
+	RouteKeys map[string]string `json:"routeKeys"`
+
+	// RouteKeyPrefix is an optional prefix (such as 'staging.') prepended to route keys not found in RouteKeys.
+	// Route keys already starting with the prefix are not changed.
+	RouteKeyPrefix string `json:"routeKeyPrefix"`
+}
+
+// EnvironmentOverlays maps environment names (such as 'staging') to overlays.
+type EnvironmentOverlays map[string]*EnvironmentOverlay
+
+// ParseEnvironmentOverlays parses and validates environment overlays in YAML (or JSON), on the format:
+//
+//	staging:
+//	  channels:
+//	    C0PAYMENTS: C0STAGINGPAYMENTS
+//	  defaultChannel: C0STAGING
+//	  routeKeyPrefix: staging.
+func ParseEnvironmentOverlays(data []byte) (EnvironmentOverlays, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse environment overlays YAML: %w", err)
+	}
+
+	// Convert to JSON, to reuse the JSON field names.
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert environment overlays YAML to JSON: %w", err)
+	}
+
+	var overlays EnvironmentOverlays
+
+	if err := json.Unmarshal(body, &overlays); err != nil {
+		return nil, fmt.Errorf("failed to decode environment overlays: %w", err)
+	}
+
+	if err := overlays.Validate(); err != nil {
+		return nil, err
+	}
+
+	return overlays, nil
+}
+
+// Validate returns an error if one or more of the overlays are invalid.
+func (o EnvironmentOverlays) Validate() error {
+	envs := make([]string, 0, len(o))
+
+	for env := range o {
+		envs = append(envs, env)
+	}
+
+	// Sort, to report the same error for the same overlays.
+	slices.Sort(envs)
+
+	for _, env := range envs {
+		if strings.TrimSpace(env) == "" {
+			return errors.New("environment name cannot be empty")
+		}
+
+		if o[env] == nil {
+			return fmt.Errorf("environment '%s' cannot be null", env)
+		}
+
+		if err := o[env].Validate(); err != nil {
+			return fmt.Errorf("environment '%s': %w", env, err)
+		}
+	}
+
+	return nil
+}
+
+// Get returns the overlay of the environment (case-insensitive), or nil if there is none.
+func (o EnvironmentOverlays) Get(env string) *EnvironmentOverlay {
+	if overlay, ok := o[env]; ok {
+		return overlay
+	}
+
+	env = strings.TrimSpace(env)
+
+	for name, overlay := range o {
+		if strings.EqualFold(strings.TrimSpace(name), env) {
+			return overlay
+		}
+	}
+
+	return nil
+}
+
+// Validate returns an error if one or more of the fields are invalid.
+func (o *EnvironmentOverlay) Validate() error {
+	if o == nil {
+		return errors.New("environment overlay is nil")
+	}
+
+	if len(o.Channels) > MaxEnvironmentOverlayRewriteCount {
+		return fmt.Errorf("too many channels, expected <=%d", MaxEnvironmentOverlayRewriteCount)
+	}
+
+	if key, ok := duplicateKeyFold(o.Channels); ok {
+		return fmt.Errorf("channels: '%s' is defined more than once", key)
+	}
+
+	for from, to := range o.Channels {
+		if !SlackChannelIDOrNameRegex.MatchString(strings.TrimSpace(from)) {
+			return fmt.Errorf("channels: '%s' is not a valid Slack channel ID or name", from)
+		}
+
+		if !SlackChannelIDOrNameRegex.MatchString(strings.TrimSpace(to)) {
+			return fmt.Errorf("channels['%s']: '%s' is not a valid Slack channel ID or name", from, to)
+		}
+	}
+
+	if o.DefaultChannel != "" && !SlackChannelIDOrNameRegex.MatchString(strings.TrimSpace(o.DefaultChannel)) {
+		return fmt.Errorf("defaultChannel '%s' is not valid", o.DefaultChannel)
+	}
+
+	if len(o.RouteKeys) > MaxEnvironmentOverlayRewriteCount {
+		return fmt.Errorf("too many routeKeys, expected <=%d", MaxEnvironmentOverlayRewriteCount)
+	}
+
+	if key, ok := duplicateKeyFold(o.RouteKeys); ok {
+		return fmt.Errorf("routeKeys: '%s' is defined more than once", key)
+	}
+
+	for from, to := range o.RouteKeys {
+		if strings.TrimSpace(from) == "" {
+			return errors.New("routeKeys: route key cannot be empty")
+		}
+
+		if strings.TrimSpace(to) == "" {
+			return fmt.Errorf("routeKeys['%s']: route key cannot be empty", from)
+		}
+
+		if len(strings.TrimSpace(to)) > MaxRouteKeyLength {
+			return fmt.Errorf("routeKeys['%s']: route key is too long, expected length <=%d", from, MaxRouteKeyLength)
+		}
+	}
+
+	if len(strings.TrimSpace(o.RouteKeyPrefix)) > MaxRouteKeyLength {
+		return fmt.Errorf("routeKeyPrefix is too long, expected length <=%d", MaxRouteKeyLength)
+	}
+
+	return nil
+}
+
+// ApplyOverlay rewrites the Slack channel ID, route key, escalation channels and rejection channel of the alert,
+// using the overlay of an environment. It returns true if the alert was changed.
+// A nil overlay (such as the overlay of a production environment) leaves the alert unchanged.
+//
+// The overlay should be valid (see EnvironmentOverlay.Validate). Call Clean after ApplyOverlay, to normalize
+// the rewritten values.
+func (a *Alert) ApplyOverlay(env *EnvironmentOverlay) bool {
+	if env == nil {
+		return false
+	}
+
+	changed := false
+
+	rewrite := func(value *string, rewritten string) {
+		if rewritten != *value {
+			*value = rewritten
+			changed = true
+		}
+	}
+
+	if a.SlackChannelID != "" {
+		rewrite(&a.SlackChannelID, env.channel(a.SlackChannelID))
+	}
+
+	if a.RouteKey != "" {
+		rewrite(&a.RouteKey, env.routeKey(a.RouteKey))
+	}
+
+	for _, e := range a.Escalation {
+		if e != nil && e.MoveToChannel != "" {
+			rewrite(&e.MoveToChannel, env.channel(e.MoveToChannel))
+		}
+	}
+
+	if a.RejectionTarget != nil && a.RejectionTarget.SlackChannelID != "" {
+		rewrite(&a.RejectionTarget.SlackChannelID, env.channel(a.RejectionTarget.SlackChannelID))
+	}
+
+	return changed
+}
+
+// channel returns the rewritten channel. Channels and the keys and values of Channels are compared after
+// NormalizeChannel, since ApplyOverlay runs before Clean, so that '#alerts' matches a key 'alerts'.
+func (o *EnvironmentOverlay) channel(channel string) string {
+	normalized := NormalizeChannel(channel)
+
+	for from, to := range o.Channels {
+		if strings.EqualFold(NormalizeChannel(from), normalized) {
+			return strings.TrimSpace(to)
+		}
+	}
+
+	if o.DefaultChannel == "" {
+		return channel
+	}
+
+	for _, to := range o.Channels {
+		if strings.EqualFold(NormalizeChannel(to), normalized) {
+			return channel
+		}
+	}
+
+	return strings.TrimSpace(o.DefaultChannel)
+}
+
+func (o *EnvironmentOverlay) routeKey(routeKey string) string {
+	if rewritten, ok := lookupFold(o.RouteKeys, routeKey); ok {
+		return rewritten
+	}
+
+	prefix := strings.TrimSpace(o.RouteKeyPrefix)
+
+	if prefix == "" || isValueFold(o.RouteKeys, routeKey) || strings.HasPrefix(strings.ToLower(strings.TrimSpace(routeKey)), strings.ToLower(prefix)) {
+		return routeKey
+	}
+
+	return prefix + strings.TrimSpace(routeKey)
+}
+
+// lookupFold returns the (trimmed) value of the key in m, matching keys case-insensitively.
+func lookupFold(m map[string]string, key string) (string, bool) {
+	key = strings.TrimSpace(key)
+
+	for k, v := range m {
+		if strings.EqualFold(strings.TrimSpace(k), key) {
+			return strings.TrimSpace(v), true
+		}
+	}
+
+	return "", false
+}
+
+// isValueFold returns true if value is one of the (trimmed) values of m, ignoring case.
+func isValueFold(m map[string]string, value string) bool {
+	value = strings.TrimSpace(value)
+
+	for _, v := range m {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// duplicateKeyFold returns the first (sorted) key of m equal to another key, ignoring case and surrounding whitespace.
+func duplicateKeyFold(m map[string]string) (string, bool) {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	seen := make(map[string]struct{}, len(keys))
+
+	for _, k := range keys {
+		normalized := strings.ToLower(strings.TrimSpace(k))
+
+		if _, ok := seen[normalized]; ok {
+			return k, true
+		}
+
+		seen[normalized] = struct{}{}
+	}
+
+	return "", false
+}
@@ -0,0 +1,149 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertApplyOverlay(t *testing.T) {
+	t.Parallel()
+
+	overlay := &types.EnvironmentOverlay{
+		Channels:       map[string]string{"c0payments": "C0STAGINGPAYMENTS", "alerts": "staging-alerts"},
+		DefaultChannel: "C0STAGING",
+		RouteKeys:      map[string]string{"DB.Orders": "staging.orders"},
+		RouteKeyPrefix: "staging.",
+	}
+	require.NoError(t, overlay.Validate())
+
+	t.Run("channels should be rewritten, or use the default channel", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{
+			SlackChannelID:  "C0PAYMENTS",
+			Escalation:      []*types.Escalation{{MoveToChannel: "C0INCIDENTS"}, {Severity: types.AlertPanic}},
+			RejectionTarget: &types.RejectionTarget{SlackChannelID: "C0PAYMENTS"},
+		}
+
+		assert.True(t, a.ApplyOverlay(overlay))
+		assert.Equal(t, "C0STAGINGPAYMENTS", a.SlackChannelID)
+		assert.Equal(t, "C0STAGING", a.Escalation[0].MoveToChannel)
+		assert.Empty(t, a.Escalation[1].MoveToChannel)
+		assert.Equal(t, "C0STAGINGPAYMENTS", a.RejectionTarget.SlackChannelID)
+		assert.Empty(t, a.RouteKey)
+
+		assert.False(t, a.ApplyOverlay(overlay), "applying the overlay again should not change the alert")
+		assert.Equal(t, "C0STAGINGPAYMENTS", a.SlackChannelID)
+	})
+
+	t.Run("channel names with a leading '#' should match the overlay", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{SlackChannelID: "#alerts"}
+		assert.True(t, a.ApplyOverlay(overlay))
+		assert.Equal(t, "staging-alerts", a.SlackChannelID)
+
+		a = &types.Alert{SlackChannelID: " #staging-alerts"}
+		assert.False(t, a.ApplyOverlay(overlay), "a rewritten channel should not be replaced with the default channel")
+
+		a = &types.Alert{SlackChannelID: "#other"}
+		assert.True(t, a.ApplyOverlay(overlay))
+		assert.Equal(t, "C0STAGING", a.SlackChannelID)
+	})
+
+	t.Run("route keys should be rewritten, or prefixed", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{RouteKey: "db.orders"}
+		assert.True(t, a.ApplyOverlay(overlay))
+		assert.Equal(t, "staging.orders", a.RouteKey)
+
+		a = &types.Alert{RouteKey: "payments"}
+		assert.True(t, a.ApplyOverlay(overlay))
+		assert.Equal(t, "staging.payments", a.RouteKey)
+		assert.False(t, a.ApplyOverlay(overlay))
+		assert.Equal(t, "staging.payments", a.RouteKey)
+	})
+
+	t.Run("nil or empty overlay should not change the alert", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{SlackChannelID: "C0PAYMENTS", RouteKey: "payments"}
+		assert.False(t, a.ApplyOverlay(nil))
+		assert.False(t, a.ApplyOverlay(&types.EnvironmentOverlay{}))
+		assert.Equal(t, "C0PAYMENTS", a.SlackChannelID)
+		assert.Equal(t, "payments", a.RouteKey)
+	})
+}
+
+func TestParseEnvironmentOverlays(t *testing.T) {
+	t.Parallel()
+
+	overlays, err := types.ParseEnvironmentOverlays([]byte(`
+staging:
+  channels:
+    C0PAYMENTS: C0STAGINGPAYMENTS
+  defaultChannel: C0STAGING
+  routeKeyPrefix: staging.
+production: {}
+`))
+	require.NoError(t, err)
+	require.Len(t, overlays, 2)
+
+	staging := overlays.Get(" Staging ")
+	require.NotNil(t, staging)
+	assert.Equal(t, "C0STAGING", staging.DefaultChannel)
+	assert.Equal(t, "staging.", staging.RouteKeyPrefix)
+	assert.Nil(t, overlays.Get("dev"))
+
+	a := &types.Alert{SlackChannelID: "C0PAYMENTS"}
+	assert.True(t, a.ApplyOverlay(staging))
+	assert.False(t, a.ApplyOverlay(overlays.Get("production")))
+	assert.False(t, a.ApplyOverlay(overlays.Get("dev")))
+	assert.Equal(t, "C0STAGINGPAYMENTS", a.SlackChannelID)
+
+	_, err = types.ParseEnvironmentOverlays([]byte("staging: ["))
+	require.ErrorContains(t, err, "failed to parse environment overlays YAML")
+
+	_, err = types.ParseEnvironmentOverlays([]byte("staging: [1]"))
+	require.ErrorContains(t, err, "failed to decode environment overlays")
+
+	_, err = types.ParseEnvironmentOverlays([]byte("staging:\n  defaultChannel: '#staging'"))
+	require.EqualError(t, err, "environment 'staging': defaultChannel '#staging' is not valid")
+}
+
+func TestEnvironmentOverlayValidate(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make(map[string]string)
+	for i := range types.MaxEnvironmentOverlayRewriteCount + 1 {
+		tooMany[strings.Repeat("x", i+1)] = "y"
+	}
+
+	tests := []struct {
+		name     string
+		overlay  *types.EnvironmentOverlay
+		expected string
+	}{
+		{"nil overlay", nil, "environment overlay is nil"},
+		{"invalid source channel", &types.EnvironmentOverlay{Channels: map[string]string{"#a": "C0B"}}, "channels: '#a' is not a valid Slack channel ID or name"},
+		{"invalid target channel", &types.EnvironmentOverlay{Channels: map[string]string{"C0A": ""}}, "channels['C0A']: '' is not a valid Slack channel ID or name"},
+		{"duplicate channel", &types.EnvironmentOverlay{Channels: map[string]string{"C0A": "C0B", "c0a": "C0C"}}, "channels: 'c0a' is defined more than once"},
+		{"too many channels", &types.EnvironmentOverlay{Channels: tooMany}, "too many channels, expected <=500"},
+		{"empty route key", &types.EnvironmentOverlay{RouteKeys: map[string]string{" ": "b"}}, "routeKeys: route key cannot be empty"},
+		{"empty target route key", &types.EnvironmentOverlay{RouteKeys: map[string]string{"a": " "}}, "routeKeys['a']: route key cannot be empty"},
+		{"duplicate route key", &types.EnvironmentOverlay{RouteKeys: map[string]string{"a": "b", " A": "c"}}, "routeKeys: 'a' is defined more than once"},
+		{"too long prefix", &types.EnvironmentOverlay{RouteKeyPrefix: strings.Repeat("x", types.MaxRouteKeyLength+1)}, "routeKeyPrefix is too long, expected length <=1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.EqualError(t, tt.overlay.Validate(), tt.expected)
+		})
+	}
+}
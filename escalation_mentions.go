@@ -0,0 +1,126 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MentionAliasRegex matches mention aliases in escalation Slack mentions, on the format 'alias:name', such as 'alias:oncall-db'.
+// Aliases are expanded to concrete Slack mentions by the Slack Manager, using Alert.ExpandMentionAliases.
+var MentionAliasRegex = regexp.MustCompile(`^alias:[a-zA-Z0-9][a-zA-Z0-9\-_.]{0,99}$`)
+
+// MentionAliases maps alias names (without the 'alias:' prefix) to concrete Slack mentions,
+// for example "oncall-db" -> ["<@U12345678>", "<!subteam^S12345678>"].
+type MentionAliases map[string][]string
+
+// ExpandMentionAliases replaces all mention aliases in the escalation points and the notification policy with
+// the corresponding Slack mentions. Identical mentions are then deduplicated across the escalation points, and the
+// MaxEscalationSlackMentionCount and MaxNotificationPolicyMentionCount limits are enforced on the expanded mentions.
+//
+// An error is returned if an alias is not defined, if an alias expands to an invalid Slack mention,
+// or if an escalation point or mention list has too many mentions after expansion.
+func (a *Alert) ExpandMentionAliases(aliases MentionAliases) error {
+	for index, e := range a.Escalation {
+		if e == nil {
+			continue
+		}
+
+		expanded, err := expandMentionAliases(fmt.Sprintf("escalation[%d].slackMentions", index), e.SlackMentions, aliases)
+		if err != nil {
+			return err
+		}
+
+		e.SlackMentions = expanded
+	}
+
+	dedupeEscalationMentions(a.Escalation)
+
+	for index, e := range a.Escalation {
+		if e != nil && len(e.SlackMentions) > MaxEscalationSlackMentionCount {
+			return fmt.Errorf("escalation[%d].slackMentions item count is too large after alias expansion, expected <=%d", index, MaxEscalationSlackMentionCount)
+		}
+	}
+
+	if p := a.NotificationPolicy; p != nil {
+		var err error
+
+		if p.MentionsOnCreate, err = expandMentionAliases("notificationPolicy.mentionsOnCreate", p.MentionsOnCreate, aliases); err != nil {
+			return err
+		}
+
+		if p.MentionsOnEscalate, err = expandMentionAliases("notificationPolicy.mentionsOnEscalate", p.MentionsOnEscalate, aliases); err != nil {
+			return err
+		}
+
+		p.MentionsOnCreate = cleanNotificationPolicyMentions(p.MentionsOnCreate)
+		p.MentionsOnEscalate = cleanNotificationPolicyMentions(p.MentionsOnEscalate)
+
+		if len(p.MentionsOnCreate) > MaxNotificationPolicyMentionCount || len(p.MentionsOnEscalate) > MaxNotificationPolicyMentionCount {
+			return fmt.Errorf("notificationPolicy mention count is too large after alias expansion, expected <=%d", MaxNotificationPolicyMentionCount)
+		}
+	}
+
+	return nil
+}
+
+// expandMentionAliases returns the mentions with all aliases replaced by the corresponding Slack mentions.
+// The name of the mention list is used as prefix in errors, as in 'escalation[0].slackMentions'.
+func expandMentionAliases(name string, mentions []string, aliases MentionAliases) ([]string, error) {
+	if mentions == nil {
+		return nil, nil
+	}
+
+	expanded := make([]string, 0, len(mentions))
+
+	for j, mention := range mentions {
+		if !strings.HasPrefix(mention, "alias:") {
+			expanded = append(expanded, mention)
+			continue
+		}
+
+		aliasName := strings.TrimPrefix(mention, "alias:")
+
+		targets, ok := aliases[aliasName]
+		if !ok {
+			return nil, fmt.Errorf("%s[%d] alias '%s' is not defined", name, j, aliasName)
+		}
+
+		for _, target := range targets {
+			target = strings.TrimSpace(target)
+
+			if !SlackMentionRegex.MatchString(target) {
+				return nil, fmt.Errorf("%s[%d] alias '%s' contains an invalid Slack mention '%s'", name, j, aliasName, target)
+			}
+
+			expanded = append(expanded, target)
+		}
+	}
+
+	return expanded, nil
+}
+
+// dedupeEscalationMentions removes mentions already present in the same or a previous escalation point,
+// since mentions from earlier escalations have already been notified. The escalation points must be sorted by delay.
+func dedupeEscalationMentions(escalations []*Escalation) {
+	seen := make(map[string]struct{})
+
+	for _, e := range escalations {
+		if e == nil || len(e.SlackMentions) == 0 {
+			continue
+		}
+
+		mentions := make([]string, 0, len(e.SlackMentions))
+
+		for _, mention := range e.SlackMentions {
+			if _, ok := seen[mention]; ok {
+				continue
+			}
+
+			seen[mention] = struct{}{}
+			mentions = append(mentions, mention)
+		}
+
+		e.SlackMentions = mentions
+	}
+}
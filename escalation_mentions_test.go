@@ -0,0 +1,96 @@
+package types_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEscalationAlert(mentions ...[]string) *types.Alert {
+	a := &types.Alert{Header: "a", RouteKey: "b", Severity: types.AlertWarning}
+
+	for i, m := range mentions {
+		a.Escalation = append(a.Escalation, &types.Escalation{
+			Severity:      types.AlertError,
+			DelaySeconds:  types.MinEscalationDelaySeconds + i*types.MinEscalationDelayDiffSeconds,
+			SlackMentions: m,
+		})
+	}
+
+	return a
+}
+
+func TestEscalationMentionDeduplication(t *testing.T) {
+	t.Parallel()
+
+	a := newEscalationAlert(
+		[]string{"<@U1>", " <@U1> ", "<!here>"},
+		[]string{"<@U1>", "<@U2>", "<!here>"},
+		[]string{"<@U2>"},
+	)
+	a.Clean()
+	require.NoError(t, a.Validate())
+
+	assert.Equal(t, []string{"<@U1>", "<!here>"}, a.Escalation[0].SlackMentions)
+	assert.Equal(t, []string{"<@U2>"}, a.Escalation[1].SlackMentions)
+	assert.Empty(t, a.Escalation[2].SlackMentions)
+}
+
+func TestExpandMentionAliases(t *testing.T) {
+	t.Parallel()
+
+	aliases := types.MentionAliases{
+		"oncall-db": {"<@U1>", "<@U4>"},
+		"lead":      {"<@U1>"},
+		"broken":    {"U1"},
+	}
+
+	t.Run("aliases should be expanded and deduplicated", func(t *testing.T) {
+		t.Parallel()
+
+		a := newEscalationAlert([]string{"alias:oncall-db", "<@U2>"}, []string{"alias:lead", "<@U3>"})
+		a.Clean()
+		require.NoError(t, a.Validate())
+		require.NoError(t, a.ExpandMentionAliases(aliases))
+
+		assert.Equal(t, []string{"<@U1>", "<@U4>", "<@U2>"}, a.Escalation[0].SlackMentions)
+		assert.Equal(t, []string{"<@U3>"}, a.Escalation[1].SlackMentions)
+	})
+
+	t.Run("undefined alias should fail", func(t *testing.T) {
+		t.Parallel()
+
+		a := newEscalationAlert([]string{"alias:unknown"})
+		require.ErrorContains(t, a.ExpandMentionAliases(aliases), "escalation[0].slackMentions[0] alias 'unknown' is not defined")
+	})
+
+	t.Run("invalid alias target should fail", func(t *testing.T) {
+		t.Parallel()
+
+		a := newEscalationAlert([]string{"alias:broken"})
+		require.ErrorContains(t, a.ExpandMentionAliases(aliases), "contains an invalid Slack mention 'U1'")
+	})
+
+	t.Run("mention cap should be enforced after expansion", func(t *testing.T) {
+		t.Parallel()
+
+		many := make([]string, types.MaxEscalationSlackMentionCount)
+		for i := range many {
+			many[i] = fmt.Sprintf("<@U%d>", 100+i)
+		}
+
+		a := newEscalationAlert([]string{"alias:many"})
+		require.ErrorContains(t, a.ExpandMentionAliases(types.MentionAliases{"many": append(many, "<@U999>")}), "item count is too large after alias expansion")
+	})
+
+	t.Run("invalid alias syntax should fail validation", func(t *testing.T) {
+		t.Parallel()
+
+		a := newEscalationAlert([]string{"alias:"})
+		a.Clean()
+		require.ErrorContains(t, a.Validate(), "escalation[0].slackMentions[0] is not valid")
+	})
+}
@@ -0,0 +1,249 @@
+// Package export defines the portable format of issue exports: a bundle with the issue summary, all alerts, events,
+// escalations and webhook clicks, with timestamps, for attaching to postmortem documents.
+//
+// The Slack Manager owns the issue and its events, and fills in the bundle. This package owns the serialization
+// contract, as JSON (Bundle.JSON and Decode) and Markdown (Bundle.Markdown), and the redaction of sensitive values
+// (Bundle.Redact), which should be applied before a bundle leaves the system:
+//
+//	redactor, _ := export.NewRedactor(export.RedactionConfig{})
+//	redacted, _ := bundle.Redact(redactor)
+//	doc := redacted.Markdown()
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// FormatVersion is the current version of the export format. Decode rejects bundles with a higher version.
+const FormatVersion = 1
+
+// Bundle is the export of a single issue.
+type Bundle struct {
+	// Version is the export format version. NewBundle sets it to FormatVersion.
+	Version int `json:"version"`
+
+	// ExportedAt is the time the bundle was created.
+	ExportedAt time.Time `json:"exportedAt"`
+
+	// Redacted is true if sensitive values have been replaced, by Bundle.Redact.
+	Redacted bool `json:"redacted"`
+
+	// Issue is the summary of the issue.
+	Issue IssueSummary `json:"issue"`
+
+	// Alerts are all alerts received for the issue, in the order they were received.
+	Alerts []AlertRecord `json:"alerts"`
+
+	// Events are the lifecycle events of the issue.
+	Events []Event `json:"events"`
+
+	// Escalations are the escalation points triggered for the issue.
+	Escalations []EscalationRecord `json:"escalations"`
+
+	// WebhookClicks are the webhook button clicks on the Slack post of the issue.
+	WebhookClicks []WebhookClick `json:"webhookClicks"`
+}
+
+// IssueSummary holds the identity and status of the exported issue.
+type IssueSummary struct {
+	// ID is the unique ID of the issue, as returned by types.Issue.UniqueID. This field is required.
+	ID string `json:"id"`
+
+	// SlackChannelID is the Slack channel ID of the issue.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// CorrelationID is the correlation ID of the issue.
+	CorrelationID string `json:"correlationId"`
+
+	// GlobalIssueKey is the global issue key of the issue, if any.
+	GlobalIssueKey string `json:"globalIssueKey"`
+
+	// Header is the current header of the issue, as shown in Slack.
+	Header string `json:"header"`
+
+	// Severity is the current severity of the issue.
+	Severity types.AlertSeverity `json:"severity"`
+
+	// Open is true if the issue is not archived.
+	Open bool `json:"open"`
+
+	// Resolution is the outcome of the issue, if resolved.
+	Resolution types.Resolution `json:"resolution"`
+
+	// SlackPermalink is the link to the Slack post of the issue, if any.
+	SlackPermalink string `json:"slackPermalink"`
+
+	// CreatedAt is the time the issue was created. ResolvedAt and ArchivedAt are zero if not resolved or archived.
+	CreatedAt  time.Time `json:"createdAt"`
+	ResolvedAt time.Time `json:"resolvedAt"`
+	ArchivedAt time.Time `json:"archivedAt"`
+}
+
+// AlertRecord is an alert received for the issue.
+type AlertRecord struct {
+	// ReceivedAt is the time the alert was received by the Slack Manager.
+	ReceivedAt time.Time `json:"receivedAt"`
+
+	// Alert is the alert, as received.
+	Alert *types.Alert `json:"alert"`
+}
+
+// Event is an issue lifecycle event. The issue fields of types.IssueEvent are omitted, as they are those of the bundle.
+type Event struct {
+	// Time is the time of the event.
+	Time time.Time `json:"time"`
+
+	// Type is the type of the event. Valid values are defined by types.IssueEventType constants.
+	Type types.IssueEventType `json:"type"`
+
+	// Actor is the name of the user or system causing the event, if any.
+	Actor string `json:"actor"`
+
+	// Message is an optional description of the event, such as a resolve note, or the target channel of a move.
+	Message string `json:"message"`
+}
+
+// EscalationRecord is a triggered escalation point (see types.Escalation).
+type EscalationRecord struct {
+	// Time is the time the escalation was triggered.
+	Time time.Time `json:"time"`
+
+	// Severity is the severity of the issue after the escalation.
+	Severity types.AlertSeverity `json:"severity"`
+
+	// SlackMentions are the mentions added to the Slack post.
+	SlackMentions []string `json:"slackMentions"`
+
+	// MoveToChannel is the channel the issue was moved to, if any.
+	MoveToChannel string `json:"moveToChannel"`
+}
+
+// WebhookClick is a webhook button click on the Slack post of the issue (see types.WebhookCallback).
+type WebhookClick struct {
+	// Time is the time of the click.
+	Time time.Time `json:"time"`
+
+	// WebhookID is the ID of the clicked webhook.
+	WebhookID string `json:"webhookId"`
+
+	// ButtonText is the text of the clicked button.
+	ButtonText string `json:"buttonText"`
+
+	// UserID and UserRealName identify the Slack user who clicked.
+	UserID       string `json:"userId"`
+	UserRealName string `json:"userRealName"`
+
+	// Input is the form input submitted with the click, if any.
+	Input map[string]string `json:"input"`
+
+	// Status is the status of the webhook response, if any.
+	Status types.WebhookResponseStatus `json:"status"`
+
+	// Message is the message of the webhook response, if any.
+	Message string `json:"message"`
+}
+
+// NewBundle returns an empty bundle for the issue, with the issue summary fields known by the types.Issue interface.
+func NewBundle(issue types.Issue, exportedAt time.Time) *Bundle {
+	b := &Bundle{
+		Version:       FormatVersion,
+		ExportedAt:    exportedAt.UTC(),
+		Alerts:        []AlertRecord{},
+		Events:        []Event{},
+		Escalations:   []EscalationRecord{},
+		WebhookClicks: []WebhookClick{},
+	}
+
+	if issue != nil {
+		b.Issue.ID = issue.UniqueID()
+		b.Issue.SlackChannelID = issue.ChannelID()
+		b.Issue.CorrelationID = issue.GetCorrelationID()
+		b.Issue.Open = issue.IsOpen()
+	}
+
+	return b
+}
+
+// Decode decodes and validates a JSON bundle.
+func Decode(data []byte) (*Bundle, error) {
+	b := &Bundle{}
+
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("failed to decode issue export: %w", err)
+	}
+
+	if err := b.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid issue export: %w", err)
+	}
+
+	return b, nil
+}
+
+// JSON returns the bundle as indented JSON.
+func (b *Bundle) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode issue export: %w", err)
+	}
+
+	return data, nil
+}
+
+// Validate returns an error if the bundle is invalid.
+func (b *Bundle) Validate() error {
+	if b == nil {
+		return errors.New("bundle is nil")
+	}
+
+	if b.Version < 1 || b.Version > FormatVersion {
+		return fmt.Errorf("version %d is not supported, expected value between 1 and %d", b.Version, FormatVersion)
+	}
+
+	if b.Issue.ID == "" {
+		return errors.New("issue.id is required")
+	}
+
+	for i, a := range b.Alerts {
+		if a.Alert == nil {
+			return fmt.Errorf("alerts[%d].alert is required", i)
+		}
+
+		if a.ReceivedAt.IsZero() {
+			return fmt.Errorf("alerts[%d].receivedAt is required", i)
+		}
+	}
+
+	for i, e := range b.Events {
+		if !types.IssueEventTypeIsValid(e.Type) {
+			return fmt.Errorf("events[%d].type '%s' is not valid, expected one of [%s]", i, e.Type, strings.Join(types.ValidIssueEventTypes(), ", "))
+		}
+
+		if e.Time.IsZero() {
+			return fmt.Errorf("events[%d].time is required", i)
+		}
+	}
+
+	for i, e := range b.Escalations {
+		if e.Time.IsZero() {
+			return fmt.Errorf("escalations[%d].time is required", i)
+		}
+	}
+
+	for i, c := range b.WebhookClicks {
+		if c.WebhookID == "" {
+			return fmt.Errorf("webhookClicks[%d].webhookId is required", i)
+		}
+
+		if c.Time.IsZero() {
+			return fmt.Errorf("webhookClicks[%d].time is required", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package export_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testIssue struct{}
+
+func (testIssue) MarshalJSON() ([]byte, error) { return []byte(`{}`), nil }
+func (testIssue) ChannelID() string            { return "C12345678" }
+func (testIssue) UniqueID() string             { return "aXNzdWUtMQ" }
+func (testIssue) GetCorrelationID() string     { return "disk-full" }
+func (testIssue) IsOpen() bool                 { return false }
+func (testIssue) CurrentPostID() string        { return "" }
+
+var t0 = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// testBundle returns a bundle with an alert, events, an escalation and a webhook click, all with secrets.
+func testBundle() *export.Bundle {
+	b := export.NewBundle(testIssue{}, t0.Add(24*time.Hour))
+
+	b.Issue.Header = "Disk full on db-01"
+	b.Issue.Severity = types.AlertPanic
+	b.Issue.Resolution = types.ResolutionResolved
+	b.Issue.CreatedAt = t0
+	b.Issue.ResolvedAt = t0.Add(time.Hour)
+	b.Issue.ArchivedAt = t0.Add(2 * time.Hour)
+
+	alert := types.NewErrorAlert()
+	alert.Header = "Disk full on db-01"
+	alert.Text = "Disk /var is 99% full\nSee https://grafana.example.com/d/disk?api_key=s3cr3t"
+	alert.Host = "db-01"
+	alert.Fields = []*types.Field{{Title: "Disk", Value: "/var"}}
+	alert.Metadata["team"] = "db"
+	alert.Metadata["password"] = "hunter2"
+	alert.Webhooks = []*types.Webhook{{
+		ID:         "restart",
+		URL:        "https://hooks.example.com/restart",
+		ButtonText: "Restart",
+		Headers:    map[string]string{"Authorization": "Bearer abc.def", "X-Request-Source": "slackmgr"},
+	}}
+
+	b.Alerts = append(b.Alerts, export.AlertRecord{ReceivedAt: t0, Alert: alert})
+
+	b.Events = append(b.Events,
+		export.Event{Time: t0, Type: types.IssueEventCreated},
+		export.Event{Time: t0.Add(time.Hour), Type: types.IssueEventResolved, Actor: "Jane Doe", Message: "Cleaned up | old logs"},
+	)
+
+	b.Escalations = append(b.Escalations, export.EscalationRecord{
+		Time:          t0.Add(30 * time.Minute),
+		Severity:      types.AlertPanic,
+		SlackMentions: []string{"<!here>"},
+	})
+
+	b.WebhookClicks = append(b.WebhookClicks, export.WebhookClick{
+		Time:         t0.Add(45 * time.Minute),
+		WebhookID:    "restart",
+		ButtonText:   "Restart",
+		UserID:       "U12345678",
+		UserRealName: "Jane Doe",
+		Input:        map[string]string{"token": "xoxb-1234-abcd"},
+		Status:       types.WebhookResponseStatusSuccess,
+		Message:      "Restarted",
+	})
+
+	return b
+}
+
+func TestNewBundle(t *testing.T) {
+	t.Parallel()
+
+	b := testBundle()
+
+	assert.Equal(t, export.FormatVersion, b.Version)
+	assert.Equal(t, "aXNzdWUtMQ", b.Issue.ID)
+	assert.Equal(t, "C12345678", b.Issue.SlackChannelID)
+	assert.Equal(t, "disk-full", b.Issue.CorrelationID)
+	assert.False(t, b.Issue.Open)
+
+	empty := export.NewBundle(nil, t0)
+	assert.Empty(t, empty.Issue.ID)
+	assert.NotNil(t, empty.Events)
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	data, err := testBundle().JSON()
+	require.NoError(t, err)
+
+	decoded, err := export.Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, testBundle().Issue, decoded.Issue)
+	assert.Equal(t, "hunter2", decoded.Alerts[0].Alert.Metadata["password"])
+	assert.Equal(t, testBundle().Events, decoded.Events)
+	assert.Equal(t, testBundle().WebhookClicks, decoded.WebhookClicks)
+
+	tests := []struct {
+		name   string
+		modify func(map[string]any)
+		err    string
+	}{
+		{"future version", func(m map[string]any) { m["version"] = 2 }, "invalid issue export: version 2 is not supported, expected value between 1 and 1"},
+		{"missing issue ID", func(m map[string]any) { m["issue"] = map[string]any{} }, "invalid issue export: issue.id is required"},
+		{"missing alert", func(m map[string]any) { m["alerts"] = []any{map[string]any{"receivedAt": t0}} }, "invalid issue export: alerts[0].alert is required"},
+		{"invalid event type", func(m map[string]any) { m["events"] = []any{map[string]any{"type": "deleted", "time": t0}} }, "invalid issue export: events[0].type 'deleted' is not valid, expected one of [created, updated, escalated, resolved, reopened, moved, archived, note]"},
+		{"missing event time", func(m map[string]any) { m["events"] = []any{map[string]any{"type": "note"}} }, "invalid issue export: events[0].time is required"},
+		{"missing webhook ID", func(m map[string]any) { m["webhookClicks"] = []any{map[string]any{"time": t0}} }, "invalid issue export: webhookClicks[0].webhookId is required"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var m map[string]any
+			require.NoError(t, json.Unmarshal(data, &m))
+			test.modify(m)
+
+			modified, err := json.Marshal(m)
+			require.NoError(t, err)
+
+			_, err = export.Decode(modified)
+			require.EqualError(t, err, test.err)
+		})
+	}
+
+	_, err = export.Decode([]byte("not json"))
+	require.ErrorContains(t, err, "failed to decode issue export")
+}
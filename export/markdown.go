@@ -0,0 +1,223 @@
+package export
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TimelineKind is the kind of a TimelineEntry.
+type TimelineKind string
+
+const (
+	// TimelineAlert is an alert received for the issue.
+	TimelineAlert TimelineKind = "alert"
+
+	// TimelineEvent is an issue lifecycle event.
+	TimelineEvent TimelineKind = "event"
+
+	// TimelineEscalation is a triggered escalation point.
+	TimelineEscalation TimelineKind = "escalation"
+
+	// TimelineWebhookClick is a webhook button click.
+	TimelineWebhookClick TimelineKind = "webhook_click"
+)
+
+// TimelineEntry is a single line of the bundle timeline.
+type TimelineEntry struct {
+	Time    time.Time    `json:"time"`
+	Kind    TimelineKind `json:"kind"`
+	Summary string       `json:"summary"`
+}
+
+// Timeline returns the alerts, events, escalations and webhook clicks of the bundle as a single list, sorted by time.
+// Entries with the same time are kept in that order.
+func (b *Bundle) Timeline() []TimelineEntry {
+	entries := make([]TimelineEntry, 0, len(b.Alerts)+len(b.Events)+len(b.Escalations)+len(b.WebhookClicks))
+
+	for _, a := range b.Alerts {
+		if a.Alert == nil {
+			continue
+		}
+
+		entries = append(entries, TimelineEntry{
+			Time:    a.ReceivedAt,
+			Kind:    TimelineAlert,
+			Summary: joinNonEmpty(" ", "["+string(a.Alert.Severity)+"]", a.Alert.Header),
+		})
+	}
+
+	for _, e := range b.Events {
+		summary := string(e.Type)
+		if e.Actor != "" {
+			summary += " by " + e.Actor
+		}
+
+		entries = append(entries, TimelineEntry{
+			Time:    e.Time,
+			Kind:    TimelineEvent,
+			Summary: joinNonEmpty(": ", summary, e.Message),
+		})
+	}
+
+	for _, e := range b.Escalations {
+		parts := []string{"severity " + string(e.Severity)}
+
+		if len(e.SlackMentions) > 0 {
+			parts = append(parts, "mentions "+strings.Join(e.SlackMentions, " "))
+		}
+
+		if e.MoveToChannel != "" {
+			parts = append(parts, "moved to "+e.MoveToChannel)
+		}
+
+		entries = append(entries, TimelineEntry{
+			Time:    e.Time,
+			Kind:    TimelineEscalation,
+			Summary: strings.Join(parts, ", "),
+		})
+	}
+
+	for _, c := range b.WebhookClicks {
+		button := c.ButtonText
+		if button == "" {
+			button = c.WebhookID
+		}
+
+		user := c.UserID
+		if c.UserRealName != "" {
+			user = fmt.Sprintf("%s (%s)", c.UserRealName, c.UserID)
+		}
+
+		summary := joinNonEmpty(" by ", button, user)
+		if c.Status != "" {
+			summary = joinNonEmpty(": ", summary, joinNonEmpty(" - ", string(c.Status), c.Message))
+		}
+
+		entries = append(entries, TimelineEntry{
+			Time:    c.Time,
+			Kind:    TimelineWebhookClick,
+			Summary: summary,
+		})
+	}
+
+	slices.SortStableFunc(entries, func(a, b TimelineEntry) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return entries
+}
+
+// Markdown returns the bundle as a Markdown document, with the issue summary, the timeline, and the alert details.
+// Times are formatted as RFC 3339, in UTC.
+func (b *Bundle) Markdown() string {
+	var sb strings.Builder
+
+	title := b.Issue.Header
+	if title == "" {
+		title = "Issue " + b.Issue.CorrelationID
+	}
+
+	fmt.Fprintf(&sb, "# %s\n\n", markdownLine(title))
+
+	if b.Redacted {
+		sb.WriteString("> Sensitive values in this export have been redacted.\n\n")
+	}
+
+	status := "archived"
+	if b.Issue.Open {
+		status = "open"
+	}
+
+	writeMarkdownItem(&sb, "Issue ID", "`"+b.Issue.ID+"`")
+	writeMarkdownItem(&sb, "Slack channel", b.Issue.SlackChannelID)
+	writeMarkdownItem(&sb, "Correlation ID", markdownCode(b.Issue.CorrelationID))
+	writeMarkdownItem(&sb, "Global issue key", markdownCode(b.Issue.GlobalIssueKey))
+	writeMarkdownItem(&sb, "Severity", string(b.Issue.Severity))
+	writeMarkdownItem(&sb, "Status", status)
+	writeMarkdownItem(&sb, "Resolution", string(b.Issue.Resolution))
+	writeMarkdownItem(&sb, "Created", formatTime(b.Issue.CreatedAt))
+	writeMarkdownItem(&sb, "Resolved", formatTime(b.Issue.ResolvedAt))
+	writeMarkdownItem(&sb, "Archived", formatTime(b.Issue.ArchivedAt))
+	writeMarkdownItem(&sb, "Slack post", b.Issue.SlackPermalink)
+	writeMarkdownItem(&sb, "Exported", formatTime(b.ExportedAt))
+
+	sb.WriteString("\n## Timeline\n\n")
+	sb.WriteString("| Time (UTC) | Kind | Details |\n")
+	sb.WriteString("| --- | --- | --- |\n")
+
+	for _, e := range b.Timeline() {
+		fmt.Fprintf(&sb, "| %s | %s | %s |\n", formatTime(e.Time), e.Kind, markdownCell(e.Summary))
+	}
+
+	if len(b.Alerts) > 0 {
+		sb.WriteString("\n## Alerts\n")
+	}
+
+	for i, a := range b.Alerts {
+		if a.Alert == nil {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "\n### %d. %s [%s] %s\n\n", i+1, formatTime(a.ReceivedAt), a.Alert.Severity, markdownLine(a.Alert.Header))
+
+		if a.Alert.Text != "" {
+			for line := range strings.SplitSeq(a.Alert.Text, "\n") {
+				sb.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+			}
+
+			sb.WriteString("\n")
+		}
+
+		for _, f := range a.Alert.Fields {
+			if f != nil {
+				writeMarkdownItem(&sb, markdownLine(f.Title), markdownLine(f.Value))
+			}
+		}
+
+		writeMarkdownItem(&sb, "Host", markdownLine(a.Alert.Host))
+		writeMarkdownItem(&sb, "Link", a.Alert.Link)
+	}
+
+	return sb.String()
+}
+
+// writeMarkdownItem writes a bold label list item, unless the value is empty.
+func writeMarkdownItem(sb *strings.Builder, label, value string) {
+	if value != "" {
+		fmt.Fprintf(sb, "- **%s:** %s\n", label, value)
+	}
+}
+
+// formatTime formats t as RFC 3339 in UTC, or returns the empty string for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// markdownCode returns s as inline code, or the empty string if s is empty.
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return "`" + strings.ReplaceAll(markdownLine(s), "`", "'") + "`"
+}
+
+// markdownLine returns s on a single line.
+func markdownLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// markdownCell returns s on a single line, with pipes escaped, for use in a table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(markdownLine(s), "|", `\|`)
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	return strings.Join(slices.DeleteFunc(values, func(s string) bool { return s == "" }), sep)
+}
@@ -0,0 +1,82 @@
+package export_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleTimeline(t *testing.T) {
+	t.Parallel()
+
+	timeline := testBundle().Timeline()
+	require.Len(t, timeline, 5)
+
+	kinds := make([]export.TimelineKind, 0, len(timeline))
+	for _, e := range timeline {
+		kinds = append(kinds, e.Kind)
+	}
+
+	assert.Equal(t, []export.TimelineKind{
+		export.TimelineAlert,
+		export.TimelineEvent,
+		export.TimelineEscalation,
+		export.TimelineWebhookClick,
+		export.TimelineEvent,
+	}, kinds)
+
+	assert.Equal(t, "[error] Disk full on db-01", timeline[0].Summary)
+	assert.Equal(t, "created", timeline[1].Summary)
+	assert.Equal(t, "severity panic, mentions <!here>", timeline[2].Summary)
+	assert.Equal(t, "Restart by Jane Doe (U12345678): success - Restarted", timeline[3].Summary)
+	assert.Equal(t, "resolved by Jane Doe: Cleaned up | old logs", timeline[4].Summary)
+}
+
+func TestBundleMarkdown(t *testing.T) {
+	t.Parallel()
+
+	r, err := export.NewRedactor(export.RedactionConfig{})
+	require.NoError(t, err)
+
+	b, err := testBundle().Redact(r)
+	require.NoError(t, err)
+
+	expected := "# Disk full on db-01\n" +
+		"\n" +
+		"> Sensitive values in this export have been redacted.\n" +
+		"\n" +
+		"- **Issue ID:** `aXNzdWUtMQ`\n" +
+		"- **Slack channel:** C12345678\n" +
+		"- **Correlation ID:** `disk-full`\n" +
+		"- **Severity:** panic\n" +
+		"- **Status:** archived\n" +
+		"- **Resolution:** resolved\n" +
+		"- **Created:** 2026-03-01T10:00:00Z\n" +
+		"- **Resolved:** 2026-03-01T11:00:00Z\n" +
+		"- **Archived:** 2026-03-01T12:00:00Z\n" +
+		"- **Exported:** 2026-03-02T10:00:00Z\n" +
+		"\n" +
+		"## Timeline\n" +
+		"\n" +
+		"| Time (UTC) | Kind | Details |\n" +
+		"| --- | --- | --- |\n" +
+		"| 2026-03-01T10:00:00Z | alert | [error] Disk full on db-01 |\n" +
+		"| 2026-03-01T10:00:00Z | event | created |\n" +
+		"| 2026-03-01T10:30:00Z | escalation | severity panic, mentions <!here> |\n" +
+		"| 2026-03-01T10:45:00Z | webhook_click | Restart by Jane Doe (U12345678): success - Restarted |\n" +
+		"| 2026-03-01T11:00:00Z | event | resolved by Jane Doe: Cleaned up \\| old logs |\n" +
+		"\n" +
+		"## Alerts\n" +
+		"\n" +
+		"### 1. 2026-03-01T10:00:00Z [error] Disk full on db-01\n" +
+		"\n" +
+		"> Disk /var is 99% full\n" +
+		"> See https://grafana.example.com/d/disk?[REDACTED]\n" +
+		"\n" +
+		"- **Disk:** /var\n" +
+		"- **Host:** db-01\n"
+
+	assert.Equal(t, expected, b.Markdown())
+}
@@ -0,0 +1,199 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactionReplacement replaces redacted values when RedactionConfig.Replacement is empty.
+const DefaultRedactionReplacement = "[REDACTED]"
+
+// DefaultRedactedKeys returns the keys whose values are always redacted, unless RedactionConfig.DisableDefaults is set:
+// credentials commonly found in webhook headers and payloads, and in alert metadata.
+func DefaultRedactedKeys() []string {
+	return []string{
+		"authorization",
+		"proxy-authorization",
+		"cookie",
+		"set-cookie",
+		"password",
+		"passwd",
+		"secret",
+		"client_secret",
+		"token",
+		"access_token",
+		"refresh_token",
+		"api_key",
+		"x-api-key",
+		"private_key",
+	}
+}
+
+// DefaultRedactionPatterns returns the regular expressions of secrets redacted in all string values, unless
+// RedactionConfig.DisableDefaults is set: bearer tokens, Slack tokens, AWS access key IDs, PEM private keys, and
+// credentials in URL query strings.
+func DefaultRedactionPatterns() []string {
+	return []string{
+		`(?i)bearer\s+[a-z0-9._~+/=-]+`,
+		`xox[abeoprs]-[A-Za-z0-9-]+`,
+		`\b(AKIA|ASIA)[0-9A-Z]{16}\b`,
+		`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+		`(?i)\b(password|passwd|secret|token|api[_-]?key|access[_-]?token)=[^\s&"']+`,
+	}
+}
+
+// RedactionConfig configures a Redactor.
+type RedactionConfig struct {
+	// Keys are additional object keys whose values are redacted, such as alert metadata keys or webhook payload keys.
+	// Keys are matched case-insensitively, ignoring '-' and '_', so that 'api_key' matches 'API-Key' and 'apiKey'.
+	Keys []string `json:"keys"`
+
+	// Patterns are additional regular expressions. Matches are replaced in all string values.
+	Patterns []string `json:"patterns"`
+
+	// Replacement replaces redacted values. If empty, DefaultRedactionReplacement is used.
+	Replacement string `json:"replacement"`
+
+	// DisableDefaults is true if DefaultRedactedKeys and DefaultRedactionPatterns should not be used.
+	DisableDefaults bool `json:"disableDefaults"`
+}
+
+// Redactor replaces sensitive values in bundles. It is safe for concurrent use.
+type Redactor struct {
+	keys        map[string]struct{}
+	patterns    []*regexp.Regexp
+	replacement string
+}
+
+// NewRedactor returns a redactor for the config. An error is returned if a key is empty, or if a pattern is not
+// a valid regular expression.
+func NewRedactor(cfg RedactionConfig) (*Redactor, error) {
+	r := &Redactor{
+		keys:        make(map[string]struct{}),
+		replacement: cfg.Replacement,
+	}
+
+	if r.replacement == "" {
+		r.replacement = DefaultRedactionReplacement
+	}
+
+	for i, key := range cfg.Keys {
+		if normalizeRedactionKey(key) == "" {
+			return nil, fmt.Errorf("keys[%d] cannot be empty", i)
+		}
+	}
+
+	keys := cfg.Keys
+	patterns := cfg.Patterns
+
+	if !cfg.DisableDefaults {
+		keys = append(DefaultRedactedKeys(), keys...)
+	}
+
+	for _, key := range keys {
+		r.keys[normalizeRedactionKey(key)] = struct{}{}
+	}
+
+	for i, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("patterns[%d] '%s' is not a valid regular expression: %w", i, pattern, err)
+		}
+
+		r.patterns = append(r.patterns, re)
+	}
+
+	if !cfg.DisableDefaults {
+		for _, pattern := range DefaultRedactionPatterns() {
+			r.patterns = append(r.patterns, regexp.MustCompile(pattern))
+		}
+	}
+
+	return r, nil
+}
+
+// String returns s with all pattern matches replaced.
+func (r *Redactor) String(s string) string {
+	for _, re := range r.patterns {
+		s = re.ReplaceAllLiteralString(s, r.replacement)
+	}
+
+	return s
+}
+
+// Value returns a redacted copy of a decoded JSON value: values of redacted keys are replaced, at any depth,
+// and pattern matches are replaced in all other strings.
+func (r *Redactor) Value(v any) any {
+	switch v := v.(type) {
+	case string:
+		return r.String(v)
+	case map[string]any:
+		result := make(map[string]any, len(v))
+
+		for key, value := range v {
+			if r.redactsKey(key) && value != nil {
+				result[key] = r.replacement
+			} else {
+				result[key] = r.Value(value)
+			}
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(v))
+
+		for i, value := range v {
+			result[i] = r.Value(value)
+		}
+
+		return result
+	default:
+		return v
+	}
+}
+
+func (r *Redactor) redactsKey(key string) bool {
+	_, ok := r.keys[normalizeRedactionKey(key)]
+	return ok
+}
+
+// Redact returns a redacted copy of the bundle, with Redacted set. The bundle itself is not modified.
+// Redaction is applied to the JSON representation, so it covers all alert fields, including metadata, webhook
+// headers and payloads, as well as events and webhook clicks.
+func (b *Bundle) Redact(r *Redactor) (*Bundle, error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode issue export: %w", err)
+	}
+
+	var tree map[string]any
+
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("failed to decode issue export: %w", err)
+	}
+
+	// The format fields are not redacted, so that patterns cannot corrupt the bundle itself.
+	redacted, _ := r.Value(tree).(map[string]any)
+	redacted["version"] = tree["version"]
+	redacted["exportedAt"] = tree["exportedAt"]
+	redacted["redacted"] = true
+
+	if data, err = json.Marshal(redacted); err != nil {
+		return nil, fmt.Errorf("failed to encode redacted issue export: %w", err)
+	}
+
+	result := &Bundle{}
+
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("failed to decode redacted issue export, check the redaction patterns: %w", err)
+	}
+
+	return result, nil
+}
+
+// normalizeRedactionKey returns the key in lower case, without '-' and '_'.
+func normalizeRedactionKey(key string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(key)))
+}
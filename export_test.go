@@ -0,0 +1,5 @@
+package types
+
+// DecompressQueuePayloadWithLimit exposes decompressQueuePayload to the tests, so that the size limit can be
+// tested without decompressing MaxDecompressedQueuePayloadSize bytes.
+var DecompressQueuePayloadWithLimit = decompressQueuePayload
@@ -0,0 +1,163 @@
+package faults
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/slackmgr/types"
+)
+
+// DB is a types.DB decorator that injects faults before each operation.
+// For TEST purposes only! Do not use in production!
+type DB struct {
+	db       types.DB
+	injector *injector
+}
+
+// WrapDB returns a DB that injects faults according to p, before passing each operation to db.
+// DuplicateRate is not used by the DB decorator.
+//
+// If db implements the optional types.IssueLister interface, so does the returned DB, with faults injected
+// before each ListIssues call. The find capabilities of db are reported with types.FindCapabilityReporter.
+//
+// For TEST purposes only! Do not use in production!
+func WrapDB(db types.DB, p FaultProfile) types.DB {
+	d := &DB{
+		db:       db,
+		injector: newInjector(p),
+	}
+
+	if lister, ok := db.(types.IssueLister); ok {
+		return &listerDB{DB: d, lister: lister}
+	}
+
+	return d
+}
+
+// listerDB is a DB that also implements types.IssueLister.
+type listerDB struct {
+	*DB
+	lister types.IssueLister
+}
+
+func (d *listerDB) ListIssues(ctx context.Context, opts ...types.FindOption) (*types.PageResult[types.IssueRecord], error) {
+	if err := d.injector.before(ctx); err != nil {
+		return nil, err
+	}
+	return d.lister.ListIssues(ctx, opts...)
+}
+
+func (d *listerDB) FindCapabilities() types.FindCapabilities {
+	return types.IssueListerCapabilities(d.lister)
+}
+
+func (d *DB) Init(ctx context.Context, skipSchemaValidation bool) error {
+	if err := d.injector.before(ctx); err != nil {
+		return err
+	}
+	return d.db.Init(ctx, skipSchemaValidation)
+}
+
+func (d *DB) SaveAlert(ctx context.Context, alert *types.Alert) error {
+	if err := d.injector.before(ctx); err != nil {
+		return err
+	}
+	return d.db.SaveAlert(ctx, alert)
+}
+
+func (d *DB) SaveIssue(ctx context.Context, issue types.Issue) error {
+	if err := d.injector.before(ctx); err != nil {
+		return err
+	}
+	return d.db.SaveIssue(ctx, issue)
+}
+
+func (d *DB) SaveIssues(ctx context.Context, issues ...types.Issue) error {
+	if err := d.injector.before(ctx); err != nil {
+		return err
+	}
+	return d.db.SaveIssues(ctx, issues...)
+}
+
+func (d *DB) MoveIssue(ctx context.Context, issue types.Issue, sourceChannelID, targetChannelID string) error {
+	if err := d.injector.before(ctx); err != nil {
+		return err
+	}
+	return d.db.MoveIssue(ctx, issue, sourceChannelID, targetChannelID)
+}
+
+func (d *DB) FindOpenIssueByCorrelationID(ctx context.Context, channelID, correlationID string) (string, json.RawMessage, error) {
+	if err := d.injector.before(ctx); err != nil {
+		return "", nil, err
+	}
+	return d.db.FindOpenIssueByCorrelationID(ctx, channelID, correlationID)
+}
+
+func (d *DB) FindIssueBySlackPostID(ctx context.Context, channelID, postID string) (string, json.RawMessage, error) {
+	if err := d.injector.before(ctx); err != nil {
+		return "", nil, err
+	}
+	return d.db.FindIssueBySlackPostID(ctx, channelID, postID)
+}
+
+func (d *DB) FindActiveChannels(ctx context.Context) ([]string, error) {
+	if err := d.injector.before(ctx); err != nil {
+		return nil, err
+	}
+	return d.db.FindActiveChannels(ctx)
+}
+
+func (d *DB) LoadOpenIssuesInChannel(ctx context.Context, channelID string) (map[string]json.RawMessage, error) {
+	if err := d.injector.before(ctx); err != nil {
+		return nil, err
+	}
+	return d.db.LoadOpenIssuesInChannel(ctx, channelID)
+}
+
+func (d *DB) SaveMoveMapping(ctx context.Context, moveMapping types.MoveMapping) error {
+	if err := d.injector.before(ctx); err != nil {
+		return err
+	}
+	return d.db.SaveMoveMapping(ctx, moveMapping)
+}
+
+func (d *DB) FindMoveMapping(ctx context.Context, channelID, correlationID string) (json.RawMessage, error) {
+	if err := d.injector.before(ctx); err != nil {
+		return nil, err
+	}
+	return d.db.FindMoveMapping(ctx, channelID, correlationID)
+}
+
+func (d *DB) DeleteMoveMapping(ctx context.Context, channelID, correlationID string) error {
+	if err := d.injector.before(ctx); err != nil {
+		return err
+	}
+	return d.db.DeleteMoveMapping(ctx, channelID, correlationID)
+}
+
+func (d *DB) SaveChannelProcessingState(ctx context.Context, state *types.ChannelProcessingState) error {
+	if err := d.injector.before(ctx); err != nil {
+		return err
+	}
+	return d.db.SaveChannelProcessingState(ctx, state)
+}
+
+func (d *DB) FindChannelProcessingState(ctx context.Context, channelID string) (*types.ChannelProcessingState, error) {
+	if err := d.injector.before(ctx); err != nil {
+		return nil, err
+	}
+	return d.db.FindChannelProcessingState(ctx, channelID)
+}
+
+func (d *DB) DropAllData(ctx context.Context) error {
+	if err := d.injector.before(ctx); err != nil {
+		return err
+	}
+	return d.db.DropAllData(ctx)
+}
+
+var (
+	_ types.DB                     = (*DB)(nil)
+	_ types.IssueLister            = (*listerDB)(nil)
+	_ types.FindCapabilityReporter = (*listerDB)(nil)
+)
@@ -0,0 +1,240 @@
+package faults_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/dbtests"
+	"github.com/slackmgr/types/faults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapDBWithoutFaults(t *testing.T) {
+	t.Parallel()
+
+	db := faults.WrapDB(types.NewInMemoryDB(), faults.FaultProfile{})
+	dbtests.RunAllTests(t, db)
+}
+
+func TestWrapDBErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := faults.WrapDB(types.NewInMemoryDB(), faults.FaultProfile{ErrorRate: 1})
+	_, err := db.FindActiveChannels(ctx)
+	require.ErrorIs(t, err, faults.ErrInjectedFault)
+
+	customErr := errors.New("boom")
+	db = faults.WrapDB(types.NewInMemoryDB(), faults.FaultProfile{ErrorRate: 1, Err: customErr})
+	require.ErrorIs(t, db.DropAllData(ctx), customErr)
+}
+
+func TestWrapDBIssueLister(t *testing.T) {
+	t.Parallel()
+
+	db := faults.WrapDB(types.NewInMemoryDB(), faults.FaultProfile{ErrorRate: 1})
+
+	lister, ok := db.(types.IssueLister)
+	require.True(t, ok)
+	assert.Equal(t, types.NewInMemoryDB().FindCapabilities(), types.IssueListerCapabilities(lister))
+
+	_, err := lister.ListIssues(context.Background())
+	require.ErrorIs(t, err, faults.ErrInjectedFault)
+
+	// The optional interface is not implemented if the wrapped DB does not implement it.
+	db = faults.WrapDB(struct{ types.DB }{types.NewInMemoryDB()}, faults.FaultProfile{})
+	_, ok = db.(types.IssueLister)
+	assert.False(t, ok)
+}
+
+func TestWrapDBErrorRateIsReproducible(t *testing.T) {
+	t.Parallel()
+
+	run := func() []bool {
+		db := faults.WrapDB(types.NewInMemoryDB(), faults.FaultProfile{ErrorRate: 0.5, Seed: 42})
+		result := make([]bool, 50)
+		for i := range result {
+			_, err := db.FindActiveChannels(context.Background())
+			result[i] = err != nil
+		}
+		return result
+	}
+
+	first := run()
+	assert.Equal(t, first, run())
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+}
+
+func TestWrapDBLatency(t *testing.T) {
+	t.Parallel()
+
+	db := faults.WrapDB(types.NewInMemoryDB(), faults.FaultProfile{Latency: 20 * time.Millisecond})
+
+	start := time.Now()
+	_, err := db.FindActiveChannels(context.Background())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	db = faults.WrapDB(types.NewInMemoryDB(), faults.FaultProfile{Latency: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = db.FindActiveChannels(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWrapFifoQueueDuplicates(t *testing.T) {
+	t.Parallel()
+
+	queue := faults.WrapFifoQueue(types.NewInMemoryFifoQueue("test", 10, time.Second), faults.FaultProfile{DuplicateRate: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, queue.Send(ctx, "C123", "dedup", "body"))
+
+	sinkCh := make(chan *types.FifoQueueItem)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- queue.Receive(ctx, sinkCh)
+	}()
+
+	first := <-sinkCh
+	second := <-sinkCh
+	assert.Equal(t, "body", first.Body)
+	assert.Equal(t, first.MessageID, second.MessageID)
+
+	cancel()
+
+	for range sinkCh {
+	}
+
+	require.ErrorIs(t, <-errCh, context.Canceled)
+}
+
+func TestWrapFifoQueueSendErrors(t *testing.T) {
+	t.Parallel()
+
+	queue := faults.WrapFifoQueue(types.NewInMemoryFifoQueue("test", 10, time.Second), faults.FaultProfile{ErrorRate: 1})
+	require.ErrorIs(t, queue.Send(context.Background(), "C123", "dedup", "body"), faults.ErrInjectedFault)
+}
+
+func TestWrapFifoQueueBatches(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	inner := types.NewInMemoryFifoQueue("test", 10, time.Second).WithDeadLetter(types.NewInMemoryFifoQueue("test-dlq", 10, time.Second))
+	queue := faults.WrapFifoQueue(inner, faults.FaultProfile{DuplicateRate: 1})
+	assert.Equal(t, "test", queue.Name())
+
+	require.NoError(t, queue.SendBatch(ctx, []*types.FifoQueueMessage{
+		{SlackChannelID: "C123", DedupID: "a", Body: "first"},
+		{SlackChannelID: "C123", DedupID: "b", Body: "second"},
+	}))
+
+	items, err := queue.ReceiveBatch(ctx, 3)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, []string{"first", "first", "second"}, []string{items[0].Body, items[1].Body, items[2].Body})
+
+	deadLetter, err := queue.DeadLetter()
+	require.NoError(t, err)
+	assert.Equal(t, "test-dlq", deadLetter.Name())
+
+	_, err = faults.WrapFifoQueue(types.NewInMemoryFifoQueue("test", 10, time.Second), faults.FaultProfile{}).DeadLetter()
+	require.ErrorIs(t, err, types.ErrDeadLetterNotSupported)
+
+	queue = faults.WrapFifoQueue(inner, faults.FaultProfile{ErrorRate: 1})
+	require.ErrorIs(t, queue.SendBatch(ctx, []*types.FifoQueueMessage{{SlackChannelID: "C123", Body: "body"}}), faults.ErrInjectedFault)
+
+	_, err = queue.ReceiveBatch(ctx, 1)
+	require.ErrorIs(t, err, faults.ErrInjectedFault)
+}
+
+func TestWrapQueue(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inner := types.NewInMemoryQueue("test", 10, time.Second)
+	queue := faults.WrapQueue(inner, faults.FaultProfile{DuplicateRate: 1})
+	assert.Equal(t, "test", queue.Name())
+
+	require.NoError(t, queue.SendBatch(ctx, []string{"first", "second"}))
+
+	items, err := queue.ReceiveBatch(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+	assert.Equal(t, items[0].MessageID, items[1].MessageID)
+
+	require.NoError(t, queue.Send(ctx, "body"))
+
+	sinkCh := make(chan *types.QueueItem)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- queue.Receive(ctx, sinkCh)
+	}()
+
+	first := <-sinkCh
+	second := <-sinkCh
+	assert.Equal(t, "body", first.Body)
+	assert.Equal(t, first.MessageID, second.MessageID)
+
+	cancel()
+
+	for range sinkCh {
+	}
+
+	require.ErrorIs(t, <-errCh, context.Canceled)
+
+	queue = faults.WrapQueue(inner, faults.FaultProfile{ErrorRate: 1})
+	require.ErrorIs(t, queue.Send(context.Background(), "body"), faults.ErrInjectedFault)
+}
+
+func TestWrapTransport(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	var bodies sync.Map
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies.Store(requests.Add(1), string(body))
+	}))
+	t.Cleanup(server.Close)
+
+	httpClient := &http.Client{Transport: faults.WrapTransport(nil, faults.FaultProfile{DuplicateRate: 1})}
+
+	resp, err := httpClient.Post(server.URL, "text/plain", strings.NewReader("body"))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Equal(t, int32(2), requests.Load())
+	first, _ := bodies.Load(int32(1))
+	second, _ := bodies.Load(int32(2))
+	assert.Equal(t, "body", first)
+	assert.Equal(t, "body", second)
+
+	httpClient = &http.Client{Transport: faults.WrapTransport(http.DefaultTransport, faults.FaultProfile{ErrorRate: 1})}
+
+	_, err = httpClient.Get(server.URL)
+	require.ErrorIs(t, err, faults.ErrInjectedFault)
+	assert.Equal(t, int32(2), requests.Load())
+}
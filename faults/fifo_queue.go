@@ -0,0 +1,85 @@
+package faults
+
+import (
+	"context"
+
+	"github.com/slackmgr/types"
+)
+
+// FaultyFifoQueue is a types.FifoQueue decorator that injects faults.
+// For TEST purposes only! Do not use in production!
+type FaultyFifoQueue struct {
+	queue    types.FifoQueue
+	injector *injector
+}
+
+var _ types.FifoQueue = (*FaultyFifoQueue)(nil)
+
+// WrapFifoQueue returns a FIFO queue that injects faults according to p.
+// Latency and errors are injected before each Send, SendBatch and ReceiveBatch. Latency and duplicate deliveries
+// are injected for each item passed to the Receive sink channel, and duplicate deliveries for each item returned
+// by ReceiveBatch. A duplicate delivery is the same item (with the same message ID) delivered twice in a row.
+//
+// The returned queue does not implement types.BatchAcker, so received items are acknowledged one by one.
+//
+// For TEST purposes only! Do not use in production!
+func WrapFifoQueue(queue types.FifoQueue, p FaultProfile) *FaultyFifoQueue {
+	return &FaultyFifoQueue{
+		queue:    queue,
+		injector: newInjector(p),
+	}
+}
+
+// Name returns the name of the wrapped queue.
+func (q *FaultyFifoQueue) Name() string {
+	return q.queue.Name()
+}
+
+// Send sends a message to the wrapped queue, unless an error is injected.
+func (q *FaultyFifoQueue) Send(ctx context.Context, slackChannelID, dedupID, body string) error {
+	if err := q.injector.before(ctx); err != nil {
+		return err
+	}
+	return q.queue.Send(ctx, slackChannelID, dedupID, body)
+}
+
+// SendBatch sends messages to the wrapped queue, unless an error is injected.
+func (q *FaultyFifoQueue) SendBatch(ctx context.Context, messages []*types.FifoQueueMessage) error {
+	if err := q.injector.before(ctx); err != nil {
+		return err
+	}
+	return q.queue.SendBatch(ctx, messages)
+}
+
+// Receive receives messages from the wrapped queue, to the specified sink channel.
+// The sink channel is closed when the function returns.
+func (q *FaultyFifoQueue) Receive(ctx context.Context, sinkCh chan<- *types.FifoQueueItem) error {
+	return receive(ctx, q.injector, q.queue.Receive, sinkCh)
+}
+
+// ReceiveBatch receives up to maxItems messages from the wrapped queue, unless an error is injected.
+func (q *FaultyFifoQueue) ReceiveBatch(ctx context.Context, maxItems int) ([]*types.FifoQueueItem, error) {
+	if err := q.injector.before(ctx); err != nil {
+		return nil, err
+	}
+
+	items, err := q.queue.ReceiveBatch(ctx, maxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicate(q.injector, items, maxItems), nil
+}
+
+// DeadLetter returns the dead-letter queue of the wrapped queue, injecting the same faults.
+func (q *FaultyFifoQueue) DeadLetter() (types.FifoQueue, error) {
+	deadLetter, err := q.queue.DeadLetter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &FaultyFifoQueue{
+		queue:    deadLetter,
+		injector: q.injector,
+	}, nil
+}
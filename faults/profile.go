@@ -0,0 +1,117 @@
+// Package faults provides fault-injecting decorators for the interfaces in the types package.
+//
+// The decorators introduce configurable latency, errors and duplicate deliveries, so that the Slack Manager
+// and its plugins can be soak-tested for resilience using shared tooling:
+//
+//	db := faults.WrapDB(types.NewInMemoryDB(), faults.FaultProfile{
+//	    Latency:   10 * time.Millisecond,
+//	    ErrorRate: 0.05,
+//	})
+//
+// For TEST purposes only! Do not use in production!
+package faults
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrInjectedFault is the default error returned by injected faults.
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultProfile configures the faults injected by the decorators in this package.
+// The zero value injects no faults.
+type FaultProfile struct {
+	// Latency is the fixed delay added before each operation.
+	Latency time.Duration
+
+	// LatencyJitter is the maximum random delay added on top of Latency, before each operation.
+	LatencyJitter time.Duration
+
+	// ErrorRate is the probability (0.0-1.0) that an operation fails with Err, without being passed to the wrapped implementation.
+	ErrorRate float64
+
+	// DuplicateRate is the probability (0.0-1.0) that a received queue item is delivered twice.
+	DuplicateRate float64
+
+	// Err is the error returned by failed operations. If nil, ErrInjectedFault is used.
+	Err error
+
+	// Seed is the seed of the random source used to decide which operations fail.
+	// Use a fixed seed for reproducible test runs. If 0, a random seed is used.
+	Seed uint64
+}
+
+// injector applies a fault profile. It is safe for concurrent use.
+type injector struct {
+	profile FaultProfile
+	mu      sync.Mutex
+	rnd     *rand.Rand
+}
+
+func newInjector(p FaultProfile) *injector {
+	seed := p.Seed
+	if seed == 0 {
+		seed = rand.Uint64() // #nosec G404 -- fault injection does not need a secure random source
+	}
+
+	return &injector{
+		profile: p,
+		rnd:     rand.New(rand.NewPCG(seed, seed)), // #nosec G404 -- fault injection does not need a secure random source
+	}
+}
+
+// before is called before each operation. It sleeps for the configured latency (or until the context is canceled),
+// and returns an error if the operation should fail.
+func (i *injector) before(ctx context.Context) error {
+	if err := i.sleep(ctx); err != nil {
+		return err
+	}
+
+	if i.chance(i.profile.ErrorRate) {
+		if i.profile.Err != nil {
+			return i.profile.Err
+		}
+		return ErrInjectedFault
+	}
+
+	return nil
+}
+
+func (i *injector) sleep(ctx context.Context) error {
+	delay := i.profile.Latency
+
+	if i.profile.LatencyJitter > 0 {
+		i.mu.Lock()
+		delay += time.Duration(i.rnd.Int64N(int64(i.profile.LatencyJitter)))
+		i.mu.Unlock()
+	}
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (i *injector) chance(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.rnd.Float64() < p
+}
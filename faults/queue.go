@@ -0,0 +1,134 @@
+package faults
+
+import (
+	"context"
+
+	"github.com/slackmgr/types"
+)
+
+// FaultyQueue is a types.Queue decorator that injects faults.
+// For TEST purposes only! Do not use in production!
+type FaultyQueue struct {
+	queue    types.Queue
+	injector *injector
+}
+
+var _ types.Queue = (*FaultyQueue)(nil)
+
+// WrapQueue returns a queue that injects faults according to p, in the same way as WrapFifoQueue.
+//
+// For TEST purposes only! Do not use in production!
+func WrapQueue(queue types.Queue, p FaultProfile) *FaultyQueue {
+	return &FaultyQueue{
+		queue:    queue,
+		injector: newInjector(p),
+	}
+}
+
+// Name returns the name of the wrapped queue.
+func (q *FaultyQueue) Name() string {
+	return q.queue.Name()
+}
+
+// Send sends a message to the wrapped queue, unless an error is injected.
+func (q *FaultyQueue) Send(ctx context.Context, body string) error {
+	if err := q.injector.before(ctx); err != nil {
+		return err
+	}
+	return q.queue.Send(ctx, body)
+}
+
+// SendBatch sends messages to the wrapped queue, unless an error is injected.
+func (q *FaultyQueue) SendBatch(ctx context.Context, bodies []string) error {
+	if err := q.injector.before(ctx); err != nil {
+		return err
+	}
+	return q.queue.SendBatch(ctx, bodies)
+}
+
+// Receive receives messages from the wrapped queue, to the specified sink channel.
+// The sink channel is closed when the function returns.
+func (q *FaultyQueue) Receive(ctx context.Context, sinkCh chan<- *types.QueueItem) error {
+	return receive(ctx, q.injector, q.queue.Receive, sinkCh)
+}
+
+// ReceiveBatch receives up to maxItems messages from the wrapped queue, unless an error is injected.
+func (q *FaultyQueue) ReceiveBatch(ctx context.Context, maxItems int) ([]*types.QueueItem, error) {
+	if err := q.injector.before(ctx); err != nil {
+		return nil, err
+	}
+
+	items, err := q.queue.ReceiveBatch(ctx, maxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicate(q.injector, items, maxItems), nil
+}
+
+// DeadLetter returns the dead-letter queue of the wrapped queue, injecting the same faults.
+func (q *FaultyQueue) DeadLetter() (types.Queue, error) {
+	deadLetter, err := q.queue.DeadLetter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &FaultyQueue{
+		queue:    deadLetter,
+		injector: q.injector,
+	}, nil
+}
+
+// receive runs receiveFn with an inner sink channel, and passes each item to sinkCh with injected latency
+// and duplicate deliveries. sinkCh is closed when the function returns.
+func receive[T any](ctx context.Context, inj *injector, receiveFn func(context.Context, chan<- T) error, sinkCh chan<- T) error {
+	defer close(sinkCh)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	innerCh := make(chan T)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- receiveFn(ctx, innerCh)
+	}()
+
+	for item := range innerCh {
+		deliveries := 1
+		if inj.chance(inj.profile.DuplicateRate) {
+			deliveries = 2
+		}
+
+		for range deliveries {
+			if err := inj.sleep(ctx); err != nil {
+				cancel()
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+			case sinkCh <- item:
+			}
+		}
+	}
+
+	return <-errCh
+}
+
+// duplicate returns the items with injected duplicate deliveries, each duplicate following the original item.
+// Duplicates are only added while the result has less than maxItems items.
+func duplicate[T any](inj *injector, items []T, maxItems int) []T {
+	result := make([]T, 0, len(items))
+
+	for i, item := range items {
+		result = append(result, item)
+
+		// Keep room for the remaining original items.
+		if len(result)+len(items)-i-1 < maxItems && inj.chance(inj.profile.DuplicateRate) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,72 @@
+package faults
+
+import (
+	"io"
+	"net/http"
+)
+
+// FaultyTransport is an http.RoundTripper decorator that injects faults, such as in the transport of
+// the client package (see client.WithFaults).
+// For TEST purposes only! Do not use in production!
+type FaultyTransport struct {
+	transport http.RoundTripper
+	injector  *injector
+}
+
+var _ http.RoundTripper = (*FaultyTransport)(nil)
+
+// WrapTransport returns a transport that injects faults according to p, before passing each request to transport
+// (or http.DefaultTransport, if nil). Latency and errors are injected before each request. A duplicate delivery is
+// the same request sent twice, the response of the first one being discarded. Requests with a body that cannot be
+// replayed (without GetBody) are never duplicated.
+//
+// For TEST purposes only! Do not use in production!
+func WrapTransport(transport http.RoundTripper, p FaultProfile) *FaultyTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &FaultyTransport{
+		transport: transport,
+		injector:  newInjector(p),
+	}
+}
+
+// RoundTrip sends the request with the wrapped transport, unless an error is injected.
+func (t *FaultyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.injector.before(req.Context()); err != nil {
+		return nil, err
+	}
+
+	if t.injector.chance(t.injector.profile.DuplicateRate) {
+		t.duplicate(req)
+	}
+
+	return t.transport.RoundTrip(req)
+}
+
+// duplicate sends a copy of the request, and discards the response.
+func (t *FaultyTransport) duplicate(req *http.Request) {
+	dup := req.Clone(req.Context())
+
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return
+		}
+
+		body, err := req.GetBody()
+		if err != nil {
+			return
+		}
+
+		dup.Body = body
+	}
+
+	resp, err := t.transport.RoundTrip(dup)
+	if err != nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
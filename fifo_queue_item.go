@@ -33,4 +33,24 @@ type FifoQueueItem struct {
 	// complete regardless of the caller's context state. Each queue implementation is responsible for
 	// managing its own timeouts and retry logic internally.
 	Nack func()
+
+	// NackWithDelay negatively acknowledges the processing of the message, making it available for reprocessing
+	// after the specified delay. Consumers use it for progressive backoff, typically based on ReceiveCount.
+	// Like Nack, it does not accept a context parameter.
+	// This function is nil if the queue implementation does not support delayed redelivery; use Nack instead.
+	NackWithDelay func(delay time.Duration)
+
+	// ReceiveCount is the number of times the message has been received, including this time (1 on first delivery).
+	// Consumers use it to detect poison messages, and move them to the dead-letter queue after a number of attempts
+	// instead of reprocessing them forever. It is 0 if the queue implementation does not track deliveries.
+	ReceiveCount int
+
+	// Extend extends the time the message stays invisible to other consumers (the visibility timeout or lease),
+	// counted from now. Consumers call it while processing takes longer than expected.
+	// This function is nil if the queue implementation does not support extending.
+	Extend func(timeout time.Duration) error
+
+	// AckToken identifies the received message in BatchAcker.AckBatch calls, as an alternative to Ack.
+	// It is empty if the queue implementation does not support batch acknowledgment.
+	AckToken string
 }
@@ -0,0 +1,720 @@
+package types
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrFindOptionNotSupported is returned by IssueLister implementations, and by FindCapabilities.Check, when the find
+// options use a matcher operator or field that the implementation cannot evaluate.
+var ErrFindOptionNotSupported = errors.New("find option is not supported")
+
+// issueFieldBodyPrefix is the prefix used to refer to values in the issue JSON body in find options, as in 'body.lastAlert.severity'.
+const issueFieldBodyPrefix = "body."
+
+// SortDirection is the direction of a sort order in FindOptions.
+type SortDirection string
+
+const (
+	// SortAscending sorts from the lowest to the highest value.
+	SortAscending SortDirection = "asc"
+
+	// SortDescending sorts from the highest to the lowest value.
+	SortDescending SortDirection = "desc"
+)
+
+// SortDirectionIsValid returns true if the provided SortDirection is valid.
+func SortDirectionIsValid(s SortDirection) bool {
+	switch s {
+	case SortAscending, SortDescending:
+		return true
+	}
+	return false
+}
+
+// ValidSortDirections returns a slice of valid SortDirection values.
+func ValidSortDirections() []string {
+	return []string{
+		string(SortAscending),
+		string(SortDescending),
+	}
+}
+
+// FindFieldIsValid returns true if the field can be used in find options: one of ValidIssueFields, or on the format
+// 'body.<path>', referring to a value in the issue JSON body by its dot separated path, as in 'body.lastAlert.severity'.
+//
+// The issue JSON body is internal to the Slack Manager (see Issue), so body fields are best effort.
+func FindFieldIsValid(field IssueField) bool {
+	if path, ok := strings.CutPrefix(string(field), issueFieldBodyPrefix); ok {
+		return path != "" && !strings.HasPrefix(path, ".") && !strings.HasSuffix(path, ".") && !strings.Contains(path, "..")
+	}
+
+	return IssueFieldIsValid(field)
+}
+
+// FindMatchOperator is the operator of a FindMatcher.
+type FindMatchOperator string
+
+const (
+	// FindMatchEquals matches fields equal to the value.
+	FindMatchEquals FindMatchOperator = "equals"
+
+	// FindMatchPrefix matches fields starting with the value, such as correlation ID prefix scans.
+	FindMatchPrefix FindMatchOperator = "prefix"
+
+	// FindMatchContains matches fields containing the value.
+	FindMatchContains FindMatchOperator = "contains"
+
+	// FindMatchExists matches fields that are present, non-null and non-empty, such as 'metadata key present' queries.
+	FindMatchExists FindMatchOperator = "exists"
+)
+
+// FindMatchOperatorIsValid returns true if the provided FindMatchOperator is valid.
+func FindMatchOperatorIsValid(s FindMatchOperator) bool {
+	switch s {
+	case FindMatchEquals, FindMatchPrefix, FindMatchContains, FindMatchExists:
+		return true
+	}
+	return false
+}
+
+// ValidFindMatchOperators returns a slice of valid FindMatchOperator values.
+func ValidFindMatchOperators() []string {
+	return []string{
+		string(FindMatchEquals),
+		string(FindMatchPrefix),
+		string(FindMatchContains),
+		string(FindMatchExists),
+	}
+}
+
+// FindMatcher is a condition on an issue field in FindOptions.
+// Values are compared as strings: numbers and booleans in the issue JSON body are formatted as in JSON.
+type FindMatcher struct {
+	Field    IssueField        `json:"field"`
+	Operator FindMatchOperator `json:"operator"`
+	Value    string            `json:"value,omitempty"`
+}
+
+// FindExpr is a node in the boolean expression tree of FindOptions, as returned by FindOptions.Expr.
+// Exactly one of Matcher, AllOf and AnyOf is set, except for the empty expression, which matches all items.
+type FindExpr struct {
+	// Matcher is set for leaf nodes.
+	Matcher *FindMatcher `json:"matcher,omitempty"`
+
+	// AllOf is set for groups matching items that match all child expressions.
+	AllOf []FindExpr `json:"allOf,omitempty"`
+
+	// AnyOf is set for groups matching items that match at least one child expression.
+	AnyOf []FindExpr `json:"anyOf,omitempty"`
+}
+
+// FindSort is a sort order in FindOptions.
+type FindSort struct {
+	Field     IssueField    `json:"field"`
+	Direction SortDirection `json:"direction"`
+}
+
+// FindOptions holds the filtering, sorting and pagination options of a list query, such as IssueLister.ListIssues.
+// Use NewFindOptions to apply and validate FindOption values.
+type FindOptions struct {
+	// Matchers are the conditions that all listed items must match.
+	Matchers []FindMatcher `json:"matchers,omitempty"`
+
+	// Groups are the nested boolean groups (see WithAnyOf and WithAllOf) that all listed items must match,
+	// in addition to Matchers.
+	Groups []FindExpr `json:"groups,omitempty"`
+
+	// Sort is the sort order, by priority. Ties are broken in a store specific, but stable, order.
+	Sort []FindSort `json:"sort,omitempty"`
+
+	// Limit is the maximum number of items per page. Zero means no limit, or a store specific default.
+	Limit int `json:"limit,omitempty"`
+
+	// Cursor is the opaque token returned as PageResult.NextCursor by the previous page. Empty means the first page.
+	Cursor string `json:"cursor,omitempty"`
+
+	// groupErr is set when WithAnyOf or WithAllOf is called with options other than matchers and groups.
+	groupErr error
+}
+
+// FindOption configures FindOptions.
+type FindOption func(*FindOptions)
+
+// WithKeyEquals adds a matcher for items where the field is equal to the value.
+func WithKeyEquals(field IssueField, value string) FindOption {
+	return withMatcher(field, FindMatchEquals, value)
+}
+
+// WithKeyPrefix adds a matcher for items where the field starts with the prefix.
+func WithKeyPrefix(field IssueField, prefix string) FindOption {
+	return withMatcher(field, FindMatchPrefix, prefix)
+}
+
+// WithKeyContains adds a matcher for items where the field contains the substring.
+func WithKeyContains(field IssueField, substr string) FindOption {
+	return withMatcher(field, FindMatchContains, substr)
+}
+
+// WithKeyExists adds a matcher for items where the field is present, such as 'body.lastAlert.metadata.team'.
+func WithKeyExists(field IssueField) FindOption {
+	return withMatcher(field, FindMatchExists, "")
+}
+
+// WithMatchers adds matchers, for example the supported matchers returned by FindCapabilities.Split.
+func WithMatchers(matchers ...FindMatcher) FindOption {
+	return func(o *FindOptions) {
+		o.Matchers = append(o.Matchers, matchers...)
+	}
+}
+
+// WithGroups adds groups, for example the supported groups returned by FindCapabilities.SplitGroups.
+func WithGroups(groups ...FindExpr) FindOption {
+	return func(o *FindOptions) {
+		o.Groups = append(o.Groups, groups...)
+	}
+}
+
+// WithAnyOf adds a group matching items that match at least one of the matchers and groups of opts, as in
+// "severity = panic OR escalated = true". Groups can be nested. Sort, limit and cursor options are not allowed in groups.
+func WithAnyOf(opts ...FindOption) FindOption {
+	return func(o *FindOptions) {
+		if children := o.groupChildren("anyOf", opts); children != nil {
+			o.Groups = append(o.Groups, FindExpr{AnyOf: children})
+		}
+	}
+}
+
+// WithAllOf adds a group matching items that match all the matchers and groups of opts, typically nested
+// in WithAnyOf. Sort, limit and cursor options are not allowed in groups.
+func WithAllOf(opts ...FindOption) FindOption {
+	return func(o *FindOptions) {
+		if children := o.groupChildren("allOf", opts); children != nil {
+			o.Groups = append(o.Groups, FindExpr{AllOf: children})
+		}
+	}
+}
+
+// groupChildren applies opts to new options, and returns the resulting matchers and groups as expressions.
+// It returns nil, and records the error for Validate, if opts is empty or has other options.
+func (o *FindOptions) groupChildren(name string, opts []FindOption) []FindExpr {
+	group := &FindOptions{}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(group)
+		}
+	}
+
+	var err error
+
+	switch {
+	case group.groupErr != nil:
+		err = group.groupErr
+	case len(group.Sort) > 0 || group.Limit != 0 || group.Cursor != "":
+		err = fmt.Errorf("%s: only matchers and groups are allowed in groups", name)
+	case len(group.Matchers)+len(group.Groups) == 0:
+		err = fmt.Errorf("%s: group cannot be empty", name)
+	}
+
+	if err != nil {
+		if o.groupErr == nil {
+			o.groupErr = err
+		}
+		return nil
+	}
+
+	return group.conditions()
+}
+
+func withMatcher(field IssueField, operator FindMatchOperator, value string) FindOption {
+	return func(o *FindOptions) {
+		o.Matchers = append(o.Matchers, FindMatcher{Field: field, Operator: operator, Value: value})
+	}
+}
+
+// WithSort adds a sort order. Call it multiple times to sort by multiple fields, by priority.
+func WithSort(field IssueField, direction SortDirection) FindOption {
+	return func(o *FindOptions) {
+		o.Sort = append(o.Sort, FindSort{Field: field, Direction: direction})
+	}
+}
+
+// WithLimit sets the maximum number of items per page.
+func WithLimit(n int) FindOption {
+	return func(o *FindOptions) {
+		o.Limit = n
+	}
+}
+
+// WithCursor sets the cursor of the page to fetch, as returned by PageResult.NextCursor.
+func WithCursor(token string) FindOption {
+	return func(o *FindOptions) {
+		o.Cursor = token
+	}
+}
+
+// NewFindOptions applies the provided options, and validates the result.
+func NewFindOptions(opts ...FindOption) (*FindOptions, error) {
+	o := &FindOptions{}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// Validate validates the find options.
+func (o *FindOptions) Validate() error {
+	if o.groupErr != nil {
+		return o.groupErr
+	}
+
+	for i, m := range o.Matchers {
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("matchers[%d]: %w", i, err)
+		}
+	}
+
+	for i, g := range o.Groups {
+		if g.Matcher != nil {
+			return fmt.Errorf("groups[%d]: expected anyOf or allOf group", i)
+		}
+
+		if err := g.Validate(); err != nil {
+			return fmt.Errorf("groups[%d]: %w", i, err)
+		}
+	}
+
+	for i, s := range o.Sort {
+		if s.Field == "" {
+			return fmt.Errorf("sort[%d]: field cannot be empty", i)
+		}
+
+		if !FindFieldIsValid(s.Field) {
+			return fmt.Errorf("sort[%d]: field '%s' is not valid, expected one of [%s] or 'body.<path>'", i, s.Field, strings.Join(ValidIssueFields(), ", "))
+		}
+
+		if !SortDirectionIsValid(s.Direction) {
+			return fmt.Errorf("sort[%d]: direction '%s' is not valid, expected one of [%s]", i, s.Direction, strings.Join(ValidSortDirections(), ", "))
+		}
+	}
+
+	if o.Limit < 0 {
+		return errors.New("limit cannot be negative")
+	}
+
+	return nil
+}
+
+// Expr returns the expression tree of the matchers and groups, combined with AND, so that stores can translate
+// complex queries such as "channel = X AND (severity = panic OR escalated = true)" in a single query.
+// A single condition is returned as is, and the empty expression is returned if there are no conditions.
+func (o *FindOptions) Expr() FindExpr {
+	children := o.conditions()
+
+	switch len(children) {
+	case 0:
+		return FindExpr{}
+	case 1:
+		return children[0]
+	default:
+		return FindExpr{AllOf: children}
+	}
+}
+
+// conditions returns the matchers, as leaf expressions, and the groups.
+func (o *FindOptions) conditions() []FindExpr {
+	conditions := make([]FindExpr, 0, len(o.Matchers)+len(o.Groups))
+
+	for _, m := range o.Matchers {
+		conditions = append(conditions, FindExpr{Matcher: &m})
+	}
+
+	return append(conditions, o.Groups...)
+}
+
+// IsEmpty returns true if the expression has no conditions, and thus matches all items.
+func (e FindExpr) IsEmpty() bool {
+	return e.Matcher == nil && len(e.AllOf) == 0 && len(e.AnyOf) == 0
+}
+
+// Validate validates the expression and its children.
+func (e FindExpr) Validate() error {
+	set := 0
+	if e.Matcher != nil {
+		set++
+	}
+	if e.AllOf != nil {
+		set++
+	}
+	if e.AnyOf != nil {
+		set++
+	}
+
+	if set > 1 {
+		return errors.New("expression must have exactly one of matcher, allOf and anyOf")
+	}
+
+	switch {
+	case e.Matcher != nil:
+		return e.Matcher.Validate()
+	case e.AllOf != nil:
+		return validateFindExprs("allOf", e.AllOf)
+	case e.AnyOf != nil:
+		return validateFindExprs("anyOf", e.AnyOf)
+	default:
+		return nil
+	}
+}
+
+func validateFindExprs(name string, exprs []FindExpr) error {
+	if len(exprs) == 0 {
+		return fmt.Errorf("%s: group cannot be empty", name)
+	}
+
+	for i, e := range exprs {
+		if e.IsEmpty() {
+			return fmt.Errorf("%s[%d]: expression cannot be empty", name, i)
+		}
+
+		if err := e.Validate(); err != nil {
+			return fmt.Errorf("%s[%d]: %w", name, i, err)
+		}
+	}
+
+	return nil
+}
+
+// Matchers returns all matchers in the expression tree, depth first.
+func (e FindExpr) Matchers() []FindMatcher {
+	var matchers []FindMatcher
+
+	if e.Matcher != nil {
+		matchers = append(matchers, *e.Matcher)
+	}
+
+	for _, child := range e.AllOf {
+		matchers = append(matchers, child.Matchers()...)
+	}
+
+	for _, child := range e.AnyOf {
+		matchers = append(matchers, child.Matchers()...)
+	}
+
+	return matchers
+}
+
+// MatchIssue returns true if the issue matches the expression. The empty expression matches all issues.
+// Use it to evaluate expressions in memory, when an IssueLister does not support them (see FindCapabilities).
+func (e FindExpr) MatchIssue(issue Issue) bool {
+	if issue == nil {
+		return false
+	}
+
+	var body any
+	decoded := false
+
+	return e.match(func(field IssueField) (any, bool) {
+		path, ok := strings.CutPrefix(string(field), issueFieldBodyPrefix)
+		if !ok {
+			value := issueFieldValue(issue, field)
+			return value, value != ""
+		}
+
+		if !decoded {
+			decoded = true
+
+			if b, err := issue.MarshalJSON(); err == nil {
+				_ = json.Unmarshal(b, &body)
+			}
+		}
+
+		return jsonPathValue(body, path)
+	})
+}
+
+// match evaluates the expression, resolving field values with the value function.
+func (e FindExpr) match(value func(IssueField) (any, bool)) bool {
+	switch {
+	case e.Matcher != nil:
+		return e.Matcher.matchValue(value(e.Matcher.Field))
+	case len(e.AnyOf) > 0:
+		for _, child := range e.AnyOf {
+			if child.match(value) {
+				return true
+			}
+		}
+		return false
+	default:
+		for _, child := range e.AllOf {
+			if !child.match(value) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Validate validates the matcher.
+func (m FindMatcher) Validate() error {
+	if m.Field == "" {
+		return errors.New("field cannot be empty")
+	}
+
+	if !FindFieldIsValid(m.Field) {
+		return fmt.Errorf("field '%s' is not valid, expected one of [%s] or 'body.<path>'", m.Field, strings.Join(ValidIssueFields(), ", "))
+	}
+
+	if !FindMatchOperatorIsValid(m.Operator) {
+		return fmt.Errorf("operator '%s' is not valid, expected one of [%s]", m.Operator, strings.Join(ValidFindMatchOperators(), ", "))
+	}
+
+	switch m.Operator {
+	case FindMatchExists:
+		if m.Value != "" {
+			return errors.New("value must be empty for operator 'exists'")
+		}
+	case FindMatchPrefix, FindMatchContains:
+		if m.Value == "" {
+			return fmt.Errorf("value cannot be empty for operator '%s'", m.Operator)
+		}
+	case FindMatchEquals:
+	}
+
+	return nil
+}
+
+// MatchIssue returns true if the issue matches. Body fields are read from the issue JSON.
+// Use it to evaluate matchers in memory, when an IssueLister does not support them (see FindCapabilities).
+func (m FindMatcher) MatchIssue(issue Issue) bool {
+	return FindExpr{Matcher: &m}.MatchIssue(issue)
+}
+
+// matchValue returns true if the resolved field value matches. The second argument is false if the field is missing.
+func (m FindMatcher) matchValue(value any, ok bool) bool {
+	if !ok {
+		return false
+	}
+
+	s := findValueString(value)
+
+	switch m.Operator {
+	case FindMatchEquals:
+		return s == m.Value
+	case FindMatchPrefix:
+		return strings.HasPrefix(s, m.Value)
+	case FindMatchContains:
+		return strings.Contains(s, m.Value)
+	case FindMatchExists:
+		return s != ""
+	default:
+		return false
+	}
+}
+
+// FindCapabilities describes the find options supported by an IssueLister, so that callers can push conditions down
+// to the store when possible, and evaluate the others in memory (see FindMatcher.MatchIssue).
+//
+// Sorting, limit and cursor on the ValidIssueFields are always supported.
+type FindCapabilities struct {
+	// Operators are the supported matcher operators.
+	Operators []FindMatchOperator `json:"operators,omitempty"`
+
+	// BodyFields is true if 'body.<path>' fields are supported, in matchers and sort orders.
+	BodyFields bool `json:"bodyFields"`
+
+	// Groups is true if nested AnyOf and AllOf groups are supported.
+	Groups bool `json:"groups"`
+}
+
+// FindCapabilityReporter is an optional interface for IssueLister implementations, reporting the supported find options.
+// IssueLister implementations that don't implement it are assumed to support no matchers, no body fields and no groups.
+type FindCapabilityReporter interface {
+	FindCapabilities() FindCapabilities
+}
+
+// IssueListerCapabilities returns the find capabilities reported by the lister, or the zero FindCapabilities
+// if it does not implement FindCapabilityReporter.
+func IssueListerCapabilities(lister IssueLister) FindCapabilities {
+	if reporter, ok := lister.(FindCapabilityReporter); ok {
+		return reporter.FindCapabilities()
+	}
+
+	return FindCapabilities{}
+}
+
+// Supports returns true if the matcher can be evaluated by the store.
+func (c FindCapabilities) Supports(m FindMatcher) bool {
+	if !c.BodyFields && strings.HasPrefix(string(m.Field), issueFieldBodyPrefix) {
+		return false
+	}
+
+	return slices.Contains(c.Operators, m.Operator)
+}
+
+// SupportsExpr returns true if the expression, and all its matchers, can be evaluated by the store.
+func (c FindCapabilities) SupportsExpr(e FindExpr) bool {
+	if !c.Groups && (e.AllOf != nil || e.AnyOf != nil) {
+		return false
+	}
+
+	for _, m := range e.Matchers() {
+		if !c.Supports(m) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Check returns an error wrapping ErrFindOptionNotSupported if the options use a matcher, group or sort field
+// that is not supported.
+func (c FindCapabilities) Check(o *FindOptions) error {
+	for i, m := range o.Matchers {
+		if !c.Supports(m) {
+			return fmt.Errorf("matchers[%d]: operator '%s' on field '%s': %w", i, m.Operator, m.Field, ErrFindOptionNotSupported)
+		}
+	}
+
+	for i, g := range o.Groups {
+		if !c.SupportsExpr(g) {
+			return fmt.Errorf("groups[%d]: %w", i, ErrFindOptionNotSupported)
+		}
+	}
+
+	if !c.BodyFields {
+		for i, s := range o.Sort {
+			if strings.HasPrefix(string(s.Field), issueFieldBodyPrefix) {
+				return fmt.Errorf("sort[%d]: field '%s': %w", i, s.Field, ErrFindOptionNotSupported)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Split splits the matchers in the ones supported by the store, to be passed as find options, and the ones
+// to be evaluated in memory with FindMatcher.MatchIssue. Note that evaluating matchers in memory may return
+// pages with fewer items than the limit.
+func (c FindCapabilities) Split(matchers []FindMatcher) (supported, unsupported []FindMatcher) {
+	for _, m := range matchers {
+		if c.Supports(m) {
+			supported = append(supported, m)
+		} else {
+			unsupported = append(unsupported, m)
+		}
+	}
+
+	return supported, unsupported
+}
+
+// SplitGroups splits the groups as Split splits matchers. Unsupported groups are evaluated in memory with
+// FindExpr.MatchIssue.
+func (c FindCapabilities) SplitGroups(groups []FindExpr) (supported, unsupported []FindExpr) {
+	for _, g := range groups {
+		if c.SupportsExpr(g) {
+			supported = append(supported, g)
+		} else {
+			unsupported = append(unsupported, g)
+		}
+	}
+
+	return supported, unsupported
+}
+
+// PageResult is a page of items returned by a list query.
+type PageResult[T any] struct {
+	// Items are the items of the page. It may be empty, even when NextCursor is set.
+	Items []T `json:"items"`
+
+	// NextCursor is the cursor of the next page, to be used with WithCursor. It is empty on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// HasMore returns true if there are more pages after this one.
+func (p *PageResult[T]) HasMore() bool {
+	return p.NextCursor != ""
+}
+
+// jsonPathValue returns the value at the dot separated path in a decoded JSON value.
+// The second return value is false if the path does not exist, or if the value is null.
+func jsonPathValue(v any, path string) (any, bool) {
+	for key := range strings.SplitSeq(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return v, v != nil
+}
+
+// findValueString formats a decoded JSON value for matching. Strings are returned as is, and other values as JSON.
+func findValueString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
+
+// compareFindValues compares two decoded JSON values for sorting. Missing values sort before present values.
+// Numbers, strings and booleans are compared by value, and other values (or values of different types) by their
+// fmt.Sprint representation.
+func compareFindValues(a any, aok bool, b any, bok bool) int {
+	switch {
+	case !aok && !bok:
+		return 0
+	case !aok:
+		return -1
+	case !bok:
+		return 1
+	}
+
+	switch av := a.(type) {
+	case float64:
+		if bv, ok := b.(float64); ok {
+			return cmp.Compare(av, bv)
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv)
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			switch {
+			case av == bv:
+				return 0
+			case bv:
+				return -1
+			default:
+				return 1
+			}
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
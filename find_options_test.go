@@ -0,0 +1,396 @@
+package types_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type listIssue struct {
+	ID            string         `json:"id"`
+	Channel       string         `json:"channel"`
+	CorrelationID string         `json:"correlationId"`
+	Open          bool           `json:"open"`
+	Details       map[string]any `json:"details,omitempty"`
+}
+
+func (i *listIssue) MarshalJSON() ([]byte, error) {
+	type alias listIssue
+	return json.Marshal((*alias)(i))
+}
+
+func (i *listIssue) ChannelID() string        { return i.Channel }
+func (i *listIssue) UniqueID() string         { return i.ID }
+func (i *listIssue) GetCorrelationID() string { return i.CorrelationID }
+func (i *listIssue) IsOpen() bool             { return i.Open }
+func (i *listIssue) CurrentPostID() string    { return "" }
+
+func listIssueIDs(page *types.PageResult[types.IssueRecord]) []string {
+	ids := make([]string, 0, len(page.Items))
+	for _, item := range page.Items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestNewFindOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("options should be applied in order", func(t *testing.T) {
+		t.Parallel()
+
+		o, err := types.NewFindOptions(
+			types.WithSort(types.IssueFieldChannelID, types.SortAscending),
+			types.WithSort("body.lastAlert.severity", types.SortDescending),
+			types.WithLimit(50),
+			types.WithCursor("abc"),
+			nil,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, &types.FindOptions{
+			Sort: []types.FindSort{
+				{Field: types.IssueFieldChannelID, Direction: types.SortAscending},
+				{Field: "body.lastAlert.severity", Direction: types.SortDescending},
+			},
+			Limit:  50,
+			Cursor: "abc",
+		}, o)
+	})
+
+	t.Run("invalid options should return error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.NewFindOptions(types.WithLimit(-1))
+		require.EqualError(t, err, "limit cannot be negative")
+
+		_, err = types.NewFindOptions(types.WithSort("", types.SortAscending))
+		require.EqualError(t, err, "sort[0]: field cannot be empty")
+
+		_, err = types.NewFindOptions(types.WithSort(types.IssueFieldStatus, types.SortAscending), types.WithSort("severity", types.SortAscending))
+		require.EqualError(t, err, "sort[1]: field 'severity' is not valid, expected one of [channel_id, correlation_id, status] or 'body.<path>'")
+
+		_, err = types.NewFindOptions(types.WithSort(types.IssueFieldStatus, "up"))
+		require.EqualError(t, err, "sort[0]: direction 'up' is not valid, expected one of [asc, desc]")
+	})
+
+	t.Run("body fields should require a valid path", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, types.FindFieldIsValid("body.details.rank"))
+		assert.True(t, types.FindFieldIsValid(types.IssueFieldCorrelationID))
+		assert.False(t, types.FindFieldIsValid("body."))
+		assert.False(t, types.FindFieldIsValid("body..rank"))
+		assert.False(t, types.FindFieldIsValid("body.details."))
+	})
+}
+
+func TestInMemoryDBListIssues(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := types.NewInMemoryDB()
+
+	require.NoError(t, db.SaveIssues(ctx,
+		&listIssue{ID: "1", Channel: "C2", CorrelationID: "b", Open: true, Details: map[string]any{"rank": 3}},
+		&listIssue{ID: "2", Channel: "C1", CorrelationID: "a", Details: map[string]any{"rank": 10}},
+		&listIssue{ID: "3", Channel: "C2", CorrelationID: "a", Open: true},
+		&listIssue{ID: "4", Channel: "C1", CorrelationID: "c", Open: true, Details: map[string]any{"rank": 3}},
+	))
+
+	t.Run("issues should be sorted by unique ID by default", func(t *testing.T) {
+		t.Parallel()
+
+		page, err := db.ListIssues(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3", "4"}, listIssueIDs(page))
+		assert.False(t, page.HasMore())
+	})
+
+	t.Run("issues should be sorted by multiple fields", func(t *testing.T) {
+		t.Parallel()
+
+		page, err := db.ListIssues(ctx, types.WithSort(types.IssueFieldChannelID, types.SortAscending), types.WithSort(types.IssueFieldCorrelationID, types.SortDescending))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"4", "2", "1", "3"}, listIssueIDs(page))
+
+		page, err = db.ListIssues(ctx, types.WithSort(types.IssueFieldStatus, types.SortAscending))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2", "1", "3", "4"}, listIssueIDs(page))
+	})
+
+	t.Run("body fields should be sorted numerically with missing values first", func(t *testing.T) {
+		t.Parallel()
+
+		page, err := db.ListIssues(ctx, types.WithSort("body.details.rank", types.SortAscending))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"3", "1", "4", "2"}, listIssueIDs(page))
+
+		page, err = db.ListIssues(ctx, types.WithSort("body.details.rank", types.SortDescending))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2", "1", "4", "3"}, listIssueIDs(page))
+	})
+
+	t.Run("cursor should return the next page", func(t *testing.T) {
+		t.Parallel()
+
+		page, err := db.ListIssues(ctx, types.WithLimit(3))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3"}, listIssueIDs(page))
+		require.True(t, page.HasMore())
+
+		page, err = db.ListIssues(ctx, types.WithLimit(3), types.WithCursor(page.NextCursor))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"4"}, listIssueIDs(page))
+		assert.False(t, page.HasMore())
+
+		_, err = db.ListIssues(ctx, types.WithCursor("!"))
+		require.EqualError(t, err, "cursor '!' is not valid")
+	})
+}
+
+func TestFindMatchers(t *testing.T) {
+	t.Parallel()
+
+	issue := &listIssue{ID: "1", Channel: "C1", CorrelationID: "deploy/api", Open: true, Details: map[string]any{"rank": 3, "team": "", "escalated": true}}
+
+	t.Run("matchers should match issue fields and body fields", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			matcher types.FindMatcher
+			match   bool
+		}{
+			{types.FindMatcher{Field: types.IssueFieldCorrelationID, Operator: types.FindMatchPrefix, Value: "deploy/"}, true},
+			{types.FindMatcher{Field: types.IssueFieldCorrelationID, Operator: types.FindMatchPrefix, Value: "api"}, false},
+			{types.FindMatcher{Field: types.IssueFieldCorrelationID, Operator: types.FindMatchContains, Value: "api"}, true},
+			{types.FindMatcher{Field: types.IssueFieldStatus, Operator: types.FindMatchEquals, Value: "open"}, true},
+			{types.FindMatcher{Field: types.IssueFieldChannelID, Operator: types.FindMatchExists}, true},
+			{types.FindMatcher{Field: "body.details.rank", Operator: types.FindMatchEquals, Value: "3"}, true},
+			{types.FindMatcher{Field: "body.details.escalated", Operator: types.FindMatchEquals, Value: "true"}, true},
+			{types.FindMatcher{Field: "body.details.rank", Operator: types.FindMatchExists}, true},
+			{types.FindMatcher{Field: "body.details.team", Operator: types.FindMatchExists}, false},
+			{types.FindMatcher{Field: "body.details.missing", Operator: types.FindMatchExists}, false},
+			{types.FindMatcher{Field: "body.details.missing", Operator: types.FindMatchEquals}, false},
+		}
+
+		for _, tt := range tests {
+			assert.Equal(t, tt.match, tt.matcher.MatchIssue(issue), "%+v", tt.matcher)
+		}
+
+		assert.False(t, types.FindMatcher{Field: types.IssueFieldChannelID, Operator: types.FindMatchExists}.MatchIssue(nil))
+	})
+
+	t.Run("invalid matchers should return error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.NewFindOptions(types.WithKeyPrefix(types.IssueFieldCorrelationID, ""))
+		require.EqualError(t, err, "matchers[0]: value cannot be empty for operator 'prefix'")
+
+		_, err = types.NewFindOptions(types.WithKeyExists("body."))
+		require.EqualError(t, err, "matchers[0]: field 'body.' is not valid, expected one of [channel_id, correlation_id, status] or 'body.<path>'")
+
+		_, err = types.NewFindOptions(types.WithMatchers(types.FindMatcher{Field: types.IssueFieldStatus, Operator: "regex", Value: ".*"}))
+		require.EqualError(t, err, "matchers[0]: operator 'regex' is not valid, expected one of [equals, prefix, contains, exists]")
+
+		_, err = types.NewFindOptions(types.WithMatchers(types.FindMatcher{Field: types.IssueFieldStatus, Operator: types.FindMatchExists, Value: "open"}))
+		require.EqualError(t, err, "matchers[0]: value must be empty for operator 'exists'")
+	})
+}
+
+func TestFindCapabilities(t *testing.T) {
+	t.Parallel()
+
+	caps := types.FindCapabilities{Operators: []types.FindMatchOperator{types.FindMatchEquals, types.FindMatchPrefix}}
+
+	o, err := types.NewFindOptions(
+		types.WithKeyPrefix(types.IssueFieldCorrelationID, "deploy/"),
+		types.WithKeyContains(types.IssueFieldCorrelationID, "api"),
+		types.WithKeyEquals("body.details.rank", "3"),
+	)
+	require.NoError(t, err)
+
+	err = caps.Check(o)
+	require.ErrorIs(t, err, types.ErrFindOptionNotSupported)
+	require.EqualError(t, err, "matchers[1]: operator 'contains' on field 'correlation_id': find option is not supported")
+
+	supported, unsupported := caps.Split(o.Matchers)
+	assert.Equal(t, []types.FindMatcher{o.Matchers[0]}, supported)
+	assert.Equal(t, []types.FindMatcher{o.Matchers[1], o.Matchers[2]}, unsupported)
+
+	o, err = types.NewFindOptions(types.WithKeyEquals(types.IssueFieldStatus, "open"), types.WithSort("body.details.rank", types.SortAscending))
+	require.NoError(t, err)
+	require.ErrorIs(t, caps.Check(o), types.ErrFindOptionNotSupported)
+
+	caps.BodyFields = true
+	require.NoError(t, caps.Check(o))
+
+	db := types.NewInMemoryDB()
+	assert.True(t, types.IssueListerCapabilities(db).BodyFields)
+	assert.Equal(t, types.FindCapabilities{}, types.IssueListerCapabilities(struct{ types.IssueLister }{db}))
+}
+
+func TestInMemoryDBListIssuesMatchers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := types.NewInMemoryDB()
+
+	require.NoError(t, db.SaveIssues(ctx,
+		&listIssue{ID: "1", Channel: "C1", CorrelationID: "deploy/api", Open: true, Details: map[string]any{"team": "platform"}},
+		&listIssue{ID: "2", Channel: "C1", CorrelationID: "deploy/web"},
+		&listIssue{ID: "3", Channel: "C2", CorrelationID: "disk/api", Open: true, Details: map[string]any{"team": "storage"}},
+	))
+
+	page, err := db.ListIssues(ctx, types.WithKeyPrefix(types.IssueFieldCorrelationID, "deploy/"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, listIssueIDs(page))
+
+	page, err = db.ListIssues(ctx, types.WithKeyContains(types.IssueFieldCorrelationID, "api"), types.WithKeyExists("body.details.team"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "3"}, listIssueIDs(page))
+
+	page, err = db.ListIssues(ctx, types.WithKeyContains(types.IssueFieldCorrelationID, "api"), types.WithKeyEquals(types.IssueFieldChannelID, "C2"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3"}, listIssueIDs(page))
+
+	page, err = db.ListIssues(ctx, types.WithKeyExists("body.details.team"), types.WithLimit(1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, listIssueIDs(page))
+	assert.True(t, page.HasMore())
+}
+
+func TestFindGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("groups should build the expression tree", func(t *testing.T) {
+		t.Parallel()
+
+		o, err := types.NewFindOptions(
+			types.WithKeyEquals(types.IssueFieldChannelID, "C1"),
+			types.WithAnyOf(
+				types.WithKeyEquals("body.severity", "panic"),
+				types.WithAllOf(types.WithKeyEquals("body.escalated", "true"), types.WithKeyExists("body.team")),
+			),
+			types.WithLimit(10),
+		)
+		require.NoError(t, err)
+
+		channel := types.FindMatcher{Field: types.IssueFieldChannelID, Operator: types.FindMatchEquals, Value: "C1"}
+		panicSeverity := types.FindMatcher{Field: "body.severity", Operator: types.FindMatchEquals, Value: "panic"}
+		escalated := types.FindMatcher{Field: "body.escalated", Operator: types.FindMatchEquals, Value: "true"}
+		team := types.FindMatcher{Field: "body.team", Operator: types.FindMatchExists}
+
+		expr := o.Expr()
+		assert.Equal(t, types.FindExpr{AllOf: []types.FindExpr{
+			{Matcher: &channel},
+			{AnyOf: []types.FindExpr{
+				{Matcher: &panicSeverity},
+				{AllOf: []types.FindExpr{{Matcher: &escalated}, {Matcher: &team}}},
+			}},
+		}}, expr)
+		assert.Equal(t, []types.FindMatcher{channel, panicSeverity, escalated, team}, expr.Matchers())
+		require.NoError(t, expr.Validate())
+
+		body, err := json.Marshal(expr)
+		require.NoError(t, err)
+
+		var decoded types.FindExpr
+		require.NoError(t, json.Unmarshal(body, &decoded))
+		assert.Equal(t, expr, decoded)
+	})
+
+	t.Run("expressions should be evaluated in memory", func(t *testing.T) {
+		t.Parallel()
+
+		o, err := types.NewFindOptions(
+			types.WithKeyEquals(types.IssueFieldChannelID, "C1"),
+			types.WithAnyOf(
+				types.WithKeyEquals("body.details.severity", "panic"),
+				types.WithAllOf(types.WithKeyEquals("body.details.escalated", "true"), types.WithKeyExists("body.details.team")),
+			),
+		)
+		require.NoError(t, err)
+
+		expr := o.Expr()
+		assert.True(t, expr.MatchIssue(&listIssue{Channel: "C1", Details: map[string]any{"severity": "panic"}}))
+		assert.True(t, expr.MatchIssue(&listIssue{Channel: "C1", Details: map[string]any{"escalated": true, "team": "a"}}))
+		assert.False(t, expr.MatchIssue(&listIssue{Channel: "C1", Details: map[string]any{"escalated": true}}))
+		assert.False(t, expr.MatchIssue(&listIssue{Channel: "C2", Details: map[string]any{"severity": "panic"}}))
+		assert.False(t, expr.MatchIssue(nil))
+		assert.True(t, types.FindExpr{}.MatchIssue(&listIssue{}))
+	})
+
+	t.Run("single conditions should not be wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		o, err := types.NewFindOptions(types.WithAnyOf(types.WithAllOf(types.WithKeyExists("body.a"), types.WithKeyExists("body.b"))))
+		require.NoError(t, err)
+		assert.Len(t, o.Expr().AnyOf, 1)
+		assert.Len(t, o.Expr().AnyOf[0].AllOf, 2)
+
+		o, err = types.NewFindOptions()
+		require.NoError(t, err)
+		assert.True(t, o.Expr().IsEmpty())
+	})
+
+	t.Run("invalid groups should return error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.NewFindOptions(types.WithAnyOf())
+		require.EqualError(t, err, "anyOf: group cannot be empty")
+
+		_, err = types.NewFindOptions(types.WithAnyOf(types.WithKeyExists("body.a"), types.WithAllOf(types.WithLimit(1))))
+		require.EqualError(t, err, "allOf: only matchers and groups are allowed in groups")
+
+		_, err = types.NewFindOptions(types.WithAllOf(types.WithKeyPrefix(types.IssueFieldCorrelationID, "")))
+		require.EqualError(t, err, "groups[0]: allOf[0]: value cannot be empty for operator 'prefix'")
+
+		_, err = types.NewFindOptions(types.WithGroups(types.FindExpr{AnyOf: []types.FindExpr{{}}}))
+		require.EqualError(t, err, "groups[0]: anyOf[0]: expression cannot be empty")
+	})
+
+	t.Run("unsupported groups should be split", func(t *testing.T) {
+		t.Parallel()
+
+		o, err := types.NewFindOptions(
+			types.WithAnyOf(types.WithKeyEquals(types.IssueFieldStatus, "open"), types.WithKeyPrefix(types.IssueFieldCorrelationID, "a")),
+			types.WithAnyOf(types.WithKeyContains(types.IssueFieldCorrelationID, "a"), types.WithKeyEquals(types.IssueFieldChannelID, "C1")),
+		)
+		require.NoError(t, err)
+
+		caps := types.FindCapabilities{Operators: []types.FindMatchOperator{types.FindMatchEquals, types.FindMatchPrefix}}
+		require.ErrorIs(t, caps.Check(o), types.ErrFindOptionNotSupported)
+
+		caps.Groups = true
+		require.EqualError(t, caps.Check(o), "groups[1]: find option is not supported")
+
+		supported, unsupported := caps.SplitGroups(o.Groups)
+		assert.Equal(t, o.Groups[:1], supported)
+		assert.Equal(t, o.Groups[1:], unsupported)
+	})
+}
+
+func TestInMemoryDBListIssuesGroups(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := types.NewInMemoryDB()
+
+	require.NoError(t, db.SaveIssues(ctx,
+		&listIssue{ID: "1", Channel: "C1", Open: true, Details: map[string]any{"severity": "panic"}},
+		&listIssue{ID: "2", Channel: "C1", Open: true, Details: map[string]any{"severity": "error", "escalated": true}},
+		&listIssue{ID: "3", Channel: "C1", Open: true, Details: map[string]any{"severity": "error"}},
+		&listIssue{ID: "4", Channel: "C2", Open: true, Details: map[string]any{"severity": "panic"}},
+	))
+
+	page, err := db.ListIssues(ctx,
+		types.WithKeyEquals(types.IssueFieldChannelID, "C1"),
+		types.WithAnyOf(types.WithKeyEquals("body.details.severity", "panic"), types.WithKeyEquals("body.details.escalated", "true")),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, listIssueIDs(page))
+}
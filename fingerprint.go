@@ -0,0 +1,83 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var (
+	fingerprintTimestampRegex = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?(Z|[+-]\d{2}:?\d{2})?`)
+	fingerprintUUIDRegex      = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	fingerprintIPv4Regex      = regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`)
+	fingerprintIPv6Regex      = regexp.MustCompile(`(?i)\b[0-9a-f]{1,4}(:[0-9a-f]{1,4}){7}\b|[0-9a-f]{0,4}(:[0-9a-f]{1,4})*::([0-9a-f]{1,4}(:[0-9a-f]{1,4})*)?`)
+	fingerprintHexRegex       = regexp.MustCompile(`(?i)\b(0x[0-9a-f]+|[0-9a-f]{8,})\b`)
+	fingerprintNumberRegex    = regexp.MustCompile(`\d+(\.\d+)?`)
+)
+
+// fingerprintRule replaces matches of a regex with a placeholder.
+type fingerprintRule struct {
+	regex       *regexp.Regexp
+	replacement string
+}
+
+// Fingerprinter computes alert fingerprints, which are hashes of the alert header and text with volatile tokens
+// (timestamps, UUIDs, IP addresses, hex IDs and numbers) replaced by placeholders. Near-identical messages,
+// such as "Request 4711 failed at 10:15" and "Request 4712 failed at 10:16", get the same fingerprint.
+//
+// Fingerprinter implements CorrelationStrategy. It is safe for concurrent use.
+type Fingerprinter struct {
+	rules []fingerprintRule
+}
+
+// NewFingerprinter creates a new Fingerprinter with the built-in normalization rules.
+// The extra patterns are regular expressions applied before the built-in rules, with matches replaced by '<x>'.
+// An error is returned if a pattern is not a valid regular expression.
+func NewFingerprinter(extraPatterns ...string) (*Fingerprinter, error) {
+	f := &Fingerprinter{}
+
+	for _, pattern := range extraPatterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fingerprint pattern '%s': %w", pattern, err)
+		}
+
+		f.rules = append(f.rules, fingerprintRule{regex: regex, replacement: "<x>"})
+	}
+
+	f.rules = append(f.rules,
+		fingerprintRule{regex: fingerprintTimestampRegex, replacement: "<ts>"},
+		fingerprintRule{regex: fingerprintUUIDRegex, replacement: "<uuid>"},
+		fingerprintRule{regex: fingerprintIPv4Regex, replacement: "<ip>"},
+		fingerprintRule{regex: fingerprintIPv6Regex, replacement: "<ip>"},
+		fingerprintRule{regex: fingerprintHexRegex, replacement: "<hex>"},
+		fingerprintRule{regex: fingerprintNumberRegex, replacement: "<n>"},
+	)
+
+	return f, nil
+}
+
+// Normalize replaces the volatile tokens in s with placeholders.
+func (f *Fingerprinter) Normalize(s string) string {
+	for _, rule := range f.rules {
+		s = rule.regex.ReplaceAllString(s, rule.replacement)
+	}
+
+	return s
+}
+
+// Fingerprint returns the fingerprint of the alert, based on the normalized header and text, and the Slack channel ID
+// and route key.
+func (f *Fingerprinter) Fingerprint(a *Alert) string {
+	return hash("fingerprint", a.SlackChannelID, a.RouteKey, f.Normalize(a.Header), f.Normalize(a.Text))
+}
+
+// CorrelationID returns the alert fingerprint, which makes Fingerprinter a CorrelationStrategy.
+func (f *Fingerprinter) CorrelationID(a *Alert) string {
+	return f.Fingerprint(a)
+}
+
+// Fingerprint returns the alert fingerprint, computed by a Fingerprinter with the built-in normalization rules.
+func (a *Alert) Fingerprint() string {
+	f, _ := NewFingerprinter()
+	return f.Fingerprint(a)
+}
@@ -0,0 +1,63 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprinterNormalize(t *testing.T) {
+	t.Parallel()
+
+	f, err := types.NewFingerprinter()
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"Request 4711 failed":                                   "Request <n> failed",
+		"Failed at 2026-03-01T10:15:00.123Z":                    "Failed at <ts>",
+		"Failed at 2026-03-01 10:15:00+02:00":                   "Failed at <ts>",
+		"Job 3f2b8c1e-5d4a-4b6f-9e7d-1a2b3c4d5e6f failed":       "Job <uuid> failed",
+		"Connection to 10.0.12.7:5432 refused":                  "Connection to <ip> refused",
+		"Connection to fe80::1:2:3 refused":                     "Connection to <ip> refused",
+		"Commit 9fceb02d0ae598e95dc970b74767f19372d61af8 broke": "Commit <hex> broke",
+		"Pointer 0x7ffe1234 is invalid":                         "Pointer <hex> is invalid",
+		"Latency 12.5ms above threshold":                        "Latency <n>ms above threshold",
+		"Disk full":                                             "Disk full",
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, f.Normalize(input), input)
+	}
+}
+
+func TestFingerprinterExtraPatterns(t *testing.T) {
+	t.Parallel()
+
+	_, err := types.NewFingerprinter("(")
+	require.ErrorContains(t, err, "invalid fingerprint pattern '('")
+
+	f, err := types.NewFingerprinter(`user=\w+`)
+	require.NoError(t, err)
+	assert.Equal(t, "Login failed for <x>", f.Normalize("Login failed for user=jane"))
+}
+
+func TestAlertFingerprint(t *testing.T) {
+	t.Parallel()
+
+	a1 := &types.Alert{SlackChannelID: "C1", Header: "Request 4711 failed", Text: "at 2026-03-01T10:15:00Z from 10.0.0.1"}
+	a2 := &types.Alert{SlackChannelID: "C1", Header: "Request 4712 failed", Text: "at 2026-03-01T10:16:00Z from 10.0.0.2"}
+	a3 := &types.Alert{SlackChannelID: "C2", Header: "Request 4712 failed", Text: "at 2026-03-01T10:16:00Z from 10.0.0.2"}
+	a4 := &types.Alert{SlackChannelID: "C1", Header: "Request 4712 timed out", Text: "at 2026-03-01T10:16:00Z from 10.0.0.2"}
+
+	assert.Equal(t, a1.Fingerprint(), a2.Fingerprint())
+	assert.NotEqual(t, a1.Fingerprint(), a3.Fingerprint())
+	assert.NotEqual(t, a1.Fingerprint(), a4.Fingerprint())
+
+	f, err := types.NewFingerprinter()
+	require.NoError(t, err)
+
+	var strategy types.CorrelationStrategy = f
+	assert.Equal(t, a1.Fingerprint(), strategy.CorrelationID(a1))
+}
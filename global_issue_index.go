@@ -0,0 +1,43 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// MaxGlobalIssueKeyLength is the maximum length of the global issue key.
+const MaxGlobalIssueKeyLength = 500
+
+// GlobalIssueReference is an entry in the global issue index, linking a workspace-wide global issue key
+// to an issue in a specific Slack channel.
+type GlobalIssueReference struct {
+	// GlobalIssueKey is the workspace-wide key shared by all issues for the same underlying incident.
+	GlobalIssueKey string `json:"globalIssueKey"`
+
+	// ChannelID is the Slack channel ID of the referenced issue.
+	ChannelID string `json:"channelId"`
+
+	// IssueID is the unique ID of the referenced issue, as returned by Issue.UniqueID.
+	IssueID string `json:"issueId"`
+
+	// Created is the time when the reference was first added to the index.
+	Created time.Time `json:"created"`
+}
+
+// GlobalIssueIndex is an interface for cross-referencing issues in different channels that share the same global issue key.
+// It allows the same underlying incident, reported into multiple channels, to be found and resolved together.
+//
+// The index is optional. Database implementations may implement it alongside the DB interface.
+type GlobalIssueIndex interface {
+	// SaveGlobalIssueReference creates or updates a single reference in the index.
+	// A reference is uniquely identified by its global issue key, channel ID and issue ID.
+	SaveGlobalIssueReference(ctx context.Context, ref *GlobalIssueReference) error
+
+	// FindGlobalIssueReferences returns all references for the specified global issue key.
+	// The returned list may be empty if no references are found.
+	FindGlobalIssueReferences(ctx context.Context, globalIssueKey string) ([]*GlobalIssueReference, error)
+
+	// DeleteGlobalIssueReference deletes a single reference from the index.
+	// No error is returned if the reference does not exist.
+	DeleteGlobalIssueReference(ctx context.Context, globalIssueKey, channelID, issueID string) error
+}
@@ -0,0 +1,273 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultHealthCheckTimeout is the timeout of each health check, when NewHealthRegistry is called with a
+// non-positive timeout.
+const DefaultHealthCheckTimeout = 5 * time.Second
+
+// HealthStatus is the status of a health check, or the aggregated status of a HealthRegistry.
+type HealthStatus string
+
+const (
+	// HealthStatusUp means that the component (or all components) is healthy.
+	HealthStatusUp HealthStatus = "up"
+
+	// HealthStatusDegraded means that optional components are unhealthy, while required components are healthy.
+	// A degraded service is still ready to serve.
+	HealthStatusDegraded HealthStatus = "degraded"
+
+	// HealthStatusDown means that the component (or a required component) is unhealthy.
+	HealthStatusDown HealthStatus = "down"
+)
+
+func HealthStatusIsValid(s HealthStatus) bool {
+	switch s {
+	case HealthStatusUp, HealthStatusDegraded, HealthStatusDown:
+		return true
+	}
+	return false
+}
+
+func ValidHealthStatuses() []string {
+	return []string{
+		string(HealthStatusUp),
+		string(HealthStatusDegraded),
+		string(HealthStatusDown),
+	}
+}
+
+// HealthChecker is implemented by components that can report their readiness, such as queue adapters, stores
+// and the Slack client, so that services can aggregate them uniformly in a HealthRegistry.
+type HealthChecker interface {
+	// CheckHealth returns nil if the component is healthy, and an error describing the problem otherwise.
+	// It should return promptly when the context is canceled.
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthCheckFunc is a function implementing the HealthChecker interface.
+type HealthCheckFunc func(ctx context.Context) error
+
+// CheckHealth calls f.
+func (f HealthCheckFunc) CheckHealth(ctx context.Context) error {
+	return f(ctx)
+}
+
+// HealthCheckResult is the result of a single health check in a HealthReport.
+type HealthCheckResult struct {
+	// Status is HealthStatusUp or HealthStatusDown.
+	Status HealthStatus `json:"status"`
+
+	// Optional is true if the check was registered with RegisterOptional.
+	Optional bool `json:"optional,omitempty"`
+
+	// Error is the error returned by the check, if any.
+	Error string `json:"error,omitempty"`
+
+	// DurationMs is the duration of the check, in milliseconds.
+	DurationMs int64 `json:"durationMs"`
+}
+
+// HealthReport is the aggregated result of the health checks of a HealthRegistry.
+type HealthReport struct {
+	// Status is HealthStatusDown if a required check failed, HealthStatusDegraded if an optional check failed,
+	// and HealthStatusUp otherwise.
+	Status HealthStatus `json:"status"`
+
+	// Checks holds the result of each check, by name.
+	Checks map[string]*HealthCheckResult `json:"checks"`
+
+	// Timestamp is the time when the checks were started.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// HealthRegistry holds named health checks, and aggregates their results in a HealthReport.
+// Handler serves the report as a readiness probe. It is safe for concurrent use.
+type HealthRegistry struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]*registeredHealthCheck
+}
+
+type registeredHealthCheck struct {
+	checker  HealthChecker
+	optional bool
+}
+
+// NewHealthRegistry creates a new, empty HealthRegistry. Each check is canceled after the specified timeout.
+// If timeout is zero or negative, DefaultHealthCheckTimeout is used.
+func NewHealthRegistry(timeout time.Duration) *HealthRegistry {
+	if timeout <= 0 {
+		timeout = DefaultHealthCheckTimeout
+	}
+
+	return &HealthRegistry{
+		timeout: timeout,
+		checks:  make(map[string]*registeredHealthCheck),
+	}
+}
+
+// Register registers a required health check. The service is not ready while a required check fails.
+// An error is returned if the name is empty or already registered, or the checker is nil.
+func (r *HealthRegistry) Register(name string, checker HealthChecker) error {
+	return r.register(name, checker, false)
+}
+
+// RegisterOptional registers an optional health check. A failing optional check degrades the status,
+// but the service remains ready. An error is returned if the name is empty or already registered, or the checker is nil.
+func (r *HealthRegistry) RegisterOptional(name string, checker HealthChecker) error {
+	return r.register(name, checker, true)
+}
+
+// Names returns the names of the registered checks, in alphabetical order.
+func (r *HealthRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Check runs all checks concurrently, each with the registry timeout, and returns the aggregated report.
+func (r *HealthRegistry) Check(ctx context.Context) *HealthReport {
+	r.mu.RLock()
+	checks := make(map[string]*registeredHealthCheck, len(r.checks))
+	for name, check := range r.checks {
+		checks[name] = check
+	}
+	r.mu.RUnlock()
+
+	report := &HealthReport{
+		Status:    HealthStatusUp,
+		Checks:    make(map[string]*HealthCheckResult, len(checks)),
+		Timestamp: time.Now().UTC(),
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for name, check := range checks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result := r.run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[name] = result
+
+			switch {
+			case result.Status == HealthStatusUp:
+			case !check.optional:
+				report.Status = HealthStatusDown
+			case report.Status == HealthStatusUp:
+				report.Status = HealthStatusDegraded
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+// Handler returns an http.Handler serving the JSON report of Check. The response status is 200 (OK) when the
+// status is up or degraded, and 503 (Service Unavailable) when it is down, as expected by readiness probes.
+func (r *HealthRegistry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := r.Check(req.Context())
+
+		status := http.StatusOK
+		if report.Status == HealthStatusDown {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+func (r *HealthRegistry) register(name string, checker HealthChecker, optional bool) error {
+	if name == "" {
+		return errors.New("health check name cannot be empty")
+	}
+
+	if checker == nil {
+		return fmt.Errorf("health check '%s' cannot be nil", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.checks[name]; ok {
+		return fmt.Errorf("health check '%s' is already registered", name)
+	}
+
+	r.checks[name] = &registeredHealthCheck{checker: checker, optional: optional}
+
+	return nil
+}
+
+// run runs a single check with the registry timeout, converting panics to errors.
+func (r *HealthRegistry) run(ctx context.Context, check *registeredHealthCheck) *HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	started := time.Now()
+	done := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("health check panic: %v", p)
+			}
+		}()
+
+		done <- check.checker.CheckHealth(ctx)
+	}()
+
+	var err error
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("health check timed out: %w", ctx.Err())
+	}
+
+	result := &HealthCheckResult{
+		Status:     HealthStatusUp,
+		Optional:   check.optional,
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+
+	if err != nil {
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
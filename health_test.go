@@ -0,0 +1,124 @@
+package types_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthRegistry(t *testing.T) {
+	t.Parallel()
+
+	healthy := types.HealthCheckFunc(func(context.Context) error { return nil })
+	failing := types.HealthCheckFunc(func(context.Context) error { return errors.New("connection refused") })
+
+	t.Run("all healthy checks should report up", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewHealthRegistry(0)
+		require.NoError(t, registry.Register("queue", healthy))
+		require.NoError(t, registry.RegisterOptional("slack", healthy))
+
+		report := registry.Check(context.Background())
+		assert.Equal(t, types.HealthStatusUp, report.Status)
+		assert.Equal(t, types.HealthStatusUp, report.Checks["queue"].Status)
+		assert.True(t, report.Checks["slack"].Optional)
+		assert.Equal(t, []string{"queue", "slack"}, registry.Names())
+	})
+
+	t.Run("failing optional checks should degrade the status", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewHealthRegistry(0)
+		require.NoError(t, registry.Register("queue", healthy))
+		require.NoError(t, registry.RegisterOptional("slack", failing))
+
+		report := registry.Check(context.Background())
+		assert.Equal(t, types.HealthStatusDegraded, report.Status)
+		assert.Equal(t, "connection refused", report.Checks["slack"].Error)
+	})
+
+	t.Run("failing required checks should report down", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewHealthRegistry(0)
+		require.NoError(t, registry.Register("db", failing))
+		require.NoError(t, registry.RegisterOptional("slack", failing))
+
+		assert.Equal(t, types.HealthStatusDown, registry.Check(context.Background()).Status)
+	})
+
+	t.Run("slow and panicking checks should fail", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewHealthRegistry(10 * time.Millisecond)
+		require.NoError(t, registry.Register("slow", types.HealthCheckFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(200 * time.Millisecond)
+			return nil
+		})))
+		require.NoError(t, registry.Register("panic", types.HealthCheckFunc(func(context.Context) error {
+			panic("boom")
+		})))
+
+		started := time.Now()
+		report := registry.Check(context.Background())
+
+		assert.Less(t, time.Since(started), 500*time.Millisecond)
+		assert.Equal(t, types.HealthStatusDown, report.Status)
+		assert.Equal(t, "health check timed out: context deadline exceeded", report.Checks["slow"].Error)
+		assert.Equal(t, "health check panic: boom", report.Checks["panic"].Error)
+	})
+
+	t.Run("handler should serve the report as readiness probe", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewHealthRegistry(0)
+		require.NoError(t, registry.Register("queue", healthy))
+
+		rec := httptest.NewRecorder()
+		registry.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var report types.HealthReport
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, types.HealthStatusUp, report.Checks["queue"].Status)
+
+		require.NoError(t, registry.Register("db", failing))
+
+		rec = httptest.NewRecorder()
+		registry.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"status":"down"`)
+	})
+
+	t.Run("invalid registrations should return error", func(t *testing.T) {
+		t.Parallel()
+
+		registry := types.NewHealthRegistry(0)
+		require.NoError(t, registry.Register("queue", healthy))
+
+		require.EqualError(t, registry.Register("queue", healthy), "health check 'queue' is already registered")
+		require.EqualError(t, registry.RegisterOptional("", healthy), "health check name cannot be empty")
+		require.EqualError(t, registry.Register("db", nil), "health check 'db' cannot be nil")
+	})
+}
+
+func TestHealthStatus(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.HealthStatusIsValid(types.HealthStatusDegraded))
+	assert.False(t, types.HealthStatusIsValid("unknown"))
+	assert.Equal(t, []string{"up", "degraded", "down"}, types.ValidHealthStatuses())
+}
@@ -0,0 +1,251 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MinHeartbeatIntervalSeconds is the minimum expected interval between heartbeats.
+	MinHeartbeatIntervalSeconds = 10
+	// MaxHeartbeatIntervalSeconds is the maximum expected interval between heartbeats (7 days).
+	MaxHeartbeatIntervalSeconds = 7 * 24 * 60 * 60
+	// MaxHeartbeatGracePeriodSeconds is the maximum grace period after a missed heartbeat (24 hours).
+	MaxHeartbeatGracePeriodSeconds = 24 * 60 * 60
+	// MaxHeartbeatNameLength is the maximum length of a heartbeat name.
+	MaxHeartbeatNameLength = 100
+)
+
+// Heartbeat is sent periodically by a producer, to signal that it is alive. A "heartbeat missed" alert is raised
+// if no heartbeat with the same correlation ID is received within IntervalSeconds + GracePeriodSeconds
+// (a dead man's switch), and resolved when heartbeats are received again.
+type Heartbeat struct {
+	// CorrelationID identifies the heartbeat, and is used as the correlation ID of the "heartbeat missed" alert.
+	// Required. Maximum length: MaxCorrelationIDLength characters.
+	CorrelationID string `json:"correlationId"`
+
+	// Name is an optional human-readable name of the producer, used in the "heartbeat missed" alert.
+	// It is automatically truncated at MaxHeartbeatNameLength characters. Defaults to the correlation ID.
+	Name string `json:"name"`
+
+	// SlackChannelID is the ID or name of the Slack channel where "heartbeat missed" alerts are posted.
+	// Takes precedence over RouteKey if both are set.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// RouteKey is used to find the Slack channel of "heartbeat missed" alerts, via the routes configured in the Slack Manager.
+	RouteKey string `json:"routeKey"`
+
+	// IntervalSeconds is the expected interval between heartbeats.
+	// Must be between MinHeartbeatIntervalSeconds and MaxHeartbeatIntervalSeconds.
+	IntervalSeconds int `json:"intervalSeconds"`
+
+	// GracePeriodSeconds is the additional time allowed after IntervalSeconds, before the heartbeat is considered missed.
+	// Must be between 0 and MaxHeartbeatGracePeriodSeconds.
+	GracePeriodSeconds int `json:"gracePeriodSeconds"`
+
+	// Severity is the severity of the "heartbeat missed" alert. Must be panic, error or warning. Defaults to error.
+	Severity AlertSeverity `json:"severity"`
+
+	// Timestamp is the time the heartbeat was sent. Defaults to the current time.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Clean normalizes the heartbeat fields, and applies default values.
+func (h *Heartbeat) Clean() {
+	if h == nil {
+		return
+	}
+
+	h.CorrelationID = strings.TrimSpace(h.CorrelationID)
+	h.Name = strings.ReplaceAll(strings.TrimSpace(h.Name), "\n", " ")
+	h.SlackChannelID = NormalizeChannel(h.SlackChannelID)
+	h.RouteKey = strings.ToLower(strings.TrimSpace(h.RouteKey))
+	h.Severity = AlertSeverity(strings.ToLower(strings.TrimSpace(string(h.Severity))))
+
+	if h.Severity == "" {
+		h.Severity = AlertError
+	}
+
+	if h.GracePeriodSeconds < 0 {
+		h.GracePeriodSeconds = 0
+	}
+
+	if h.Timestamp.IsZero() {
+		h.Timestamp = time.Now().UTC()
+	}
+
+	if utf8.RuneCountInString(h.Name) > MaxHeartbeatNameLength {
+		h.Name = strings.TrimSpace(truncateString(h.Name, MaxHeartbeatNameLength-3)) + "..."
+	}
+}
+
+// Validate returns an error if one or more of the fields are invalid. Call Clean first.
+func (h *Heartbeat) Validate() error {
+	if h == nil {
+		return errors.New("heartbeat is nil")
+	}
+
+	if h.CorrelationID == "" {
+		return errors.New("correlationId is required")
+	}
+
+	if len(h.CorrelationID) > MaxCorrelationIDLength {
+		return fmt.Errorf("correlationId is too long, expected length <=%d", MaxCorrelationIDLength)
+	}
+
+	if utf8.RuneCountInString(h.Name) > MaxHeartbeatNameLength {
+		return fmt.Errorf("name is too long, expected length <=%d", MaxHeartbeatNameLength)
+	}
+
+	switch {
+	case h.SlackChannelID != "":
+		if !SlackChannelIDOrNameRegex.MatchString(h.SlackChannelID) {
+			return fmt.Errorf("slackChannelId '%s' is not valid", h.SlackChannelID)
+		}
+	case h.RouteKey != "":
+		if len(h.RouteKey) > MaxRouteKeyLength {
+			return fmt.Errorf("routeKey is too long, expected length <=%d", MaxRouteKeyLength)
+		}
+	default:
+		return errors.New("slackChannelId or routeKey is required")
+	}
+
+	if h.IntervalSeconds < MinHeartbeatIntervalSeconds || h.IntervalSeconds > MaxHeartbeatIntervalSeconds {
+		return fmt.Errorf("intervalSeconds %d is not valid, expected value between %d and %d", h.IntervalSeconds, MinHeartbeatIntervalSeconds, MaxHeartbeatIntervalSeconds)
+	}
+
+	if h.GracePeriodSeconds > MaxHeartbeatGracePeriodSeconds {
+		return fmt.Errorf("gracePeriodSeconds %d is too high, expected value <=%d", h.GracePeriodSeconds, MaxHeartbeatGracePeriodSeconds)
+	}
+
+	if h.Severity != AlertPanic && h.Severity != AlertError && h.Severity != AlertWarning {
+		return fmt.Errorf("severity '%s' is not valid, expected one of [%s, %s, %s]", h.Severity, AlertPanic, AlertError, AlertWarning)
+	}
+
+	return nil
+}
+
+// Deadline returns the time after which the heartbeat is considered missed, if no newer heartbeat is received.
+func (h *Heartbeat) Deadline() time.Time {
+	return h.Timestamp.Add(time.Duration(h.IntervalSeconds+h.GracePeriodSeconds) * time.Second)
+}
+
+// IsMissed returns true if the heartbeat deadline has passed at the given time.
+func (h *Heartbeat) IsMissed(now time.Time) bool {
+	return now.After(h.Deadline())
+}
+
+// MissedAlert returns the "heartbeat missed" alert for this heartbeat, as of the given time.
+func (h *Heartbeat) MissedAlert(now time.Time) *Alert {
+	a := NewAlert(h.Severity)
+
+	a.CorrelationID = h.CorrelationID
+	a.SlackChannelID = h.SlackChannelID
+	a.RouteKey = h.RouteKey
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = MaxAutoResolveSeconds // Resolved by RestoredAlert, not by time
+	a.Header = ":status: Heartbeat missed: " + h.displayName()
+	a.HeaderWhenResolved = ":status: Heartbeat restored: " + h.displayName()
+	a.Text = fmt.Sprintf("No heartbeat received since %s (expected every %s, with a grace period of %s). Silent for %s.",
+		h.Timestamp.UTC().Format(time.RFC3339),
+		time.Duration(h.IntervalSeconds)*time.Second,
+		time.Duration(h.GracePeriodSeconds)*time.Second,
+		now.Sub(h.Timestamp).Truncate(time.Second))
+	a.TextWhenResolved = "Heartbeats are received again."
+
+	return a
+}
+
+// RestoredAlert returns the alert resolving the "heartbeat missed" alert for this heartbeat.
+func (h *Heartbeat) RestoredAlert() *Alert {
+	return h.MissedAlert(h.Timestamp).NewResolution()
+}
+
+func (h *Heartbeat) displayName() string {
+	if h.Name != "" {
+		return h.Name
+	}
+
+	return h.CorrelationID
+}
+
+// HeartbeatMonitor keeps track of the latest heartbeat of each producer, and emits "heartbeat missed" alerts
+// when heartbeats stop, and resolving alerts when they resume. It is safe for concurrent use.
+//
+// The monitor is in-memory only, and is thus only effective within a single process.
+type HeartbeatMonitor struct {
+	mu         sync.Mutex
+	heartbeats map[string]*monitoredHeartbeat
+}
+
+type monitoredHeartbeat struct {
+	latest *Heartbeat
+	missed bool
+}
+
+// NewHeartbeatMonitor creates a new HeartbeatMonitor instance.
+func NewHeartbeatMonitor() *HeartbeatMonitor {
+	return &HeartbeatMonitor{
+		heartbeats: make(map[string]*monitoredHeartbeat),
+	}
+}
+
+// Record records a heartbeat, which must be cleaned and valid. If the previous heartbeat with the same destination
+// and correlation ID was reported as missed, the alert resolving the "heartbeat missed" alert is returned.
+// Otherwise, nil is returned. Heartbeats older than the latest recorded heartbeat are ignored.
+func (m *HeartbeatMonitor) Record(h *Heartbeat) *Alert {
+	key := hash("heartbeat", h.SlackChannelID, h.RouteKey, h.CorrelationID)
+	hb := *h
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.heartbeats[key]
+	if !ok {
+		m.heartbeats[key] = &monitoredHeartbeat{latest: &hb}
+		return nil
+	}
+
+	if hb.Timestamp.Before(entry.latest.Timestamp) {
+		return nil
+	}
+
+	entry.latest = &hb
+
+	if entry.missed {
+		entry.missed = false
+		return hb.RestoredAlert()
+	}
+
+	return nil
+}
+
+// Check returns a "heartbeat missed" alert for each heartbeat whose deadline has passed at the given time.
+// Each missed heartbeat is reported once, until a new heartbeat is recorded.
+func (m *HeartbeatMonitor) Check(now time.Time) []*Alert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var alerts []*Alert
+
+	for _, entry := range m.heartbeats {
+		if !entry.missed && entry.latest.IsMissed(now) {
+			entry.missed = true
+			alerts = append(alerts, entry.latest.MissedAlert(now))
+		}
+	}
+
+	return alerts
+}
+
+// Remove stops monitoring the heartbeat with the specified destination and correlation ID.
+func (m *HeartbeatMonitor) Remove(slackChannelID, routeKey, correlationID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.heartbeats, hash("heartbeat", slackChannelID, routeKey, correlationID))
+}
@@ -0,0 +1,113 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHeartbeat(timestamp time.Time) *types.Heartbeat {
+	h := &types.Heartbeat{
+		CorrelationID:      "billing-job",
+		Name:               "Billing job",
+		SlackChannelID:     "c12345678",
+		IntervalSeconds:    60,
+		GracePeriodSeconds: 30,
+		Timestamp:          timestamp,
+	}
+
+	h.Clean()
+
+	return h
+}
+
+func TestHeartbeatCleanAndValidate(t *testing.T) {
+	t.Parallel()
+
+	h := newHeartbeat(time.Time{})
+	assert.Equal(t, "C12345678", h.SlackChannelID)
+	assert.Equal(t, types.AlertError, h.Severity)
+	assert.False(t, h.Timestamp.IsZero())
+	require.NoError(t, h.Validate())
+
+	tests := []struct {
+		modify   func(h *types.Heartbeat)
+		expected string
+	}{
+		{func(h *types.Heartbeat) { h.CorrelationID = "" }, "correlationId is required"},
+		{func(h *types.Heartbeat) { h.CorrelationID = strings.Repeat("x", 501) }, "correlationId is too long, expected length <=500"},
+		{func(h *types.Heartbeat) { h.SlackChannelID = "" }, "slackChannelId or routeKey is required"},
+		{func(h *types.Heartbeat) { h.SlackChannelID = "C 1" }, "slackChannelId 'C 1' is not valid"},
+		{func(h *types.Heartbeat) { h.IntervalSeconds = 5 }, "intervalSeconds 5 is not valid, expected value between 10 and 604800"},
+		{func(h *types.Heartbeat) { h.GracePeriodSeconds = 86401 }, "gracePeriodSeconds 86401 is too high, expected value <=86400"},
+		{func(h *types.Heartbeat) { h.Severity = types.AlertInfo }, "severity 'info' is not valid, expected one of [panic, error, warning]"},
+	}
+
+	for _, test := range tests {
+		h := newHeartbeat(time.Now())
+		test.modify(h)
+		require.EqualError(t, h.Validate(), test.expected)
+	}
+
+	var nilHeartbeat *types.Heartbeat
+	require.EqualError(t, nilHeartbeat.Validate(), "heartbeat is nil")
+}
+
+func TestHeartbeatMissedAlert(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	h := newHeartbeat(now)
+
+	assert.Equal(t, now.Add(90*time.Second), h.Deadline())
+	assert.False(t, h.IsMissed(now.Add(90*time.Second)))
+	assert.True(t, h.IsMissed(now.Add(91*time.Second)))
+
+	missed := h.MissedAlert(now.Add(2 * time.Minute))
+	assert.Equal(t, "billing-job", missed.CorrelationID)
+	assert.Equal(t, "C12345678", missed.SlackChannelID)
+	assert.Equal(t, types.AlertError, missed.Severity)
+	assert.Equal(t, ":status: Heartbeat missed: Billing job", missed.Header)
+	assert.Contains(t, missed.Text, "Silent for 2m0s")
+
+	missed.Clean()
+	require.NoError(t, missed.Validate())
+
+	restored := h.RestoredAlert()
+	assert.Equal(t, types.AlertResolved, restored.Severity)
+	assert.Equal(t, "billing-job", restored.CorrelationID)
+	assert.Equal(t, ":status: Heartbeat restored: Billing job", restored.Header)
+}
+
+func TestHeartbeatMonitor(t *testing.T) {
+	t.Parallel()
+
+	m := types.NewHeartbeatMonitor()
+	now := time.Now()
+
+	assert.Nil(t, m.Record(newHeartbeat(now)))
+	assert.Empty(t, m.Check(now.Add(time.Minute)))
+
+	alerts := m.Check(now.Add(2 * time.Minute))
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "billing-job", alerts[0].CorrelationID)
+
+	// Reported once only
+	assert.Empty(t, m.Check(now.Add(3*time.Minute)))
+
+	// Older heartbeats are ignored
+	assert.Nil(t, m.Record(newHeartbeat(now.Add(-time.Minute))))
+
+	restored := m.Record(newHeartbeat(now.Add(4 * time.Minute)))
+	require.NotNil(t, restored)
+	assert.Equal(t, types.AlertResolved, restored.Severity)
+
+	assert.Nil(t, m.Record(newHeartbeat(now.Add(5*time.Minute))))
+
+	m.Remove("C12345678", "", "billing-job")
+	assert.Empty(t, m.Check(now.Add(time.Hour)))
+}
@@ -0,0 +1,230 @@
+package types
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxHTTPTargetTimeoutSeconds is the maximum request timeout of an HTTP target.
+	MaxHTTPTargetTimeoutSeconds = 300
+
+	// MaxHTTPTargetPEMLength is the maximum length of the PEM encoded CA bundle, client certificate and client key
+	// of an HTTP target.
+	MaxHTTPTargetPEMLength = 64 * 1024
+
+	// MaxHTTPTargetNameLength is the maximum length of an HTTP target name.
+	MaxHTTPTargetNameLength = 100
+)
+
+// HTTPTargetNameRegex matches valid HTTP target names, such as 'payments-mtls'.
+var HTTPTargetNameRegex = regexp.MustCompile(fmt.Sprintf(`^[a-zA-Z0-9][a-zA-Z0-9._\-]{0,%d}$`, MaxHTTPTargetNameLength-1))
+
+// TLSVersion represents the minimum TLS version of an HTTP target.
+type TLSVersion string
+
+const (
+	// TLSVersion12 represents TLS 1.2. This is the default minimum version.
+	TLSVersion12 TLSVersion = "1.2"
+
+	// TLSVersion13 represents TLS 1.3.
+	TLSVersion13 TLSVersion = "1.3"
+)
+
+// TLSVersionIsValid returns true if the provided TLSVersion is valid.
+func TLSVersionIsValid(s TLSVersion) bool {
+	switch s {
+	case TLSVersion12, TLSVersion13:
+		return true
+	}
+	return false
+}
+
+// ValidTLSVersions returns a slice of valid TLSVersion values.
+func ValidTLSVersions() []string {
+	return []string{
+		string(TLSVersion12),
+		string(TLSVersion13),
+	}
+}
+
+// HTTPTargetConfig defines how outbound requests reach a destination: through a proxy, with a private CA,
+// with a client certificate (mTLS), and with a timeout. It is shared by the client SDK (client.WithHTTPTarget)
+// and HTTP webhooks, which refer to a target configured in the webhook dispatcher by name (Webhook.HTTPTarget),
+// so that certificates and keys are never part of an alert.
+type HTTPTargetConfig struct {
+	// ProxyURL is the URL of the proxy used for requests, such as 'http://proxy.internal:3128'.
+	// The scheme must be http, https or socks5. If empty, the transport returned by Transport selects the proxy
+	// from the environment (HTTP_PROXY, HTTPS_PROXY and NO_PROXY), which the client SDK uses as is.
+	// The webhook dispatcher removes the environment proxy, so that no proxy is used for webhooks without ProxyURL.
+	ProxyURL string `json:"proxyUrl"`
+
+	// CABundle is the PEM encoded certificates of the CAs trusted for server certificates, replacing the system pool.
+	// If empty, the system pool is used. Maximum length: MaxHTTPTargetPEMLength characters.
+	CABundle string `json:"caBundle"`
+
+	// ClientCert is the PEM encoded client certificate (chain) presented to servers requiring mTLS.
+	// Requires ClientKey. Maximum length: MaxHTTPTargetPEMLength characters.
+	ClientCert string `json:"clientCert"`
+
+	// ClientKey is the PEM encoded private key of ClientCert. Maximum length: MaxHTTPTargetPEMLength characters.
+	ClientKey string `json:"clientKey"`
+
+	// TLSMinVersion is the minimum TLS version accepted.
+	// Valid values are defined by TLSVersion constants. If empty, TLSVersion12 is used.
+	TLSMinVersion TLSVersion `json:"tlsMinVersion"`
+
+	// TimeoutSeconds is the request timeout. If 0, the default timeout of the client or dispatcher is used.
+	// Maximum value: MaxHTTPTargetTimeoutSeconds.
+	TimeoutSeconds int `json:"timeoutSeconds"`
+}
+
+// Clean normalizes the target fields.
+func (c *HTTPTargetConfig) Clean() {
+	if c == nil {
+		return
+	}
+
+	c.ProxyURL = strings.TrimSpace(c.ProxyURL)
+	c.CABundle = strings.TrimSpace(c.CABundle)
+	c.ClientCert = strings.TrimSpace(c.ClientCert)
+	c.ClientKey = strings.TrimSpace(c.ClientKey)
+	c.TLSMinVersion = TLSVersion(strings.TrimSpace(string(c.TLSMinVersion)))
+}
+
+// Validate returns an error if the target is invalid, including certificates and keys that cannot be parsed.
+// Call Clean first.
+func (c *HTTPTargetConfig) Validate() error {
+	if c == nil {
+		return errors.New("http target is nil")
+	}
+
+	if c.ProxyURL != "" {
+		u, err := url.Parse(c.ProxyURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") || u.Host == "" {
+			return errors.New("proxyUrl is not valid, expected an absolute http, https or socks5 URL")
+		}
+	}
+
+	if len(c.CABundle) > MaxHTTPTargetPEMLength {
+		return fmt.Errorf("caBundle is too long, expected length <=%d", MaxHTTPTargetPEMLength)
+	}
+
+	if len(c.ClientCert) > MaxHTTPTargetPEMLength || len(c.ClientKey) > MaxHTTPTargetPEMLength {
+		return fmt.Errorf("clientCert and clientKey are too long, expected length <=%d", MaxHTTPTargetPEMLength)
+	}
+
+	if c.TLSMinVersion != "" && !TLSVersionIsValid(c.TLSMinVersion) {
+		return fmt.Errorf("tlsMinVersion '%s' is not valid, expected empty or one of [%s]", c.TLSMinVersion, strings.Join(ValidTLSVersions(), ", "))
+	}
+
+	if c.TimeoutSeconds < 0 {
+		return errors.New("timeoutSeconds must be >=0")
+	}
+
+	if c.TimeoutSeconds > MaxHTTPTargetTimeoutSeconds {
+		return fmt.Errorf("timeoutSeconds is too high, expected value <=%d", MaxHTTPTargetTimeoutSeconds)
+	}
+
+	_, err := c.TLSConfig()
+
+	return err
+}
+
+// TLSConfig returns the TLS configuration of the target.
+func (c *HTTPTargetConfig) TLSConfig() (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if c.TLSMinVersion == TLSVersion13 {
+		cfg.MinVersion = tls.VersionTLS13
+	}
+
+	if c.CABundle != "" {
+		pool := x509.NewCertPool()
+
+		if !pool.AppendCertsFromPEM([]byte(c.CABundle)) {
+			return nil, errors.New("caBundle is not valid, expected one or more PEM encoded certificates")
+		}
+
+		cfg.RootCAs = pool
+	}
+
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		return nil, errors.New("clientCert and clientKey must be set together")
+	}
+
+	if c.ClientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("clientCert and clientKey are not a valid key pair: %w", err)
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// Transport returns a new HTTP transport for the target, based on DefaultHTTPTransport.
+// Without a ProxyURL, the transport selects the proxy from the environment. An error is returned if the target is invalid.
+func (c *HTTPTargetConfig) Transport() (*http.Transport, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := c.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := DefaultHTTPTransport()
+	transport.TLSClientConfig = tlsConfig
+
+	if c.ProxyURL != "" {
+		proxyURL, _ := url.Parse(c.ProxyURL)
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
+
+// DefaultHTTPTransport returns a clone of http.DefaultTransport. If http.DefaultTransport has been replaced by
+// another http.RoundTripper (as done by some instrumentation and mocking libraries), a new transport with the
+// settings of the standard library default is returned instead.
+func DefaultHTTPTransport() *http.Transport {
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		return transport.Clone()
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// Timeout returns the request timeout of the target, or defaultTimeout if the target has no timeout.
+func (c *HTTPTargetConfig) Timeout(defaultTimeout time.Duration) time.Duration {
+	if c == nil || c.TimeoutSeconds == 0 {
+		return defaultTimeout
+	}
+
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
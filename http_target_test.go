@@ -0,0 +1,155 @@
+package types_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClientCertPEM returns a self-signed client certificate and its key, PEM encoded.
+func newClientCertPEM(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "slackmgr-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestHTTPTargetConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	cert, key := newClientCertPEM(t)
+
+	tests := []struct {
+		name   string
+		target types.HTTPTargetConfig
+		err    string
+	}{
+		{"empty target", types.HTTPTargetConfig{}, ""},
+		{"valid target", types.HTTPTargetConfig{
+			ProxyURL:       "socks5://proxy.internal:1080",
+			CABundle:       cert,
+			ClientCert:     cert,
+			ClientKey:      key,
+			TLSMinVersion:  types.TLSVersion13,
+			TimeoutSeconds: types.MaxHTTPTargetTimeoutSeconds,
+		}, ""},
+		{"invalid proxy scheme", types.HTTPTargetConfig{ProxyURL: "ftp://proxy.internal"}, "proxyUrl is not valid, expected an absolute http, https or socks5 URL"},
+		{"relative proxy", types.HTTPTargetConfig{ProxyURL: "proxy.internal:3128"}, "proxyUrl is not valid"},
+		{"invalid CA bundle", types.HTTPTargetConfig{CABundle: "not a certificate"}, "caBundle is not valid, expected one or more PEM encoded certificates"},
+		{"too long CA bundle", types.HTTPTargetConfig{CABundle: strings.Repeat("x", types.MaxHTTPTargetPEMLength+1)}, "caBundle is too long"},
+		{"certificate without key", types.HTTPTargetConfig{ClientCert: cert}, "clientCert and clientKey must be set together"},
+		{"mismatched key", types.HTTPTargetConfig{ClientCert: cert, ClientKey: cert}, "clientCert and clientKey are not a valid key pair"},
+		{"invalid TLS version", types.HTTPTargetConfig{TLSMinVersion: "1.1"}, "tlsMinVersion '1.1' is not valid, expected empty or one of [1.2, 1.3]"},
+		{"negative timeout", types.HTTPTargetConfig{TimeoutSeconds: -1}, "timeoutSeconds must be >=0"},
+		{"too high timeout", types.HTTPTargetConfig{TimeoutSeconds: types.MaxHTTPTargetTimeoutSeconds + 1}, "timeoutSeconds is too high"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			test.target.Clean()
+			err := test.target.Validate()
+
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestHTTPTargetConfigTransport(t *testing.T) {
+	t.Parallel()
+
+	cert, key := newClientCertPEM(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	target := &types.HTTPTargetConfig{CABundle: caBundle, ClientCert: cert, ClientKey: key, TimeoutSeconds: 5}
+
+	transport, err := target.Transport()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, target.Timeout(time.Minute))
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Without the CA bundle, the server certificate is not trusted.
+	transport, err = (&types.HTTPTargetConfig{ClientCert: cert, ClientKey: key}).Transport()
+	require.NoError(t, err)
+
+	_, err = (&http.Client{Transport: transport}).Get(server.URL) //nolint:bodyclose
+	require.Error(t, err)
+
+	var nilTarget *types.HTTPTargetConfig
+	assert.Equal(t, time.Minute, nilTarget.Timeout(time.Minute))
+}
+
+// roundTripperFunc replaces http.DefaultTransport in TestDefaultHTTPTransport.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// TestDefaultHTTPTransport replaces http.DefaultTransport, so it must not run in parallel with other tests.
+func TestDefaultHTTPTransport(t *testing.T) {
+	original := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = original })
+
+	assert.NotSame(t, original, types.DefaultHTTPTransport())
+
+	http.DefaultTransport = roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("not used")
+	})
+
+	transport := types.DefaultHTTPTransport()
+	require.NotNil(t, transport)
+	assert.NotNil(t, transport.Proxy)
+
+	transport, err := (&types.HTTPTargetConfig{ProxyURL: "http://proxy.internal:3128"}).Transport()
+	require.NoError(t, err)
+	assert.NotNil(t, transport.Proxy)
+}
@@ -0,0 +1,241 @@
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	// DefaultFailureThreshold is the number of consecutive failures opening the circuit of a host,
+	// when BreakerConfig.FailureThreshold is zero.
+	DefaultFailureThreshold = 5
+
+	// DefaultOpenDuration is the time a circuit stays open before a probe request is allowed,
+	// when BreakerConfig.OpenDuration is zero.
+	DefaultOpenDuration = 30 * time.Second
+
+	// CircuitStateMetric is the name of the gauge with the circuit state of each host: 0 when closed,
+	// 1 when half-open and 2 when open. The gauge has a single label, 'host', with the host name.
+	CircuitStateMetric = "http_circuit_state"
+)
+
+// ErrCircuitOpen is returned for requests to a host with an open circuit, without sending the request.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of the circuit of a host.
+type CircuitState string
+
+const (
+	// CircuitClosed lets all requests through. This is the initial state.
+	CircuitClosed CircuitState = "closed"
+
+	// CircuitOpen rejects all requests, until the open duration has elapsed.
+	CircuitOpen CircuitState = "open"
+
+	// CircuitHalfOpen lets a single probe request through, which closes the circuit if it succeeds,
+	// and opens it again if it fails.
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// BreakerConfig holds the configuration of a CircuitBreaker.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures opening the circuit of a host.
+	// Defaults to DefaultFailureThreshold.
+	FailureThreshold int
+
+	// OpenDuration is the time a circuit stays open before a probe request is allowed.
+	// Defaults to DefaultOpenDuration.
+	OpenDuration time.Duration
+
+	// Logger receives the circuit state changes. Optional.
+	Logger types.Logger
+
+	// Metrics receives the CircuitStateMetric gauge. Optional.
+	Metrics types.Metrics
+}
+
+// CircuitBreaker stops sending requests to hosts that keep failing, so that a broken host does not slow down
+// every caller with timeouts and retries. Each host has its own circuit, which opens after FailureThreshold
+// consecutive failures, and lets a single probe request through after OpenDuration.
+// It is safe for concurrent use, and is typically shared by all the transports and clients of a service.
+type CircuitBreaker struct {
+	cfg BreakerConfig
+
+	mu    sync.Mutex
+	hosts map[string]*circuit
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a new CircuitBreaker. An error is returned if the configuration is invalid.
+func NewCircuitBreaker(cfg BreakerConfig) (*CircuitBreaker, error) {
+	if cfg.FailureThreshold < 0 {
+		return nil, errors.New("failure threshold cannot be negative")
+	}
+
+	if cfg.OpenDuration < 0 {
+		return nil, errors.New("open duration cannot be negative")
+	}
+
+	if cfg.FailureThreshold == 0 {
+		cfg.FailureThreshold = DefaultFailureThreshold
+	}
+
+	if cfg.OpenDuration == 0 {
+		cfg.OpenDuration = DefaultOpenDuration
+	}
+
+	if cfg.Logger == nil {
+		cfg.Logger = &types.NoopLogger{}
+	}
+
+	if cfg.Metrics == nil {
+		cfg.Metrics = &types.NoopMetrics{}
+	}
+
+	cfg.Metrics.RegisterGauge(CircuitStateMetric, "State of the HTTP circuit breaker per host (0 closed, 1 half-open, 2 open)", "host")
+
+	return &CircuitBreaker{
+		cfg:   cfg,
+		hosts: make(map[string]*circuit),
+	}, nil
+}
+
+// Allow returns nil if a request to the host can be sent, and an error wrapping ErrCircuitOpen otherwise.
+// Every allowed request must be followed by a call to Record with its outcome, or to Release if it has none.
+func (b *CircuitBreaker) Allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(host)
+
+	switch c.state {
+	case CircuitOpen:
+		if time.Since(c.openedAt) < b.cfg.OpenDuration {
+			return fmt.Errorf("%w for host %s", ErrCircuitOpen, host)
+		}
+
+		b.setState(host, c, CircuitHalfOpen)
+		c.probing = true
+
+		return nil
+	case CircuitHalfOpen:
+		if c.probing {
+			return fmt.Errorf("%w for host %s (probe in progress)", ErrCircuitOpen, host)
+		}
+
+		c.probing = true
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record records the outcome of a request allowed by Allow. Failures are network errors and server errors,
+// not client errors such as 404 Not Found.
+func (b *CircuitBreaker) Record(host string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(host)
+	c.probing = false
+
+	if !failed {
+		c.failures = 0
+
+		if c.state != CircuitClosed {
+			b.setState(host, c, CircuitClosed)
+		}
+
+		return
+	}
+
+	c.failures++
+
+	if c.state == CircuitHalfOpen || (c.state == CircuitClosed && c.failures >= b.cfg.FailureThreshold) {
+		c.openedAt = time.Now()
+		b.setState(host, c, CircuitOpen)
+	}
+}
+
+// Done ends a request allowed by Allow, with the response or error of the request. If the request failed after
+// the caller's context ctx was done, it is released (see Release), since the caller gave up. Otherwise, the outcome
+// is recorded, with IsFailure. Pass the caller's context rather than a per-attempt timeout context, so that
+// timed out attempts are recorded as failures.
+func (b *CircuitBreaker) Done(ctx context.Context, host string, resp *http.Response, err error) {
+	if err != nil && ctx.Err() != nil {
+		b.Release(host)
+		return
+	}
+
+	b.Record(host, IsFailure(resp, err))
+}
+
+// State returns the circuit state of the host.
+func (b *CircuitBreaker) State(host string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.hosts[host]; ok {
+		return c.state
+	}
+
+	return CircuitClosed
+}
+
+// Release ends a request allowed by Allow without recording an outcome, such as a request canceled by the caller,
+// which says nothing about the health of the host. The failure count is kept, and a half-open circuit allows
+// another probe request.
+func (b *CircuitBreaker) Release(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.circuit(host).probing = false
+}
+
+func (b *CircuitBreaker) circuit(host string) *circuit {
+	c, ok := b.hosts[host]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		b.hosts[host] = c
+	}
+
+	return c
+}
+
+func (b *CircuitBreaker) setState(host string, c *circuit, state CircuitState) {
+	from := c.state
+	c.state = state
+
+	value := 0.0
+
+	switch state {
+	case CircuitHalfOpen:
+		value = 1
+	case CircuitOpen:
+		value = 2
+	case CircuitClosed:
+	}
+
+	b.cfg.Metrics.Set(CircuitStateMetric, value, host)
+
+	logger := b.cfg.Logger.WithField("host", host)
+
+	if state == CircuitOpen {
+		logger.Warnf("Circuit breaker changed from %s to %s after %d consecutive failure(s)", from, state, c.failures)
+	} else {
+		logger.Infof("Circuit breaker changed from %s to %s", from, state)
+	}
+}
@@ -0,0 +1,143 @@
+package httpretry_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/httpretry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	_, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: -1})
+	require.EqualError(t, err, "failure threshold cannot be negative")
+
+	_, err = httpretry.NewCircuitBreaker(httpretry.BreakerConfig{OpenDuration: -time.Second})
+	require.EqualError(t, err, "open duration cannot be negative")
+
+	breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, httpretry.CircuitClosed, breaker.State("example.com"))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("circuit should open after consecutive failures", func(t *testing.T) {
+		t.Parallel()
+
+		logger := types.NewTestLogger()
+		metrics := newRecordingMetrics()
+
+		breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 3, OpenDuration: time.Hour, Logger: logger, Metrics: metrics})
+		require.NoError(t, err)
+
+		for range 2 {
+			require.NoError(t, breaker.Allow("a.example.com"))
+			breaker.Record("a.example.com", true)
+		}
+
+		// A success resets the failure count.
+		require.NoError(t, breaker.Allow("a.example.com"))
+		breaker.Record("a.example.com", false)
+
+		for range 3 {
+			require.NoError(t, breaker.Allow("a.example.com"))
+			breaker.Record("a.example.com", true)
+		}
+
+		assert.Equal(t, httpretry.CircuitOpen, breaker.State("a.example.com"))
+		require.ErrorIs(t, breaker.Allow("a.example.com"), httpretry.ErrCircuitOpen)
+		require.EqualError(t, breaker.Allow("a.example.com"), "circuit breaker is open for host a.example.com")
+
+		// Other hosts are not affected.
+		require.NoError(t, breaker.Allow("b.example.com"))
+		assert.Equal(t, httpretry.CircuitClosed, breaker.State("b.example.com"))
+
+		assert.Equal(t, 2.0, metrics.value(httpretry.CircuitStateMetric, "a.example.com"))
+		assert.True(t, logger.Contains("Circuit breaker changed from closed to open after 3 consecutive failure(s)"))
+	})
+
+	t.Run("half-open circuit should allow a single probe", func(t *testing.T) {
+		t.Parallel()
+
+		breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 1, OpenDuration: 10 * time.Millisecond})
+		require.NoError(t, err)
+
+		require.NoError(t, breaker.Allow("example.com"))
+		breaker.Record("example.com", true)
+		require.ErrorIs(t, breaker.Allow("example.com"), httpretry.ErrCircuitOpen)
+
+		time.Sleep(20 * time.Millisecond)
+
+		require.NoError(t, breaker.Allow("example.com"))
+		assert.Equal(t, httpretry.CircuitHalfOpen, breaker.State("example.com"))
+		require.ErrorIs(t, breaker.Allow("example.com"), httpretry.ErrCircuitOpen)
+
+		// A failed probe opens the circuit again.
+		breaker.Record("example.com", true)
+		assert.Equal(t, httpretry.CircuitOpen, breaker.State("example.com"))
+
+		time.Sleep(20 * time.Millisecond)
+
+		// A successful probe closes the circuit.
+		require.NoError(t, breaker.Allow("example.com"))
+		breaker.Record("example.com", false)
+		assert.Equal(t, httpretry.CircuitClosed, breaker.State("example.com"))
+		require.NoError(t, breaker.Allow("example.com"))
+	})
+
+	t.Run("released requests should not change the circuit", func(t *testing.T) {
+		t.Parallel()
+
+		breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 2, OpenDuration: 10 * time.Millisecond})
+		require.NoError(t, err)
+
+		require.NoError(t, breaker.Allow("example.com"))
+		breaker.Record("example.com", true)
+
+		// The failure count is kept, so the next failure opens the circuit.
+		require.NoError(t, breaker.Allow("example.com"))
+		breaker.Release("example.com")
+		require.NoError(t, breaker.Allow("example.com"))
+		breaker.Record("example.com", true)
+		assert.Equal(t, httpretry.CircuitOpen, breaker.State("example.com"))
+
+		time.Sleep(20 * time.Millisecond)
+
+		// A released probe lets another probe through, without closing the circuit.
+		require.NoError(t, breaker.Allow("example.com"))
+		breaker.Release("example.com")
+		assert.Equal(t, httpretry.CircuitHalfOpen, breaker.State("example.com"))
+		require.NoError(t, breaker.Allow("example.com"))
+	})
+
+	t.Run("done should release requests cancelled by the caller", func(t *testing.T) {
+		t.Parallel()
+
+		breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.NoError(t, breaker.Allow("example.com"))
+		breaker.Done(ctx, "example.com", nil, context.Canceled)
+		assert.Equal(t, httpretry.CircuitClosed, breaker.State("example.com"))
+
+		require.NoError(t, breaker.Allow("example.com"))
+		breaker.Done(context.Background(), "example.com", &http.Response{StatusCode: http.StatusNotFound}, nil)
+		assert.Equal(t, httpretry.CircuitClosed, breaker.State("example.com"))
+
+		// An attempt timing out while the caller is still waiting is a failure.
+		require.NoError(t, breaker.Allow("example.com"))
+		breaker.Done(context.Background(), "example.com", nil, context.DeadlineExceeded)
+		assert.Equal(t, httpretry.CircuitOpen, breaker.State("example.com"))
+	})
+}
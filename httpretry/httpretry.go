@@ -0,0 +1,347 @@
+// Package httpretry provides an http.RoundTripper retrying failed outbound requests with exponential backoff
+// and jitter, honoring Retry-After, with an optional per-host CircuitBreaker.
+//
+// Only idempotent requests are retried: requests with an idempotent method (GET, HEAD, OPTIONS, PUT and DELETE),
+// and requests with an Idempotency-Key header. Use it for outbound API clients, instead of a retry loop per service,
+// as the client package does. Set Config.AttemptTimeout rather than an http.Client timeout, so that each attempt
+// has its own timeout, and timed out attempts are recorded as failures by the circuit breaker:
+//
+//	breaker, _ := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{Logger: logger, Metrics: metrics})
+//	transport, _ := httpretry.NewTransport(http.DefaultTransport, httpretry.Config{Name: "billing", Breaker: breaker, AttemptTimeout: 10 * time.Second})
+//	httpClient := &http.Client{Transport: transport}
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	// DefaultMaxAttempts is the maximum number of attempts per request, including the first one,
+	// when Config.MaxAttempts is zero.
+	DefaultMaxAttempts = 4
+
+	// DefaultInitialBackoff is the upper bound of the delay before the first retry, when Config.InitialBackoff is zero.
+	DefaultInitialBackoff = 200 * time.Millisecond
+
+	// DefaultMaxBackoff is the upper bound of the delay between retries, when Config.MaxBackoff is zero.
+	DefaultMaxBackoff = 10 * time.Second
+
+	// DefaultMaxRetryAfter is the longest Retry-After delay waited for, when Config.MaxRetryAfter is zero.
+	DefaultMaxRetryAfter = time.Minute
+
+	// IdempotencyKeyHeader is the HTTP header marking a request with a non-idempotent method as safe to retry.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// RetriesMetric is the name of the counter incremented for each retried request.
+	// The counter has three labels: 'client', with the transport name, 'host', with the request host,
+	// and 'reason', with the response status code or 'error'.
+	RetriesMetric = "http_retries_total"
+
+	// CircuitRejectionsMetric is the name of the counter incremented for each request rejected by an open circuit.
+	// The counter has two labels: 'client', with the transport name, and 'host', with the request host.
+	CircuitRejectionsMetric = "http_circuit_rejections_total"
+
+	// drainLimit is the maximum number of response body bytes read from a retried response,
+	// so that the connection can be reused.
+	drainLimit = 64 << 10
+)
+
+// Config holds the configuration of a Transport.
+type Config struct {
+	// Name identifies the transport in logs and metrics, such as 'webhooks'. Required.
+	Name string
+
+	// MaxAttempts is the maximum number of attempts per request, including the first one.
+	// Defaults to DefaultMaxAttempts. A value of 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the upper bound of the delay before the first retry. The bound is doubled for each retry,
+	// and the actual delay is random between zero and the bound (full jitter). Defaults to DefaultInitialBackoff.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the upper bound of the delay between retries. Defaults to DefaultMaxBackoff.
+	MaxBackoff time.Duration
+
+	// MaxRetryAfter is the longest Retry-After delay waited for. Responses asking for a longer delay
+	// are returned without retrying. Defaults to DefaultMaxRetryAfter.
+	MaxRetryAfter time.Duration
+
+	// RetryOnStatusCodes is the list of response status codes that are retried.
+	// Defaults to types.DefaultWebhookRetryStatusCodes. Network errors are always retried.
+	RetryOnStatusCodes []int
+
+	// RetryNonIdempotent retries all requests, including POST and PATCH requests without an Idempotency-Key header.
+	RetryNonIdempotent bool
+
+	// AttemptTimeout is the timeout of each attempt. A timed out attempt is a failure of the host: it is retried,
+	// and recorded as a failure by the circuit breaker. Optional: without it, attempts are only bounded by the request
+	// context, and an http.Client timeout covers all attempts of a request.
+	AttemptTimeout time.Duration
+
+	// Breaker is the circuit breaker checked before each attempt. Optional.
+	Breaker *CircuitBreaker
+
+	// Logger receives the retried requests. Optional.
+	Logger types.Logger
+
+	// Metrics receives the RetriesMetric and CircuitRejectionsMetric counters. Optional.
+	Metrics types.Metrics
+}
+
+// Transport is an http.RoundTripper retrying failed idempotent requests. It is safe for concurrent use.
+type Transport struct {
+	next http.RoundTripper
+	cfg  Config
+}
+
+// NewTransport creates a new Transport sending requests with next, or http.DefaultTransport if next is nil.
+// An error is returned if the configuration is invalid.
+func NewTransport(next http.RoundTripper, cfg Config) (*Transport, error) {
+	if cfg.Name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+
+	if cfg.MaxAttempts < 0 {
+		return nil, errors.New("max attempts cannot be negative")
+	}
+
+	if cfg.InitialBackoff < 0 || cfg.MaxBackoff < 0 || cfg.MaxRetryAfter < 0 {
+		return nil, errors.New("backoff cannot be negative")
+	}
+
+	if cfg.AttemptTimeout < 0 {
+		return nil, errors.New("attempt timeout cannot be negative")
+	}
+
+	for i, code := range cfg.RetryOnStatusCodes {
+		if code < 400 || code > 599 {
+			return nil, fmt.Errorf("retryOnStatusCodes[%d] %d is not valid, expected value between 400 and 599", i, code)
+		}
+	}
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+
+	if cfg.InitialBackoff == 0 {
+		cfg.InitialBackoff = DefaultInitialBackoff
+	}
+
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = DefaultMaxBackoff
+	}
+
+	if cfg.MaxRetryAfter == 0 {
+		cfg.MaxRetryAfter = DefaultMaxRetryAfter
+	}
+
+	if len(cfg.RetryOnStatusCodes) == 0 {
+		cfg.RetryOnStatusCodes = types.DefaultWebhookRetryStatusCodes()
+	}
+
+	if cfg.Logger == nil {
+		cfg.Logger = &types.NoopLogger{}
+	}
+
+	if cfg.Metrics == nil {
+		cfg.Metrics = &types.NoopMetrics{}
+	}
+
+	cfg.Metrics.RegisterCounter(RetriesMetric, "Number of retried outbound HTTP requests", "client", "host", "reason")
+	cfg.Metrics.RegisterCounter(CircuitRejectionsMetric, "Number of outbound HTTP requests rejected by an open circuit", "client", "host")
+
+	return &Transport{
+		next: next,
+		cfg:  cfg,
+	}, nil
+}
+
+// RoundTrip sends the request, retrying network errors and responses with a retryable status code.
+// The response of the last attempt is returned when all attempts fail with a retryable status code.
+// An error wrapping ErrCircuitOpen is returned if the circuit of the host is open.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retry := t.cfg.RetryNonIdempotent || isIdempotent(req)
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		retry = false
+	}
+
+	ctx := req.Context()
+	host := req.URL.Host
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		resp, err := t.send(req, host)
+
+		if errors.Is(err, ErrCircuitOpen) || !retry || attempt >= t.cfg.MaxAttempts || !t.shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := Backoff(attempt, t.cfg.InitialBackoff, t.cfg.MaxBackoff)
+		reason := "error"
+
+		if resp != nil {
+			retryAfter := ParseRetryAfter(resp.Header.Get("Retry-After"))
+			if retryAfter > t.cfg.MaxRetryAfter {
+				return resp, nil
+			}
+
+			delay = max(delay, retryAfter)
+			reason = strconv.Itoa(resp.StatusCode)
+
+			_, _ = io.CopyN(io.Discard, resp.Body, drainLimit)
+			_ = resp.Body.Close()
+		}
+
+		t.cfg.Metrics.Inc(RetriesMetric, t.cfg.Name, host, reason)
+		t.cfg.Logger.WithFields(map[string]any{"client": t.cfg.Name, "host": host, "attempt": attempt}).
+			Debugf("Retrying %s request in %s (%s)", req.Method, delay, reason)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("request cancelled after %d attempt(s): %w", attempt, errors.Join(ctx.Err(), err))
+		case <-timer.C:
+		}
+	}
+}
+
+// send sends a single attempt, with the attempt timeout, checking and updating the circuit breaker.
+func (t *Transport) send(req *http.Request, host string) (*http.Response, error) {
+	if t.cfg.Breaker != nil {
+		if err := t.cfg.Breaker.Allow(host); err != nil {
+			t.cfg.Metrics.Inc(CircuitRejectionsMetric, t.cfg.Name, host)
+			return nil, err
+		}
+	}
+
+	ctx := req.Context()
+	attempt := req
+	cancel := context.CancelFunc(func() {})
+
+	if t.cfg.AttemptTimeout > 0 {
+		var attemptCtx context.Context
+
+		attemptCtx, cancel = context.WithTimeout(ctx, t.cfg.AttemptTimeout)
+		attempt = req.WithContext(attemptCtx)
+	}
+
+	resp, err := t.next.RoundTrip(attempt)
+
+	if err != nil {
+		cancel()
+	} else {
+		// The attempt context must live until the body is read.
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	}
+
+	if t.cfg.Breaker != nil {
+		t.cfg.Breaker.Done(ctx, host, resp, err)
+	}
+
+	return resp, err
+}
+
+// cancelOnClose cancels the context of an attempt when the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
+
+func (t *Transport) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return slices.Contains(t.cfg.RetryOnStatusCodes, resp.StatusCode)
+}
+
+// IsFailure returns true if a request outcome is a failure of the host, for the circuit breaker:
+// a network error (including a timeout), 429 Too Many Requests or a 5xx response.
+func IsFailure(resp *http.Response, err error) bool {
+	return err != nil || resp == nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, "":
+		return true
+	}
+
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// Backoff returns the delay before the retry following attempt number attempt (starting at 1),
+// using exponential backoff with full jitter: a random delay between zero and initial doubled per retry,
+// bounded by maxBackoff.
+func Backoff(attempt int, initial, maxBackoff time.Duration) time.Duration {
+	if attempt < 1 {
+		return 0
+	}
+
+	ceiling := initial << min(attempt-1, 62)
+	if ceiling <= 0 || ceiling > maxBackoff {
+		ceiling = maxBackoff
+	}
+
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int64N(int64(ceiling)) + 1) // #nosec G404 -- jitter does not need a secure random source
+}
+
+// ParseRetryAfter returns the delay of a Retry-After header value, in seconds or as an HTTP date.
+// It returns zero for empty and invalid values, and dates in the past.
+func ParseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,325 @@
+package httpretry_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/httpretry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingMetrics struct {
+	types.NoopMetrics
+
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{values: make(map[string]float64)}
+}
+
+func (m *recordingMetrics) Inc(name string, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[name+"|"+strings.Join(labelValues, "|")]++
+}
+
+func (m *recordingMetrics) Set(name string, value float64, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[name+"|"+strings.Join(labelValues, "|")] = value
+}
+
+func (m *recordingMetrics) value(name string, labelValues ...string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.values[name+"|"+strings.Join(labelValues, "|")]
+}
+
+// newServer returns a server responding with the given status codes in order, and then 200 OK.
+func newServer(t *testing.T, statusCodes ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		n := int(calls.Add(1))
+
+		if n <= len(statusCodes) {
+			w.WriteHeader(statusCodes[n-1])
+			return
+		}
+
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func newClient(t *testing.T, cfg httpretry.Config) *http.Client {
+	t.Helper()
+
+	if cfg.Name == "" {
+		cfg.Name = "test"
+	}
+
+	if cfg.InitialBackoff == 0 {
+		cfg.InitialBackoff = time.Millisecond
+	}
+
+	transport, err := httpretry.NewTransport(nil, cfg)
+	require.NoError(t, err)
+
+	return &http.Client{Transport: transport}
+}
+
+func TestNewTransport(t *testing.T) {
+	t.Parallel()
+
+	_, err := httpretry.NewTransport(nil, httpretry.Config{})
+	require.EqualError(t, err, "name cannot be empty")
+
+	_, err = httpretry.NewTransport(nil, httpretry.Config{Name: "test", MaxAttempts: -1})
+	require.EqualError(t, err, "max attempts cannot be negative")
+
+	_, err = httpretry.NewTransport(nil, httpretry.Config{Name: "test", MaxRetryAfter: -time.Second})
+	require.EqualError(t, err, "backoff cannot be negative")
+
+	_, err = httpretry.NewTransport(nil, httpretry.Config{Name: "test", AttemptTimeout: -time.Second})
+	require.EqualError(t, err, "attempt timeout cannot be negative")
+
+	_, err = httpretry.NewTransport(nil, httpretry.Config{Name: "test", RetryOnStatusCodes: []int{503, 200}})
+	require.EqualError(t, err, "retryOnStatusCodes[1] 200 is not valid, expected value between 400 and 599")
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("idempotent requests should be retried", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newServer(t, http.StatusServiceUnavailable, http.StatusBadGateway)
+		metrics := newRecordingMetrics()
+		c := newClient(t, httpretry.Config{Metrics: metrics})
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, server.URL, strings.NewReader("payload"))
+		require.NoError(t, err)
+
+		resp, err := c.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "payload", string(body))
+		assert.Equal(t, int32(3), calls.Load())
+
+		host := req.URL.Host
+		assert.Equal(t, 1.0, metrics.value(httpretry.RetriesMetric, "test", host, "503"))
+		assert.Equal(t, 1.0, metrics.value(httpretry.RetriesMetric, "test", host, "502"))
+	})
+
+	t.Run("post requests should only be retried with an idempotency key", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newServer(t, http.StatusServiceUnavailable)
+		c := newClient(t, httpretry.Config{})
+
+		resp, err := c.Post(server.URL, "text/plain", strings.NewReader("payload"))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+
+		server, calls = newServer(t, http.StatusServiceUnavailable)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, strings.NewReader("payload"))
+		require.NoError(t, err)
+		req.Header.Set(httpretry.IdempotencyKeyHeader, "key")
+
+		resp, err = c.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("last response should be returned when attempts are exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newServer(t, 503, 503, 503, 503)
+		c := newClient(t, httpretry.Config{MaxAttempts: 2})
+
+		resp, err := c.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("non-retryable status codes should not be retried", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newServer(t, http.StatusInternalServerError)
+		c := newClient(t, httpretry.Config{})
+
+		resp, err := c.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("retry after should be respected", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		c := newClient(t, httpretry.Config{})
+		start := time.Now()
+
+		resp, err := c.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+
+		// Retry-After above the maximum is returned without retrying.
+		calls.Store(0)
+		c = newClient(t, httpretry.Config{MaxRetryAfter: 500 * time.Millisecond})
+
+		resp, err = c.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("cancelled context should stop retrying", func(t *testing.T) {
+		t.Parallel()
+
+		server, _ := newServer(t, 503, 503, 503, 503)
+		c := newClient(t, httpretry.Config{InitialBackoff: time.Hour, MaxBackoff: time.Hour})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		_, err = c.Do(req) //nolint:bodyclose
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("timed out attempts should be retried and recorded as failures", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(200 * time.Millisecond):
+				}
+
+				return
+			}
+
+			_, _ = w.Write([]byte("ok"))
+		}))
+		t.Cleanup(server.Close)
+
+		c := newClient(t, httpretry.Config{AttemptTimeout: 50 * time.Millisecond})
+
+		resp, err := c.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "ok", string(body))
+		assert.Equal(t, int32(2), calls.Load())
+
+		breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour})
+		require.NoError(t, err)
+
+		calls.Store(0)
+		c = newClient(t, httpretry.Config{AttemptTimeout: 50 * time.Millisecond, Breaker: breaker})
+
+		_, err = c.Get(server.URL) //nolint:bodyclose
+		require.ErrorIs(t, err, httpretry.ErrCircuitOpen)
+
+		u, _ := url.Parse(server.URL)
+		assert.Equal(t, httpretry.CircuitOpen, breaker.State(u.Host))
+	})
+
+	t.Run("open circuit should reject requests", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newServer(t, 503, 503, 503, 503)
+		metrics := newRecordingMetrics()
+
+		breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 2, OpenDuration: time.Hour})
+		require.NoError(t, err)
+
+		c := newClient(t, httpretry.Config{Breaker: breaker, Metrics: metrics})
+
+		_, err = c.Get(server.URL) //nolint:bodyclose
+		require.ErrorIs(t, err, httpretry.ErrCircuitOpen)
+		assert.Equal(t, int32(2), calls.Load())
+
+		u, _ := url.Parse(server.URL)
+		assert.Equal(t, httpretry.CircuitOpen, breaker.State(u.Host))
+		assert.Equal(t, 1.0, metrics.value(httpretry.CircuitRejectionsMetric, "test", u.Host))
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Duration(0), httpretry.Backoff(0, time.Second, time.Minute))
+	assert.Equal(t, time.Duration(0), httpretry.Backoff(1, 0, 0))
+
+	for attempt := 1; attempt <= 100; attempt++ {
+		delay := httpretry.Backoff(attempt, time.Second, 10*time.Second)
+		assert.Positive(t, delay)
+		assert.LessOrEqual(t, delay, min(time.Second<<min(attempt-1, 10), 10*time.Second))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Duration(0), httpretry.ParseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), httpretry.ParseRetryAfter("soon"))
+	assert.Equal(t, time.Duration(0), httpretry.ParseRetryAfter("-5"))
+	assert.Equal(t, 30*time.Second, httpretry.ParseRetryAfter("30"))
+	assert.Equal(t, time.Duration(0), httpretry.ParseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)))
+
+	delay := httpretry.ParseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.Greater(t, delay, 59*time.Minute)
+}
@@ -0,0 +1,209 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// MaxIgnoreRuleCount is the maximum number of ignore rules per alert.
+	MaxIgnoreRuleCount = 20
+	// MaxIgnoreRuleValueLength is the maximum length of an ignore rule value.
+	MaxIgnoreRuleValueLength = 1000
+	// MaxIgnoreRuleKeyLength is the maximum length of an ignore rule metadata key.
+	MaxIgnoreRuleKeyLength = 100
+)
+
+// IgnoreRule causes an alert to be ignored if the rule target matches the rule value.
+// It generalizes IgnoreIfTextContains to other parts of the alert, and to other comparisons.
+// Regular expressions are compiled on first use, so a rule must not be modified after it is used.
+type IgnoreRule struct {
+	// Target is the part of the alert the rule is applied to. Defaults to IgnoreRuleTargetText.
+	Target IgnoreRuleTarget `json:"target"`
+
+	// Key is the metadata key, for the IgnoreRuleTargetMetadata target. Required for that target, and not allowed for others.
+	// Maximum length: MaxIgnoreRuleKeyLength characters.
+	Key string `json:"key"`
+
+	// Operator is the comparison applied to the target. Defaults to IgnoreRuleOperatorContains.
+	Operator IgnoreRuleOperator `json:"operator"`
+
+	// Value is the value to compare with, or the regular expression for IgnoreRuleOperatorRegex. Cannot be empty.
+	// Maximum length: MaxIgnoreRuleValueLength characters.
+	Value string `json:"value"`
+
+	regexOnce sync.Once
+	regex     *regexp.Regexp
+	regexErr  error
+}
+
+// cleanIgnoreRules normalizes the target and operator of each rule, and applies default values.
+// Rule values are not modified, since whitespace may be significant.
+func cleanIgnoreRules(rules []*IgnoreRule) {
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		rule.Target = IgnoreRuleTarget(canonicalEnumValue(string(rule.Target), ValidIgnoreRuleTargets()))
+		rule.Operator = IgnoreRuleOperator(canonicalEnumValue(string(rule.Operator), ValidIgnoreRuleOperators()))
+		rule.Key = strings.TrimSpace(rule.Key)
+
+		if rule.Target == "" {
+			rule.Target = IgnoreRuleTargetText
+		}
+
+		if rule.Operator == "" {
+			rule.Operator = IgnoreRuleOperatorContains
+		}
+	}
+}
+
+// canonicalEnumValue returns the valid value equal to s ignoring case and surrounding whitespace, or the trimmed s if there is none.
+func canonicalEnumValue(s string, validValues []string) string {
+	s = strings.TrimSpace(s)
+
+	for _, v := range validValues {
+		if strings.EqualFold(s, v) {
+			return v
+		}
+	}
+
+	return s
+}
+
+// ValidateIgnoreRules validates the IgnoreRules slice: the number of rules, and the target, key, operator and value of each rule.
+func (a *Alert) ValidateIgnoreRules() error {
+	if len(a.IgnoreRules) == 0 {
+		return nil
+	}
+
+	if len(a.IgnoreRules) > MaxIgnoreRuleCount {
+		return fmt.Errorf("too many ignoreRules, expected <=%d", MaxIgnoreRuleCount)
+	}
+
+	for i, rule := range a.IgnoreRules {
+		if rule == nil {
+			return fmt.Errorf("ignoreRules[%d] cannot be null", i)
+		}
+
+		if !IgnoreRuleTargetIsValid(rule.Target) {
+			return fmt.Errorf("ignoreRules[%d].target '%s' is not valid, expected one of [%s]", i, rule.Target, strings.Join(ValidIgnoreRuleTargets(), ", "))
+		}
+
+		if rule.Target == IgnoreRuleTargetMetadata && rule.Key == "" {
+			return fmt.Errorf("ignoreRules[%d].key is required for target '%s'", i, rule.Target)
+		}
+
+		if rule.Target != IgnoreRuleTargetMetadata && rule.Key != "" {
+			return fmt.Errorf("ignoreRules[%d].key is only allowed for target '%s'", i, IgnoreRuleTargetMetadata)
+		}
+
+		if len(rule.Key) > MaxIgnoreRuleKeyLength {
+			return fmt.Errorf("ignoreRules[%d].key is too long, expected length <=%d", i, MaxIgnoreRuleKeyLength)
+		}
+
+		if !IgnoreRuleOperatorIsValid(rule.Operator) {
+			return fmt.Errorf("ignoreRules[%d].operator '%s' is not valid, expected one of [%s]", i, rule.Operator, strings.Join(ValidIgnoreRuleOperators(), ", "))
+		}
+
+		if rule.Value == "" {
+			return fmt.Errorf("ignoreRules[%d].value cannot be empty", i)
+		}
+
+		if len(rule.Value) > MaxIgnoreRuleValueLength {
+			return fmt.Errorf("ignoreRules[%d].value is too long, expected length <=%d", i, MaxIgnoreRuleValueLength)
+		}
+
+		if err := rule.compile(); err != nil {
+			return fmt.Errorf("ignoreRules[%d].value is not a valid regular expression: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ShouldIgnore returns true if the alert text contains any of the IgnoreIfTextContains substrings,
+// or if any of the IgnoreRules matches the alert.
+func (a *Alert) ShouldIgnore() bool {
+	for _, s := range a.IgnoreIfTextContains {
+		if s != "" && strings.Contains(a.Text, s) {
+			return true
+		}
+	}
+
+	for _, rule := range a.IgnoreRules {
+		if rule.Matches(a) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches returns true if the rule target of the alert matches the rule value.
+// Invalid rules never match.
+func (r *IgnoreRule) Matches(a *Alert) bool {
+	if r == nil || a == nil || r.Value == "" {
+		return false
+	}
+
+	switch r.Target {
+	case IgnoreRuleTargetHeader:
+		return r.matchValue(a.Header)
+	case IgnoreRuleTargetText, "":
+		return r.matchValue(a.Text)
+	case IgnoreRuleTargetFieldTitle, IgnoreRuleTargetFieldValue:
+		for _, f := range a.Fields {
+			if f == nil {
+				continue
+			}
+
+			value := f.Value
+			if r.Target == IgnoreRuleTargetFieldTitle {
+				value = f.Title
+			}
+
+			if r.matchValue(value) {
+				return true
+			}
+		}
+
+		return false
+	case IgnoreRuleTargetMetadata:
+		value, ok := alertFieldValue(a, alertFieldMetadataPrefix+r.Key)
+		return ok && r.matchValue(value)
+	default:
+		return false
+	}
+}
+
+func (r *IgnoreRule) matchValue(value string) bool {
+	switch r.Operator {
+	case IgnoreRuleOperatorContains, "":
+		return strings.Contains(value, r.Value)
+	case IgnoreRuleOperatorEquals:
+		return value == r.Value
+	case IgnoreRuleOperatorPrefix:
+		return strings.HasPrefix(value, r.Value)
+	case IgnoreRuleOperatorRegex:
+		return r.compile() == nil && r.regex.MatchString(value)
+	default:
+		return false
+	}
+}
+
+// compile compiles the rule regular expression once, for IgnoreRuleOperatorRegex.
+func (r *IgnoreRule) compile() error {
+	if r.Operator != IgnoreRuleOperatorRegex {
+		return nil
+	}
+
+	r.regexOnce.Do(func() {
+		r.regex, r.regexErr = regexp.Compile(r.Value)
+	})
+
+	return r.regexErr
+}
@@ -0,0 +1,37 @@
+package types
+
+// IgnoreRuleOperator is the comparison an ignore rule applies to its target.
+type IgnoreRuleOperator string
+
+const (
+	// IgnoreRuleOperatorContains matches targets containing the rule value. This is the default.
+	IgnoreRuleOperatorContains IgnoreRuleOperator = "contains"
+
+	// IgnoreRuleOperatorEquals matches targets equal to the rule value.
+	IgnoreRuleOperatorEquals IgnoreRuleOperator = "equals"
+
+	// IgnoreRuleOperatorPrefix matches targets starting with the rule value.
+	IgnoreRuleOperatorPrefix IgnoreRuleOperator = "prefix"
+
+	// IgnoreRuleOperatorRegex matches targets matching the rule value as a regular expression (unanchored).
+	IgnoreRuleOperatorRegex IgnoreRuleOperator = "regex"
+)
+
+// IgnoreRuleOperatorIsValid returns true if the provided IgnoreRuleOperator is valid.
+func IgnoreRuleOperatorIsValid(s IgnoreRuleOperator) bool {
+	switch s {
+	case IgnoreRuleOperatorContains, IgnoreRuleOperatorEquals, IgnoreRuleOperatorPrefix, IgnoreRuleOperatorRegex:
+		return true
+	}
+	return false
+}
+
+// ValidIgnoreRuleOperators returns a slice of valid IgnoreRuleOperator values.
+func ValidIgnoreRuleOperators() []string {
+	return []string{
+		string(IgnoreRuleOperatorContains),
+		string(IgnoreRuleOperatorEquals),
+		string(IgnoreRuleOperatorPrefix),
+		string(IgnoreRuleOperatorRegex),
+	}
+}
@@ -0,0 +1,19 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoreRuleOperatorValidation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.IgnoreRuleOperatorIsValid(types.IgnoreRuleOperatorContains))
+	assert.True(t, types.IgnoreRuleOperatorIsValid(types.IgnoreRuleOperatorEquals))
+	assert.True(t, types.IgnoreRuleOperatorIsValid(types.IgnoreRuleOperatorPrefix))
+	assert.True(t, types.IgnoreRuleOperatorIsValid(types.IgnoreRuleOperatorRegex))
+	assert.False(t, types.IgnoreRuleOperatorIsValid("suffix"))
+	assert.Len(t, types.ValidIgnoreRuleOperators(), 4)
+}
@@ -0,0 +1,42 @@
+package types
+
+// IgnoreRuleTarget is the part of an alert an ignore rule is applied to.
+type IgnoreRuleTarget string
+
+const (
+	// IgnoreRuleTargetHeader applies the rule to the alert header.
+	IgnoreRuleTargetHeader IgnoreRuleTarget = "header"
+
+	// IgnoreRuleTargetText applies the rule to the alert text. This is the default.
+	IgnoreRuleTargetText IgnoreRuleTarget = "text"
+
+	// IgnoreRuleTargetFieldTitle applies the rule to the titles of the alert fields. The rule matches if any title matches.
+	IgnoreRuleTargetFieldTitle IgnoreRuleTarget = "fieldTitle"
+
+	// IgnoreRuleTargetFieldValue applies the rule to the values of the alert fields. The rule matches if any value matches.
+	IgnoreRuleTargetFieldValue IgnoreRuleTarget = "fieldValue"
+
+	// IgnoreRuleTargetMetadata applies the rule to the alert metadata value with the rule key.
+	// The rule does not match if the key does not exist.
+	IgnoreRuleTargetMetadata IgnoreRuleTarget = "metadata"
+)
+
+// IgnoreRuleTargetIsValid returns true if the provided IgnoreRuleTarget is valid.
+func IgnoreRuleTargetIsValid(s IgnoreRuleTarget) bool {
+	switch s {
+	case IgnoreRuleTargetHeader, IgnoreRuleTargetText, IgnoreRuleTargetFieldTitle, IgnoreRuleTargetFieldValue, IgnoreRuleTargetMetadata:
+		return true
+	}
+	return false
+}
+
+// ValidIgnoreRuleTargets returns a slice of valid IgnoreRuleTarget values.
+func ValidIgnoreRuleTargets() []string {
+	return []string{
+		string(IgnoreRuleTargetHeader),
+		string(IgnoreRuleTargetText),
+		string(IgnoreRuleTargetFieldTitle),
+		string(IgnoreRuleTargetFieldValue),
+		string(IgnoreRuleTargetMetadata),
+	}
+}
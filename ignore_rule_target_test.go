@@ -0,0 +1,20 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoreRuleTargetValidation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.IgnoreRuleTargetIsValid(types.IgnoreRuleTargetHeader))
+	assert.True(t, types.IgnoreRuleTargetIsValid(types.IgnoreRuleTargetText))
+	assert.True(t, types.IgnoreRuleTargetIsValid(types.IgnoreRuleTargetFieldTitle))
+	assert.True(t, types.IgnoreRuleTargetIsValid(types.IgnoreRuleTargetFieldValue))
+	assert.True(t, types.IgnoreRuleTargetIsValid(types.IgnoreRuleTargetMetadata))
+	assert.False(t, types.IgnoreRuleTargetIsValid("footer"))
+	assert.Len(t, types.ValidIgnoreRuleTargets(), 5)
+}
@@ -0,0 +1,105 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIgnoreRuleAlert(rules ...*types.IgnoreRule) *types.Alert {
+	a := types.NewErrorAlert()
+	a.SlackChannelID = "C12345678"
+	a.Header = "Health check failed"
+	a.Text = "Connection refused by upstream"
+	a.Fields = []*types.Field{{Title: "Environment", Value: "staging"}}
+	a.Metadata["service"] = "billing"
+	a.IgnoreRules = rules
+
+	a.Clean()
+
+	return a
+}
+
+func TestAlertIgnoreRulesClean(t *testing.T) {
+	t.Parallel()
+
+	a := newIgnoreRuleAlert(
+		&types.IgnoreRule{Value: "x"},
+		&types.IgnoreRule{Target: " FIELDTITLE ", Operator: " Prefix ", Value: " x"},
+		&types.IgnoreRule{Target: "metadata", Key: " service ", Value: "x"},
+	)
+
+	assert.Equal(t, types.IgnoreRuleTargetText, a.IgnoreRules[0].Target)
+	assert.Equal(t, types.IgnoreRuleOperatorContains, a.IgnoreRules[0].Operator)
+	assert.Equal(t, types.IgnoreRuleTargetFieldTitle, a.IgnoreRules[1].Target)
+	assert.Equal(t, types.IgnoreRuleOperatorPrefix, a.IgnoreRules[1].Operator)
+	assert.Equal(t, " x", a.IgnoreRules[1].Value, "values are not trimmed")
+	assert.Equal(t, "service", a.IgnoreRules[2].Key)
+	require.NoError(t, a.Validate())
+}
+
+func TestAlertIgnoreRulesValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rule     *types.IgnoreRule
+		expected string
+	}{
+		{nil, "ignoreRules[0] cannot be null"},
+		{&types.IgnoreRule{Target: "footer", Value: "x"}, "ignoreRules[0].target 'footer' is not valid, expected one of [header, text, fieldTitle, fieldValue, metadata]"},
+		{&types.IgnoreRule{Target: "metadata", Value: "x"}, "ignoreRules[0].key is required for target 'metadata'"},
+		{&types.IgnoreRule{Key: "service", Value: "x"}, "ignoreRules[0].key is only allowed for target 'metadata'"},
+		{&types.IgnoreRule{Operator: "suffix", Value: "x"}, "ignoreRules[0].operator 'suffix' is not valid, expected one of [contains, equals, prefix, regex]"},
+		{&types.IgnoreRule{}, "ignoreRules[0].value cannot be empty"},
+		{&types.IgnoreRule{Value: strings.Repeat("x", 1001)}, "ignoreRules[0].value is too long, expected length <=1000"},
+		{&types.IgnoreRule{Operator: "regex", Value: "("}, "ignoreRules[0].value is not a valid regular expression"},
+	}
+
+	for _, test := range tests {
+		a := newIgnoreRuleAlert(test.rule)
+		require.ErrorContains(t, a.Validate(), test.expected)
+	}
+
+	rules := make([]*types.IgnoreRule, types.MaxIgnoreRuleCount+1)
+	for i := range rules {
+		rules[i] = &types.IgnoreRule{Value: "x"}
+	}
+
+	require.EqualError(t, newIgnoreRuleAlert(rules...).Validate(), "too many ignoreRules, expected <=20")
+}
+
+func TestAlertShouldIgnore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rule     *types.IgnoreRule
+		expected bool
+	}{
+		{&types.IgnoreRule{Value: "refused"}, true},
+		{&types.IgnoreRule{Value: "timeout"}, false},
+		{&types.IgnoreRule{Target: "header", Operator: "equals", Value: "Health check failed"}, true},
+		{&types.IgnoreRule{Target: "header", Operator: "equals", Value: "Health check"}, false},
+		{&types.IgnoreRule{Target: "header", Operator: "prefix", Value: "Health"}, true},
+		{&types.IgnoreRule{Target: "header", Operator: "regex", Value: `(?i)^health\s+CHECK`}, true},
+		{&types.IgnoreRule{Target: "fieldTitle", Operator: "equals", Value: "Environment"}, true},
+		{&types.IgnoreRule{Target: "fieldValue", Operator: "equals", Value: "staging"}, true},
+		{&types.IgnoreRule{Target: "fieldValue", Operator: "equals", Value: "production"}, false},
+		{&types.IgnoreRule{Target: "metadata", Key: "service", Operator: "equals", Value: "billing"}, true},
+		{&types.IgnoreRule{Target: "metadata", Key: "team", Operator: "equals", Value: "billing"}, false},
+	}
+
+	for _, test := range tests {
+		a := newIgnoreRuleAlert(test.rule)
+		require.NoError(t, a.Validate())
+		assert.Equal(t, test.expected, a.ShouldIgnore(), "%+v", test.rule)
+	}
+
+	a := newIgnoreRuleAlert()
+	assert.False(t, a.ShouldIgnore())
+
+	a.IgnoreIfTextContains = []string{"upstream"}
+	assert.True(t, a.ShouldIgnore())
+}
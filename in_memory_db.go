@@ -2,9 +2,13 @@ package types
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -18,6 +22,12 @@ type InMemoryDB struct {
 	channelProcessingStates map[string]*ChannelProcessingState
 }
 
+var (
+	_ DB                     = (*InMemoryDB)(nil)
+	_ IssueLister            = (*InMemoryDB)(nil)
+	_ FindCapabilityReporter = (*InMemoryDB)(nil)
+)
+
 type inMemoryIssueRecord struct {
 	channelID     string
 	correlationID string
@@ -225,6 +235,82 @@ func (db *InMemoryDB) LoadOpenIssuesInChannel(_ context.Context, channelID strin
 	return result, nil
 }
 
+// FindCapabilities reports that all matcher operators, body fields and groups are supported by ListIssues.
+func (db *InMemoryDB) FindCapabilities() FindCapabilities {
+	return FindCapabilities{
+		Operators:  []FindMatchOperator{FindMatchEquals, FindMatchPrefix, FindMatchContains, FindMatchExists},
+		BodyFields: true,
+		Groups:     true,
+	}
+}
+
+// ListIssues returns a page of issues matching the options, sorted according to the options, and then by unique ID.
+// The cursor is the offset of the page, so pages may overlap or skip issues if issues are added or removed between calls.
+func (db *InMemoryDB) ListIssues(_ context.Context, opts ...FindOption) (*PageResult[IssueRecord], error) {
+	o, err := NewFindOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := decodeInMemoryCursor(o.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+
+	items := make([]*inMemoryFindItem, 0, len(db.issues))
+
+	for id, record := range db.issues {
+		recordCopy := *record
+		items = append(items, &inMemoryFindItem{id: id, record: &recordCopy})
+	}
+
+	db.mu.RUnlock()
+
+	expr := o.Expr()
+
+	items = slices.DeleteFunc(items, func(item *inMemoryFindItem) bool {
+		return !expr.match(item.value)
+	})
+
+	slices.SortFunc(items, func(a, b *inMemoryFindItem) int {
+		for _, s := range o.Sort {
+			av, aok := a.value(s.Field)
+			bv, bok := b.value(s.Field)
+
+			c := compareFindValues(av, aok, bv, bok)
+			if s.Direction == SortDescending {
+				c = -c
+			}
+
+			if c != 0 {
+				return c
+			}
+		}
+
+		return strings.Compare(a.id, b.id)
+	})
+
+	result := &PageResult[IssueRecord]{Items: []IssueRecord{}}
+
+	if offset >= len(items) {
+		return result, nil
+	}
+
+	end := len(items)
+	if o.Limit > 0 && offset+o.Limit < end {
+		end = offset + o.Limit
+		result.NextCursor = encodeInMemoryCursor(end)
+	}
+
+	for _, item := range items[offset:end] {
+		result.Items = append(result.Items, IssueRecord{ID: item.id, Body: item.record.body})
+	}
+
+	return result, nil
+}
+
 // SaveMoveMapping creates or updates a move mapping.
 func (db *InMemoryDB) SaveMoveMapping(_ context.Context, moveMapping MoveMapping) error {
 	if moveMapping == nil {
@@ -318,6 +404,67 @@ func (db *InMemoryDB) DropAllData(_ context.Context) error {
 	return nil
 }
 
+// inMemoryFindItem is an issue record evaluated by ListIssues. The decoded JSON body is cached on first use.
+type inMemoryFindItem struct {
+	id      string
+	record  *inMemoryIssueRecord
+	body    any
+	decoded bool
+}
+
+func (item *inMemoryFindItem) value(field IssueField) (any, bool) {
+	path, ok := strings.CutPrefix(string(field), issueFieldBodyPrefix)
+	if !ok {
+		value := inMemoryIssueFieldValue(item.record, field)
+		return value, value != ""
+	}
+
+	if !item.decoded {
+		item.decoded = true
+		_ = json.Unmarshal(item.record.body, &item.body)
+	}
+
+	return jsonPathValue(item.body, path)
+}
+
+func inMemoryIssueFieldValue(record *inMemoryIssueRecord, field IssueField) string {
+	switch field {
+	case IssueFieldChannelID:
+		return record.channelID
+	case IssueFieldCorrelationID:
+		return record.correlationID
+	case IssueFieldStatus:
+		if record.isOpen {
+			return "open"
+		}
+		return "archived"
+	default:
+		return ""
+	}
+}
+
+func encodeInMemoryCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeInMemoryCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("cursor '%s' is not valid", cursor)
+	}
+
+	offset, err := strconv.Atoi(string(b))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("cursor '%s' is not valid", cursor)
+	}
+
+	return offset, nil
+}
+
 func moveMappingKey(channelID, correlationID string) string {
 	return channelID + "\x00" + correlationID
 }
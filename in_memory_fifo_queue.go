@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,8 +15,14 @@ type InMemoryFifoQueue struct {
 	name         string
 	items        chan *FifoQueueItem
 	writeTimeout time.Duration
+	deadLetter   *InMemoryFifoQueue
 }
 
+var (
+	_ FifoQueue  = (*InMemoryFifoQueue)(nil)
+	_ BatchAcker = (*InMemoryFifoQueue)(nil)
+)
+
 // NewInMemoryFifoQueue creates a new InMemoryFifoQueue instance.
 // name is the name of the queue (for logging purposes only).
 // bufferSize is the maximum number of items that can be stored in the queue.
@@ -30,6 +37,13 @@ func NewInMemoryFifoQueue(name string, bufferSize int, writeTimeout time.Duratio
 	}
 }
 
+// WithDeadLetter sets the queue returned by DeadLetter, and returns q.
+// The in-memory queue never moves messages to the dead-letter queue by itself.
+func (q *InMemoryFifoQueue) WithDeadLetter(deadLetter *InMemoryFifoQueue) *InMemoryFifoQueue {
+	q.deadLetter = deadLetter
+	return q
+}
+
 // Name returns the name of the queue.
 func (q *InMemoryFifoQueue) Name() string {
 	return q.name
@@ -38,13 +52,19 @@ func (q *InMemoryFifoQueue) Name() string {
 // Send sends a message to the queue.
 // An error is returned if the context is canceled or the write timeout is reached.
 func (q *InMemoryFifoQueue) Send(ctx context.Context, slackChannelID, _, body string) error {
+	id := uuid.New().String()
+
 	item := &FifoQueueItem{
-		MessageID:        uuid.New().String(),
+		MessageID:        id,
 		SlackChannelID:   slackChannelID,
 		ReceiveTimestamp: time.Now(),
 		Body:             body,
 		Ack:              func() {},
 		Nack:             func() {},
+		NackWithDelay:    func(time.Duration) {},
+		ReceiveCount:     1,
+		Extend:           func(time.Duration) error { return nil },
+		AckToken:         id,
 	}
 
 	select {
@@ -76,3 +96,62 @@ func (q *InMemoryFifoQueue) Receive(ctx context.Context, sinkCh chan<- *FifoQueu
 		}
 	}
 }
+
+// SendBatch sends multiple messages to the queue, in order.
+// An error is returned if the context is canceled or the write timeout is reached.
+func (q *InMemoryFifoQueue) SendBatch(ctx context.Context, messages []*FifoQueueMessage) error {
+	for index, m := range messages {
+		if err := q.Send(ctx, m.SlackChannelID, m.DedupID, m.Body); err != nil {
+			return fmt.Errorf("messages[%d]: %w", index, err)
+		}
+	}
+
+	return nil
+}
+
+// ReceiveBatch waits until at least one message is available, and returns up to maxItems messages.
+// An error is returned if the context is canceled.
+func (q *InMemoryFifoQueue) ReceiveBatch(ctx context.Context, maxItems int) ([]*FifoQueueItem, error) {
+	return receiveBatch(ctx, q.items, maxItems)
+}
+
+// DeadLetter returns the queue set with WithDeadLetter, or ErrDeadLetterNotSupported.
+func (q *InMemoryFifoQueue) DeadLetter() (FifoQueue, error) {
+	if q.deadLetter == nil {
+		return nil, ErrDeadLetterNotSupported
+	}
+
+	return q.deadLetter, nil
+}
+
+// receiveBatch waits until at least one item is available in the channel, and returns up to maxItems items.
+func receiveBatch[T any](ctx context.Context, items <-chan T, maxItems int) ([]T, error) {
+	if maxItems <= 0 {
+		return nil, fmt.Errorf("maxItems must be positive, got %d", maxItems)
+	}
+
+	var batch []T
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case item := <-items:
+		batch = append(batch, item)
+	}
+
+	for len(batch) < maxItems {
+		select {
+		case item := <-items:
+			batch = append(batch, item)
+		default:
+			return batch, nil
+		}
+	}
+
+	return batch, nil
+}
+
+// AckBatch acknowledges the messages identified by the ack tokens. Like Ack, it is a no-op for the in-memory queue.
+func (q *InMemoryFifoQueue) AckBatch(context.Context, []string) error {
+	return nil
+}
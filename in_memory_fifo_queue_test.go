@@ -96,4 +96,56 @@ func TestInMemoryFifoQueue(t *testing.T) {
 			break
 		}
 	})
+
+	t.Run("batch functions should send and receive items in order", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		queue := types.NewInMemoryFifoQueue("alerts", 3, time.Millisecond)
+		err := queue.SendBatch(ctx, []*types.FifoQueueMessage{
+			{SlackChannelID: "C000000001", DedupID: "dedupID_1", Body: "body_1"},
+			{SlackChannelID: "C000000001", DedupID: "dedupID_2", Body: "body_2"},
+			{SlackChannelID: "C000000002", DedupID: "dedupID_3", Body: "body_3"},
+		})
+		require.NoError(t, err)
+
+		err = queue.SendBatch(ctx, []*types.FifoQueueMessage{{SlackChannelID: "C000000001", Body: "body_4"}})
+		require.ErrorContains(t, err, "messages[0]: timeout")
+
+		items, err := queue.ReceiveBatch(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, "body_1", items[0].Body)
+		assert.Equal(t, "body_2", items[1].Body)
+		require.NoError(t, items[0].Extend(time.Minute))
+		assert.Equal(t, 1, items[0].ReceiveCount)
+		items[1].NackWithDelay(time.Second)
+
+		items, err = queue.ReceiveBatch(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "C000000002", items[0].SlackChannelID)
+
+		_, err = queue.ReceiveBatch(ctx, 0)
+		require.Error(t, err)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err = queue.ReceiveBatch(timeoutCtx, 1)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("dead letter queue must be set to be accessed", func(t *testing.T) {
+		t.Parallel()
+
+		queue := types.NewInMemoryFifoQueue("alerts", 1, time.Millisecond)
+		_, err := queue.DeadLetter()
+		require.ErrorIs(t, err, types.ErrDeadLetterNotSupported)
+
+		dlq := types.NewInMemoryFifoQueue("alerts-dlq", 1, time.Millisecond)
+		deadLetter, err := queue.WithDeadLetter(dlq).DeadLetter()
+		require.NoError(t, err)
+		assert.Equal(t, "alerts-dlq", deadLetter.Name())
+	})
 }
@@ -0,0 +1,107 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// InMemoryGlobalIssueIndex is an in-memory implementation of the GlobalIssueIndex interface.
+// For TEST purposes only! Do not use in production!
+type InMemoryGlobalIssueIndex struct {
+	mu   sync.RWMutex
+	refs map[string]map[string]*GlobalIssueReference
+}
+
+// NewInMemoryGlobalIssueIndex creates a new InMemoryGlobalIssueIndex instance.
+// For TEST purposes only! Do not use in production!
+func NewInMemoryGlobalIssueIndex() *InMemoryGlobalIssueIndex {
+	return &InMemoryGlobalIssueIndex{
+		refs: make(map[string]map[string]*GlobalIssueReference),
+	}
+}
+
+// SaveGlobalIssueReference creates or updates a single reference.
+// Returns an error if the reference is nil, or if any of the key fields are empty.
+func (idx *InMemoryGlobalIssueIndex) SaveGlobalIssueReference(_ context.Context, ref *GlobalIssueReference) error {
+	if ref == nil {
+		return errors.New("reference is nil")
+	}
+
+	if ref.GlobalIssueKey == "" {
+		return errors.New("globalIssueKey is required")
+	}
+
+	if ref.ChannelID == "" {
+		return errors.New("channelID is required")
+	}
+
+	if ref.IssueID == "" {
+		return errors.New("issueID is required")
+	}
+
+	refCopy := *ref
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	refs, ok := idx.refs[ref.GlobalIssueKey]
+	if !ok {
+		refs = make(map[string]*GlobalIssueReference)
+		idx.refs[ref.GlobalIssueKey] = refs
+	}
+
+	refs[globalIssueReferenceKey(ref.ChannelID, ref.IssueID)] = &refCopy
+
+	return nil
+}
+
+// FindGlobalIssueReferences returns all references for the specified global issue key, sorted by channel ID and issue ID.
+func (idx *InMemoryGlobalIssueIndex) FindGlobalIssueReferences(_ context.Context, globalIssueKey string) ([]*GlobalIssueReference, error) {
+	if globalIssueKey == "" {
+		return nil, errors.New("globalIssueKey is required")
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	result := make([]*GlobalIssueReference, 0, len(idx.refs[globalIssueKey]))
+
+	for _, ref := range idx.refs[globalIssueKey] {
+		refCopy := *ref
+		result = append(result, &refCopy)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ChannelID != result[j].ChannelID {
+			return result[i].ChannelID < result[j].ChannelID
+		}
+		return result[i].IssueID < result[j].IssueID
+	})
+
+	return result, nil
+}
+
+// DeleteGlobalIssueReference deletes a single reference. No error is returned if the reference does not exist.
+func (idx *InMemoryGlobalIssueIndex) DeleteGlobalIssueReference(_ context.Context, globalIssueKey, channelID, issueID string) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	refs, ok := idx.refs[globalIssueKey]
+	if !ok {
+		return nil
+	}
+
+	delete(refs, globalIssueReferenceKey(channelID, issueID))
+
+	if len(refs) == 0 {
+		delete(idx.refs, globalIssueKey)
+	}
+
+	return nil
+}
+
+func globalIssueReferenceKey(channelID, issueID string) string {
+	return channelID + "\x00" + issueID
+}
@@ -0,0 +1,77 @@
+package types_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryGlobalIssueIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("references should be saved, found and deleted", func(t *testing.T) {
+		t.Parallel()
+
+		idx := types.NewInMemoryGlobalIssueIndex()
+
+		require.NoError(t, idx.SaveGlobalIssueReference(ctx, &types.GlobalIssueReference{GlobalIssueKey: "incident-1", ChannelID: "C2", IssueID: "issue2", Created: time.Now()}))
+		require.NoError(t, idx.SaveGlobalIssueReference(ctx, &types.GlobalIssueReference{GlobalIssueKey: "incident-1", ChannelID: "C1", IssueID: "issue1", Created: time.Now()}))
+		require.NoError(t, idx.SaveGlobalIssueReference(ctx, &types.GlobalIssueReference{GlobalIssueKey: "incident-2", ChannelID: "C1", IssueID: "issue3", Created: time.Now()}))
+
+		// Saving the same reference again should not create a duplicate
+		require.NoError(t, idx.SaveGlobalIssueReference(ctx, &types.GlobalIssueReference{GlobalIssueKey: "incident-1", ChannelID: "C1", IssueID: "issue1", Created: time.Now()}))
+
+		refs, err := idx.FindGlobalIssueReferences(ctx, "incident-1")
+		require.NoError(t, err)
+		require.Len(t, refs, 2)
+		assert.Equal(t, "C1", refs[0].ChannelID)
+		assert.Equal(t, "C2", refs[1].ChannelID)
+
+		require.NoError(t, idx.DeleteGlobalIssueReference(ctx, "incident-1", "C1", "issue1"))
+		require.NoError(t, idx.DeleteGlobalIssueReference(ctx, "incident-1", "C1", "issue1"))
+		require.NoError(t, idx.DeleteGlobalIssueReference(ctx, "unknown", "C1", "issue1"))
+
+		refs, err = idx.FindGlobalIssueReferences(ctx, "incident-1")
+		require.NoError(t, err)
+		require.Len(t, refs, 1)
+		assert.Equal(t, "issue2", refs[0].IssueID)
+
+		refs, err = idx.FindGlobalIssueReferences(ctx, "unknown")
+		require.NoError(t, err)
+		assert.Empty(t, refs)
+	})
+
+	t.Run("invalid references should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		idx := types.NewInMemoryGlobalIssueIndex()
+
+		require.Error(t, idx.SaveGlobalIssueReference(ctx, nil))
+		require.ErrorContains(t, idx.SaveGlobalIssueReference(ctx, &types.GlobalIssueReference{ChannelID: "C1", IssueID: "issue1"}), "globalIssueKey")
+		require.ErrorContains(t, idx.SaveGlobalIssueReference(ctx, &types.GlobalIssueReference{GlobalIssueKey: "incident-1", IssueID: "issue1"}), "channelID")
+		require.ErrorContains(t, idx.SaveGlobalIssueReference(ctx, &types.GlobalIssueReference{GlobalIssueKey: "incident-1", ChannelID: "C1"}), "issueID")
+
+		_, err := idx.FindGlobalIssueReferences(ctx, "")
+		require.Error(t, err)
+	})
+
+	t.Run("alert global issue key should be validated", func(t *testing.T) {
+		t.Parallel()
+
+		a := &types.Alert{Header: "a", RouteKey: "b", GlobalIssueKey: "  incident-1  "}
+		a.Clean()
+		assert.Equal(t, "incident-1", a.GlobalIssueKey)
+		require.NoError(t, a.Validate())
+
+		a = &types.Alert{Header: "a", RouteKey: "b", GlobalIssueKey: strings.Repeat("x", types.MaxGlobalIssueKeyLength+1)}
+		a.Clean()
+		require.ErrorContains(t, a.Validate(), "globalIssueKey is too long")
+	})
+}
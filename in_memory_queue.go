@@ -0,0 +1,129 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InMemoryQueue is an in-memory implementation of the Queue interface.
+// For TEST purposes only! Do not use in production!
+type InMemoryQueue struct {
+	name         string
+	items        chan *QueueItem
+	writeTimeout time.Duration
+	deadLetter   *InMemoryQueue
+}
+
+var (
+	_ Queue      = (*InMemoryQueue)(nil)
+	_ BatchAcker = (*InMemoryQueue)(nil)
+)
+
+// NewInMemoryQueue creates a new InMemoryQueue instance.
+// name is the name of the queue (for logging purposes only).
+// bufferSize is the maximum number of items that can be stored in the queue.
+// writeTimeout is the maximum time to wait for writing an item to the queue.
+//
+// For TEST purposes only! Do not use in production!
+func NewInMemoryQueue(name string, bufferSize int, writeTimeout time.Duration) *InMemoryQueue {
+	return &InMemoryQueue{
+		name:         name,
+		items:        make(chan *QueueItem, bufferSize),
+		writeTimeout: writeTimeout,
+	}
+}
+
+// WithDeadLetter sets the queue returned by DeadLetter, and returns q.
+// The in-memory queue never moves messages to the dead-letter queue by itself.
+func (q *InMemoryQueue) WithDeadLetter(deadLetter *InMemoryQueue) *InMemoryQueue {
+	q.deadLetter = deadLetter
+	return q
+}
+
+// Name returns the name of the queue.
+func (q *InMemoryQueue) Name() string {
+	return q.name
+}
+
+// Send sends a message to the queue.
+// An error is returned if the context is canceled or the write timeout is reached.
+func (q *InMemoryQueue) Send(ctx context.Context, body string) error {
+	id := uuid.New().String()
+
+	item := &QueueItem{
+		MessageID:        id,
+		ReceiveTimestamp: time.Now(),
+		Body:             body,
+		Ack:              func() {},
+		Nack:             func() {},
+		NackWithDelay:    func(time.Duration) {},
+		ReceiveCount:     1,
+		Extend:           func(time.Duration) error { return nil },
+		AckToken:         id,
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(q.writeTimeout):
+		return errors.New("timeout while writing to queue")
+	case q.items <- item:
+		return nil
+	}
+}
+
+// SendBatch sends multiple messages to the queue.
+// An error is returned if the context is canceled or the write timeout is reached.
+func (q *InMemoryQueue) SendBatch(ctx context.Context, bodies []string) error {
+	for index, body := range bodies {
+		if err := q.Send(ctx, body); err != nil {
+			return fmt.Errorf("bodies[%d]: %w", index, err)
+		}
+	}
+
+	return nil
+}
+
+// Receive receives messages from the queue, to the specified sink channel.
+// An error is returned if the context is canceled.
+// The sink channel is closed when the function returns.
+func (q *InMemoryQueue) Receive(ctx context.Context, sinkCh chan<- *QueueItem) error {
+	defer close(sinkCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item := <-q.items:
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case sinkCh <- item:
+			}
+		}
+	}
+}
+
+// ReceiveBatch waits until at least one message is available, and returns up to maxItems messages.
+// An error is returned if the context is canceled.
+func (q *InMemoryQueue) ReceiveBatch(ctx context.Context, maxItems int) ([]*QueueItem, error) {
+	return receiveBatch(ctx, q.items, maxItems)
+}
+
+// DeadLetter returns the queue set with WithDeadLetter, or ErrDeadLetterNotSupported.
+func (q *InMemoryQueue) DeadLetter() (Queue, error) {
+	if q.deadLetter == nil {
+		return nil, ErrDeadLetterNotSupported
+	}
+
+	return q.deadLetter, nil
+}
+
+// AckBatch acknowledges the messages identified by the ack tokens. Like Ack, it is a no-op for the in-memory queue.
+func (q *InMemoryQueue) AckBatch(context.Context, []string) error {
+	return nil
+}
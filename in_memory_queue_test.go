@@ -0,0 +1,87 @@
+package types_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("full queue should produce timeout error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		queue := types.NewInMemoryQueue("callbacks", 1, time.Millisecond)
+		require.NoError(t, queue.Send(ctx, "body_1"))
+		require.ErrorContains(t, queue.Send(ctx, "body_2"), "timeout")
+		require.ErrorContains(t, queue.SendBatch(ctx, []string{"body_3"}), "bodies[0]: timeout")
+	})
+
+	t.Run("receive function should return all items", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		queue := types.NewInMemoryQueue("callbacks", 3, time.Millisecond)
+		require.NoError(t, queue.SendBatch(ctx, []string{"body_1", "body_2", "body_3"}))
+
+		receivedItems := make(chan *types.QueueItem, 3)
+
+		go func() {
+			err := queue.Receive(ctx, receivedItems)
+			assert.ErrorIs(t, err, context.Canceled)
+		}()
+
+		var result []string
+
+		for item := range receivedItems {
+			item.Ack()
+			result = append(result, item.Body)
+
+			if len(result) == 3 {
+				cancel()
+			}
+		}
+
+		assert.ElementsMatch(t, []string{"body_1", "body_2", "body_3"}, result)
+	})
+
+	t.Run("receive batch should return up to max items", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		queue := types.NewInMemoryQueue("callbacks", 3, time.Millisecond)
+		require.NoError(t, queue.SendBatch(ctx, []string{"body_1", "body_2", "body_3"}))
+
+		items, err := queue.ReceiveBatch(ctx, 2)
+		require.NoError(t, err)
+		assert.Len(t, items, 2)
+		require.NoError(t, items[0].Extend(time.Minute))
+		assert.Equal(t, 1, items[0].ReceiveCount)
+		items[1].NackWithDelay(time.Second)
+
+		items, err = queue.ReceiveBatch(ctx, 2)
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.NotEmpty(t, items[0].MessageID)
+	})
+
+	t.Run("dead letter queue must be set to be accessed", func(t *testing.T) {
+		t.Parallel()
+
+		queue := types.NewInMemoryQueue("callbacks", 1, time.Millisecond)
+		_, err := queue.DeadLetter()
+		require.ErrorIs(t, err, types.ErrDeadLetterNotSupported)
+
+		deadLetter, err := queue.WithDeadLetter(types.NewInMemoryQueue("callbacks-dlq", 1, time.Millisecond)).DeadLetter()
+		require.NoError(t, err)
+		assert.Equal(t, "callbacks-dlq", deadLetter.Name())
+	})
+}
@@ -0,0 +1,128 @@
+package grpcingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/ingest/grpcingest/ingestv1"
+	"google.golang.org/grpc"
+)
+
+// Client submits alerts to the gRPC service, and receives webhook callbacks from it, with the Go types.
+// It is safe for concurrent use.
+type Client struct {
+	client ingestv1.AlertIngestionClient
+}
+
+// NewClient creates a new Client using the connection, such as a *grpc.ClientConn.
+// Retries and timeouts are configured on the connection and the call contexts.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{client: ingestv1.NewAlertIngestionClient(conn)}
+}
+
+// SubmitAlert cleans and validates the alert, and submits it.
+func (c *Client) SubmitAlert(ctx context.Context, alert *types.Alert) error {
+	if alert == nil {
+		return errors.New("alert cannot be nil")
+	}
+
+	alert.Clean()
+
+	if err := alert.Validate(); err != nil {
+		return fmt.Errorf("alert is not valid: %w", err)
+	}
+
+	pb, err := AlertToProto(alert)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SubmitAlert(ctx, &ingestv1.SubmitAlertRequest{Alert: pb})
+
+	return err
+}
+
+// SubmitBatch cleans and validates the batch and all alerts in it, submits the batch, and returns the result
+// of each alert.
+func (c *Client) SubmitBatch(ctx context.Context, batch *types.AlertBatch) ([]types.AlertBatchItemResult, error) {
+	if batch == nil {
+		return nil, errors.New("batch cannot be nil")
+	}
+
+	batch.Clean()
+
+	if err := batch.Validate(); err != nil {
+		return nil, fmt.Errorf("batch is not valid: %w", err)
+	}
+
+	for _, result := range batch.ValidateItems() {
+		if result.Error != "" {
+			return nil, fmt.Errorf("alerts[%d] is not valid: %s", result.Index, result.Error)
+		}
+	}
+
+	req := &ingestv1.SubmitBatchRequest{Alerts: make([]*ingestv1.Alert, 0, batch.Len())}
+
+	for _, alert := range batch.Alerts {
+		pb, err := AlertToProto(alert)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Alerts = append(req.Alerts, pb)
+	}
+
+	resp, err := c.client.SubmitBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return batchResultsFromProto(resp.GetResults()), nil
+}
+
+// StreamCallbacks calls handle for each webhook callback matching the filter, until the context is canceled,
+// the server ends the stream or handle returns an error. The error of handle is returned as is.
+// The context error is returned if the context is canceled, and nil if the server ends the stream.
+func (c *Client) StreamCallbacks(ctx context.Context, filter CallbackFilter, handle func(*types.WebhookCallback) error) error {
+	if handle == nil {
+		return errors.New("handle cannot be nil")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.StreamCallbacks(ctx, &ingestv1.StreamCallbacksRequest{
+		ChannelIds: filter.ChannelIDs,
+		WebhookIds: filter.WebhookIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		pb, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return err
+		}
+
+		callback, err := CallbackFromProto(pb)
+		if err != nil {
+			return err
+		}
+
+		if err := handle(callback); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package grpcingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/ingest/grpcingest/ingestv1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	// alertFields are the JSON fields of types.Alert carried in the typed fields of ingestv1.Alert.
+	alertFields = []string{
+		"timestamp", "correlationId", "type", "header", "text", "fallbackText", "author", "host", "footer", "link",
+		"severity", "priority", "slackChannelId", "routeKey", "username", "iconEmoji", "issueFollowUpEnabled", "autoResolveSeconds",
+	}
+
+	// callbackFields are the JSON fields of types.WebhookCallback carried in the typed fields of ingestv1.WebhookCallback.
+	callbackFields = []string{"id", "userId", "userRealName", "channelId", "messageId", "timestamp", "input", "deliveryId"}
+
+	// zeroJSONValues are the JSON encodings of zero values, which are left out of the extra JSON.
+	zeroJSONValues = map[string]bool{`null`: true, `""`: true, `0`: true, `false`: true, `[]`: true, `{}`: true, `"0001-01-01T00:00:00Z"`: true}
+)
+
+// AlertToProto converts an alert to its protobuf message. Fields without a typed protobuf field, and metadata
+// values that are not strings, are carried in the extra JSON.
+func AlertToProto(alert *types.Alert) (*ingestv1.Alert, error) {
+	if alert == nil {
+		return nil, errors.New("alert cannot be nil")
+	}
+
+	extra, err := extraJSON(alert, alertFields, func(fields map[string]json.RawMessage) error {
+		return otherMetadata(alert.Metadata, fields)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode alert: %w", err)
+	}
+
+	pb := &ingestv1.Alert{
+		CorrelationId:        alert.CorrelationID,
+		Type:                 alert.Type,
+		Header:               alert.Header,
+		Text:                 alert.Text,
+		FallbackText:         alert.FallbackText,
+		Author:               alert.Author,
+		Host:                 alert.Host,
+		Footer:               alert.Footer,
+		Link:                 alert.Link,
+		Severity:             string(alert.Severity),
+		Priority:             string(alert.Priority),
+		SlackChannelId:       alert.SlackChannelID,
+		RouteKey:             alert.RouteKey,
+		Username:             alert.Username,
+		IconEmoji:            alert.IconEmoji,
+		IssueFollowUpEnabled: alert.IssueFollowUpEnabled,
+		AutoResolveSeconds:   int64(alert.AutoResolveSeconds),
+		ExtraJson:            extra,
+	}
+
+	if !alert.Timestamp.IsZero() {
+		pb.Timestamp = timestamppb.New(alert.Timestamp)
+	}
+
+	for key, value := range alert.Metadata {
+		if s, ok := value.(string); ok {
+			if pb.Metadata == nil {
+				pb.Metadata = make(map[string]string)
+			}
+
+			pb.Metadata[key] = s
+		}
+	}
+
+	return pb, nil
+}
+
+// AlertFromProto converts a protobuf message to an alert. The extra JSON is decoded first, and the typed fields
+// that are not empty take precedence. The alert is not cleaned or validated.
+func AlertFromProto(pb *ingestv1.Alert) (*types.Alert, error) {
+	if pb == nil {
+		return nil, errors.New("alert cannot be nil")
+	}
+
+	alert := &types.Alert{}
+
+	if len(pb.GetExtraJson()) > 0 {
+		if err := json.Unmarshal(pb.GetExtraJson(), alert); err != nil {
+			return nil, fmt.Errorf("invalid alert extra JSON: %w", err)
+		}
+	}
+
+	if pb.GetTimestamp() != nil {
+		alert.Timestamp = pb.GetTimestamp().AsTime()
+	}
+
+	setString(&alert.CorrelationID, pb.GetCorrelationId())
+	setString(&alert.Type, pb.GetType())
+	setString(&alert.Header, pb.GetHeader())
+	setString(&alert.Text, pb.GetText())
+	setString(&alert.FallbackText, pb.GetFallbackText())
+	setString(&alert.Author, pb.GetAuthor())
+	setString(&alert.Host, pb.GetHost())
+	setString(&alert.Footer, pb.GetFooter())
+	setString(&alert.Link, pb.GetLink())
+	setString(&alert.Severity, types.AlertSeverity(pb.GetSeverity()))
+	setString(&alert.Priority, types.AlertPriority(pb.GetPriority()))
+	setString(&alert.SlackChannelID, pb.GetSlackChannelId())
+	setString(&alert.RouteKey, pb.GetRouteKey())
+	setString(&alert.Username, pb.GetUsername())
+	setString(&alert.IconEmoji, pb.GetIconEmoji())
+
+	if pb.GetIssueFollowUpEnabled() {
+		alert.IssueFollowUpEnabled = true
+	}
+
+	if pb.GetAutoResolveSeconds() != 0 {
+		alert.AutoResolveSeconds = int(pb.GetAutoResolveSeconds())
+	}
+
+	if len(pb.GetMetadata()) > 0 && alert.Metadata == nil {
+		alert.Metadata = make(map[string]any, len(pb.GetMetadata()))
+	}
+
+	for key, value := range pb.GetMetadata() {
+		alert.Metadata[key] = value
+	}
+
+	return alert, nil
+}
+
+// CallbackToProto converts a webhook callback to its protobuf message. Fields without a typed protobuf field
+// are carried in the extra JSON.
+func CallbackToProto(callback *types.WebhookCallback) (*ingestv1.WebhookCallback, error) {
+	if callback == nil {
+		return nil, errors.New("callback cannot be nil")
+	}
+
+	extra, err := extraJSON(callback, callbackFields, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode callback: %w", err)
+	}
+
+	pb := &ingestv1.WebhookCallback{
+		Id:           callback.ID,
+		UserId:       callback.UserID,
+		UserRealName: callback.UserRealName,
+		ChannelId:    callback.ChannelID,
+		MessageId:    callback.MessageID,
+		Input:        callback.Input,
+		DeliveryId:   callback.DeliveryID,
+		ExtraJson:    extra,
+	}
+
+	if !callback.Timestamp.IsZero() {
+		pb.Timestamp = timestamppb.New(callback.Timestamp)
+	}
+
+	return pb, nil
+}
+
+// CallbackFromProto converts a protobuf message to a webhook callback. The extra JSON is decoded first,
+// and the typed fields that are not empty take precedence.
+func CallbackFromProto(pb *ingestv1.WebhookCallback) (*types.WebhookCallback, error) {
+	if pb == nil {
+		return nil, errors.New("callback cannot be nil")
+	}
+
+	callback := &types.WebhookCallback{}
+
+	if len(pb.GetExtraJson()) > 0 {
+		if err := json.Unmarshal(pb.GetExtraJson(), callback); err != nil {
+			return nil, fmt.Errorf("invalid callback extra JSON: %w", err)
+		}
+	}
+
+	if pb.GetTimestamp() != nil {
+		callback.Timestamp = pb.GetTimestamp().AsTime()
+	}
+
+	setString(&callback.ID, pb.GetId())
+	setString(&callback.UserID, pb.GetUserId())
+	setString(&callback.UserRealName, pb.GetUserRealName())
+	setString(&callback.ChannelID, pb.GetChannelId())
+	setString(&callback.MessageID, pb.GetMessageId())
+	setString(&callback.DeliveryID, pb.GetDeliveryId())
+
+	if len(pb.GetInput()) > 0 {
+		callback.Input = pb.GetInput()
+	}
+
+	return callback, nil
+}
+
+// batchResultsToProto converts the results of a batch to their protobuf messages.
+func batchResultsToProto(results []types.AlertBatchItemResult) []*ingestv1.BatchItemResult {
+	pb := make([]*ingestv1.BatchItemResult, 0, len(results))
+
+	for _, result := range results {
+		item := &ingestv1.BatchItemResult{
+			Index:    int32(result.Index), // #nosec G115 -- indexes are bounded by the number of alerts in a request
+			UniqueId: result.UniqueID,
+			Error:    result.Error,
+		}
+
+		for _, fieldErr := range result.FieldErrors {
+			item.FieldErrors = append(item.FieldErrors, &ingestv1.FieldError{Field: fieldErr.Field, Message: fieldErr.Message})
+		}
+
+		pb = append(pb, item)
+	}
+
+	return pb
+}
+
+// batchResultsFromProto converts protobuf messages to the results of a batch.
+func batchResultsFromProto(pb []*ingestv1.BatchItemResult) []types.AlertBatchItemResult {
+	results := make([]types.AlertBatchItemResult, 0, len(pb))
+
+	for _, item := range pb {
+		result := types.AlertBatchItemResult{
+			Index:    int(item.GetIndex()),
+			UniqueID: item.GetUniqueId(),
+			Error:    item.GetError(),
+		}
+
+		for _, fieldErr := range item.GetFieldErrors() {
+			result.FieldErrors = append(result.FieldErrors, types.FieldError{Field: fieldErr.GetField(), Message: fieldErr.GetMessage()})
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// extraJSON returns the JSON object of v without the typed fields and the fields with zero values,
+// or nil if no fields remain. The edit function, if not nil, may change the remaining fields.
+func extraJSON(v any, typed []string, edit func(map[string]json.RawMessage) error) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for _, name := range typed {
+		delete(fields, name)
+	}
+
+	if edit != nil {
+		if err := edit(fields); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, value := range fields {
+		if zeroJSONValues[string(value)] {
+			delete(fields, name)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(fields)
+}
+
+// otherMetadata replaces the metadata field with the metadata values that are not strings.
+func otherMetadata(metadata map[string]any, fields map[string]json.RawMessage) error {
+	other := make(map[string]any)
+
+	for key, value := range metadata {
+		if _, ok := value.(string); !ok {
+			other[key] = value
+		}
+	}
+
+	data, err := json.Marshal(other)
+	if err != nil {
+		return err
+	}
+
+	fields["metadata"] = data
+
+	return nil
+}
+
+func setString[T ~string](dst *T, value T) {
+	if value != "" {
+		*dst = value
+	}
+}
@@ -0,0 +1,25 @@
+module github.com/slackmgr/types/ingest/grpcingest
+
+go 1.25.0
+
+require (
+	github.com/slackmgr/types v0.0.0
+	github.com/stretchr/testify v1.11.1
+	google.golang.org/grpc v1.80.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.49.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/text v0.33.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260120221211-b8f7ae30c516 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The ingestion service is developed together with the core module.
+replace github.com/slackmgr/types => ../..
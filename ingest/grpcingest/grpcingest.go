@@ -0,0 +1,66 @@
+// Package grpcingest provides a gRPC service for submitting alerts to the Slack Manager, and streaming webhook
+// callbacks back to the producers, as an alternative to the HTTP API for high-volume producers.
+//
+// The package is a separate module, so that the core module does not depend on gRPC and protobuf.
+// The service and its messages are defined in proto/slackmgr/ingest/v1/alert_ingestion.proto, and the generated
+// Go code is in the ingestv1 package (regenerate it with 'buf generate' in this directory).
+//
+// Server adapts a Handler, working with the Go types, to the generated service interface:
+//
+//	grpcServer := grpc.NewServer()
+//	ingestv1.RegisterAlertIngestionServer(grpcServer, grpcingest.NewServer(handler))
+//
+// Client submits alerts and receives callbacks with the Go types:
+//
+//	conn, err := grpc.NewClient("slack-manager.internal:9090", grpc.WithTransportCredentials(creds))
+//	c := grpcingest.NewClient(conn)
+//	err = c.SubmitAlert(ctx, alert)
+//
+// The most common fields of alerts and callbacks have typed protobuf fields. All other fields are carried as JSON,
+// in the format of the HTTP API, so that new fields of the Go types don't require a schema change.
+// AlertToProto, AlertFromProto, CallbackToProto and CallbackFromProto convert between the Go types and the messages.
+package grpcingest
+
+import (
+	"context"
+	"slices"
+
+	"github.com/slackmgr/types"
+)
+
+// Handler processes the calls of the gRPC service, with the Go types. It is implemented by the Slack Manager API.
+// Return a gRPC status error (see google.golang.org/grpc/status) to set the status code of a failed call.
+type Handler interface {
+	// SubmitAlert processes a single alert. The alert is not cleaned or validated by the Server.
+	SubmitAlert(ctx context.Context, alert *types.Alert) error
+
+	// SubmitBatch processes a batch of alerts, and returns the result of each alert.
+	// The alerts are not cleaned or validated by the Server.
+	SubmitBatch(ctx context.Context, batch *types.AlertBatch) ([]types.AlertBatchItemResult, error)
+
+	// StreamCallbacks calls send for each webhook callback matching the filter, until the context is canceled
+	// or send returns an error.
+	StreamCallbacks(ctx context.Context, filter CallbackFilter, send func(*types.WebhookCallback) error) error
+}
+
+// CallbackFilter selects the webhook callbacks streamed by StreamCallbacks.
+type CallbackFilter struct {
+	// ChannelIDs are the Slack channels of the streamed callbacks. All channels match if empty.
+	ChannelIDs []string
+
+	// WebhookIDs are the webhook IDs of the streamed callbacks. All webhooks match if empty.
+	WebhookIDs []string
+}
+
+// Matches returns true if the callback matches the filter.
+func (f CallbackFilter) Matches(callback *types.WebhookCallback) bool {
+	if callback == nil {
+		return false
+	}
+
+	if len(f.ChannelIDs) > 0 && !slices.Contains(f.ChannelIDs, callback.ChannelID) {
+		return false
+	}
+
+	return len(f.WebhookIDs) == 0 || slices.Contains(f.WebhookIDs, callback.ID)
+}
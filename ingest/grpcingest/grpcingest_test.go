@@ -0,0 +1,258 @@
+package grpcingest_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/ingest/grpcingest"
+	"github.com/slackmgr/types/ingest/grpcingest/ingestv1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type testHandler struct {
+	mu        sync.Mutex
+	alerts    []*types.Alert
+	callbacks []*types.WebhookCallback
+	err       error
+}
+
+func (h *testHandler) SubmitAlert(_ context.Context, alert *types.Alert) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.alerts = append(h.alerts, alert)
+
+	return h.err
+}
+
+func (h *testHandler) SubmitBatch(_ context.Context, batch *types.AlertBatch) ([]types.AlertBatchItemResult, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.alerts = append(h.alerts, batch.Alerts...)
+
+	if h.err != nil {
+		return nil, h.err
+	}
+
+	return batch.ValidateItems(), nil
+}
+
+func (h *testHandler) StreamCallbacks(ctx context.Context, filter grpcingest.CallbackFilter, send func(*types.WebhookCallback) error) error {
+	for _, callback := range h.callbacks {
+		if !filter.Matches(callback) {
+			continue
+		}
+
+		if err := send(callback); err != nil {
+			return err
+		}
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+func (h *testHandler) submitted() []*types.Alert {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]*types.Alert(nil), h.alerts...)
+}
+
+// newClient starts a gRPC server with the handler on an in-memory listener, and returns a client connected to it.
+func newClient(t *testing.T, handler grpcingest.Handler) *grpcingest.Client {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer()
+	ingestv1.RegisterAlertIngestionServer(server, grpcingest.NewServer(handler))
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return grpcingest.NewClient(conn)
+}
+
+func newAlert(header string) *types.Alert {
+	alert := types.NewErrorAlert()
+	alert.SlackChannelID = "C0123456789"
+	alert.Header = header
+	alert.Metadata = map[string]any{"service": "billing", "shard": 3.0}
+	alert.Escalation = []*types.Escalation{{DelaySeconds: types.MinEscalationDelaySeconds, Severity: types.AlertPanic, SlackMentions: []string{"<!here>"}}}
+
+	return alert
+}
+
+func TestAlertConversion(t *testing.T) {
+	t.Parallel()
+
+	alert := newAlert("foo")
+	alert.Timestamp = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	alert.AutoResolveSeconds = 3600
+	alert.IssueFollowUpEnabled = true
+	alert.Priority = types.AlertPriorityP2
+
+	pb, err := grpcingest.AlertToProto(alert)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", pb.GetHeader())
+	assert.Equal(t, "C0123456789", pb.GetSlackChannelId())
+	assert.Equal(t, int64(3600), pb.GetAutoResolveSeconds())
+	assert.Equal(t, "p2", pb.GetPriority())
+	assert.Equal(t, map[string]string{"service": "billing"}, pb.GetMetadata())
+	assert.JSONEq(t, `{"metadata":{"shard":3},"escalation":[{"severity":"panic","delaySeconds":30,"slackMentions":["<!here>"],"moveToChannel":""}]}`, string(pb.GetExtraJson()))
+
+	decoded, err := grpcingest.AlertFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, alert, decoded)
+
+	// Non-empty typed fields take precedence over the extra JSON.
+	decoded, err = grpcingest.AlertFromProto(&ingestv1.Alert{Header: "typed", ExtraJson: []byte(`{"header":"extra","text":"extra"}`)})
+	require.NoError(t, err)
+	assert.Equal(t, "typed", decoded.Header)
+	assert.Equal(t, "extra", decoded.Text)
+
+	_, err = grpcingest.AlertFromProto(&ingestv1.Alert{ExtraJson: []byte(`[]`)})
+	require.ErrorContains(t, err, "invalid alert extra JSON")
+
+	_, err = grpcingest.AlertToProto(nil)
+	require.EqualError(t, err, "alert cannot be nil")
+}
+
+func TestCallbackConversion(t *testing.T) {
+	t.Parallel()
+
+	callback := &types.WebhookCallback{
+		ID:            "restart",
+		UserID:        "U12345678",
+		ChannelID:     "C0123456789",
+		MessageID:     "1234.5678",
+		Timestamp:     time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		Input:         map[string]string{"reason": "stuck"},
+		CheckboxInput: map[string][]string{"targets": {"a", "b"}},
+		Payload:       map[string]any{"service": "billing"},
+		DeliveryID:    "d1",
+	}
+
+	pb, err := grpcingest.CallbackToProto(callback)
+	require.NoError(t, err)
+	assert.Equal(t, "d1", pb.GetDeliveryId())
+	assert.JSONEq(t, `{"checkboxInput":{"targets":["a","b"]},"payload":{"service":"billing"}}`, string(pb.GetExtraJson()))
+
+	decoded, err := grpcingest.CallbackFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, callback, decoded)
+}
+
+func TestSubmitAlert(t *testing.T) {
+	t.Parallel()
+
+	handler := &testHandler{}
+	c := newClient(t, handler)
+
+	alert := newAlert("foo")
+	require.NoError(t, c.SubmitAlert(context.Background(), alert))
+
+	submitted := handler.submitted()
+	require.Len(t, submitted, 1)
+	assert.Equal(t, alert, submitted[0])
+
+	err := c.SubmitAlert(context.Background(), &types.Alert{})
+	require.ErrorContains(t, err, "alert is not valid")
+	assert.Len(t, handler.submitted(), 1)
+
+	handler.mu.Lock()
+	handler.err = status.Error(codes.ResourceExhausted, "rate limited")
+	handler.mu.Unlock()
+
+	err = c.SubmitAlert(context.Background(), newAlert("bar"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestSubmitBatch(t *testing.T) {
+	t.Parallel()
+
+	handler := &testHandler{}
+	c := newClient(t, handler)
+
+	batch := types.NewAlertBatch(newAlert("foo"), newAlert("bar"))
+
+	results, err := c.SubmitBatch(context.Background(), batch)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, batch.Alerts[1].UniqueID(), results[1].UniqueID)
+	assert.Equal(t, 1, results[1].Index)
+	assert.Len(t, handler.submitted(), 2)
+
+	_, err = c.SubmitBatch(context.Background(), types.NewAlertBatch(newAlert("foo"), &types.Alert{}))
+	require.ErrorContains(t, err, "alerts[1] is not valid")
+}
+
+func TestServerRejectsInvalidMessages(t *testing.T) {
+	t.Parallel()
+
+	server := grpcingest.NewServer(&testHandler{})
+
+	_, err := server.SubmitAlert(context.Background(), &ingestv1.SubmitAlertRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.SubmitBatch(context.Background(), &ingestv1.SubmitBatchRequest{Alerts: []*ingestv1.Alert{{}, {ExtraJson: []byte(`"foo"`)}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "alerts[1]")
+}
+
+func TestStreamCallbacks(t *testing.T) {
+	t.Parallel()
+
+	handler := &testHandler{callbacks: []*types.WebhookCallback{
+		{ID: "restart", ChannelID: "C0123456789", DeliveryID: "d1"},
+		{ID: "restart", ChannelID: "C9876543210", DeliveryID: "d2"},
+		{ID: "ack", ChannelID: "C0123456789", DeliveryID: "d3"},
+		{ID: "restart", ChannelID: "C0123456789", DeliveryID: "d4"},
+	}}
+	c := newClient(t, handler)
+
+	var received []string
+
+	errStop := errors.New("stop")
+
+	err := c.StreamCallbacks(context.Background(), grpcingest.CallbackFilter{ChannelIDs: []string{"C0123456789"}, WebhookIDs: []string{"restart"}},
+		func(callback *types.WebhookCallback) error {
+			received = append(received, callback.DeliveryID)
+
+			if len(received) == 2 {
+				return errStop
+			}
+
+			return nil
+		})
+	require.ErrorIs(t, err, errStop)
+	assert.Equal(t, []string{"d1", "d4"}, received)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = c.StreamCallbacks(ctx, grpcingest.CallbackFilter{WebhookIDs: []string{"ack"}}, func(*types.WebhookCallback) error { return nil })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,81 @@
+package grpcingest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/ingest/grpcingest/ingestv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the generated ingestv1.AlertIngestionServer interface, translating the protobuf messages
+// to the Go types passed to a Handler. Messages that cannot be converted are rejected with codes.InvalidArgument.
+type Server struct {
+	ingestv1.UnimplementedAlertIngestionServer
+
+	handler Handler
+}
+
+var _ ingestv1.AlertIngestionServer = (*Server)(nil)
+
+// NewServer creates a new Server passing the calls to handler, which cannot be nil.
+func NewServer(handler Handler) *Server {
+	return &Server{handler: handler}
+}
+
+// SubmitAlert converts the alert and passes it to the handler. The response carries the unique ID of the alert,
+// as processed by the handler.
+func (s *Server) SubmitAlert(ctx context.Context, req *ingestv1.SubmitAlertRequest) (*ingestv1.SubmitAlertResponse, error) {
+	alert, err := AlertFromProto(req.GetAlert())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.handler.SubmitAlert(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	return &ingestv1.SubmitAlertResponse{UniqueId: alert.UniqueID()}, nil
+}
+
+// SubmitBatch converts the alerts and passes them to the handler as one batch.
+func (s *Server) SubmitBatch(ctx context.Context, req *ingestv1.SubmitBatchRequest) (*ingestv1.SubmitBatchResponse, error) {
+	alerts := make([]*types.Alert, 0, len(req.GetAlerts()))
+
+	for i, pb := range req.GetAlerts() {
+		alert, err := AlertFromProto(pb)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("alerts[%d]: %v", i, err))
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	results, err := s.handler.SubmitBatch(ctx, types.NewAlertBatch(alerts...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ingestv1.SubmitBatchResponse{Results: batchResultsToProto(results)}, nil
+}
+
+// StreamCallbacks passes the filter to the handler, and sends the callbacks to the stream.
+// The handler context is canceled when the client cancels the call.
+func (s *Server) StreamCallbacks(req *ingestv1.StreamCallbacksRequest, stream grpc.ServerStreamingServer[ingestv1.WebhookCallback]) error {
+	filter := CallbackFilter{
+		ChannelIDs: req.GetChannelIds(),
+		WebhookIDs: req.GetWebhookIds(),
+	}
+
+	return s.handler.StreamCallbacks(stream.Context(), filter, func(callback *types.WebhookCallback) error {
+		pb, err := CallbackToProto(callback)
+		if err != nil {
+			return err
+		}
+
+		return stream.Send(pb)
+	})
+}
@@ -0,0 +1,187 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxIssueTimeBucketCount is the maximum number of buckets returned by Issues.BucketByTime.
+const MaxIssueTimeBucketCount = 10000
+
+// IssueField is an issue field that issues can be aggregated by.
+type IssueField string
+
+const (
+	// IssueFieldChannelID aggregates issues by Slack channel ID.
+	IssueFieldChannelID IssueField = "channel_id"
+
+	// IssueFieldCorrelationID aggregates issues by correlation ID.
+	IssueFieldCorrelationID IssueField = "correlation_id"
+
+	// IssueFieldStatus aggregates issues by status, which is either 'open' or 'archived'.
+	IssueFieldStatus IssueField = "status"
+)
+
+// IssueFieldIsValid returns true if the provided IssueField is valid.
+func IssueFieldIsValid(s IssueField) bool {
+	switch s {
+	case IssueFieldChannelID, IssueFieldCorrelationID, IssueFieldStatus:
+		return true
+	}
+	return false
+}
+
+// ValidIssueFields returns a slice of valid IssueField values.
+func ValidIssueFields() []string {
+	return []string{
+		string(IssueFieldChannelID),
+		string(IssueFieldCorrelationID),
+		string(IssueFieldStatus),
+	}
+}
+
+// Issues is a list of issues, with aggregation helpers for reporting, such as channel noise statistics.
+type Issues []Issue
+
+// IssueCount is the number of issues with a specific field value.
+type IssueCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// IssueTimeBucket is the number of issues in the time interval starting at Start.
+type IssueTimeBucket struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+// CountBy counts the issues by the specified field.
+// The result is sorted by count (descending), then by value (ascending). Nil issues are ignored.
+func (issues Issues) CountBy(field IssueField) ([]IssueCount, error) {
+	if !IssueFieldIsValid(field) {
+		return nil, fmt.Errorf("issue field '%s' is not valid, expected one of [%s]", field, strings.Join(ValidIssueFields(), ", "))
+	}
+
+	counts := make(map[string]int)
+
+	for _, issue := range issues {
+		if issue == nil {
+			continue
+		}
+
+		counts[issueFieldValue(issue, field)]++
+	}
+
+	result := make([]IssueCount, 0, len(counts))
+
+	for value, count := range counts {
+		result = append(result, IssueCount{Value: value, Count: count})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Value < result[j].Value
+	})
+
+	return result, nil
+}
+
+// TopN returns the n field values with the highest issue count, sorted as CountBy.
+// Fewer than n items are returned if there are fewer distinct values.
+func (issues Issues) TopN(field IssueField, n int) ([]IssueCount, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("n %d is not valid, expected value >=0", n)
+	}
+
+	result, err := issues.CountBy(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) > n {
+		result = result[:n]
+	}
+
+	return result, nil
+}
+
+// BucketByTime counts the issues in consecutive time intervals, for example to render a heat map.
+// The Issue interface has no timestamp, so the issue time is provided by the timeFn function (typically
+// a type assertion on the concrete issue type). Issues where timeFn returns the zero time are ignored.
+//
+// Bucket start times are truncated to a multiple of the interval, in UTC. The result is sorted by start time,
+// and includes empty buckets between the first and the last issue. An error is returned if the interval is
+// not positive, or if the result would have more than MaxIssueTimeBucketCount buckets.
+func (issues Issues) BucketByTime(interval time.Duration, timeFn func(Issue) time.Time) ([]IssueTimeBucket, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval %s is not valid, expected a positive duration", interval)
+	}
+
+	if timeFn == nil {
+		return nil, errors.New("time function is required")
+	}
+
+	counts := make(map[time.Time]int)
+
+	var first, last time.Time
+
+	for _, issue := range issues {
+		if issue == nil {
+			continue
+		}
+
+		t := timeFn(issue)
+		if t.IsZero() {
+			continue
+		}
+
+		start := t.UTC().Truncate(interval)
+		counts[start]++
+
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+
+		if start.After(last) {
+			last = start
+		}
+	}
+
+	if len(counts) == 0 {
+		return []IssueTimeBucket{}, nil
+	}
+
+	bucketCount := int(last.Sub(first)/interval) + 1
+	if bucketCount > MaxIssueTimeBucketCount {
+		return nil, fmt.Errorf("too many time buckets, expected <=%d", MaxIssueTimeBucketCount)
+	}
+
+	result := make([]IssueTimeBucket, 0, bucketCount)
+
+	for start := first; !start.After(last); start = start.Add(interval) {
+		result = append(result, IssueTimeBucket{Start: start, Count: counts[start]})
+	}
+
+	return result, nil
+}
+
+func issueFieldValue(issue Issue, field IssueField) string {
+	switch field {
+	case IssueFieldChannelID:
+		return issue.ChannelID()
+	case IssueFieldCorrelationID:
+		return issue.GetCorrelationID()
+	case IssueFieldStatus:
+		if issue.IsOpen() {
+			return "open"
+		}
+		return "archived"
+	default:
+		return ""
+	}
+}
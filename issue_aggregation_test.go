@@ -0,0 +1,101 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type aggregationIssue struct {
+	channelID     string
+	correlationID string
+	open          bool
+	created       time.Time
+}
+
+func (i *aggregationIssue) MarshalJSON() ([]byte, error) { return json.Marshal(i.correlationID) }
+func (i *aggregationIssue) ChannelID() string            { return i.channelID }
+func (i *aggregationIssue) UniqueID() string             { return i.channelID + i.correlationID }
+func (i *aggregationIssue) GetCorrelationID() string     { return i.correlationID }
+func (i *aggregationIssue) IsOpen() bool                 { return i.open }
+func (i *aggregationIssue) CurrentPostID() string        { return "" }
+
+func TestIssuesCountBy(t *testing.T) {
+	t.Parallel()
+
+	issues := types.Issues{
+		&aggregationIssue{channelID: "C1", correlationID: "a", open: true},
+		&aggregationIssue{channelID: "C2", correlationID: "a", open: true},
+		&aggregationIssue{channelID: "C2", correlationID: "b"},
+		&aggregationIssue{channelID: "C3", correlationID: "c"},
+		nil,
+	}
+
+	counts, err := issues.CountBy(types.IssueFieldChannelID)
+	require.NoError(t, err)
+	assert.Equal(t, []types.IssueCount{{Value: "C2", Count: 2}, {Value: "C1", Count: 1}, {Value: "C3", Count: 1}}, counts)
+
+	counts, err = issues.CountBy(types.IssueFieldStatus)
+	require.NoError(t, err)
+	assert.Equal(t, []types.IssueCount{{Value: "archived", Count: 2}, {Value: "open", Count: 2}}, counts)
+
+	top, err := issues.TopN(types.IssueFieldCorrelationID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []types.IssueCount{{Value: "a", Count: 2}}, top)
+
+	top, err = issues.TopN(types.IssueFieldCorrelationID, 10)
+	require.NoError(t, err)
+	assert.Len(t, top, 3)
+
+	_, err = issues.TopN(types.IssueFieldCorrelationID, -1)
+	require.Error(t, err)
+
+	_, err = issues.CountBy("severity")
+	require.ErrorContains(t, err, "issue field 'severity' is not valid")
+
+	body, err := json.Marshal(counts[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"value":"archived","count":2}`, string(body))
+}
+
+func TestIssuesBucketByTime(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	issues := types.Issues{
+		&aggregationIssue{created: base.Add(5 * time.Minute)},
+		&aggregationIssue{created: base.Add(20 * time.Minute)},
+		&aggregationIssue{created: base.Add(3*time.Hour + time.Minute)},
+		&aggregationIssue{},
+	}
+
+	timeFn := func(issue types.Issue) time.Time {
+		return issue.(*aggregationIssue).created //nolint:forcetypeassert
+	}
+
+	buckets, err := issues.BucketByTime(time.Hour, timeFn)
+	require.NoError(t, err)
+	assert.Equal(t, []types.IssueTimeBucket{
+		{Start: base, Count: 2},
+		{Start: base.Add(time.Hour), Count: 0},
+		{Start: base.Add(2 * time.Hour), Count: 0},
+		{Start: base.Add(3 * time.Hour), Count: 1},
+	}, buckets)
+
+	buckets, err = types.Issues{}.BucketByTime(time.Hour, timeFn)
+	require.NoError(t, err)
+	assert.Empty(t, buckets)
+
+	_, err = issues.BucketByTime(0, timeFn)
+	require.Error(t, err)
+
+	_, err = issues.BucketByTime(time.Hour, nil)
+	require.Error(t, err)
+
+	_, err = issues.BucketByTime(time.Millisecond, timeFn)
+	require.ErrorContains(t, err, "too many time buckets")
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IssueEventType is the type of an issue lifecycle event.
+type IssueEventType string
+
+const (
+	// IssueEventCreated is recorded when the issue is created by its first alert.
+	IssueEventCreated IssueEventType = "created"
+
+	// IssueEventUpdated is recorded when the issue is updated by a new alert.
+	IssueEventUpdated IssueEventType = "updated"
+
+	// IssueEventEscalated is recorded when an escalation point of the issue is triggered.
+	IssueEventEscalated IssueEventType = "escalated"
+
+	// IssueEventResolved is recorded when the issue is resolved, by an alert, a user or a command.
+	IssueEventResolved IssueEventType = "resolved"
+
+	// IssueEventReopened is recorded when a resolved issue is reopened by a new alert.
+	IssueEventReopened IssueEventType = "reopened"
+
+	// IssueEventMoved is recorded when the issue is moved to another channel.
+	IssueEventMoved IssueEventType = "moved"
+
+	// IssueEventArchived is recorded when the issue is archived.
+	IssueEventArchived IssueEventType = "archived"
+
+	// IssueEventNote is a free-form note added to the issue, such as a comment by a responder.
+	IssueEventNote IssueEventType = "note"
+)
+
+// IssueEventTypeIsValid returns true if the provided IssueEventType is valid.
+func IssueEventTypeIsValid(s IssueEventType) bool {
+	switch s {
+	case IssueEventCreated, IssueEventUpdated, IssueEventEscalated, IssueEventResolved, IssueEventReopened,
+		IssueEventMoved, IssueEventArchived, IssueEventNote:
+		return true
+	}
+	return false
+}
+
+// ValidIssueEventTypes returns a slice of valid IssueEventType values.
+func ValidIssueEventTypes() []string {
+	return []string{
+		string(IssueEventCreated),
+		string(IssueEventUpdated),
+		string(IssueEventEscalated),
+		string(IssueEventResolved),
+		string(IssueEventReopened),
+		string(IssueEventMoved),
+		string(IssueEventArchived),
+		string(IssueEventNote),
+	}
+}
+
+// IssueEvent is an issue lifecycle event, as emitted by the Slack Manager for reporting services
+// (see StatsAggregator).
+type IssueEvent struct {
+	// Time is the time of the event. This field is required.
+	Time time.Time `json:"time"`
+
+	// Type is the type of the event. Valid values are defined by IssueEventType constants.
+	Type IssueEventType `json:"type"`
+
+	// IssueID is the unique ID of the issue, as returned by Issue.UniqueID. This field is required.
+	IssueID string `json:"issueId"`
+
+	// SlackChannelID is the Slack channel ID of the issue. For moved events, it is the target channel.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// CorrelationID is the correlation ID of the issue.
+	CorrelationID string `json:"correlationId"`
+
+	// Severity is the severity of the alert causing the event, for events caused by alerts: created, updated and
+	// reopened events, and resolved events caused by resolution alerts. It is empty for other events.
+	Severity AlertSeverity `json:"severity"`
+
+	// Actor is the name of the user or system causing the event, if any.
+	Actor string `json:"actor"`
+
+	// Message is an optional description of the event, such as a resolve note.
+	Message string `json:"message"`
+}
+
+// Validate returns an error if the event is invalid.
+func (e *IssueEvent) Validate() error {
+	if e == nil {
+		return errors.New("issue event is nil")
+	}
+
+	if e.Time.IsZero() {
+		return errors.New("time is required")
+	}
+
+	if !IssueEventTypeIsValid(e.Type) {
+		return fmt.Errorf("type '%s' is not valid, expected one of [%s]", e.Type, strings.Join(ValidIssueEventTypes(), ", "))
+	}
+
+	if e.IssueID == "" {
+		return errors.New("issueId is required")
+	}
+
+	if e.Severity != "" && !SeverityIsValid(e.Severity) {
+		return fmt.Errorf("severity '%s' is not valid, expected one of [%s]", e.Severity, strings.Join(ValidSeverities(), ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueEventTypeIsValid(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range types.ValidIssueEventTypes() {
+		assert.True(t, types.IssueEventTypeIsValid(types.IssueEventType(s)), s)
+	}
+
+	assert.False(t, types.IssueEventTypeIsValid("deleted"))
+	assert.False(t, types.IssueEventTypeIsValid(""))
+}
+
+func TestIssueEventValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		event *types.IssueEvent
+		err   string
+	}{
+		{"valid event", &types.IssueEvent{Time: now, Type: types.IssueEventCreated, IssueID: "1", Severity: types.AlertError}, ""},
+		{"nil event", nil, "issue event is nil"},
+		{"missing time", &types.IssueEvent{Type: types.IssueEventCreated, IssueID: "1"}, "time is required"},
+		{"invalid type", &types.IssueEvent{Time: now, Type: "Created", IssueID: "1"}, "type 'Created' is not valid"},
+		{"missing issue ID", &types.IssueEvent{Time: now, Type: types.IssueEventNote}, "issueId is required"},
+		{"invalid severity", &types.IssueEvent{Time: now, Type: types.IssueEventUpdated, IssueID: "1", Severity: "critical"}, "severity 'critical' is not valid"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.event.Validate()
+
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
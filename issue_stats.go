@@ -0,0 +1,245 @@
+package types
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultNoisiestCorrelationIDCount is the number of correlation IDs in ChannelStats.NoisiestCorrelationIDs,
+// when the StatsAggregator is created with a count of 0.
+const DefaultNoisiestCorrelationIDCount = 10
+
+// AlertStats holds alert counts, by severity.
+type AlertStats struct {
+	// Total is the number of alerts.
+	Total int `json:"total"`
+
+	// BySeverity is the number of alerts per severity. Severities without alerts are omitted.
+	BySeverity map[AlertSeverity]int `json:"bySeverity"`
+}
+
+// CorrelationIDCount is the number of alerts with a correlation ID, in a Slack channel.
+type CorrelationIDCount struct {
+	SlackChannelID string `json:"slackChannelId"`
+	CorrelationID  string `json:"correlationId"`
+	Count          int    `json:"count"`
+}
+
+// ChannelStats holds the KPIs of the issues in a Slack channel, or in all channels (see StatsAggregator.Total).
+type ChannelStats struct {
+	// SlackChannelID is the Slack channel ID. It is empty for the totals of all channels.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// Alerts are the alert counts, from the severities of the events caused by alerts (see IssueEvent.Severity).
+	Alerts AlertStats `json:"alerts"`
+
+	// IssuesCreated is the number of created issues.
+	IssuesCreated int `json:"issuesCreated"`
+
+	// IssuesResolved is the number of resolutions. An issue that is reopened and resolved again is counted twice.
+	IssuesResolved int `json:"issuesResolved"`
+
+	// IssuesEscalated is the number of created issues that were escalated at least once.
+	IssuesEscalated int `json:"issuesEscalated"`
+
+	// MeanTimeToResolveSeconds is the mean time from creation (or reopening) to resolution, in seconds,
+	// for resolutions of issues created (or reopened) in the aggregated events. Zero if there are no such resolutions.
+	MeanTimeToResolveSeconds float64 `json:"meanTimeToResolveSeconds"`
+
+	// EscalationRate is IssuesEscalated divided by IssuesCreated, between 0 and 1. Zero if no issues were created.
+	EscalationRate float64 `json:"escalationRate"`
+
+	// NoisiestCorrelationIDs are the correlation IDs with the most alerts, sorted by count (descending), then by
+	// channel and correlation ID (ascending).
+	NoisiestCorrelationIDs []CorrelationIDCount `json:"noisiestCorrelationIds"`
+}
+
+// StatsAggregator folds a stream of issue events into ChannelStats, so that reporting services share one definition
+// of these KPIs. Events should be added in time order. It is safe for concurrent use.
+type StatsAggregator struct {
+	mu       sync.Mutex
+	topN     int
+	channels map[string]*channelStatsAccumulator
+	issues   map[string]*issueStatsState
+}
+
+type channelStatsAccumulator struct {
+	severities       map[AlertSeverity]int
+	correlationIDs   map[string]int
+	created          int
+	resolved         int
+	escalated        int
+	resolveCount     int
+	totalResolveTime time.Duration
+}
+
+// issueStatsState is the state of an issue created in the aggregated events. It is removed when the issue is archived.
+type issueStatsState struct {
+	channelID string
+	openedAt  time.Time
+	escalated bool
+}
+
+// NewStatsAggregator returns a new aggregator. noisiest is the number of correlation IDs in
+// ChannelStats.NoisiestCorrelationIDs. If 0, DefaultNoisiestCorrelationIDCount is used.
+func NewStatsAggregator(noisiest int) (*StatsAggregator, error) {
+	if noisiest < 0 {
+		return nil, fmt.Errorf("noisiest %d is not valid, expected value >=0", noisiest)
+	}
+
+	if noisiest == 0 {
+		noisiest = DefaultNoisiestCorrelationIDCount
+	}
+
+	return &StatsAggregator{
+		topN:     noisiest,
+		channels: make(map[string]*channelStatsAccumulator),
+		issues:   make(map[string]*issueStatsState),
+	}, nil
+}
+
+// Add folds an event into the statistics. An error is returned if the event is invalid.
+func (a *StatsAggregator) Add(event *IssueEvent) error {
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid issue event: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ch := a.channel(event.SlackChannelID)
+
+	if event.Severity != "" {
+		ch.severities[event.Severity]++
+		ch.correlationIDs[event.CorrelationID]++
+	}
+
+	issue := a.issues[event.IssueID]
+
+	switch event.Type {
+	case IssueEventCreated:
+		ch.created++
+		a.issues[event.IssueID] = &issueStatsState{channelID: event.SlackChannelID, openedAt: event.Time}
+	case IssueEventReopened:
+		if issue != nil {
+			issue.openedAt = event.Time
+		}
+	case IssueEventResolved:
+		ch.resolved++
+
+		if issue != nil && !issue.openedAt.IsZero() {
+			ch.resolveCount++
+			ch.totalResolveTime += event.Time.Sub(issue.openedAt)
+			issue.openedAt = time.Time{}
+		}
+	case IssueEventEscalated:
+		// Escalations are counted in the channel where the issue was created, to keep the escalation rate <=1.
+		if issue != nil && !issue.escalated {
+			issue.escalated = true
+			a.channel(issue.channelID).escalated++
+		}
+	case IssueEventArchived:
+		delete(a.issues, event.IssueID)
+	case IssueEventUpdated, IssueEventMoved, IssueEventNote:
+	}
+
+	return nil
+}
+
+func (a *StatsAggregator) channel(channelID string) *channelStatsAccumulator {
+	ch, ok := a.channels[channelID]
+	if !ok {
+		ch = &channelStatsAccumulator{
+			severities:     make(map[AlertSeverity]int),
+			correlationIDs: make(map[string]int),
+		}
+		a.channels[channelID] = ch
+	}
+
+	return ch
+}
+
+// Channels returns the statistics per Slack channel, sorted by channel ID.
+func (a *StatsAggregator) Channels() []ChannelStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result := make([]ChannelStats, 0, len(a.channels))
+
+	for channelID, ch := range a.channels {
+		result = append(result, a.stats(channelID, map[string]*channelStatsAccumulator{channelID: ch}))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SlackChannelID < result[j].SlackChannelID
+	})
+
+	return result
+}
+
+// Total returns the statistics of all channels, with an empty SlackChannelID.
+func (a *StatsAggregator) Total() ChannelStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.stats("", a.channels)
+}
+
+// stats combines the accumulators of the channels.
+func (a *StatsAggregator) stats(channelID string, channels map[string]*channelStatsAccumulator) ChannelStats {
+	stats := ChannelStats{
+		SlackChannelID:         channelID,
+		Alerts:                 AlertStats{BySeverity: make(map[AlertSeverity]int)},
+		NoisiestCorrelationIDs: []CorrelationIDCount{},
+	}
+
+	var resolveCount int
+	var totalResolveTime time.Duration
+
+	for id, ch := range channels {
+		for severity, count := range ch.severities {
+			stats.Alerts.BySeverity[severity] += count
+			stats.Alerts.Total += count
+		}
+
+		for correlationID, count := range ch.correlationIDs {
+			stats.NoisiestCorrelationIDs = append(stats.NoisiestCorrelationIDs, CorrelationIDCount{
+				SlackChannelID: id,
+				CorrelationID:  correlationID,
+				Count:          count,
+			})
+		}
+
+		stats.IssuesCreated += ch.created
+		stats.IssuesResolved += ch.resolved
+		stats.IssuesEscalated += ch.escalated
+		resolveCount += ch.resolveCount
+		totalResolveTime += ch.totalResolveTime
+	}
+
+	if resolveCount > 0 {
+		stats.MeanTimeToResolveSeconds = totalResolveTime.Seconds() / float64(resolveCount)
+	}
+
+	if stats.IssuesCreated > 0 {
+		stats.EscalationRate = float64(stats.IssuesEscalated) / float64(stats.IssuesCreated)
+	}
+
+	slices.SortFunc(stats.NoisiestCorrelationIDs, func(x, y CorrelationIDCount) int {
+		return cmp.Or(
+			cmp.Compare(y.Count, x.Count),
+			cmp.Compare(x.SlackChannelID, y.SlackChannelID),
+			cmp.Compare(x.CorrelationID, y.CorrelationID),
+		)
+	})
+
+	if len(stats.NoisiestCorrelationIDs) > a.topN {
+		stats.NoisiestCorrelationIDs = stats.NoisiestCorrelationIDs[:a.topN]
+	}
+
+	return stats
+}
@@ -0,0 +1,119 @@
+package types_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStatsAggregator(t *testing.T) {
+	t.Parallel()
+
+	_, err := types.NewStatsAggregator(-1)
+	require.EqualError(t, err, "noisiest -1 is not valid, expected value >=0")
+
+	a, err := types.NewStatsAggregator(0)
+	require.NoError(t, err)
+
+	total := a.Total()
+	assert.Zero(t, total.IssuesCreated)
+	assert.Empty(t, total.NoisiestCorrelationIDs)
+	assert.Empty(t, a.Channels())
+}
+
+func TestStatsAggregator(t *testing.T) {
+	t.Parallel()
+
+	t0 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	event := func(minutes int, eventType types.IssueEventType, issueID, channelID, correlationID string, severity types.AlertSeverity) *types.IssueEvent {
+		return &types.IssueEvent{
+			Time:           t0.Add(time.Duration(minutes) * time.Minute),
+			Type:           eventType,
+			IssueID:        issueID,
+			SlackChannelID: channelID,
+			CorrelationID:  correlationID,
+			Severity:       severity,
+		}
+	}
+
+	events := []*types.IssueEvent{
+		event(0, types.IssueEventCreated, "1", "C1", "disk-full", types.AlertError),
+		event(5, types.IssueEventUpdated, "1", "C1", "disk-full", types.AlertError),
+		event(10, types.IssueEventEscalated, "1", "C1", "disk-full", ""),
+		event(11, types.IssueEventEscalated, "1", "C1", "disk-full", ""),
+		event(20, types.IssueEventResolved, "1", "C1", "disk-full", types.AlertResolved),
+		event(30, types.IssueEventReopened, "1", "C1", "disk-full", types.AlertWarning),
+		event(40, types.IssueEventResolved, "1", "C1", "disk-full", ""),
+		event(0, types.IssueEventCreated, "2", "C1", "cpu-high", types.AlertWarning),
+		event(60, types.IssueEventArchived, "2", "C1", "cpu-high", ""),
+		event(61, types.IssueEventEscalated, "2", "C1", "cpu-high", ""),
+		event(0, types.IssueEventCreated, "3", "C2", "api-down", types.AlertPanic),
+		event(30, types.IssueEventResolved, "3", "C2", "api-down", ""),
+		event(5, types.IssueEventResolved, "4", "C2", "unknown", ""),
+	}
+
+	a, err := types.NewStatsAggregator(2)
+	require.NoError(t, err)
+
+	// Events of different issues are added concurrently, and events of the same issue in order.
+	byIssue := make(map[string][]*types.IssueEvent)
+	for _, e := range events {
+		byIssue[e.IssueID] = append(byIssue[e.IssueID], e)
+	}
+
+	var wg sync.WaitGroup
+	for _, issueEvents := range byIssue {
+		wg.Go(func() {
+			for _, e := range issueEvents {
+				assert.NoError(t, a.Add(e))
+			}
+		})
+	}
+	wg.Wait()
+
+	channels := a.Channels()
+	require.Len(t, channels, 2)
+
+	c1 := channels[0]
+	assert.Equal(t, "C1", c1.SlackChannelID)
+	assert.Equal(t, types.AlertStats{Total: 5, BySeverity: map[types.AlertSeverity]int{
+		types.AlertError:    2,
+		types.AlertResolved: 1,
+		types.AlertWarning:  2,
+	}}, c1.Alerts)
+	assert.Equal(t, 2, c1.IssuesCreated)
+	assert.Equal(t, 2, c1.IssuesResolved)
+	assert.Equal(t, 1, c1.IssuesEscalated, "escalations are counted once per issue, and not after archiving")
+	assert.InDelta(t, 0.5, c1.EscalationRate, 0.0001)
+	assert.InDelta(t, 15*60, c1.MeanTimeToResolveSeconds, 0.0001, "resolved after 20 and 10 minutes")
+	assert.Equal(t, []types.CorrelationIDCount{
+		{SlackChannelID: "C1", CorrelationID: "disk-full", Count: 4},
+		{SlackChannelID: "C1", CorrelationID: "cpu-high", Count: 1},
+	}, c1.NoisiestCorrelationIDs)
+
+	c2 := channels[1]
+	assert.Equal(t, 1, c2.IssuesCreated)
+	assert.Equal(t, 2, c2.IssuesResolved)
+	assert.InDelta(t, 30*60, c2.MeanTimeToResolveSeconds, 0.0001, "resolutions of unknown issues are not timed")
+	assert.Zero(t, c2.EscalationRate)
+
+	total := a.Total()
+	assert.Empty(t, total.SlackChannelID)
+	assert.Equal(t, 6, total.Alerts.Total)
+	assert.Equal(t, 3, total.IssuesCreated)
+	assert.Equal(t, 4, total.IssuesResolved)
+	assert.InDelta(t, 20*60, total.MeanTimeToResolveSeconds, 0.0001)
+	assert.InDelta(t, 1.0/3, total.EscalationRate, 0.0001)
+	assert.Equal(t, []types.CorrelationIDCount{
+		{SlackChannelID: "C1", CorrelationID: "disk-full", Count: 4},
+		{SlackChannelID: "C1", CorrelationID: "cpu-high", Count: 1},
+	}, total.NoisiestCorrelationIDs)
+
+	err = a.Add(&types.IssueEvent{Time: t0, Type: "deleted", IssueID: "1"})
+	require.EqualError(t, err, "invalid issue event: type 'deleted' is not valid, expected one of [created, updated, escalated, resolved, reopened, moved, archived, note]")
+}
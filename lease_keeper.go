@@ -0,0 +1,147 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultLeaseTimeout is the visibility timeout set by each extension, when LeaseKeeperOptions.Timeout is zero.
+const DefaultLeaseTimeout = time.Minute
+
+// LeaseKeeperOptions holds the options of a LeaseKeeper.
+type LeaseKeeperOptions struct {
+	// Timeout is the visibility timeout set by each extension, counted from the time of the extension.
+	// Defaults to DefaultLeaseTimeout.
+	Timeout time.Duration
+
+	// Interval is the time between extensions. It must be shorter than Timeout, to leave time for the extension
+	// call to complete before the lease expires. Defaults to half the Timeout.
+	Interval time.Duration
+
+	// Logger receives failed extensions. Optional.
+	Logger Logger
+}
+
+// LeaseKeeper periodically extends the visibility timeout (the lease) of a received queue item while it is
+// being processed, so that long-running handlers (such as slow webhook calls) do not cause duplicate processing
+// when the lease silently expires:
+//
+//	keeper, err := types.NewLeaseKeeper(item.Extend, types.LeaseKeeperOptions{Timeout: time.Minute})
+//	keeper.Start(ctx)
+//	err = handle(ctx, item)
+//	keeper.Stop()
+//
+// Extending stops when Stop is called, or when the context passed to Start is canceled. Failed extensions are
+// logged and retried at the next interval. A LeaseKeeper for an item without Extend (nil) does nothing.
+type LeaseKeeper struct {
+	extend func(timeout time.Duration) error
+	opts   LeaseKeeperOptions
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	done      chan struct{}
+	stopped   chan struct{}
+
+	mu         sync.Mutex
+	extensions int
+	err        error
+}
+
+// NewLeaseKeeper creates a new LeaseKeeper for the specified Extend function of a FifoQueueItem or QueueItem,
+// which may be nil. An error is returned if the options are invalid.
+func NewLeaseKeeper(extend func(timeout time.Duration) error, opts LeaseKeeperOptions) (*LeaseKeeper, error) {
+	if opts.Timeout < 0 {
+		return nil, errors.New("timeout cannot be negative")
+	}
+
+	if opts.Interval < 0 {
+		return nil, errors.New("interval cannot be negative")
+	}
+
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultLeaseTimeout
+	}
+
+	if opts.Interval == 0 {
+		opts.Interval = max(opts.Timeout/2, time.Nanosecond)
+	}
+
+	if opts.Interval >= opts.Timeout {
+		return nil, errors.New("interval must be shorter than the timeout")
+	}
+
+	if opts.Logger == nil {
+		opts.Logger = &NoopLogger{}
+	}
+
+	return &LeaseKeeper{
+		extend:  extend,
+		opts:    opts,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}, nil
+}
+
+// Start starts extending the lease every interval, in the background, until Stop is called or the context
+// is canceled. Subsequent calls do nothing.
+func (k *LeaseKeeper) Start(ctx context.Context) {
+	k.startOnce.Do(func() {
+		if k.extend == nil {
+			close(k.stopped)
+			return
+		}
+
+		go k.run(ctx)
+	})
+}
+
+// Stop stops extending the lease, and waits for a running extension to complete. It returns the error of the
+// last extension, if it failed. It is safe to call Stop multiple times, and without calling Start.
+func (k *LeaseKeeper) Stop() error {
+	k.stopOnce.Do(func() { close(k.done) })
+	k.startOnce.Do(func() { close(k.stopped) })
+	<-k.stopped
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.err
+}
+
+// Extensions returns the number of successful extensions.
+func (k *LeaseKeeper) Extensions() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.extensions
+}
+
+func (k *LeaseKeeper) run(ctx context.Context) {
+	defer close(k.stopped)
+
+	ticker := time.NewTicker(k.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-k.done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := k.extend(k.opts.Timeout)
+			if err != nil {
+				k.opts.Logger.Errorf("Failed to extend lease: %s", err)
+			}
+
+			k.mu.Lock()
+			k.err = err
+			if err == nil {
+				k.extensions++
+			}
+			k.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaseKeeper(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lease should be extended until stopped", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		extend := func(timeout time.Duration) error {
+			assert.Equal(t, 20*time.Millisecond, timeout)
+			calls.Add(1)
+			return nil
+		}
+
+		keeper, err := types.NewLeaseKeeper(extend, types.LeaseKeeperOptions{Timeout: 20 * time.Millisecond})
+		require.NoError(t, err)
+
+		keeper.Start(context.Background())
+		require.Eventually(t, func() bool { return keeper.Extensions() >= 3 }, time.Second, time.Millisecond)
+		require.NoError(t, keeper.Stop())
+		require.NoError(t, keeper.Stop())
+
+		n := calls.Load()
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(t, n, calls.Load(), "the lease should not be extended after Stop")
+	})
+
+	t.Run("context cancellation should stop extending", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		keeper, err := types.NewLeaseKeeper(func(time.Duration) error {
+			calls.Add(1)
+			return nil
+		}, types.LeaseKeeperOptions{Timeout: 20 * time.Millisecond, Interval: 5 * time.Millisecond})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		keeper.Start(ctx)
+		cancel()
+		require.NoError(t, keeper.Stop())
+
+		n := calls.Load()
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, n, calls.Load())
+	})
+
+	t.Run("failed extensions should be retried and reported", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		keeper, err := types.NewLeaseKeeper(func(time.Duration) error {
+			calls.Add(1)
+			return errors.New("receipt handle is invalid")
+		}, types.LeaseKeeperOptions{Timeout: 10 * time.Millisecond})
+		require.NoError(t, err)
+
+		keeper.Start(context.Background())
+		require.Eventually(t, func() bool { return calls.Load() >= 2 }, time.Second, time.Millisecond)
+		require.EqualError(t, keeper.Stop(), "receipt handle is invalid")
+		assert.Zero(t, keeper.Extensions())
+	})
+
+	t.Run("nil extend and unstarted keepers should do nothing", func(t *testing.T) {
+		t.Parallel()
+
+		keeper, err := types.NewLeaseKeeper(nil, types.LeaseKeeperOptions{})
+		require.NoError(t, err)
+
+		keeper.Start(context.Background())
+		require.NoError(t, keeper.Stop())
+
+		keeper, err = types.NewLeaseKeeper(func(time.Duration) error { return nil }, types.LeaseKeeperOptions{})
+		require.NoError(t, err)
+		require.NoError(t, keeper.Stop())
+	})
+
+	t.Run("invalid options should return error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.NewLeaseKeeper(nil, types.LeaseKeeperOptions{Timeout: -1})
+		require.EqualError(t, err, "timeout cannot be negative")
+
+		_, err = types.NewLeaseKeeper(nil, types.LeaseKeeperOptions{Interval: -1})
+		require.EqualError(t, err, "interval cannot be negative")
+
+		_, err = types.NewLeaseKeeper(nil, types.LeaseKeeperOptions{Timeout: time.Second, Interval: time.Second})
+		require.EqualError(t, err, "interval must be shorter than the timeout")
+	})
+}
@@ -5,6 +5,8 @@ type Logger interface {
 	Debugf(format string, args ...any)
 	Info(msg string)
 	Infof(format string, args ...any)
+	Warn(msg string)
+	Warnf(format string, args ...any)
 	Error(msg string)
 	Errorf(format string, args ...any)
 	WithField(key string, value any) Logger
@@ -0,0 +1,78 @@
+package types
+
+import "context"
+
+// Log field names used across the Slack Manager services, so that log lines about the same alert can be joined
+// across ingestion, queueing and posting when debugging a lost alert.
+const (
+	// LogFieldCorrelationID is the log field holding the alert correlation ID.
+	LogFieldCorrelationID = "correlationId"
+
+	// LogFieldChannelID is the log field holding the Slack channel ID.
+	LogFieldChannelID = "channelId"
+
+	// LogFieldRouteKey is the log field holding the alert route key.
+	LogFieldRouteKey = "routeKey"
+
+	// LogFieldMessageID is the log field holding the queue message ID.
+	LogFieldMessageID = "messageId"
+
+	// LogFieldQueue is the log field holding the queue name.
+	LogFieldQueue = "queue"
+)
+
+type loggerContextKey struct{}
+
+// ContextWithLogger returns a copy of ctx holding the specified logger, which is returned by LoggerFromContext.
+func ContextWithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// LoggerFromContext returns the logger set with ContextWithLogger, or a NoopLogger if there is none.
+func LoggerFromContext(ctx context.Context) Logger { //nolint:ireturn
+	if logger, ok := ctx.Value(loggerContextKey{}).(Logger); ok && logger != nil {
+		return logger
+	}
+
+	return &NoopLogger{}
+}
+
+// ContextWithLogFields returns a copy of ctx holding the logger of ctx with the specified fields added.
+// Empty string values are skipped, so that callers do not have to check optional values.
+func ContextWithLogFields(ctx context.Context, fields map[string]any) context.Context {
+	nonEmpty := make(map[string]any, len(fields))
+
+	for key, value := range fields {
+		if s, ok := value.(string); ok && s == "" {
+			continue
+		}
+
+		nonEmpty[key] = value
+	}
+
+	if len(nonEmpty) == 0 {
+		return ctx
+	}
+
+	return ContextWithLogger(ctx, LoggerFromContext(ctx).WithFields(nonEmpty))
+}
+
+// LogFields returns the fields identifying the alert in log lines: the correlation ID, Slack channel ID and
+// route key. Empty values are omitted. Use with Logger.WithFields or ContextWithLogFields.
+func (a *Alert) LogFields() map[string]any {
+	fields := make(map[string]any, 3)
+
+	if a.CorrelationID != "" {
+		fields[LogFieldCorrelationID] = a.CorrelationID
+	}
+
+	if a.SlackChannelID != "" {
+		fields[LogFieldChannelID] = a.SlackChannelID
+	}
+
+	if a.RouteKey != "" {
+		fields[LogFieldRouteKey] = a.RouteKey
+	}
+
+	return fields
+}
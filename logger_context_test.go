@@ -0,0 +1,47 @@
+package types_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("context without logger should return a noop logger", func(t *testing.T) {
+		t.Parallel()
+
+		assert.IsType(t, &types.NoopLogger{}, types.LoggerFromContext(context.Background()))
+	})
+
+	t.Run("fields should be added to the logger of the context", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		alert := types.NewErrorAlert()
+		alert.CorrelationID = "disk-full"
+		alert.SlackChannelID = "C12345678"
+
+		ctx := types.ContextWithLogger(context.Background(), types.NewStdLogger(&buf, types.LogLevelInfo))
+		ctx = types.ContextWithLogFields(ctx, alert.LogFields())
+		ctx = types.ContextWithLogFields(ctx, map[string]any{types.LogFieldMessageID: "m1", types.LogFieldQueue: ""})
+
+		types.LoggerFromContext(ctx).Info("posted")
+
+		assert.Contains(t, buf.String(), `"msg":"posted","channelId":"C12345678","correlationId":"disk-full","messageId":"m1"}`)
+	})
+
+	t.Run("alert log fields should omit empty values", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.RouteKey = "team-a"
+
+		assert.Equal(t, map[string]any{types.LogFieldRouteKey: "team-a"}, alert.LogFields())
+	})
+}
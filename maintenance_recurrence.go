@@ -0,0 +1,38 @@
+package types
+
+// MaintenanceRecurrence defines how a maintenance window repeats.
+type MaintenanceRecurrence string
+
+const (
+	// MaintenanceRecurrenceNone indicates a one-off maintenance window. This is the default.
+	MaintenanceRecurrenceNone MaintenanceRecurrence = "none"
+
+	// MaintenanceRecurrenceDaily indicates a maintenance window repeating every day, at the same local time.
+	MaintenanceRecurrenceDaily MaintenanceRecurrence = "daily"
+
+	// MaintenanceRecurrenceWeekly indicates a maintenance window repeating every week, on the same weekday and local time.
+	MaintenanceRecurrenceWeekly MaintenanceRecurrence = "weekly"
+
+	// MaintenanceRecurrenceMonthly indicates a maintenance window repeating every month, on the same day of month and local time.
+	// Months without the day (such as the 31st) are normalized as by time.Date, i.e. to the first days of the next month.
+	MaintenanceRecurrenceMonthly MaintenanceRecurrence = "monthly"
+)
+
+// MaintenanceRecurrenceIsValid returns true if the provided MaintenanceRecurrence is valid.
+func MaintenanceRecurrenceIsValid(s MaintenanceRecurrence) bool {
+	switch s {
+	case MaintenanceRecurrenceNone, MaintenanceRecurrenceDaily, MaintenanceRecurrenceWeekly, MaintenanceRecurrenceMonthly:
+		return true
+	}
+	return false
+}
+
+// ValidMaintenanceRecurrences returns a slice of valid MaintenanceRecurrence values.
+func ValidMaintenanceRecurrences() []string {
+	return []string{
+		string(MaintenanceRecurrenceNone),
+		string(MaintenanceRecurrenceDaily),
+		string(MaintenanceRecurrenceWeekly),
+		string(MaintenanceRecurrenceMonthly),
+	}
+}
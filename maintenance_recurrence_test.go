@@ -0,0 +1,19 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceRecurrenceValidation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.MaintenanceRecurrenceIsValid(types.MaintenanceRecurrenceNone))
+	assert.True(t, types.MaintenanceRecurrenceIsValid(types.MaintenanceRecurrenceDaily))
+	assert.True(t, types.MaintenanceRecurrenceIsValid(types.MaintenanceRecurrenceWeekly))
+	assert.True(t, types.MaintenanceRecurrenceIsValid(types.MaintenanceRecurrenceMonthly))
+	assert.False(t, types.MaintenanceRecurrenceIsValid("yearly"))
+	assert.Len(t, types.ValidMaintenanceRecurrences(), 4)
+}
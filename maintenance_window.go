@@ -0,0 +1,293 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxMaintenanceWindowIDLength is the maximum length of a maintenance window ID.
+	MaxMaintenanceWindowIDLength = 100
+	// MaxMaintenanceWindowDescriptionLength is the maximum length of a maintenance window description.
+	MaxMaintenanceWindowDescriptionLength = 500
+	// MaxMaintenanceWindowMatcherCount is the maximum number of values in each matcher list of a maintenance window.
+	MaxMaintenanceWindowMatcherCount = 50
+	// MaxMaintenanceWindowLabelCount is the maximum number of label matchers of a maintenance window.
+	MaxMaintenanceWindowLabelCount = 20
+)
+
+// MaintenanceWindow is a planned silence for a set of alerts, either one-off or recurring.
+// Producers and the Slack Manager use Matches to consistently decide whether an alert falls inside the window.
+//
+// An alert matches the window if it matches all the non-empty matchers: its Slack channel ID is one of SlackChannelIDs,
+// its route key is one of RouteKeys, its type is one of Types, and its metadata contains all Labels.
+// At least one matcher is required.
+type MaintenanceWindow struct {
+	// ID is an optional identifier of the window. Maximum length: MaxMaintenanceWindowIDLength characters.
+	ID string `json:"id"`
+
+	// Description is an optional human-readable description of the maintenance.
+	// It is automatically truncated at MaxMaintenanceWindowDescriptionLength characters.
+	Description string `json:"description"`
+
+	// Start is the start of the (first occurrence of the) window. Required.
+	// For recurring windows, the location of Start determines the local time of each occurrence.
+	Start time.Time `json:"start"`
+
+	// End is the end of the (first occurrence of the) window, exclusive. Required, and must be after Start.
+	End time.Time `json:"end"`
+
+	// Recurrence defines how the window repeats. Defaults to MaintenanceRecurrenceNone.
+	// For recurring windows, the duration from Start to End must not exceed the recurrence period.
+	Recurrence MaintenanceRecurrence `json:"recurrence"`
+
+	// RecurUntil is an optional time after which no new occurrences of a recurring window start.
+	RecurUntil time.Time `json:"recurUntil"`
+
+	// SlackChannelIDs matches alerts by Slack channel ID or name.
+	SlackChannelIDs []string `json:"slackChannelIds"`
+
+	// RouteKeys matches alerts by route key.
+	RouteKeys []string `json:"routeKeys"`
+
+	// Types matches alerts by type.
+	Types []string `json:"types"`
+
+	// Labels matches alerts by metadata values. All labels must be present in the alert metadata, with equal values.
+	Labels map[string]string `json:"labels"`
+}
+
+// Clean normalizes the window fields, using the same normalization as for alerts.
+func (w *MaintenanceWindow) Clean() {
+	if w == nil {
+		return
+	}
+
+	w.ID = strings.TrimSpace(w.ID)
+	w.Description = strings.TrimSpace(w.Description)
+	w.Recurrence = MaintenanceRecurrence(strings.ToLower(strings.TrimSpace(string(w.Recurrence))))
+
+	if w.Recurrence == "" {
+		w.Recurrence = MaintenanceRecurrenceNone
+	}
+
+	if utf8.RuneCountInString(w.Description) > MaxMaintenanceWindowDescriptionLength {
+		w.Description = strings.TrimSpace(truncateString(w.Description, MaxMaintenanceWindowDescriptionLength-3)) + "..."
+	}
+
+	w.SlackChannelIDs = cleanMaintenanceMatcherValues(w.SlackChannelIDs, NormalizeChannel)
+	w.RouteKeys = cleanMaintenanceMatcherValues(w.RouteKeys, strings.ToLower)
+	w.Types = cleanMaintenanceMatcherValues(w.Types, strings.ToLower)
+}
+
+func cleanMaintenanceMatcherValues(values []string, normalize func(string) string) []string {
+	if values == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if v = normalize(strings.TrimSpace(v)); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// Validate returns an error if one or more of the fields are invalid. Call Clean first.
+func (w *MaintenanceWindow) Validate() error {
+	if w == nil {
+		return errors.New("maintenance window is nil")
+	}
+
+	if len(w.ID) > MaxMaintenanceWindowIDLength {
+		return fmt.Errorf("id is too long, expected length <=%d", MaxMaintenanceWindowIDLength)
+	}
+
+	if utf8.RuneCountInString(w.Description) > MaxMaintenanceWindowDescriptionLength {
+		return fmt.Errorf("description is too long, expected length <=%d", MaxMaintenanceWindowDescriptionLength)
+	}
+
+	if w.Start.IsZero() || w.End.IsZero() {
+		return errors.New("start and end are required")
+	}
+
+	if !w.End.After(w.Start) {
+		return errors.New("end must be after start")
+	}
+
+	if !MaintenanceRecurrenceIsValid(w.Recurrence) {
+		return fmt.Errorf("recurrence '%s' is not valid, expected one of [%s]", w.Recurrence, strings.Join(ValidMaintenanceRecurrences(), ", "))
+	}
+
+	if period := w.Recurrence.period(); period > 0 && w.End.Sub(w.Start) > period {
+		return fmt.Errorf("duration of a %s maintenance window must not exceed %s", w.Recurrence, period)
+	}
+
+	if !w.RecurUntil.IsZero() && w.RecurUntil.Before(w.Start) {
+		return errors.New("recurUntil must not be before start")
+	}
+
+	if len(w.SlackChannelIDs) == 0 && len(w.RouteKeys) == 0 && len(w.Types) == 0 && len(w.Labels) == 0 {
+		return errors.New("at least one of slackChannelIds, routeKeys, types and labels is required")
+	}
+
+	if len(w.SlackChannelIDs) > MaxMaintenanceWindowMatcherCount {
+		return fmt.Errorf("too many slackChannelIds, expected <=%d", MaxMaintenanceWindowMatcherCount)
+	}
+
+	for i, id := range w.SlackChannelIDs {
+		if !SlackChannelIDOrNameRegex.MatchString(id) {
+			return fmt.Errorf("slackChannelIds[%d] '%s' is not valid", i, id)
+		}
+	}
+
+	if len(w.RouteKeys) > MaxMaintenanceWindowMatcherCount {
+		return fmt.Errorf("too many routeKeys, expected <=%d", MaxMaintenanceWindowMatcherCount)
+	}
+
+	for i, key := range w.RouteKeys {
+		if len(key) > MaxRouteKeyLength {
+			return fmt.Errorf("routeKeys[%d] is too long, expected length <=%d", i, MaxRouteKeyLength)
+		}
+	}
+
+	if len(w.Types) > MaxMaintenanceWindowMatcherCount {
+		return fmt.Errorf("too many types, expected <=%d", MaxMaintenanceWindowMatcherCount)
+	}
+
+	if len(w.Labels) > MaxMaintenanceWindowLabelCount {
+		return fmt.Errorf("too many labels, expected <=%d", MaxMaintenanceWindowLabelCount)
+	}
+
+	for key := range w.Labels {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("labels cannot contain an empty key")
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if the window is active at the given time, and the alert matches the window matchers.
+func (w *MaintenanceWindow) Matches(a *Alert, now time.Time) bool {
+	if w == nil || a == nil {
+		return false
+	}
+
+	return w.IsActive(now) && w.matchesAlert(a)
+}
+
+// IsActive returns true if the given time is within an occurrence of the window.
+func (w *MaintenanceWindow) IsActive(now time.Time) bool {
+	start, ok := w.currentOccurrence(now)
+	if !ok {
+		return false
+	}
+
+	return now.Before(start.Add(w.End.Sub(w.Start)))
+}
+
+// currentOccurrence returns the start of the latest occurrence starting at or before now.
+func (w *MaintenanceWindow) currentOccurrence(now time.Time) (time.Time, bool) {
+	if now.Before(w.Start) {
+		return time.Time{}, false
+	}
+
+	period := w.Recurrence.period()
+	if period == 0 {
+		return w.Start, true
+	}
+
+	var n int
+
+	if w.Recurrence == MaintenanceRecurrenceMonthly {
+		local := now.In(w.Start.Location())
+		n = (local.Year()-w.Start.Year())*12 + int(local.Month()) - int(w.Start.Month())
+	} else {
+		n = int(now.Sub(w.Start) / period)
+	}
+
+	// The estimate may be off by one, because of daylight saving time changes and month lengths
+	for n > 0 && w.occurrence(n).After(now) {
+		n--
+	}
+
+	for !w.occurrence(n + 1).After(now) {
+		n++
+	}
+
+	start := w.occurrence(n)
+
+	if !w.RecurUntil.IsZero() && start.After(w.RecurUntil) {
+		return time.Time{}, false
+	}
+
+	return start, true
+}
+
+func (w *MaintenanceWindow) occurrence(n int) time.Time {
+	switch w.Recurrence {
+	case MaintenanceRecurrenceDaily:
+		return w.Start.AddDate(0, 0, n)
+	case MaintenanceRecurrenceWeekly:
+		return w.Start.AddDate(0, 0, 7*n)
+	case MaintenanceRecurrenceMonthly:
+		return w.Start.AddDate(0, n, 0)
+	default:
+		return w.Start
+	}
+}
+
+// period returns the minimum interval between occurrences, or zero for non-recurring windows.
+func (r MaintenanceRecurrence) period() time.Duration {
+	switch r {
+	case MaintenanceRecurrenceDaily:
+		return 24 * time.Hour
+	case MaintenanceRecurrenceWeekly:
+		return 7 * 24 * time.Hour
+	case MaintenanceRecurrenceMonthly:
+		return 28 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+func (w *MaintenanceWindow) matchesAlert(a *Alert) bool {
+	if len(w.SlackChannelIDs) > 0 && !containsFold(w.SlackChannelIDs, a.SlackChannelID) {
+		return false
+	}
+
+	if len(w.RouteKeys) > 0 && !containsFold(w.RouteKeys, a.RouteKey) {
+		return false
+	}
+
+	if len(w.Types) > 0 && !containsFold(w.Types, a.Type) {
+		return false
+	}
+
+	for key, expected := range w.Labels {
+		if value, ok := alertFieldValue(a, alertFieldMetadataPrefix+key); !ok || value != expected {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsFold(values []string, s string) bool {
+	s = strings.TrimSpace(s)
+
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,128 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindowValidate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+
+	newWindow := func() *types.MaintenanceWindow {
+		w := &types.MaintenanceWindow{
+			Start:           start,
+			End:             start.Add(2 * time.Hour),
+			SlackChannelIDs: []string{" c12345678 ", ""},
+		}
+		w.Clean()
+		return w
+	}
+
+	w := newWindow()
+	assert.Equal(t, []string{"C12345678"}, w.SlackChannelIDs)
+	assert.Equal(t, types.MaintenanceRecurrenceNone, w.Recurrence)
+	require.NoError(t, w.Validate())
+
+	tests := []struct {
+		modify   func(w *types.MaintenanceWindow)
+		expected string
+	}{
+		{func(w *types.MaintenanceWindow) { w.Start = time.Time{} }, "start and end are required"},
+		{func(w *types.MaintenanceWindow) { w.End = w.Start }, "end must be after start"},
+		{func(w *types.MaintenanceWindow) { w.Recurrence = "yearly" }, "recurrence 'yearly' is not valid, expected one of [none, daily, weekly, monthly]"},
+		{func(w *types.MaintenanceWindow) {
+			w.Recurrence = types.MaintenanceRecurrenceDaily
+			w.End = w.Start.Add(25 * time.Hour)
+		}, "duration of a daily maintenance window must not exceed 24h0m0s"},
+		{func(w *types.MaintenanceWindow) { w.RecurUntil = w.Start.Add(-time.Hour) }, "recurUntil must not be before start"},
+		{func(w *types.MaintenanceWindow) { w.SlackChannelIDs = nil }, "at least one of slackChannelIds, routeKeys, types and labels is required"},
+		{func(w *types.MaintenanceWindow) { w.SlackChannelIDs = []string{"C 1"} }, "slackChannelIds[0] 'C 1' is not valid"},
+		{func(w *types.MaintenanceWindow) { w.Labels = map[string]string{" ": "x"} }, "labels cannot contain an empty key"},
+	}
+
+	for _, test := range tests {
+		w := newWindow()
+		test.modify(w)
+		require.EqualError(t, w.Validate(), test.expected)
+	}
+}
+
+func TestMaintenanceWindowMatches(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+
+	w := &types.MaintenanceWindow{
+		Start:     start,
+		End:       start.Add(2 * time.Hour),
+		RouteKeys: []string{"db"},
+		Types:     []string{"metrics"},
+		Labels:    map[string]string{"cluster": "eu-1"},
+	}
+	w.Clean()
+	require.NoError(t, w.Validate())
+
+	a := &types.Alert{RouteKey: "DB", Type: "metrics", Metadata: map[string]any{"cluster": "eu-1"}}
+
+	assert.False(t, w.Matches(a, start.Add(-time.Second)))
+	assert.True(t, w.Matches(a, start))
+	assert.True(t, w.Matches(a, start.Add(119*time.Minute)))
+	assert.False(t, w.Matches(a, start.Add(2*time.Hour)))
+	assert.False(t, w.Matches(a, start.Add(24*time.Hour)))
+
+	assert.False(t, w.Matches(&types.Alert{RouteKey: "db", Type: "metrics"}, start))
+	assert.False(t, w.Matches(&types.Alert{RouteKey: "web", Type: "metrics", Metadata: map[string]any{"cluster": "eu-1"}}, start))
+	assert.False(t, w.Matches(nil, start))
+}
+
+func TestMaintenanceWindowRecurrence(t *testing.T) {
+	t.Parallel()
+
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	require.NoError(t, err)
+
+	// Daily 02:00-03:00 local time, across the daylight saving time change on 2026-03-29
+	daily := &types.MaintenanceWindow{
+		Start:           time.Date(2026, 3, 20, 2, 0, 0, 0, oslo),
+		End:             time.Date(2026, 3, 20, 3, 0, 0, 0, oslo),
+		Recurrence:      types.MaintenanceRecurrenceDaily,
+		RecurUntil:      time.Date(2026, 4, 30, 0, 0, 0, 0, oslo),
+		SlackChannelIDs: []string{"C1"},
+	}
+	require.NoError(t, daily.Validate())
+
+	assert.True(t, daily.IsActive(time.Date(2026, 3, 25, 2, 30, 0, 0, oslo)))
+	assert.False(t, daily.IsActive(time.Date(2026, 3, 25, 3, 0, 0, 0, oslo)))
+	assert.True(t, daily.IsActive(time.Date(2026, 4, 10, 2, 59, 0, 0, oslo)))
+	assert.False(t, daily.IsActive(time.Date(2026, 4, 10, 1, 59, 0, 0, oslo)))
+	assert.False(t, daily.IsActive(time.Date(2026, 5, 1, 2, 30, 0, 0, oslo)), "after recurUntil")
+
+	weekly := &types.MaintenanceWindow{
+		Start:           time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC), // Sunday
+		End:             time.Date(2026, 3, 2, 2, 0, 0, 0, time.UTC),
+		Recurrence:      types.MaintenanceRecurrenceWeekly,
+		SlackChannelIDs: []string{"C1"},
+	}
+	require.NoError(t, weekly.Validate())
+
+	assert.True(t, weekly.IsActive(time.Date(2026, 3, 16, 1, 0, 0, 0, time.UTC)))
+	assert.False(t, weekly.IsActive(time.Date(2026, 3, 17, 1, 0, 0, 0, time.UTC)))
+
+	monthly := &types.MaintenanceWindow{
+		Start:           time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC),
+		End:             time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC),
+		Recurrence:      types.MaintenanceRecurrenceMonthly,
+		SlackChannelIDs: []string{"C1"},
+	}
+	require.NoError(t, monthly.Validate())
+
+	assert.True(t, monthly.IsActive(time.Date(2026, 7, 15, 11, 0, 0, 0, time.UTC)))
+	assert.False(t, monthly.IsActive(time.Date(2026, 7, 16, 11, 0, 0, 0, time.UTC)))
+	assert.False(t, monthly.IsActive(time.Date(2026, 7, 15, 9, 0, 0, 0, time.UTC)))
+}
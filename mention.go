@@ -0,0 +1,46 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MentionUser returns the Slack mention of the user with the specified ID, such as '<@U12345678>'.
+// The ID is trimmed and uppercased, and an error is returned if it is not a valid user ID (see SlackUserIDRegex).
+// The mention always matches SlackMentionRegex.
+func MentionUser(id string) (string, error) {
+	id = strings.ToUpper(strings.TrimSpace(id))
+
+	if !SlackUserIDRegex.MatchString(id) {
+		return "", fmt.Errorf("user ID '%s' is not valid", id)
+	}
+
+	if len(id) > MaxMentionLength {
+		return "", fmt.Errorf("user ID '%s' is too long, expected length <=%d", id, MaxMentionLength)
+	}
+
+	return "<@" + id + ">", nil
+}
+
+// MentionGroup returns the Slack mention of the user group with the specified ID, such as '<!subteam^S12345678>'.
+// The ID is trimmed and uppercased, and an error is returned if it is not a valid user group ID
+// (see SlackUserGroupIDRegex). The mention always matches SlackMentionRegex.
+func MentionGroup(id string) (string, error) {
+	id = strings.ToUpper(strings.TrimSpace(id))
+
+	if !SlackUserGroupIDRegex.MatchString(id) {
+		return "", fmt.Errorf("user group ID '%s' is not valid", id)
+	}
+
+	return "<!subteam^" + id + ">", nil
+}
+
+// MentionHere returns the Slack mention of the active members of the channel, '<!here>'.
+func MentionHere() string {
+	return "<!here>"
+}
+
+// MentionChannel returns the Slack mention of all members of the channel, '<!channel>'.
+func MentionChannel() string {
+	return "<!channel>"
+}
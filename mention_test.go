@@ -0,0 +1,88 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMentionHelpers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid mentions should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		user, err := types.MentionUser(" u12345678 ")
+		require.NoError(t, err)
+		assert.Equal(t, "<@U12345678>", user)
+
+		group, err := types.MentionGroup("S0123ABCD")
+		require.NoError(t, err)
+		assert.Equal(t, "<!subteam^S0123ABCD>", group)
+
+		for _, mention := range []string{user, group, types.MentionHere(), types.MentionChannel()} {
+			assert.True(t, types.SlackMentionRegex.MatchString(mention), mention)
+		}
+
+		assert.Equal(t, "<!here>", types.MentionHere())
+		assert.Equal(t, "<!channel>", types.MentionChannel())
+	})
+
+	t.Run("invalid IDs should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.MentionUser("S12345678")
+		require.EqualError(t, err, "user ID 'S12345678' is not valid")
+
+		_, err = types.MentionUser("")
+		require.EqualError(t, err, "user ID '' is not valid")
+
+		_, err = types.MentionUser("U" + strings.Repeat("1", types.MaxMentionLength))
+		require.EqualError(t, err, "user ID 'U"+strings.Repeat("1", types.MaxMentionLength)+"' is too long, expected length <=20")
+
+		_, err = types.MentionGroup("<!subteam^S12345678>")
+		require.EqualError(t, err, "user group ID '<!SUBTEAM^S12345678>' is not valid")
+	})
+}
+
+func TestSlackMentionRegex(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{
+		"<!here>",
+		"<!channel>",
+		"<@U12345678>",
+		"<!subteam^S12345678>",
+		"<!subteam^S12345678|oncall-db>",
+		"<!subteam^S12345678|@oncall-db>",
+	}
+
+	for _, mention := range valid {
+		assert.True(t, types.SlackMentionRegex.MatchString(mention), mention)
+	}
+
+	invalid := []string{
+		"@here",
+		"<!everyone>",
+		"<!subteam^>",
+		"<!subteam^U12345678>",
+		"<!subteam^s12345678>",
+		"<!subteam^S12345678|>",
+		"<!subteam^S12345678|on call>",
+		"<!subteam^S12345678|" + strings.Repeat("a", types.MaxMentionHandleLength+1) + ">",
+	}
+
+	for _, mention := range invalid {
+		assert.False(t, types.SlackMentionRegex.MatchString(mention), mention)
+	}
+
+	a := types.NewErrorAlert()
+	a.SlackChannelID = "C12345678"
+	a.Header = "foo"
+	a.Escalation = []*types.Escalation{{DelaySeconds: types.MinEscalationDelaySeconds, Severity: types.AlertError, SlackMentions: []string{"<!subteam^S12345678|oncall>"}}}
+	a.Clean()
+	require.NoError(t, a.Validate())
+}
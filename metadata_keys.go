@@ -0,0 +1,118 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ReservedMetadataPrefix is the prefix of the well-known metadata keys. Producers cannot use other keys with this
+// prefix (case-insensitive), so that new well-known keys can be added without colliding with free-form metadata.
+const ReservedMetadataPrefix = "slackmgr."
+
+const (
+	// MetadataKeySource is the well-known metadata key of the system that emitted the alert, e.g. 'prometheus'.
+	MetadataKeySource = ReservedMetadataPrefix + "source"
+
+	// MetadataKeyEnvironment is the well-known metadata key of the environment of the alert, e.g. 'production'.
+	MetadataKeyEnvironment = ReservedMetadataPrefix + "environment"
+
+	// MetadataKeyTeam is the well-known metadata key of the team owning the alert.
+	MetadataKeyTeam = ReservedMetadataPrefix + "team"
+
+	// MetadataKeyRunbookURL is the well-known metadata key of the runbook URL of the alert.
+	MetadataKeyRunbookURL = ReservedMetadataPrefix + "runbookUrl"
+
+	// MetadataKeyTraceID is the well-known metadata key of the trace ID of the operation that caused the alert.
+	MetadataKeyTraceID = ReservedMetadataPrefix + "traceId"
+)
+
+// WellKnownMetadataKeys returns the well-known metadata keys.
+func WellKnownMetadataKeys() []string {
+	return []string{
+		MetadataKeySource,
+		MetadataKeyEnvironment,
+		MetadataKeyTeam,
+		MetadataKeyRunbookURL,
+		MetadataKeyTraceID,
+	}
+}
+
+// WellKnownMetadataKeyIsValid returns true if the key is one of the well-known metadata keys.
+func WellKnownMetadataKeyIsValid(key string) bool {
+	switch key {
+	case MetadataKeySource, MetadataKeyEnvironment, MetadataKeyTeam, MetadataKeyRunbookURL, MetadataKeyTraceID:
+		return true
+	default:
+		return false
+	}
+}
+
+// WellKnownMetadata holds the values of the well-known metadata keys of an alert. Empty fields are not set.
+type WellKnownMetadata struct {
+	Source      string `json:"source"`
+	Environment string `json:"environment"`
+	Team        string `json:"team"`
+	RunbookURL  string `json:"runbookUrl"`
+	TraceID     string `json:"traceId"`
+}
+
+// WellKnownMetadata returns the values of the well-known metadata keys. Missing and non-string values are empty.
+func (a *Alert) WellKnownMetadata() WellKnownMetadata {
+	return WellKnownMetadata{
+		Source:      a.GetMetadataString(MetadataKeySource),
+		Environment: a.GetMetadataString(MetadataKeyEnvironment),
+		Team:        a.GetMetadataString(MetadataKeyTeam),
+		RunbookURL:  a.GetMetadataString(MetadataKeyRunbookURL),
+		TraceID:     a.GetMetadataString(MetadataKeyTraceID),
+	}
+}
+
+// SetWellKnownMetadata sets the well-known metadata keys of the non-empty fields of m. Other keys are left unchanged.
+func (a *Alert) SetWellKnownMetadata(m WellKnownMetadata) {
+	for key, value := range map[string]string{
+		MetadataKeySource:      m.Source,
+		MetadataKeyEnvironment: m.Environment,
+		MetadataKeyTeam:        m.Team,
+		MetadataKeyRunbookURL:  m.RunbookURL,
+		MetadataKeyTraceID:     m.TraceID,
+	} {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+
+		if a.Metadata == nil {
+			a.Metadata = make(map[string]any)
+		}
+
+		a.Metadata[key] = value
+	}
+}
+
+// validateReservedMetadata validates that keys with the reserved prefix are well-known keys with string values,
+// and that the runbook URL is a valid absolute http or https URL.
+func (a *Alert) validateReservedMetadata() error {
+	for _, key := range sortedMetadataKeys(a.Metadata) {
+		if !strings.HasPrefix(strings.ToLower(key), ReservedMetadataPrefix) {
+			continue
+		}
+
+		if !WellKnownMetadataKeyIsValid(key) {
+			return fmt.Errorf("metadata key '%s' uses the reserved prefix '%s', expected one of [%s]", key, ReservedMetadataPrefix, strings.Join(WellKnownMetadataKeys(), ", "))
+		}
+
+		value, ok := a.Metadata[key].(string)
+		if !ok {
+			return fmt.Errorf("metadata['%s'] must be a string", key)
+		}
+
+		if key == MetadataKeyRunbookURL {
+			parsedURL, err := url.ParseRequestURI(value)
+			if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+				return fmt.Errorf("metadata['%s'] is not a valid absolute http or https URL", key)
+			}
+		}
+	}
+
+	return nil
+}
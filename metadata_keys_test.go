@@ -0,0 +1,64 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWellKnownMetadata(t *testing.T) {
+	t.Parallel()
+
+	t.Run("well-known metadata should be set and read", func(t *testing.T) {
+		t.Parallel()
+
+		a := types.NewErrorAlert()
+		a.SlackChannelID = "C12345678"
+		a.Header = "Disk full"
+		a.Metadata = nil
+
+		a.SetWellKnownMetadata(types.WellKnownMetadata{Source: " prometheus ", Environment: "production", RunbookURL: "https://runbooks.example.com/disk"})
+		a.SetWellKnownMetadata(types.WellKnownMetadata{Team: "storage"})
+		a.Metadata["service"] = "db"
+
+		require.NoError(t, a.Validate())
+		assert.Equal(t, types.WellKnownMetadata{
+			Source:      "prometheus",
+			Environment: "production",
+			Team:        "storage",
+			RunbookURL:  "https://runbooks.example.com/disk",
+		}, a.WellKnownMetadata())
+		assert.Equal(t, "storage", a.Metadata[types.MetadataKeyTeam])
+
+		var nilAlert *types.Alert
+		assert.Equal(t, types.WellKnownMetadata{}, nilAlert.WellKnownMetadata())
+	})
+
+	t.Run("reserved keys should be validated", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			metadata map[string]any
+			expected string
+		}{
+			{map[string]any{"slackmgr.custom": "x"}, "metadata key 'slackmgr.custom' uses the reserved prefix 'slackmgr.', expected one of [slackmgr.source, slackmgr.environment, slackmgr.team, slackmgr.runbookUrl, slackmgr.traceId]"},
+			{map[string]any{"SlackMgr.Team": "x"}, "metadata key 'SlackMgr.Team' uses the reserved prefix 'slackmgr.', expected one of [slackmgr.source, slackmgr.environment, slackmgr.team, slackmgr.runbookUrl, slackmgr.traceId]"},
+			{map[string]any{types.MetadataKeyTeam: 42}, "metadata['slackmgr.team'] must be a string"},
+			{map[string]any{types.MetadataKeyRunbookURL: "wiki/disk"}, "metadata['slackmgr.runbookUrl'] is not a valid absolute http or https URL"},
+		}
+
+		for _, tt := range tests {
+			a := types.NewErrorAlert()
+			a.SlackChannelID = "C12345678"
+			a.Header = "Disk full"
+			a.Metadata = tt.metadata
+
+			require.EqualError(t, a.Validate(), tt.expected)
+		}
+
+		assert.True(t, types.WellKnownMetadataKeyIsValid(types.MetadataKeyTraceID))
+		assert.False(t, types.WellKnownMetadataKeyIsValid("traceId"))
+	})
+}
@@ -1,5 +1,26 @@
 package types
 
+// Standard metric names, shared by the Slack Manager services and plugins so that dashboards work across deployments.
+// Use RegisterStandardMetrics to register them with consistent help texts and labels.
+const (
+	// AlertsIngestedMetric is the name of the counter incremented for each alert accepted for processing.
+	// The counter has two labels: 'source', with the ingestion source (such as 'api' or an adapter name),
+	// and 'severity', with the alert severity.
+	AlertsIngestedMetric = "alerts_ingested_total"
+
+	// AlertValidationFailuresMetric is the name of the counter incremented for each alert rejected by validation.
+	// The counter has a single label, 'source', with the ingestion source.
+	AlertValidationFailuresMetric = "alert_validation_failures_total"
+
+	// QueueLatencyMetric is the name of the histogram observing the time between sending and receiving a queue
+	// message, in seconds. The histogram has a single label, 'queue', with the queue name.
+	QueueLatencyMetric = "queue_latency_seconds"
+
+	// SlackPostLatencyMetric is the name of the histogram observing the duration of Slack API calls for posts,
+	// in seconds. The histogram has a single label, 'operation', such as 'post', 'update' or 'delete'.
+	SlackPostLatencyMetric = "slack_post_latency_seconds"
+)
+
 type Metrics interface {
 	// RegisterCounter registers a counter metric with the given name, help text, and optional labels.
 	RegisterCounter(name, help string, labels ...string)
@@ -22,3 +43,12 @@ type Metrics interface {
 	// Observe records an observation for the specified histogram metric, with optional label values.
 	Observe(name string, value float64, labelValues ...string)
 }
+
+// RegisterStandardMetrics registers the standard metrics (AlertsIngestedMetric, AlertValidationFailuresMetric,
+// QueueLatencyMetric and SlackPostLatencyMetric) with their labels, and default histogram buckets.
+func RegisterStandardMetrics(m Metrics) {
+	m.RegisterCounter(AlertsIngestedMetric, "Number of alerts accepted for processing", "source", "severity")
+	m.RegisterCounter(AlertValidationFailuresMetric, "Number of alerts rejected by validation", "source")
+	m.RegisterHistogram(QueueLatencyMetric, "Time between sending and receiving queue messages, in seconds", nil, "queue")
+	m.RegisterHistogram(SlackPostLatencyMetric, "Duration of Slack API calls for posts, in seconds", nil, "operation")
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsHelper wraps a Metrics implementation with helpers for timing operations and tracking in-flight operations.
+// It implements Metrics itself, so it can be passed wherever a Metrics is expected. It is safe for concurrent use.
+type MetricsHelper struct {
+	Metrics
+
+	mu       sync.Mutex
+	inFlight map[string]int
+}
+
+// NewMetricsHelper creates a new MetricsHelper wrapping the specified metrics. If m is nil, NoopMetrics is used.
+func NewMetricsHelper(m Metrics) *MetricsHelper {
+	if m == nil {
+		m = &NoopMetrics{}
+	}
+
+	return &MetricsHelper{
+		Metrics:  m,
+		inFlight: make(map[string]int),
+	}
+}
+
+// StartTimer starts timing an operation, and returns a function observing the elapsed time in seconds in the
+// specified histogram, with the label values. Only the first call of the returned function observes the time:
+//
+//	defer metrics.StartTimer(types.SlackPostLatencyMetric, "post")()
+func (h *MetricsHelper) StartTimer(name string, labelValues ...string) func() {
+	started := time.Now()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			h.Observe(name, time.Since(started).Seconds(), labelValues...)
+		})
+	}
+}
+
+// TrackInFlight increments the specified gauge, with the label values, and returns a function decrementing it
+// when the operation completes. Only the first call of the returned function decrements the gauge:
+//
+//	defer metrics.TrackInFlight("webhooks_in_flight", "slack")()
+func (h *MetricsHelper) TrackInFlight(name string, labelValues ...string) func() {
+	key := name + "\x00" + strings.Join(labelValues, "\x00")
+
+	h.addInFlight(key, name, labelValues, 1)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			h.addInFlight(key, name, labelValues, -1)
+		})
+	}
+}
+
+func (h *MetricsHelper) addInFlight(key, name string, labelValues []string, delta int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.inFlight[key] += delta
+	h.Set(name, float64(h.inFlight[key]), labelValues...)
+
+	if h.inFlight[key] == 0 {
+		delete(h.inFlight, key)
+	}
+}
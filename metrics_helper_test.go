@@ -0,0 +1,138 @@
+package types_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMetrics records registered metrics, counter and gauge values and histogram observations, keyed by name and label values.
+type recordingMetrics struct {
+	types.NoopMetrics
+
+	mu           sync.Mutex
+	registered   map[string][]string
+	counters     map[string]float64
+	gauges       map[string]float64
+	observations map[string][]float64
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{
+		registered:   map[string][]string{},
+		counters:     map[string]float64{},
+		gauges:       map[string]float64{},
+		observations: map[string][]float64{},
+	}
+}
+
+func (m *recordingMetrics) register(name string, labels []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.registered[name] = labels
+}
+
+func (m *recordingMetrics) RegisterCounter(name, _ string, labels ...string) {
+	m.register(name, labels)
+}
+
+func (m *recordingMetrics) RegisterHistogram(name, _ string, _ []float64, labels ...string) {
+	m.register(name, labels)
+}
+
+func (m *recordingMetrics) RegisterGauge(name, _ string, labels ...string) {
+	m.register(name, labels)
+}
+
+func (m *recordingMetrics) Add(name string, value float64, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.counters[strings.Join(append([]string{name}, labelValues...), "|")] += value
+}
+
+func (m *recordingMetrics) Inc(name string, labelValues ...string) {
+	m.Add(name, 1, labelValues...)
+}
+
+func (m *recordingMetrics) Set(name string, value float64, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.gauges[strings.Join(append([]string{name}, labelValues...), "|")] = value
+}
+
+func (m *recordingMetrics) Observe(name string, value float64, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := strings.Join(append([]string{name}, labelValues...), "|")
+	m.observations[key] = append(m.observations[key], value)
+}
+
+func TestMetricsHelper(t *testing.T) {
+	t.Parallel()
+
+	t.Run("timer should observe the elapsed time once", func(t *testing.T) {
+		t.Parallel()
+
+		metrics := newRecordingMetrics()
+		helper := types.NewMetricsHelper(metrics)
+
+		stop := helper.StartTimer(types.SlackPostLatencyMetric, "post")
+		stop()
+		stop()
+
+		require.Len(t, metrics.observations["slack_post_latency_seconds|post"], 1)
+		assert.GreaterOrEqual(t, metrics.observations["slack_post_latency_seconds|post"][0], 0.0)
+	})
+
+	t.Run("in-flight gauge should track concurrent operations per label values", func(t *testing.T) {
+		t.Parallel()
+
+		metrics := newRecordingMetrics()
+		helper := types.NewMetricsHelper(metrics)
+
+		done1 := helper.TrackInFlight("in_flight", "a")
+		done2 := helper.TrackInFlight("in_flight", "a")
+		done3 := helper.TrackInFlight("in_flight", "b")
+		assert.InDelta(t, 2.0, metrics.gauges["in_flight|a"], 0)
+		assert.InDelta(t, 1.0, metrics.gauges["in_flight|b"], 0)
+
+		done1()
+		done1()
+		assert.InDelta(t, 1.0, metrics.gauges["in_flight|a"], 0)
+
+		done2()
+		done3()
+		assert.InDelta(t, 0.0, metrics.gauges["in_flight|a"], 0)
+		assert.InDelta(t, 0.0, metrics.gauges["in_flight|b"], 0)
+	})
+
+	t.Run("nil metrics should be replaced with noop metrics", func(t *testing.T) {
+		t.Parallel()
+
+		helper := types.NewMetricsHelper(nil)
+		helper.StartTimer("foo")()
+		helper.TrackInFlight("bar")()
+	})
+}
+
+func TestRegisterStandardMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := newRecordingMetrics()
+	types.RegisterStandardMetrics(metrics)
+
+	assert.Equal(t, map[string][]string{
+		types.AlertsIngestedMetric:          {"source", "severity"},
+		types.AlertValidationFailuresMetric: {"source"},
+		types.QueueLatencyMetric:            {"queue"},
+		types.SlackPostLatencyMetric:        {"operation"},
+	}, metrics.registered)
+}
@@ -0,0 +1,64 @@
+package types
+
+// MultiMetrics is a Metrics implementation forwarding all calls to multiple Metrics implementations, in order,
+// so that several sinks (such as Prometheus and statsd) can be fed simultaneously.
+type MultiMetrics struct {
+	sinks []Metrics
+}
+
+var _ Metrics = (*MultiMetrics)(nil)
+
+// NewMultiMetrics creates a new MultiMetrics forwarding to the specified sinks. Nil sinks are ignored.
+func NewMultiMetrics(sinks ...Metrics) *MultiMetrics {
+	m := &MultiMetrics{}
+
+	for _, sink := range sinks {
+		if sink != nil {
+			m.sinks = append(m.sinks, sink)
+		}
+	}
+
+	return m
+}
+
+func (m *MultiMetrics) RegisterCounter(name, help string, labels ...string) {
+	for _, sink := range m.sinks {
+		sink.RegisterCounter(name, help, labels...)
+	}
+}
+
+func (m *MultiMetrics) RegisterGauge(name, help string, labels ...string) {
+	for _, sink := range m.sinks {
+		sink.RegisterGauge(name, help, labels...)
+	}
+}
+
+func (m *MultiMetrics) RegisterHistogram(name, help string, buckets []float64, labels ...string) {
+	for _, sink := range m.sinks {
+		sink.RegisterHistogram(name, help, buckets, labels...)
+	}
+}
+
+func (m *MultiMetrics) Add(name string, value float64, labelValues ...string) {
+	for _, sink := range m.sinks {
+		sink.Add(name, value, labelValues...)
+	}
+}
+
+func (m *MultiMetrics) Inc(name string, labelValues ...string) {
+	for _, sink := range m.sinks {
+		sink.Inc(name, labelValues...)
+	}
+}
+
+func (m *MultiMetrics) Set(name string, value float64, labelValues ...string) {
+	for _, sink := range m.sinks {
+		sink.Set(name, value, labelValues...)
+	}
+}
+
+func (m *MultiMetrics) Observe(name string, value float64, labelValues ...string) {
+	for _, sink := range m.sinks {
+		sink.Observe(name, value, labelValues...)
+	}
+}
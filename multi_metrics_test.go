@@ -0,0 +1,35 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiMetrics(t *testing.T) {
+	t.Parallel()
+
+	first := newRecordingMetrics()
+	second := newRecordingMetrics()
+
+	var m types.Metrics = types.NewMultiMetrics(first, nil, second)
+
+	m.RegisterCounter("requests_total", "", "route")
+	m.RegisterGauge("in_flight", "")
+	m.RegisterHistogram("latency_seconds", "", nil, "route")
+	m.Add("requests_total", 2, "alerts")
+	m.Inc("requests_total", "alerts")
+	m.Set("in_flight", 3)
+	m.Observe("latency_seconds", 0.5, "alerts")
+
+	for _, sink := range []*recordingMetrics{first, second} {
+		assert.Equal(t, map[string][]string{"requests_total": {"route"}, "in_flight": nil, "latency_seconds": {"route"}}, sink.registered)
+		assert.Equal(t, map[string]float64{"requests_total|alerts": 3}, sink.counters)
+		assert.Equal(t, map[string]float64{"in_flight": 3}, sink.gauges)
+		assert.Equal(t, map[string][]float64{"latency_seconds|alerts": {0.5}}, sink.observations)
+	}
+
+	// No sinks should not panic.
+	types.NewMultiMetrics().Inc("requests_total")
+}
@@ -2,6 +2,8 @@ package types
 
 type NoopLogger struct{}
 
+var _ Logger = (*NoopLogger)(nil)
+
 func (l *NoopLogger) Debug(msg string) {
 }
 
@@ -14,6 +16,12 @@ func (l *NoopLogger) Info(msg string) {
 func (l *NoopLogger) Infof(format string, args ...any) {
 }
 
+func (l *NoopLogger) Warn(msg string) {
+}
+
+func (l *NoopLogger) Warnf(format string, args ...any) {
+}
+
 func (l *NoopLogger) Error(msg string) {
 }
 
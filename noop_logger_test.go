@@ -17,6 +17,8 @@ func TestNoopLogger(t *testing.T) {
 	m.Debugf("", nil)
 	m.Info("")
 	m.Infof("", nil)
+	m.Warn("")
+	m.Warnf("", nil)
 	m.Error("")
 	m.Errorf("", nil)
 	m.WithField("", nil)
@@ -4,6 +4,8 @@ package types
 // It does not record any metrics. Use if no metrics are needed.
 type NoopMetrics struct{}
 
+var _ Metrics = (*NoopMetrics)(nil)
+
 func (m *NoopMetrics) RegisterCounter(_, _ string, _ ...string) {
 }
 
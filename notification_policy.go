@@ -0,0 +1,213 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxNotificationPolicyMentionCount is the maximum number of Slack mentions in each mention list of a notification policy.
+const MaxNotificationPolicyMentionCount = 10
+
+// quietHoursLayout is the layout of QuietHours.Start and QuietHours.End.
+const quietHoursLayout = "15:04"
+
+// NotificationPolicy controls how people are notified about the issue of an alert: who is mentioned when the issue
+// is created and escalated, whether the resolution is notified, and when mentions are held back.
+//
+// Mentions are Slack mentions (see SlackMentionRegex) or mention aliases (see MentionAliasRegex).
+type NotificationPolicy struct {
+	// MentionsOnCreate are the Slack mentions added to the Slack post when the issue is created.
+	// Maximum of MaxNotificationPolicyMentionCount mentions.
+	MentionsOnCreate []string `json:"mentionsOnCreate"`
+
+	// MentionsOnEscalate are the Slack mentions added to the Slack post when any escalation point is triggered,
+	// in addition to the Slack mentions of the escalation point itself.
+	// Maximum of MaxNotificationPolicyMentionCount mentions.
+	MentionsOnEscalate []string `json:"mentionsOnEscalate"`
+
+	// NotifyOnResolve makes the Slack Manager post a notification (a thread reply) when the issue is resolved,
+	// so that people who were mentioned know that they can stand down.
+	NotifyOnResolve bool `json:"notifyOnResolve"`
+
+	// QuietHours is an optional daily interval in which mentions are held back. The Slack post is still created
+	// and updated, but without mentions.
+	QuietHours *QuietHours `json:"quietHours"`
+}
+
+// QuietHours is a daily interval in which mentions are held back, such as '22:00' to '07:00'.
+type QuietHours struct {
+	// Start is the start of the interval, inclusive, on the format 'HH:MM'. Required.
+	Start string `json:"start"`
+
+	// End is the end of the interval, exclusive, on the format 'HH:MM'. Required, and must be different from Start.
+	// If End is before Start, the interval spans midnight.
+	End string `json:"end"`
+
+	// Location is the optional IANA time zone name, as in 'Europe/Oslo'. Defaults to UTC.
+	Location string `json:"location"`
+
+	// BreakthroughPriority is an optional priority at or above which mentions are not held back, such as 'p1'.
+	BreakthroughPriority AlertPriority `json:"breakthroughPriority"`
+}
+
+// Clean normalizes the policy fields, and removes empty and duplicate mentions.
+func (p *NotificationPolicy) Clean() {
+	if p == nil {
+		return
+	}
+
+	p.MentionsOnCreate = cleanNotificationPolicyMentions(p.MentionsOnCreate)
+	p.MentionsOnEscalate = cleanNotificationPolicyMentions(p.MentionsOnEscalate)
+
+	if q := p.QuietHours; q != nil {
+		q.Start = strings.TrimSpace(q.Start)
+		q.End = strings.TrimSpace(q.End)
+		q.Location = strings.TrimSpace(q.Location)
+		q.BreakthroughPriority = AlertPriority(strings.ToLower(strings.TrimSpace(string(q.BreakthroughPriority))))
+	}
+}
+
+func cleanNotificationPolicyMentions(mentions []string) []string {
+	if mentions == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(mentions))
+
+	for _, mention := range mentions {
+		if mention = strings.TrimSpace(mention); mention != "" && !slices.Contains(result, mention) {
+			result = append(result, mention)
+		}
+	}
+
+	return result
+}
+
+// Validate returns an error if one or more of the fields are invalid. Call Clean first.
+func (p *NotificationPolicy) Validate() error {
+	if p == nil {
+		return errors.New("notification policy is nil")
+	}
+
+	if err := validateNotificationPolicyMentions("mentionsOnCreate", p.MentionsOnCreate); err != nil {
+		return err
+	}
+
+	if err := validateNotificationPolicyMentions("mentionsOnEscalate", p.MentionsOnEscalate); err != nil {
+		return err
+	}
+
+	if p.QuietHours != nil {
+		if err := p.QuietHours.Validate(); err != nil {
+			return fmt.Errorf("quietHours: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func validateNotificationPolicyMentions(name string, mentions []string) error {
+	if len(mentions) > MaxNotificationPolicyMentionCount {
+		return fmt.Errorf("%s item count is too large, expected <=%d", name, MaxNotificationPolicyMentionCount)
+	}
+
+	for i, mention := range mentions {
+		if !SlackMentionRegex.MatchString(mention) && !MentionAliasRegex.MatchString(mention) {
+			return fmt.Errorf("%s[%d] is not valid", name, i)
+		}
+	}
+
+	return nil
+}
+
+// MentionsHeldBack returns true if mentions are held back for an alert with the given priority, at the given time.
+// It is always false if the policy has no quiet hours, or if the quiet hours are invalid.
+func (p *NotificationPolicy) MentionsHeldBack(priority AlertPriority, now time.Time) bool {
+	if p == nil || p.QuietHours == nil {
+		return false
+	}
+
+	q := p.QuietHours
+
+	if q.BreakthroughPriority != "" && priority != "" && ComparePriorities(priority, q.BreakthroughPriority) >= 0 {
+		return false
+	}
+
+	return q.Contains(now)
+}
+
+// Validate returns an error if one or more of the fields are invalid.
+func (q *QuietHours) Validate() error {
+	if q == nil {
+		return errors.New("quiet hours is nil")
+	}
+
+	if _, _, _, err := q.parse(); err != nil {
+		return err
+	}
+
+	if q.BreakthroughPriority != "" && !PriorityIsValid(q.BreakthroughPriority) {
+		return fmt.Errorf("breakthroughPriority '%s' is not valid, expected one of [%s]", q.BreakthroughPriority, strings.Join(ValidPriorities(), ", "))
+	}
+
+	return nil
+}
+
+// Contains returns true if the given time is within the quiet hours. Invalid quiet hours contain no times.
+func (q *QuietHours) Contains(now time.Time) bool {
+	if q == nil {
+		return false
+	}
+
+	start, end, location, err := q.parse()
+	if err != nil {
+		return false
+	}
+
+	local := now.In(location)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+
+	if start < end {
+		return sinceMidnight >= start && sinceMidnight < end
+	}
+
+	return sinceMidnight >= start || sinceMidnight < end
+}
+
+func (q *QuietHours) parse() (time.Duration, time.Duration, *time.Location, error) {
+	start, err := parseQuietHoursTime(q.Start)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("start: %w", err)
+	}
+
+	end, err := parseQuietHoursTime(q.End)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("end: %w", err)
+	}
+
+	if start == end {
+		return 0, 0, nil, errors.New("end must be different from start")
+	}
+
+	location := time.UTC
+
+	if q.Location != "" {
+		if location, err = time.LoadLocation(q.Location); err != nil {
+			return 0, 0, nil, fmt.Errorf("location '%s' is not valid: %w", q.Location, err)
+		}
+	}
+
+	return start, end, location, nil
+}
+
+func parseQuietHoursTime(s string) (time.Duration, error) {
+	parsed, err := time.Parse(quietHoursLayout, strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not valid, expected format HH:MM", s)
+	}
+
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
@@ -0,0 +1,99 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	a := &types.Alert{
+		Header:   "a",
+		RouteKey: "b",
+		NotificationPolicy: &types.NotificationPolicy{
+			MentionsOnCreate:   []string{" <@U1> ", "", "<@U1>", "alias:oncall-db"},
+			MentionsOnEscalate: []string{"<!here>"},
+			NotifyOnResolve:    true,
+			QuietHours:         &types.QuietHours{Start: " 22:00", End: "07:00 ", Location: "Europe/Oslo", BreakthroughPriority: " P1 "},
+		},
+	}
+	a.Clean()
+	require.NoError(t, a.Validate())
+	assert.Equal(t, []string{"<@U1>", "alias:oncall-db"}, a.NotificationPolicy.MentionsOnCreate)
+	assert.Equal(t, "22:00", a.NotificationPolicy.QuietHours.Start)
+	assert.Equal(t, types.AlertPriorityP1, a.NotificationPolicy.QuietHours.BreakthroughPriority)
+
+	tests := []struct {
+		name     string
+		policy   *types.NotificationPolicy
+		expected string
+	}{
+		{"invalid mention", &types.NotificationPolicy{MentionsOnCreate: []string{"U1"}}, "notificationPolicy.mentionsOnCreate[0] is not valid"},
+		{"too many mentions", &types.NotificationPolicy{MentionsOnEscalate: make([]string, types.MaxNotificationPolicyMentionCount+1)}, "notificationPolicy.mentionsOnEscalate item count is too large, expected <=10"},
+		{"invalid start", &types.NotificationPolicy{QuietHours: &types.QuietHours{Start: "22", End: "07:00"}}, "notificationPolicy.quietHours: start: '22' is not valid, expected format HH:MM"},
+		{"empty interval", &types.NotificationPolicy{QuietHours: &types.QuietHours{Start: "07:00", End: "07:00"}}, "notificationPolicy.quietHours: end must be different from start"},
+		{"invalid location", &types.NotificationPolicy{QuietHours: &types.QuietHours{Start: "22:00", End: "07:00", Location: "Mars/Olympus"}}, "notificationPolicy.quietHours: location 'Mars/Olympus' is not valid"},
+		{"invalid priority", &types.NotificationPolicy{QuietHours: &types.QuietHours{Start: "22:00", End: "07:00", BreakthroughPriority: "high"}}, "notificationPolicy.quietHours: breakthroughPriority 'high' is not valid, expected one of [p1, p2, p3, p4, p5]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := &types.Alert{Header: "a", RouteKey: "b", Severity: types.AlertError, NotificationPolicy: tt.policy}
+			require.ErrorContains(t, a.Validate(), tt.expected)
+		})
+	}
+}
+
+func TestNotificationPolicyMentionsHeldBack(t *testing.T) {
+	t.Parallel()
+
+	policy := &types.NotificationPolicy{
+		QuietHours: &types.QuietHours{Start: "22:00", End: "07:00", Location: "Europe/Oslo", BreakthroughPriority: types.AlertPriorityP2},
+	}
+
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	require.NoError(t, err)
+
+	night := time.Date(2026, 10, 14, 23, 30, 0, 0, oslo)
+	morning := time.Date(2026, 10, 15, 6, 59, 0, 0, oslo)
+	day := time.Date(2026, 10, 15, 7, 0, 0, 0, oslo)
+
+	assert.True(t, policy.MentionsHeldBack("", night))
+	assert.True(t, policy.MentionsHeldBack(types.AlertPriorityP3, morning))
+	assert.False(t, policy.MentionsHeldBack(types.AlertPriorityP2, night), "breakthrough priority should not be held back")
+	assert.False(t, policy.MentionsHeldBack(types.AlertPriorityP1, night))
+	assert.False(t, policy.MentionsHeldBack(types.AlertPriorityP5, day))
+
+	assert.True(t, (&types.QuietHours{Start: "12:00", End: "13:00"}).Contains(time.Date(2026, 10, 15, 12, 30, 0, 0, time.UTC)))
+	assert.False(t, (&types.QuietHours{Start: "12:00", End: "13:00"}).Contains(time.Date(2026, 10, 15, 13, 0, 0, 0, time.UTC)))
+	assert.False(t, (&types.QuietHours{Start: "invalid", End: "13:00"}).Contains(time.Date(2026, 10, 15, 12, 30, 0, 0, time.UTC)))
+
+	assert.False(t, (&types.NotificationPolicy{}).MentionsHeldBack("", night))
+	assert.False(t, (*types.NotificationPolicy)(nil).MentionsHeldBack("", night))
+}
+
+func TestExpandMentionAliasesNotificationPolicy(t *testing.T) {
+	t.Parallel()
+
+	aliases := types.MentionAliases{"oncall-db": {"<@U1>", "<@U2>"}}
+
+	a := &types.Alert{
+		NotificationPolicy: &types.NotificationPolicy{
+			MentionsOnCreate:   []string{"<@U1>", "alias:oncall-db"},
+			MentionsOnEscalate: []string{"alias:oncall-db", "<!here>"},
+		},
+	}
+	require.NoError(t, a.ExpandMentionAliases(aliases))
+	assert.Equal(t, []string{"<@U1>", "<@U2>"}, a.NotificationPolicy.MentionsOnCreate)
+	assert.Equal(t, []string{"<@U1>", "<@U2>", "<!here>"}, a.NotificationPolicy.MentionsOnEscalate)
+
+	a.NotificationPolicy.MentionsOnCreate = []string{"alias:missing"}
+	require.EqualError(t, a.ExpandMentionAliases(aliases), "notificationPolicy.mentionsOnCreate[0] alias 'missing' is not defined")
+}
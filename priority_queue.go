@@ -0,0 +1,477 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPriorityStarvationLimit is the number of times a lane with waiting messages can be skipped,
+	// when PriorityQueueOptions.StarvationLimit is zero.
+	DefaultPriorityStarvationLimit = 10
+
+	// DefaultPriorityPrefetch is the max number of messages received ahead from each lane,
+	// when PriorityQueueOptions.Prefetch is zero.
+	DefaultPriorityPrefetch = 10
+)
+
+// PriorityQueueLane is a queue holding the messages of one priority in a PriorityQueue.
+type PriorityQueueLane struct {
+	// Priority is the priority of the messages in the lane. Higher is more urgent (see SeverityPriority).
+	Priority int
+
+	// Queue is the queue holding the messages of the lane.
+	Queue Queue
+}
+
+// PriorityQueueOptions holds the options of a PriorityQueue.
+type PriorityQueueOptions struct {
+	// Priority returns the priority of a message body sent with Send or SendBatch. Defaults to QueueBodyPriority.
+	Priority func(body string) int
+
+	// StarvationLimit is the number of times a lane with waiting messages can be skipped in favor of
+	// higher-priority lanes, before one of its messages is received. Defaults to DefaultPriorityStarvationLimit.
+	StarvationLimit int
+
+	// Prefetch is the max number of messages received ahead from each lane, to compare the waiting messages
+	// of the lanes. Prefetched messages are invisible to other consumers, and their lease is extended with a
+	// LeaseKeeper until they are received or the queue is closed. Defaults to DefaultPriorityPrefetch.
+	Prefetch int
+
+	// LeaseTimeout is the visibility timeout set by each extension of the lease of prefetched messages
+	// (see LeaseKeeperOptions.Timeout). Leases are extended every half LeaseTimeout, so the visibility timeout
+	// of the lanes must be longer than that. Messages without Extend are not extended. Defaults to DefaultLeaseTimeout.
+	LeaseTimeout time.Duration
+}
+
+// PriorityQueue is a Queue that drains higher-priority messages before lower-priority ones, so that panic and error
+// alerts are not stuck behind thousands of info alerts during alert storms. Each priority is held by a separate lane
+// queue, since queue backends do not support priorities. Send routes messages to the lane with the highest priority
+// not above the message priority (or the lowest lane), and received items have the Priority of their lane.
+//
+// To prevent starvation, a lane that has been skipped StarvationLimit times while it had waiting messages
+// gets the next message, regardless of higher-priority messages.
+//
+// Lanes are received from in the background, from the first Receive or ReceiveBatch call until Close is called.
+// A PriorityQueue does not implement BatchAcker, and has no dead-letter queue; use those of the lanes.
+// It is safe for concurrent use.
+type PriorityQueue struct {
+	name     string
+	lanes    []*priorityLane
+	opts     PriorityQueueOptions
+	notify   chan struct{}
+	stop     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+	started  sync.Once
+	closeErr error
+
+	mu sync.Mutex
+}
+
+type priorityLane struct {
+	priority int
+	queue    Queue
+	slots    chan struct{}
+	pending  []*prefetchedItem
+	skipped  int
+}
+
+// prefetchedItem is a prefetched message, with the LeaseKeeper extending its lease while it is waiting.
+type prefetchedItem struct {
+	item   *QueueItem
+	keeper *LeaseKeeper
+}
+
+var _ Queue = (*PriorityQueue)(nil)
+
+// NewPriorityQueue creates a new PriorityQueue with the specified lanes, which must have distinct priorities.
+// An error is returned if the lanes or options are invalid.
+func NewPriorityQueue(name string, lanes []PriorityQueueLane, opts PriorityQueueOptions) (*PriorityQueue, error) {
+	if len(lanes) == 0 {
+		return nil, errors.New("at least one lane is required")
+	}
+
+	if opts.StarvationLimit < 0 {
+		return nil, errors.New("starvation limit cannot be negative")
+	}
+
+	if opts.Prefetch < 0 {
+		return nil, errors.New("prefetch cannot be negative")
+	}
+
+	if opts.LeaseTimeout < 0 {
+		return nil, errors.New("lease timeout cannot be negative")
+	}
+
+	if opts.Priority == nil {
+		opts.Priority = QueueBodyPriority
+	}
+
+	if opts.StarvationLimit == 0 {
+		opts.StarvationLimit = DefaultPriorityStarvationLimit
+	}
+
+	if opts.Prefetch == 0 {
+		opts.Prefetch = DefaultPriorityPrefetch
+	}
+
+	if opts.LeaseTimeout == 0 {
+		opts.LeaseTimeout = DefaultLeaseTimeout
+	}
+
+	q := &PriorityQueue{
+		name:    name,
+		opts:    opts,
+		notify:  make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	priorities := make(map[int]bool, len(lanes))
+
+	for i, lane := range lanes {
+		if lane.Queue == nil {
+			return nil, fmt.Errorf("lanes[%d]: queue cannot be nil", i)
+		}
+
+		if priorities[lane.Priority] {
+			return nil, fmt.Errorf("lanes[%d]: priority %d is not unique", i, lane.Priority)
+		}
+
+		priorities[lane.Priority] = true
+
+		q.lanes = append(q.lanes, &priorityLane{
+			priority: lane.Priority,
+			queue:    lane.Queue,
+			slots:    make(chan struct{}, opts.Prefetch),
+		})
+	}
+
+	sort.Slice(q.lanes, func(i, j int) bool { return q.lanes[i].priority > q.lanes[j].priority })
+
+	return q, nil
+}
+
+// Name returns the name of the queue.
+func (q *PriorityQueue) Name() string {
+	return q.name
+}
+
+// Send sends a message to the lane matching the priority returned by PriorityQueueOptions.Priority.
+func (q *PriorityQueue) Send(ctx context.Context, body string) error {
+	return q.SendWithPriority(ctx, q.opts.Priority(body), body)
+}
+
+// SendWithPriority sends a message to the lane matching the specified priority.
+func (q *PriorityQueue) SendWithPriority(ctx context.Context, priority int, body string) error {
+	lane := q.lane(priority)
+
+	if err := lane.queue.Send(ctx, body); err != nil {
+		return fmt.Errorf("lane with priority %d: %w", lane.priority, err)
+	}
+
+	return nil
+}
+
+// SendBatch sends multiple messages, with one SendBatch call per lane. Messages of the same lane keep their order.
+func (q *PriorityQueue) SendBatch(ctx context.Context, bodies []string) error {
+	batches := make(map[*priorityLane][]string)
+
+	for _, body := range bodies {
+		lane := q.lane(q.opts.Priority(body))
+		batches[lane] = append(batches[lane], body)
+	}
+
+	for _, lane := range q.lanes {
+		if len(batches[lane]) == 0 {
+			continue
+		}
+
+		if err := lane.queue.SendBatch(ctx, batches[lane]); err != nil {
+			return fmt.Errorf("lane with priority %d: %w", lane.priority, err)
+		}
+	}
+
+	return nil
+}
+
+// Receive receives messages continuously, in priority order, to the specified sink channel, until the context
+// is canceled or the queue is closed. The sink channel is closed when the function returns.
+func (q *PriorityQueue) Receive(ctx context.Context, sinkCh chan<- *QueueItem) error {
+	defer close(sinkCh)
+
+	for {
+		items, err := q.ReceiveBatch(ctx, 1)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			select {
+			case <-ctx.Done():
+				item.Nack()
+				return ctx.Err()
+			case sinkCh <- item:
+			}
+		}
+	}
+}
+
+// ReceiveBatch waits until at least one message is available, and returns up to maxItems messages in priority order.
+// An error is returned if the context is canceled, the queue is closed, or receiving from a lane failed.
+func (q *PriorityQueue) ReceiveBatch(ctx context.Context, maxItems int) ([]*QueueItem, error) {
+	if maxItems <= 0 {
+		return nil, fmt.Errorf("maxItems must be positive, got %d", maxItems)
+	}
+
+	q.started.Do(q.start)
+
+	for {
+		if items := q.next(maxItems); len(items) > 0 {
+			return items, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-q.notify:
+		case <-q.stopped:
+			if items := q.next(maxItems); len(items) > 0 {
+				return items, nil
+			}
+
+			q.mu.Lock()
+			defer q.mu.Unlock()
+
+			if q.closeErr != nil {
+				return nil, q.closeErr
+			}
+
+			return nil, errors.New("queue is closed")
+		}
+	}
+}
+
+// DeadLetter returns ErrDeadLetterNotSupported, since each lane has its own dead-letter queue.
+func (q *PriorityQueue) DeadLetter() (Queue, error) {
+	return nil, ErrDeadLetterNotSupported
+}
+
+// Close stops receiving from the lanes, and nacks the prefetched messages.
+func (q *PriorityQueue) Close() {
+	q.shutdown()
+	q.started.Do(func() { close(q.stopped) })
+	<-q.stopped
+
+	q.mu.Lock()
+	var pending []*prefetchedItem
+	for _, lane := range q.lanes {
+		pending = append(pending, lane.pending...)
+		lane.pending = nil
+	}
+	q.mu.Unlock()
+
+	for _, p := range pending {
+		_ = p.keeper.Stop()
+		p.item.Nack()
+	}
+}
+
+// lane returns the lane with the highest priority not above the specified priority, or the lowest lane.
+func (q *PriorityQueue) lane(priority int) *priorityLane {
+	for _, lane := range q.lanes {
+		if lane.priority <= priority {
+			return lane
+		}
+	}
+
+	return q.lanes[len(q.lanes)-1]
+}
+
+func (q *PriorityQueue) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		<-q.stop
+		cancel()
+	}()
+
+	for _, lane := range q.lanes {
+		q.wg.Add(1)
+
+		go q.receiveLane(ctx, lane)
+	}
+
+	go func() {
+		q.wg.Wait()
+		close(q.stopped)
+	}()
+}
+
+// receiveLane receives messages from a lane, until the queue is closed. At most Prefetch messages are held at a time.
+// If receiving fails, the error is recorded and the queue is closed.
+func (q *PriorityQueue) receiveLane(ctx context.Context, lane *priorityLane) {
+	defer q.wg.Done()
+
+	sinkCh := make(chan *QueueItem)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- lane.queue.Receive(ctx, sinkCh)
+	}()
+
+	for item := range sinkCh {
+		select {
+		case <-ctx.Done():
+			item.Nack()
+			continue
+		case lane.slots <- struct{}{}:
+		}
+
+		item.Priority = lane.priority
+
+		// The options were validated by NewPriorityQueue.
+		keeper, _ := NewLeaseKeeper(item.Extend, LeaseKeeperOptions{Timeout: q.opts.LeaseTimeout})
+		keeper.Start(ctx)
+
+		q.mu.Lock()
+		lane.pending = append(lane.pending, &prefetchedItem{item: item, keeper: keeper})
+		q.mu.Unlock()
+
+		q.signal()
+	}
+
+	if err := <-errCh; err != nil && ctx.Err() == nil {
+		q.mu.Lock()
+		if q.closeErr == nil {
+			q.closeErr = fmt.Errorf("lane with priority %d: %w", lane.priority, err)
+		}
+		q.mu.Unlock()
+
+		q.shutdown()
+	}
+}
+
+func (q *PriorityQueue) shutdown() {
+	q.stopOnce.Do(func() { close(q.stop) })
+}
+
+// next removes and returns up to maxItems prefetched messages, in priority order.
+// Extending the leases of the returned messages is left to the caller.
+func (q *PriorityQueue) next(maxItems int) []*QueueItem {
+	picked := q.pickItems(maxItems)
+	items := make([]*QueueItem, 0, len(picked))
+
+	for _, p := range picked {
+		_ = p.keeper.Stop()
+		items = append(items, p.item)
+	}
+
+	return items
+}
+
+// pickItems removes and returns up to maxItems prefetched messages, in priority order.
+func (q *PriorityQueue) pickItems(maxItems int) []*prefetchedItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var picked []*prefetchedItem
+
+	for len(picked) < maxItems {
+		lane := q.pick()
+		if lane == nil {
+			break
+		}
+
+		picked = append(picked, lane.pending[0])
+		lane.pending = lane.pending[1:]
+		<-lane.slots
+	}
+
+	// Wake up another waiting receiver, if messages remain.
+	for _, lane := range q.lanes {
+		if len(lane.pending) > 0 {
+			q.signal()
+			break
+		}
+	}
+
+	return picked
+}
+
+// pick returns the lane to take the next message from: the highest-priority lane with waiting messages, unless
+// a lane has been skipped StarvationLimit times. It returns nil if no messages are waiting. Must be called with mu held.
+func (q *PriorityQueue) pick() *priorityLane {
+	var picked, starved *priorityLane
+
+	for _, lane := range q.lanes {
+		if len(lane.pending) == 0 {
+			lane.skipped = 0
+			continue
+		}
+
+		if picked == nil {
+			picked = lane
+		}
+
+		if starved == nil && lane.skipped >= q.opts.StarvationLimit {
+			starved = lane
+		}
+	}
+
+	if starved != nil {
+		picked = starved
+	}
+
+	for _, lane := range q.lanes {
+		if lane != picked && len(lane.pending) > 0 {
+			lane.skipped++
+		}
+	}
+
+	if picked != nil {
+		picked.skipped = 0
+	}
+
+	return picked
+}
+
+func (q *PriorityQueue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// QueueBodyPriority returns the priority of a queue message body, for use with PriorityQueue. Bodies holding an alert,
+// either in a QueueEnvelope or as raw JSON, get the SeverityPriority of the alert severity, except resolved alerts,
+// which get the priority of error alerts so that resolutions are not stuck behind info alerts. Other bodies get 0.
+func QueueBodyPriority(body string) int {
+	var alert struct {
+		Severity AlertSeverity `json:"severity"`
+	}
+
+	envelope, err := DecodeQueueEnvelope(body)
+
+	switch {
+	case err == nil:
+		if envelope.Kind != QueueEnvelopeKindAlert || envelope.Unmarshal(&alert) != nil {
+			return 0
+		}
+	case json.Unmarshal([]byte(body), &alert) != nil:
+		return 0
+	}
+
+	if alert.Severity == AlertResolved {
+		return SeverityPriority(AlertError)
+	}
+
+	return max(SeverityPriority(alert.Severity), 0)
+}
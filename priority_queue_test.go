@@ -0,0 +1,285 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingQueue is a types.Queue whose Receive fails immediately.
+type failingQueue struct {
+	*types.InMemoryQueue
+}
+
+func (q *failingQueue) Receive(_ context.Context, sinkCh chan<- *types.QueueItem) error {
+	close(sinkCh)
+	return errors.New("access denied")
+}
+
+// extendingQueue is a types.Queue counting the lease extensions of the received items.
+type extendingQueue struct {
+	*types.InMemoryQueue
+
+	extensions atomic.Int32
+}
+
+func (q *extendingQueue) Receive(ctx context.Context, sinkCh chan<- *types.QueueItem) error {
+	innerCh := make(chan *types.QueueItem)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- q.InMemoryQueue.Receive(ctx, innerCh)
+	}()
+
+	defer close(sinkCh)
+
+	for item := range innerCh {
+		item.Extend = func(time.Duration) error {
+			q.extensions.Add(1)
+			return nil
+		}
+
+		sinkCh <- item
+	}
+
+	return <-errCh
+}
+
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+
+	newLanes := func() (*types.InMemoryQueue, *types.InMemoryQueue, []types.PriorityQueueLane) {
+		high := types.NewInMemoryQueue("high", 100, time.Millisecond)
+		low := types.NewInMemoryQueue("low", 100, time.Millisecond)
+
+		return high, low, []types.PriorityQueueLane{
+			{Priority: 0, Queue: low},
+			{Priority: types.SeverityPriority(types.AlertError), Queue: high},
+		}
+	}
+
+	alertBody := func(t *testing.T, severity types.AlertSeverity) string {
+		t.Helper()
+
+		alert := types.NewAlert(severity)
+		alert.Header = string(severity)
+
+		body, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindAlert, alert)
+		require.NoError(t, err)
+
+		return body
+	}
+
+	t.Run("send should route messages by severity", func(t *testing.T) {
+		t.Parallel()
+
+		high, low, lanes := newLanes()
+
+		queue, err := types.NewPriorityQueue("alerts", lanes, types.PriorityQueueOptions{})
+		require.NoError(t, err)
+
+		ctx := context.Background()
+
+		require.NoError(t, queue.Send(ctx, alertBody(t, types.AlertPanic)))
+		require.NoError(t, queue.SendBatch(ctx, []string{
+			alertBody(t, types.AlertInfo),
+			alertBody(t, types.AlertResolved),
+			alertBody(t, types.AlertWarning),
+			`{"severity": "error"}`,
+			"not json",
+		}))
+		require.NoError(t, queue.SendWithPriority(ctx, 5, "explicit"))
+
+		highItems, err := high.ReceiveBatch(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, highItems, 4)
+
+		lowItems, err := low.ReceiveBatch(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, lowItems, 3)
+	})
+
+	t.Run("higher priority messages should be received first, without starving lower lanes", func(t *testing.T) {
+		t.Parallel()
+
+		high, low, lanes := newLanes()
+		ctx := context.Background()
+
+		for range 40 {
+			require.NoError(t, low.Send(ctx, "info"))
+			require.NoError(t, high.Send(ctx, "panic"))
+		}
+
+		queue, err := types.NewPriorityQueue("alerts", lanes, types.PriorityQueueOptions{StarvationLimit: 3, Prefetch: 5})
+		require.NoError(t, err)
+		defer queue.Close()
+
+		// Let both lanes prefetch.
+		first, err := queue.ReceiveBatch(ctx, 1)
+		require.NoError(t, err)
+		first[0].Ack()
+		time.Sleep(20 * time.Millisecond)
+
+		items, err := queue.ReceiveBatch(ctx, 20)
+		require.NoError(t, err)
+		require.Len(t, items, 10)
+
+		// Both lanes have 5 prefetched items: high priority items come first, but the low priority lane
+		// gets an item after being skipped 3 times (or fewer, if it was skipped by the first receive).
+		assert.Equal(t, "panic", items[0].Body)
+		assert.Equal(t, "panic", items[1].Body)
+
+		infos := 0
+		for i, item := range items {
+			if item.Body == "panic" {
+				assert.Equal(t, 2, item.Priority, "items[%d]", i)
+			} else {
+				assert.Equal(t, 0, item.Priority, "items[%d]", i)
+				if i < 4 {
+					infos++
+				}
+			}
+		}
+
+		assert.Equal(t, 1, infos, "one low priority item should be received among the first 4")
+
+		received := 11
+		for received < 80 {
+			items, err := queue.ReceiveBatch(ctx, 10)
+			require.NoError(t, err)
+			received += len(items)
+		}
+	})
+
+	t.Run("close should stop receiving", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, lanes := newLanes()
+
+		queue, err := types.NewPriorityQueue("alerts", lanes, types.PriorityQueueOptions{})
+		require.NoError(t, err)
+
+		done := make(chan error, 1)
+
+		go func() {
+			_, err := queue.ReceiveBatch(context.Background(), 1)
+			done <- err
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		queue.Close()
+
+		require.EqualError(t, <-done, "queue is closed")
+	})
+
+	t.Run("lane receive errors should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		_, low, _ := newLanes()
+
+		queue, err := types.NewPriorityQueue("alerts", []types.PriorityQueueLane{
+			{Priority: 0, Queue: low},
+			{Priority: 1, Queue: &failingQueue{types.NewInMemoryQueue("failing", 1, time.Millisecond)}},
+		}, types.PriorityQueueOptions{})
+		require.NoError(t, err)
+
+		_, err = queue.ReceiveBatch(context.Background(), 1)
+		require.EqualError(t, err, "lane with priority 1: access denied")
+	})
+
+	t.Run("leases of prefetched messages should be extended until they are received", func(t *testing.T) {
+		t.Parallel()
+
+		lane := &extendingQueue{InMemoryQueue: types.NewInMemoryQueue("lane", 10, time.Millisecond)}
+
+		queue, err := types.NewPriorityQueue("alerts", []types.PriorityQueueLane{{Queue: lane}}, types.PriorityQueueOptions{LeaseTimeout: 20 * time.Millisecond})
+		require.NoError(t, err)
+		t.Cleanup(queue.Close)
+
+		// Start receiving from the lanes.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = queue.ReceiveBatch(ctx, 1)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		require.NoError(t, lane.Send(context.Background(), "foo"))
+		require.Eventually(t, func() bool { return lane.extensions.Load() >= 2 }, time.Second, time.Millisecond)
+
+		items, err := queue.ReceiveBatch(context.Background(), 1)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+
+		extensions := lane.extensions.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, extensions, lane.extensions.Load())
+	})
+
+	t.Run("leases of prefetched messages should not be extended after close", func(t *testing.T) {
+		t.Parallel()
+
+		lane := &extendingQueue{InMemoryQueue: types.NewInMemoryQueue("lane", 10, time.Millisecond)}
+
+		queue, err := types.NewPriorityQueue("alerts", []types.PriorityQueueLane{{Queue: lane}}, types.PriorityQueueOptions{LeaseTimeout: 20 * time.Millisecond})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = queue.ReceiveBatch(ctx, 1)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		require.NoError(t, lane.Send(context.Background(), "foo"))
+		require.Eventually(t, func() bool { return lane.extensions.Load() >= 1 }, time.Second, time.Millisecond)
+
+		queue.Close()
+
+		extensions := lane.extensions.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, extensions, lane.extensions.Load())
+	})
+
+	t.Run("invalid lanes should return error", func(t *testing.T) {
+		t.Parallel()
+
+		low := types.NewInMemoryQueue("low", 1, time.Millisecond)
+
+		_, err := types.NewPriorityQueue("alerts", nil, types.PriorityQueueOptions{})
+		require.EqualError(t, err, "at least one lane is required")
+
+		_, err = types.NewPriorityQueue("alerts", []types.PriorityQueueLane{{Priority: 0}}, types.PriorityQueueOptions{})
+		require.EqualError(t, err, "lanes[0]: queue cannot be nil")
+
+		_, err = types.NewPriorityQueue("alerts", []types.PriorityQueueLane{{Queue: low}, {Queue: low}}, types.PriorityQueueOptions{})
+		require.EqualError(t, err, "lanes[1]: priority 0 is not unique")
+
+		_, err = types.NewPriorityQueue("alerts", []types.PriorityQueueLane{{Queue: low}}, types.PriorityQueueOptions{StarvationLimit: -1})
+		require.EqualError(t, err, "starvation limit cannot be negative")
+
+		_, err = types.NewPriorityQueue("alerts", []types.PriorityQueueLane{{Queue: low}}, types.PriorityQueueOptions{LeaseTimeout: -1})
+		require.EqualError(t, err, "lease timeout cannot be negative")
+	})
+}
+
+func TestQueueBodyPriority(t *testing.T) {
+	t.Parallel()
+
+	envelope, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindAlert, types.NewPanicAlert())
+	require.NoError(t, err)
+
+	callback, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindWebhookCallback, &types.WebhookCallback{ID: "cb1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, types.QueueBodyPriority(envelope))
+	assert.Equal(t, 0, types.QueueBodyPriority(callback))
+	assert.Equal(t, 1, types.QueueBodyPriority(`{"severity": "warning"}`))
+	assert.Equal(t, 2, types.QueueBodyPriority(`{"severity": "resolved"}`))
+	assert.Equal(t, 0, types.QueueBodyPriority(`{"severity": "foo"}`))
+	assert.Equal(t, 0, types.QueueBodyPriority("not json"))
+}
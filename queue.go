@@ -0,0 +1,85 @@
+package types
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDeadLetterNotSupported is returned by FifoQueue.DeadLetter and Queue.DeadLetter when the queue has no
+// dead-letter queue, or the queue implementation does not support accessing it.
+var ErrDeadLetterNotSupported = errors.New("dead-letter queue is not supported")
+
+// FifoQueue is the contract of FIFO queues used by the Slack Manager, such as the alert queue.
+// Messages are ordered per Slack channel, and deduplicated by the dedup ID (if supported by the implementation).
+// Queue plugins implement this interface, and InMemoryFifoQueue is provided for testing.
+type FifoQueue interface {
+	// Name returns the name of the queue (for logging purposes).
+	Name() string
+
+	// Send sends a message related to the specified Slack channel.
+	Send(ctx context.Context, slackChannelID, dedupID, body string) error
+
+	// SendBatch sends multiple messages, in order. Implementations should use the batch API of the backend
+	// where available. An error is returned if any message fails to be sent; messages preceding it may have been sent.
+	SendBatch(ctx context.Context, messages []*FifoQueueMessage) error
+
+	// Receive receives messages continuously, to the specified sink channel, until the context is canceled
+	// or an unrecoverable error occurs. The sink channel is closed when the function returns.
+	Receive(ctx context.Context, sinkCh chan<- *FifoQueueItem) error
+
+	// ReceiveBatch waits until at least one message is available (or the context is canceled),
+	// and returns up to maxItems messages.
+	ReceiveBatch(ctx context.Context, maxItems int) ([]*FifoQueueItem, error)
+
+	// DeadLetter returns the dead-letter queue of this queue, where messages that repeatedly fail processing end up.
+	// ErrDeadLetterNotSupported is returned if there is none.
+	DeadLetter() (FifoQueue, error)
+}
+
+// FifoQueueMessage is a message sent with FifoQueue.SendBatch.
+type FifoQueueMessage struct {
+	// SlackChannelID is the ID of the Slack channel to which the message is related. Messages are ordered per channel.
+	SlackChannelID string
+
+	// DedupID is the deduplication ID of the message.
+	DedupID string
+
+	// Body is the body of the message.
+	Body string
+}
+
+// Queue is the contract of unordered queues used by the Slack Manager, such as the webhook callback queue.
+// Queue plugins implement this interface, and InMemoryQueue is provided for testing.
+type Queue interface {
+	// Name returns the name of the queue (for logging purposes).
+	Name() string
+
+	// Send sends a message.
+	Send(ctx context.Context, body string) error
+
+	// SendBatch sends multiple messages. Implementations should use the batch API of the backend where available.
+	// An error is returned if any message fails to be sent; other messages may have been sent.
+	SendBatch(ctx context.Context, bodies []string) error
+
+	// Receive receives messages continuously, to the specified sink channel, until the context is canceled
+	// or an unrecoverable error occurs. The sink channel is closed when the function returns.
+	Receive(ctx context.Context, sinkCh chan<- *QueueItem) error
+
+	// ReceiveBatch waits until at least one message is available (or the context is canceled),
+	// and returns up to maxItems messages.
+	ReceiveBatch(ctx context.Context, maxItems int) ([]*QueueItem, error)
+
+	// DeadLetter returns the dead-letter queue of this queue, where messages that repeatedly fail processing end up.
+	// ErrDeadLetterNotSupported is returned if there is none.
+	DeadLetter() (Queue, error)
+}
+
+// BatchAcker is implemented by queues that can acknowledge multiple received messages in one backend call,
+// which is typically cheaper than acknowledging each message. Items received from such queues have a non-empty AckToken.
+// Use a BatchAck to collect acknowledgments and flush them in batches.
+type BatchAcker interface {
+	// AckBatch acknowledges the messages identified by the ack tokens. Implementations split the tokens
+	// into multiple backend calls if needed. An error is returned if any message fails to be acknowledged;
+	// other messages may have been acknowledged.
+	AckBatch(ctx context.Context, ackTokens []string) error
+}
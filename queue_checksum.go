@@ -0,0 +1,89 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+// ErrQueueChecksumMismatch is returned by DecodeQueueEnvelope and VerifyQueueChecksum if the payload does not match
+// its checksum, typically because the message body was corrupted or truncated in transit.
+var ErrQueueChecksumMismatch = errors.New("queue payload checksum mismatch")
+
+// QueueChecksum represents the checksum algorithm of queue envelope payloads.
+type QueueChecksum string
+
+const (
+	// QueueChecksumNone disables checksums.
+	QueueChecksumNone QueueChecksum = "none"
+
+	// QueueChecksumCRC32C checksums payloads with CRC-32 (Castagnoli), which is fast and detects corruption and truncation.
+	QueueChecksumCRC32C QueueChecksum = "crc32c"
+
+	// QueueChecksumSHA256 checksums payloads with SHA-256, which also detects deliberate tampering
+	// when the envelope is otherwise protected.
+	QueueChecksumSHA256 QueueChecksum = "sha256"
+)
+
+// QueueChecksumIsValid returns true if the provided QueueChecksum is valid.
+func QueueChecksumIsValid(s QueueChecksum) bool {
+	switch s {
+	case QueueChecksumNone, QueueChecksumCRC32C, QueueChecksumSHA256:
+		return true
+	}
+	return false
+}
+
+// ValidQueueChecksums returns a slice of valid QueueChecksum values.
+func ValidQueueChecksums() []string {
+	return []string{
+		string(QueueChecksumNone),
+		string(QueueChecksumCRC32C),
+		string(QueueChecksumSHA256),
+	}
+}
+
+// QueuePayloadChecksum returns the checksum of the payload on the format '<algorithm>:<hex digest>',
+// e.g. 'crc32c:e3069283'. It returns an empty string for QueueChecksumNone, and an error for unknown algorithms.
+func QueuePayloadChecksum(payload []byte, algorithm QueueChecksum) (string, error) {
+	switch algorithm {
+	case QueueChecksumNone:
+		return "", nil
+	case QueueChecksumCRC32C:
+		return fmt.Sprintf("%s:%08x", algorithm, crc32.Checksum(payload, crc32.MakeTable(crc32.Castagnoli))), nil
+	case QueueChecksumSHA256:
+		sum := sha256.Sum256(payload)
+		return string(algorithm) + ":" + hex.EncodeToString(sum[:]), nil
+	default:
+		return "", fmt.Errorf("checksum '%s' is not valid, expected one of [%s]", algorithm, strings.Join(ValidQueueChecksums(), ", "))
+	}
+}
+
+// VerifyQueueChecksum verifies that the payload matches the checksum created by QueuePayloadChecksum.
+// An empty checksum is accepted, for envelopes created without a checksum. An error wrapping ErrQueueChecksumMismatch
+// is returned if the payload does not match, and another error if the checksum is malformed.
+func VerifyQueueChecksum(payload []byte, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+
+	algorithm, _, ok := strings.Cut(strings.ToLower(checksum), ":")
+	if !ok || QueueChecksum(algorithm) == QueueChecksumNone || !QueueChecksumIsValid(QueueChecksum(algorithm)) {
+		return fmt.Errorf("checksum '%s' is not valid, expected '<algorithm>:<digest>' with algorithm one of [%s, %s]",
+			truncateString(checksum, 80), QueueChecksumCRC32C, QueueChecksumSHA256)
+	}
+
+	actual, err := QueuePayloadChecksum(payload, QueueChecksum(algorithm))
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(actual, checksum) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrQueueChecksumMismatch, checksum, actual)
+	}
+
+	return nil
+}
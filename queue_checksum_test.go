@@ -0,0 +1,60 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueChecksum(t *testing.T) {
+	t.Parallel()
+
+	t.Run("checksums should match the reference values", func(t *testing.T) {
+		t.Parallel()
+
+		checksum, err := types.QueuePayloadChecksum([]byte("123456789"), types.QueueChecksumCRC32C)
+		require.NoError(t, err)
+		assert.Equal(t, "crc32c:e3069283", checksum)
+
+		checksum, err = types.QueuePayloadChecksum([]byte("abc"), types.QueueChecksumSHA256)
+		require.NoError(t, err)
+		assert.Equal(t, "sha256:ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", checksum)
+
+		checksum, err = types.QueuePayloadChecksum([]byte("abc"), types.QueueChecksumNone)
+		require.NoError(t, err)
+		assert.Empty(t, checksum)
+
+		_, err = types.QueuePayloadChecksum([]byte("abc"), "md5")
+		require.EqualError(t, err, "checksum 'md5' is not valid, expected one of [none, crc32c, sha256]")
+	})
+
+	t.Run("payloads should be verified", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, types.VerifyQueueChecksum([]byte("123456789"), "crc32c:e3069283"))
+		require.NoError(t, types.VerifyQueueChecksum([]byte("123456789"), "CRC32C:E3069283"))
+		require.NoError(t, types.VerifyQueueChecksum([]byte("anything"), ""))
+
+		err := types.VerifyQueueChecksum([]byte("12345678"), "crc32c:e3069283")
+		require.ErrorIs(t, err, types.ErrQueueChecksumMismatch)
+		require.EqualError(t, err, "queue payload checksum mismatch: expected crc32c:e3069283, got crc32c:6087809a")
+
+		for _, checksum := range []string{"e3069283", "none:", "md5:abc"} {
+			err := types.VerifyQueueChecksum([]byte("123456789"), checksum)
+			require.ErrorContains(t, err, "is not valid, expected '<algorithm>:<digest>' with algorithm one of [crc32c, sha256]", checksum)
+			require.NotErrorIs(t, err, types.ErrQueueChecksumMismatch)
+		}
+	})
+
+	t.Run("valid checksums should be listed", func(t *testing.T) {
+		t.Parallel()
+
+		for _, s := range types.ValidQueueChecksums() {
+			assert.True(t, types.QueueChecksumIsValid(types.QueueChecksum(s)))
+		}
+
+		assert.False(t, types.QueueChecksumIsValid("md5"))
+	})
+}
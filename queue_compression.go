@@ -0,0 +1,138 @@
+package types
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// DefaultQueueCompressionThreshold is the payload size (in bytes) above which EncodeQueueEnvelope compresses
+	// payloads. It keeps envelopes of large alerts well below the 256 KiB message size limit of SQS, even with
+	// the base64 overhead of the envelope JSON.
+	DefaultQueueCompressionThreshold = 64 * 1024
+
+	// MaxDecompressedQueuePayloadSize is the max size (in bytes) of a decompressed payload.
+	// Larger payloads are rejected, to protect consumers against decompression bombs.
+	MaxDecompressedQueuePayloadSize = 16 * 1024 * 1024
+)
+
+// QueueCompression represents the compression algorithm of queue envelope payloads.
+type QueueCompression string
+
+const (
+	// QueueCompressionNone disables compression.
+	QueueCompressionNone QueueCompression = "none"
+
+	// QueueCompressionGzip compresses payloads with gzip.
+	QueueCompressionGzip QueueCompression = "gzip"
+
+	// QueueCompressionZstd compresses payloads with zstd, which is faster and compresses better than gzip.
+	QueueCompressionZstd QueueCompression = "zstd"
+)
+
+// QueueCompressionIsValid returns true if the provided QueueCompression is valid.
+func QueueCompressionIsValid(s QueueCompression) bool {
+	switch s {
+	case QueueCompressionNone, QueueCompressionGzip, QueueCompressionZstd:
+		return true
+	}
+	return false
+}
+
+// ValidQueueCompressions returns a slice of valid QueueCompression values.
+func ValidQueueCompressions() []string {
+	return []string{
+		string(QueueCompressionNone),
+		string(QueueCompressionGzip),
+		string(QueueCompressionZstd),
+	}
+}
+
+// Magic numbers used to detect the compression algorithm of payloads.
+const (
+	gzipMagic = "\x1f\x8b"
+	zstdMagic = "\x28\xb5\x2f\xfd"
+)
+
+// CompressQueuePayload compresses the data with the specified algorithm.
+// The data is returned as is for QueueCompressionNone.
+func CompressQueuePayload(data []byte, compression QueueCompression) ([]byte, error) {
+	switch compression {
+	case QueueCompressionNone:
+		return data, nil
+	case QueueCompressionGzip:
+		var buf bytes.Buffer
+
+		w := gzip.NewWriter(&buf)
+
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to gzip payload: %w", err)
+		}
+
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("failed to gzip payload: %w", err)
+		}
+
+		return buf.Bytes(), nil
+	case QueueCompressionZstd:
+		w, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+		}
+		defer w.Close()
+
+		return w.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("compression '%s' is not valid, expected one of [%s]", compression, strings.Join(ValidQueueCompressions(), ", "))
+	}
+}
+
+// DecompressQueuePayload decompresses data compressed by CompressQueuePayload. The algorithm is detected from
+// the magic number of the data. An error is returned if the algorithm is unknown, or if the decompressed data
+// is larger than MaxDecompressedQueuePayloadSize.
+func DecompressQueuePayload(data []byte) ([]byte, error) {
+	return decompressQueuePayload(data, MaxDecompressedQueuePayloadSize)
+}
+
+// decompressQueuePayload decompresses data, returning an error if the decompressed data is larger than limit bytes.
+func decompressQueuePayload(data []byte, limit int) ([]byte, error) {
+	var (
+		r   io.Reader
+		err error
+	)
+
+	switch {
+	case bytes.HasPrefix(data, []byte(gzipMagic)):
+		r, err = gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to gunzip payload: %w", err)
+		}
+	case bytes.HasPrefix(data, []byte(zstdMagic)):
+		d, err := zstd.NewReader(bytes.NewReader(data), zstd.WithDecoderMaxMemory(MaxDecompressedQueuePayloadSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+		}
+		defer d.Close()
+
+		r = d
+	default:
+		return nil, errors.New("payload compression is unknown, expected gzip or zstd")
+	}
+
+	result, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress payload: %w", err)
+	}
+
+	if len(result) > limit {
+		return nil, fmt.Errorf("decompressed payload is too large, expected size <=%d", limit)
+	}
+
+	return result, nil
+}
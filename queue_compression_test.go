@@ -0,0 +1,123 @@
+package types_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueCompression(t *testing.T) {
+	t.Parallel()
+
+	t.Run("payloads should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte(strings.Repeat("stack trace line\n", 1000))
+
+		for _, compression := range []types.QueueCompression{types.QueueCompressionGzip, types.QueueCompressionZstd} {
+			compressed, err := types.CompressQueuePayload(data, compression)
+			require.NoError(t, err)
+			assert.Less(t, len(compressed), len(data))
+
+			decompressed, err := types.DecompressQueuePayload(compressed)
+			require.NoError(t, err)
+			assert.Equal(t, data, decompressed)
+		}
+
+		uncompressed, err := types.CompressQueuePayload(data, types.QueueCompressionNone)
+		require.NoError(t, err)
+		assert.Equal(t, data, uncompressed)
+	})
+
+	t.Run("invalid compression should return error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.CompressQueuePayload([]byte("x"), "lz4")
+		require.ErrorContains(t, err, "compression 'lz4' is not valid, expected one of [none, gzip, zstd]")
+
+		_, err = types.DecompressQueuePayload([]byte("not compressed"))
+		require.ErrorContains(t, err, "payload compression is unknown")
+	})
+
+	t.Run("decompression bombs should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		require.NoError(t, err)
+
+		_, err = w.Write(make([]byte, 8*1024))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		// The real limit is too large to test quickly, so a limit of a few KiB is used instead.
+		_, err = types.DecompressQueuePayloadWithLimit(buf.Bytes(), 4*1024)
+		require.EqualError(t, err, "decompressed payload is too large, expected size <=4096")
+
+		result, err := types.DecompressQueuePayloadWithLimit(buf.Bytes(), 8*1024)
+		require.NoError(t, err)
+		assert.Len(t, result, 8*1024)
+	})
+
+	t.Run("large envelope payloads should be compressed", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.Text = strings.Repeat("diagnostic output ", 5000)
+
+		body, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindAlert, alert)
+		require.NoError(t, err)
+		assert.Less(t, len(body), len(alert.Text))
+
+		envelope, err := types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+		assert.True(t, envelope.Compressed)
+
+		decoded, err := envelope.Alert()
+		require.NoError(t, err)
+		assert.Equal(t, alert.Text, decoded.Text)
+	})
+
+	t.Run("envelope compression should follow the options", func(t *testing.T) {
+		t.Parallel()
+
+		payload := map[string]string{"text": strings.Repeat("a", 200)}
+
+		body, err := types.EncodeQueueEnvelopeWithOptions(types.QueueEnvelopeKindCommand, payload, types.QueueEnvelopeOptions{
+			Compression:          types.QueueCompressionZstd,
+			CompressionThreshold: -1,
+		})
+		require.NoError(t, err)
+
+		envelope, err := types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+		assert.True(t, envelope.Compressed)
+
+		var decoded map[string]string
+		require.NoError(t, envelope.Unmarshal(&decoded))
+		assert.Equal(t, payload, decoded)
+
+		body, err = types.EncodeQueueEnvelope(types.QueueEnvelopeKindCommand, payload)
+		require.NoError(t, err)
+
+		envelope, err = types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+		assert.False(t, envelope.Compressed, "payloads below the threshold should not be compressed")
+
+		body, err = types.EncodeQueueEnvelopeWithOptions(types.QueueEnvelopeKindCommand, "x", types.QueueEnvelopeOptions{CompressionThreshold: -1})
+		require.NoError(t, err)
+
+		envelope, err = types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+		assert.False(t, envelope.Compressed, "payloads should not be compressed if that makes them larger")
+
+		_, err = types.EncodeQueueEnvelopeWithOptions(types.QueueEnvelopeKindCommand, "x", types.QueueEnvelopeOptions{Compression: "lz4"})
+		require.ErrorContains(t, err, "compression 'lz4' is not valid")
+	})
+}
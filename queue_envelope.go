@@ -0,0 +1,301 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// QueueEnvelopeSchemaVersion is the current schema version of QueueEnvelope. Decoding fails for envelopes
+// with a higher version, so that consumers never misinterpret messages from newer producers.
+const QueueEnvelopeSchemaVersion = 1
+
+// ErrNotQueueEnvelope is returned by DecodeQueueEnvelope if the body is not a queue envelope, such as a message
+// sent by a producer that predates envelopes. Consumers can fall back to decoding the body directly.
+var ErrNotQueueEnvelope = errors.New("queue message body is not an envelope")
+
+// QueueEnvelopeKind represents the kind of payload in a QueueEnvelope.
+type QueueEnvelopeKind string
+
+const (
+	// QueueEnvelopeKindAlert indicates that the payload is an Alert.
+	QueueEnvelopeKindAlert QueueEnvelopeKind = "alert"
+
+	// QueueEnvelopeKindWebhookCallback indicates that the payload is a WebhookCallback.
+	QueueEnvelopeKindWebhookCallback QueueEnvelopeKind = "webhook_callback"
+
+	// QueueEnvelopeKindCommand indicates that the payload is a command for the Slack Manager.
+	QueueEnvelopeKindCommand QueueEnvelopeKind = "command"
+)
+
+// QueueEnvelopeKindIsValid returns true if the provided QueueEnvelopeKind is valid.
+func QueueEnvelopeKindIsValid(s QueueEnvelopeKind) bool {
+	switch s {
+	case QueueEnvelopeKindAlert, QueueEnvelopeKindWebhookCallback, QueueEnvelopeKindCommand:
+		return true
+	}
+	return false
+}
+
+// ValidQueueEnvelopeKinds returns a slice of valid QueueEnvelopeKind values.
+func ValidQueueEnvelopeKinds() []string {
+	return []string{
+		string(QueueEnvelopeKindAlert),
+		string(QueueEnvelopeKindWebhookCallback),
+		string(QueueEnvelopeKindCommand),
+	}
+}
+
+// QueueEnvelope wraps the body of a queue message with its kind and schema version, so that consumers
+// can decode it without inspecting the payload. Use EncodeQueueEnvelope to create a message body,
+// and DecodeQueueEnvelope to read it.
+type QueueEnvelope struct {
+	// Kind is the kind of payload.
+	Kind QueueEnvelopeKind `json:"kind"`
+
+	// SchemaVersion is the schema version of the envelope, QueueEnvelopeSchemaVersion when encoded.
+	SchemaVersion int `json:"schemaVersion"`
+
+	// Compressed is true if the payload is compressed. The algorithm (gzip or zstd) is detected when decoding.
+	Compressed bool `json:"compressed,omitempty"`
+
+	// Payload is the JSON encoded payload (base64 encoded in the envelope JSON).
+	Payload []byte `json:"payload"`
+
+	// TraceContext is the trace context of the producer, written by Tracer.Inject, if any.
+	// Consumers pass it to Tracer.Extract, so that their spans are children of the producer span.
+	TraceContext map[string]string `json:"traceContext,omitempty"`
+
+	// IdempotencyKey identifies the payload across deliveries, so that consumers can detect duplicates, e.g. with
+	// a ReplayGuard. It defaults to Alert.UniqueID for alert payloads, and is empty if not set by the producer.
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+
+	// Checksum is the checksum of the payload (as stored, after compression), on the format '<algorithm>:<digest>',
+	// created by QueuePayloadChecksum. It is verified by DecodeQueueEnvelope. Empty for envelopes without checksum.
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// QueueEnvelopeOptions configures EncodeQueueEnvelopeWithOptions.
+type QueueEnvelopeOptions struct {
+	// Compression is the compression algorithm used for payloads larger than CompressionThreshold.
+	// Defaults to QueueCompressionGzip, which consumers in any language can decompress.
+	Compression QueueCompression
+
+	// CompressionThreshold is the payload size (in bytes) above which payloads are compressed.
+	// Defaults to DefaultQueueCompressionThreshold. Set it to a negative value to compress all payloads.
+	CompressionThreshold int
+
+	// TraceContext is the trace context to propagate to consumers, typically written by Tracer.Inject. Optional.
+	TraceContext map[string]string
+
+	// IdempotencyKey is the idempotency key of the envelope. Defaults to Alert.UniqueID for alert payloads
+	// (Alert or *Alert). Optional.
+	IdempotencyKey string
+
+	// Checksum is the checksum algorithm of the payload. Defaults to QueueChecksumCRC32C.
+	// Set it to QueueChecksumNone to omit the checksum.
+	Checksum QueueChecksum
+}
+
+// EncodeQueueEnvelope encodes the value as JSON, wraps it in an envelope of the specified kind, and returns
+// the envelope JSON, for use as queue message body. Payloads larger than DefaultQueueCompressionThreshold
+// are compressed with gzip, and all payloads are checksummed with CRC-32C.
+func EncodeQueueEnvelope(kind QueueEnvelopeKind, v any) (string, error) {
+	return EncodeQueueEnvelopeWithOptions(kind, v, QueueEnvelopeOptions{})
+}
+
+// EncodeQueueEnvelopeWithOptions is like EncodeQueueEnvelope, with custom compression, checksum and idempotency options.
+// The payload is only compressed if that makes it smaller.
+func EncodeQueueEnvelopeWithOptions(kind QueueEnvelopeKind, v any, opts QueueEnvelopeOptions) (string, error) {
+	if !QueueEnvelopeKindIsValid(kind) {
+		return "", fmt.Errorf("kind '%s' is not valid, expected one of [%s]", kind, strings.Join(ValidQueueEnvelopeKinds(), ", "))
+	}
+
+	if opts.Compression == "" {
+		opts.Compression = QueueCompressionGzip
+	}
+
+	if !QueueCompressionIsValid(opts.Compression) {
+		return "", fmt.Errorf("compression '%s' is not valid, expected one of [%s]", opts.Compression, strings.Join(ValidQueueCompressions(), ", "))
+	}
+
+	if opts.CompressionThreshold == 0 {
+		opts.CompressionThreshold = DefaultQueueCompressionThreshold
+	}
+
+	if opts.Checksum == "" {
+		opts.Checksum = QueueChecksumCRC32C
+	}
+
+	if !QueueChecksumIsValid(opts.Checksum) {
+		return "", fmt.Errorf("checksum '%s' is not valid, expected one of [%s]", opts.Checksum, strings.Join(ValidQueueChecksums(), ", "))
+	}
+
+	if opts.IdempotencyKey == "" {
+		switch alert := v.(type) {
+		case *Alert:
+			if alert != nil {
+				opts.IdempotencyKey = alert.UniqueID()
+			}
+		case Alert:
+			opts.IdempotencyKey = alert.UniqueID()
+		}
+	}
+
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s payload: %w", kind, err)
+	}
+
+	envelope := &QueueEnvelope{
+		Kind:           kind,
+		SchemaVersion:  QueueEnvelopeSchemaVersion,
+		Payload:        payload,
+		TraceContext:   opts.TraceContext,
+		IdempotencyKey: opts.IdempotencyKey,
+	}
+
+	if opts.Compression != QueueCompressionNone && len(payload) > opts.CompressionThreshold {
+		compressed, err := CompressQueuePayload(payload, opts.Compression)
+		if err != nil {
+			return "", err
+		}
+
+		if len(compressed) < len(payload) {
+			envelope.Payload = compressed
+			envelope.Compressed = true
+		}
+	}
+
+	if envelope.Checksum, err = QueuePayloadChecksum(envelope.Payload, opts.Checksum); err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode queue envelope: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// DecodeQueueEnvelope decodes a queue message body created by EncodeQueueEnvelope.
+// ErrNotQueueEnvelope is returned if the body is not an envelope, and an error is returned
+// if the kind is unknown or the schema version is newer than QueueEnvelopeSchemaVersion.
+// An error wrapping ErrQueueChecksumMismatch is returned if the payload does not match the envelope checksum,
+// before any attempt to decompress or decode it.
+func DecodeQueueEnvelope(body string) (*QueueEnvelope, error) {
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal([]byte(body), &fields); err != nil {
+		return nil, ErrNotQueueEnvelope
+	}
+
+	if _, ok := fields["kind"]; !ok {
+		return nil, ErrNotQueueEnvelope
+	}
+
+	if _, ok := fields["schemaVersion"]; !ok {
+		return nil, ErrNotQueueEnvelope
+	}
+
+	var envelope QueueEnvelope
+
+	if err := json.Unmarshal([]byte(body), &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode queue envelope: %w", err)
+	}
+
+	if !QueueEnvelopeKindIsValid(envelope.Kind) {
+		return nil, fmt.Errorf("kind '%s' is not valid, expected one of [%s]", envelope.Kind, strings.Join(ValidQueueEnvelopeKinds(), ", "))
+	}
+
+	if envelope.SchemaVersion < 1 || envelope.SchemaVersion > QueueEnvelopeSchemaVersion {
+		return nil, fmt.Errorf("schemaVersion %d is not supported, expected 1-%d", envelope.SchemaVersion, QueueEnvelopeSchemaVersion)
+	}
+
+	if err := VerifyQueueChecksum(envelope.Payload, envelope.Checksum); err != nil {
+		return nil, fmt.Errorf("invalid %s envelope: %w", envelope.Kind, err)
+	}
+
+	return &envelope, nil
+}
+
+// QueueEnvelopeTraceContext returns the trace context of a queue message body created by EncodeQueueEnvelope,
+// without decoding the payload. It returns nil if the body is not an envelope, or has no trace context.
+func QueueEnvelopeTraceContext(body string) map[string]string {
+	var envelope struct {
+		TraceContext map[string]string `json:"traceContext"`
+	}
+
+	if err := json.Unmarshal([]byte(body), &envelope); err != nil {
+		return nil
+	}
+
+	return envelope.TraceContext
+}
+
+// QueueEnvelopeIdempotencyKey returns the idempotency key of a queue message body created by EncodeQueueEnvelope,
+// without decoding the payload. It returns an empty string if the body is not an envelope, or has no idempotency key.
+func QueueEnvelopeIdempotencyKey(body string) string {
+	var envelope struct {
+		IdempotencyKey string `json:"idempotencyKey"`
+	}
+
+	if err := json.Unmarshal([]byte(body), &envelope); err != nil {
+		return ""
+	}
+
+	return envelope.IdempotencyKey
+}
+
+// Unmarshal decodes the JSON payload into v, decompressing it first if needed.
+func (e *QueueEnvelope) Unmarshal(v any) error {
+	payload := e.Payload
+
+	if e.Compressed {
+		var err error
+
+		payload, err = DecompressQueuePayload(payload)
+		if err != nil {
+			return fmt.Errorf("failed to decompress %s payload: %w", e.Kind, err)
+		}
+	}
+
+	if err := json.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("failed to decode %s payload: %w", e.Kind, err)
+	}
+
+	return nil
+}
+
+// Alert decodes the payload as an Alert. An error is returned if the kind is not QueueEnvelopeKindAlert.
+func (e *QueueEnvelope) Alert() (*Alert, error) {
+	if e.Kind != QueueEnvelopeKindAlert {
+		return nil, fmt.Errorf("envelope kind is '%s', expected '%s'", e.Kind, QueueEnvelopeKindAlert)
+	}
+
+	var alert Alert
+
+	if err := e.Unmarshal(&alert); err != nil {
+		return nil, err
+	}
+
+	return &alert, nil
+}
+
+// WebhookCallback decodes the payload as a WebhookCallback. An error is returned if the kind is not
+// QueueEnvelopeKindWebhookCallback.
+func (e *QueueEnvelope) WebhookCallback() (*WebhookCallback, error) {
+	if e.Kind != QueueEnvelopeKindWebhookCallback {
+		return nil, fmt.Errorf("envelope kind is '%s', expected '%s'", e.Kind, QueueEnvelopeKindWebhookCallback)
+	}
+
+	var callback WebhookCallback
+
+	if err := e.Unmarshal(&callback); err != nil {
+		return nil, err
+	}
+
+	return &callback, nil
+}
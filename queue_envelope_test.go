@@ -0,0 +1,190 @@
+package types_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueEnvelope(t *testing.T) {
+	t.Parallel()
+
+	t.Run("alert should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.Header = "Disk full"
+		alert.SlackChannelID = "C12345678"
+
+		body, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindAlert, alert)
+		require.NoError(t, err)
+
+		envelope, err := types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+		assert.Equal(t, types.QueueEnvelopeKindAlert, envelope.Kind)
+		assert.Equal(t, types.QueueEnvelopeSchemaVersion, envelope.SchemaVersion)
+		assert.False(t, envelope.Compressed)
+
+		decoded, err := envelope.Alert()
+		require.NoError(t, err)
+		assert.Equal(t, "Disk full", decoded.Header)
+		assert.Equal(t, types.AlertError, decoded.Severity)
+
+		_, err = envelope.WebhookCallback()
+		require.ErrorContains(t, err, "envelope kind is 'alert', expected 'webhook_callback'")
+	})
+
+	t.Run("webhook callback should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		body, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindWebhookCallback, &types.WebhookCallback{ID: "cb1", ChannelID: "C12345678"})
+		require.NoError(t, err)
+
+		envelope, err := types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+
+		callback, err := envelope.WebhookCallback()
+		require.NoError(t, err)
+		assert.Equal(t, "cb1", callback.ID)
+
+		_, err = envelope.Alert()
+		require.Error(t, err)
+	})
+
+	t.Run("command payload should be decoded with unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		body, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindCommand, map[string]string{"action": "resolve"})
+		require.NoError(t, err)
+
+		envelope, err := types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+
+		var command map[string]string
+		require.NoError(t, envelope.Unmarshal(&command))
+		assert.Equal(t, "resolve", command["action"])
+	})
+
+	t.Run("trace context should be propagated", func(t *testing.T) {
+		t.Parallel()
+
+		traceContext := map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+
+		body, err := types.EncodeQueueEnvelopeWithOptions(types.QueueEnvelopeKindAlert, types.NewErrorAlert(), types.QueueEnvelopeOptions{TraceContext: traceContext})
+		require.NoError(t, err)
+
+		envelope, err := types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+		assert.Equal(t, traceContext, envelope.TraceContext)
+		assert.Equal(t, traceContext, types.QueueEnvelopeTraceContext(body))
+
+		body, err = types.EncodeQueueEnvelope(types.QueueEnvelopeKindAlert, types.NewErrorAlert())
+		require.NoError(t, err)
+		assert.NotContains(t, body, "traceContext")
+		assert.Nil(t, types.QueueEnvelopeTraceContext(body))
+		assert.Nil(t, types.QueueEnvelopeTraceContext("not json"))
+	})
+
+	t.Run("idempotency key should default to the alert unique ID", func(t *testing.T) {
+		t.Parallel()
+
+		alert := types.NewErrorAlert()
+		alert.Header = "Disk full"
+
+		body, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindAlert, alert)
+		require.NoError(t, err)
+
+		envelope, err := types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+		assert.Equal(t, alert.UniqueID(), envelope.IdempotencyKey)
+		assert.Equal(t, alert.UniqueID(), types.QueueEnvelopeIdempotencyKey(body))
+
+		body, err = types.EncodeQueueEnvelopeWithOptions(types.QueueEnvelopeKindAlert, alert, types.QueueEnvelopeOptions{IdempotencyKey: "key-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "key-1", types.QueueEnvelopeIdempotencyKey(body))
+
+		body, err = types.EncodeQueueEnvelope(types.QueueEnvelopeKindCommand, map[string]string{"action": "resolve"})
+		require.NoError(t, err)
+		assert.NotContains(t, body, "idempotencyKey")
+		assert.Empty(t, types.QueueEnvelopeIdempotencyKey(body))
+		assert.Empty(t, types.QueueEnvelopeIdempotencyKey("not json"))
+	})
+
+	t.Run("corrupted payloads should fail the checksum", func(t *testing.T) {
+		t.Parallel()
+
+		body, err := types.EncodeQueueEnvelope(types.QueueEnvelopeKindAlert, types.NewErrorAlert())
+		require.NoError(t, err)
+
+		envelope, err := types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(envelope.Checksum, "crc32c:"))
+
+		envelope.Payload = envelope.Payload[:len(envelope.Payload)-5]
+		corrupted, err := json.Marshal(envelope)
+		require.NoError(t, err)
+
+		_, err = types.DecodeQueueEnvelope(string(corrupted))
+		require.ErrorIs(t, err, types.ErrQueueChecksumMismatch)
+		require.ErrorContains(t, err, "invalid alert envelope: queue payload checksum mismatch")
+
+		body, err = types.EncodeQueueEnvelopeWithOptions(types.QueueEnvelopeKindAlert, types.NewErrorAlert(), types.QueueEnvelopeOptions{Checksum: types.QueueChecksumSHA256})
+		require.NoError(t, err)
+
+		envelope, err = types.DecodeQueueEnvelope(body)
+		require.NoError(t, err)
+		assert.Len(t, envelope.Checksum, len("sha256:")+64)
+
+		body, err = types.EncodeQueueEnvelopeWithOptions(types.QueueEnvelopeKindAlert, types.NewErrorAlert(), types.QueueEnvelopeOptions{Checksum: types.QueueChecksumNone})
+		require.NoError(t, err)
+		assert.NotContains(t, body, "checksum")
+
+		_, err = types.EncodeQueueEnvelopeWithOptions(types.QueueEnvelopeKindAlert, types.NewErrorAlert(), types.QueueEnvelopeOptions{Checksum: "md5"})
+		require.ErrorContains(t, err, "checksum 'md5' is not valid, expected one of [none, crc32c, sha256]")
+	})
+
+	t.Run("invalid kind should not be encoded", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.EncodeQueueEnvelope("foo", "bar")
+		require.ErrorContains(t, err, "kind 'foo' is not valid, expected one of [alert, webhook_callback, command]")
+	})
+
+	t.Run("non-envelope bodies should return ErrNotQueueEnvelope", func(t *testing.T) {
+		t.Parallel()
+
+		for _, body := range []string{"", "not json", `[1, 2]`, `{"header": "Disk full", "severity": "error"}`, `{"kind": "alert"}`} {
+			_, err := types.DecodeQueueEnvelope(body)
+			require.ErrorIs(t, err, types.ErrNotQueueEnvelope, body)
+		}
+	})
+
+	t.Run("invalid envelopes should return errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := types.DecodeQueueEnvelope(`{"kind": "foo", "schemaVersion": 1, "payload": "e30="}`)
+		require.ErrorContains(t, err, "kind 'foo' is not valid")
+
+		_, err = types.DecodeQueueEnvelope(`{"kind": "alert", "schemaVersion": 99, "payload": "e30="}`)
+		require.ErrorContains(t, err, "schemaVersion 99 is not supported")
+
+		_, err = types.DecodeQueueEnvelope(`{"kind": "alert", "schemaVersion": "1", "payload": "e30="}`)
+		require.ErrorContains(t, err, "failed to decode queue envelope")
+
+		envelope, err := types.DecodeQueueEnvelope(`{"kind": "alert", "schemaVersion": 1, "payload": "bm90IGpzb24="}`)
+		require.NoError(t, err)
+
+		_, err = envelope.Alert()
+		require.ErrorContains(t, err, "failed to decode alert payload")
+
+		envelope, err = types.DecodeQueueEnvelope(`{"kind": "alert", "schemaVersion": 1, "compressed": true, "payload": "e30="}`)
+		require.NoError(t, err)
+
+		_, err = envelope.Alert()
+		require.ErrorContains(t, err, "failed to decompress alert payload: payload compression is unknown")
+	})
+}
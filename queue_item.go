@@ -0,0 +1,52 @@
+package types
+
+import (
+	"time"
+)
+
+// QueueItem represents an item received from an unordered Queue.
+type QueueItem struct {
+	// MessageID is the unique identifier of the message (as defined by the queue implementation).
+	MessageID string
+
+	// ReceiveTimestamp is the time when the message was received from the queue.
+	ReceiveTimestamp time.Time
+
+	// Body is the body of the message.
+	Body string
+
+	// Ack acknowledges the successful processing of the message, effectively removing it from the queue.
+	// This function cannot be nil.
+	//
+	// Like FifoQueueItem.Ack, it does not accept a context parameter, since acknowledgment is a commitment
+	// that must complete regardless of the caller's context state.
+	Ack func()
+
+	// Nack negatively acknowledges the processing of the message, thus making it available for reprocessing.
+	// This function cannot be nil.
+	Nack func()
+
+	// NackWithDelay negatively acknowledges the processing of the message, making it available for reprocessing
+	// after the specified delay. Consumers use it for progressive backoff, typically based on ReceiveCount.
+	// Like Nack, it does not accept a context parameter.
+	// This function is nil if the queue implementation does not support delayed redelivery; use Nack instead.
+	NackWithDelay func(delay time.Duration)
+
+	// ReceiveCount is the number of times the message has been received, including this time (1 on first delivery).
+	// Consumers use it to detect poison messages, and move them to the dead-letter queue after a number of attempts
+	// instead of reprocessing them forever. It is 0 if the queue implementation does not track deliveries.
+	ReceiveCount int
+
+	// Extend extends the time the message stays invisible to other consumers (the visibility timeout or lease),
+	// counted from now. Consumers call it while processing takes longer than expected.
+	// This function is nil if the queue implementation does not support extending.
+	Extend func(timeout time.Duration) error
+
+	// Priority is the priority of the message, set by PriorityQueue to the priority of the lane the message was
+	// received from (higher is more urgent, see SeverityPriority). It is 0 for other queue implementations.
+	Priority int
+
+	// AckToken identifies the received message in BatchAcker.AckBatch calls, as an alternative to Ack.
+	// It is empty if the queue implementation does not support batch acknowledgment.
+	AckToken string
+}
@@ -0,0 +1,135 @@
+package natsqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/slackmgr/types"
+)
+
+var _ types.FifoQueue = (*FifoQueue)(nil)
+
+// FifoQueue is a types.FifoQueue backed by a JetStream stream and pull consumer.
+type FifoQueue struct {
+	*base
+	deadLetter *FifoQueue
+}
+
+// NewFifoQueue creates a new FifoQueue, publishing to '<subject>.<Slack channel ID>'.
+func NewFifoQueue(publisher Publisher, subject string, consumer Consumer, opts ...Option) *FifoQueue {
+	return &FifoQueue{base: newBase(publisher, subject, consumer, opts)}
+}
+
+// WithDeadLetter sets the queue returned by DeadLetter, and returns q.
+// JetStream has no dead-letter streams, so it is up to the caller to move messages there,
+// for example based on the max deliveries advisories.
+func (q *FifoQueue) WithDeadLetter(deadLetter *FifoQueue) *FifoQueue {
+	q.deadLetter = deadLetter
+	return q
+}
+
+// Name returns the name of the queue, which defaults to the subject.
+func (q *FifoQueue) Name() string {
+	return q.opts.name
+}
+
+// Send publishes a message to the subject of the Slack channel.
+func (q *FifoQueue) Send(ctx context.Context, slackChannelID, dedupID, body string) error {
+	msg, err := q.message(slackChannelID, body)
+	if err != nil {
+		return err
+	}
+
+	return q.publish(ctx, msg, dedupID)
+}
+
+// SendBatch publishes multiple messages, in order. JetStream has no batch publish, so each message is
+// published and acknowledged by the server before the next one.
+func (q *FifoQueue) SendBatch(ctx context.Context, messages []*types.FifoQueueMessage) error {
+	for i, m := range messages {
+		if err := q.Send(ctx, m.SlackChannelID, m.DedupID, m.Body); err != nil {
+			return fmt.Errorf("messages[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Receive receives messages continuously, to the specified sink channel, until the context is canceled.
+// Failed fetch requests are logged and retried. The sink channel is closed when the function returns.
+func (q *FifoQueue) Receive(ctx context.Context, sinkCh chan<- *types.FifoQueueItem) error {
+	defer close(sinkCh)
+
+	return q.receiveLoop(ctx, func(m jetstream.Msg) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case sinkCh <- q.item(m):
+			return true
+		}
+	})
+}
+
+// ReceiveBatch waits until at least one message is available, and returns up to maxItems messages.
+func (q *FifoQueue) ReceiveBatch(ctx context.Context, maxItems int) ([]*types.FifoQueueItem, error) {
+	messages, err := q.fetchBatch(ctx, maxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.FifoQueueItem, len(messages))
+	for i, m := range messages {
+		items[i] = q.item(m)
+	}
+
+	return items, nil
+}
+
+// DeadLetter returns the queue set with WithDeadLetter, or types.ErrDeadLetterNotSupported if there is none.
+func (q *FifoQueue) DeadLetter() (types.FifoQueue, error) {
+	if q.deadLetter == nil {
+		return nil, types.ErrDeadLetterNotSupported
+	}
+
+	return q.deadLetter, nil
+}
+
+func (q *FifoQueue) message(slackChannelID, body string) (*nats.Msg, error) {
+	if slackChannelID == "" {
+		return nil, errors.New("slackChannelID is required for FIFO queues")
+	}
+
+	if strings.ContainsAny(slackChannelID, ".*> \t\r\n") {
+		return nil, fmt.Errorf("slackChannelID '%s' is not a valid subject token", slackChannelID)
+	}
+
+	msg := nats.NewMsg(q.subject + "." + slackChannelID)
+	msg.Data = []byte(body)
+	msg.Header.Set(SlackChannelIDHeader, slackChannelID)
+
+	return msg, nil
+}
+
+func (q *FifoQueue) item(m jetstream.Msg) *types.FifoQueueItem {
+	channelID := m.Headers().Get(SlackChannelIDHeader)
+	if channelID == "" {
+		channelID = strings.TrimPrefix(m.Subject(), q.subject+".")
+	}
+
+	return &types.FifoQueueItem{
+		MessageID:        messageID(m),
+		SlackChannelID:   channelID,
+		ReceiveTimestamp: time.Now(),
+		Body:             string(m.Data()),
+		Ack:              q.ack(m),
+		Nack:             q.nack(m),
+		NackWithDelay:    q.nackWithDelay(m),
+		ReceiveCount:     receiveCount(m),
+		Extend:           extend(m),
+	}
+}
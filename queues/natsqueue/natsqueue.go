@@ -0,0 +1,205 @@
+// Package natsqueue implements the types.FifoQueue and types.Queue interfaces on top of NATS JetStream.
+//
+// The package is a separate module, so that the core module does not depend on the NATS client.
+// FIFO queues publish each message to '<subject>.<Slack channel ID>', so the stream must bind '<subject>.>',
+// and messages of a channel are stored (and delivered by a pull consumer) in order. The dedup ID is used as
+// JetStream message ID (the Nats-Msg-Id header), so duplicates within the duplicate window of the stream are dropped:
+//
+//	js, err := jetstream.New(nc)
+//	consumer, err := js.Consumer(ctx, "ALERTS", "slack-manager")
+//	queue := natsqueue.NewFifoQueue(js, "alerts", consumer)
+//
+// The consumer may be nil for send-only queues. Extend signals to JetStream that the message is in progress,
+// which resets the redelivery timer to the AckWait of the consumer.
+package natsqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// SlackChannelIDHeader is the name of the message header carrying the Slack channel ID of FIFO messages.
+const SlackChannelIDHeader = "Slack-Channel-Id"
+
+var errNoConsumer = errors.New("queue has no consumer")
+
+// Publisher is the subset of jetstream.JetStream used to send messages.
+type Publisher interface {
+	PublishMsg(ctx context.Context, msg *nats.Msg, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error)
+}
+
+// Consumer is the subset of jetstream.Consumer used to receive messages. It should be a pull consumer
+// with explicit acknowledgment.
+type Consumer interface {
+	Fetch(batch int, opts ...jetstream.FetchOpt) (jetstream.MessageBatch, error)
+}
+
+var (
+	_ Publisher = (jetstream.JetStream)(nil)
+	_ Consumer  = (jetstream.Consumer)(nil)
+)
+
+// base holds the logic shared by FifoQueue and Queue.
+type base struct {
+	publisher Publisher
+	subject   string
+	consumer  Consumer
+	opts      *options
+}
+
+func newBase(publisher Publisher, subject string, consumer Consumer, opts []Option) *base {
+	return &base{
+		publisher: publisher,
+		subject:   subject,
+		consumer:  consumer,
+		opts:      newOptions(subject, opts),
+	}
+}
+
+func (b *base) publish(ctx context.Context, msg *nats.Msg, dedupID string) error {
+	var opts []jetstream.PublishOpt
+	if dedupID != "" {
+		opts = append(opts, jetstream.WithMsgID(dedupID))
+	}
+
+	if _, err := b.publisher.PublishMsg(ctx, msg, opts...); err != nil {
+		return fmt.Errorf("failed to publish message to %s: %w", b.opts.name, err)
+	}
+
+	return nil
+}
+
+// fetch makes a single fetch request, returning up to maxItems messages (and possibly none).
+func (b *base) fetch(maxItems int) ([]jetstream.Msg, error) {
+	if b.consumer == nil {
+		return nil, errNoConsumer
+	}
+
+	batch, err := b.consumer.Fetch(max(maxItems, 1), jetstream.FetchMaxWait(b.opts.fetchWait))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch messages from %s: %w", b.opts.name, err)
+	}
+
+	var messages []jetstream.Msg
+	for m := range batch.Messages() {
+		messages = append(messages, m)
+	}
+
+	if err := batch.Error(); err != nil && len(messages) == 0 {
+		return nil, fmt.Errorf("failed to fetch messages from %s: %w", b.opts.name, err)
+	}
+
+	return messages, nil
+}
+
+// fetchBatch makes fetch requests until at least one message is returned, or the context is canceled.
+func (b *base) fetchBatch(ctx context.Context, maxItems int) ([]jetstream.Msg, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		messages, err := b.fetch(maxItems)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(messages) > 0 {
+			return messages, nil
+		}
+	}
+}
+
+// receiveLoop makes fetch requests until the context is canceled, passing each message to handle.
+// Failed fetch requests are logged and retried after the retry delay.
+func (b *base) receiveLoop(ctx context.Context, handle func(jetstream.Msg) bool) error {
+	if b.consumer == nil {
+		return errNoConsumer
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		messages, err := b.fetch(1)
+		if err != nil {
+			b.opts.logger.Error(err.Error())
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(b.opts.retryDelay):
+			}
+
+			continue
+		}
+
+		for _, m := range messages {
+			if !handle(m) {
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+func (b *base) ack(m jetstream.Msg) func() {
+	return func() {
+		if err := m.Ack(); err != nil {
+			b.opts.logger.Errorf("Failed to ack message %s from %s: %s", messageID(m), b.opts.name, err)
+		}
+	}
+}
+
+func (b *base) nack(m jetstream.Msg) func() {
+	return func() {
+		if err := m.Nak(); err != nil {
+			b.opts.logger.Errorf("Failed to nack message %s from %s: %s", messageID(m), b.opts.name, err)
+		}
+	}
+}
+
+func (b *base) nackWithDelay(m jetstream.Msg) func(time.Duration) {
+	return func(delay time.Duration) {
+		if err := m.NakWithDelay(delay); err != nil {
+			b.opts.logger.Errorf("Failed to nack message %s from %s: %s", messageID(m), b.opts.name, err)
+		}
+	}
+}
+
+// extend returns a function resetting the redelivery timer of the message. JetStream does not support
+// custom durations, so the timeout argument is ignored, and the AckWait of the consumer is used.
+func extend(m jetstream.Msg) func(time.Duration) error {
+	return func(time.Duration) error {
+		if err := m.InProgress(); err != nil {
+			return fmt.Errorf("failed to extend message %s: %w", messageID(m), err)
+		}
+
+		return nil
+	}
+}
+
+// receiveCount returns the number of deliveries of the message, or 0 if the message has no JetStream metadata.
+func receiveCount(m jetstream.Msg) int {
+	md, err := m.Metadata()
+	if err != nil {
+		return 0
+	}
+
+	return int(md.NumDelivered) // #nosec G115 -- delivery counts are small
+}
+
+// messageID returns '<stream>-<stream sequence>', or an empty string if the message has no JetStream metadata.
+func messageID(m jetstream.Msg) string {
+	md, err := m.Metadata()
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s-%d", md.Stream, md.Sequence.Stream)
+}
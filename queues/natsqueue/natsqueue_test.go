@@ -0,0 +1,159 @@
+package natsqueue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/queues/natsqueue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newJetStream starts an embedded NATS server with JetStream, and creates a stream binding the specified subjects,
+// with a pull consumer.
+func newJetStream(t *testing.T, stream string, subjects ...string) (jetstream.JetStream, jetstream.Consumer) {
+	t.Helper()
+
+	srv, err := server.NewServer(&server.Options{Port: -1, JetStream: true, StoreDir: t.TempDir(), NoLog: true, NoSigs: true})
+	require.NoError(t, err)
+
+	go srv.Start()
+	t.Cleanup(srv.Shutdown)
+	require.True(t, srv.ReadyForConnections(5*time.Second))
+
+	nc, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	js, err := jetstream.New(nc)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, err = js.CreateStream(ctx, jetstream.StreamConfig{Name: stream, Subjects: subjects})
+	require.NoError(t, err)
+
+	consumer, err := js.CreateOrUpdateConsumer(ctx, stream, jetstream.ConsumerConfig{
+		Durable:   "slack-manager",
+		AckPolicy: jetstream.AckExplicitPolicy,
+		AckWait:   time.Second,
+	})
+	require.NoError(t, err)
+
+	return js, consumer
+}
+
+func TestFifoQueue(t *testing.T) {
+	t.Parallel()
+
+	js, consumer := newJetStream(t, "ALERTS", "alerts.>")
+	q := natsqueue.NewFifoQueue(js, "alerts", consumer, natsqueue.WithFetchWait(100*time.Millisecond))
+
+	assert.Equal(t, "alerts", q.Name())
+
+	ctx := context.Background()
+
+	require.NoError(t, q.Send(ctx, "C123", "dedup-1", "b1"))
+	require.NoError(t, q.Send(ctx, "C123", "dedup-1", "b1"), "duplicates are dropped by the server")
+	require.NoError(t, q.SendBatch(ctx, []*types.FifoQueueMessage{
+		{SlackChannelID: "C123", Body: "b2"},
+		{SlackChannelID: "C456", DedupID: "dedup-3", Body: "b3"},
+	}))
+
+	require.Error(t, q.Send(ctx, "", "", "b"))
+	require.Error(t, q.Send(ctx, "alerts.*", "", "b"))
+
+	err := q.SendBatch(ctx, []*types.FifoQueueMessage{{Body: "b"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "messages[0]")
+
+	items, err := q.ReceiveBatch(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	assert.Equal(t, "b1", items[0].Body)
+	assert.Equal(t, "C123", items[0].SlackChannelID)
+	assert.Equal(t, "ALERTS-1", items[0].MessageID)
+	assert.Equal(t, "b2", items[1].Body)
+	assert.Equal(t, "C456", items[2].SlackChannelID)
+
+	assert.Equal(t, 1, items[2].ReceiveCount)
+
+	require.NoError(t, items[0].Extend(time.Minute))
+	items[0].Ack()
+	items[1].Ack()
+	items[2].Nack()
+
+	items, err = q.ReceiveBatch(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "b3", items[0].Body)
+	assert.Equal(t, 2, items[0].ReceiveCount)
+	items[0].NackWithDelay(50 * time.Millisecond)
+
+	items, err = q.ReceiveBatch(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 3, items[0].ReceiveCount)
+	items[0].Ack()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	_, err = q.ReceiveBatch(timeoutCtx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = q.DeadLetter()
+	require.ErrorIs(t, err, types.ErrDeadLetterNotSupported)
+
+	dlq := natsqueue.NewFifoQueue(js, "alerts-dlq", nil)
+	q.WithDeadLetter(dlq)
+
+	result, err := q.DeadLetter()
+	require.NoError(t, err)
+	assert.Same(t, dlq, result)
+
+	_, err = dlq.ReceiveBatch(ctx, 1)
+	require.Error(t, err)
+}
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	js, consumer := newJetStream(t, "CALLBACKS", "callbacks")
+	q := natsqueue.NewQueue(js, "callbacks", consumer, natsqueue.WithName("callbacks-queue"), natsqueue.WithFetchWait(100*time.Millisecond))
+
+	assert.Equal(t, "callbacks-queue", q.Name())
+
+	ctx := context.Background()
+
+	require.NoError(t, q.Send(ctx, "b1"))
+	require.NoError(t, q.SendBatch(ctx, []string{"b2", "b3"}))
+
+	receiveCtx, cancel := context.WithCancel(ctx)
+	sinkCh := make(chan *types.QueueItem)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- q.Receive(receiveCtx, sinkCh)
+	}()
+
+	for _, body := range []string{"b1", "b2", "b3"} {
+		item := <-sinkCh
+		assert.Equal(t, body, item.Body)
+		assert.NotNil(t, item.Extend)
+		item.Ack()
+	}
+
+	cancel()
+
+	require.ErrorIs(t, <-errCh, context.Canceled)
+
+	_, ok := <-sinkCh
+	assert.False(t, ok)
+}
@@ -0,0 +1,71 @@
+package natsqueue
+
+import (
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	// DefaultFetchWait is the default maximum time a single fetch request waits for messages.
+	DefaultFetchWait = 5 * time.Second
+
+	// DefaultRetryDelay is the default delay before Receive retries after a failed fetch request.
+	DefaultRetryDelay = time.Second
+)
+
+// Option configures a queue.
+type Option func(*options)
+
+type options struct {
+	name       string
+	fetchWait  time.Duration
+	retryDelay time.Duration
+	logger     types.Logger
+}
+
+func newOptions(name string, opts []Option) *options {
+	o := &options{
+		name:       name,
+		fetchWait:  DefaultFetchWait,
+		retryDelay: DefaultRetryDelay,
+		logger:     &types.NoopLogger{},
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithName sets the name returned by Name (for logging purposes). Defaults to the subject.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithFetchWait sets the maximum time a single fetch request waits for messages. Defaults to DefaultFetchWait.
+// Since fetch requests are not context aware, this is also the maximum delay before Receive and ReceiveBatch
+// return after the context is canceled.
+func WithFetchWait(wait time.Duration) Option {
+	return func(o *options) {
+		o.fetchWait = wait
+	}
+}
+
+// WithRetryDelay sets the delay before Receive retries after a failed fetch request. Defaults to DefaultRetryDelay.
+func WithRetryDelay(delay time.Duration) Option {
+	return func(o *options) {
+		o.retryDelay = delay
+	}
+}
+
+// WithLogger sets the logger used for errors that cannot be returned to the caller, such as failed acknowledgments.
+// Defaults to a no-op logger.
+func WithLogger(logger types.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
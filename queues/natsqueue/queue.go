@@ -0,0 +1,106 @@
+package natsqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/slackmgr/types"
+)
+
+var _ types.Queue = (*Queue)(nil)
+
+// Queue is a types.Queue backed by a JetStream stream and pull consumer.
+type Queue struct {
+	*base
+	deadLetter *Queue
+}
+
+// NewQueue creates a new Queue, publishing to the specified subject.
+func NewQueue(publisher Publisher, subject string, consumer Consumer, opts ...Option) *Queue {
+	return &Queue{base: newBase(publisher, subject, consumer, opts)}
+}
+
+// WithDeadLetter sets the queue returned by DeadLetter, and returns q.
+// JetStream has no dead-letter streams, so it is up to the caller to move messages there,
+// for example based on the max deliveries advisories.
+func (q *Queue) WithDeadLetter(deadLetter *Queue) *Queue {
+	q.deadLetter = deadLetter
+	return q
+}
+
+// Name returns the name of the queue, which defaults to the subject.
+func (q *Queue) Name() string {
+	return q.opts.name
+}
+
+// Send publishes a message.
+func (q *Queue) Send(ctx context.Context, body string) error {
+	return q.publish(ctx, &nats.Msg{Subject: q.subject, Data: []byte(body)}, "")
+}
+
+// SendBatch publishes multiple messages. JetStream has no batch publish, so each message is
+// published and acknowledged by the server before the next one.
+func (q *Queue) SendBatch(ctx context.Context, bodies []string) error {
+	for i, body := range bodies {
+		if err := q.Send(ctx, body); err != nil {
+			return fmt.Errorf("messages[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Receive receives messages continuously, to the specified sink channel, until the context is canceled.
+// Failed fetch requests are logged and retried. The sink channel is closed when the function returns.
+func (q *Queue) Receive(ctx context.Context, sinkCh chan<- *types.QueueItem) error {
+	defer close(sinkCh)
+
+	return q.receiveLoop(ctx, func(m jetstream.Msg) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case sinkCh <- q.item(m):
+			return true
+		}
+	})
+}
+
+// ReceiveBatch waits until at least one message is available, and returns up to maxItems messages.
+func (q *Queue) ReceiveBatch(ctx context.Context, maxItems int) ([]*types.QueueItem, error) {
+	messages, err := q.fetchBatch(ctx, maxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.QueueItem, len(messages))
+	for i, m := range messages {
+		items[i] = q.item(m)
+	}
+
+	return items, nil
+}
+
+// DeadLetter returns the queue set with WithDeadLetter, or types.ErrDeadLetterNotSupported if there is none.
+func (q *Queue) DeadLetter() (types.Queue, error) {
+	if q.deadLetter == nil {
+		return nil, types.ErrDeadLetterNotSupported
+	}
+
+	return q.deadLetter, nil
+}
+
+func (q *Queue) item(m jetstream.Msg) *types.QueueItem {
+	return &types.QueueItem{
+		MessageID:        messageID(m),
+		ReceiveTimestamp: time.Now(),
+		Body:             string(m.Data()),
+		Ack:              q.ack(m),
+		Nack:             q.nack(m),
+		NackWithDelay:    q.nackWithDelay(m),
+		ReceiveCount:     receiveCount(m),
+		Extend:           extend(m),
+	}
+}
@@ -0,0 +1,149 @@
+package pubsubqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2"
+	"github.com/slackmgr/types"
+)
+
+var _ types.FifoQueue = (*FifoQueue)(nil)
+
+var errEmptyChannelID = errors.New("slackChannelID is required for FIFO queues")
+
+// FifoQueue is a types.FifoQueue backed by a Pub/Sub topic and a subscription with message ordering enabled.
+type FifoQueue struct {
+	*base
+	deadLetter *FifoQueue
+}
+
+// NewFifoQueue creates a new FifoQueue. Message ordering is enabled on the publisher.
+// The subscriber is started by the first Receive or ReceiveBatch call, and runs until Close is called.
+func NewFifoQueue(publisher *pubsub.Publisher, subscriber *pubsub.Subscriber, opts ...Option) *FifoQueue {
+	if publisher != nil {
+		publisher.EnableMessageOrdering = true
+	}
+
+	return &FifoQueue{base: newBase(publisher, subscriber, opts)}
+}
+
+// WithDeadLetter sets the queue returned by DeadLetter, and returns q.
+// It should refer to the dead-letter topic of the subscription, and a subscription to that topic.
+func (q *FifoQueue) WithDeadLetter(deadLetter *FifoQueue) *FifoQueue {
+	q.deadLetter = deadLetter
+	return q
+}
+
+// Name returns the name of the queue.
+func (q *FifoQueue) Name() string {
+	return q.opts.name
+}
+
+// Send publishes a message with the Slack channel ID as ordering key.
+func (q *FifoQueue) Send(ctx context.Context, slackChannelID, dedupID, body string) error {
+	m, err := fifoMessage(slackChannelID, dedupID, body)
+	if err != nil {
+		return err
+	}
+
+	return q.publish(ctx, []*pubsub.Message{m})
+}
+
+// SendBatch publishes multiple messages, in order.
+func (q *FifoQueue) SendBatch(ctx context.Context, messages []*types.FifoQueueMessage) error {
+	pubsubMessages := make([]*pubsub.Message, len(messages))
+
+	for i, m := range messages {
+		pm, err := fifoMessage(m.SlackChannelID, m.DedupID, m.Body)
+		if err != nil {
+			return fmt.Errorf("messages[%d]: %w", i, err)
+		}
+
+		pubsubMessages[i] = pm
+	}
+
+	return q.publish(ctx, pubsubMessages)
+}
+
+// Receive receives messages continuously, to the specified sink channel, until the context is canceled
+// or the subscriber stops. The sink channel is closed when the function returns.
+func (q *FifoQueue) Receive(ctx context.Context, sinkCh chan<- *types.FifoQueueItem) error {
+	defer close(sinkCh)
+
+	for {
+		m, err := q.next(ctx)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			m.Nack()
+			return ctx.Err()
+		case sinkCh <- fifoItem(m):
+		}
+	}
+}
+
+// ReceiveBatch waits until at least one message is available, and returns up to maxItems messages,
+// collecting messages for at most the batch window after the first one.
+func (q *FifoQueue) ReceiveBatch(ctx context.Context, maxItems int) ([]*types.FifoQueueItem, error) {
+	messages, err := q.nextBatch(ctx, maxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.FifoQueueItem, len(messages))
+	for i, m := range messages {
+		items[i] = fifoItem(m)
+	}
+
+	return items, nil
+}
+
+// DeadLetter returns the queue set with WithDeadLetter, or types.ErrDeadLetterNotSupported if there is none.
+func (q *FifoQueue) DeadLetter() (types.FifoQueue, error) {
+	if q.deadLetter == nil {
+		return nil, types.ErrDeadLetterNotSupported
+	}
+
+	return q.deadLetter, nil
+}
+
+func fifoMessage(slackChannelID, dedupID, body string) (*pubsub.Message, error) {
+	if slackChannelID == "" {
+		return nil, errEmptyChannelID
+	}
+
+	m := &pubsub.Message{
+		Data:        []byte(body),
+		OrderingKey: slackChannelID,
+		Attributes:  map[string]string{SlackChannelIDAttribute: slackChannelID},
+	}
+
+	if dedupID != "" {
+		m.Attributes[DedupIDAttribute] = dedupID
+	}
+
+	return m, nil
+}
+
+func fifoItem(m *pubsub.Message) *types.FifoQueueItem {
+	channelID := m.Attributes[SlackChannelIDAttribute]
+	if channelID == "" {
+		channelID = m.OrderingKey
+	}
+
+	return &types.FifoQueueItem{
+		MessageID:        m.ID,
+		SlackChannelID:   channelID,
+		ReceiveTimestamp: time.Now(),
+		Body:             string(m.Data),
+		Ack:              m.Ack,
+		Nack:             m.Nack,
+		ReceiveCount:     receiveCount(m),
+	}
+}
@@ -0,0 +1,45 @@
+package pubsubqueue
+
+import (
+	"time"
+)
+
+// DefaultBatchWindow is the default time ReceiveBatch waits for more messages, after the first one is received.
+const DefaultBatchWindow = 100 * time.Millisecond
+
+// Option configures a queue.
+type Option func(*options)
+
+type options struct {
+	name        string
+	batchWindow time.Duration
+}
+
+func newOptions(name string, opts []Option) *options {
+	o := &options{
+		name:        name,
+		batchWindow: DefaultBatchWindow,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithName sets the name returned by Name (for logging purposes).
+// Defaults to the subscription name, or the topic name if there is no subscriber.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithBatchWindow sets the time ReceiveBatch waits for more messages, after the first one is received.
+// Defaults to DefaultBatchWindow.
+func WithBatchWindow(window time.Duration) Option {
+	return func(o *options) {
+		o.batchWindow = window
+	}
+}
@@ -0,0 +1,205 @@
+// Package pubsubqueue implements the types.FifoQueue and types.Queue interfaces on top of Google Cloud Pub/Sub.
+//
+// The package is a separate module, so that the core module does not depend on the Google Cloud SDK.
+// FIFO queues publish with the Slack channel ID as ordering key, so the subscription must have message ordering
+// enabled. The channel ID and dedup ID are also carried in the SlackChannelID and DedupID message attributes:
+//
+//	client, err := pubsub.NewClient(ctx, projectID)
+//	queue := pubsubqueue.NewFifoQueue(client.Publisher("alerts"), client.Subscriber("alerts-sub"))
+//
+// Either the publisher or the subscriber may be nil, for send-only or receive-only queues.
+// Close stops the subscriber. Pub/Sub extends the lease of received messages automatically (up to ReceiveSettings.MaxExtension of the subscriber),
+// so the Extend function of the queue items is nil. Delayed redelivery is configured by the retry policy of the
+// subscription, so NackWithDelay is nil as well. ReceiveCount is only set if the subscription has a dead-letter policy.
+// Pub/Sub does not deduplicate messages by the dedup ID.
+package pubsubqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2"
+)
+
+const (
+	// SlackChannelIDAttribute is the name of the message attribute carrying the Slack channel ID of FIFO messages.
+	SlackChannelIDAttribute = "SlackChannelID"
+
+	// DedupIDAttribute is the name of the message attribute carrying the dedup ID of FIFO messages.
+	DedupIDAttribute = "DedupID"
+)
+
+var (
+	errNoPublisher  = errors.New("queue has no publisher")
+	errNoSubscriber = errors.New("queue has no subscriber")
+)
+
+// base holds the logic shared by FifoQueue and Queue.
+type base struct {
+	publisher  *pubsub.Publisher
+	subscriber *pubsub.Subscriber
+	opts       *options
+
+	startOnce sync.Once
+	stop      context.CancelFunc
+	msgCh     chan *pubsub.Message
+	done      chan struct{}
+	err       error
+}
+
+func newBase(publisher *pubsub.Publisher, subscriber *pubsub.Subscriber, opts []Option) *base {
+	name := ""
+
+	switch {
+	case subscriber != nil:
+		name = subscriber.String()
+	case publisher != nil:
+		name = publisher.String()
+	}
+
+	return &base{
+		publisher:  publisher,
+		subscriber: subscriber,
+		opts:       newOptions(name, opts),
+		msgCh:      make(chan *pubsub.Message),
+		done:       make(chan struct{}),
+	}
+}
+
+// publish publishes the messages, and waits for all of them to be acknowledged by the server.
+// The Pub/Sub client batches the messages internally. Ordering keys that failed are resumed,
+// so that later messages with the same key can be published.
+func (b *base) publish(ctx context.Context, messages []*pubsub.Message) error {
+	if b.publisher == nil {
+		return errNoPublisher
+	}
+
+	results := make([]*pubsub.PublishResult, len(messages))
+	for i, m := range messages {
+		results[i] = b.publisher.Publish(ctx, m)
+	}
+
+	var firstErr error
+
+	for i, r := range results {
+		if _, err := r.Get(ctx); err != nil {
+			if messages[i].OrderingKey != "" {
+				b.publisher.ResumePublish(messages[i].OrderingKey)
+			}
+
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to publish message to %s: %w", b.opts.name, err)
+
+				if len(messages) > 1 {
+					firstErr = fmt.Errorf("messages[%d]: %w", i, firstErr)
+				}
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// receiveCount returns the delivery attempt of the message, which is only set if the subscription has
+// a dead-letter policy, or 0.
+func receiveCount(m *pubsub.Message) int {
+	if m.DeliveryAttempt == nil {
+		return 0
+	}
+
+	return *m.DeliveryAttempt
+}
+
+// start starts the subscriber on first use. The subscriber runs until Close is called or it fails,
+// passing messages to msgCh. Messages that are not consumed before the subscriber stops are nacked.
+func (b *base) start() {
+	b.startOnce.Do(func() {
+		if b.subscriber == nil {
+			b.err = errNoSubscriber
+			close(b.done)
+
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		b.stop = cancel
+
+		go func() {
+			defer close(b.done)
+
+			err := b.subscriber.Receive(ctx, func(callbackCtx context.Context, m *pubsub.Message) {
+				select {
+				case b.msgCh <- m:
+				case <-callbackCtx.Done():
+					m.Nack()
+				}
+			})
+
+			if err == nil {
+				err = fmt.Errorf("receiving from %s stopped", b.opts.name)
+			} else {
+				err = fmt.Errorf("failed to receive messages from %s: %w", b.opts.name, err)
+			}
+
+			b.err = err
+		}()
+	})
+}
+
+// next returns the next received message, waiting until one is available, the context is canceled or the subscriber stops.
+func (b *base) next(ctx context.Context) (*pubsub.Message, error) {
+	b.start()
+
+	select {
+	case m := <-b.msgCh:
+		return m, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-b.done:
+		return nil, b.err
+	}
+}
+
+// nextBatch waits until at least one message is available, and then collects more messages
+// until maxItems is reached or the batch window has passed.
+func (b *base) nextBatch(ctx context.Context, maxItems int) ([]*pubsub.Message, error) {
+	m, err := b.next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []*pubsub.Message{m}
+
+	window := time.NewTimer(b.opts.batchWindow)
+	defer window.Stop()
+
+	for len(messages) < maxItems {
+		select {
+		case m := <-b.msgCh:
+			messages = append(messages, m)
+		case <-window.C:
+			return messages, nil
+		case <-ctx.Done():
+			return messages, nil
+		case <-b.done:
+			return messages, nil
+		}
+	}
+
+	return messages, nil
+}
+
+// Close stops the subscriber, if it was started. Messages delivered by Pub/Sub but not yet received are nacked,
+// while messages already received must still be acked or nacked. Close does not wait for the subscriber to stop.
+func (b *base) Close() error {
+	b.start()
+
+	if b.stop != nil {
+		b.stop()
+	}
+
+	return nil
+}
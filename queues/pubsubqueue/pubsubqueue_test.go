@@ -0,0 +1,198 @@
+package pubsubqueue_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2"
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/v2/pstest"
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/queues/pubsubqueue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const project = "test-project"
+
+// newClient starts a fake Pub/Sub server, and creates a topic and an ordered subscription with the specified name.
+func newClient(t *testing.T, name string) (*pubsub.Client, *pstest.Server) {
+	t.Helper()
+
+	srv := pstest.NewServer()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	conn, err := grpc.NewClient(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx := context.Background()
+
+	client, err := pubsub.NewClient(ctx, project, option.WithGRPCConn(conn))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	topic := fmt.Sprintf("projects/%s/topics/%s", project, name)
+
+	_, err = client.TopicAdminClient.CreateTopic(ctx, &pubsubpb.Topic{Name: topic})
+	require.NoError(t, err)
+
+	_, err = client.SubscriptionAdminClient.CreateSubscription(ctx, &pubsubpb.Subscription{
+		Name:                  fmt.Sprintf("projects/%s/subscriptions/%s", project, name),
+		Topic:                 topic,
+		EnableMessageOrdering: true,
+		AckDeadlineSeconds:    10,
+	})
+	require.NoError(t, err)
+
+	return client, srv
+}
+
+func TestFifoQueue(t *testing.T) {
+	t.Parallel()
+
+	client, srv := newClient(t, "alerts")
+	q := pubsubqueue.NewFifoQueue(client.Publisher("alerts"), client.Subscriber("alerts"), pubsubqueue.WithBatchWindow(50*time.Millisecond))
+
+	assert.Equal(t, "projects/test-project/subscriptions/alerts", q.Name())
+
+	ctx := context.Background()
+
+	require.NoError(t, q.Send(ctx, "C123", "dedup-1", "b1"))
+	require.NoError(t, q.SendBatch(ctx, []*types.FifoQueueMessage{
+		{SlackChannelID: "C123", Body: "b2"},
+		{SlackChannelID: "C456", DedupID: "dedup-3", Body: "b3"},
+	}))
+
+	published := map[string]*pstest.Message{}
+	for _, m := range srv.Messages() {
+		published[string(m.Data)] = m
+	}
+
+	require.Len(t, published, 3)
+	assert.Equal(t, "C123", published["b1"].OrderingKey)
+	assert.Equal(t, map[string]string{"SlackChannelID": "C123", "DedupID": "dedup-1"}, published["b1"].Attributes)
+	assert.Equal(t, map[string]string{"SlackChannelID": "C123"}, published["b2"].Attributes)
+	assert.Equal(t, "C456", published["b3"].OrderingKey)
+
+	require.Error(t, q.Send(ctx, "", "", "b"))
+
+	err := q.SendBatch(ctx, []*types.FifoQueueMessage{{Body: "b"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "messages[0]")
+
+	received := map[string]*types.FifoQueueItem{}
+
+	for len(received) < 3 {
+		items, err := q.ReceiveBatch(ctx, 10)
+		require.NoError(t, err)
+
+		for _, item := range items {
+			received[item.Body] = item
+			item.Ack()
+		}
+	}
+
+	assert.Equal(t, "C123", received["b1"].SlackChannelID)
+	assert.Equal(t, "C456", received["b3"].SlackChannelID)
+	assert.NotEmpty(t, received["b1"].MessageID)
+	assert.Nil(t, received["b1"].Extend)
+
+	_, err = q.DeadLetter()
+	require.ErrorIs(t, err, types.ErrDeadLetterNotSupported)
+
+	dlq := pubsubqueue.NewFifoQueue(nil, nil)
+	q.WithDeadLetter(dlq)
+
+	result, err := q.DeadLetter()
+	require.NoError(t, err)
+	assert.Same(t, dlq, result)
+
+	require.NoError(t, q.Close())
+}
+
+func TestFifoQueueReceive(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newClient(t, "alerts")
+	q := pubsubqueue.NewFifoQueue(client.Publisher("alerts"), client.Subscriber("alerts"))
+	t.Cleanup(func() { _ = q.Close() })
+
+	require.NoError(t, q.Send(context.Background(), "C123", "", "b1"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sinkCh := make(chan *types.FifoQueueItem)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- q.Receive(ctx, sinkCh)
+	}()
+
+	item := <-sinkCh
+	assert.Equal(t, "b1", item.Body)
+	assert.Equal(t, "C123", item.SlackChannelID)
+	item.Ack()
+
+	cancel()
+
+	require.ErrorIs(t, <-errCh, context.Canceled)
+
+	_, ok := <-sinkCh
+	assert.False(t, ok)
+}
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	client, srv := newClient(t, "callbacks")
+	q := pubsubqueue.NewQueue(client.Publisher("callbacks"), client.Subscriber("callbacks"), pubsubqueue.WithName("callbacks"))
+
+	assert.Equal(t, "callbacks", q.Name())
+
+	ctx := context.Background()
+
+	require.NoError(t, q.Send(ctx, "b1"))
+	require.NoError(t, q.SendBatch(ctx, []string{"b2", "b3"}))
+	assert.Len(t, srv.Messages(), 3)
+
+	received := 0
+
+	for received < 3 {
+		items, err := q.ReceiveBatch(ctx, 2)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(items), 2)
+
+		for _, item := range items {
+			item.Ack()
+		}
+
+		received += len(items)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	_, err := q.ReceiveBatch(timeoutCtx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.NoError(t, q.Close())
+
+	_, err = q.ReceiveBatch(ctx, 1)
+	require.Error(t, err)
+}
+
+func TestNilPublisherAndSubscriber(t *testing.T) {
+	t.Parallel()
+
+	q := pubsubqueue.NewQueue(nil, nil)
+
+	require.Error(t, q.Send(context.Background(), "b"))
+
+	_, err := q.ReceiveBatch(context.Background(), 1)
+	require.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package pubsubqueue
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2"
+	"github.com/slackmgr/types"
+)
+
+var _ types.Queue = (*Queue)(nil)
+
+// Queue is a types.Queue backed by a Pub/Sub topic and subscription.
+type Queue struct {
+	*base
+	deadLetter *Queue
+}
+
+// NewQueue creates a new Queue.
+// The subscriber is started by the first Receive or ReceiveBatch call, and runs until Close is called.
+func NewQueue(publisher *pubsub.Publisher, subscriber *pubsub.Subscriber, opts ...Option) *Queue {
+	return &Queue{base: newBase(publisher, subscriber, opts)}
+}
+
+// WithDeadLetter sets the queue returned by DeadLetter, and returns q.
+// It should refer to the dead-letter topic of the subscription, and a subscription to that topic.
+func (q *Queue) WithDeadLetter(deadLetter *Queue) *Queue {
+	q.deadLetter = deadLetter
+	return q
+}
+
+// Name returns the name of the queue.
+func (q *Queue) Name() string {
+	return q.opts.name
+}
+
+// Send publishes a message.
+func (q *Queue) Send(ctx context.Context, body string) error {
+	return q.publish(ctx, []*pubsub.Message{{Data: []byte(body)}})
+}
+
+// SendBatch publishes multiple messages.
+func (q *Queue) SendBatch(ctx context.Context, bodies []string) error {
+	messages := make([]*pubsub.Message, len(bodies))
+	for i, body := range bodies {
+		messages[i] = &pubsub.Message{Data: []byte(body)}
+	}
+
+	return q.publish(ctx, messages)
+}
+
+// Receive receives messages continuously, to the specified sink channel, until the context is canceled
+// or the subscriber stops. The sink channel is closed when the function returns.
+func (q *Queue) Receive(ctx context.Context, sinkCh chan<- *types.QueueItem) error {
+	defer close(sinkCh)
+
+	for {
+		m, err := q.next(ctx)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			m.Nack()
+			return ctx.Err()
+		case sinkCh <- item(m):
+		}
+	}
+}
+
+// ReceiveBatch waits until at least one message is available, and returns up to maxItems messages,
+// collecting messages for at most the batch window after the first one.
+func (q *Queue) ReceiveBatch(ctx context.Context, maxItems int) ([]*types.QueueItem, error) {
+	messages, err := q.nextBatch(ctx, maxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.QueueItem, len(messages))
+	for i, m := range messages {
+		items[i] = item(m)
+	}
+
+	return items, nil
+}
+
+// DeadLetter returns the queue set with WithDeadLetter, or types.ErrDeadLetterNotSupported if there is none.
+func (q *Queue) DeadLetter() (types.Queue, error) {
+	if q.deadLetter == nil {
+		return nil, types.ErrDeadLetterNotSupported
+	}
+
+	return q.deadLetter, nil
+}
+
+func item(m *pubsub.Message) *types.QueueItem {
+	return &types.QueueItem{
+		MessageID:        m.ID,
+		ReceiveTimestamp: time.Now(),
+		Body:             string(m.Data),
+		Ack:              m.Ack,
+		Nack:             m.Nack,
+		ReceiveCount:     receiveCount(m),
+	}
+}
@@ -0,0 +1,159 @@
+package sqsqueue_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// fakeSQS is an in-memory fake of the SQS API, recording the calls made.
+type fakeSQS struct {
+	mu          sync.Mutex
+	sent        []*sqs.SendMessageInput
+	batches     []*sqs.SendMessageBatchInput
+	receives    []*sqs.ReceiveMessageInput
+	deleted     []string
+	visibility  map[string]int32
+	messages    []sqstypes.Message
+	attributes  map[string]string
+	queueURLs   map[string]string
+	failBatchID string
+	receiveErr  error
+}
+
+func newFakeSQS() *fakeSQS {
+	return &fakeSQS{visibility: map[string]int32{}, attributes: map[string]string{}, queueURLs: map[string]string{}}
+}
+
+func (f *fakeSQS) SendMessage(_ context.Context, params *sqs.SendMessageInput, _ ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sent = append(f.sent, params)
+
+	return &sqs.SendMessageOutput{MessageId: aws.String(strconv.Itoa(len(f.sent)))}, nil
+}
+
+func (f *fakeSQS) SendMessageBatch(_ context.Context, params *sqs.SendMessageBatchInput, _ ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Copy the entries, since the caller reuses the backing array.
+	params.Entries = append([]sqstypes.SendMessageBatchRequestEntry(nil), params.Entries...)
+	f.batches = append(f.batches, params)
+
+	out := &sqs.SendMessageBatchOutput{}
+
+	for _, e := range params.Entries {
+		if aws.ToString(e.MessageBody) == f.failBatchID {
+			out.Failed = append(out.Failed, sqstypes.BatchResultErrorEntry{Id: e.Id, Code: aws.String("InvalidMessageContents"), Message: aws.String("bad body")})
+		}
+	}
+
+	return out, nil
+}
+
+func (f *fakeSQS) ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, _ ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	f.mu.Lock()
+	f.receives = append(f.receives, params)
+
+	if f.receiveErr != nil {
+		err := f.receiveErr
+		f.receiveErr = nil
+		f.mu.Unlock()
+
+		return nil, err
+	}
+
+	n := min(int(params.MaxNumberOfMessages), len(f.messages))
+	messages := f.messages[:n]
+	f.messages = f.messages[n:]
+	f.mu.Unlock()
+
+	if n == 0 {
+		// Simulate a (short) long polling wait.
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	return &sqs.ReceiveMessageOutput{Messages: messages}, nil
+}
+
+func (f *fakeSQS) DeleteMessage(_ context.Context, params *sqs.DeleteMessageInput, _ ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.deleted = append(f.deleted, aws.ToString(params.ReceiptHandle))
+
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+func (f *fakeSQS) DeleteMessageBatch(_ context.Context, params *sqs.DeleteMessageBatchInput, _ ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := &sqs.DeleteMessageBatchOutput{}
+
+	for _, e := range params.Entries {
+		if aws.ToString(e.ReceiptHandle) == f.failBatchID {
+			out.Failed = append(out.Failed, sqstypes.BatchResultErrorEntry{Id: e.Id, Code: aws.String("ReceiptHandleIsInvalid"), Message: aws.String("bad handle")})
+			continue
+		}
+
+		f.deleted = append(f.deleted, aws.ToString(e.ReceiptHandle))
+	}
+
+	return out, nil
+}
+
+func (f *fakeSQS) ChangeMessageVisibility(_ context.Context, params *sqs.ChangeMessageVisibilityInput, _ ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.visibility[aws.ToString(params.ReceiptHandle)] = params.VisibilityTimeout
+
+	return &sqs.ChangeMessageVisibilityOutput{}, nil
+}
+
+func (f *fakeSQS) GetQueueAttributes(_ context.Context, _ *sqs.GetQueueAttributesInput, _ ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return &sqs.GetQueueAttributesOutput{Attributes: f.attributes}, nil
+}
+
+func (f *fakeSQS) GetQueueUrl(_ context.Context, params *sqs.GetQueueUrlInput, _ ...func(*sqs.Options)) (*sqs.GetQueueUrlOutput, error) { //nolint:revive // Matches the SQS client method name.
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	url, ok := f.queueURLs[aws.ToString(params.QueueOwnerAWSAccountId)+"/"+aws.ToString(params.QueueName)]
+	if !ok {
+		return nil, errors.New("queue does not exist")
+	}
+
+	return &sqs.GetQueueUrlOutput{QueueUrl: aws.String(url)}, nil
+}
+
+func (f *fakeSQS) addMessage(id, body, groupID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	m := sqstypes.Message{
+		MessageId:     aws.String(id),
+		Body:          aws.String(body),
+		ReceiptHandle: aws.String("rh-" + id),
+	}
+
+	m.Attributes = map[string]string{string(sqstypes.MessageSystemAttributeNameApproximateReceiveCount): "2"}
+
+	if groupID != "" {
+		m.Attributes[string(sqstypes.MessageSystemAttributeNameMessageGroupId)] = groupID
+	}
+
+	f.messages = append(f.messages, m)
+}
@@ -0,0 +1,154 @@
+package sqsqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/slackmgr/types"
+)
+
+var (
+	_ types.FifoQueue  = (*FifoQueue)(nil)
+	_ types.BatchAcker = (*FifoQueue)(nil)
+)
+
+// FifoQueue is a types.FifoQueue backed by an SQS FIFO queue.
+//
+// The Slack channel ID is used as message group ID, and the dedup ID (if any) as message deduplication ID.
+// If the dedup ID is empty, the queue must have content-based deduplication enabled.
+type FifoQueue struct {
+	*base
+}
+
+// NewFifoQueue creates a new FifoQueue for the SQS FIFO queue with the specified URL.
+func NewFifoQueue(client API, queueURL string, opts ...Option) *FifoQueue {
+	return &FifoQueue{base: newBase(client, queueURL, opts)}
+}
+
+// Name returns the name of the queue, which defaults to the queue URL.
+func (q *FifoQueue) Name() string {
+	return q.opts.name
+}
+
+// Send sends a message related to the specified Slack channel.
+func (q *FifoQueue) Send(ctx context.Context, slackChannelID, dedupID, body string) error {
+	if slackChannelID == "" {
+		return errEmptyChannelID
+	}
+
+	if _, err := q.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:               aws.String(q.queueURL),
+		MessageBody:            aws.String(body),
+		MessageGroupId:         aws.String(slackChannelID),
+		MessageDeduplicationId: optionalString(dedupID),
+		MessageAttributes:      channelAttributes(slackChannelID),
+	}); err != nil {
+		return fmt.Errorf("failed to send message to %s: %w", q.opts.name, err)
+	}
+
+	return nil
+}
+
+// SendBatch sends multiple messages, in order, in batches of up to MaxBatchSize messages.
+func (q *FifoQueue) SendBatch(ctx context.Context, messages []*types.FifoQueueMessage) error {
+	entries := make([]sqstypes.SendMessageBatchRequestEntry, len(messages))
+
+	for i, m := range messages {
+		if m.SlackChannelID == "" {
+			return fmt.Errorf("messages[%d]: %w", i, errEmptyChannelID)
+		}
+
+		entries[i] = sqstypes.SendMessageBatchRequestEntry{
+			MessageBody:            aws.String(m.Body),
+			MessageGroupId:         aws.String(m.SlackChannelID),
+			MessageDeduplicationId: optionalString(m.DedupID),
+			MessageAttributes:      channelAttributes(m.SlackChannelID),
+		}
+	}
+
+	return q.sendBatch(ctx, entries)
+}
+
+// Receive receives messages continuously, to the specified sink channel, until the context is canceled.
+// Failed receive calls are logged and retried. The sink channel is closed when the function returns.
+func (q *FifoQueue) Receive(ctx context.Context, sinkCh chan<- *types.FifoQueueItem) error {
+	defer close(sinkCh)
+
+	return q.receiveLoop(ctx, func(m sqstypes.Message) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case sinkCh <- q.item(m):
+			return true
+		}
+	})
+}
+
+// ReceiveBatch waits until at least one message is available, and returns up to maxItems messages.
+// At most MaxBatchSize messages are returned, regardless of maxItems.
+func (q *FifoQueue) ReceiveBatch(ctx context.Context, maxItems int) ([]*types.FifoQueueItem, error) {
+	messages, err := q.receiveBatch(ctx, maxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.FifoQueueItem, len(messages))
+	for i, m := range messages {
+		items[i] = q.item(m)
+	}
+
+	return items, nil
+}
+
+// DeadLetter returns the dead-letter queue configured in the redrive policy of the queue.
+// types.ErrDeadLetterNotSupported is returned if the queue has no redrive policy.
+func (q *FifoQueue) DeadLetter() (types.FifoQueue, error) {
+	dlq, err := q.deadLetter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &FifoQueue{base: dlq}, nil
+}
+
+func (q *FifoQueue) item(m sqstypes.Message) *types.FifoQueueItem {
+	channelID := m.Attributes[string(sqstypes.MessageSystemAttributeNameMessageGroupId)]
+
+	if attr, ok := m.MessageAttributes[SlackChannelIDAttribute]; ok && aws.ToString(attr.StringValue) != "" {
+		channelID = aws.ToString(attr.StringValue)
+	}
+
+	return &types.FifoQueueItem{
+		MessageID:        aws.ToString(m.MessageId),
+		SlackChannelID:   channelID,
+		ReceiveTimestamp: time.Now(),
+		Body:             aws.ToString(m.Body),
+		Ack:              q.ack(m),
+		Nack:             q.nack(m),
+		NackWithDelay:    q.nackWithDelay(m),
+		ReceiveCount:     receiveCount(m),
+		Extend:           q.extend(m),
+		AckToken:         aws.ToString(m.ReceiptHandle),
+	}
+}
+
+func channelAttributes(slackChannelID string) map[string]sqstypes.MessageAttributeValue {
+	return map[string]sqstypes.MessageAttributeValue{
+		SlackChannelIDAttribute: {
+			DataType:    aws.String("String"),
+			StringValue: aws.String(slackChannelID),
+		},
+	}
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return aws.String(s)
+}
@@ -0,0 +1,93 @@
+package sqsqueue
+
+import (
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	// DefaultWaitTimeSeconds is the default long polling wait time of receive calls (the SQS maximum).
+	DefaultWaitTimeSeconds = 20
+
+	// DefaultAckTimeout is the default timeout of acknowledgment, visibility and dead-letter lookup calls,
+	// which do not take a context from the caller.
+	DefaultAckTimeout = 10 * time.Second
+
+	// DefaultRetryDelay is the default delay before Receive retries after a failed receive call.
+	DefaultRetryDelay = time.Second
+)
+
+// Option configures a queue.
+type Option func(*options)
+
+type options struct {
+	name              string
+	waitTimeSeconds   int32
+	visibilityTimeout time.Duration
+	ackTimeout        time.Duration
+	retryDelay        time.Duration
+	logger            types.Logger
+}
+
+func newOptions(queueURL string, opts []Option) *options {
+	o := &options{
+		name:            queueURL,
+		waitTimeSeconds: DefaultWaitTimeSeconds,
+		ackTimeout:      DefaultAckTimeout,
+		retryDelay:      DefaultRetryDelay,
+		logger:          &types.NoopLogger{},
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithName sets the name returned by Name (for logging purposes). Defaults to the queue URL.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithWaitTimeSeconds sets the long polling wait time of receive calls, between 0 and 20 seconds.
+// Defaults to DefaultWaitTimeSeconds.
+func WithWaitTimeSeconds(seconds int) Option {
+	return func(o *options) {
+		o.waitTimeSeconds = int32(min(max(seconds, 0), DefaultWaitTimeSeconds)) // #nosec G115 -- clamped to [0, 20]
+	}
+}
+
+// WithVisibilityTimeout overrides the visibility timeout of received messages.
+// If not set, the visibility timeout configured on the queue is used.
+func WithVisibilityTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.visibilityTimeout = timeout
+	}
+}
+
+// WithAckTimeout sets the timeout of acknowledgment, visibility and dead-letter lookup calls.
+// Defaults to DefaultAckTimeout.
+func WithAckTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.ackTimeout = timeout
+	}
+}
+
+// WithRetryDelay sets the delay before Receive retries after a failed receive call. Defaults to DefaultRetryDelay.
+func WithRetryDelay(delay time.Duration) Option {
+	return func(o *options) {
+		o.retryDelay = delay
+	}
+}
+
+// WithLogger sets the logger used for errors that cannot be returned to the caller, such as failed acknowledgments.
+// Defaults to a no-op logger.
+func WithLogger(logger types.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
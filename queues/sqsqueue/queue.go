@@ -0,0 +1,111 @@
+package sqsqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/slackmgr/types"
+)
+
+var (
+	_ types.Queue      = (*Queue)(nil)
+	_ types.BatchAcker = (*Queue)(nil)
+)
+
+// Queue is a types.Queue backed by an SQS standard queue.
+type Queue struct {
+	*base
+}
+
+// NewQueue creates a new Queue for the SQS standard queue with the specified URL.
+func NewQueue(client API, queueURL string, opts ...Option) *Queue {
+	return &Queue{base: newBase(client, queueURL, opts)}
+}
+
+// Name returns the name of the queue, which defaults to the queue URL.
+func (q *Queue) Name() string {
+	return q.opts.name
+}
+
+// Send sends a message.
+func (q *Queue) Send(ctx context.Context, body string) error {
+	if _, err := q.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(q.queueURL),
+		MessageBody: aws.String(body),
+	}); err != nil {
+		return fmt.Errorf("failed to send message to %s: %w", q.opts.name, err)
+	}
+
+	return nil
+}
+
+// SendBatch sends multiple messages, in batches of up to MaxBatchSize messages.
+func (q *Queue) SendBatch(ctx context.Context, bodies []string) error {
+	entries := make([]sqstypes.SendMessageBatchRequestEntry, len(bodies))
+
+	for i, body := range bodies {
+		entries[i] = sqstypes.SendMessageBatchRequestEntry{MessageBody: aws.String(body)}
+	}
+
+	return q.sendBatch(ctx, entries)
+}
+
+// Receive receives messages continuously, to the specified sink channel, until the context is canceled.
+// Failed receive calls are logged and retried. The sink channel is closed when the function returns.
+func (q *Queue) Receive(ctx context.Context, sinkCh chan<- *types.QueueItem) error {
+	defer close(sinkCh)
+
+	return q.receiveLoop(ctx, func(m sqstypes.Message) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case sinkCh <- q.item(m):
+			return true
+		}
+	})
+}
+
+// ReceiveBatch waits until at least one message is available, and returns up to maxItems messages.
+// At most MaxBatchSize messages are returned, regardless of maxItems.
+func (q *Queue) ReceiveBatch(ctx context.Context, maxItems int) ([]*types.QueueItem, error) {
+	messages, err := q.receiveBatch(ctx, maxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.QueueItem, len(messages))
+	for i, m := range messages {
+		items[i] = q.item(m)
+	}
+
+	return items, nil
+}
+
+// DeadLetter returns the dead-letter queue configured in the redrive policy of the queue.
+// types.ErrDeadLetterNotSupported is returned if the queue has no redrive policy.
+func (q *Queue) DeadLetter() (types.Queue, error) {
+	dlq, err := q.deadLetter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Queue{base: dlq}, nil
+}
+
+func (q *Queue) item(m sqstypes.Message) *types.QueueItem {
+	return &types.QueueItem{
+		MessageID:        aws.ToString(m.MessageId),
+		ReceiveTimestamp: time.Now(),
+		Body:             aws.ToString(m.Body),
+		Ack:              q.ack(m),
+		Nack:             q.nack(m),
+		NackWithDelay:    q.nackWithDelay(m),
+		ReceiveCount:     receiveCount(m),
+		Extend:           q.extend(m),
+		AckToken:         aws.ToString(m.ReceiptHandle),
+	}
+}
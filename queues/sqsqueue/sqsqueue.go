@@ -0,0 +1,326 @@
+// Package sqsqueue implements the types.FifoQueue and types.Queue interfaces on top of Amazon SQS.
+//
+// The package is a separate module, so that the core module does not depend on the AWS SDK.
+// FIFO queues use the Slack channel ID as message group ID, so that messages are ordered per channel,
+// and the channel ID is also carried in the SlackChannelID message attribute. The visibility timeout
+// of received messages can be extended with the Extend function of the queue items:
+//
+//	client := sqs.NewFromConfig(cfg)
+//	queue := sqsqueue.NewFifoQueue(client, "https://sqs.eu-west-1.amazonaws.com/123456789012/alerts.fifo")
+package sqsqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/slackmgr/types"
+)
+
+const (
+	// MaxBatchSize is the maximum number of messages per SQS batch call. Larger batches are split.
+	MaxBatchSize = 10
+
+	// SlackChannelIDAttribute is the name of the message attribute carrying the Slack channel ID of FIFO messages.
+	SlackChannelIDAttribute = "SlackChannelID"
+)
+
+// API is the subset of the SQS client used by the queues. It is implemented by *sqs.Client.
+type API interface {
+	SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+	SendMessageBatch(ctx context.Context, params *sqs.SendMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error)
+	ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+	DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error)
+	DeleteMessageBatch(ctx context.Context, params *sqs.DeleteMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error)
+	ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error)
+	GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+	GetQueueUrl(ctx context.Context, params *sqs.GetQueueUrlInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueUrlOutput, error) //nolint:revive // Matches the SQS client method name.
+}
+
+var _ API = (*sqs.Client)(nil)
+
+// base holds the logic shared by FifoQueue and Queue.
+type base struct {
+	client   API
+	queueURL string
+	opts     *options
+}
+
+func newBase(client API, queueURL string, opts []Option) *base {
+	return &base{
+		client:   client,
+		queueURL: queueURL,
+		opts:     newOptions(queueURL, opts),
+	}
+}
+
+// sendBatch sends the entries in chunks of MaxBatchSize. The entry IDs are the indexes of the entries within each chunk.
+func (b *base) sendBatch(ctx context.Context, entries []sqstypes.SendMessageBatchRequestEntry) error {
+	for start := 0; start < len(entries); start += MaxBatchSize {
+		chunk := entries[start:min(start+MaxBatchSize, len(entries))]
+
+		for i := range chunk {
+			chunk[i].Id = aws.String(strconv.Itoa(i))
+		}
+
+		out, err := b.client.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+			QueueUrl: aws.String(b.queueURL),
+			Entries:  chunk,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send messages to %s: %w", b.opts.name, err)
+		}
+
+		if len(out.Failed) > 0 {
+			failed := out.Failed[0]
+			index, _ := strconv.Atoi(aws.ToString(failed.Id))
+
+			return fmt.Errorf("messages[%d]: failed to send message to %s: %s: %s", start+index, b.opts.name, aws.ToString(failed.Code), aws.ToString(failed.Message))
+		}
+	}
+
+	return nil
+}
+
+// receive makes a single long polling receive call, returning up to maxItems messages (and possibly none).
+func (b *base) receive(ctx context.Context, maxItems int) ([]sqstypes.Message, error) {
+	input := &sqs.ReceiveMessageInput{
+		QueueUrl:              aws.String(b.queueURL),
+		MaxNumberOfMessages:   int32(min(max(maxItems, 1), MaxBatchSize)), // #nosec G115 -- clamped to [1, 10]
+		WaitTimeSeconds:       b.opts.waitTimeSeconds,
+		MessageAttributeNames: []string{SlackChannelIDAttribute},
+		MessageSystemAttributeNames: []sqstypes.MessageSystemAttributeName{
+			sqstypes.MessageSystemAttributeNameMessageGroupId,
+			sqstypes.MessageSystemAttributeNameApproximateReceiveCount,
+		},
+	}
+
+	if b.opts.visibilityTimeout > 0 {
+		input.VisibilityTimeout = int32(b.opts.visibilityTimeout / time.Second) // #nosec G115 -- visibility timeouts are at most 12 hours
+	}
+
+	out, err := b.client.ReceiveMessage(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive messages from %s: %w", b.opts.name, err)
+	}
+
+	return out.Messages, nil
+}
+
+// receiveBatch makes receive calls until at least one message is returned, or the context is canceled.
+func (b *base) receiveBatch(ctx context.Context, maxItems int) ([]sqstypes.Message, error) {
+	for {
+		messages, err := b.receive(ctx, maxItems)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(messages) > 0 {
+			return messages, nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// receiveLoop makes receive calls until the context is canceled, passing each received message to handle.
+// Failed receive calls are logged and retried after the retry delay.
+func (b *base) receiveLoop(ctx context.Context, handle func(sqstypes.Message) bool) error {
+	for {
+		messages, err := b.receive(ctx, MaxBatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			b.opts.logger.Error(err.Error())
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(b.opts.retryDelay):
+			}
+
+			continue
+		}
+
+		for _, m := range messages {
+			if !handle(m) {
+				return ctx.Err()
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// ack returns a function deleting the message from the queue.
+func (b *base) ack(m sqstypes.Message) func() {
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), b.opts.ackTimeout)
+		defer cancel()
+
+		if _, err := b.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+			QueueUrl:      aws.String(b.queueURL),
+			ReceiptHandle: m.ReceiptHandle,
+		}); err != nil {
+			b.opts.logger.Errorf("Failed to ack message %s from %s: %s", aws.ToString(m.MessageId), b.opts.name, err)
+		}
+	}
+}
+
+// AckBatch deletes the messages identified by the ack tokens (the receipt handles), in batches of up to MaxBatchSize.
+func (b *base) AckBatch(ctx context.Context, ackTokens []string) error {
+	for start := 0; start < len(ackTokens); start += MaxBatchSize {
+		chunk := ackTokens[start:min(start+MaxBatchSize, len(ackTokens))]
+
+		entries := make([]sqstypes.DeleteMessageBatchRequestEntry, len(chunk))
+		for i, token := range chunk {
+			entries[i] = sqstypes.DeleteMessageBatchRequestEntry{
+				Id:            aws.String(strconv.Itoa(i)),
+				ReceiptHandle: aws.String(token),
+			}
+		}
+
+		out, err := b.client.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
+			QueueUrl: aws.String(b.queueURL),
+			Entries:  entries,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to ack messages from %s: %w", b.opts.name, err)
+		}
+
+		if len(out.Failed) > 0 {
+			failed := out.Failed[0]
+			index, _ := strconv.Atoi(aws.ToString(failed.Id))
+
+			return fmt.Errorf("ackTokens[%d]: failed to ack message from %s: %s: %s", start+index, b.opts.name, aws.ToString(failed.Code), aws.ToString(failed.Message))
+		}
+	}
+
+	return nil
+}
+
+// nack returns a function making the message visible to other consumers immediately.
+func (b *base) nack(m sqstypes.Message) func() {
+	nackWithDelay := b.nackWithDelay(m)
+
+	return func() {
+		nackWithDelay(0)
+	}
+}
+
+// nackWithDelay returns a function making the message visible to other consumers after the delay,
+// by changing its visibility timeout.
+func (b *base) nackWithDelay(m sqstypes.Message) func(delay time.Duration) {
+	extend := b.extend(m)
+
+	return func(delay time.Duration) {
+		if err := extend(delay); err != nil {
+			b.opts.logger.Errorf("Failed to nack message %s from %s: %s", aws.ToString(m.MessageId), b.opts.name, err)
+		}
+	}
+}
+
+// extend returns a function changing the visibility timeout of the message, counted from now.
+func (b *base) extend(m sqstypes.Message) func(timeout time.Duration) error {
+	return func(timeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), b.opts.ackTimeout)
+		defer cancel()
+
+		if _, err := b.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          aws.String(b.queueURL),
+			ReceiptHandle:     m.ReceiptHandle,
+			VisibilityTimeout: int32(max(timeout, 0) / time.Second), // #nosec G115 -- visibility timeouts are at most 12 hours
+		}); err != nil {
+			return fmt.Errorf("failed to change visibility of message %s: %w", aws.ToString(m.MessageId), err)
+		}
+
+		return nil
+	}
+}
+
+// receiveCount returns the approximate receive count of the message, or 0 if it is not known.
+func receiveCount(m sqstypes.Message) int {
+	count, _ := strconv.Atoi(m.Attributes[string(sqstypes.MessageSystemAttributeNameApproximateReceiveCount)])
+	return count
+}
+
+// deadLetter returns the dead-letter queue, with the options of this queue and the dead-letter queue URL as name.
+func (b *base) deadLetter() (*base, error) {
+	queueURL, err := b.deadLetterURL()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := *b.opts
+	opts.name = queueURL
+
+	return &base{client: b.client, queueURL: queueURL, opts: &opts}, nil
+}
+
+// deadLetterURL returns the URL of the dead-letter queue, as configured by the redrive policy of the queue.
+// types.ErrDeadLetterNotSupported is returned if the queue has no redrive policy.
+func (b *base) deadLetterURL() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.opts.ackTimeout)
+	defer cancel()
+
+	attrs, err := b.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(b.queueURL),
+		AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameRedrivePolicy},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get attributes of %s: %w", b.opts.name, err)
+	}
+
+	policy, ok := attrs.Attributes[string(sqstypes.QueueAttributeNameRedrivePolicy)]
+	if !ok || policy == "" {
+		return "", types.ErrDeadLetterNotSupported
+	}
+
+	var redrive struct {
+		DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+	}
+
+	if err := json.Unmarshal([]byte(policy), &redrive); err != nil {
+		return "", fmt.Errorf("failed to decode redrive policy of %s: %w", b.opts.name, err)
+	}
+
+	account, name, err := parseQueueARN(redrive.DeadLetterTargetArn)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := b.client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
+		QueueName:              aws.String(name),
+		QueueOwnerAWSAccountId: aws.String(account),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL of dead-letter queue %s: %w", name, err)
+	}
+
+	return aws.ToString(out.QueueUrl), nil
+}
+
+// parseQueueARN returns the account ID and queue name of an SQS queue ARN, as in 'arn:aws:sqs:<region>:<account>:<name>'.
+func parseQueueARN(arn string) (string, string, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "sqs" || parts[4] == "" || parts[5] == "" {
+		return "", "", fmt.Errorf("'%s' is not a valid SQS queue ARN", arn)
+	}
+
+	return parts[4], parts[5], nil
+}
+
+var errEmptyChannelID = errors.New("slackChannelID is required for FIFO queues")
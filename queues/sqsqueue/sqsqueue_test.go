@@ -0,0 +1,226 @@
+package sqsqueue_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/queues/sqsqueue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const queueURL = "https://sqs.eu-west-1.amazonaws.com/123456789012/alerts.fifo"
+
+func TestFifoQueueSend(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeSQS()
+	q := sqsqueue.NewFifoQueue(fake, queueURL, sqsqueue.WithName("alerts"))
+
+	assert.Equal(t, "alerts", q.Name())
+	require.NoError(t, q.Send(context.Background(), "C123", "dedup-1", "body"))
+	require.NoError(t, q.Send(context.Background(), "C123", "", "body"))
+
+	require.Len(t, fake.sent, 2)
+	assert.Equal(t, queueURL, aws.ToString(fake.sent[0].QueueUrl))
+	assert.Equal(t, "C123", aws.ToString(fake.sent[0].MessageGroupId))
+	assert.Equal(t, "dedup-1", aws.ToString(fake.sent[0].MessageDeduplicationId))
+	assert.Equal(t, "C123", aws.ToString(fake.sent[0].MessageAttributes[sqsqueue.SlackChannelIDAttribute].StringValue))
+	assert.Nil(t, fake.sent[1].MessageDeduplicationId)
+
+	require.Error(t, q.Send(context.Background(), "", "", "body"))
+}
+
+func TestFifoQueueSendBatch(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeSQS()
+	q := sqsqueue.NewFifoQueue(fake, queueURL)
+
+	messages := make([]*types.FifoQueueMessage, 12)
+	for i := range messages {
+		messages[i] = &types.FifoQueueMessage{SlackChannelID: "C123", DedupID: fmt.Sprintf("d%d", i), Body: fmt.Sprintf("b%d", i)}
+	}
+
+	require.NoError(t, q.SendBatch(context.Background(), messages))
+	require.Len(t, fake.batches, 2)
+	assert.Len(t, fake.batches[0].Entries, sqsqueue.MaxBatchSize)
+	assert.Len(t, fake.batches[1].Entries, 2)
+	assert.Equal(t, "1", aws.ToString(fake.batches[1].Entries[1].Id))
+	assert.Equal(t, "b11", aws.ToString(fake.batches[1].Entries[1].MessageBody))
+
+	fake.failBatchID = "b11"
+	err := q.SendBatch(context.Background(), messages)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "messages[11]")
+	assert.Contains(t, err.Error(), "InvalidMessageContents")
+
+	err = q.SendBatch(context.Background(), []*types.FifoQueueMessage{{Body: "x"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "messages[0]")
+}
+
+func TestFifoQueueReceiveBatch(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeSQS()
+	q := sqsqueue.NewFifoQueue(fake, queueURL, sqsqueue.WithVisibilityTimeout(time.Minute), sqsqueue.WithWaitTimeSeconds(50))
+
+	fake.addMessage("m1", "b1", "C123")
+	fake.addMessage("m2", "b2", "C456")
+
+	items, err := q.ReceiveBatch(context.Background(), 100)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, int32(sqsqueue.MaxBatchSize), fake.receives[0].MaxNumberOfMessages)
+	assert.Equal(t, int32(60), fake.receives[0].VisibilityTimeout)
+	assert.Equal(t, int32(sqsqueue.DefaultWaitTimeSeconds), fake.receives[0].WaitTimeSeconds)
+
+	assert.Equal(t, "m1", items[0].MessageID)
+	assert.Equal(t, "C123", items[0].SlackChannelID)
+	assert.Equal(t, "b1", items[0].Body)
+	assert.False(t, items[0].ReceiveTimestamp.IsZero())
+	assert.Equal(t, 2, items[0].ReceiveCount)
+
+	items[0].Ack()
+	items[1].Nack()
+	require.NoError(t, items[0].Extend(90*time.Second))
+
+	assert.Equal(t, []string{"rh-m1"}, fake.deleted)
+	assert.Equal(t, int32(0), fake.visibility["rh-m2"])
+	assert.Equal(t, int32(90), fake.visibility["rh-m1"])
+
+	items[1].NackWithDelay(30 * time.Second)
+	assert.Equal(t, int32(30), fake.visibility["rh-m2"])
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = q.ReceiveBatch(ctx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFifoQueueReceive(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeSQS()
+	fake.receiveErr = errors.New("throttled")
+	q := sqsqueue.NewFifoQueue(fake, queueURL, sqsqueue.WithRetryDelay(time.Millisecond))
+
+	fake.addMessage("m1", "b1", "C123")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sinkCh := make(chan *types.FifoQueueItem)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- q.Receive(ctx, sinkCh)
+	}()
+
+	item := <-sinkCh
+	assert.Equal(t, "m1", item.MessageID)
+
+	cancel()
+
+	require.ErrorIs(t, <-errCh, context.Canceled)
+
+	_, ok := <-sinkCh
+	assert.False(t, ok)
+}
+
+func TestDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeSQS()
+	q := sqsqueue.NewQueue(fake, queueURL)
+
+	_, err := q.DeadLetter()
+	require.ErrorIs(t, err, types.ErrDeadLetterNotSupported)
+
+	fake.attributes["RedrivePolicy"] = `{"deadLetterTargetArn":"arn:aws:sqs:eu-west-1:123456789012:alerts-dlq","maxReceiveCount":5}`
+	fake.queueURLs["123456789012/alerts-dlq"] = "https://sqs.eu-west-1.amazonaws.com/123456789012/alerts-dlq"
+
+	dlq, err := q.DeadLetter()
+	require.NoError(t, err)
+	assert.Equal(t, "https://sqs.eu-west-1.amazonaws.com/123456789012/alerts-dlq", dlq.Name())
+
+	require.NoError(t, dlq.Send(context.Background(), "body"))
+	assert.Equal(t, "https://sqs.eu-west-1.amazonaws.com/123456789012/alerts-dlq", aws.ToString(fake.sent[0].QueueUrl))
+
+	fake.attributes["RedrivePolicy"] = `{"deadLetterTargetArn":"not-an-arn"}`
+	_, err = q.DeadLetter()
+	require.Error(t, err)
+
+	fifo := sqsqueue.NewFifoQueue(fake, queueURL)
+	fake.attributes["RedrivePolicy"] = `{"deadLetterTargetArn":"arn:aws:sqs:eu-west-1:123456789012:alerts-dlq"}`
+
+	fifoDLQ, err := fifo.DeadLetter()
+	require.NoError(t, err)
+	assert.NotNil(t, fifoDLQ)
+}
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeSQS()
+	q := sqsqueue.NewQueue(fake, queueURL)
+
+	require.NoError(t, q.Send(context.Background(), "body"))
+	assert.Nil(t, fake.sent[0].MessageGroupId)
+	assert.Nil(t, fake.sent[0].MessageAttributes)
+
+	require.NoError(t, q.SendBatch(context.Background(), []string{"a", "b"}))
+	require.Len(t, fake.batches, 1)
+	assert.Len(t, fake.batches[0].Entries, 2)
+
+	fake.addMessage("m1", "b1", "")
+
+	items, err := q.ReceiveBatch(context.Background(), 5)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "b1", items[0].Body)
+	assert.NotNil(t, items[0].Extend)
+}
+
+func TestAckBatch(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeSQS()
+	q := sqsqueue.NewFifoQueue(fake, queueURL)
+
+	for i := range 12 {
+		fake.addMessage(fmt.Sprintf("m%d", i), "body", "C123")
+	}
+
+	batch := types.NewBatchAck(q, 0)
+
+	for len(fake.deleted) < 12 {
+		items, err := q.ReceiveBatch(context.Background(), 10)
+		require.NoError(t, err)
+
+		for _, item := range items {
+			assert.Equal(t, "rh-"+item.MessageID, item.AckToken)
+			require.NoError(t, batch.AddFifoItem(context.Background(), item))
+		}
+
+		require.NoError(t, batch.Flush(context.Background()))
+	}
+
+	assert.Len(t, fake.deleted, 12)
+
+	tokens := make([]string, 12)
+	for i := range tokens {
+		tokens[i] = fmt.Sprintf("rh-x%d", i)
+	}
+
+	fake.failBatchID = "rh-x11"
+
+	err := q.AckBatch(context.Background(), tokens)
+	require.ErrorContains(t, err, "ackTokens[11]")
+	require.ErrorContains(t, err, "ReceiptHandleIsInvalid")
+}
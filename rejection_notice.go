@@ -0,0 +1,117 @@
+package types
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// fieldPathRegex matches the JSON field paths used at the start of validation error messages, such as 'webhook[0].url'.
+var fieldPathRegex = regexp.MustCompile(`^[a-zA-Z]+(\[\d+\])?(\.[a-zA-Z]+(\[\d+\])?)*$`)
+
+// RejectionNotice describes an alert that was rejected or dropped by the Slack Manager after it was accepted,
+// for example by an ignore rule or by validation after queueing. Notices are sent to the RejectionHook, so that
+// otherwise silently dropped alerts become observable to their producers.
+type RejectionNotice struct {
+	// AlertUniqueID is the unique ID of the rejected alert, as returned by Alert.UniqueID.
+	AlertUniqueID string `json:"alertUniqueId"`
+
+	// CorrelationID is the correlation ID of the rejected alert, if any.
+	CorrelationID string `json:"correlationId"`
+
+	// Reason is the reason the alert was rejected.
+	Reason RejectionReason `json:"reason"`
+
+	// Message is a human-readable description of the rejection.
+	Message string `json:"message"`
+
+	// FieldErrors lists the invalid fields, when Reason is RejectionReasonValidationFailed.
+	FieldErrors []FieldError `json:"fieldErrors"`
+
+	// Target is the rejection target declared by the alert producer, if any.
+	Target *RejectionTarget `json:"target"`
+
+	// OccurredAt is the time of the rejection.
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// FieldError is a validation error for a single alert field.
+type FieldError struct {
+	// Field is the JSON path of the invalid field, such as 'webhook[0].url'.
+	// It is empty if the error does not concern a single field.
+	Field string `json:"field"`
+
+	// Message is the validation error message.
+	Message string `json:"message"`
+}
+
+// RejectionHook receives notices about rejected alerts.
+// Implementations typically post the notice to the producer's RejectionTarget, or to a central channel.
+type RejectionHook interface {
+	NotifyRejection(ctx context.Context, notice *RejectionNotice) error
+}
+
+// RejectionHookFunc is an adapter allowing an ordinary function to be used as a RejectionHook.
+type RejectionHookFunc func(ctx context.Context, notice *RejectionNotice) error
+
+// NotifyRejection calls f(ctx, notice).
+func (f RejectionHookFunc) NotifyRejection(ctx context.Context, notice *RejectionNotice) error {
+	return f(ctx, notice)
+}
+
+// NewRejectionNotice creates a rejection notice for the specified alert.
+// If err is not nil, it is used as the notice message. For RejectionReasonValidationFailed, the error is also
+// converted to a FieldError, with the field path taken from the start of the error message.
+func NewRejectionNotice(alert *Alert, reason RejectionReason, err error) *RejectionNotice {
+	notice := &RejectionNotice{
+		Reason:     reason,
+		OccurredAt: time.Now().UTC(),
+	}
+
+	if alert != nil {
+		notice.AlertUniqueID = alert.UniqueID()
+		notice.CorrelationID = alert.CorrelationID
+		notice.Target = alert.RejectionTarget
+	}
+
+	if err != nil {
+		notice.Message = err.Error()
+
+		if reason == RejectionReasonValidationFailed {
+			notice.FieldErrors = []FieldError{NewFieldError(err)}
+		}
+	}
+
+	return notice
+}
+
+// NewFieldError converts a validation error, such as those returned by Alert.Validate, to a FieldError.
+// The field path is taken from the start of the error message, if present (as in "webhook[0].url is too long").
+func NewFieldError(err error) FieldError {
+	if err == nil {
+		return FieldError{}
+	}
+
+	message := err.Error()
+	words := strings.SplitN(message, " ", 3)
+
+	if len(words) >= 2 && fieldPathRegex.MatchString(words[0]) {
+		if strings.ContainsAny(words[0], ".[") || words[1] == "is" || strings.HasPrefix(words[1], "'") {
+			return FieldError{Field: words[0], Message: message}
+		}
+	}
+
+	return FieldError{Message: message}
+}
+
+// RejectionTarget is declared by the alert producer, to receive notices if the alert is rejected or dropped.
+// At least one of SlackChannelID and CallbackURL must be set.
+type RejectionTarget struct {
+	// SlackChannelID is the ID or name of a Slack channel owned by the producer, where rejection notices are posted.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// CallbackURL is an HTTP(S) URL owned by the producer, where rejection notices are posted as JSON.
+	// Maximum length: MaxWebhookURLLength characters.
+	CallbackURL string `json:"callbackUrl"`
+}
@@ -0,0 +1,98 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRejectionReasonValidation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.RejectionReasonIsValid(types.RejectionReasonValidationFailed))
+	assert.True(t, types.RejectionReasonIsValid(types.RejectionReasonIgnored))
+	assert.True(t, types.RejectionReasonIsValid(types.RejectionReasonRateLimited))
+	assert.True(t, types.RejectionReasonIsValid(types.RejectionReasonDuplicate))
+	assert.False(t, types.RejectionReasonIsValid("unknown"))
+	assert.Len(t, types.ValidRejectionReasons(), 4)
+}
+
+func TestNewRejectionNotice(t *testing.T) {
+	t.Parallel()
+
+	a := &types.Alert{
+		Header:          "a",
+		RouteKey:        "b",
+		CorrelationID:   "c",
+		RejectionTarget: &types.RejectionTarget{SlackChannelID: "C123"},
+		Webhooks:        []*types.Webhook{{ID: "x", URL: "https://example.com"}},
+	}
+	a.Clean()
+
+	err := a.Validate()
+	require.Error(t, err)
+
+	notice := types.NewRejectionNotice(a, types.RejectionReasonValidationFailed, err)
+	assert.Equal(t, a.UniqueID(), notice.AlertUniqueID)
+	assert.Equal(t, "c", notice.CorrelationID)
+	assert.Equal(t, types.RejectionReasonValidationFailed, notice.Reason)
+	assert.Equal(t, "webhook[0].buttonText is required", notice.Message)
+	assert.Equal(t, []types.FieldError{{Field: "webhook[0].buttonText", Message: "webhook[0].buttonText is required"}}, notice.FieldErrors)
+	assert.Equal(t, "C123", notice.Target.SlackChannelID)
+	assert.False(t, notice.OccurredAt.IsZero())
+
+	notice = types.NewRejectionNotice(a, types.RejectionReasonIgnored, errors.New("text contains 'maintenance'"))
+	assert.Equal(t, "text contains 'maintenance'", notice.Message)
+	assert.Empty(t, notice.FieldErrors)
+
+	notice = types.NewRejectionNotice(nil, types.RejectionReasonDuplicate, nil)
+	assert.Empty(t, notice.AlertUniqueID)
+	assert.Empty(t, notice.Message)
+}
+
+func TestNewFieldError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, types.FieldError{}, types.NewFieldError(nil))
+	assert.Equal(t, "severity", types.NewFieldError(errors.New("severity 'x' is not valid")).Field)
+	assert.Equal(t, "correlationId", types.NewFieldError(errors.New("correlationId is too long")).Field)
+	assert.Equal(t, "escalation[1].delaySeconds", types.NewFieldError(errors.New("escalation[1].delaySeconds '10' is too low")).Field)
+	assert.Empty(t, types.NewFieldError(errors.New("too many webhooks, expected <=25")).Field)
+	assert.Empty(t, types.NewFieldError(errors.New("header and text cannot both be empty")).Field)
+}
+
+func TestRejectionHookFunc(t *testing.T) {
+	t.Parallel()
+
+	var received *types.RejectionNotice
+
+	var hook types.RejectionHook = types.RejectionHookFunc(func(_ context.Context, notice *types.RejectionNotice) error {
+		received = notice
+		return nil
+	})
+
+	notice := &types.RejectionNotice{Reason: types.RejectionReasonRateLimited}
+	require.NoError(t, hook.NotifyRejection(context.Background(), notice))
+	assert.Same(t, notice, received)
+}
+
+func TestAlertRejectionTargetValidation(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(target *types.RejectionTarget) *types.Alert {
+		a := &types.Alert{Header: "a", RouteKey: "b", RejectionTarget: target}
+		a.Clean()
+		return a
+	}
+
+	require.NoError(t, newAlert(&types.RejectionTarget{SlackChannelID: " c123 "}).Validate())
+	require.NoError(t, newAlert(&types.RejectionTarget{CallbackURL: "https://example.com/rejections"}).Validate())
+	require.ErrorContains(t, newAlert(&types.RejectionTarget{}).Validate(), "rejectionTarget.slackChannelId or rejectionTarget.callbackUrl is required")
+	require.ErrorContains(t, newAlert(&types.RejectionTarget{SlackChannelID: "foo bar"}).Validate(), "rejectionTarget.slackChannelId 'foo bar' is not valid")
+	require.ErrorContains(t, newAlert(&types.RejectionTarget{CallbackURL: "ftp://example.com"}).Validate(), "rejectionTarget.callbackUrl is not a valid absolute http or https URL")
+	require.ErrorContains(t, newAlert(&types.RejectionTarget{CallbackURL: "/rejections"}).Validate(), "rejectionTarget.callbackUrl is not a valid absolute http or https URL")
+}
@@ -0,0 +1,37 @@
+package types
+
+// RejectionReason is the reason an alert was rejected or dropped by the Slack Manager.
+type RejectionReason string
+
+const (
+	// RejectionReasonValidationFailed indicates that the alert failed validation, for example after being queued.
+	RejectionReasonValidationFailed RejectionReason = "validation_failed"
+
+	// RejectionReasonIgnored indicates that the alert was dropped by an ignore rule, such as IgnoreIfTextContains.
+	RejectionReasonIgnored RejectionReason = "ignored"
+
+	// RejectionReasonRateLimited indicates that the alert was dropped because of rate limiting.
+	RejectionReasonRateLimited RejectionReason = "rate_limited"
+
+	// RejectionReasonDuplicate indicates that the alert was dropped as a duplicate of a previous alert.
+	RejectionReasonDuplicate RejectionReason = "duplicate"
+)
+
+// RejectionReasonIsValid returns true if the provided RejectionReason is valid.
+func RejectionReasonIsValid(s RejectionReason) bool {
+	switch s {
+	case RejectionReasonValidationFailed, RejectionReasonIgnored, RejectionReasonRateLimited, RejectionReasonDuplicate:
+		return true
+	}
+	return false
+}
+
+// ValidRejectionReasons returns a slice of valid RejectionReason values.
+func ValidRejectionReasons() []string {
+	return []string{
+		string(RejectionReasonValidationFailed),
+		string(RejectionReasonIgnored),
+		string(RejectionReasonRateLimited),
+		string(RejectionReasonDuplicate),
+	}
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"sync"
+	"time"
+)
+
+// inMemoryReplayGuardPruneInterval is the number of Seen calls between each removal of expired IDs.
+const inMemoryReplayGuardPruneInterval = 1000
+
+// ReplayGuard detects duplicate deliveries of the same message, such as webhook callbacks delivered more than once
+// because of retries. Handlers typically call Seen with WebhookCallback.DeliveryID, and ignore the callback if it returns true.
+type ReplayGuard interface {
+	// Seen returns true if the ID has been seen within its TTL. Otherwise, the ID is recorded with the specified TTL,
+	// and false is returned. Implementations must be safe for concurrent use, and Seen must be atomic, so that
+	// concurrent calls with the same ID return false at most once.
+	Seen(id string, ttl time.Duration) bool
+}
+
+// InMemoryReplayGuard is an in-memory implementation of the ReplayGuard interface.
+// It is only effective within a single process. Expired IDs are removed periodically.
+type InMemoryReplayGuard struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	calls   int
+}
+
+// NewInMemoryReplayGuard creates a new InMemoryReplayGuard instance.
+func NewInMemoryReplayGuard() *InMemoryReplayGuard {
+	return &InMemoryReplayGuard{
+		expires: make(map[string]time.Time),
+	}
+}
+
+// Seen returns true if the ID has been seen within its TTL, otherwise it records the ID and returns false.
+func (g *InMemoryReplayGuard) Seen(id string, ttl time.Duration) bool {
+	now := time.Now()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.calls++
+
+	if g.calls%inMemoryReplayGuardPruneInterval == 0 {
+		g.prune(now)
+	}
+
+	if expires, ok := g.expires[id]; ok && now.Before(expires) {
+		return true
+	}
+
+	g.expires[id] = now.Add(ttl)
+
+	return false
+}
+
+// Len returns the number of recorded IDs, including expired IDs not yet removed.
+func (g *InMemoryReplayGuard) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return len(g.expires)
+}
+
+func (g *InMemoryReplayGuard) prune(now time.Time) {
+	for id, expires := range g.expires {
+		if !now.Before(expires) {
+			delete(g.expires, id)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package types_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryReplayGuard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("duplicate ids should be detected within the ttl", func(t *testing.T) {
+		t.Parallel()
+
+		var guard types.ReplayGuard = types.NewInMemoryReplayGuard()
+
+		assert.False(t, guard.Seen("a", time.Minute))
+		assert.True(t, guard.Seen("a", time.Minute))
+		assert.False(t, guard.Seen("b", time.Minute))
+	})
+
+	t.Run("ids should expire after the ttl", func(t *testing.T) {
+		t.Parallel()
+
+		guard := types.NewInMemoryReplayGuard()
+
+		assert.False(t, guard.Seen("a", 10*time.Millisecond))
+		time.Sleep(20 * time.Millisecond)
+		assert.False(t, guard.Seen("a", time.Minute))
+		assert.True(t, guard.Seen("a", time.Minute))
+	})
+
+	t.Run("expired ids should be pruned", func(t *testing.T) {
+		t.Parallel()
+
+		guard := types.NewInMemoryReplayGuard()
+
+		for i := range 999 {
+			guard.Seen(fmt.Sprintf("id%d", i), time.Nanosecond)
+		}
+
+		assert.Equal(t, 999, guard.Len())
+		time.Sleep(time.Millisecond)
+
+		guard.Seen("last", time.Minute)
+		assert.Equal(t, 1, guard.Len())
+	})
+
+	t.Run("concurrent calls should detect a single first delivery", func(t *testing.T) {
+		t.Parallel()
+
+		guard := types.NewInMemoryReplayGuard()
+
+		var firstDeliveries atomic.Int32
+		var wg sync.WaitGroup
+
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if !guard.Seen("a", time.Minute) {
+					firstDeliveries.Add(1)
+				}
+			}()
+		}
+
+		wg.Wait()
+		assert.Equal(t, int32(1), firstDeliveries.Load())
+	})
+}
@@ -0,0 +1,30 @@
+package types
+
+// Resolution is the outcome of a resolved issue.
+type Resolution string
+
+const (
+	// ResolutionResolved indicates that the underlying problem is fixed.
+	ResolutionResolved Resolution = "resolved"
+
+	// ResolutionInconclusive indicates that the issue is resolved without knowing whether the underlying problem is fixed,
+	// for example because the alert source stopped reporting.
+	ResolutionInconclusive Resolution = "inconclusive"
+)
+
+// ResolutionIsValid returns true if the provided Resolution is valid.
+func ResolutionIsValid(s Resolution) bool {
+	switch s {
+	case ResolutionResolved, ResolutionInconclusive:
+		return true
+	}
+	return false
+}
+
+// ValidResolutions returns a slice of valid Resolution values.
+func ValidResolutions() []string {
+	return []string{
+		string(ResolutionResolved),
+		string(ResolutionInconclusive),
+	}
+}
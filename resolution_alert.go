@@ -0,0 +1,71 @@
+package types
+
+// NewResolution returns a resolved alert for the same issue as this alert.
+//
+// The correlation fields (SlackChannelID, RouteKey, CorrelationID and GlobalIssueKey) and issue settings
+// (IssueFollowUpEnabled, auto-resolve and archiving) are copied verbatim, so that the resolution matches the original issue. If the alert has no correlation ID, the ID
+// generated by the default correlation strategy is used. The header and text are HeaderWhenResolved and
+// TextWhenResolved if set, otherwise the original header and text. Presentation fields (author, host, footer,
+// link, username, icon and fields) are copied, while escalations, webhooks and ignore patterns are not.
+func (a *Alert) NewResolution() *Alert {
+	r := NewAlert(AlertResolved)
+
+	r.SlackChannelID = a.SlackChannelID
+	r.RouteKey = a.RouteKey
+	r.CorrelationID = a.CorrelationID
+	r.GlobalIssueKey = a.GlobalIssueKey
+	r.IssueFollowUpEnabled = a.IssueFollowUpEnabled
+	r.AutoResolveSeconds = a.AutoResolveSeconds
+	r.AutoResolveAsInconclusive = a.AutoResolveAsInconclusive
+	r.ArchivingDelaySeconds = a.ArchivingDelaySeconds
+	r.Type = a.Type
+
+	if r.CorrelationID == "" {
+		r.CorrelationID = DefaultCorrelationStrategy().CorrelationID(a)
+	}
+
+	r.Header = a.Header
+	r.HeaderWhenResolved = a.HeaderWhenResolved
+	r.Text = a.Text
+	r.TextWhenResolved = a.TextWhenResolved
+
+	if r.HeaderWhenResolved != "" {
+		r.Header = r.HeaderWhenResolved
+	}
+
+	if r.TextWhenResolved != "" {
+		r.Text = r.TextWhenResolved
+	}
+
+	r.Author = a.Author
+	r.Host = a.Host
+	r.Footer = a.Footer
+	r.Link = a.Link
+	r.Username = a.Username
+	r.IconEmoji = a.IconEmoji
+
+	for _, f := range a.Fields {
+		if f != nil {
+			r.Fields = append(r.Fields, &Field{Title: f.Title, Value: f.Value})
+		}
+	}
+
+	for k, v := range a.Metadata {
+		r.Metadata[k] = v
+	}
+
+	return r
+}
+
+// NewResolutionAlert returns a resolved alert for the issue with the specified correlation ID, in the specified
+// Slack channel (ID or name), with a generic header. Use Alert.NewResolution instead when the original alert is available,
+// to keep the original header, text and issue settings.
+func NewResolutionAlert(correlationID, slackChannelID string) *Alert {
+	r := NewAlert(AlertResolved)
+
+	r.CorrelationID = correlationID
+	r.SlackChannelID = slackChannelID
+	r.Header = "Resolved"
+
+	return r
+}
@@ -0,0 +1,79 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertNewResolution(t *testing.T) {
+	t.Parallel()
+
+	a := types.NewErrorAlert()
+	a.SlackChannelID = "C12345678"
+	a.RouteKey = "db"
+	a.CorrelationID = "  disk-full  "
+	a.GlobalIssueKey = "incident-1"
+	a.IssueFollowUpEnabled = true
+	a.AutoResolveSeconds = 3600
+	a.Header = "Disk full"
+	a.HeaderWhenResolved = "Disk no longer full"
+	a.Text = "Disk is 99% full"
+	a.Host = "db-01"
+	a.Fields = []*types.Field{{Title: "Disk", Value: "/var"}}
+	a.Webhooks = []*types.Webhook{{ID: "restart", URL: "https://example.com", ButtonText: "Restart"}}
+	a.Metadata["service"] = "db"
+
+	r := a.NewResolution()
+
+	assert.Equal(t, types.AlertResolved, r.Severity)
+	assert.Equal(t, "C12345678", r.SlackChannelID)
+	assert.Equal(t, "db", r.RouteKey)
+	assert.Equal(t, "  disk-full  ", r.CorrelationID, "correlation ID is copied verbatim")
+	assert.Equal(t, "incident-1", r.GlobalIssueKey)
+	assert.True(t, r.IssueFollowUpEnabled)
+	assert.Equal(t, 3600, r.AutoResolveSeconds)
+	assert.Equal(t, "Disk no longer full", r.Header)
+	assert.Equal(t, "Disk is 99% full", r.Text)
+	assert.Equal(t, "db-01", r.Host)
+	assert.Equal(t, "db", r.Metadata["service"])
+	assert.Empty(t, r.Webhooks)
+
+	require.Len(t, r.Fields, 1)
+	assert.NotSame(t, a.Fields[0], r.Fields[0])
+
+	r.Clean()
+	require.NoError(t, r.Validate())
+}
+
+func TestAlertNewResolutionDefaultCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	a := types.NewErrorAlert()
+	a.SlackChannelID = "C12345678"
+	a.Header = "Disk full"
+	a.TextWhenResolved = "All good"
+
+	r := a.NewResolution()
+
+	assert.Empty(t, a.CorrelationID, "original alert is not modified")
+	assert.Equal(t, types.DefaultCorrelationStrategy().CorrelationID(a), r.CorrelationID)
+	assert.Equal(t, "Disk full", r.Header)
+	assert.Equal(t, "All good", r.Text)
+}
+
+func TestNewResolutionAlert(t *testing.T) {
+	t.Parallel()
+
+	r := types.NewResolutionAlert("disk-full", "C12345678")
+
+	assert.Equal(t, types.AlertResolved, r.Severity)
+	assert.Equal(t, "disk-full", r.CorrelationID)
+	assert.Equal(t, "C12345678", r.SlackChannelID)
+	assert.Equal(t, "Resolved", r.Header)
+
+	r.Clean()
+	require.NoError(t, r.Validate())
+}
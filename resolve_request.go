@@ -0,0 +1,109 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxResolveNoteLength is the maximum length of the note in a resolve request.
+	MaxResolveNoteLength = 1000
+	// MaxResolveActorLength is the maximum length of the actor in a resolve request.
+	MaxResolveActorLength = 200
+)
+
+// ResolveRequest is a lightweight request for resolving an existing issue, as an alternative to sending
+// a full Alert with severity resolved. The issue is found by correlation ID, in the channel specified
+// directly by SlackChannelID, or indirectly by RouteKey (the same way as for alerts).
+type ResolveRequest struct {
+	// CorrelationID is the correlation ID of the issue to resolve.
+	// This field is required.
+	// Maximum length: MaxCorrelationIDLength characters.
+	CorrelationID string `json:"correlationId"`
+
+	// SlackChannelID is the ID or name of the Slack channel of the issue.
+	// Takes precedence over RouteKey if both are set.
+	SlackChannelID string `json:"slackChannelId"`
+
+	// RouteKey is used to find the Slack channel of the issue, via the routes configured in the Slack Manager.
+	// Maximum length: MaxRouteKeyLength characters.
+	RouteKey string `json:"routeKey"`
+
+	// Resolution is the outcome of the issue.
+	// Valid values are defined by Resolution constants.
+	// If empty, the issue is resolved as ResolutionResolved.
+	Resolution Resolution `json:"resolution"`
+
+	// Note is an optional note added to the resolved issue, such as the reason for the resolution.
+	// It is automatically truncated at MaxResolveNoteLength characters.
+	Note string `json:"note"`
+
+	// Actor is an optional name of the user or system resolving the issue.
+	// It is automatically truncated at MaxResolveActorLength characters.
+	Actor string `json:"actor"`
+}
+
+// Clean normalizes the request fields.
+func (r *ResolveRequest) Clean() {
+	if r == nil {
+		return
+	}
+
+	r.CorrelationID = strings.TrimSpace(r.CorrelationID)
+	r.SlackChannelID = NormalizeChannel(r.SlackChannelID)
+	r.RouteKey = strings.ToLower(strings.TrimSpace(r.RouteKey))
+	r.Resolution = Resolution(strings.ToLower(strings.TrimSpace(string(r.Resolution))))
+	r.Note = strings.TrimSpace(r.Note)
+	r.Actor = strings.ReplaceAll(strings.TrimSpace(r.Actor), "\n", " ")
+
+	if r.Resolution == "" {
+		r.Resolution = ResolutionResolved
+	}
+
+	if utf8.RuneCountInString(r.Note) > MaxResolveNoteLength {
+		r.Note = strings.TrimSpace(truncateString(r.Note, MaxResolveNoteLength-3)) + "..."
+	}
+
+	if utf8.RuneCountInString(r.Actor) > MaxResolveActorLength {
+		r.Actor = strings.TrimSpace(truncateString(r.Actor, MaxResolveActorLength-3)) + "..."
+	}
+}
+
+// Validate returns an error if one or more of the fields are invalid. Call Clean first.
+func (r *ResolveRequest) Validate() error {
+	if r == nil {
+		return errors.New("resolve request is nil")
+	}
+
+	if r.CorrelationID == "" {
+		return errors.New("correlationId is required")
+	}
+
+	if len(r.CorrelationID) > MaxCorrelationIDLength {
+		return fmt.Errorf("correlationId is too long, expected length <=%d", MaxCorrelationIDLength)
+	}
+
+	if r.SlackChannelID != "" {
+		if !SlackChannelIDOrNameRegex.MatchString(r.SlackChannelID) {
+			return fmt.Errorf("slackChannelId '%s' is not valid", r.SlackChannelID)
+		}
+	} else if len(r.RouteKey) > MaxRouteKeyLength {
+		return fmt.Errorf("routeKey is too long, expected length <=%d", MaxRouteKeyLength)
+	}
+
+	if !ResolutionIsValid(r.Resolution) {
+		return fmt.Errorf("resolution '%s' is not valid, expected one of [%s]", r.Resolution, strings.Join(ValidResolutions(), ", "))
+	}
+
+	if utf8.RuneCountInString(r.Note) > MaxResolveNoteLength {
+		return fmt.Errorf("note is too long, expected length <=%d", MaxResolveNoteLength)
+	}
+
+	if utf8.RuneCountInString(r.Actor) > MaxResolveActorLength {
+		return fmt.Errorf("actor is too long, expected length <=%d", MaxResolveActorLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package types_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolutionValidation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.ResolutionIsValid(types.ResolutionResolved))
+	assert.True(t, types.ResolutionIsValid(types.ResolutionInconclusive))
+	assert.False(t, types.ResolutionIsValid("fixed"))
+	assert.Len(t, types.ValidResolutions(), 2)
+}
+
+func TestResolveRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("request should be decoded and cleaned", func(t *testing.T) {
+		t.Parallel()
+
+		r := &types.ResolveRequest{}
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"correlationId": " disk-host-1 ",
+			"routeKey": " Infra ",
+			"resolution": "INCONCLUSIVE",
+			"note": " Host decommissioned ",
+			"actor": " jane "
+		}`), r))
+
+		r.Clean()
+		require.NoError(t, r.Validate())
+		assert.Equal(t, "disk-host-1", r.CorrelationID)
+		assert.Equal(t, "infra", r.RouteKey)
+		assert.Equal(t, types.ResolutionInconclusive, r.Resolution)
+		assert.Equal(t, "Host decommissioned", r.Note)
+		assert.Equal(t, "jane", r.Actor)
+	})
+
+	t.Run("resolution should default to resolved", func(t *testing.T) {
+		t.Parallel()
+
+		r := &types.ResolveRequest{CorrelationID: "a", SlackChannelID: "c0123456789"}
+		r.Clean()
+		require.NoError(t, r.Validate())
+		assert.Equal(t, types.ResolutionResolved, r.Resolution)
+		assert.Equal(t, "C0123456789", r.SlackChannelID)
+	})
+
+	t.Run("long fields should be truncated", func(t *testing.T) {
+		t.Parallel()
+
+		r := &types.ResolveRequest{CorrelationID: "a", Note: strings.Repeat("n", 2000), Actor: strings.Repeat("a", 300)}
+		r.Clean()
+		require.NoError(t, r.Validate())
+		assert.Len(t, r.Note, types.MaxResolveNoteLength)
+		assert.Len(t, r.Actor, types.MaxResolveActorLength)
+	})
+
+	t.Run("invalid requests should fail", func(t *testing.T) {
+		t.Parallel()
+
+		var r *types.ResolveRequest
+		require.ErrorContains(t, r.Validate(), "resolve request is nil")
+
+		r = &types.ResolveRequest{}
+		r.Clean()
+		require.ErrorContains(t, r.Validate(), "correlationId is required")
+
+		r = &types.ResolveRequest{CorrelationID: strings.Repeat("a", types.MaxCorrelationIDLength+1)}
+		r.Clean()
+		require.ErrorContains(t, r.Validate(), "correlationId is too long")
+
+		r = &types.ResolveRequest{CorrelationID: "a", SlackChannelID: "foo bar"}
+		r.Clean()
+		require.ErrorContains(t, r.Validate(), "slackChannelId 'foo bar' is not valid")
+
+		r = &types.ResolveRequest{CorrelationID: "a", RouteKey: strings.Repeat("a", types.MaxRouteKeyLength+1)}
+		r.Clean()
+		require.ErrorContains(t, r.Validate(), "routeKey is too long")
+
+		r = &types.ResolveRequest{CorrelationID: "a", Resolution: "fixed"}
+		r.Clean()
+		require.ErrorContains(t, r.Validate(), "resolution 'fixed' is not valid")
+	})
+}
@@ -0,0 +1,217 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultChannelCacheTTL is the time a resolved channel ID is cached by a CachingChannelResolver, when
+// NewCachingChannelResolver is called with a non-positive TTL.
+const DefaultChannelCacheTTL = 10 * time.Minute
+
+var (
+	// ErrRouteNotFound is returned by a RouteResolver when no channel is configured for a route key.
+	ErrRouteNotFound = errors.New("route key not found")
+
+	// ErrChannelNotFound is returned by a ChannelResolver when no channel exists with the specified name.
+	ErrChannelNotFound = errors.New("channel not found")
+)
+
+// RouteResolver resolves a route key (see Alert.RouteKey) to the ID of the Slack channel it routes to.
+type RouteResolver interface {
+	// ResolveRoute returns the Slack channel ID of the (case-insensitive) route key, or an error wrapping
+	// ErrRouteNotFound if the route key is not configured. It should return promptly when the context is canceled.
+	ResolveRoute(ctx context.Context, routeKey string) (string, error)
+}
+
+// ChannelResolver resolves a Slack channel name to its ID, as the API does for alerts where SlackChannelID is
+// a channel name.
+//
+// Names are case-insensitive, and a leading '#' is ignored. Channel IDs are returned as is, without a lookup.
+// Implementations backed by the Slack API should cache resolved IDs, since channel lookups are rate limited;
+// a renamed channel may then resolve by its old name until the cache entry expires. Failed lookups must not be
+// cached, so that newly created channels are resolved at once. Use NewCachingChannelResolver to add such a cache
+// to an uncached implementation.
+type ChannelResolver interface {
+	// ResolveChannel returns the ID of the channel with the specified name or ID, or an error wrapping
+	// ErrChannelNotFound if no such channel exists. It should return promptly when the context is canceled.
+	ResolveChannel(ctx context.Context, nameOrID string) (string, error)
+}
+
+// InMemoryResolver is an in-memory implementation of the RouteResolver and ChannelResolver interfaces, for tests
+// and for producer-side tooling with a static configuration. It is safe for concurrent use.
+type InMemoryResolver struct {
+	mu       sync.RWMutex
+	routes   map[string]string
+	channels map[string]string
+}
+
+// NewInMemoryResolver creates a new, empty InMemoryResolver.
+func NewInMemoryResolver() *InMemoryResolver {
+	return &InMemoryResolver{
+		routes:   make(map[string]string),
+		channels: make(map[string]string),
+	}
+}
+
+// SetRoute configures the route key to route to the specified channel name or ID. An empty channel removes the route.
+func (r *InMemoryResolver) SetRoute(routeKey, channel string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	routeKey = strings.ToLower(strings.TrimSpace(routeKey))
+
+	if channel = strings.TrimSpace(channel); channel == "" {
+		delete(r.routes, routeKey)
+	} else {
+		r.routes[routeKey] = channel
+	}
+}
+
+// SetChannel configures the name of a channel ID. An empty channel ID removes the name.
+func (r *InMemoryResolver) SetChannel(name, channelID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name = normalizeChannelName(name)
+
+	if channelID = strings.TrimSpace(channelID); channelID == "" {
+		delete(r.channels, name)
+	} else {
+		r.channels[name] = channelID
+	}
+}
+
+// ResolveRoute returns the channel ID of the route key. Routes configured with a channel name are resolved with
+// ResolveChannel.
+func (r *InMemoryResolver) ResolveRoute(ctx context.Context, routeKey string) (string, error) {
+	r.mu.RLock()
+	channel, ok := r.routes[strings.ToLower(strings.TrimSpace(routeKey))]
+	r.mu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("%w: '%s'", ErrRouteNotFound, routeKey)
+	}
+
+	channelID, err := r.ResolveChannel(ctx, channel)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve channel of route key '%s': %w", routeKey, err)
+	}
+
+	return channelID, nil
+}
+
+// ResolveChannel returns the ID of the channel with the specified name or ID.
+func (r *InMemoryResolver) ResolveChannel(_ context.Context, nameOrID string) (string, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
+	if slackChannelIDRegex.MatchString(nameOrID) {
+		return nameOrID, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if channelID, ok := r.channels[normalizeChannelName(nameOrID)]; ok {
+		return channelID, nil
+	}
+
+	return "", fmt.Errorf("%w: '%s'", ErrChannelNotFound, nameOrID)
+}
+
+// CachingChannelResolver is a ChannelResolver caching the channel IDs resolved by another ChannelResolver,
+// following the caching contract of the interface. It is safe for concurrent use.
+type CachingChannelResolver struct {
+	resolver ChannelResolver
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	cache map[string]*cachedChannel
+}
+
+type cachedChannel struct {
+	channelID string
+	expires   time.Time
+}
+
+// NewCachingChannelResolver creates a new CachingChannelResolver, caching the IDs resolved by the specified resolver
+// for the specified TTL. If ttl is zero or negative, DefaultChannelCacheTTL is used.
+func NewCachingChannelResolver(resolver ChannelResolver, ttl time.Duration) (*CachingChannelResolver, error) {
+	if resolver == nil {
+		return nil, errors.New("channel resolver cannot be nil")
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultChannelCacheTTL
+	}
+
+	return &CachingChannelResolver{
+		resolver: resolver,
+		ttl:      ttl,
+		cache:    make(map[string]*cachedChannel),
+	}, nil
+}
+
+// ResolveChannel returns the cached ID of the channel, or resolves it with the underlying resolver.
+// Errors are not cached.
+func (r *CachingChannelResolver) ResolveChannel(ctx context.Context, nameOrID string) (string, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
+	if slackChannelIDRegex.MatchString(nameOrID) {
+		return nameOrID, nil
+	}
+
+	name := normalizeChannelName(nameOrID)
+	now := time.Now()
+
+	r.mu.Lock()
+	cached, ok := r.cache[name]
+	r.mu.Unlock()
+
+	if ok && now.Before(cached.expires) {
+		return cached.channelID, nil
+	}
+
+	channelID, err := r.resolver.ResolveChannel(ctx, nameOrID)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Expired entries are removed on insert, so that the cache does not grow with names that are no longer used.
+	for key, entry := range r.cache {
+		if !now.Before(entry.expires) {
+			delete(r.cache, key)
+		}
+	}
+
+	r.cache[name] = &cachedChannel{channelID: channelID, expires: now.Add(r.ttl)}
+
+	return channelID, nil
+}
+
+// Invalidate removes the cached ID of the channel name, for example when the channel is renamed or archived.
+func (r *CachingChannelResolver) Invalidate(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.cache, normalizeChannelName(name))
+}
+
+// Len returns the number of cached channel names, including expired entries not yet removed.
+func (r *CachingChannelResolver) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.cache)
+}
+
+func normalizeChannelName(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+}
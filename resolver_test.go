@@ -0,0 +1,149 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type channelResolverFunc func(ctx context.Context, nameOrID string) (string, error)
+
+func (f channelResolverFunc) ResolveChannel(ctx context.Context, nameOrID string) (string, error) {
+	return f(ctx, nameOrID)
+}
+
+func TestInMemoryResolver(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	r := types.NewInMemoryResolver()
+	r.SetChannel("#Alerts-Payments", "C0PAYMENTS")
+	r.SetRoute("Payments", "alerts-payments")
+	r.SetRoute("billing", "C0BILLING1")
+	r.SetRoute("orphan", "no-such-channel")
+
+	channelID, err := r.ResolveChannel(ctx, " alerts-PAYMENTS ")
+	require.NoError(t, err)
+	assert.Equal(t, "C0PAYMENTS", channelID)
+
+	channelID, err = r.ResolveChannel(ctx, "C0UNKNOWN1")
+	require.NoError(t, err)
+	assert.Equal(t, "C0UNKNOWN1", channelID, "channel IDs should be returned as is")
+
+	_, err = r.ResolveChannel(ctx, "general")
+	require.ErrorIs(t, err, types.ErrChannelNotFound)
+
+	channelID, err = r.ResolveRoute(ctx, "PAYMENTS")
+	require.NoError(t, err)
+	assert.Equal(t, "C0PAYMENTS", channelID)
+
+	channelID, err = r.ResolveRoute(ctx, "billing")
+	require.NoError(t, err)
+	assert.Equal(t, "C0BILLING1", channelID)
+
+	_, err = r.ResolveRoute(ctx, "unknown")
+	require.EqualError(t, err, "route key not found: 'unknown'")
+	require.ErrorIs(t, err, types.ErrRouteNotFound)
+
+	_, err = r.ResolveRoute(ctx, "orphan")
+	require.ErrorIs(t, err, types.ErrChannelNotFound)
+
+	r.SetRoute("payments", "")
+	_, err = r.ResolveRoute(ctx, "payments")
+	require.ErrorIs(t, err, types.ErrRouteNotFound)
+
+	r.SetChannel("alerts-payments", "")
+	_, err = r.ResolveChannel(ctx, "alerts-payments")
+	require.ErrorIs(t, err, types.ErrChannelNotFound)
+
+	a := types.NewErrorAlert()
+	a.RouteKey = "billing"
+	a.Header = "Disk full"
+	require.NoError(t, a.ValidateContext(ctx, &types.ValidateOptions{RouteResolver: r}))
+}
+
+func TestCachingChannelResolver(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	_, err := types.NewCachingChannelResolver(nil, 0)
+	require.EqualError(t, err, "channel resolver cannot be nil")
+
+	var lookups atomic.Int32
+
+	inner := channelResolverFunc(func(_ context.Context, nameOrID string) (string, error) {
+		lookups.Add(1)
+
+		if nameOrID == "general" {
+			return "C0GENERAL1", nil
+		}
+
+		return "", errors.New("channel not found")
+	})
+
+	t.Run("resolved channels should be cached", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := types.NewCachingChannelResolver(inner, time.Hour)
+		require.NoError(t, err)
+
+		before := lookups.Load()
+
+		for range 3 {
+			channelID, err := r.ResolveChannel(ctx, "general")
+			require.NoError(t, err)
+			assert.Equal(t, "C0GENERAL1", channelID)
+		}
+
+		_, err = r.ResolveChannel(ctx, "#GENERAL")
+		require.NoError(t, err)
+
+		_, err = r.ResolveChannel(ctx, "C0DIRECT01")
+		require.NoError(t, err)
+
+		assert.Equal(t, before+1, lookups.Load())
+		assert.Equal(t, 1, r.Len())
+
+		r.Invalidate("#General")
+		assert.Equal(t, 0, r.Len())
+	})
+
+	t.Run("failed lookups and expired entries should not be cached", func(t *testing.T) {
+		t.Parallel()
+
+		var count atomic.Int32
+
+		counting := channelResolverFunc(func(ctx context.Context, nameOrID string) (string, error) {
+			count.Add(1)
+			return inner(ctx, nameOrID)
+		})
+
+		r, err := types.NewCachingChannelResolver(counting, time.Millisecond)
+		require.NoError(t, err)
+
+		for range 2 {
+			_, err := r.ResolveChannel(ctx, "random")
+			require.Error(t, err)
+		}
+
+		assert.Equal(t, int32(2), count.Load())
+		assert.Equal(t, 0, r.Len())
+
+		_, err = r.ResolveChannel(ctx, "general")
+		require.NoError(t, err)
+
+		time.Sleep(5 * time.Millisecond)
+
+		_, err = r.ResolveChannel(ctx, "general")
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), count.Load())
+	})
+}
@@ -0,0 +1,209 @@
+package routing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/slackmgr/types"
+	"gopkg.in/yaml.v3"
+)
+
+// FileVersion is the current version of the routing configuration file format.
+const FileVersion = 1
+
+// File is a routing configuration file, in YAML or JSON, using the same (camelCase) field names for both:
+//
+//	version: 1
+//	receivers:
+//	  - name: db-oncall
+//	    channels: [C0DBONCALL]
+//	  - name: default
+//	    channels: [C0FALLBACK]
+//	routes:
+//	  - name: db-panics
+//	    routeKeys: [db.*]
+//	    severities: [panic]
+//	    receiver: db-oncall
+//	fallback: default
+//
+// Routes are evaluated in order, as RoutingRule values. Use Config to resolve the receivers, and Lint to
+// find unreachable routes and duplicate route keys before deploying.
+type File struct {
+	// Version is the file format version. Defaults to FileVersion.
+	Version int `json:"version"`
+
+	// Receivers are named sets of Slack channels, referred to by routes and the fallback.
+	Receivers []*Receiver `json:"receivers"`
+
+	// Routes are the routing rules, in evaluation order.
+	Routes []*Route `json:"routes"`
+
+	// Fallback is the optional name of the receiver of alerts matching no route.
+	Fallback string `json:"fallback"`
+}
+
+// Receiver is a named set of Slack channels.
+type Receiver struct {
+	// Name is the unique name of the receiver. Required.
+	Name string `json:"name"`
+
+	// Channels are the Slack channel IDs or names. Between 1 and MaxRuleChannelCount channels are required.
+	Channels []string `json:"channels"`
+}
+
+// Route is a routing rule in a File. The target channels are the channels of the receiver, followed by
+// the channels of the route itself, if any. At least one of them is required.
+type Route struct {
+	RoutingRule
+
+	// Receiver is the name of the receiver of the route.
+	Receiver string `json:"receiver"`
+}
+
+// ParseFile parses a routing configuration file in YAML or JSON. Unknown fields are rejected, so that typos
+// are caught before deploying. The file is not validated: call Config or LoadRouter to validate it.
+func ParseFile(data []byte) (*File, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse routing configuration: %w", err)
+	}
+
+	// Convert to JSON, to reuse the JSON field names.
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert routing configuration to JSON: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+
+	var f File
+
+	if err := decoder.Decode(&f); err != nil {
+		return nil, fmt.Errorf("failed to decode routing configuration: %w", err)
+	}
+
+	return &f, nil
+}
+
+// LoadRouter parses and validates a routing configuration file, and creates a Router.
+func LoadRouter(data []byte) (*Router, error) {
+	f, err := ParseFile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := f.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRouter(cfg)
+}
+
+// Config validates the file, and resolves the receivers of the routes and the fallback.
+func (f *File) Config() (Config, error) {
+	if f.Version != 0 && f.Version != FileVersion {
+		return Config{}, fmt.Errorf("version %d is not supported, expected %d", f.Version, FileVersion)
+	}
+
+	receivers, err := f.receivers()
+	if err != nil {
+		return Config{}, err
+	}
+
+	cfg := Config{Rules: make([]*RoutingRule, 0, len(f.Routes))}
+
+	for i, route := range f.Routes {
+		if route == nil {
+			return Config{}, fmt.Errorf("routes[%d] cannot be null", i)
+		}
+
+		rule, err := route.resolve(receivers)
+		if err != nil {
+			return Config{}, fmt.Errorf("routes[%d]: %w", i, err)
+		}
+
+		if err := rule.Validate(); err != nil {
+			return Config{}, fmt.Errorf("routes[%d]: %w", i, err)
+		}
+
+		cfg.Rules = append(cfg.Rules, rule)
+	}
+
+	if f.Fallback != "" {
+		receiver, ok := receivers[f.Fallback]
+		if !ok {
+			return Config{}, fmt.Errorf("fallback receiver '%s' is not defined", f.Fallback)
+		}
+
+		if len(receiver.Channels) != 1 {
+			return Config{}, fmt.Errorf("fallback receiver '%s' must have exactly one channel", f.Fallback)
+		}
+
+		cfg.FallbackChannel = receiver.Channels[0]
+	}
+
+	return cfg, nil
+}
+
+func (f *File) receivers() (map[string]*Receiver, error) {
+	receivers := make(map[string]*Receiver, len(f.Receivers))
+
+	for i, receiver := range f.Receivers {
+		if receiver == nil {
+			return nil, fmt.Errorf("receivers[%d] cannot be null", i)
+		}
+
+		if receiver.Name == "" {
+			return nil, fmt.Errorf("receivers[%d]: name cannot be empty", i)
+		}
+
+		if _, ok := receivers[receiver.Name]; ok {
+			return nil, fmt.Errorf("receivers[%d]: name '%s' is already used", i, receiver.Name)
+		}
+
+		if len(receiver.Channels) == 0 {
+			return nil, fmt.Errorf("receivers[%d]: channels cannot be empty", i)
+		}
+
+		for j, channel := range receiver.Channels {
+			if !types.SlackChannelIDOrNameRegex.MatchString(channel) {
+				return nil, fmt.Errorf("receivers[%d]: channels[%d] '%s' is not valid", i, j, channel)
+			}
+		}
+
+		receivers[receiver.Name] = receiver
+	}
+
+	return receivers, nil
+}
+
+func (r *Route) resolve(receivers map[string]*Receiver) (*RoutingRule, error) {
+	rule := r.RoutingRule
+	rule.Channels = nil
+
+	if r.Receiver != "" {
+		receiver, ok := receivers[r.Receiver]
+		if !ok {
+			return nil, fmt.Errorf("receiver '%s' is not defined", r.Receiver)
+		}
+
+		rule.Channels = append(rule.Channels, receiver.Channels...)
+	}
+
+	for _, channel := range r.Channels {
+		if !slices.Contains(rule.Channels, channel) {
+			rule.Channels = append(rule.Channels, channel)
+		}
+	}
+
+	if len(rule.Channels) == 0 {
+		return nil, errors.New("receiver or channels is required")
+	}
+
+	return &rule, nil
+}
@@ -0,0 +1,104 @@
+package routing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/routing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const routingYAML = `
+version: 1
+receivers:
+  - name: db-oncall
+    channels: [C0DBONCALL]
+  - name: db
+    channels: [C0DBALERTS]
+  - name: default
+    channels: [C0FALLBACK]
+routes:
+  - name: db-panics
+    routeKeys: [db.*]
+    severities: [panic]
+    receiver: db-oncall
+    channels: [C0INCIDENTS]
+  - name: db
+    routeKeys: [db.*]
+    receiver: db
+  - name: office-hours
+    timeOfDay:
+      start: "08:00"
+      end: "16:00"
+      weekdays: [mon, tue, wed, thu, fri]
+    channels: [C0OFFICE]
+fallback: default
+`
+
+func TestLoadRouter(t *testing.T) {
+	t.Parallel()
+
+	router, err := routing.LoadRouter([]byte(routingYAML))
+	require.NoError(t, err)
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC) // Saturday
+
+	channels, err := router.RouteAt(newAlert("db.orders", types.AlertPanic), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C0DBONCALL", "C0INCIDENTS"}, channels)
+
+	channels, err = router.RouteAt(newAlert("db.orders", types.AlertError), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C0DBALERTS"}, channels)
+
+	channels, err = router.RouteAt(newAlert("web", types.AlertError), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C0FALLBACK"}, channels)
+
+	assert.Equal(t, "C0FALLBACK", router.FallbackChannel())
+	assert.Len(t, router.Rules(), 3)
+}
+
+func TestLoadRouterJSON(t *testing.T) {
+	t.Parallel()
+
+	router, err := routing.LoadRouter([]byte(`{"routes": [{"routeKeys": ["web"], "channels": ["C0WEB"]}]}`))
+	require.NoError(t, err)
+
+	channels, err := router.Route(newAlert("WEB", types.AlertError))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C0WEB"}, channels)
+}
+
+func TestLoadRouterErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		yaml string
+		err  string
+	}{
+		{"invalid yaml", "routes: [", "failed to parse routing configuration"},
+		{"unknown field", "routes:\n  - routeKey: db\n    channels: [C0DB]", `failed to decode routing configuration: json: unknown field "routeKey"`},
+		{"unsupported version", "version: 2", "version 2 is not supported, expected 1"},
+		{"duplicate receiver", "receivers:\n  - {name: a, channels: [C0A]}\n  - {name: a, channels: [C0B]}", "receivers[1]: name 'a' is already used"},
+		{"receiver without channels", "receivers:\n  - {name: a}", "receivers[0]: channels cannot be empty"},
+		{"invalid receiver channel", "receivers:\n  - {name: a, channels: ['#a']}", "receivers[0]: channels[0] '#a' is not valid"},
+		{"unknown receiver", "routes:\n  - {routeKeys: [db], receiver: db}", "routes[0]: receiver 'db' is not defined"},
+		{"route without target", "routes:\n  - {routeKeys: [db]}", "routes[0]: receiver or channels is required"},
+		{"invalid route", "routes:\n  - {severities: [critical], channels: [C0DB]}", "routes[0]: severities[0] 'critical' is not valid, expected one of [panic, error, warning, resolved, info]"},
+		{"unknown fallback", "fallback: default", "fallback receiver 'default' is not defined"},
+		{"fallback with multiple channels", "receivers:\n  - {name: default, channels: [C0A, C0B]}\nfallback: default", "fallback receiver 'default' must have exactly one channel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := routing.LoadRouter([]byte(tt.yaml))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
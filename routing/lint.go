@@ -0,0 +1,299 @@
+package routing
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LintKind is the kind of a LintFinding.
+type LintKind string
+
+const (
+	// LintUnreachableRule reports a rule that never matches, because an earlier rule (without Continue)
+	// matches all the alerts it matches.
+	LintUnreachableRule LintKind = "unreachable_rule"
+
+	// LintDuplicateRouteKey reports a route key used by more than one rule with otherwise the same matchers,
+	// typically the same route key claimed twice.
+	LintDuplicateRouteKey LintKind = "duplicate_route_key"
+
+	// LintDuplicateRuleName reports a rule name used by more than one rule.
+	LintDuplicateRuleName LintKind = "duplicate_rule_name"
+
+	// LintUnreachableFallback reports a fallback channel that is never used, because a rule (without Continue)
+	// matches all alerts.
+	LintUnreachableFallback LintKind = "unreachable_fallback"
+
+	// LintUnusedReceiver reports a receiver of a File that is not used by any route, nor as fallback.
+	LintUnusedReceiver LintKind = "unused_receiver"
+)
+
+// LintFinding is a probable mistake in a routing configuration, reported by Config.Lint and File.Lint.
+type LintFinding struct {
+	// Kind is the kind of finding.
+	Kind LintKind `json:"kind"`
+
+	// Rule is the index of the rule (or route) of the finding, or -1 if the finding is not about a rule.
+	Rule int `json:"rule"`
+
+	// Message describes the finding.
+	Message string `json:"message"`
+}
+
+// String returns the finding as a single line, for command line tools.
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s", f.Kind, f.Message)
+}
+
+// Lint reports probable mistakes in the configuration, such as unreachable rules and duplicate route keys.
+// The configuration should be valid (see Validate). Findings are ordered by rule index, followed by the findings
+// that are not about a rule.
+//
+// Unreachable rules are found conservatively: a rule is reported if an earlier rule without Continue has,
+// for each matcher, no values or a superset of the values of the rule. Time of day matchers must be equal.
+func (c *Config) Lint() []LintFinding {
+	return c.lint("rules")
+}
+
+// lint reports the findings, referring to rules as '<label>[<index>]'.
+func (c *Config) lint(label string) []LintFinding {
+	var findings []LintFinding
+
+	routeKeys := make(map[string][]int)
+	names := make(map[string]int)
+
+	for j, rule := range c.Rules {
+		if rule == nil {
+			continue
+		}
+
+		for i := range j {
+			if earlier := c.Rules[i]; earlier != nil && !earlier.Continue && earlier.covers(rule) {
+				findings = append(findings, LintFinding{
+					Kind:    LintUnreachableRule,
+					Rule:    j,
+					Message: fmt.Sprintf("%s is unreachable, all its alerts are matched by %s", ruleName(label, j, rule), ruleName(label, i, earlier)),
+				})
+
+				break
+			}
+		}
+
+		if rule.Name != "" {
+			if i, ok := names[rule.Name]; ok {
+				findings = append(findings, LintFinding{
+					Kind:    LintDuplicateRuleName,
+					Rule:    j,
+					Message: fmt.Sprintf("%s[%d] has the same name as %s[%d] (%s)", label, j, label, i, rule.Name),
+				})
+			} else {
+				names[rule.Name] = j
+			}
+		}
+
+		for _, key := range rule.RouteKeys {
+			normalized := strings.ToLower(strings.TrimSpace(key))
+
+			for _, i := range routeKeys[normalized] {
+				if c.Rules[i].sameMatchersExceptRouteKeys(rule) {
+					findings = append(findings, LintFinding{
+						Kind:    LintDuplicateRouteKey,
+						Rule:    j,
+						Message: fmt.Sprintf("route key '%s' of %s is also used by %s, with the same matchers", key, ruleName(label, j, rule), ruleName(label, i, c.Rules[i])),
+					})
+
+					break
+				}
+			}
+
+			if !slices.Contains(routeKeys[normalized], j) {
+				routeKeys[normalized] = append(routeKeys[normalized], j)
+			}
+		}
+	}
+
+	if c.FallbackChannel != "" {
+		for i, rule := range c.Rules {
+			if rule != nil && !rule.Continue && rule.matchesAll() {
+				findings = append(findings, LintFinding{
+					Kind:    LintUnreachableFallback,
+					Rule:    -1,
+					Message: fmt.Sprintf("fallback channel '%s' is unreachable, all alerts are matched by %s", c.FallbackChannel, ruleName(label, i, rule)),
+				})
+
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+// Lint validates the file, and reports probable mistakes: the findings of Config.Lint (with route indexes),
+// and unused receivers.
+func (f *File) Lint() ([]LintFinding, error) {
+	cfg, err := f.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	findings := cfg.lint("routes")
+
+	used := make(map[string]bool)
+	used[f.Fallback] = true
+
+	for _, route := range f.Routes {
+		used[route.Receiver] = true
+	}
+
+	for _, receiver := range f.Receivers {
+		if !used[receiver.Name] {
+			findings = append(findings, LintFinding{
+				Kind:    LintUnusedReceiver,
+				Rule:    -1,
+				Message: fmt.Sprintf("receiver '%s' is not used", receiver.Name),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// covers returns true if the rule matches all the alerts matched by the other rule.
+// HasRunbook matchers must be equal.
+func (r *RoutingRule) covers(other *RoutingRule) bool {
+	if !coversFold(r.Types, other.Types) {
+		return false
+	}
+
+	if len(r.RouteKeys) > 0 {
+		if len(other.RouteKeys) == 0 {
+			return false
+		}
+
+		for _, key := range other.RouteKeys {
+			if !slices.ContainsFunc(r.RouteKeys, func(pattern string) bool { return routeKeyCovers(pattern, key) }) {
+				return false
+			}
+		}
+	}
+
+	if len(r.RunbookIDs) > 0 {
+		if len(other.RunbookIDs) == 0 {
+			return false
+		}
+
+		for _, id := range other.RunbookIDs {
+			if !slices.ContainsFunc(r.RunbookIDs, func(pattern string) bool { return routeKeyCovers(pattern, id) }) {
+				return false
+			}
+		}
+	}
+
+	if r.HasRunbook != nil && (other.HasRunbook == nil || *other.HasRunbook != *r.HasRunbook) {
+		return false
+	}
+
+	if len(r.Severities) > 0 {
+		if len(other.Severities) == 0 {
+			return false
+		}
+
+		for _, s := range other.Severities {
+			if !slices.Contains(r.Severities, s) {
+				return false
+			}
+		}
+	}
+
+	if len(r.Priorities) > 0 {
+		if len(other.Priorities) == 0 {
+			return false
+		}
+
+		for _, p := range other.Priorities {
+			if !slices.Contains(r.Priorities, p) {
+				return false
+			}
+		}
+	}
+
+	for key, value := range r.Labels {
+		if otherValue, ok := other.Labels[key]; !ok || otherValue != value {
+			return false
+		}
+	}
+
+	if r.TimeOfDay != nil {
+		if other.TimeOfDay == nil {
+			return false
+		}
+
+		if r.TimeOfDay.Start != other.TimeOfDay.Start || r.TimeOfDay.End != other.TimeOfDay.End ||
+			r.TimeOfDay.Location != other.TimeOfDay.Location || !slices.Equal(r.TimeOfDay.Weekdays, other.TimeOfDay.Weekdays) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameMatchersExceptRouteKeys returns true if the rules have the same matchers, ignoring route keys.
+func (r *RoutingRule) sameMatchersExceptRouteKeys(other *RoutingRule) bool {
+	a := &RoutingRule{
+		Types: r.Types, Severities: r.Severities, Priorities: r.Priorities, RunbookIDs: r.RunbookIDs, HasRunbook: r.HasRunbook,
+		Labels: r.Labels, TimeOfDay: r.TimeOfDay,
+	}
+	b := &RoutingRule{
+		Types: other.Types, Severities: other.Severities, Priorities: other.Priorities, RunbookIDs: other.RunbookIDs, HasRunbook: other.HasRunbook,
+		Labels: other.Labels, TimeOfDay: other.TimeOfDay,
+	}
+
+	return a.covers(b) && b.covers(a)
+}
+
+// matchesAll returns true if the rule has no matchers.
+func (r *RoutingRule) matchesAll() bool {
+	return len(r.Types) == 0 && len(r.RouteKeys) == 0 && len(r.Severities) == 0 && len(r.Priorities) == 0 &&
+		len(r.RunbookIDs) == 0 && r.HasRunbook == nil && len(r.Labels) == 0 && r.TimeOfDay == nil
+}
+
+// coversFold returns true if values is empty (matching all), or contains all the other values (case-insensitive).
+func coversFold(values, other []string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	if len(other) == 0 {
+		return false
+	}
+
+	for _, v := range other {
+		if !containsFold(values, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// routeKeyCovers returns true if the route key pattern matches all the route keys matched by the other pattern.
+func routeKeyCovers(pattern, other string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	other = strings.ToLower(strings.TrimSpace(other))
+
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(strings.TrimSuffix(other, "*"), prefix)
+	}
+
+	return pattern == other
+}
+
+func ruleName(label string, index int, rule *RoutingRule) string {
+	if rule.Name == "" {
+		return fmt.Sprintf("%s[%d]", label, index)
+	}
+
+	return fmt.Sprintf("%s[%d] (%s)", label, index, rule.Name)
+}
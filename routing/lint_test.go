@@ -0,0 +1,143 @@
+package routing_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/routing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigLint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid configuration should have no findings", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := routing.ParseFile([]byte(routingYAML))
+		require.NoError(t, err)
+
+		findings, err := f.Lint()
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("shadowed rules should be unreachable", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := routing.Config{
+			Rules: []*routing.RoutingRule{
+				{Name: "db", RouteKeys: []string{"db.*"}, Channels: []string{"C0DB"}},
+				{Name: "db-orders", RouteKeys: []string{"DB.orders"}, Severities: []types.AlertSeverity{types.AlertPanic}, Channels: []string{"C0ORDERS"}},
+				{Name: "payments", Labels: map[string]string{"team": "payments"}, Channels: []string{"C0PAYMENTS"}, Continue: true},
+				{Name: "payments-tier1", Labels: map[string]string{"team": "payments", "tier": "1"}, Channels: []string{"C0TIER1"}},
+				{Name: "web", Types: []string{"deploy"}, RouteKeys: []string{"web"}, Channels: []string{"C0WEB"}},
+				{Name: "web-all", RouteKeys: []string{"web"}, Channels: []string{"C0WEB"}},
+				{Name: "p1", Priorities: []types.AlertPriority{types.AlertPriorityP1}, Channels: []string{"C0P1"}},
+				{Name: "p1-p2", Priorities: []types.AlertPriority{types.AlertPriorityP1, types.AlertPriorityP2}, Channels: []string{"C0P1"}},
+				{Name: "p2", Priorities: []types.AlertPriority{types.AlertPriorityP2}, Channels: []string{"C0P2"}},
+			},
+		}
+
+		findings := cfg.Lint()
+		require.Len(t, findings, 2)
+		assert.Equal(t, routing.LintFinding{
+			Kind:    routing.LintUnreachableRule,
+			Rule:    1,
+			Message: "rules[1] (db-orders) is unreachable, all its alerts are matched by rules[0] (db)",
+		}, findings[0])
+		assert.Equal(t, "rules[8] (p2) is unreachable, all its alerts are matched by rules[7] (p1-p2)", findings[1].Message)
+		assert.Equal(t, "unreachable_rule: rules[1] (db-orders) is unreachable, all its alerts are matched by rules[0] (db)", findings[0].String())
+	})
+
+	t.Run("runbook matchers should be compared", func(t *testing.T) {
+		t.Parallel()
+
+		withRunbook, withoutRunbook := true, false
+
+		cfg := routing.Config{
+			Rules: []*routing.RoutingRule{
+				{Name: "db-runbooks", RunbookIDs: []string{"db/*"}, Channels: []string{"C0DB"}},
+				{Name: "db-disk", RunbookIDs: []string{"DB/disk-full"}, Channels: []string{"C0DISK"}},
+				{Name: "no-runbook", HasRunbook: &withoutRunbook, Channels: []string{"C0TRIAGE"}},
+				{Name: "runbook", HasRunbook: &withRunbook, Channels: []string{"C0RUNBOOK"}},
+				{Name: "no-runbook-web", RouteKeys: []string{"web"}, HasRunbook: &withoutRunbook, Channels: []string{"C0WEB"}},
+			},
+		}
+
+		findings := cfg.Lint()
+		require.Len(t, findings, 2)
+		assert.Equal(t, "rules[1] (db-disk) is unreachable, all its alerts are matched by rules[0] (db-runbooks)", findings[0].Message)
+		assert.Equal(t, "rules[4] (no-runbook-web) is unreachable, all its alerts are matched by rules[2] (no-runbook)", findings[1].Message)
+	})
+
+	t.Run("duplicate route keys and names should be reported", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := routing.Config{
+			Rules: []*routing.RoutingRule{
+				{Name: "payments", RouteKeys: []string{"payments"}, Channels: []string{"C0A"}, Continue: true},
+				{Name: "payments", RouteKeys: []string{"Payments", "billing"}, Channels: []string{"C0B"}},
+				{RouteKeys: []string{"billing"}, Severities: []types.AlertSeverity{types.AlertPanic}, Channels: []string{"C0C"}},
+			},
+		}
+
+		findings := cfg.Lint()
+		require.Len(t, findings, 3)
+		assert.Equal(t, routing.LintDuplicateRuleName, findings[0].Kind)
+		assert.Equal(t, "rules[1] has the same name as rules[0] (payments)", findings[0].Message)
+		assert.Equal(t, routing.LintFinding{
+			Kind:    routing.LintDuplicateRouteKey,
+			Rule:    1,
+			Message: "route key 'Payments' of rules[1] (payments) is also used by rules[0] (payments), with the same matchers",
+		}, findings[1])
+		assert.Equal(t, routing.LintUnreachableRule, findings[2].Kind)
+		assert.Equal(t, 2, findings[2].Rule)
+	})
+
+	t.Run("catch-all rules should make the fallback unreachable", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := routing.Config{
+			Rules: []*routing.RoutingRule{
+				{Name: "all", Channels: []string{"C0ALL"}},
+				{Name: "db", RouteKeys: []string{"db"}, Channels: []string{"C0DB"}},
+			},
+			FallbackChannel: "C0FALLBACK",
+		}
+
+		findings := cfg.Lint()
+		require.Len(t, findings, 2)
+		assert.Equal(t, routing.LintUnreachableRule, findings[0].Kind)
+		assert.Equal(t, routing.LintFinding{
+			Kind:    routing.LintUnreachableFallback,
+			Rule:    -1,
+			Message: "fallback channel 'C0FALLBACK' is unreachable, all alerts are matched by rules[0] (all)",
+		}, findings[1])
+	})
+
+	t.Run("file lint should report routes and unused receivers", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := routing.ParseFile([]byte(`
+receivers:
+  - {name: db, channels: [C0DB]}
+  - {name: unused, channels: [C0UNUSED]}
+routes:
+  - {name: db, routeKeys: [db], receiver: db}
+  - {name: db-again, routeKeys: [db], receiver: db}
+`))
+		require.NoError(t, err)
+
+		findings, err := f.Lint()
+		require.NoError(t, err)
+		require.Len(t, findings, 3)
+		assert.Equal(t, "routes[1] (db-again) is unreachable, all its alerts are matched by routes[0] (db)", findings[0].Message)
+		assert.Equal(t, routing.LintDuplicateRouteKey, findings[1].Kind)
+		assert.Equal(t, routing.LintFinding{Kind: routing.LintUnusedReceiver, Rule: -1, Message: "receiver 'unused' is not used"}, findings[2])
+
+		_, err = (&routing.File{Fallback: "missing"}).Lint()
+		require.Error(t, err)
+	})
+}
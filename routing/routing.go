@@ -0,0 +1,357 @@
+// Package routing routes alerts to Slack channels with ordered rules, so that producer teams can test their
+// routing locally, with the same logic as the Slack Manager:
+//
+//	router, err := routing.NewRouter(routing.Config{
+//	    Rules: []*routing.RoutingRule{
+//	        {Name: "db-panics", RouteKeys: []string{"db.*"}, Severities: []types.AlertSeverity{types.AlertPanic}, Channels: []string{"C0DBONCALL"}},
+//	        {Name: "db", RouteKeys: []string{"db.*"}, Channels: []string{"C0DBALERTS"}},
+//	    },
+//	    FallbackChannel: "C0FALLBACK",
+//	})
+//
+//	channels, err := router.Route(alert)
+//
+// Rules are evaluated in order, and the first matching rule wins, unless it has Continue set, in which case
+// evaluation continues with the next rules and the channels of all matching rules are returned.
+// Alerts matching no rule are routed to the fallback channel, if any.
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+const (
+	// MaxRuleNameLength is the maximum length of a rule name.
+	MaxRuleNameLength = 100
+	// MaxRuleChannelCount is the maximum number of target channels of a rule.
+	MaxRuleChannelCount = 10
+	// MaxRuleMatcherCount is the maximum number of values in each matcher list of a rule, and of labels.
+	MaxRuleMatcherCount = 50
+)
+
+// ErrNoRoute is returned by Router.Route when no rule matches the alert, and there is no fallback channel.
+var ErrNoRoute = errors.New("no routing rule matches the alert")
+
+// RoutingRule routes the alerts matching all its non-empty matchers to one or more Slack channels.
+// An alert matches the rule if its type is one of Types, its route key is one of RouteKeys, its severity
+// is one of Severities, its priority is one of Priorities, its runbook ID is one of RunbookIDs, it has a runbook
+// as required by HasRunbook, its metadata contains all Labels, and the current time is within TimeOfDay.
+// A rule with no matchers matches all alerts.
+//
+// The time of day is compiled on first use, so a rule must not be modified after it is used.
+type RoutingRule struct {
+	// Name is an optional name of the rule, used in errors and lint reports. Maximum length: MaxRuleNameLength characters.
+	Name string `json:"name"`
+
+	// Types matches alerts by type (case-insensitive).
+	Types []string `json:"types"`
+
+	// RouteKeys matches alerts by route key (case-insensitive). A value ending with '*' matches route keys
+	// starting with the value before the '*', as in 'db.*'.
+	RouteKeys []string `json:"routeKeys"`
+
+	// Severities matches alerts by severity.
+	Severities []types.AlertSeverity `json:"severities"`
+
+	// Priorities matches alerts by priority. Alerts without a priority do not match.
+	Priorities []types.AlertPriority `json:"priorities"`
+
+	// RunbookIDs matches alerts by runbook ID (case-insensitive). A value ending with '*' matches runbook IDs
+	// starting with the value before the '*', as in 'db/*'.
+	RunbookIDs []string `json:"runbookIds"`
+
+	// HasRunbook matches alerts with a runbook URL or ID if true, and alerts without a runbook if false,
+	// for example to route alerts without a runbook to a triage channel.
+	HasRunbook *bool `json:"hasRunbook"`
+
+	// Labels matches alerts by metadata values. All labels must be present in the alert metadata, with equal values.
+	Labels map[string]string `json:"labels"`
+
+	// TimeOfDay matches alerts by the time they are routed, for example to route to an on-call channel outside
+	// office hours.
+	TimeOfDay *TimeOfDay `json:"timeOfDay"`
+
+	// Channels are the target Slack channel IDs or names. Between 1 and MaxRuleChannelCount channels are required.
+	Channels []string `json:"channels"`
+
+	// Continue makes the evaluation continue with the next rules when this rule matches.
+	Continue bool `json:"continue"`
+}
+
+// Config is the configuration of a Router.
+type Config struct {
+	// Rules are the routing rules, in evaluation order.
+	Rules []*RoutingRule `json:"rules"`
+
+	// FallbackChannel is the optional Slack channel ID or name of alerts matching no rule.
+	FallbackChannel string `json:"fallbackChannel"`
+}
+
+// Router routes alerts to Slack channels. It is safe for concurrent use.
+type Router struct {
+	rules           []*RoutingRule
+	fallbackChannel string
+}
+
+// NewRouter validates the configuration, and creates a new Router.
+func NewRouter(cfg Config) (*Router, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Router{
+		rules:           slices.Clone(cfg.Rules),
+		fallbackChannel: cfg.FallbackChannel,
+	}, nil
+}
+
+// Validate returns an error if one or more of the rules, or the fallback channel, is invalid.
+func (c *Config) Validate() error {
+	for i, rule := range c.Rules {
+		if rule == nil {
+			return fmt.Errorf("rules[%d] cannot be null", i)
+		}
+
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+	}
+
+	if c.FallbackChannel != "" && !types.SlackChannelIDOrNameRegex.MatchString(c.FallbackChannel) {
+		return fmt.Errorf("fallbackChannel '%s' is not valid", c.FallbackChannel)
+	}
+
+	return nil
+}
+
+// Route returns the Slack channels of the alert, at the current time. See RouteAt.
+func (r *Router) Route(alert *types.Alert) ([]string, error) {
+	return r.RouteAt(alert, time.Now())
+}
+
+// RouteAt returns the Slack channels of the alert, at the given time.
+//
+// An alert with a SlackChannelID is not routed, since SlackChannelID takes precedence over RouteKey: the channel
+// is returned as is. Otherwise, the rules are evaluated in order, and the channels of the matching rules
+// are returned, without duplicates. If no rule matches, the fallback channel is returned, or ErrNoRoute
+// if there is no fallback channel.
+func (r *Router) RouteAt(alert *types.Alert, now time.Time) ([]string, error) {
+	if alert == nil {
+		return nil, errors.New("alert is nil")
+	}
+
+	if alert.SlackChannelID != "" {
+		return []string{alert.SlackChannelID}, nil
+	}
+
+	var channels []string
+
+	for _, rule := range r.rules {
+		if !rule.Matches(alert, now) {
+			continue
+		}
+
+		for _, channel := range rule.Channels {
+			if !slices.Contains(channels, channel) {
+				channels = append(channels, channel)
+			}
+		}
+
+		if !rule.Continue {
+			break
+		}
+	}
+
+	if len(channels) > 0 {
+		return channels, nil
+	}
+
+	if r.fallbackChannel != "" {
+		return []string{r.fallbackChannel}, nil
+	}
+
+	return nil, fmt.Errorf("%w (route key '%s')", ErrNoRoute, alert.RouteKey)
+}
+
+// Rules returns the routing rules, in evaluation order.
+func (r *Router) Rules() []*RoutingRule {
+	return slices.Clone(r.rules)
+}
+
+// FallbackChannel returns the fallback channel, or an empty string if there is none.
+func (r *Router) FallbackChannel() string {
+	return r.fallbackChannel
+}
+
+// Validate returns an error if one or more of the fields are invalid.
+func (r *RoutingRule) Validate() error {
+	if r == nil {
+		return errors.New("rule is nil")
+	}
+
+	if len(r.Name) > MaxRuleNameLength {
+		return fmt.Errorf("name is too long, expected length <=%d", MaxRuleNameLength)
+	}
+
+	if len(r.Channels) == 0 {
+		return errors.New("channels cannot be empty")
+	}
+
+	if len(r.Channels) > MaxRuleChannelCount {
+		return fmt.Errorf("too many channels, expected <=%d", MaxRuleChannelCount)
+	}
+
+	for i, channel := range r.Channels {
+		if !types.SlackChannelIDOrNameRegex.MatchString(channel) {
+			return fmt.Errorf("channels[%d] '%s' is not valid", i, channel)
+		}
+	}
+
+	if len(r.Types) > MaxRuleMatcherCount || len(r.RouteKeys) > MaxRuleMatcherCount || len(r.Severities) > MaxRuleMatcherCount ||
+		len(r.Priorities) > MaxRuleMatcherCount || len(r.RunbookIDs) > MaxRuleMatcherCount || len(r.Labels) > MaxRuleMatcherCount {
+		return fmt.Errorf("too many matcher values, expected <=%d per matcher", MaxRuleMatcherCount)
+	}
+
+	for i, key := range r.RouteKeys {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("routeKeys[%d] cannot be empty", i)
+		}
+
+		if len(key) > types.MaxRouteKeyLength {
+			return fmt.Errorf("routeKeys[%d] is too long, expected length <=%d", i, types.MaxRouteKeyLength)
+		}
+
+		if strings.Contains(strings.TrimSuffix(key, "*"), "*") {
+			return fmt.Errorf("routeKeys[%d] '%s' is not valid, '*' is only allowed at the end", i, key)
+		}
+	}
+
+	for i, id := range r.RunbookIDs {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("runbookIds[%d] cannot be empty", i)
+		}
+
+		if len(id) > types.MaxRunbookIDLength {
+			return fmt.Errorf("runbookIds[%d] is too long, expected length <=%d", i, types.MaxRunbookIDLength)
+		}
+
+		if strings.Contains(strings.TrimSuffix(id, "*"), "*") {
+			return fmt.Errorf("runbookIds[%d] '%s' is not valid, '*' is only allowed at the end", i, id)
+		}
+	}
+
+	for i, s := range r.Severities {
+		if !types.SeverityIsValid(s) {
+			return fmt.Errorf("severities[%d] '%s' is not valid, expected one of [%s]", i, s, strings.Join(types.ValidSeverities(), ", "))
+		}
+	}
+
+	for i, p := range r.Priorities {
+		if !types.PriorityIsValid(p) {
+			return fmt.Errorf("priorities[%d] '%s' is not valid, expected one of [%s]", i, p, strings.Join(types.ValidPriorities(), ", "))
+		}
+	}
+
+	for key := range r.Labels {
+		if key == "" {
+			return errors.New("label keys cannot be empty")
+		}
+	}
+
+	if r.TimeOfDay != nil {
+		if err := r.TimeOfDay.Validate(); err != nil {
+			return fmt.Errorf("timeOfDay: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if the alert matches all the non-empty matchers of the rule, at the given time.
+// Rules with an invalid time of day never match.
+func (r *RoutingRule) Matches(a *types.Alert, now time.Time) bool {
+	if r == nil || a == nil {
+		return false
+	}
+
+	if len(r.Types) > 0 && !containsFold(r.Types, a.Type) {
+		return false
+	}
+
+	if len(r.RouteKeys) > 0 && !slices.ContainsFunc(r.RouteKeys, func(key string) bool { return routeKeyMatches(key, a.RouteKey) }) {
+		return false
+	}
+
+	if len(r.Severities) > 0 && !slices.Contains(r.Severities, a.Severity) {
+		return false
+	}
+
+	if len(r.Priorities) > 0 && !slices.Contains(r.Priorities, a.Priority) {
+		return false
+	}
+
+	if len(r.RunbookIDs) > 0 && !slices.ContainsFunc(r.RunbookIDs, func(id string) bool { return routeKeyMatches(id, a.RunbookID) }) {
+		return false
+	}
+
+	if r.HasRunbook != nil && *r.HasRunbook != a.HasRunbook() {
+		return false
+	}
+
+	for key, expected := range r.Labels {
+		if value, ok := metadataValue(a, key); !ok || value != expected {
+			return false
+		}
+	}
+
+	if r.TimeOfDay != nil && !r.TimeOfDay.Contains(now) {
+		return false
+	}
+
+	return true
+}
+
+// routeKeyMatches returns true if the route key (or runbook ID) matches the pattern, case-insensitively.
+// A pattern ending with '*' matches by prefix.
+func routeKeyMatches(pattern, routeKey string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	routeKey = strings.ToLower(strings.TrimSpace(routeKey))
+
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(routeKey, prefix)
+	}
+
+	return routeKey == pattern
+}
+
+// metadataValue returns the alert metadata value of the key, formatted with fmt.Sprint if it is not a string.
+func metadataValue(a *types.Alert, key string) (string, bool) {
+	value, ok := a.Metadata[key]
+	if !ok || value == nil {
+		return "", false
+	}
+
+	if s, ok := value.(string); ok {
+		return s, true
+	}
+
+	return fmt.Sprint(value), true
+}
+
+func containsFold(values []string, s string) bool {
+	s = strings.TrimSpace(s)
+
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,230 @@
+package routing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/routing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAlert(routeKey string, severity types.AlertSeverity) *types.Alert {
+	alert := types.NewErrorAlert()
+	alert.RouteKey = routeKey
+	alert.Severity = severity
+	alert.Metadata = map[string]any{}
+	return alert
+}
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC) // Wednesday
+
+	router, err := routing.NewRouter(routing.Config{
+		Rules: []*routing.RoutingRule{
+			{Name: "db-panics", RouteKeys: []string{"db.*"}, Severities: []types.AlertSeverity{types.AlertPanic}, Channels: []string{"C0DBONCALL"}, Continue: true},
+			{Name: "db", RouteKeys: []string{"DB.*"}, Channels: []string{"C0DBALERTS"}},
+			{Name: "payments", Labels: map[string]string{"team": "payments", "tier": "1"}, Channels: []string{"C0PAYMENTS", "C0TIER1"}},
+			{Name: "deploys", Types: []string{"deploy"}, Channels: []string{"C0DEPLOYS"}},
+			{Name: "urgent", Priorities: []types.AlertPriority{types.AlertPriorityP1, types.AlertPriorityP2}, Channels: []string{"C0URGENT"}},
+			{Name: "never", RouteKeys: []string{"db.orders"}, Channels: []string{"C0NEVER"}},
+		},
+		FallbackChannel: "C0FALLBACK",
+	})
+	require.NoError(t, err)
+
+	t.Run("first matching rule should win", func(t *testing.T) {
+		t.Parallel()
+
+		channels, err := router.RouteAt(newAlert("db.orders", types.AlertError), now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C0DBALERTS"}, channels)
+	})
+
+	t.Run("continue should collect the channels of the next matching rules", func(t *testing.T) {
+		t.Parallel()
+
+		channels, err := router.RouteAt(newAlert("db.orders", types.AlertPanic), now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C0DBONCALL", "C0DBALERTS"}, channels)
+	})
+
+	t.Run("labels and types should match metadata and alert type", func(t *testing.T) {
+		t.Parallel()
+
+		alert := newAlert("billing", types.AlertWarning)
+		alert.Metadata["team"] = "payments"
+		alert.Metadata["tier"] = 1
+
+		channels, err := router.RouteAt(alert, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C0PAYMENTS", "C0TIER1"}, channels)
+
+		alert.Metadata["tier"] = 2
+		alert.Type = "Deploy"
+
+		channels, err = router.RouteAt(alert, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C0DEPLOYS"}, channels)
+	})
+
+	t.Run("priorities should match the alert priority", func(t *testing.T) {
+		t.Parallel()
+
+		alert := newAlert("web", types.AlertWarning)
+		alert.Priority = types.AlertPriorityP2
+
+		channels, err := router.RouteAt(alert, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C0URGENT"}, channels)
+
+		alert.Priority = types.AlertPriorityP3
+
+		channels, err = router.RouteAt(alert, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C0FALLBACK"}, channels)
+	})
+
+	t.Run("alerts matching no rule should use the fallback channel", func(t *testing.T) {
+		t.Parallel()
+
+		channels, err := router.RouteAt(newAlert("web", types.AlertError), now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C0FALLBACK"}, channels)
+	})
+
+	t.Run("alerts with a channel ID should not be routed", func(t *testing.T) {
+		t.Parallel()
+
+		alert := newAlert("db.orders", types.AlertPanic)
+		alert.SlackChannelID = "C0EXPLICIT"
+
+		channels, err := router.Route(alert)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C0EXPLICIT"}, channels)
+
+		_, err = router.Route(nil)
+		require.EqualError(t, err, "alert is nil")
+	})
+
+	t.Run("alerts matching no rule without fallback should return error", func(t *testing.T) {
+		t.Parallel()
+
+		router, err := routing.NewRouter(routing.Config{Rules: []*routing.RoutingRule{{RouteKeys: []string{"db"}, Channels: []string{"C0DB"}}}})
+		require.NoError(t, err)
+
+		_, err = router.RouteAt(newAlert("web", types.AlertError), now)
+		require.ErrorIs(t, err, routing.ErrNoRoute)
+		require.EqualError(t, err, "no routing rule matches the alert (route key 'web')")
+	})
+}
+
+func TestRouterTimeOfDay(t *testing.T) {
+	t.Parallel()
+
+	router, err := routing.NewRouter(routing.Config{
+		Rules: []*routing.RoutingRule{
+			{Name: "office-hours", TimeOfDay: &routing.TimeOfDay{Start: "08:00", End: "16:00", Weekdays: []string{"mon", "Tuesday", "wed", "thu", "fri"}, Location: "Europe/Oslo"}, Channels: []string{"C0OFFICE"}},
+			{Name: "night", TimeOfDay: &routing.TimeOfDay{Start: "22:00", End: "06:00", Weekdays: []string{"friday"}}, Channels: []string{"C0NIGHT"}},
+		},
+		FallbackChannel: "C0ONCALL",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected string
+	}{
+		{"weekday in office hours (local time)", time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC), "C0OFFICE"},
+		{"weekday before office hours (local time)", time.Date(2026, 10, 14, 5, 59, 0, 0, time.UTC), "C0ONCALL"},
+		{"end should be exclusive", time.Date(2026, 10, 14, 14, 0, 0, 0, time.UTC), "C0ONCALL"},
+		{"weekend in office hours", time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC), "C0ONCALL"},
+		{"friday night before midnight", time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC), "C0NIGHT"},
+		{"friday night after midnight", time.Date(2026, 10, 17, 5, 0, 0, 0, time.UTC), "C0NIGHT"},
+		{"thursday night after midnight", time.Date(2026, 10, 16, 5, 0, 0, 0, time.UTC), "C0ONCALL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			channels, err := router.RouteAt(newAlert("web", types.AlertError), tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.expected}, channels)
+		})
+	}
+}
+
+func TestRouterRunbooks(t *testing.T) {
+	t.Parallel()
+
+	hasRunbook := false
+
+	router, err := routing.NewRouter(routing.Config{
+		Rules: []*routing.RoutingRule{
+			{Name: "db-runbooks", RunbookIDs: []string{"db/*"}, Channels: []string{"C0DB"}},
+			{Name: "no-runbook", HasRunbook: &hasRunbook, Channels: []string{"C0TRIAGE"}},
+		},
+		FallbackChannel: "C0FALLBACK",
+	})
+	require.NoError(t, err)
+
+	alert := newAlert("web", types.AlertError)
+	alert.RunbookID = "DB/disk-full"
+
+	channels, err := router.Route(alert)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C0DB"}, channels)
+
+	alert.RunbookID = ""
+
+	channels, err = router.Route(alert)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C0TRIAGE"}, channels)
+
+	alert.RunbookURL = "https://runbooks.example.com/web"
+
+	channels, err = router.Route(alert)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C0FALLBACK"}, channels)
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  routing.Config
+		err  string
+	}{
+		{"nil rule", routing.Config{Rules: []*routing.RoutingRule{nil}}, "rules[0] cannot be null"},
+		{"no channels", routing.Config{Rules: []*routing.RoutingRule{{RouteKeys: []string{"db"}}}}, "rules[0]: channels cannot be empty"},
+		{"invalid channel", routing.Config{Rules: []*routing.RoutingRule{{Channels: []string{"#db"}}}}, "rules[0]: channels[0] '#db' is not valid"},
+		{"empty route key", routing.Config{Rules: []*routing.RoutingRule{{RouteKeys: []string{" "}, Channels: []string{"C0DB"}}}}, "rules[0]: routeKeys[0] cannot be empty"},
+		{"wildcard in the middle", routing.Config{Rules: []*routing.RoutingRule{{RouteKeys: []string{"db.*.orders"}, Channels: []string{"C0DB"}}}}, "rules[0]: routeKeys[0] 'db.*.orders' is not valid, '*' is only allowed at the end"},
+		{"empty runbook ID", routing.Config{Rules: []*routing.RoutingRule{{RunbookIDs: []string{""}, Channels: []string{"C0DB"}}}}, "rules[0]: runbookIds[0] cannot be empty"},
+		{"runbook ID wildcard in the middle", routing.Config{Rules: []*routing.RoutingRule{{RunbookIDs: []string{"db/*/disk"}, Channels: []string{"C0DB"}}}}, "rules[0]: runbookIds[0] 'db/*/disk' is not valid, '*' is only allowed at the end"},
+		{"invalid severity", routing.Config{Rules: []*routing.RoutingRule{{Severities: []types.AlertSeverity{"critical"}, Channels: []string{"C0DB"}}}}, "rules[0]: severities[0] 'critical' is not valid, expected one of [panic, error, warning, resolved, info]"},
+		{"invalid priority", routing.Config{Rules: []*routing.RoutingRule{{Priorities: []types.AlertPriority{"high"}, Channels: []string{"C0DB"}}}}, "rules[0]: priorities[0] 'high' is not valid, expected one of [p1, p2, p3, p4, p5]"},
+		{"invalid time", routing.Config{Rules: []*routing.RoutingRule{{TimeOfDay: &routing.TimeOfDay{Start: "8", End: "16:00"}, Channels: []string{"C0DB"}}}}, "rules[0]: timeOfDay: start: '8' is not valid, expected format HH:MM"},
+		{"empty interval", routing.Config{Rules: []*routing.RoutingRule{{TimeOfDay: &routing.TimeOfDay{Start: "08:00", End: "08:00"}, Channels: []string{"C0DB"}}}}, "rules[0]: timeOfDay: end must be different from start"},
+		{"invalid weekday", routing.Config{Rules: []*routing.RoutingRule{{TimeOfDay: &routing.TimeOfDay{Start: "08:00", End: "16:00", Weekdays: []string{"mo"}}, Channels: []string{"C0DB"}}}}, "rules[0]: timeOfDay: weekdays[0] 'mo' is not valid, expected a day name such as 'monday' or 'mon'"},
+		{"invalid fallback", routing.Config{FallbackChannel: "#fallback"}, "fallbackChannel '#fallback' is not valid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := routing.NewRouter(tt.cfg)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+
+	_, err := routing.NewRouter(routing.Config{Rules: []*routing.RoutingRule{{TimeOfDay: &routing.TimeOfDay{Start: "08:00", End: "16:00", Location: "Mars/Olympus"}, Channels: []string{"C0DB"}}}})
+	require.ErrorContains(t, err, "rules[0]: timeOfDay: location 'Mars/Olympus' is not valid")
+}
@@ -0,0 +1,149 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timeOfDayLayout is the layout of TimeOfDay.Start and TimeOfDay.End.
+const timeOfDayLayout = "15:04"
+
+// TimeOfDay matches times within a daily interval, optionally on specific weekdays, in a time zone.
+// It is compiled on first use, so it must not be modified after it is used.
+type TimeOfDay struct {
+	// Start is the start of the interval, inclusive, on the format 'HH:MM'. Required.
+	Start string `json:"start"`
+
+	// End is the end of the interval, exclusive, on the format 'HH:MM'. Required, and must be different from Start.
+	// If End is before Start, the interval spans midnight, as in '22:00' to '06:00'.
+	End string `json:"end"`
+
+	// Weekdays are the optional days of the week when the interval starts, as in 'monday' or 'mon' (case-insensitive).
+	// For intervals spanning midnight, the time after midnight belongs to the previous day.
+	Weekdays []string `json:"weekdays"`
+
+	// Location is the optional IANA time zone name, as in 'Europe/Oslo'. Defaults to UTC.
+	Location string `json:"location"`
+
+	compileOnce sync.Once
+	compiled    *compiledTimeOfDay
+	compileErr  error
+}
+
+type compiledTimeOfDay struct {
+	start    time.Duration
+	end      time.Duration
+	weekdays []time.Weekday
+	location *time.Location
+}
+
+// Validate returns an error if one or more of the fields are invalid.
+func (t *TimeOfDay) Validate() error {
+	if t == nil {
+		return errors.New("time of day is nil")
+	}
+
+	return t.compile()
+}
+
+// Contains returns true if the given time is within the interval. An invalid TimeOfDay contains no times.
+func (t *TimeOfDay) Contains(now time.Time) bool {
+	if t == nil || t.compile() != nil {
+		return false
+	}
+
+	c := t.compiled
+	local := now.In(c.location)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+	day := local.Weekday()
+
+	if c.start < c.end {
+		if sinceMidnight < c.start || sinceMidnight >= c.end {
+			return false
+		}
+	} else {
+		switch {
+		case sinceMidnight >= c.start:
+		case sinceMidnight < c.end:
+			day = (day + 6) % 7
+		default:
+			return false
+		}
+	}
+
+	return len(c.weekdays) == 0 || slices.Contains(c.weekdays, day)
+}
+
+func (t *TimeOfDay) compile() error {
+	t.compileOnce.Do(func() {
+		t.compiled, t.compileErr = compileTimeOfDay(t)
+	})
+
+	return t.compileErr
+}
+
+func compileTimeOfDay(t *TimeOfDay) (*compiledTimeOfDay, error) {
+	start, err := parseTimeOfDay(t.Start)
+	if err != nil {
+		return nil, fmt.Errorf("start: %w", err)
+	}
+
+	end, err := parseTimeOfDay(t.End)
+	if err != nil {
+		return nil, fmt.Errorf("end: %w", err)
+	}
+
+	if start == end {
+		return nil, errors.New("end must be different from start")
+	}
+
+	c := &compiledTimeOfDay{start: start, end: end, location: time.UTC}
+
+	for i, name := range t.Weekdays {
+		day, ok := parseWeekday(name)
+		if !ok {
+			return nil, fmt.Errorf("weekdays[%d] '%s' is not valid, expected a day name such as 'monday' or 'mon'", i, name)
+		}
+
+		c.weekdays = append(c.weekdays, day)
+	}
+
+	if t.Location != "" {
+		if c.location, err = time.LoadLocation(t.Location); err != nil {
+			return nil, fmt.Errorf("location '%s' is not valid: %w", t.Location, err)
+		}
+	}
+
+	return c, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	parsed, err := time.Parse(timeOfDayLayout, strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not valid, expected format HH:MM", s)
+	}
+
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if len(name) < 3 {
+		return 0, false
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+
+	return 0, false
+}
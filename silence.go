@@ -0,0 +1,251 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxSilenceMatcherCount is the maximum number of matchers in a silence.
+	MaxSilenceMatcherCount = 20
+	// MaxSilenceMatcherValueLength is the maximum length of a silence matcher value.
+	MaxSilenceMatcherValueLength = 1000
+	// MaxSilenceCommentLength is the maximum length of a silence comment.
+	MaxSilenceCommentLength = 1000
+	// MaxSilenceCreatedByLength is the maximum length of the silence creator.
+	MaxSilenceCreatedByLength = 200
+	// MaxSilenceDuration is the maximum duration of a silence.
+	MaxSilenceDuration = 90 * 24 * time.Hour
+)
+
+// Silence suppresses alerts matching all of its matchers, between StartsAt and EndsAt.
+// It works like an Alertmanager silence, with matchers over alert fields instead of labels.
+type Silence struct {
+	// ID is an optional identifier of the silence.
+	ID string `json:"id"`
+
+	// Matchers are the conditions an alert must satisfy to be silenced. All matchers must match.
+	// Between 1 and MaxSilenceMatcherCount matchers are required, and at least one must not match an empty value,
+	// to prevent silencing all alerts by mistake.
+	Matchers []*SilenceMatcher `json:"matchers"`
+
+	// StartsAt is the start of the silence. Defaults to the current time.
+	StartsAt time.Time `json:"startsAt"`
+
+	// EndsAt is the expiration of the silence, exclusive. Required, and must be after StartsAt,
+	// and at most MaxSilenceDuration after it.
+	EndsAt time.Time `json:"endsAt"`
+
+	// Comment is an optional description of the silence. It is automatically truncated at MaxSilenceCommentLength characters.
+	Comment string `json:"comment"`
+
+	// CreatedBy is an optional name of the user or system creating the silence.
+	// It is automatically truncated at MaxSilenceCreatedByLength characters.
+	CreatedBy string `json:"createdBy"`
+}
+
+// SilenceMatcher matches an alert field against a value or regular expression.
+// Regular expressions are compiled on first use, so a matcher must not be modified after it is used.
+type SilenceMatcher struct {
+	// Field is the alert field name, as in ValidAlertFields, or 'metadata.<key>' for metadata values.
+	// Missing metadata values are matched as empty strings.
+	Field string `json:"field"`
+
+	// Operator is the match operator. Defaults to SilenceMatchEqual.
+	Operator SilenceMatchOperator `json:"operator"`
+
+	// Value is the value to compare with, or the regular expression for the regex operators.
+	// Regular expressions are anchored, i.e. they must match the full field value.
+	// Maximum length: MaxSilenceMatcherValueLength characters.
+	Value string `json:"value"`
+
+	regexOnce sync.Once
+	regex     *regexp.Regexp
+	regexErr  error
+}
+
+// Clean normalizes the silence fields, and applies default values.
+func (s *Silence) Clean() {
+	if s == nil {
+		return
+	}
+
+	s.ID = strings.TrimSpace(s.ID)
+	s.Comment = strings.TrimSpace(s.Comment)
+	s.CreatedBy = strings.ReplaceAll(strings.TrimSpace(s.CreatedBy), "\n", " ")
+
+	if s.StartsAt.IsZero() {
+		s.StartsAt = time.Now().UTC()
+	}
+
+	if utf8.RuneCountInString(s.Comment) > MaxSilenceCommentLength {
+		s.Comment = strings.TrimSpace(truncateString(s.Comment, MaxSilenceCommentLength-3)) + "..."
+	}
+
+	if utf8.RuneCountInString(s.CreatedBy) > MaxSilenceCreatedByLength {
+		s.CreatedBy = strings.TrimSpace(truncateString(s.CreatedBy, MaxSilenceCreatedByLength-3)) + "..."
+	}
+
+	for _, m := range s.Matchers {
+		if m == nil {
+			continue
+		}
+
+		m.Field = strings.TrimSpace(m.Field)
+		m.Operator = SilenceMatchOperator(strings.TrimSpace(string(m.Operator)))
+
+		if m.Operator == "" {
+			m.Operator = SilenceMatchEqual
+		}
+	}
+}
+
+// Validate returns an error if one or more of the fields are invalid. Call Clean first.
+func (s *Silence) Validate() error {
+	if s == nil {
+		return errors.New("silence is nil")
+	}
+
+	if len(s.Matchers) == 0 {
+		return errors.New("matchers cannot be empty")
+	}
+
+	if len(s.Matchers) > MaxSilenceMatcherCount {
+		return fmt.Errorf("too many matchers, expected <=%d", MaxSilenceMatcherCount)
+	}
+
+	matchesAll := true
+
+	for i, m := range s.Matchers {
+		if m == nil {
+			return fmt.Errorf("matchers[%d] cannot be null", i)
+		}
+
+		if !AlertFieldIsValid(m.Field) {
+			return fmt.Errorf("matchers[%d].field '%s' is not valid, expected one of [%s] or 'metadata.<key>'", i, m.Field, strings.Join(ValidAlertFields(), ", "))
+		}
+
+		if !SilenceMatchOperatorIsValid(m.Operator) {
+			return fmt.Errorf("matchers[%d].operator '%s' is not valid, expected one of [%s]", i, m.Operator, strings.Join(ValidSilenceMatchOperators(), ", "))
+		}
+
+		if utf8.RuneCountInString(m.Value) > MaxSilenceMatcherValueLength {
+			return fmt.Errorf("matchers[%d].value is too long, expected length <=%d", i, MaxSilenceMatcherValueLength)
+		}
+
+		if err := m.compile(); err != nil {
+			return fmt.Errorf("matchers[%d].value is not a valid regular expression: %w", i, err)
+		}
+
+		if !m.matchValue("") {
+			matchesAll = false
+		}
+	}
+
+	if matchesAll {
+		return errors.New("at least one matcher must not match an empty value")
+	}
+
+	if s.StartsAt.IsZero() || s.EndsAt.IsZero() {
+		return errors.New("startsAt and endsAt are required")
+	}
+
+	if !s.EndsAt.After(s.StartsAt) {
+		return errors.New("endsAt must be after startsAt")
+	}
+
+	if s.EndsAt.Sub(s.StartsAt) > MaxSilenceDuration {
+		return fmt.Errorf("silence is too long, expected duration <=%s", MaxSilenceDuration)
+	}
+
+	if utf8.RuneCountInString(s.Comment) > MaxSilenceCommentLength {
+		return fmt.Errorf("comment is too long, expected length <=%d", MaxSilenceCommentLength)
+	}
+
+	if utf8.RuneCountInString(s.CreatedBy) > MaxSilenceCreatedByLength {
+		return fmt.Errorf("createdBy is too long, expected length <=%d", MaxSilenceCreatedByLength)
+	}
+
+	return nil
+}
+
+// Evaluate returns true if the silence is active at the current time, and the alert matches all matchers.
+func (s *Silence) Evaluate(a *Alert) bool {
+	return s.EvaluateAt(a, time.Now())
+}
+
+// EvaluateAt returns true if the silence is active at the given time, and the alert matches all matchers.
+// Matchers with invalid fields, operators or regular expressions never match.
+func (s *Silence) EvaluateAt(a *Alert, now time.Time) bool {
+	if s == nil || a == nil || len(s.Matchers) == 0 || !s.IsActive(now) {
+		return false
+	}
+
+	for _, m := range s.Matchers {
+		if !m.Matches(a) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsActive returns true if the given time is between StartsAt (inclusive) and EndsAt (exclusive).
+func (s *Silence) IsActive(now time.Time) bool {
+	return !now.Before(s.StartsAt) && now.Before(s.EndsAt)
+}
+
+// IsExpired returns true if the silence has ended at the given time.
+func (s *Silence) IsExpired(now time.Time) bool {
+	return !now.Before(s.EndsAt)
+}
+
+// Matches returns true if the alert field satisfies the matcher.
+func (m *SilenceMatcher) Matches(a *Alert) bool {
+	if m == nil || a == nil {
+		return false
+	}
+
+	if !AlertFieldIsValid(m.Field) {
+		return false
+	}
+
+	value, _ := alertFieldValue(a, m.Field)
+
+	return m.matchValue(value)
+}
+
+func (m *SilenceMatcher) matchValue(value string) bool {
+	switch m.Operator {
+	case SilenceMatchEqual, "":
+		return value == m.Value
+	case SilenceMatchNotEqual:
+		return value != m.Value
+	case SilenceMatchRegex, SilenceMatchNotRegex:
+		if m.compile() != nil {
+			return false
+		}
+
+		return m.regex.MatchString(value) == (m.Operator == SilenceMatchRegex)
+	default:
+		return false
+	}
+}
+
+// compile compiles the matcher regular expression once, for the regex operators.
+func (m *SilenceMatcher) compile() error {
+	if m.Operator != SilenceMatchRegex && m.Operator != SilenceMatchNotRegex {
+		return nil
+	}
+
+	m.regexOnce.Do(func() {
+		m.regex, m.regexErr = regexp.Compile("^(?:" + m.Value + ")$")
+	})
+
+	return m.regexErr
+}
@@ -0,0 +1,37 @@
+package types
+
+// SilenceMatchOperator is the operator of a silence matcher, using the same syntax as Alertmanager silences.
+type SilenceMatchOperator string
+
+const (
+	// SilenceMatchEqual matches alert field values equal to the matcher value. This is the default.
+	SilenceMatchEqual SilenceMatchOperator = "="
+
+	// SilenceMatchNotEqual matches alert field values not equal to the matcher value.
+	SilenceMatchNotEqual SilenceMatchOperator = "!="
+
+	// SilenceMatchRegex matches alert field values fully matching the matcher regular expression.
+	SilenceMatchRegex SilenceMatchOperator = "=~"
+
+	// SilenceMatchNotRegex matches alert field values not fully matching the matcher regular expression.
+	SilenceMatchNotRegex SilenceMatchOperator = "!~"
+)
+
+// SilenceMatchOperatorIsValid returns true if the provided SilenceMatchOperator is valid.
+func SilenceMatchOperatorIsValid(s SilenceMatchOperator) bool {
+	switch s {
+	case SilenceMatchEqual, SilenceMatchNotEqual, SilenceMatchRegex, SilenceMatchNotRegex:
+		return true
+	}
+	return false
+}
+
+// ValidSilenceMatchOperators returns a slice of valid SilenceMatchOperator values.
+func ValidSilenceMatchOperators() []string {
+	return []string{
+		string(SilenceMatchEqual),
+		string(SilenceMatchNotEqual),
+		string(SilenceMatchRegex),
+		string(SilenceMatchNotRegex),
+	}
+}
@@ -0,0 +1,19 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSilenceMatchOperatorValidation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.SilenceMatchOperatorIsValid(types.SilenceMatchEqual))
+	assert.True(t, types.SilenceMatchOperatorIsValid(types.SilenceMatchNotEqual))
+	assert.True(t, types.SilenceMatchOperatorIsValid(types.SilenceMatchRegex))
+	assert.True(t, types.SilenceMatchOperatorIsValid(types.SilenceMatchNotRegex))
+	assert.False(t, types.SilenceMatchOperatorIsValid("=="))
+	assert.Len(t, types.ValidSilenceMatchOperators(), 4)
+}
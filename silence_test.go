@@ -0,0 +1,85 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSilence(matchers ...*types.SilenceMatcher) *types.Silence {
+	s := &types.Silence{
+		Matchers: matchers,
+		EndsAt:   time.Now().Add(time.Hour),
+	}
+
+	s.Clean()
+
+	return s
+}
+
+func TestSilenceValidate(t *testing.T) {
+	t.Parallel()
+
+	s := newSilence(&types.SilenceMatcher{Field: " host ", Value: "db-01"})
+	assert.Equal(t, "host", s.Matchers[0].Field)
+	assert.Equal(t, types.SilenceMatchEqual, s.Matchers[0].Operator)
+	assert.False(t, s.StartsAt.IsZero())
+	require.NoError(t, s.Validate())
+
+	tests := []struct {
+		silence  *types.Silence
+		expected string
+	}{
+		{newSilence(), "matchers cannot be empty"},
+		{newSilence(nil), "matchers[0] cannot be null"},
+		{newSilence(&types.SilenceMatcher{Field: "foo", Value: "x"}), "matchers[0].field 'foo' is not valid"},
+		{newSilence(&types.SilenceMatcher{Field: "host", Operator: "==", Value: "x"}), "matchers[0].operator '==' is not valid, expected one of [=, !=, =~, !~]"},
+		{newSilence(&types.SilenceMatcher{Field: "host", Operator: "=~", Value: "("}), "matchers[0].value is not a valid regular expression"},
+		{newSilence(&types.SilenceMatcher{Field: "host", Operator: "=~", Value: ".*"}), "at least one matcher must not match an empty value"},
+		{newSilence(&types.SilenceMatcher{Field: "host", Operator: "!=", Value: "db-01"}), "at least one matcher must not match an empty value"},
+	}
+
+	for _, test := range tests {
+		require.ErrorContains(t, test.silence.Validate(), test.expected)
+	}
+
+	s = newSilence(&types.SilenceMatcher{Field: "host", Value: "db-01"})
+	s.EndsAt = s.StartsAt
+	require.EqualError(t, s.Validate(), "endsAt must be after startsAt")
+
+	s.EndsAt = s.StartsAt.Add(types.MaxSilenceDuration + time.Second)
+	require.EqualError(t, s.Validate(), "silence is too long, expected duration <=2160h0m0s")
+}
+
+func TestSilenceEvaluate(t *testing.T) {
+	t.Parallel()
+
+	s := newSilence(
+		&types.SilenceMatcher{Field: "routeKey", Value: "db"},
+		&types.SilenceMatcher{Field: "host", Operator: "=~", Value: "db-0[1-3]"},
+		&types.SilenceMatcher{Field: "severity", Operator: "!=", Value: "panic"},
+		&types.SilenceMatcher{Field: "metadata.cluster", Operator: "!~", Value: "us-.*"},
+	)
+	require.NoError(t, s.Validate())
+
+	match := &types.Alert{RouteKey: "db", Host: "db-02", Severity: types.AlertError, Metadata: map[string]any{"cluster": "eu-1"}}
+	assert.True(t, s.Evaluate(match))
+
+	noCluster := &types.Alert{RouteKey: "db", Host: "db-02", Severity: types.AlertError}
+	assert.True(t, s.Evaluate(noCluster), "missing metadata is matched as an empty value")
+
+	assert.False(t, s.Evaluate(&types.Alert{RouteKey: "db", Host: "db-04", Severity: types.AlertError}))
+	assert.False(t, s.Evaluate(&types.Alert{RouteKey: "db", Host: "db-012", Severity: types.AlertError}), "regex is anchored")
+	assert.False(t, s.Evaluate(&types.Alert{RouteKey: "db", Host: "db-02", Severity: types.AlertPanic}))
+	assert.False(t, s.Evaluate(&types.Alert{RouteKey: "db", Host: "db-02", Metadata: map[string]any{"cluster": "us-1"}}))
+	assert.False(t, s.Evaluate(nil))
+
+	assert.False(t, s.EvaluateAt(match, s.StartsAt.Add(-time.Second)))
+	assert.True(t, s.EvaluateAt(match, s.StartsAt))
+	assert.False(t, s.EvaluateAt(match, s.EndsAt))
+	assert.False(t, s.IsExpired(s.StartsAt))
+	assert.True(t, s.IsExpired(s.EndsAt))
+}
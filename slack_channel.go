@@ -0,0 +1,64 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// slackChannelIDRegex matches Slack conversation IDs: a type prefix (C for public channels, G for private
+	// channels and group DMs, D for DMs), followed by at least 8 upper case letters and digits.
+	slackChannelIDRegex = regexp.MustCompile(`^[CGD][A-Z0-9]{8,}$`)
+
+	// slackChannelNameRegex matches Slack channel names, which are lower case.
+	slackChannelNameRegex = regexp.MustCompile(`^[0-9a-z\-_]+$`)
+)
+
+// IsChannelID reports whether s looks like a Slack channel ID, such as 'C0123456789', as opposed to a channel name.
+//
+// Channel names are lower case, so upper case values are unambiguous. Lower case values, such as 'c0123456789',
+// are only considered IDs if the prefix is followed by a digit, so that names like 'deployments' are not.
+func IsChannelID(s string) bool {
+	s = strings.TrimSpace(s)
+
+	if len(s) > MaxSlackChannelIDLength {
+		return false
+	}
+
+	if slackChannelIDRegex.MatchString(s) {
+		return true
+	}
+
+	return len(s) > 1 && s[1] >= '0' && s[1] <= '9' && slackChannelIDRegex.MatchString(strings.ToUpper(s))
+}
+
+// IsChannelName reports whether s is a valid Slack channel name, such as 'alerts-prod' or '#alerts-prod':
+// lower case letters, digits, hyphens and underscores, with an optional leading '#'.
+// Values that look like channel IDs (see IsChannelID) are not considered names.
+func IsChannelName(s string) bool {
+	s = trimChannelPrefix(strings.TrimSpace(s))
+
+	return len(s) <= MaxSlackChannelIDLength && slackChannelNameRegex.MatchString(s) && !IsChannelID(s)
+}
+
+// NormalizeChannel normalizes a Slack channel ID or name: surrounding whitespace and a leading '#' are removed,
+// and channel IDs are upper cased. Other values, such as channel names, are left as is.
+// It is used by Clean for all channel fields, and may be used to normalize channels in routing configuration.
+func NormalizeChannel(s string) string {
+	s = trimChannelPrefix(strings.TrimSpace(s))
+
+	if IsChannelID(s) {
+		return strings.ToUpper(s)
+	}
+
+	return s
+}
+
+// trimChannelPrefix removes a leading '#' from a channel name, unless nothing else remains.
+func trimChannelPrefix(s string) string {
+	if len(s) > 1 && s[0] == '#' {
+		return strings.TrimSpace(s[1:])
+	}
+
+	return s
+}
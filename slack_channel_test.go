@@ -0,0 +1,70 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsChannelIDAndName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		id    bool
+		name  bool
+	}{
+		{"C0123456789", true, false},
+		{"G01ABCDEFGH", true, false},
+		{"D024BE91L", true, false},
+		{" c0123456789 ", true, false},
+		{"CABCDEFGH", true, false},
+		{"cabcdefgh", false, true},
+		{"deployments", false, true},
+		{"alerts-prod", false, true},
+		{"#alerts_prod", false, true},
+		{"general", false, true},
+		{"C123", false, false},
+		{"Alerts", false, false},
+		{"foo bar", false, false},
+		{"#", false, false},
+		{"", false, false},
+		{strings.Repeat("a", types.MaxSlackChannelIDLength+1), false, false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.id, types.IsChannelID(test.value), "IsChannelID(%q)", test.value)
+		assert.Equal(t, test.name, types.IsChannelName(test.value), "IsChannelName(%q)", test.value)
+	}
+}
+
+func TestNormalizeChannel(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "C0123456789", types.NormalizeChannel(" c0123456789 "))
+	assert.Equal(t, "C0123456789", types.NormalizeChannel("#C0123456789"))
+	assert.Equal(t, "deployments", types.NormalizeChannel("deployments"))
+	assert.Equal(t, "alerts-prod", types.NormalizeChannel(" #alerts-prod"))
+	assert.Equal(t, "#", types.NormalizeChannel("#"))
+	assert.Empty(t, types.NormalizeChannel(" "))
+
+	a := &types.Alert{
+		SlackChannelID:  "#alerts-prod",
+		Header:          "foo",
+		RejectionTarget: &types.RejectionTarget{SlackChannelID: "c0123456789"},
+		Escalation:      []*types.Escalation{{DelaySeconds: types.MinEscalationDelaySeconds, Severity: types.AlertError, MoveToChannel: "#deployments"}},
+	}
+	a.Clean()
+	require.NoError(t, a.Validate())
+	assert.Equal(t, "alerts-prod", a.SlackChannelID)
+	assert.Equal(t, "C0123456789", a.RejectionTarget.SlackChannelID)
+	assert.Equal(t, "deployments", a.Escalation[0].MoveToChannel)
+
+	cmd := &types.MoveIssueCommand{CorrelationID: "abc", SlackChannelID: "#alerts-prod", TargetChannelID: "c0123456789"}
+	cmd.Clean()
+	assert.Equal(t, "alerts-prod", cmd.SlackChannelID)
+	assert.Equal(t, "C0123456789", cmd.TargetChannelID)
+}
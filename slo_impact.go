@@ -0,0 +1,190 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxSLONameLength is the maximum length of the SLO name of an SLOImpact.
+	MaxSLONameLength = 100
+
+	// MaxSLOWindowSeconds is the maximum burn rate window of an SLOImpact (90 days).
+	MaxSLOWindowSeconds = 90 * 24 * 60 * 60
+
+	// SLOBurnRateCritical is the lowest burn rate of SLOBurnCritical: at this rate, 2% of a 30 day error budget
+	// is consumed in 1 hour.
+	SLOBurnRateCritical = 14.4
+
+	// SLOBurnRateHigh is the lowest burn rate of SLOBurnHigh: at this rate, 5% of a 30 day error budget is consumed
+	// in 6 hours.
+	SLOBurnRateHigh = 6.0
+)
+
+// SLOBurnLevel classifies the burn rate of an SLOImpact, so that Slack posts and reports display it consistently.
+type SLOBurnLevel string
+
+const (
+	// SLOBurnCritical is a burn rate of at least SLOBurnRateCritical, exhausting a 30 day budget in about 2 days.
+	SLOBurnCritical SLOBurnLevel = "critical"
+
+	// SLOBurnHigh is a burn rate of at least SLOBurnRateHigh, exhausting a 30 day budget in about 5 days.
+	SLOBurnHigh SLOBurnLevel = "high"
+
+	// SLOBurnElevated is a burn rate above 1, exhausting the budget before the end of the SLO period.
+	SLOBurnElevated SLOBurnLevel = "elevated"
+
+	// SLOBurnNormal is a burn rate of at most 1, within the error budget.
+	SLOBurnNormal SLOBurnLevel = "normal"
+)
+
+// SLOImpact describes the impact of an alert on a service level objective (SLO) and its error budget, typically
+// for SLO burn rate alerts. It is displayed as a context block in the Slack post (see ContextText).
+type SLOImpact struct {
+	// Name is the name of the SLO, e.g. 'checkout-availability'.
+	// This field is required if the SLO impact is set.
+	// It is automatically truncated at MaxSLONameLength characters.
+	Name string `json:"name"`
+
+	// Objective is the target of the SLO, in percent, e.g. 99.9.
+	// This field is optional (0 means not set). Must be less than 100.
+	Objective float64 `json:"objective"`
+
+	// BurnRate is the rate at which the error budget is consumed, relative to the rate that exactly exhausts
+	// the budget at the end of the SLO period: 1 is on budget, and 14.4 exhausts a 30 day budget in about 2 days.
+	// It must be >=0.
+	BurnRate float64 `json:"burnRate"`
+
+	// WindowSeconds is the window the burn rate was measured over, e.g. 3600 for 1 hour.
+	// This field is required if the SLO impact is set. Maximum value: MaxSLOWindowSeconds.
+	WindowSeconds int `json:"windowSeconds"`
+}
+
+// Clean normalizes the SLO impact fields.
+func (s *SLOImpact) Clean() {
+	if s == nil {
+		return
+	}
+
+	s.Name = truncateText(strings.Join(strings.Fields(s.Name), " "), MaxSLONameLength)
+}
+
+// ValidateSLOImpact validates the SLO impact, if set.
+func (a *Alert) ValidateSLOImpact() error {
+	s := a.SLOImpact
+
+	if s == nil {
+		return nil
+	}
+
+	if s.Name == "" {
+		return errors.New("sloImpact.name is required")
+	}
+
+	if utf8.RuneCountInString(s.Name) > MaxSLONameLength {
+		return fmt.Errorf("sloImpact.name is too long, expected length <=%d", MaxSLONameLength)
+	}
+
+	if math.IsNaN(s.Objective) || s.Objective < 0 || s.Objective >= 100 {
+		return fmt.Errorf("sloImpact.objective %v is not valid, expected value >=0 and <100", s.Objective)
+	}
+
+	if math.IsNaN(s.BurnRate) || math.IsInf(s.BurnRate, 0) || s.BurnRate < 0 {
+		return fmt.Errorf("sloImpact.burnRate %v is not valid, expected a finite value >=0", s.BurnRate)
+	}
+
+	if s.WindowSeconds <= 0 {
+		return errors.New("sloImpact.windowSeconds must be >0")
+	}
+
+	if s.WindowSeconds > MaxSLOWindowSeconds {
+		return fmt.Errorf("sloImpact.windowSeconds is too high, expected value <=%d", MaxSLOWindowSeconds)
+	}
+
+	return nil
+}
+
+// Window returns the burn rate window as a duration.
+func (s *SLOImpact) Window() time.Duration {
+	if s == nil {
+		return 0
+	}
+
+	return time.Duration(s.WindowSeconds) * time.Second
+}
+
+// BurnLevel returns the level of the burn rate.
+func (s *SLOImpact) BurnLevel() SLOBurnLevel {
+	switch {
+	case s == nil || s.BurnRate <= 1:
+		return SLOBurnNormal
+	case s.BurnRate >= SLOBurnRateCritical:
+		return SLOBurnCritical
+	case s.BurnRate >= SLOBurnRateHigh:
+		return SLOBurnHigh
+	default:
+		return SLOBurnElevated
+	}
+}
+
+// SuggestedSeverity returns the alert severity matching the burn level: panic for critical, error for high,
+// warning for elevated and info for normal. Alert producers may use it when the source has no severity of its own.
+func (s *SLOImpact) SuggestedSeverity() AlertSeverity {
+	switch s.BurnLevel() {
+	case SLOBurnCritical:
+		return AlertPanic
+	case SLOBurnHigh:
+		return AlertError
+	case SLOBurnElevated:
+		return AlertWarning
+	case SLOBurnNormal:
+	}
+
+	return AlertInfo
+}
+
+// BudgetConsumed returns the fraction of the error budget of an SLO period consumed during the window,
+// e.g. 0.02 for a burn rate of 14.4 over 1 hour, with a 30 day period. It returns 0 if period is not positive.
+func (s *SLOImpact) BudgetConsumed(period time.Duration) float64 {
+	if s == nil || period <= 0 {
+		return 0
+	}
+
+	return s.BurnRate * s.Window().Seconds() / period.Seconds()
+}
+
+// ContextText returns the SLO impact as a single line of text, suitable for a Slack context block,
+// e.g. 'SLO checkout-availability (99.9%) · burn rate 14.4x over 1h · critical'.
+func (s *SLOImpact) ContextText() string {
+	if s == nil {
+		return ""
+	}
+
+	slo := "SLO " + s.Name
+	if s.Objective > 0 {
+		slo += " (" + strconv.FormatFloat(s.Objective, 'f', -1, 64) + "%)"
+	}
+
+	burnRate := strconv.FormatFloat(math.Round(s.BurnRate*10)/10, 'f', -1, 64)
+
+	return fmt.Sprintf("%s · burn rate %sx over %s · %s", slo, burnRate, FormatSLOWindow(s.WindowSeconds), s.BurnLevel())
+}
+
+// FormatSLOWindow formats a window in the largest whole unit, as in Prometheus durations: '3d', '6h', '30m' or '90s'.
+func FormatSLOWindow(seconds int) string {
+	switch {
+	case seconds > 0 && seconds%86400 == 0:
+		return strconv.Itoa(seconds/86400) + "d"
+	case seconds > 0 && seconds%3600 == 0:
+		return strconv.Itoa(seconds/3600) + "h"
+	case seconds > 0 && seconds%60 == 0:
+		return strconv.Itoa(seconds/60) + "m"
+	default:
+		return strconv.Itoa(seconds) + "s"
+	}
+}
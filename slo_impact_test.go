@@ -0,0 +1,105 @@
+package types_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertSLOImpact(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(impact *types.SLOImpact) *types.Alert {
+		a := types.NewErrorAlert()
+		a.SlackChannelID = "C12345678"
+		a.Header = "Checkout error budget burn"
+		a.SLOImpact = impact
+
+		return a
+	}
+
+	t.Run("SLO impact should be cleaned and accepted", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(&types.SLOImpact{Name: " checkout\n availability ", Objective: 99.9, BurnRate: 14.4, WindowSeconds: 3600})
+		a.Clean()
+		require.NoError(t, a.Validate())
+		assert.Equal(t, "checkout availability", a.SLOImpact.Name)
+
+		long := newAlert(&types.SLOImpact{Name: strings.Repeat("a", 150), BurnRate: 1, WindowSeconds: 60})
+		long.Clean()
+		require.NoError(t, long.Validate())
+		assert.Equal(t, strings.Repeat("a", 97)+"...", long.SLOImpact.Name)
+
+		none := newAlert(nil)
+		none.Clean()
+		require.NoError(t, none.Validate())
+	})
+
+	t.Run("invalid SLO impact should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			impact types.SLOImpact
+			err    string
+		}{
+			{types.SLOImpact{BurnRate: 1, WindowSeconds: 3600}, "sloImpact.name is required"},
+			{types.SLOImpact{Name: "checkout", Objective: 100, WindowSeconds: 3600}, "sloImpact.objective 100 is not valid, expected value >=0 and <100"},
+			{types.SLOImpact{Name: "checkout", Objective: -1, WindowSeconds: 3600}, "sloImpact.objective -1 is not valid, expected value >=0 and <100"},
+			{types.SLOImpact{Name: "checkout", BurnRate: -0.5, WindowSeconds: 3600}, "sloImpact.burnRate -0.5 is not valid, expected a finite value >=0"},
+			{types.SLOImpact{Name: "checkout", BurnRate: math.Inf(1), WindowSeconds: 3600}, "sloImpact.burnRate +Inf is not valid, expected a finite value >=0"},
+			{types.SLOImpact{Name: "checkout", BurnRate: 1}, "sloImpact.windowSeconds must be >0"},
+			{types.SLOImpact{Name: "checkout", BurnRate: 1, WindowSeconds: types.MaxSLOWindowSeconds + 1}, "sloImpact.windowSeconds is too high, expected value <=7776000"},
+		}
+
+		for _, test := range tests {
+			a := newAlert(&test.impact)
+			require.EqualError(t, a.ValidateSLOImpact(), test.err)
+			require.EqualError(t, a.Validate(), test.err)
+		}
+	})
+}
+
+func TestSLOImpactRendering(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		burnRate float64
+		level    types.SLOBurnLevel
+		severity types.AlertSeverity
+	}{
+		{0, types.SLOBurnNormal, types.AlertInfo},
+		{1, types.SLOBurnNormal, types.AlertInfo},
+		{2.5, types.SLOBurnElevated, types.AlertWarning},
+		{6, types.SLOBurnHigh, types.AlertError},
+		{14.4, types.SLOBurnCritical, types.AlertPanic},
+	}
+
+	for _, test := range tests {
+		impact := &types.SLOImpact{Name: "checkout", BurnRate: test.burnRate, WindowSeconds: 3600}
+		assert.Equal(t, test.level, impact.BurnLevel(), test.burnRate)
+		assert.Equal(t, test.severity, impact.SuggestedSeverity(), test.burnRate)
+	}
+
+	impact := &types.SLOImpact{Name: "checkout-availability", Objective: 99.9, BurnRate: 14.4321, WindowSeconds: 3600}
+	assert.Equal(t, "SLO checkout-availability (99.9%) · burn rate 14.4x over 1h · critical", impact.ContextText())
+	assert.Equal(t, time.Hour, impact.Window())
+	assert.InDelta(t, 0.02, impact.BudgetConsumed(30*24*time.Hour), 0.0001)
+	assert.Zero(t, impact.BudgetConsumed(0))
+
+	impact = &types.SLOImpact{Name: "latency", BurnRate: 0.5, WindowSeconds: 3 * 86400}
+	assert.Equal(t, "SLO latency · burn rate 0.5x over 3d · normal", impact.ContextText())
+
+	var nilImpact *types.SLOImpact
+	assert.Empty(t, nilImpact.ContextText())
+	assert.Equal(t, types.SLOBurnNormal, nilImpact.BurnLevel())
+
+	assert.Equal(t, "6h", types.FormatSLOWindow(6*3600))
+	assert.Equal(t, "30m", types.FormatSLOWindow(1800))
+	assert.Equal(t, "90s", types.FormatSLOWindow(90))
+}
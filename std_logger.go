@@ -0,0 +1,208 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel is the minimum level of messages written by a StdLogger.
+type LogLevel string
+
+const (
+	// LogLevelDebug writes all messages.
+	LogLevelDebug LogLevel = "debug"
+
+	// LogLevelInfo writes info, warn and error messages.
+	LogLevelInfo LogLevel = "info"
+
+	// LogLevelWarn writes warn and error messages.
+	LogLevelWarn LogLevel = "warn"
+
+	// LogLevelError writes error messages only.
+	LogLevelError LogLevel = "error"
+)
+
+// LogLevelIsValid returns true if the provided LogLevel is valid.
+func LogLevelIsValid(s LogLevel) bool {
+	switch s {
+	case LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError:
+		return true
+	}
+	return false
+}
+
+// ValidLogLevels returns a slice of valid LogLevel values.
+func ValidLogLevels() []string {
+	return []string{
+		string(LogLevelDebug),
+		string(LogLevelInfo),
+		string(LogLevelWarn),
+		string(LogLevelError),
+	}
+}
+
+// ParseLogLevel parses a log level from configuration, ignoring case. 'warning' is accepted as an alias of 'warn'.
+func ParseLogLevel(s string) (LogLevel, error) {
+	level := LogLevel(strings.ToLower(strings.TrimSpace(s)))
+
+	if level == "warning" {
+		return LogLevelWarn, nil
+	}
+
+	if !LogLevelIsValid(level) {
+		return "", fmt.Errorf("log level '%s' is not valid, expected one of [%s]", s, strings.Join(ValidLogLevels(), ", "))
+	}
+
+	return level, nil
+}
+
+func logLevelRank(level LogLevel) int {
+	switch level {
+	case LogLevelDebug:
+		return 0
+	case LogLevelWarn:
+		return 2
+	case LogLevelError:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// StdLogger is a Logger writing one JSON object per line to an io.Writer, such as os.Stderr. Each line has the
+// 'time' (RFC 3339, UTC), 'level' and 'msg' keys, followed by the fields in key order. Fields named like these
+// keys are ignored. Messages below the minimum level are discarded. It is safe for concurrent use.
+type StdLogger struct {
+	out    *stdLoggerOutput
+	rank   int
+	fields map[string]any
+}
+
+// stdLoggerOutput is shared by a StdLogger and the loggers derived from it with WithField and WithFields,
+// so that their lines are not interleaved.
+type stdLoggerOutput struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+var _ Logger = (*StdLogger)(nil)
+
+// NewStdLogger creates a new StdLogger writing messages at or above the specified level to w.
+// An invalid level is treated as LogLevelInfo.
+func NewStdLogger(w io.Writer, level LogLevel) *StdLogger {
+	return &StdLogger{
+		out:  &stdLoggerOutput{w: w},
+		rank: logLevelRank(level),
+	}
+}
+
+func (l *StdLogger) Debug(msg string) {
+	l.write(LogLevelDebug, msg)
+}
+
+func (l *StdLogger) Debugf(format string, args ...any) {
+	l.writef(LogLevelDebug, format, args...)
+}
+
+func (l *StdLogger) Info(msg string) {
+	l.write(LogLevelInfo, msg)
+}
+
+func (l *StdLogger) Infof(format string, args ...any) {
+	l.writef(LogLevelInfo, format, args...)
+}
+
+func (l *StdLogger) Warn(msg string) {
+	l.write(LogLevelWarn, msg)
+}
+
+func (l *StdLogger) Warnf(format string, args ...any) {
+	l.writef(LogLevelWarn, format, args...)
+}
+
+func (l *StdLogger) Error(msg string) {
+	l.write(LogLevelError, msg)
+}
+
+func (l *StdLogger) Errorf(format string, args ...any) {
+	l.writef(LogLevelError, format, args...)
+}
+
+func (l *StdLogger) WithField(key string, value any) Logger { //nolint:ireturn
+	return l.WithFields(map[string]any{key: value})
+}
+
+func (l *StdLogger) WithFields(fields map[string]any) Logger { //nolint:ireturn
+	merged := make(map[string]any, len(l.fields)+len(fields))
+	maps.Copy(merged, l.fields)
+	maps.Copy(merged, fields)
+
+	return &StdLogger{
+		out:    l.out,
+		rank:   l.rank,
+		fields: merged,
+	}
+}
+
+func (l *StdLogger) writef(level LogLevel, format string, args ...any) {
+	if logLevelRank(level) < l.rank {
+		return
+	}
+
+	l.write(level, fmt.Sprintf(format, args...))
+}
+
+func (l *StdLogger) write(level LogLevel, msg string) {
+	if logLevelRank(level) < l.rank {
+		return
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(`{"time":`)
+	writeJSONValue(&buf, time.Now().UTC().Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSONValue(&buf, level)
+	buf.WriteString(`,"msg":`)
+	writeJSONValue(&buf, msg)
+
+	for _, key := range slices.Sorted(maps.Keys(l.fields)) {
+		if key == "time" || key == "level" || key == "msg" {
+			continue
+		}
+
+		buf.WriteByte(',')
+		writeJSONValue(&buf, key)
+		buf.WriteByte(':')
+		writeJSONValue(&buf, l.fields[key])
+	}
+
+	buf.WriteString("}\n")
+
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+
+	_, _ = l.out.w.Write(buf.Bytes())
+}
+
+// writeJSONValue writes the JSON encoding of v, or of its string representation if it cannot be encoded
+// (such as errors, which encode as empty objects, and channels).
+func writeJSONValue(buf *bytes.Buffer, v any) {
+	if err, ok := v.(error); ok {
+		v = err.Error()
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(v))
+	}
+
+	buf.Write(data)
+}
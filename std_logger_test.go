@@ -0,0 +1,106 @@
+package types_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdLogger(t *testing.T) {
+	t.Parallel()
+
+	lines := func(t *testing.T, buf *bytes.Buffer) []map[string]any {
+		t.Helper()
+
+		var result []map[string]any
+
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+			result = append(result, entry)
+		}
+
+		return result
+	}
+
+	t.Run("messages below the minimum level should be discarded", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := types.NewStdLogger(&buf, types.LogLevelWarn)
+		logger.Debug("debug")
+		logger.Infof("info %d", 1)
+		logger.Warnf("warn %d", 2)
+		logger.Error("error")
+
+		entries := lines(t, &buf)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "warn", entries[0]["level"])
+		assert.Equal(t, "warn 2", entries[0]["msg"])
+		assert.Equal(t, "error", entries[1]["level"])
+
+		ts, err := time.Parse(time.RFC3339Nano, entries[0]["time"].(string))
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), ts, time.Minute)
+	})
+
+	t.Run("fields should be written in key order without affecting the parent logger", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := types.NewStdLogger(&buf, types.LogLevelDebug)
+		child := logger.WithField("queue", "alerts").WithFields(map[string]any{"count": 3, "err": errors.New("boom"), "msg": "ignored"})
+
+		child.Debug("child")
+		logger.Info("parent")
+
+		output := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, output, 2)
+		assert.Contains(t, output[0], `"level":"debug","msg":"child","count":3,"err":"boom","queue":"alerts"}`)
+		assert.Contains(t, output[1], `"level":"info","msg":"parent"}`)
+	})
+
+	t.Run("invalid level should default to info", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := types.NewStdLogger(&buf, "foo")
+		logger.Debug("debug")
+		logger.Info("info")
+
+		assert.Len(t, lines(t, &buf), 1)
+	})
+}
+
+func TestParseLogLevel(t *testing.T) {
+	t.Parallel()
+
+	for input, expected := range map[string]types.LogLevel{
+		"debug":   types.LogLevelDebug,
+		" INFO ":  types.LogLevelInfo,
+		"Warning": types.LogLevelWarn,
+		"warn":    types.LogLevelWarn,
+		"error":   types.LogLevelError,
+	} {
+		level, err := types.ParseLogLevel(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, level, input)
+	}
+
+	_, err := types.ParseLogLevel("trace")
+	require.EqualError(t, err, "log level 'trace' is not valid, expected one of [debug, info, warn, error]")
+}
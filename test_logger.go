@@ -0,0 +1,156 @@
+package types
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+)
+
+// LogEntry is a log message recorded by a TestLogger.
+type LogEntry struct {
+	// Level is the level of the message.
+	Level LogLevel
+
+	// Message is the message, with the format arguments applied.
+	Message string
+
+	// Fields are the fields of the logger, set with WithField and WithFields.
+	Fields map[string]any
+}
+
+// TestLogger is a Logger recording all messages, for assertions in unit tests. Loggers derived with WithField
+// and WithFields record to the same TestLogger. It is safe for concurrent use.
+//
+// For TEST purposes only! Do not use in production!
+type TestLogger struct {
+	rec    *testLogRecorder
+	fields map[string]any
+}
+
+type testLogRecorder struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+var _ Logger = (*TestLogger)(nil)
+
+// NewTestLogger creates a new TestLogger.
+//
+// For TEST purposes only! Do not use in production!
+func NewTestLogger() *TestLogger {
+	return &TestLogger{
+		rec: &testLogRecorder{},
+	}
+}
+
+func (l *TestLogger) Debug(msg string) {
+	l.record(LogLevelDebug, msg)
+}
+
+func (l *TestLogger) Debugf(format string, args ...any) {
+	l.record(LogLevelDebug, fmt.Sprintf(format, args...))
+}
+
+func (l *TestLogger) Info(msg string) {
+	l.record(LogLevelInfo, msg)
+}
+
+func (l *TestLogger) Infof(format string, args ...any) {
+	l.record(LogLevelInfo, fmt.Sprintf(format, args...))
+}
+
+func (l *TestLogger) Warn(msg string) {
+	l.record(LogLevelWarn, msg)
+}
+
+func (l *TestLogger) Warnf(format string, args ...any) {
+	l.record(LogLevelWarn, fmt.Sprintf(format, args...))
+}
+
+func (l *TestLogger) Error(msg string) {
+	l.record(LogLevelError, msg)
+}
+
+func (l *TestLogger) Errorf(format string, args ...any) {
+	l.record(LogLevelError, fmt.Sprintf(format, args...))
+}
+
+func (l *TestLogger) WithField(key string, value any) Logger { //nolint:ireturn
+	return l.WithFields(map[string]any{key: value})
+}
+
+func (l *TestLogger) WithFields(fields map[string]any) Logger { //nolint:ireturn
+	merged := make(map[string]any, len(l.fields)+len(fields))
+	maps.Copy(merged, l.fields)
+	maps.Copy(merged, fields)
+
+	return &TestLogger{
+		rec:    l.rec,
+		fields: merged,
+	}
+}
+
+// Entries returns the recorded entries, in the order they were logged.
+func (l *TestLogger) Entries() []LogEntry {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+
+	return append([]LogEntry(nil), l.rec.entries...)
+}
+
+// Contains returns true if a recorded message, at any level, contains the specified substring.
+func (l *TestLogger) Contains(substr string) bool {
+	for _, entry := range l.Entries() {
+		if strings.Contains(entry.Message, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CountByLevel returns the number of recorded messages with the specified level.
+func (l *TestLogger) CountByLevel(level LogLevel) int {
+	count := 0
+
+	for _, entry := range l.Entries() {
+		if entry.Level == level {
+			count++
+		}
+	}
+
+	return count
+}
+
+// EntriesWithField returns the recorded entries having the specified field value.
+func (l *TestLogger) EntriesWithField(key string, value any) []LogEntry {
+	var result []LogEntry
+
+	for _, entry := range l.Entries() {
+		if v, ok := entry.Fields[key]; ok && v == value {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
+
+// Reset removes all recorded entries.
+func (l *TestLogger) Reset() {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+
+	l.rec.entries = nil
+}
+
+func (l *TestLogger) record(level LogLevel, msg string) {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+
+	l.rec.entries = append(l.rec.entries, LogEntry{
+		Level:   level,
+		Message: msg,
+		Fields:  maps.Clone(l.fields),
+	})
+}
@@ -0,0 +1,48 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestLogger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("entries should be recorded with level and fields", func(t *testing.T) {
+		t.Parallel()
+
+		logger := types.NewTestLogger()
+		child := logger.WithField(types.LogFieldQueue, "alerts")
+
+		logger.Debug("starting")
+		child.Infof("received %d items", 3)
+		child.WithFields(map[string]any{types.LogFieldMessageID: "m1"}).Warn("slow handler")
+		child.Errorf("failed: %s", "boom")
+
+		entries := logger.Entries()
+		require.Len(t, entries, 4)
+		assert.Equal(t, types.LogEntry{Level: types.LogLevelInfo, Message: "received 3 items", Fields: map[string]any{"queue": "alerts"}}, entries[1])
+		assert.Equal(t, map[string]any{"queue": "alerts", "messageId": "m1"}, entries[2].Fields)
+		assert.Nil(t, entries[0].Fields)
+
+		assert.True(t, logger.Contains("received 3"))
+		assert.False(t, logger.Contains("posted"))
+		assert.Equal(t, 1, logger.CountByLevel(types.LogLevelError))
+		assert.Equal(t, 0, logger.CountByLevel("foo"))
+		assert.Len(t, logger.EntriesWithField(types.LogFieldQueue, "alerts"), 3)
+		assert.Len(t, logger.EntriesWithField(types.LogFieldMessageID, "m1"), 1)
+	})
+
+	t.Run("reset should remove all entries", func(t *testing.T) {
+		t.Parallel()
+
+		logger := types.NewTestLogger()
+		logger.Error("boom")
+		logger.Reset()
+
+		assert.Empty(t, logger.Entries())
+	})
+}
@@ -0,0 +1,140 @@
+package textlen
+
+import (
+	"cmp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultEllipsis is appended to strings truncated by TruncateMarkdown, when MarkdownOptions.Ellipsis is empty.
+const DefaultEllipsis = "..."
+
+const codeFence = "```"
+
+// MarkdownOptions holds the options of TruncateMarkdown.
+type MarkdownOptions struct {
+	// Unit is the unit of the maximum length. The zero value counts runes.
+	Unit Unit
+
+	// Ellipsis is appended to truncated strings. If empty, DefaultEllipsis is used.
+	Ellipsis string
+
+	// LineBoundary truncates at the end of the last complete line that fits, rather than mid-line.
+	// If not even the first line fits, the string is truncated mid-line.
+	LineBoundary bool
+}
+
+// TruncateMarkdown truncates a Slack mrkdwn string to at most maxLength in the unit of the options, including the
+// ellipsis and a closing code fence. Unlike Truncate, it never splits a link or mention (<...>), a code span
+// (`...`), or a bold, italic or strikethrough span (*...*, _..._, ~...~); such spans are dropped as a whole
+// if they do not fit. A code block left open by the truncation is closed after the ellipsis.
+//
+// A string ending with an unbalanced code fence is treated as a code block opened before the start of the string,
+// so that truncated log excerpts keep their closing fence.
+func TruncateMarkdown(s string, maxLength int, opts MarkdownOptions) string {
+	if Fits(s, maxLength, opts.Unit) {
+		return s
+	}
+
+	ellipsis := cmp.Or(opts.Ellipsis, DefaultEllipsis)
+	ellipsisLength := Count(ellipsis, opts.Unit)
+	fenceLength := Count(codeFence, opts.Unit)
+
+	inFence := strings.HasSuffix(s, codeFence) && strings.Count(s, codeFence)%2 == 1
+
+	cut, cutInFence := -1, false
+	lineCut, lineCutInFence := -1, false
+
+	for i, n := 0, 0; n+ellipsisLength <= maxLength; {
+		reserved := ellipsisLength
+		if inFence {
+			reserved += fenceLength
+		}
+
+		if n+reserved <= maxLength {
+			cut, cutInFence = i, inFence
+
+			if i > 0 && s[i] == '\n' {
+				lineCut, lineCutInFence = i, inFence
+			}
+		}
+
+		size := nextMarkdownSegmentLen(s, i, inFence)
+
+		if strings.HasPrefix(s[i:], codeFence) {
+			inFence = !inFence
+		}
+
+		n += Count(s[i:i+size], opts.Unit)
+		i += size
+	}
+
+	if opts.LineBoundary && lineCut > 0 {
+		cut, cutInFence = lineCut, lineCutInFence
+	}
+
+	if cut < 0 {
+		return Truncate(ellipsis, maxLength, opts.Unit)
+	}
+
+	truncated := strings.TrimRightFunc(s[:cut], unicode.IsSpace) + ellipsis
+
+	if cutInFence {
+		truncated += codeFence
+	}
+
+	return truncated
+}
+
+// nextMarkdownSegmentLen returns the length in bytes of the segment at s[i:] which must not be split: a code fence,
+// a link, a code span, a formatted span or a grapheme cluster. Only code fences and grapheme clusters are
+// recognized inside code blocks.
+func nextMarkdownSegmentLen(s string, i int, inFence bool) int {
+	rest := s[i:]
+
+	if strings.HasPrefix(rest, codeFence) {
+		return len(codeFence)
+	}
+
+	if inFence {
+		return nextGraphemeLen(rest)
+	}
+
+	switch marker := rest[0]; marker {
+	case '<':
+		if end := strings.IndexAny(rest[1:], "<>\n"); end > 0 && rest[1+end] == '>' {
+			return end + 2
+		}
+	case '`':
+		if end := strings.IndexAny(rest[1:], "`\n"); end > 0 && rest[1+end] == '`' {
+			return end + 2
+		}
+	case '*', '_', '~':
+		if !opensFormatting(s, i) {
+			break
+		}
+
+		if end := strings.IndexAny(rest[1:], string(marker)+"\n"); end > 0 && rest[1+end] == marker {
+			return end + 2
+		}
+	}
+
+	return nextGraphemeLen(rest)
+}
+
+// opensFormatting returns true if the formatting marker at s[i] can open a span, i.e. if it is not preceded by
+// a letter or digit (as in snake_case), and not followed by a space.
+func opensFormatting(s string, i int) bool {
+	if i+1 >= len(s) || s[i+1] == ' ' || s[i+1] == '\n' {
+		return false
+	}
+
+	if i == 0 {
+		return true
+	}
+
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+
+	return !unicode.IsLetter(prev) && !unicode.IsDigit(prev)
+}
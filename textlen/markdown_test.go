@@ -0,0 +1,64 @@
+package textlen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types/textlen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateMarkdown(t *testing.T) {
+	t.Parallel()
+
+	opts := textlen.MarkdownOptions{}
+
+	tests := []struct {
+		name      string
+		s         string
+		maxLength int
+		opts      textlen.MarkdownOptions
+		expected  string
+	}{
+		{"short text should be returned as is", "*hello*", 10, opts, "*hello*"},
+		{"plain text should be truncated with an ellipsis", "hello world", 8, opts, "hello..."},
+		{"links should not be split", "see <https://example.com/runbook|runbook> now", 30, opts, "see..."},
+		{"mentions should not be split", "cc <@U012345> please", 12, opts, "cc..."},
+		{"code spans should not be split", "run `kubectl get pods` now", 20, opts, "run..."},
+		{"bold spans should not be split", "status *very bad* now", 16, opts, "status..."},
+		{"complete spans should be kept", "a *b* `c` <d> and more", 16, opts, "a *b* `c` <d>..."},
+		{"snake_case should not be treated as italic", "some_long_name_here", 12, opts, "some_long..."},
+		{"unclosed markers should be treated as text", "price * 2 and *unclosed", 16, opts, "price * 2 and..."},
+		{"code blocks should be closed", "log:\n```\nline 1\nline 2\nline 3\n```", 24, opts, "log:\n```\nline 1\nli...```"},
+		{"unbalanced trailing fences should be closed", "line 1\nline 2\nline 3```", 16, opts, "line 1\nlin...```"},
+		{"custom ellipsis should be used", "hello world", 8, textlen.MarkdownOptions{Ellipsis: " [+]"}, "hell [+]"},
+		{"line boundaries should be preferred", "line one\nline two\nline three", 24, textlen.MarkdownOptions{LineBoundary: true}, "line one\nline two..."},
+		{"long first lines should be truncated mid-line", "a very long first line\nsecond", 12, textlen.MarkdownOptions{LineBoundary: true}, "a very lo..."},
+		{"tiny limits should return the ellipsis", "hello world", 2, opts, ".."},
+		{"emojis should count in the configured unit", "🔥🔥🔥🔥", 7, textlen.MarkdownOptions{Unit: textlen.UTF16}, "🔥🔥..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			truncated := textlen.TruncateMarkdown(tt.s, tt.maxLength, tt.opts)
+			assert.Equal(t, tt.expected, truncated)
+			assert.LessOrEqual(t, textlen.Count(truncated, tt.opts.Unit), tt.maxLength)
+		})
+	}
+}
+
+func TestTruncateMarkdownFits(t *testing.T) {
+	t.Parallel()
+
+	s := strings.Repeat("*bold* `code` <https://example.com|link> 🔥 ```block``` _it_ ~st~\n", 20)
+
+	for _, unit := range []textlen.Unit{textlen.Runes, textlen.UTF16, textlen.Graphemes} {
+		for maxLength := 1; maxLength < 300; maxLength++ {
+			truncated := textlen.TruncateMarkdown(s, maxLength, textlen.MarkdownOptions{Unit: unit})
+			assert.LessOrEqual(t, textlen.Count(truncated, unit), maxLength, "%s %d", unit, maxLength)
+			assert.Equal(t, 0, strings.Count(truncated, "```")%2, "code fences should be balanced: %q", truncated)
+		}
+	}
+}
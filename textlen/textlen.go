@@ -0,0 +1,185 @@
+// Package textlen measures and truncates strings the way Slack does, rather than in bytes or runes:
+//
+//	textlen.Count("👍🏽 ok", textlen.Runes)     // 5
+//	textlen.Count("👍🏽 ok", textlen.UTF16)     // 7, as counted by the Slack API limits
+//	textlen.Count("👍🏽 ok", textlen.Graphemes) // 4, as displayed
+//
+//	s := textlen.Truncate(text, 3000, textlen.UTF16) // Never splits an emoji or a character with combining marks
+//
+// Most Slack API limits, such as the 150 character limit of header blocks and the 3000 character limit of section
+// text, are counted in UTF-16 code units, like JavaScript string lengths. Characters outside the Basic Multilingual
+// Plane, such as most emojis, count as 2 code units, while CJK characters count as 1. Graphemes are the characters
+// perceived by the user, e.g. a thumbs up with a skin tone modifier, or a letter with combining accents.
+//
+// Grapheme clusters are approximated: combining marks, variation selectors, emoji modifiers, tags, zero width
+// joiner sequences and regional indicator pairs (flags) are kept with the preceding character. This covers emojis
+// and accented text, without the full tables of Unicode Standard Annex #29.
+package textlen
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Unit is the unit in which the length of a string is measured.
+type Unit int
+
+const (
+	// Runes counts unicode code points, like utf8.RuneCountInString.
+	Runes Unit = iota
+
+	// UTF16 counts UTF-16 code units, like JavaScript and the Slack API limits.
+	UTF16
+
+	// Graphemes counts user-perceived characters (approximated grapheme clusters).
+	Graphemes
+)
+
+// String returns the name of the unit.
+func (u Unit) String() string {
+	switch u {
+	case Runes:
+		return "runes"
+	case UTF16:
+		return "utf16"
+	case Graphemes:
+		return "graphemes"
+	default:
+		return fmt.Sprintf("Unit(%d)", int(u))
+	}
+}
+
+const zeroWidthJoiner = 0x200D
+
+// Count returns the length of s in the specified unit. Unknown units count runes.
+func Count(s string, unit Unit) int {
+	switch unit {
+	case UTF16:
+		n := 0
+		for _, r := range s {
+			n += runeUTF16Len(r)
+		}
+
+		return n
+	case Graphemes:
+		n := 0
+		for i := 0; i < len(s); n++ {
+			i += nextGraphemeLen(s[i:])
+		}
+
+		return n
+	default:
+		return utf8.RuneCountInString(s)
+	}
+}
+
+// Fits returns true if the length of s in the specified unit is at most maxLength.
+func Fits(s string, maxLength int, unit Unit) bool {
+	// A string of n bytes is never longer than n in any unit, which avoids counting short strings.
+	return len(s) <= maxLength || Count(s, unit) <= maxLength
+}
+
+// Truncate returns the longest prefix of s with a length of at most maxLength in the specified unit, without
+// splitting grapheme clusters. s is returned as is if it fits, and an empty string if maxLength is not positive.
+func Truncate(s string, maxLength int, unit Unit) string {
+	if maxLength <= 0 {
+		return ""
+	}
+
+	if Fits(s, maxLength, unit) {
+		return s
+	}
+
+	n := 0
+	end := 0
+
+	for end < len(s) {
+		size := nextGraphemeLen(s[end:])
+
+		switch unit {
+		case UTF16:
+			n += Count(s[end:end+size], UTF16)
+		case Graphemes:
+			n++
+		default:
+			n += utf8.RuneCountInString(s[end : end+size])
+		}
+
+		if n > maxLength {
+			break
+		}
+
+		end += size
+	}
+
+	return s[:end]
+}
+
+// runeUTF16Len returns the number of UTF-16 code units of r. Invalid runes are encoded as U+FFFD, a single code unit.
+func runeUTF16Len(r rune) int {
+	if r >= 0x10000 && r <= unicode.MaxRune {
+		return 2
+	}
+
+	return 1
+}
+
+// nextGraphemeLen returns the length in bytes of the (approximated) grapheme cluster at the start of s.
+func nextGraphemeLen(s string) int {
+	first, i := utf8.DecodeRuneInString(s)
+
+	// CR LF is a single grapheme cluster.
+	if first == '\r' && i < len(s) && s[i] == '\n' {
+		return i + 1
+	}
+
+	if first == '\r' || first == '\n' {
+		return i
+	}
+
+	prev := first
+	regionalIndicators := 0
+
+	if isRegionalIndicator(first) {
+		regionalIndicators = 1
+	}
+
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case isGraphemeExtend(r):
+		case prev == zeroWidthJoiner && r != '\r' && r != '\n':
+		case isRegionalIndicator(r) && regionalIndicators == 1:
+			regionalIndicators = 2
+		default:
+			return i
+		}
+
+		prev = r
+		i += size
+	}
+
+	return i
+}
+
+// isGraphemeExtend returns true if r extends the preceding grapheme cluster.
+func isGraphemeExtend(r rune) bool {
+	switch {
+	case r == zeroWidthJoiner:
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F: // Variation selectors
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // Emoji skin tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // Tags, used by subdivision flags
+		return true
+	}
+
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
@@ -0,0 +1,83 @@
+package textlen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types/textlen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s         string
+		runes     int
+		utf16     int
+		graphemes int
+	}{
+		{"", 0, 0, 0},
+		{"hello", 5, 5, 5},
+		{"日本語", 3, 3, 3},
+		{"🔥", 1, 2, 1},
+		{"👍🏽 ok", 5, 7, 4},
+		{"👩‍💻", 3, 5, 1},
+		{"🇳🇴🇸🇪", 4, 8, 2},
+		{"été", 5, 5, 3},
+		{"1️⃣", 3, 3, 1},
+		{"a\r\nb", 4, 4, 3},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.runes, textlen.Count(tt.s, textlen.Runes), "runes of %q", tt.s)
+		assert.Equal(t, tt.utf16, textlen.Count(tt.s, textlen.UTF16), "utf16 of %q", tt.s)
+		assert.Equal(t, tt.graphemes, textlen.Count(tt.s, textlen.Graphemes), "graphemes of %q", tt.s)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("strings that fit should be returned as is", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "hello", textlen.Truncate("hello", 5, textlen.UTF16))
+		assert.Equal(t, "🔥🔥", textlen.Truncate("🔥🔥", 4, textlen.UTF16))
+		assert.Empty(t, textlen.Truncate("hello", 0, textlen.Runes))
+		assert.True(t, textlen.Fits("日本語", 3, textlen.UTF16))
+		assert.False(t, textlen.Fits("🔥🔥", 3, textlen.UTF16))
+	})
+
+	t.Run("emojis should count as two code units", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "🔥", textlen.Truncate("🔥🔥", 3, textlen.UTF16))
+		assert.Equal(t, "🔥🔥", textlen.Truncate("🔥🔥", 3, textlen.Runes))
+		assert.Equal(t, "日本", textlen.Truncate("日本語", 2, textlen.UTF16))
+	})
+
+	t.Run("grapheme clusters should never be split", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "ok ", textlen.Truncate("ok 👍🏽", 4, textlen.UTF16))
+		assert.Equal(t, "ok 👍🏽", textlen.Truncate("ok 👍🏽!", 7, textlen.UTF16))
+		assert.Equal(t, "🇳🇴", textlen.Truncate("🇳🇴🇸🇪", 6, textlen.UTF16))
+		assert.Equal(t, "é", textlen.Truncate("été", 1, textlen.Graphemes))
+		assert.Equal(t, "👩‍💻", textlen.Truncate("👩‍💻👩‍💻", 1, textlen.Graphemes))
+	})
+
+	t.Run("truncated strings should fit", func(t *testing.T) {
+		t.Parallel()
+
+		s := strings.Repeat("a😀日́", 100)
+
+		for _, unit := range []textlen.Unit{textlen.Runes, textlen.UTF16, textlen.Graphemes} {
+			for maxLength := 1; maxLength < 50; maxLength++ {
+				truncated := textlen.Truncate(s, maxLength, unit)
+				assert.LessOrEqual(t, textlen.Count(truncated, unit), maxLength, unit.String())
+				assert.True(t, strings.HasPrefix(s, truncated))
+			}
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package types
+
+import "context"
+
+// Span attribute names used across the Slack Manager services, so that spans of the same alert can be found
+// from producer to Slack post.
+const (
+	// TraceAttrCorrelationID is the span attribute holding the alert correlation ID.
+	TraceAttrCorrelationID = "slackmgr.correlation_id"
+
+	// TraceAttrChannelID is the span attribute holding the Slack channel ID.
+	TraceAttrChannelID = "slackmgr.channel_id"
+
+	// TraceAttrRouteKey is the span attribute holding the alert route key.
+	TraceAttrRouteKey = "slackmgr.route_key"
+
+	// TraceAttrQueue is the span attribute holding the queue name.
+	TraceAttrQueue = "messaging.destination.name"
+
+	// TraceAttrMessageID is the span attribute holding the queue message ID.
+	TraceAttrMessageID = "messaging.message.id"
+)
+
+// Tracer is a lightweight tracing interface, implemented by adapters for tracing libraries such as OpenTelemetry
+// (see the tracers/oteltracer module). It lets the client, the consumer framework and services built on this package
+// trace an alert from the producer to the Slack post, without depending on a specific tracing library.
+type Tracer interface {
+	// StartSpan starts a span with the specified name and attributes, as a child of the span of ctx, if any.
+	// It returns a context holding the new span, and a function ending the span with the error of the operation
+	// (nil on success). The end function must be called exactly once.
+	StartSpan(ctx context.Context, name string, attrs map[string]any) (context.Context, func(err error))
+
+	// Inject writes the trace context of ctx to the carrier, such as HTTP headers or QueueEnvelope.TraceContext,
+	// so that it can be propagated to another service.
+	Inject(ctx context.Context, carrier map[string]string)
+
+	// Extract returns a copy of ctx holding the remote trace context read from the carrier, so that spans started
+	// with the returned context are children of the propagated span.
+	Extract(ctx context.Context, carrier map[string]string) context.Context
+}
+
+// NoopTracer is a no-op implementation of the Tracer interface. Use if no tracing is needed.
+type NoopTracer struct{}
+
+var _ Tracer = (*NoopTracer)(nil)
+
+func (t *NoopTracer) StartSpan(ctx context.Context, _ string, _ map[string]any) (context.Context, func(err error)) {
+	return ctx, func(error) {}
+}
+
+func (t *NoopTracer) Inject(_ context.Context, _ map[string]string) {
+}
+
+func (t *NoopTracer) Extract(ctx context.Context, _ map[string]string) context.Context {
+	return ctx
+}
+
+// TraceAttrs returns the span attributes identifying the alert: the correlation ID, Slack channel ID and route key.
+// Empty values are omitted.
+func (a *Alert) TraceAttrs() map[string]any {
+	attrs := make(map[string]any, 3)
+
+	if a.CorrelationID != "" {
+		attrs[TraceAttrCorrelationID] = a.CorrelationID
+	}
+
+	if a.SlackChannelID != "" {
+		attrs[TraceAttrChannelID] = a.SlackChannelID
+	}
+
+	if a.RouteKey != "" {
+		attrs[TraceAttrRouteKey] = a.RouteKey
+	}
+
+	return attrs
+}
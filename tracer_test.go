@@ -0,0 +1,40 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoopTracer(t *testing.T) {
+	t.Parallel()
+
+	var tracer types.Tracer = &types.NoopTracer{}
+
+	ctx := context.Background()
+
+	spanCtx, end := tracer.StartSpan(ctx, "span", nil)
+	assert.Equal(t, ctx, spanCtx)
+	end(errors.New("boom"))
+
+	carrier := map[string]string{}
+	tracer.Inject(ctx, carrier)
+	assert.Empty(t, carrier)
+	assert.Equal(t, ctx, tracer.Extract(ctx, map[string]string{"traceparent": "00-abc"}))
+}
+
+func TestAlertTraceAttrs(t *testing.T) {
+	t.Parallel()
+
+	alert := types.NewErrorAlert()
+	alert.CorrelationID = "disk-full"
+	alert.SlackChannelID = "C12345678"
+
+	assert.Equal(t, map[string]any{
+		types.TraceAttrCorrelationID: "disk-full",
+		types.TraceAttrChannelID:     "C12345678",
+	}, alert.TraceAttrs())
+}
@@ -0,0 +1,112 @@
+// Package oteltracer implements the types.Tracer interface on top of OpenTelemetry.
+//
+// The package is a separate module, so that the core module does not depend on the OpenTelemetry SDK.
+// Spans are created with a tracer of the specified provider, and trace contexts are propagated with the
+// global text map propagator (W3C trace context, if configured), unless another propagator is set:
+//
+//	otel.SetTextMapPropagator(propagation.TraceContext{})
+//	tracer := oteltracer.New(otel.GetTracerProvider())
+//	c, err := client.New(baseURL, client.WithTracer(tracer))
+package oteltracer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slackmgr/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the OpenTelemetry tracer created by New.
+const InstrumentationName = "github.com/slackmgr/types"
+
+// Option configures a Tracer.
+type Option func(*Tracer)
+
+// WithPropagator sets the propagator used by Inject and Extract. Defaults to the global text map propagator.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(t *Tracer) {
+		t.propagator = propagator
+	}
+}
+
+// Tracer is a types.Tracer creating OpenTelemetry spans.
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+var _ types.Tracer = (*Tracer)(nil)
+
+// New creates a new Tracer using the specified tracer provider.
+func New(provider trace.TracerProvider, opts ...Option) *Tracer {
+	t := &Tracer{
+		tracer: provider.Tracer(InstrumentationName),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if t.propagator == nil {
+		t.propagator = otel.GetTextMapPropagator()
+	}
+
+	return t
+}
+
+// StartSpan starts an OpenTelemetry span with the specified attributes. The end function records a non-nil error
+// on the span, and sets the span status to error.
+func (t *Tracer) StartSpan(ctx context.Context, name string, attrs map[string]any) (context.Context, func(err error)) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(attributes(attrs)...))
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		span.End()
+	}
+}
+
+// Inject writes the trace context of ctx to the carrier, with the configured propagator.
+func (t *Tracer) Inject(ctx context.Context, carrier map[string]string) {
+	t.propagator.Inject(ctx, propagation.MapCarrier(carrier))
+}
+
+// Extract returns a copy of ctx holding the trace context read from the carrier, with the configured propagator.
+func (t *Tracer) Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return t.propagator.Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// attributes converts attribute values to OpenTelemetry attributes. Values of unsupported types are converted
+// to strings.
+func attributes(attrs map[string]any) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+
+	for key, value := range attrs {
+		switch v := value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(key, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(key, v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(key, v))
+		case []string:
+			kvs = append(kvs, attribute.StringSlice(key, v))
+		default:
+			kvs = append(kvs, attribute.String(key, fmt.Sprint(v)))
+		}
+	}
+
+	return kvs
+}
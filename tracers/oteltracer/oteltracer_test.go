@@ -0,0 +1,76 @@
+package oteltracer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/tracers/oteltracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	newTracer := func() (*oteltracer.Tracer, *tracetest.SpanRecorder) {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+		return oteltracer.New(provider, oteltracer.WithPropagator(propagation.TraceContext{})), recorder
+	}
+
+	t.Run("spans should have attributes and error status", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, recorder := newTracer()
+
+		_, end := tracer.StartSpan(context.Background(), "slackmgr.client.send_alert", map[string]any{
+			types.TraceAttrCorrelationID: "disk-full",
+			"slackmgr.batch_size":        3,
+			"slackmgr.retry":             true,
+			"slackmgr.other":             struct{ A int }{1},
+		})
+		end(errors.New("boom"))
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "slackmgr.client.send_alert", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.ElementsMatch(t, []attribute.KeyValue{
+			attribute.String(types.TraceAttrCorrelationID, "disk-full"),
+			attribute.Int("slackmgr.batch_size", 3),
+			attribute.Bool("slackmgr.retry", true),
+			attribute.String("slackmgr.other", "{1}"),
+		}, spans[0].Attributes())
+	})
+
+	t.Run("trace context should be propagated through a carrier", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, recorder := newTracer()
+
+		ctx, endProducer := tracer.StartSpan(context.Background(), "producer", nil)
+
+		carrier := map[string]string{}
+		tracer.Inject(ctx, carrier)
+		require.Contains(t, carrier, "traceparent")
+
+		endProducer(nil)
+
+		_, endConsumer := tracer.StartSpan(tracer.Extract(context.Background(), carrier), "consumer", nil)
+		endConsumer(nil)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, spans[0].SpanContext().TraceID(), spans[1].SpanContext().TraceID())
+		assert.Equal(t, spans[0].SpanContext().SpanID(), spans[1].Parent().SpanID())
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	})
+}
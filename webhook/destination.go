@@ -0,0 +1,227 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// ErrDestinationNotAllowed is returned for webhook requests to a destination rejected by the DestinationPolicy.
+var ErrDestinationNotAllowed = errors.New("webhook destination is not allowed")
+
+// DestinationPolicy restricts the destinations of webhook requests, to prevent alerts from using webhooks
+// to reach internal services (SSRF). Host rules are checked before sending the request, and address rules
+// are checked for each address the request connects to, after DNS resolution, so that a public hostname
+// resolving to an internal address is also rejected. Denials take precedence over allowances.
+type DestinationPolicy struct {
+	// AllowedHosts restricts requests to the listed hostnames, if not empty. A leading '*.' matches any subdomain,
+	// so '*.example.com' matches 'hooks.example.com' but not 'example.com'. URLs with an IP address as host are then
+	// only allowed if the address is listed, such as '203.0.113.7', or covered by AllowedCIDRs.
+	AllowedHosts []string
+
+	// DeniedHosts rejects requests to the listed hostnames, with the same wildcard syntax as AllowedHosts.
+	DeniedHosts []string
+
+	// AllowedCIDRs allows connections to the listed address ranges, such as '10.20.0.0/16',
+	// even if they are internal.
+	AllowedCIDRs []string
+
+	// DeniedCIDRs rejects connections to the listed address ranges.
+	DeniedCIDRs []string
+
+	// AllowPrivateNetworks allows connections to all internal addresses: loopback, private, link-local (including
+	// cloud metadata endpoints such as 169.254.169.254) and other special-purpose ranges. Use AllowedCIDRs instead,
+	// to allow specific internal destinations.
+	AllowPrivateNetworks bool
+}
+
+// destinationPolicy is the parsed form of a DestinationPolicy.
+type destinationPolicy struct {
+	allowedHosts         []string
+	deniedHosts          []string
+	allowedCIDRs         []netip.Prefix
+	deniedCIDRs          []netip.Prefix
+	allowPrivateNetworks bool
+}
+
+func (p DestinationPolicy) parse() (*destinationPolicy, error) {
+	parsed := &destinationPolicy{
+		allowPrivateNetworks: p.AllowPrivateNetworks,
+	}
+
+	var err error
+
+	if parsed.allowedHosts, err = parseHostRules("allowedHosts", p.AllowedHosts); err != nil {
+		return nil, err
+	}
+
+	if parsed.deniedHosts, err = parseHostRules("deniedHosts", p.DeniedHosts); err != nil {
+		return nil, err
+	}
+
+	if parsed.allowedCIDRs, err = parseCIDRs("allowedCIDRs", p.AllowedCIDRs); err != nil {
+		return nil, err
+	}
+
+	if parsed.deniedCIDRs, err = parseCIDRs("deniedCIDRs", p.DeniedCIDRs); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+func parseHostRules(name string, rules []string) ([]string, error) {
+	parsed := make([]string, 0, len(rules))
+
+	for i, rule := range rules {
+		rule = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(rule), "."))
+
+		if rule == "" || rule == "*." || strings.Contains(strings.TrimPrefix(rule, "*."), "*") {
+			return nil, fmt.Errorf("%s[%d] '%s' is not valid, expected a hostname, optionally prefixed with '*.'", name, i, rules[i])
+		}
+
+		parsed = append(parsed, rule)
+	}
+
+	return parsed, nil
+}
+
+func parseCIDRs(name string, cidrs []string) ([]netip.Prefix, error) {
+	parsed := make([]netip.Prefix, 0, len(cidrs))
+
+	for i, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d] '%s' is not a valid CIDR range", name, i, cidr)
+		}
+
+		parsed = append(parsed, prefix.Masked())
+	}
+
+	return parsed, nil
+}
+
+// checkHost checks the hostname of a request URL against the host rules, and literal IP addresses
+// against the allowed hosts and the address rules.
+func (p *destinationPolicy) checkHost(host string) error {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return p.checkLiteralAddr(addr)
+	}
+
+	for _, rule := range p.deniedHosts {
+		if hostMatches(rule, host) {
+			return fmt.Errorf("%w: host %s is denied", ErrDestinationNotAllowed, host)
+		}
+	}
+
+	if len(p.allowedHosts) == 0 {
+		return nil
+	}
+
+	for _, rule := range p.allowedHosts {
+		if hostMatches(rule, host) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: host %s is not in the allowed hosts", ErrDestinationNotAllowed, host)
+}
+
+// checkLiteralAddr checks the IP address of a request URL. With allowed hosts, the address must be listed in them,
+// or covered by the allowed CIDRs, so that IP addresses do not bypass the allowed hosts.
+func (p *destinationPolicy) checkLiteralAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+
+	if len(p.allowedHosts) > 0 && !slices.ContainsFunc(p.allowedHosts, func(rule string) bool { return ruleMatchesAddr(rule, addr) }) &&
+		!slices.ContainsFunc(p.allowedCIDRs, func(prefix netip.Prefix) bool { return prefix.Contains(addr) }) {
+		return fmt.Errorf("%w: address %s is not in the allowed hosts or CIDRs", ErrDestinationNotAllowed, addr)
+	}
+
+	return p.checkAddr(addr)
+}
+
+// checkAddr checks a resolved address against the address rules.
+func (p *destinationPolicy) checkAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+
+	for _, prefix := range p.deniedCIDRs {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("%w: address %s is denied", ErrDestinationNotAllowed, addr)
+		}
+	}
+
+	for _, prefix := range p.allowedCIDRs {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+
+	if !p.allowPrivateNetworks && isInternalAddr(addr) {
+		return fmt.Errorf("%w: address %s is internal", ErrDestinationNotAllowed, addr)
+	}
+
+	return nil
+}
+
+// control is a net.Dialer Control function, rejecting connections to addresses denied by the policy.
+func (p *destinationPolicy) control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDestinationNotAllowed, err)
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDestinationNotAllowed, err)
+	}
+
+	return p.checkAddr(addr)
+}
+
+func ruleMatchesAddr(rule string, addr netip.Addr) bool {
+	ruleAddr, err := netip.ParseAddr(strings.Trim(rule, "[]"))
+
+	return err == nil && ruleAddr.Unmap() == addr
+}
+
+func hostMatches(rule, host string) bool {
+	if suffix, ok := strings.CutPrefix(rule, "*"); ok {
+		return strings.HasSuffix(host, suffix)
+	}
+
+	return rule == host
+}
+
+func isInternalAddr(addr netip.Addr) bool {
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return true
+	}
+
+	for _, prefix := range internalPrefixes() {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// internalPrefixes returns the ranges blocked unless DestinationPolicy.AllowPrivateNetworks is set, in addition to
+// the loopback, private, link-local, multicast and unspecified addresses recognized by netip.
+func internalPrefixes() []netip.Prefix {
+	return []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),     // "This" network
+		netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT
+		netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+		netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking
+		netip.MustParsePrefix("240.0.0.0/4"),   // Reserved
+		netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which may embed an internal IPv4 address
+	}
+}
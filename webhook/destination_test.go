@@ -0,0 +1,114 @@
+package webhook_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestinationPolicy(t *testing.T) {
+	t.Parallel()
+
+	dispatch := func(t *testing.T, policy webhook.DestinationPolicy, url string) error {
+		t.Helper()
+
+		d, err := webhook.NewDispatcher(webhook.Config{Secret: []byte("secret"), Destinations: policy})
+		require.NoError(t, err)
+
+		_, err = d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: url}, &types.WebhookCallback{ID: "hook"})
+
+		return err
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	port := server.URL[strings.LastIndex(server.URL, ":"):]
+
+	t.Run("internal addresses should be rejected by default", func(t *testing.T) {
+		t.Parallel()
+
+		for _, url := range []string{
+			server.URL,
+			"http://localhost" + port,
+			"http://169.254.169.254/latest/meta-data",
+			"http://10.0.0.1",
+			"http://[::1]" + port,
+			"http://[::ffff:127.0.0.1]" + port,
+			"http://100.64.0.1",
+			"http://0.0.0.0" + port,
+		} {
+			err := dispatch(t, webhook.DestinationPolicy{}, url)
+			require.ErrorIs(t, err, webhook.ErrDestinationNotAllowed, url)
+		}
+	})
+
+	t.Run("allowed CIDRs should allow internal addresses", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, dispatch(t, webhook.DestinationPolicy{AllowedCIDRs: []string{"127.0.0.0/8"}}, server.URL))
+		require.NoError(t, dispatch(t, webhook.DestinationPolicy{AllowPrivateNetworks: true}, server.URL))
+
+		// Denied CIDRs take precedence.
+		err := dispatch(t, webhook.DestinationPolicy{AllowPrivateNetworks: true, DeniedCIDRs: []string{"127.0.0.1/32"}}, server.URL)
+		require.ErrorIs(t, err, webhook.ErrDestinationNotAllowed)
+	})
+
+	t.Run("host rules should be checked before resolving", func(t *testing.T) {
+		t.Parallel()
+
+		policy := webhook.DestinationPolicy{
+			AllowedHosts: []string{"*.example.com", "localhost"},
+			DeniedHosts:  []string{"internal.example.com"},
+			AllowedCIDRs: []string{"127.0.0.0/8"},
+		}
+
+		require.NoError(t, dispatch(t, policy, "http://localhost"+port))
+
+		err := dispatch(t, policy, "https://internal.example.com/hook")
+		require.EqualError(t, err, "webhook destination is not allowed: host internal.example.com is denied")
+
+		err = dispatch(t, policy, "https://example.org/hook")
+		require.EqualError(t, err, "webhook destination is not allowed: host example.org is not in the allowed hosts")
+
+		err = dispatch(t, policy, "https://example.com/hook")
+		require.ErrorIs(t, err, webhook.ErrDestinationNotAllowed)
+	})
+
+	t.Run("IP addresses should not bypass the allowed hosts", func(t *testing.T) {
+		t.Parallel()
+
+		policy := webhook.DestinationPolicy{AllowedHosts: []string{"hooks.example.com"}, AllowPrivateNetworks: true}
+
+		err := dispatch(t, policy, "http://203.0.113.7/hook")
+		require.EqualError(t, err, "webhook destination is not allowed: address 203.0.113.7 is not in the allowed hosts or CIDRs")
+
+		err = dispatch(t, policy, server.URL)
+		require.ErrorIs(t, err, webhook.ErrDestinationNotAllowed)
+
+		err = dispatch(t, policy, "http://[::ffff:127.0.0.1]"+port)
+		require.ErrorIs(t, err, webhook.ErrDestinationNotAllowed)
+
+		// Listed addresses and addresses in the allowed CIDRs are allowed.
+		policy.AllowedHosts = append(policy.AllowedHosts, "127.0.0.1")
+		require.NoError(t, dispatch(t, policy, server.URL))
+
+		require.NoError(t, dispatch(t, webhook.DestinationPolicy{AllowedHosts: []string{"hooks.example.com"}, AllowedCIDRs: []string{"127.0.0.0/8"}}, server.URL))
+	})
+
+	t.Run("invalid rules should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := webhook.NewDispatcher(webhook.Config{Secret: []byte("secret"), Destinations: webhook.DestinationPolicy{AllowedHosts: []string{"hooks.*.com"}}})
+		require.EqualError(t, err, "invalid destination policy: allowedHosts[0] 'hooks.*.com' is not valid, expected a hostname, optionally prefixed with '*.'")
+
+		_, err = webhook.NewDispatcher(webhook.Config{Secret: []byte("secret"), Destinations: webhook.DestinationPolicy{DeniedCIDRs: []string{"10.0.0.0"}}})
+		require.EqualError(t, err, "invalid destination policy: deniedCIDRs[0] '10.0.0.0' is not a valid CIDR range")
+	})
+}
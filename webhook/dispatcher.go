@@ -0,0 +1,467 @@
+// Package webhook dispatches HTTP webhook requests for webhook button clicks.
+//
+// The Dispatcher builds the request body from a types.Webhook and the types.WebhookCallback of the click, signs it
+// with an HMAC (see Sign and VerifySignature), rejects destinations not allowed by the DestinationPolicy (blocking
+// requests to internal networks by default), retries failed requests according to the webhook retry policy,
+// and decodes the types.WebhookResponse returned by the handler.
+//
+// Retries use the backoff, Retry-After parsing, circuit breaker semantics and metrics of the httpretry package,
+// with the webhook retry policy instead of a fixed httpretry.Config, since every webhook has its own.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/httpretry"
+)
+
+const (
+	// DefaultTimeout is the timeout of each attempt for webhooks without a timeout, when Config.DefaultTimeout is zero.
+	DefaultTimeout = 10 * time.Second
+
+	// DefaultUserAgent is the User-Agent header of webhook requests, when Config.UserAgent is empty.
+	DefaultUserAgent = "slackmgr-webhook"
+
+	// MaxResponseBodySize is the maximum number of response body bytes read, in bytes.
+	MaxResponseBodySize = 1 << 20
+
+	// maxErrorBodyLength is the maximum number of response body bytes kept in a StatusError.
+	maxErrorBodyLength = 4096
+
+	// DispatchesMetric is the name of the counter incremented for each dispatched webhook.
+	// The counter has two labels: 'host', with the webhook host, and 'result', with 'success', 'failure' or 'rejected'
+	// (rejected by the destination policy or an open circuit).
+	DispatchesMetric = "webhook_dispatches_total"
+
+	// RetriesMetric is the name of the counter incremented for each retried webhook request.
+	// The counter has a single label, 'host', with the webhook host.
+	RetriesMetric = "webhook_retries_total"
+
+	// DispatchDurationMetric is the name of the histogram observing the dispatch duration, in seconds, including retries.
+	// The histogram has a single label, 'host', with the webhook host.
+	DispatchDurationMetric = "webhook_dispatch_duration_seconds"
+
+	// ClientName is the 'client' label of the httpretry.RetriesMetric and httpretry.CircuitRejectionsMetric counters
+	// reported by the Dispatcher, in addition to its own metrics, so that outbound HTTP dashboards include webhooks.
+	ClientName = "webhooks"
+)
+
+// StatusError is returned when the webhook handler responds with a non-2xx status code, after all retries.
+// Redirects are not followed, and are also returned as a StatusError.
+type StatusError struct {
+	// StatusCode is the HTTP response status code.
+	StatusCode int
+
+	// Body is the response body, truncated at 4096 bytes.
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("webhook returned status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("webhook returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Config holds the configuration of a Dispatcher.
+type Config struct {
+	// Secret is the key used to sign the requests. Required.
+	Secret []byte
+
+	// Destinations restricts the destinations of the requests. The zero value allows all public destinations,
+	// and rejects internal addresses.
+	Destinations DestinationPolicy
+
+	// DefaultTimeout is the timeout of each attempt, for webhooks without a timeout. Defaults to DefaultTimeout.
+	DefaultTimeout time.Duration
+
+	// MaxRetryAfter is the longest Retry-After delay waited for before a retry. Responses asking for a longer delay
+	// are not retried. Defaults to httpretry.DefaultMaxRetryAfter.
+	MaxRetryAfter time.Duration
+
+	// UserAgent is the User-Agent header of the requests. Defaults to DefaultUserAgent.
+	UserAgent string
+
+	// Targets are the HTTP targets webhooks may refer to by name (Webhook.HTTPTarget), such as destinations behind mTLS.
+	// Requests through a target with a proxy are only checked against the host rules of the destination policy,
+	// since the addresses are resolved by the proxy. Optional.
+	Targets map[string]*types.HTTPTargetConfig
+
+	// Breaker is the circuit breaker checked before each attempt. Optional.
+	Breaker *httpretry.CircuitBreaker
+
+	// Logger receives the failed and retried requests. Optional.
+	Logger types.Logger
+
+	// Metrics receives the dispatch metrics. Optional.
+	Metrics types.Metrics
+
+	// Tracer creates a span per dispatch, and propagates the trace context to the handler in the request headers. Optional.
+	Tracer types.Tracer
+}
+
+// Dispatcher sends webhook requests. It is safe for concurrent use.
+type Dispatcher struct {
+	cfg        Config
+	policy     *destinationPolicy
+	httpClient *http.Client
+	targets    map[string]*target
+}
+
+type target struct {
+	cfg        *types.HTTPTargetConfig
+	httpClient *http.Client
+}
+
+// NewDispatcher creates a new Dispatcher. An error is returned if the configuration is invalid.
+func NewDispatcher(cfg Config) (*Dispatcher, error) {
+	if len(cfg.Secret) == 0 {
+		return nil, errors.New("secret cannot be empty")
+	}
+
+	if cfg.DefaultTimeout < 0 || cfg.MaxRetryAfter < 0 {
+		return nil, errors.New("timeouts cannot be negative")
+	}
+
+	policy, err := cfg.Destinations.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination policy: %w", err)
+	}
+
+	if cfg.DefaultTimeout == 0 {
+		cfg.DefaultTimeout = DefaultTimeout
+	}
+
+	if cfg.MaxRetryAfter == 0 {
+		cfg.MaxRetryAfter = httpretry.DefaultMaxRetryAfter
+	}
+
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = DefaultUserAgent
+	}
+
+	if cfg.Logger == nil {
+		cfg.Logger = &types.NoopLogger{}
+	}
+
+	if cfg.Metrics == nil {
+		cfg.Metrics = &types.NoopMetrics{}
+	}
+
+	if cfg.Tracer == nil {
+		cfg.Tracer = &types.NoopTracer{}
+	}
+
+	cfg.Metrics.RegisterCounter(DispatchesMetric, "Number of dispatched webhooks", "host", "result")
+	cfg.Metrics.RegisterCounter(RetriesMetric, "Number of retried webhook requests", "host")
+	cfg.Metrics.RegisterHistogram(DispatchDurationMetric, "Duration of webhook dispatches including retries, in seconds", nil, "host")
+	cfg.Metrics.RegisterCounter(httpretry.RetriesMetric, "Number of retried outbound HTTP requests", "client", "host", "reason")
+	cfg.Metrics.RegisterCounter(httpretry.CircuitRejectionsMetric, "Number of outbound HTTP requests rejected by an open circuit", "client", "host")
+
+	d := &Dispatcher{
+		cfg:        cfg,
+		policy:     policy,
+		httpClient: newHTTPClient(policy, nil, types.DefaultHTTPTransport(), cfg.DefaultTimeout),
+		targets:    make(map[string]*target, len(cfg.Targets)),
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.Targets)) {
+		targetCfg := cfg.Targets[name]
+
+		if !types.HTTPTargetNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("http target name '%s' is not valid, expected letters, digits, '.', '_' and '-', with length <=%d", name, types.MaxHTTPTargetNameLength)
+		}
+
+		targetCfg.Clean()
+
+		transport, err := targetCfg.Transport()
+		if err != nil {
+			return nil, fmt.Errorf("invalid http target '%s': %w", name, err)
+		}
+
+		d.targets[name] = &target{
+			cfg:        targetCfg,
+			httpClient: newHTTPClient(policy, targetCfg, transport, cfg.DefaultTimeout),
+		}
+	}
+
+	return d, nil
+}
+
+// newHTTPClient returns the HTTP client of a target (nil for direct requests), which checks the destination policy
+// when connecting, unless the target has a proxy, and does not follow redirects.
+func newHTTPClient(policy *destinationPolicy, targetCfg *types.HTTPTargetConfig, transport *http.Transport, dialTimeout time.Duration) *http.Client {
+	if targetCfg == nil || targetCfg.ProxyURL == "" {
+		dialer := &net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: 30 * time.Second,
+			Control:   policy.control,
+		}
+
+		transport.DialContext = dialer.DialContext
+
+		// A proxy would make the dialer check the proxy address instead of the destination.
+		transport.Proxy = nil
+	}
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// MergePayload returns the payload sent to the webhook handler: the webhook payload, overridden by the
+// callback payload (which holds the alert metadata added by the Slack Manager).
+func MergePayload(hook *types.Webhook, callback *types.WebhookCallback) map[string]any {
+	payload := make(map[string]any, len(hook.Payload)+len(callback.Payload))
+
+	maps.Copy(payload, hook.Payload)
+	maps.Copy(payload, callback.Payload)
+
+	return payload
+}
+
+// Dispatch sends the callback of a click on an HTTP webhook button to the webhook URL, and returns the cleaned and
+// validated response of the handler. An empty or non-JSON 2xx response body is a successful response without a message.
+//
+// Failed requests are retried according to the webhook retry policy, waiting a random delay up to the policy backoff
+// (full jitter, see httpretry.Backoff), and at least the Retry-After delay.
+// Destinations rejected by the destination policy return an error wrapping ErrDestinationNotAllowed, and non-2xx
+// responses a *StatusError.
+func (d *Dispatcher) Dispatch(ctx context.Context, hook *types.Webhook, callback *types.WebhookCallback) (resp *types.WebhookResponse, err error) {
+	if hook == nil || callback == nil {
+		return nil, errors.New("webhook and callback cannot be nil")
+	}
+
+	if hook.ButtonType == types.WebhookButtonTypeLink {
+		return nil, fmt.Errorf("webhook %s is a link button", hook.ID)
+	}
+
+	u, err := url.Parse(hook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook %s is not an HTTP webhook", hook.ID)
+	}
+
+	host := u.Hostname()
+	start := time.Now()
+
+	ctx, endSpan := d.cfg.Tracer.StartSpan(ctx, "webhook.dispatch", map[string]any{
+		"webhook.id":          hook.ID,
+		"webhook.delivery_id": callback.DeliveryID,
+		"server.address":      host,
+	})
+
+	defer func() {
+		endSpan(err)
+
+		result := "success"
+
+		switch {
+		case errors.Is(err, ErrDestinationNotAllowed), errors.Is(err, httpretry.ErrCircuitOpen):
+			result = "rejected"
+		case err != nil:
+			result = "failure"
+		}
+
+		d.cfg.Metrics.Inc(DispatchesMetric, host, result)
+		d.cfg.Metrics.Observe(DispatchDurationMetric, time.Since(start).Seconds(), host)
+	}()
+
+	httpClient, timeout := d.httpClient, d.cfg.DefaultTimeout
+
+	if hook.HTTPTarget != "" {
+		t, ok := d.targets[hook.HTTPTarget]
+		if !ok {
+			return nil, fmt.Errorf("webhook %s refers to unknown http target '%s'", hook.ID, hook.HTTPTarget)
+		}
+
+		httpClient, timeout = t.httpClient, t.cfg.Timeout(timeout)
+	}
+
+	if hook.TimeoutSeconds > 0 {
+		timeout = time.Duration(hook.TimeoutSeconds) * time.Second
+	}
+
+	// The host rules are checked before the circuit breaker is involved, since a rejected destination says nothing
+	// about the health of the host.
+	if err := d.policy.checkHost(host); err != nil {
+		d.cfg.Logger.WithFields(map[string]any{"webhook_id": hook.ID, "host": host}).Warnf("Webhook rejected: %s", err)
+		return nil, err
+	}
+
+	cb := *callback
+	cb.Payload = MergePayload(hook, callback)
+
+	body, err := json.Marshal(&cb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook callback: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, retryAfter, err := d.send(ctx, httpClient, timeout, hook, u, body, callback.DeliveryID)
+		if err == nil {
+			return resp, nil
+		}
+
+		statusCode := 0
+
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			statusCode = statusErr.StatusCode
+		}
+
+		if errors.Is(err, ErrDestinationNotAllowed) || errors.Is(err, httpretry.ErrCircuitOpen) || ctx.Err() != nil ||
+			!hook.RetryPolicy.ShouldRetry(attempt, statusCode) || retryAfter > d.cfg.MaxRetryAfter {
+			d.cfg.Logger.WithFields(map[string]any{"webhook_id": hook.ID, "host": host, "attempt": attempt}).Warnf("Webhook failed: %s", err)
+			return nil, err
+		}
+
+		initial := time.Duration(hook.RetryPolicy.BackoffSeconds) * time.Second
+		delay := max(httpretry.Backoff(attempt, initial, hook.RetryPolicy.Backoff(attempt)), retryAfter)
+
+		reason := "error"
+		if statusCode != 0 {
+			reason = strconv.Itoa(statusCode)
+		}
+
+		d.cfg.Metrics.Inc(RetriesMetric, host)
+		d.cfg.Metrics.Inc(httpretry.RetriesMetric, ClientName, host, reason)
+		d.cfg.Logger.WithFields(map[string]any{"webhook_id": hook.ID, "host": host, "attempt": attempt}).
+			Debugf("Retrying webhook in %s (%s): %s", delay, reason, err)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("webhook cancelled after %d attempt(s): %w", attempt, errors.Join(ctx.Err(), err))
+		case <-timer.C:
+		}
+	}
+}
+
+// send sends a single attempt, with the attempt timeout. It returns the Retry-After delay requested by the handler, if any.
+// ctx is the context of the caller, which tells the circuit breaker whether a failed attempt timed out or was canceled.
+func (d *Dispatcher) send(ctx context.Context, httpClient *http.Client, timeout time.Duration, hook *types.Webhook, u *url.URL,
+	body []byte, deliveryID string,
+) (*types.WebhookResponse, time.Duration, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := string(hook.Method)
+	if method == "" {
+		method = string(types.WebhookMethodPost)
+	}
+
+	req, err := http.NewRequestWithContext(attemptCtx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for name, value := range hook.Headers {
+		req.Header.Set(name, value)
+	}
+
+	contentType := hook.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", d.cfg.UserAgent)
+	req.Header.Set(SignatureHeader, Sign(d.cfg.Secret, time.Now(), body))
+
+	if deliveryID != "" {
+		req.Header.Set(DeliveryIDHeader, deliveryID)
+		req.Header.Set(httpretry.IdempotencyKeyHeader, deliveryID)
+	}
+
+	traceHeaders := make(map[string]string)
+	d.cfg.Tracer.Inject(attemptCtx, traceHeaders)
+
+	for name, value := range traceHeaders {
+		req.Header.Set(name, value)
+	}
+
+	host := u.Hostname()
+
+	if d.cfg.Breaker != nil {
+		if err := d.cfg.Breaker.Allow(host); err != nil {
+			d.cfg.Metrics.Inc(httpretry.CircuitRejectionsMetric, ClientName, host)
+			return nil, 0, err
+		}
+	}
+
+	httpResp, err := httpClient.Do(req)
+
+	if d.cfg.Breaker != nil {
+		if errors.Is(err, ErrDestinationNotAllowed) {
+			// An address rejected by the destination policy when connecting is not a request outcome.
+			d.cfg.Breaker.Release(host)
+		} else {
+			// Timed out attempts are failures, while attempts canceled by the caller are released.
+			d.cfg.Breaker.Done(ctx, host, httpResp, err)
+		}
+	}
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, MaxResponseBodySize))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read webhook response: %w", err)
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		if len(respBody) > maxErrorBodyLength {
+			respBody = respBody[:maxErrorBodyLength]
+		}
+
+		retryAfter := httpretry.ParseRetryAfter(httpResp.Header.Get("Retry-After"))
+
+		return nil, retryAfter, &StatusError{StatusCode: httpResp.StatusCode, Body: string(bytes.TrimSpace(respBody))}
+	}
+
+	resp, err := decodeResponse(respBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp, 0, nil
+}
+
+func decodeResponse(body []byte) (*types.WebhookResponse, error) {
+	resp := &types.WebhookResponse{}
+
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '{' {
+		if err := json.Unmarshal(body, resp); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook response: %w", err)
+		}
+	}
+
+	resp.Clean()
+
+	if err := resp.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid webhook response: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,348 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/slackmgr/types/httpretry"
+	"github.com/slackmgr/types/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	method string
+	header http.Header
+	body   []byte
+}
+
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+// newTestServer returns a server responding with the given status codes in order, and then with the response body.
+func newTestServer(t *testing.T, response string, statuses ...int) *testServer {
+	t.Helper()
+
+	s := &testServer{}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.mu.Lock()
+		s.requests = append(s.requests, recordedRequest{method: r.Method, header: r.Header.Clone(), body: body})
+		n := len(s.requests)
+		s.mu.Unlock()
+
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			_, _ = w.Write([]byte("unavailable"))
+			return
+		}
+
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *testServer) recorded() []recordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]recordedRequest(nil), s.requests...)
+}
+
+// newHangingServer returns a server that does not respond until the request is cancelled.
+func newHangingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+type countingMetrics struct {
+	types.NoopMetrics
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (m *countingMetrics) Inc(name string, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.counts[name+"|"+strings.Join(labelValues, "|")]++
+}
+
+func (m *countingMetrics) count(name string, labelValues ...string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.counts[name+"|"+strings.Join(labelValues, "|")]
+}
+
+func newDispatcher(t *testing.T, cfg webhook.Config) *webhook.Dispatcher {
+	t.Helper()
+
+	cfg.Secret = []byte("secret")
+	cfg.Destinations.AllowedCIDRs = []string{"127.0.0.0/8"}
+
+	d, err := webhook.NewDispatcher(cfg)
+	require.NoError(t, err)
+
+	return d
+}
+
+func newCallback() *types.WebhookCallback {
+	return &types.WebhookCallback{
+		ID:         "restart",
+		UserID:     "U12345678",
+		ChannelID:  "C12345678",
+		DeliveryID: "delivery-1",
+		Payload:    map[string]any{"service": "payments-api"},
+	}
+}
+
+func TestNewDispatcher(t *testing.T) {
+	t.Parallel()
+
+	_, err := webhook.NewDispatcher(webhook.Config{})
+	require.EqualError(t, err, "secret cannot be empty")
+
+	_, err = webhook.NewDispatcher(webhook.Config{Secret: []byte("secret"), DefaultTimeout: -time.Second})
+	require.EqualError(t, err, "timeouts cannot be negative")
+}
+
+func TestDispatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("request should be signed and include the merged payload", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestServer(t, `{"status": "SUCCESS", "message": " Restarted "}`)
+		d := newDispatcher(t, webhook.Config{UserAgent: "test-agent"})
+
+		hook := &types.Webhook{
+			ID:      "restart",
+			URL:     server.URL + "/hooks/restart",
+			Method:  types.WebhookMethodPut,
+			Headers: map[string]string{"Authorization": "Bearer token"},
+			Payload: map[string]any{"action": "restart", "service": "overridden"},
+		}
+
+		resp, err := d.Dispatch(context.Background(), hook, newCallback())
+		require.NoError(t, err)
+		assert.Equal(t, types.WebhookResponseStatusSuccess, resp.Status)
+		assert.Equal(t, "Restarted", resp.Message)
+
+		requests := server.recorded()
+		require.Len(t, requests, 1)
+
+		req := requests[0]
+		assert.Equal(t, http.MethodPut, req.method)
+		assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", req.header.Get("Authorization"))
+		assert.Equal(t, "test-agent", req.header.Get("User-Agent"))
+		assert.Equal(t, "delivery-1", req.header.Get(webhook.DeliveryIDHeader))
+		assert.Equal(t, "delivery-1", req.header.Get(httpretry.IdempotencyKeyHeader))
+		require.NoError(t, webhook.VerifySignature(req.header.Get(webhook.SignatureHeader), req.body, 0, []byte("secret")))
+
+		var callback types.WebhookCallback
+		require.NoError(t, json.Unmarshal(req.body, &callback))
+		assert.Equal(t, map[string]any{"action": "restart", "service": "payments-api"}, callback.Payload)
+		assert.Equal(t, "U12345678", callback.UserID)
+	})
+
+	t.Run("empty and plain text responses should be successful", func(t *testing.T) {
+		t.Parallel()
+
+		for _, body := range []string{"", "OK"} {
+			server := newTestServer(t, body)
+			d := newDispatcher(t, webhook.Config{})
+
+			resp, err := d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: server.URL}, newCallback())
+			require.NoError(t, err)
+			assert.Equal(t, types.WebhookResponseStatusSuccess, resp.Status)
+			assert.Empty(t, resp.Message)
+		}
+	})
+
+	t.Run("failed requests should be retried according to the retry policy", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestServer(t, "", http.StatusServiceUnavailable, http.StatusBadGateway)
+		d := newDispatcher(t, webhook.Config{})
+
+		hook := &types.Webhook{ID: "hook", URL: server.URL, RetryPolicy: &types.WebhookRetryPolicy{MaxAttempts: 3}}
+
+		_, err := d.Dispatch(context.Background(), hook, newCallback())
+		require.NoError(t, err)
+		assert.Len(t, server.recorded(), 3)
+	})
+
+	t.Run("failed requests should not be retried without a retry policy", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestServer(t, "", http.StatusServiceUnavailable)
+		d := newDispatcher(t, webhook.Config{})
+
+		_, err := d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: server.URL}, newCallback())
+
+		var statusErr *webhook.StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+		require.EqualError(t, err, "webhook returned status 503: unavailable")
+		assert.Len(t, server.recorded(), 1)
+	})
+
+	t.Run("redirects should not be followed", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.RedirectHandler("http://169.254.169.254/", http.StatusFound))
+		t.Cleanup(server.Close)
+
+		d := newDispatcher(t, webhook.Config{})
+
+		_, err := d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: server.URL}, newCallback())
+
+		var statusErr *webhook.StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusFound, statusErr.StatusCode)
+	})
+
+	t.Run("open circuit should reject requests", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestServer(t, "", 500, 500, 500)
+
+		breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour})
+		require.NoError(t, err)
+
+		d := newDispatcher(t, webhook.Config{Breaker: breaker})
+		hook := &types.Webhook{ID: "hook", URL: server.URL, RetryPolicy: &types.WebhookRetryPolicy{MaxAttempts: 3, RetryOnStatusCodes: []int{500}}}
+
+		_, err = d.Dispatch(context.Background(), hook, newCallback())
+		require.ErrorIs(t, err, httpretry.ErrCircuitOpen)
+		assert.Len(t, server.recorded(), 1)
+	})
+
+	t.Run("timed out attempts should be recorded as failures by the circuit breaker", func(t *testing.T) {
+		t.Parallel()
+
+		server := newHangingServer(t)
+		metrics := &countingMetrics{counts: make(map[string]int)}
+
+		breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 2, OpenDuration: time.Hour})
+		require.NoError(t, err)
+
+		d := newDispatcher(t, webhook.Config{Breaker: breaker, DefaultTimeout: 50 * time.Millisecond, Metrics: metrics})
+		hook := &types.Webhook{ID: "hook", URL: server.URL, RetryPolicy: &types.WebhookRetryPolicy{MaxAttempts: 3}}
+
+		_, err = d.Dispatch(context.Background(), hook, newCallback())
+		require.ErrorIs(t, err, httpretry.ErrCircuitOpen)
+
+		u, _ := url.Parse(server.URL)
+		assert.Equal(t, httpretry.CircuitOpen, breaker.State(u.Hostname()))
+		assert.Equal(t, 2, metrics.count(httpretry.RetriesMetric, webhook.ClientName, u.Hostname(), "error"))
+		assert.Equal(t, 1, metrics.count(httpretry.CircuitRejectionsMetric, webhook.ClientName, u.Hostname()))
+	})
+
+	t.Run("rejected and cancelled requests should not be recorded by the circuit breaker", func(t *testing.T) {
+		t.Parallel()
+
+		server := newHangingServer(t)
+
+		breaker, err := httpretry.NewCircuitBreaker(httpretry.BreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour})
+		require.NoError(t, err)
+
+		// The address of localhost is rejected when connecting.
+		d, err := webhook.NewDispatcher(webhook.Config{Secret: []byte("secret"), Breaker: breaker})
+		require.NoError(t, err)
+
+		port := server.URL[strings.LastIndex(server.URL, ":"):]
+
+		_, err = d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: "http://localhost" + port}, newCallback())
+		require.ErrorIs(t, err, webhook.ErrDestinationNotAllowed)
+		assert.Equal(t, httpretry.CircuitClosed, breaker.State("localhost"))
+		require.NoError(t, breaker.Allow("localhost"), "the rejected request should have released the breaker")
+		breaker.Release("localhost")
+
+		d = newDispatcher(t, webhook.Config{Breaker: breaker})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err = d.Dispatch(ctx, &types.Webhook{ID: "hook", URL: server.URL}, newCallback())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		u, _ := url.Parse(server.URL)
+		assert.Equal(t, httpretry.CircuitClosed, breaker.State(u.Hostname()))
+	})
+
+	t.Run("http targets should apply their TLS settings", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		t.Cleanup(server.Close)
+
+		caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+		d := newDispatcher(t, webhook.Config{Targets: map[string]*types.HTTPTargetConfig{
+			"private-ca": {CABundle: caBundle, TLSMinVersion: types.TLSVersion12},
+		}})
+
+		_, err := d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: server.URL, HTTPTarget: "private-ca"}, newCallback())
+		require.NoError(t, err)
+
+		// The server certificate is not trusted without the target.
+		_, err = d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: server.URL}, newCallback())
+		require.ErrorContains(t, err, "certificate")
+
+		_, err = d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: server.URL, HTTPTarget: "unknown"}, newCallback())
+		require.EqualError(t, err, "webhook hook refers to unknown http target 'unknown'")
+
+		_, err = webhook.NewDispatcher(webhook.Config{Secret: []byte("secret"), Targets: map[string]*types.HTTPTargetConfig{"private-ca": {CABundle: "invalid"}}})
+		require.EqualError(t, err, "invalid http target 'private-ca': caBundle is not valid, expected one or more PEM encoded certificates")
+	})
+
+	t.Run("invalid webhooks should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		d := newDispatcher(t, webhook.Config{})
+
+		_, err := d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: "restart-handler"}, newCallback())
+		require.EqualError(t, err, "webhook hook is not an HTTP webhook")
+
+		_, err = d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: "https://example.com", ButtonType: types.WebhookButtonTypeLink}, newCallback())
+		require.EqualError(t, err, "webhook hook is a link button")
+
+		server := newTestServer(t, `{"status": "unknown"}`)
+
+		_, err = d.Dispatch(context.Background(), &types.Webhook{ID: "hook", URL: server.URL}, newCallback())
+		require.ErrorContains(t, err, "invalid webhook response")
+	})
+}
@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is the HTTP header carrying the signature of a webhook request, on the format
+	// 't=<unix timestamp>,v1=<hex HMAC-SHA256>'. The HMAC is computed over '<timestamp>.<body>'.
+	SignatureHeader = "X-Slackmgr-Signature"
+
+	// DeliveryIDHeader is the HTTP header carrying the delivery ID of the webhook callback.
+	// It is the same for all attempts of the same click.
+	DeliveryIDHeader = "X-Slackmgr-Delivery"
+
+	// DefaultSignatureTolerance is the maximum age of a signature accepted by VerifySignature,
+	// when the tolerance is zero.
+	DefaultSignatureTolerance = 5 * time.Minute
+)
+
+// ErrInvalidSignature is returned by VerifySignature for missing, malformed, expired or non-matching signatures.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Sign returns the SignatureHeader value of the body, signed with the secret at the given time.
+func Sign(secret []byte, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + ts + ",v1=" + signature(secret, ts, body)
+}
+
+// VerifySignature verifies the SignatureHeader value of a received webhook request, and that the signature is
+// not older than tolerance (DefaultSignatureTolerance if zero), to prevent replays. Any of the secrets may match,
+// so that secrets can be rotated without downtime. An error wrapping ErrInvalidSignature is returned if the
+// signature is not valid.
+func VerifySignature(header string, body []byte, tolerance time.Duration, secrets ...[]byte) error {
+	if tolerance == 0 {
+		tolerance = DefaultSignatureTolerance
+	}
+
+	var ts string
+	var signatures []string
+
+	for part := range strings.SplitSeq(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return fmt.Errorf("%w: expected 't=<timestamp>,v1=<signature>'", ErrInvalidSignature)
+	}
+
+	if age := time.Since(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp is outside the tolerance of %s", ErrInvalidSignature, tolerance)
+	}
+
+	for _, secret := range secrets {
+		expected := signature(secret, ts, body)
+
+		for _, s := range signatures {
+			if hmac.Equal([]byte(expected), []byte(s)) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: signature does not match", ErrInvalidSignature)
+}
+
+func signature(secret []byte, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
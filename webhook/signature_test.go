@@ -0,0 +1,37 @@
+package webhook_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"id":"hook"}`)
+	header := webhook.Sign([]byte("secret"), time.Now(), body)
+
+	assert.Regexp(t, `^t=\d+,v1=[0-9a-f]{64}$`, header)
+	require.NoError(t, webhook.VerifySignature(header, body, 0, []byte("secret")))
+
+	// Any of the secrets may match, for secret rotation.
+	require.NoError(t, webhook.VerifySignature(header, body, 0, []byte("new"), []byte("secret")))
+
+	err := webhook.VerifySignature(header, []byte(`{"id":"other"}`), 0, []byte("secret"))
+	require.ErrorIs(t, err, webhook.ErrInvalidSignature)
+	require.EqualError(t, err, "invalid webhook signature: signature does not match")
+
+	require.ErrorIs(t, webhook.VerifySignature(header, body, 0, []byte("other")), webhook.ErrInvalidSignature)
+
+	old := webhook.Sign([]byte("secret"), time.Now().Add(-10*time.Minute), body)
+	require.EqualError(t, webhook.VerifySignature(old, body, 0, []byte("secret")), "invalid webhook signature: timestamp is outside the tolerance of 5m0s")
+	require.NoError(t, webhook.VerifySignature(old, body, time.Hour, []byte("secret")))
+
+	for _, header := range []string{"", "v1=abc", "t=abc,v1=abc", "t=123"} {
+		require.ErrorIs(t, webhook.VerifySignature(header, body, 0, []byte("secret")), webhook.ErrInvalidSignature, header)
+	}
+}
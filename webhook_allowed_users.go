@@ -0,0 +1,95 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// SlackUserIDRegex matches valid Slack user IDs, such as U12345678 or W12345678.
+	SlackUserIDRegex = regexp.MustCompile(`^[UW][A-Z0-9]{2,20}$`)
+
+	// SlackUserGroupIDRegex matches valid Slack user group IDs, such as S12345678.
+	SlackUserGroupIDRegex = regexp.MustCompile(`^S[A-Z0-9]{2,20}$`)
+)
+
+const (
+	// MaxWebhookAllowedUserCount is the maximum number of allowed user IDs per webhook.
+	MaxWebhookAllowedUserCount = 20
+	// MaxWebhookAllowedGroupCount is the maximum number of allowed user group IDs per webhook.
+	MaxWebhookAllowedGroupCount = 10
+)
+
+// IsUserAllowed returns true if the user with the specified ID, member of the specified user groups,
+// is allowed to click the webhook button according to AllowedUserIDs and AllowedGroupIDs.
+// If both lists are empty, all users are allowed.
+//
+// The check complements AccessLevel, which is enforced separately by the Slack Manager.
+func (hook *Webhook) IsUserAllowed(userID string, groupIDs []string) bool {
+	if len(hook.AllowedUserIDs) == 0 && len(hook.AllowedGroupIDs) == 0 {
+		return true
+	}
+
+	for _, id := range hook.AllowedUserIDs {
+		if id == userID {
+			return true
+		}
+	}
+
+	for _, allowed := range hook.AllowedGroupIDs {
+		for _, id := range groupIDs {
+			if id == allowed {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// cleanWebhookAllowedIDs trims and uppercases the IDs, and strips the Slack mention format
+// (<@U12345678> and <!subteam^S12345678|handle>), so that mentions can be used as IDs.
+func cleanWebhookAllowedIDs(ids []string) []string {
+	for i, id := range ids {
+		id = strings.TrimSpace(id)
+
+		if strings.HasPrefix(id, "<") && strings.HasSuffix(id, ">") {
+			id = strings.TrimSuffix(strings.TrimPrefix(id, "<"), ">")
+			id = strings.TrimPrefix(id, "@")
+			id = strings.TrimPrefix(id, "!subteam^")
+
+			if pos := strings.Index(id, "|"); pos >= 0 {
+				id = id[:pos]
+			}
+		}
+
+		ids[i] = strings.ToUpper(id)
+	}
+
+	return ids
+}
+
+func validateWebhookAllowedIDs(index int, hook *Webhook) error {
+	if len(hook.AllowedUserIDs) > MaxWebhookAllowedUserCount {
+		return fmt.Errorf("webhook[%d].allowedUserIds item count is too large, expected <=%d", index, MaxWebhookAllowedUserCount)
+	}
+
+	for i, id := range hook.AllowedUserIDs {
+		if !SlackUserIDRegex.MatchString(id) {
+			return fmt.Errorf("webhook[%d].allowedUserIds[%d] '%s' is not a valid Slack user ID", index, i, id)
+		}
+	}
+
+	if len(hook.AllowedGroupIDs) > MaxWebhookAllowedGroupCount {
+		return fmt.Errorf("webhook[%d].allowedGroupIds item count is too large, expected <=%d", index, MaxWebhookAllowedGroupCount)
+	}
+
+	for i, id := range hook.AllowedGroupIDs {
+		if !SlackUserGroupIDRegex.MatchString(id) {
+			return fmt.Errorf("webhook[%d].allowedGroupIds[%d] '%s' is not a valid Slack user group ID", index, i, id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookAllowedIDs(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(hook *types.Webhook) *types.Alert {
+		hook.ID = "rollback"
+		hook.URL = "https://example.com/rollback"
+		hook.ButtonText = "Rollback"
+		return &types.Alert{Header: "a", RouteKey: "b", Severity: types.AlertError, Webhooks: []*types.Webhook{hook}}
+	}
+
+	t.Run("ids and mentions should be cleaned and accepted", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(&types.Webhook{
+			AllowedUserIDs:  []string{" u12345678 ", "<@W87654321>"},
+			AllowedGroupIDs: []string{"<!subteam^S12345678|oncall>", "s999"},
+		})
+		a.Clean()
+		require.NoError(t, a.Validate())
+		assert.Equal(t, []string{"U12345678", "W87654321"}, a.Webhooks[0].AllowedUserIDs)
+		assert.Equal(t, []string{"S12345678", "S999"}, a.Webhooks[0].AllowedGroupIDs)
+	})
+
+	t.Run("invalid user id should fail", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(&types.Webhook{AllowedUserIDs: []string{"S12345678"}})
+		a.Clean()
+		require.ErrorContains(t, a.Validate(), "webhook[0].allowedUserIds[0] 'S12345678' is not a valid Slack user ID")
+	})
+
+	t.Run("invalid group id should fail", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(&types.Webhook{AllowedGroupIDs: []string{"<!here>"}})
+		a.Clean()
+		require.ErrorContains(t, a.Validate(), "webhook[0].allowedGroupIds[0]")
+	})
+
+	t.Run("too many ids should fail", func(t *testing.T) {
+		t.Parallel()
+
+		ids := make([]string, types.MaxWebhookAllowedUserCount+1)
+		for i := range ids {
+			ids[i] = "U12345678"
+		}
+
+		a := newAlert(&types.Webhook{AllowedUserIDs: ids})
+		require.ErrorContains(t, a.Validate(), "webhook[0].allowedUserIds item count is too large")
+
+		groups := make([]string, types.MaxWebhookAllowedGroupCount+1)
+		for i := range groups {
+			groups[i] = "S12345678"
+		}
+
+		a = newAlert(&types.Webhook{AllowedGroupIDs: groups})
+		require.ErrorContains(t, a.Validate(), "webhook[0].allowedGroupIds item count is too large")
+	})
+}
+
+func TestWebhookIsUserAllowed(t *testing.T) {
+	t.Parallel()
+
+	hook := &types.Webhook{}
+	assert.True(t, hook.IsUserAllowed("U1", nil))
+
+	hook = &types.Webhook{AllowedUserIDs: []string{"U1", "U2"}}
+	assert.True(t, hook.IsUserAllowed("U2", nil))
+	assert.False(t, hook.IsUserAllowed("U3", []string{"S1"}))
+
+	hook = &types.Webhook{AllowedUserIDs: []string{"U1"}, AllowedGroupIDs: []string{"S1"}}
+	assert.True(t, hook.IsUserAllowed("U3", []string{"S2", "S1"}))
+	assert.False(t, hook.IsUserAllowed("U3", []string{"S2"}))
+}
@@ -0,0 +1,30 @@
+package types
+
+// WebhookButtonType is the type of a webhook button.
+type WebhookButtonType string
+
+const (
+	// WebhookButtonTypeCallback is a button that triggers an HTTP request or a custom webhook handler when clicked.
+	// This is the default button type.
+	WebhookButtonTypeCallback WebhookButtonType = "callback"
+
+	// WebhookButtonTypeLink is a button that opens the webhook URL in the browser, without any request or callback.
+	WebhookButtonTypeLink WebhookButtonType = "link"
+)
+
+// WebhookButtonTypeIsValid returns true if the provided WebhookButtonType is valid.
+func WebhookButtonTypeIsValid(s WebhookButtonType) bool {
+	switch s {
+	case WebhookButtonTypeCallback, WebhookButtonTypeLink:
+		return true
+	}
+	return false
+}
+
+// ValidWebhookButtonTypes returns a slice of valid WebhookButtonType values.
+func ValidWebhookButtonTypes() []string {
+	return []string{
+		string(WebhookButtonTypeCallback),
+		string(WebhookButtonTypeLink),
+	}
+}
@@ -0,0 +1,61 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookButtonTypeValidation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.WebhookButtonTypeIsValid(types.WebhookButtonTypeCallback))
+	assert.True(t, types.WebhookButtonTypeIsValid(types.WebhookButtonTypeLink))
+	assert.False(t, types.WebhookButtonTypeIsValid("submit"))
+	assert.Len(t, types.ValidWebhookButtonTypes(), 2)
+}
+
+func TestWebhookLinkButton(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(hook *types.Webhook) *types.Alert {
+		hook.ID = "runbook"
+		hook.ButtonText = "Open runbook"
+		if hook.URL == "" {
+			hook.URL = "https://example.com/runbooks/disk"
+		}
+		return &types.Alert{Header: "a", RouteKey: "b", Severity: types.AlertError, Webhooks: []*types.Webhook{hook}}
+	}
+
+	a := newAlert(&types.Webhook{ButtonType: " LINK "})
+	a.Clean()
+	require.NoError(t, a.Validate())
+	assert.Equal(t, types.WebhookButtonTypeLink, a.Webhooks[0].ButtonType)
+
+	a = newAlert(&types.Webhook{ButtonType: "popup"})
+	a.Clean()
+	require.ErrorContains(t, a.Validate(), "webhook[0].buttonType 'popup' is not valid")
+
+	a = newAlert(&types.Webhook{ButtonType: types.WebhookButtonTypeLink, URL: "restart-handler"})
+	a.Clean()
+	require.ErrorContains(t, a.Validate(), "webhook[0].url must be an http or https URL for link buttons")
+
+	tests := map[string]*types.Webhook{
+		"confirmationText": {ConfirmationText: "Sure?"},
+		"payload":          {Payload: map[string]any{"a": 1}},
+		"plainTextInput":   {PlainTextInput: []*types.WebhookPlainTextInput{{ID: "a", MaxLength: 10}}},
+		"method":           {Method: types.WebhookMethodPut},
+		"retryPolicy":      {RetryPolicy: &types.WebhookRetryPolicy{MaxAttempts: 1}},
+		"httpTarget":       {HTTPTarget: "payments-mtls"},
+		"cooldownSeconds":  {CooldownSeconds: 10},
+	}
+
+	for field, hook := range tests {
+		hook.ButtonType = types.WebhookButtonTypeLink
+		a := newAlert(hook)
+		a.Clean()
+		require.ErrorContains(t, a.Validate(), "webhook[0]."+field+" is not supported for link buttons")
+	}
+}
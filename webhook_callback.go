@@ -1,6 +1,10 @@
 package types
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 type WebhookCallback struct {
 	ID            string              `json:"id"`
@@ -12,6 +16,14 @@ type WebhookCallback struct {
 	Input         map[string]string   `json:"input"`
 	CheckboxInput map[string][]string `json:"checkboxInput"`
 	Payload       map[string]any      `json:"payload"`
+
+	// DeliveryID uniquely identifies a button click. It is the same for all delivery attempts (retries) of the
+	// same click, so that handlers can use a ReplayGuard to reject duplicate deliveries.
+	DeliveryID string `json:"deliveryId"`
+
+	// Inputs of multi-step forms (Webhook.Steps), keyed by step ID and input ID.
+	StepInput         map[string]map[string]string   `json:"stepInput"`
+	StepCheckboxInput map[string]map[string][]string `json:"stepCheckboxInput"`
 }
 
 func (w *WebhookCallback) GetPayloadValue(key string) any {
@@ -45,8 +57,8 @@ func (w *WebhookCallback) GetPayloadInt(key string, defaultValue int) int {
 		return defaultValue
 	}
 
-	if s, ok := w.Payload[key]; ok {
-		if val, ok := s.(int); ok {
+	if v, ok := w.Payload[key]; ok {
+		if val, ok := coerceTo[int](v); ok {
 			return val
 		}
 	}
@@ -54,6 +66,53 @@ func (w *WebhookCallback) GetPayloadInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// GetPayloadFloat returns the payload value as a float64, or defaultValue if the key is missing or not a number.
+// Numeric strings are parsed.
+func (w *WebhookCallback) GetPayloadFloat(key string, defaultValue float64) float64 {
+	if w == nil || w.Payload == nil {
+		return defaultValue
+	}
+
+	if v, ok := w.Payload[key]; ok {
+		if val, ok := coerceTo[float64](v); ok {
+			return val
+		}
+	}
+
+	return defaultValue
+}
+
+// GetPayloadTime returns the payload value as a time, or defaultValue if the key is missing or not a valid time.
+// Strings are parsed as RFC3339 timestamps. Numbers are parsed as Unix epoch timestamps, in seconds,
+// or in milliseconds for values >=1e12.
+func (w *WebhookCallback) GetPayloadTime(key string, defaultValue time.Time) time.Time {
+	if w == nil || w.Payload == nil {
+		return defaultValue
+	}
+
+	if v, ok := w.Payload[key]; ok {
+		if t, ok := coerceTime(v); ok {
+			return t
+		}
+	}
+
+	return defaultValue
+}
+
+// GetPayloadStringSlice returns the payload value as a string slice, or an empty slice if the key is missing,
+// or if the value is not a list of strings.
+func (w *WebhookCallback) GetPayloadStringSlice(key string) []string {
+	if w == nil || w.Payload == nil {
+		return []string{}
+	}
+
+	if val, ok := coerceStringSlice(w.Payload[key]); ok {
+		return val
+	}
+
+	return []string{}
+}
+
 func (w *WebhookCallback) GetPayloadBool(key string, defaultValue bool) bool {
 	if w == nil || w.Payload == nil {
 		return defaultValue
@@ -80,6 +139,37 @@ func (w *WebhookCallback) GetInputValue(key string) string {
 	return ""
 }
 
+// GetInputInt returns the text input value parsed as an int, or defaultValue if the input is missing or not an integer.
+func (w *WebhookCallback) GetInputInt(key string, defaultValue int) int {
+	if w == nil || w.Input == nil {
+		return defaultValue
+	}
+
+	if s, ok := w.Input[key]; ok {
+		if val, ok := coerceTo[int](s); ok {
+			return val
+		}
+	}
+
+	return defaultValue
+}
+
+// GetInputBool returns the text input value parsed as a bool, or defaultValue if the input is missing or not a bool.
+// Accepted values are those accepted by strconv.ParseBool, such as 'true', 'false', '1' and '0'.
+func (w *WebhookCallback) GetInputBool(key string, defaultValue bool) bool {
+	if w == nil || w.Input == nil {
+		return defaultValue
+	}
+
+	if s, ok := w.Input[key]; ok {
+		if val, ok := coerceTo[bool](s); ok {
+			return val
+		}
+	}
+
+	return defaultValue
+}
+
 func (w *WebhookCallback) GetCheckboxInputSelectedValues(key string) []string {
 	if w == nil || w.CheckboxInput == nil {
 		return []string{}
@@ -91,3 +181,67 @@ func (w *WebhookCallback) GetCheckboxInputSelectedValues(key string) []string {
 
 	return []string{}
 }
+
+func (w *WebhookCallback) GetStepInputValue(stepID, key string) string {
+	if w == nil || w.StepInput == nil {
+		return ""
+	}
+
+	if s, ok := w.StepInput[stepID][key]; ok {
+		return s
+	}
+
+	return ""
+}
+
+func (w *WebhookCallback) GetStepCheckboxInputSelectedValues(stepID, key string) []string {
+	if w == nil || w.StepCheckboxInput == nil {
+		return []string{}
+	}
+
+	if v, ok := w.StepCheckboxInput[stepID][key]; ok {
+		return v
+	}
+
+	return []string{}
+}
+
+// DecodePayload maps the payload values onto the fields of the struct pointed to by v, using the json field tags.
+// Numbers are converted to the field type where this is lossless, for example from float64 to int.
+// Payload keys without a matching field are ignored.
+func (w *WebhookCallback) DecodePayload(v any) error {
+	if w == nil {
+		return errors.New("webhook callback is nil")
+	}
+
+	if err := decodeMapIntoStruct(w.Payload, v); err != nil {
+		return fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+
+	return nil
+}
+
+// DecodeInputs maps the text input and checkbox input values onto the fields of the struct pointed to by v,
+// using the json field tags. Text input values may be decoded into numeric and bool fields, and checkbox
+// input values are decoded into []string fields. Input IDs without a matching field are ignored.
+func (w *WebhookCallback) DecodeInputs(v any) error {
+	if w == nil {
+		return errors.New("webhook callback is nil")
+	}
+
+	inputs := make(map[string]any, len(w.Input)+len(w.CheckboxInput))
+
+	for key, value := range w.Input {
+		inputs[key] = value
+	}
+
+	for key, values := range w.CheckboxInput {
+		inputs[key] = values
+	}
+
+	if err := decodeMapIntoStruct(inputs, v); err != nil {
+		return fmt.Errorf("failed to decode webhook inputs: %w", err)
+	}
+
+	return nil
+}
@@ -1,10 +1,14 @@
 package types_test
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/slackmgr/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWebhookGetPayloadValue(t *testing.T) {
@@ -74,6 +78,97 @@ func TestWebhookGetPayloadInt(t *testing.T) {
 
 	val = w.GetPayloadInt("invalid", 42)
 	assert.Equal(t, 42, val)
+	w = &types.WebhookCallback{}
+	require.NoError(t, json.Unmarshal([]byte(`{"payload":{"count":7,"ratio":7.5,"text":"x"}}`), w))
+	assert.Equal(t, 7, w.GetPayloadInt("count", 42))
+	assert.Equal(t, 42, w.GetPayloadInt("ratio", 42))
+	assert.Equal(t, 42, w.GetPayloadInt("text", 42))
+}
+
+func TestWebhookGetPayloadFloat(t *testing.T) {
+	t.Parallel()
+
+	var w *types.WebhookCallback
+	assert.InDelta(t, 1.5, w.GetPayloadFloat("key", 1.5), 0)
+
+	w = &types.WebhookCallback{Payload: map[string]any{"f": 2.5, "i": 3, "s": "4.5", "b": true}}
+	assert.InDelta(t, 2.5, w.GetPayloadFloat("f", 0), 0)
+	assert.InDelta(t, 3.0, w.GetPayloadFloat("i", 0), 0)
+	assert.InDelta(t, 4.5, w.GetPayloadFloat("s", 0), 0)
+	assert.InDelta(t, 1.5, w.GetPayloadFloat("b", 1.5), 0)
+	assert.InDelta(t, 1.5, w.GetPayloadFloat("missing", 1.5), 0)
+}
+
+func TestWebhookGetPayloadTime(t *testing.T) {
+	t.Parallel()
+
+	def := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := time.Date(2026, 3, 1, 10, 0, 0, 500000000, time.UTC)
+
+	var w *types.WebhookCallback
+	assert.Equal(t, def, w.GetPayloadTime("key", def))
+
+	w = &types.WebhookCallback{Payload: map[string]any{
+		"rfc3339": "2026-03-01T10:00:00.5Z",
+		"seconds": float64(ts.Unix()) + 0.5,
+		"millis":  float64(ts.UnixMilli()),
+		"time":    ts,
+		"invalid": "yesterday",
+		"bool":    true,
+		"huge":    1e300,
+		"tiny":    -1e300,
+		"nan":     math.NaN(),
+		"inf":     math.Inf(1),
+	}}
+
+	assert.True(t, ts.Equal(w.GetPayloadTime("rfc3339", def)))
+	assert.True(t, ts.Equal(w.GetPayloadTime("seconds", def)))
+	assert.True(t, ts.Equal(w.GetPayloadTime("millis", def)))
+	assert.True(t, ts.Equal(w.GetPayloadTime("time", def)))
+	assert.Equal(t, def, w.GetPayloadTime("invalid", def))
+	assert.Equal(t, def, w.GetPayloadTime("bool", def))
+	assert.Equal(t, def, w.GetPayloadTime("missing", def))
+	assert.Equal(t, def, w.GetPayloadTime("huge", def))
+	assert.Equal(t, def, w.GetPayloadTime("tiny", def))
+	assert.Equal(t, def, w.GetPayloadTime("nan", def))
+	assert.Equal(t, def, w.GetPayloadTime("inf", def))
+}
+
+func TestWebhookGetPayloadStringSlice(t *testing.T) {
+	t.Parallel()
+
+	var w *types.WebhookCallback
+	assert.Empty(t, w.GetPayloadStringSlice("key"))
+
+	w = &types.WebhookCallback{Payload: map[string]any{
+		"strings": []string{"a", "b"},
+		"any":     []any{"c", "d"},
+		"mixed":   []any{"e", 1.0},
+		"string":  "f",
+	}}
+
+	assert.Equal(t, []string{"a", "b"}, w.GetPayloadStringSlice("strings"))
+	assert.Equal(t, []string{"c", "d"}, w.GetPayloadStringSlice("any"))
+	assert.Empty(t, w.GetPayloadStringSlice("mixed"))
+	assert.Empty(t, w.GetPayloadStringSlice("string"))
+	assert.Empty(t, w.GetPayloadStringSlice("missing"))
+}
+
+func TestWebhookGetInputIntAndBool(t *testing.T) {
+	t.Parallel()
+
+	var w *types.WebhookCallback
+	assert.Equal(t, 42, w.GetInputInt("key", 42))
+	assert.True(t, w.GetInputBool("key", true))
+
+	w = &types.WebhookCallback{Input: map[string]string{"replicas": " 3 ", "ratio": "1.5", "force": "true", "text": "abc"}}
+	assert.Equal(t, 3, w.GetInputInt("replicas", 42))
+	assert.Equal(t, 42, w.GetInputInt("ratio", 42))
+	assert.Equal(t, 42, w.GetInputInt("text", 42))
+	assert.Equal(t, 42, w.GetInputInt("missing", 42))
+	assert.True(t, w.GetInputBool("force", false))
+	assert.False(t, w.GetInputBool("text", false))
+	assert.True(t, w.GetInputBool("missing", true))
 }
 
 func TestWebhookGetPayloadBool(t *testing.T) {
@@ -144,3 +239,145 @@ func TestGetCheckboxInputSelectedValues(t *testing.T) {
 	val = w.GetCheckboxInputSelectedValues("invalid")
 	assert.Empty(t, val)
 }
+
+func TestWebhookGetStepInputValue(t *testing.T) {
+	t.Parallel()
+
+	var w *types.WebhookCallback
+	assert.Empty(t, w.GetStepInputValue("step", "key"))
+
+	w = &types.WebhookCallback{
+		StepInput: map[string]map[string]string{
+			"step": {"key": "value"},
+		},
+	}
+	assert.Equal(t, "value", w.GetStepInputValue("step", "key"))
+	assert.Empty(t, w.GetStepInputValue("step", "invalid"))
+	assert.Empty(t, w.GetStepInputValue("invalid", "key"))
+}
+
+func TestGetStepCheckboxInputSelectedValues(t *testing.T) {
+	t.Parallel()
+
+	var w *types.WebhookCallback
+	assert.Empty(t, w.GetStepCheckboxInputSelectedValues("step", "key"))
+
+	w = &types.WebhookCallback{
+		StepCheckboxInput: map[string]map[string][]string{
+			"step": {"key": {"value1", "value2"}},
+		},
+	}
+	assert.Equal(t, []string{"value1", "value2"}, w.GetStepCheckboxInputSelectedValues("step", "key"))
+	assert.Empty(t, w.GetStepCheckboxInputSelectedValues("step", "invalid"))
+	assert.Empty(t, w.GetStepCheckboxInputSelectedValues("invalid", "key"))
+}
+
+func TestWebhookDecodePayload(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Host          string            `json:"host"`
+		RetentionDays int               `json:"retentionDays"`
+		Ratio         float32           `json:"ratio"`
+		DryRun        bool              `json:"dryRun"`
+		Limit         *uint             `json:"limit"`
+		Tags          []string          `json:"tags"`
+		Labels        map[string]string `json:"labels"`
+		Ignored       string            `json:"-"`
+		Untagged      string
+	}
+
+	w := &types.WebhookCallback{}
+	require.NoError(t, json.Unmarshal([]byte(`{"payload":{
+		"host": "host-1",
+		"retentionDays": 7,
+		"ratio": 0.5,
+		"dryRun": true,
+		"limit": 10,
+		"tags": ["a", "b"],
+		"labels": {"team": "infra"},
+		"-": "x",
+		"Untagged": "y",
+		"unknown": 1
+	}}`), w))
+
+	var p payload
+	require.NoError(t, w.DecodePayload(&p))
+
+	assert.Equal(t, "host-1", p.Host)
+	assert.Equal(t, 7, p.RetentionDays)
+	assert.InDelta(t, 0.5, p.Ratio, 0.0001)
+	assert.True(t, p.DryRun)
+	require.NotNil(t, p.Limit)
+	assert.Equal(t, uint(10), *p.Limit)
+	assert.Equal(t, []string{"a", "b"}, p.Tags)
+	assert.Equal(t, map[string]string{"team": "infra"}, p.Labels)
+	assert.Empty(t, p.Ignored)
+	assert.Equal(t, "y", p.Untagged)
+
+	w = &types.WebhookCallback{Payload: map[string]any{"retentionDays": 7.5}}
+	require.ErrorContains(t, w.DecodePayload(&p), "key 'retentionDays': cannot convert 7.5 to int")
+
+	w = &types.WebhookCallback{Payload: map[string]any{"limit": -1.0}}
+	require.ErrorContains(t, w.DecodePayload(&p), "key 'limit'")
+
+	w = &types.WebhookCallback{Payload: map[string]any{"dryRun": []any{}}}
+	require.ErrorContains(t, w.DecodePayload(&p), "cannot convert []interface {} to bool")
+
+	require.ErrorContains(t, w.DecodePayload(p), "target must be a non-nil pointer to a struct")
+
+	var nilCallback *types.WebhookCallback
+	require.ErrorContains(t, nilCallback.DecodePayload(&p), "webhook callback is nil")
+}
+
+func TestWebhookDecodeInputs(t *testing.T) {
+	t.Parallel()
+
+	type inputs struct {
+		Reason   string   `json:"reason"`
+		Replicas int      `json:"replicas"`
+		Force    bool     `json:"force"`
+		Regions  []string `json:"regions"`
+	}
+
+	w := &types.WebhookCallback{
+		Input:         map[string]string{"reason": "Disk full", "replicas": " 3 ", "force": "true"},
+		CheckboxInput: map[string][]string{"regions": {"eu", "us"}},
+	}
+
+	var in inputs
+	require.NoError(t, w.DecodeInputs(&in))
+	assert.Equal(t, inputs{Reason: "Disk full", Replicas: 3, Force: true, Regions: []string{"eu", "us"}}, in)
+
+	w = &types.WebhookCallback{Input: map[string]string{"replicas": "three"}}
+	require.ErrorContains(t, w.DecodeInputs(&in), "key 'replicas': cannot convert string 'three' to a number")
+
+	w = &types.WebhookCallback{Input: map[string]string{"force": "maybe"}}
+	require.ErrorContains(t, w.DecodeInputs(&in), "cannot convert string 'maybe' to bool")
+}
+
+func TestWebhookGetPayloadIntOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{name: "positive float", value: 1e19},
+		{name: "positive string", value: "1e19"},
+		{name: "negative float", value: -1e19},
+		{name: "max uint64 float", value: 1.8446744073709552e19},
+		{name: "nan", value: math.NaN()},
+		{name: "nan string", value: "NaN"},
+		{name: "inf", value: math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := &types.WebhookCallback{Payload: map[string]any{"key": tt.value}}
+			assert.Equal(t, 42, w.GetPayloadInt("key", 42))
+		})
+	}
+}
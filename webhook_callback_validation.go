@@ -0,0 +1,141 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxWebhookCallbackPayloadSize is the maximum JSON encoded size of a webhook callback payload, in bytes.
+	// The payload includes the webhook payload, the alert metadata and the input values.
+	MaxWebhookCallbackPayloadSize = 64 * 1024
+	// MaxWebhookDeliveryIDLength is the maximum length of a webhook callback delivery ID.
+	MaxWebhookDeliveryIDLength = 200
+	// MaxWebhookCallbackClockSkew is the maximum time a webhook callback timestamp may be in the future.
+	MaxWebhookCallbackClockSkew = 5 * time.Minute
+	// MaxWebhookCallbackAge is the maximum age of a webhook callback timestamp.
+	MaxWebhookCallbackAge = 24 * time.Hour
+)
+
+// Clean normalizes the callback fields. A zero timestamp is replaced with the current time.
+func (w *WebhookCallback) Clean() {
+	if w == nil {
+		return
+	}
+
+	w.ID = strings.TrimSpace(w.ID)
+	w.UserID = strings.ToUpper(strings.TrimSpace(w.UserID))
+	w.UserRealName = strings.TrimSpace(w.UserRealName)
+	w.ChannelID = strings.ToUpper(strings.TrimSpace(w.ChannelID))
+	w.MessageID = strings.TrimSpace(w.MessageID)
+	w.DeliveryID = strings.TrimSpace(w.DeliveryID)
+
+	if w.Timestamp.IsZero() {
+		w.Timestamp = time.Now().UTC()
+	}
+}
+
+// Validate returns an error if one or more of the required fields are empty or invalid. Call Clean first.
+func (w *WebhookCallback) Validate() error {
+	if w == nil {
+		return errors.New("webhook callback is nil")
+	}
+
+	if w.ID == "" {
+		return errors.New("id is required")
+	}
+
+	if len(w.ID) > MaxWebhookIDLength {
+		return fmt.Errorf("id is too long, expected length <=%d", MaxWebhookIDLength)
+	}
+
+	if w.UserID == "" {
+		return errors.New("userId is required")
+	}
+
+	if !SlackUserIDRegex.MatchString(w.UserID) {
+		return fmt.Errorf("userId '%s' is not a valid Slack user ID", w.UserID)
+	}
+
+	if w.ChannelID == "" {
+		return errors.New("channelId is required")
+	}
+
+	if !SlackChannelIDOrNameRegex.MatchString(w.ChannelID) {
+		return fmt.Errorf("channelId '%s' is not valid", w.ChannelID)
+	}
+
+	if len(w.DeliveryID) > MaxWebhookDeliveryIDLength {
+		return fmt.Errorf("deliveryId is too long, expected length <=%d", MaxWebhookDeliveryIDLength)
+	}
+
+	if !isValidASCII(w.DeliveryID) {
+		return errors.New("deliveryId contains invalid characters, expected printable ASCII")
+	}
+
+	if age := time.Since(w.Timestamp); age < -MaxWebhookCallbackClockSkew {
+		return fmt.Errorf("timestamp is too far in the future, expected <=%s ahead", MaxWebhookCallbackClockSkew)
+	} else if age > MaxWebhookCallbackAge {
+		return fmt.Errorf("timestamp is too old, expected age <=%s", MaxWebhookCallbackAge)
+	}
+
+	if err := validateWebhookCallbackInputs("input", "checkboxInput", w.Input, w.CheckboxInput); err != nil {
+		return err
+	}
+
+	if len(w.StepInput) > MaxWebhookFormStepCount || len(w.StepCheckboxInput) > MaxWebhookFormStepCount {
+		return fmt.Errorf("step input count is too large, expected <=%d steps", MaxWebhookFormStepCount)
+	}
+
+	for stepID, input := range w.StepInput {
+		if err := validateWebhookCallbackInputs(fmt.Sprintf("stepInput[%s]", stepID), "", input, nil); err != nil {
+			return err
+		}
+	}
+
+	for stepID, input := range w.StepCheckboxInput {
+		if err := validateWebhookCallbackInputs("", fmt.Sprintf("stepCheckboxInput[%s]", stepID), nil, input); err != nil {
+			return err
+		}
+	}
+
+	if len(w.Payload) > 0 {
+		body, err := json.Marshal(w.Payload)
+		if err != nil {
+			return fmt.Errorf("payload cannot be encoded as JSON: %w", err)
+		}
+
+		if len(body) > MaxWebhookCallbackPayloadSize {
+			return fmt.Errorf("payload is too large, expected size <=%d bytes", MaxWebhookCallbackPayloadSize)
+		}
+	}
+
+	return nil
+}
+
+func validateWebhookCallbackInputs(inputName, checkboxName string, input map[string]string, checkboxInput map[string][]string) error {
+	if len(input) > MaxWebhookPlainTextInputCount {
+		return fmt.Errorf("%s item count is too large, expected <=%d", inputName, MaxWebhookPlainTextInputCount)
+	}
+
+	for key, value := range input {
+		if len(value) > MaxWebhookInputTextLength {
+			return fmt.Errorf("%s[%s] is too long, expected length <=%d", inputName, key, MaxWebhookInputTextLength)
+		}
+	}
+
+	if len(checkboxInput) > MaxWebhookCheckboxInputCount {
+		return fmt.Errorf("%s item count is too large, expected <=%d", checkboxName, MaxWebhookCheckboxInputCount)
+	}
+
+	for key, values := range checkboxInput {
+		if len(values) > MaxWebhookCheckboxOptionCount {
+			return fmt.Errorf("%s[%s] item count is too large, expected <=%d", checkboxName, key, MaxWebhookCheckboxOptionCount)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWebhookCallback() *types.WebhookCallback {
+	return &types.WebhookCallback{
+		ID:        " rollback ",
+		UserID:    " u12345678 ",
+		ChannelID: " c12345678 ",
+		MessageID: " 1708337700.123456 ",
+	}
+}
+
+func TestWebhookCallbackClean(t *testing.T) {
+	t.Parallel()
+
+	var nilCallback *types.WebhookCallback
+	nilCallback.Clean()
+
+	w := newWebhookCallback()
+	w.Clean()
+	require.NoError(t, w.Validate())
+
+	assert.Equal(t, "rollback", w.ID)
+	assert.Equal(t, "U12345678", w.UserID)
+	assert.Equal(t, "C12345678", w.ChannelID)
+	assert.Equal(t, "1708337700.123456", w.MessageID)
+	assert.WithinDuration(t, time.Now(), w.Timestamp, time.Minute)
+}
+
+func TestWebhookCallbackValidate(t *testing.T) {
+	t.Parallel()
+
+	var nilCallback *types.WebhookCallback
+	require.ErrorContains(t, nilCallback.Validate(), "webhook callback is nil")
+
+	tests := map[string]struct {
+		modify  func(w *types.WebhookCallback)
+		wantErr string
+	}{
+		"id is required":           {func(w *types.WebhookCallback) { w.ID = "" }, "id is required"},
+		"id is too long":           {func(w *types.WebhookCallback) { w.ID = strings.Repeat("a", types.MaxWebhookIDLength+1) }, "id is too long"},
+		"user id is required":      {func(w *types.WebhookCallback) { w.UserID = "" }, "userId is required"},
+		"user id must be valid":    {func(w *types.WebhookCallback) { w.UserID = "C123" }, "userId 'C123' is not a valid Slack user ID"},
+		"channel id is required":   {func(w *types.WebhookCallback) { w.ChannelID = "" }, "channelId is required"},
+		"channel id must be valid": {func(w *types.WebhookCallback) { w.ChannelID = "foo bar" }, "channelId 'FOO BAR' is not valid"},
+		"timestamp in the future":  {func(w *types.WebhookCallback) { w.Timestamp = time.Now().Add(time.Hour) }, "timestamp is too far in the future"},
+		"timestamp too old":        {func(w *types.WebhookCallback) { w.Timestamp = time.Now().Add(-48 * time.Hour) }, "timestamp is too old"},
+		"too many inputs": {func(w *types.WebhookCallback) {
+			w.Input = map[string]string{}
+			for i := range types.MaxWebhookPlainTextInputCount + 1 {
+				w.Input[strings.Repeat("a", i+1)] = "x"
+			}
+		}, "input item count is too large"},
+		"input too long": {func(w *types.WebhookCallback) {
+			w.Input = map[string]string{"reason": strings.Repeat("a", types.MaxWebhookInputTextLength+1)}
+		}, "input[reason] is too long"},
+		"too many checkbox values": {func(w *types.WebhookCallback) {
+			w.CheckboxInput = map[string][]string{"regions": make([]string, types.MaxWebhookCheckboxOptionCount+1)}
+		}, "checkboxInput[regions] item count is too large"},
+		"step input too long": {func(w *types.WebhookCallback) {
+			w.StepInput = map[string]map[string]string{"confirm": {"reason": strings.Repeat("a", types.MaxWebhookInputTextLength+1)}}
+		}, "stepInput[confirm][reason] is too long"},
+		"delivery id too long":  {func(w *types.WebhookCallback) { w.DeliveryID = strings.Repeat("a", types.MaxWebhookDeliveryIDLength+1) }, "deliveryId is too long"},
+		"delivery id not ascii": {func(w *types.WebhookCallback) { w.DeliveryID = "é" }, "deliveryId contains invalid characters"},
+		"payload too large": {func(w *types.WebhookCallback) {
+			w.Payload = map[string]any{"data": strings.Repeat("a", types.MaxWebhookCallbackPayloadSize)}
+		}, "payload is too large"},
+		"payload not encodable": {func(w *types.WebhookCallback) {
+			w.Payload = map[string]any{"ch": make(chan int)}
+		}, "payload cannot be encoded as JSON"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			w := newWebhookCallback()
+			w.Clean()
+			tc.modify(w)
+			w.Clean()
+			require.ErrorContains(t, w.Validate(), tc.wantErr)
+		})
+	}
+}
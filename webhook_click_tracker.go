@@ -0,0 +1,109 @@
+package types
+
+import (
+	"sync"
+	"time"
+)
+
+// WebhookClickTracker keeps track of webhook button clicks, and decides whether a new click is allowed
+// according to the webhook CooldownSeconds and MaxClicksPerHour settings.
+// It is safe for concurrent use.
+//
+// The tracker is in-memory only, and is thus only effective within a single process.
+type WebhookClickTracker struct {
+	mu     sync.Mutex
+	clicks map[string]*webhookClicks
+}
+
+type webhookClicks struct {
+	retention time.Duration
+	times     []time.Time
+}
+
+// NewWebhookClickTracker creates a new WebhookClickTracker instance.
+func NewWebhookClickTracker() *WebhookClickTracker {
+	return &WebhookClickTracker{
+		clicks: make(map[string]*webhookClicks),
+	}
+}
+
+// WebhookClickKey returns the key used to track clicks for a specific webhook button in a specific Slack post.
+func WebhookClickKey(channelID, messageID, webhookID string) string {
+	return channelID + "\x00" + messageID + "\x00" + webhookID
+}
+
+// Allow returns true if a click on the webhook identified by key is allowed at the given time, and records the click.
+// A click is rejected if it is within CooldownSeconds of the previous allowed click, or if MaxClicksPerHour allowed
+// clicks have already been recorded during the last hour. Rejected clicks are not recorded.
+//
+// Clicks on webhooks without CooldownSeconds and MaxClicksPerHour are always allowed, and are not recorded.
+func (t *WebhookClickTracker) Allow(key string, hook *Webhook, now time.Time) bool {
+	if hook == nil || (hook.CooldownSeconds <= 0 && hook.MaxClicksPerHour <= 0) {
+		return true
+	}
+
+	cooldown := time.Duration(hook.CooldownSeconds) * time.Second
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.clicks[key]
+	if !ok {
+		entry = &webhookClicks{}
+		t.clicks[key] = entry
+	}
+
+	entry.retention = max(cooldown, time.Hour)
+	entry.prune(now)
+
+	if n := len(entry.times); n > 0 && cooldown > 0 && now.Sub(entry.times[n-1]) < cooldown {
+		return false
+	}
+
+	if hook.MaxClicksPerHour > 0 && entry.countSince(now.Add(-time.Hour)) >= hook.MaxClicksPerHour {
+		return false
+	}
+
+	entry.times = append(entry.times, now)
+
+	return true
+}
+
+// Prune removes all clicks that are no longer relevant for any decision at the given time.
+// Call it periodically to limit the memory usage of long-lived trackers.
+func (t *WebhookClickTracker) Prune(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, entry := range t.clicks {
+		entry.prune(now)
+
+		if len(entry.times) == 0 {
+			delete(t.clicks, key)
+		}
+	}
+}
+
+// prune removes clicks older than the retention, which covers both the cooldown period and the one hour window.
+func (c *webhookClicks) prune(now time.Time) {
+	cutoff := now.Add(-c.retention)
+
+	i := 0
+	for i < len(c.times) && c.times[i].Before(cutoff) {
+		i++
+	}
+
+	c.times = c.times[i:]
+}
+
+func (c *webhookClicks) countSince(since time.Time) int {
+	count := 0
+
+	for _, t := range c.times {
+		if !t.Before(since) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,89 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookClickTracker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	key := types.WebhookClickKey("C12345678", "1234.5678", "restart")
+
+	t.Run("webhooks without limits should always be allowed", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := types.NewWebhookClickTracker()
+		hook := &types.Webhook{ID: "restart"}
+
+		assert.True(t, tracker.Allow(key, hook, now))
+		assert.True(t, tracker.Allow(key, hook, now))
+		assert.True(t, tracker.Allow(key, nil, now))
+	})
+
+	t.Run("clicks within the cooldown should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := types.NewWebhookClickTracker()
+		hook := &types.Webhook{ID: "restart", CooldownSeconds: 60}
+
+		assert.True(t, tracker.Allow(key, hook, now))
+		assert.False(t, tracker.Allow(key, hook, now.Add(time.Second)))
+		assert.False(t, tracker.Allow(key, hook, now.Add(59*time.Second)))
+		assert.True(t, tracker.Allow(key, hook, now.Add(60*time.Second)))
+
+		// Other posts are tracked separately
+		assert.True(t, tracker.Allow(types.WebhookClickKey("C12345678", "9999.0000", "restart"), hook, now.Add(time.Second)))
+	})
+
+	t.Run("clicks above the hourly limit should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := types.NewWebhookClickTracker()
+		hook := &types.Webhook{ID: "restart", MaxClicksPerHour: 2}
+
+		assert.True(t, tracker.Allow(key, hook, now))
+		assert.True(t, tracker.Allow(key, hook, now.Add(time.Minute)))
+		assert.False(t, tracker.Allow(key, hook, now.Add(2*time.Minute)))
+		assert.False(t, tracker.Allow(key, hook, now.Add(59*time.Minute)))
+		assert.True(t, tracker.Allow(key, hook, now.Add(61*time.Minute)))
+	})
+
+	t.Run("prune should not affect decisions", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := types.NewWebhookClickTracker()
+		hook := &types.Webhook{ID: "restart", CooldownSeconds: 2 * 3600}
+
+		assert.True(t, tracker.Allow(key, hook, now))
+		tracker.Prune(now.Add(90 * time.Minute))
+		assert.False(t, tracker.Allow(key, hook, now.Add(90*time.Minute)))
+		tracker.Prune(now.Add(3 * time.Hour))
+		assert.True(t, tracker.Allow(key, hook, now.Add(3*time.Hour)))
+	})
+}
+
+func TestWebhookRateLimitValidation(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(cooldown, maxClicks int) *types.Alert {
+		return &types.Alert{Header: "a", RouteKey: "b", Severity: types.AlertError, Webhooks: []*types.Webhook{{
+			ID:               "foo",
+			URL:              "https://foo.bar",
+			ButtonText:       "press me",
+			CooldownSeconds:  cooldown,
+			MaxClicksPerHour: maxClicks,
+		}}}
+	}
+
+	require.NoError(t, newAlert(60, 10).Validate())
+	require.ErrorContains(t, newAlert(-1, 0).Validate(), "webhook[0].cooldownSeconds -1 is not valid")
+	require.ErrorContains(t, newAlert(types.MaxWebhookCooldownSeconds+1, 0).Validate(), "webhook[0].cooldownSeconds")
+	require.ErrorContains(t, newAlert(0, -1).Validate(), "webhook[0].maxClicksPerHour -1 is not valid")
+	require.ErrorContains(t, newAlert(0, types.MaxWebhookClicksPerHour+1).Validate(), "webhook[0].maxClicksPerHour")
+}
@@ -0,0 +1,37 @@
+package types
+
+import "fmt"
+
+// MaxWebhookLockKeyLength is the maximum length of a webhook lock key.
+const MaxWebhookLockKeyLength = 200
+
+// InvocationLockKey returns the key used to guard concurrent invocations of a SingleFlight webhook.
+// If LockKey is set, it is returned as is, so that invocations are guarded across Slack posts and alerts.
+// Otherwise, the key identifies the webhook button in the specified Slack post (see WebhookClickKey).
+func (hook *Webhook) InvocationLockKey(channelID, messageID string) string {
+	if hook.LockKey != "" {
+		return hook.LockKey
+	}
+
+	return WebhookClickKey(channelID, messageID, hook.ID)
+}
+
+func validateWebhookConcurrency(index int, hook *Webhook) error {
+	if hook.LockKey == "" {
+		return nil
+	}
+
+	if !hook.SingleFlight {
+		return fmt.Errorf("webhook[%d].lockKey requires singleFlight to be true", index)
+	}
+
+	if len(hook.LockKey) > MaxWebhookLockKeyLength {
+		return fmt.Errorf("webhook[%d].lockKey is too long, expected length <=%d", index, MaxWebhookLockKeyLength)
+	}
+
+	if !isValidASCII(hook.LockKey) {
+		return fmt.Errorf("webhook[%d].lockKey contains invalid characters, expected printable ASCII", index)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookConcurrencyGuard(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(hook *types.Webhook) *types.Alert {
+		hook.ID = "rollback"
+		hook.URL = "https://example.com/rollback"
+		hook.ButtonText = "Rollback"
+		return &types.Alert{Header: "a", RouteKey: "b", Severity: types.AlertError, Webhooks: []*types.Webhook{hook}}
+	}
+
+	a := newAlert(&types.Webhook{SingleFlight: true})
+	a.Clean()
+	require.NoError(t, a.Validate())
+
+	a = newAlert(&types.Webhook{SingleFlight: true, LockKey: " rollback:payments-api "})
+	a.Clean()
+	require.NoError(t, a.Validate())
+	assert.Equal(t, "rollback:payments-api", a.Webhooks[0].LockKey)
+
+	a = newAlert(&types.Webhook{LockKey: "rollback"})
+	a.Clean()
+	require.ErrorContains(t, a.Validate(), "webhook[0].lockKey requires singleFlight to be true")
+
+	a = newAlert(&types.Webhook{SingleFlight: true, LockKey: strings.Repeat("a", types.MaxWebhookLockKeyLength+1)})
+	a.Clean()
+	require.ErrorContains(t, a.Validate(), "webhook[0].lockKey is too long")
+
+	a = newAlert(&types.Webhook{SingleFlight: true, LockKey: "rollback\tpayments"})
+	a.Clean()
+	require.ErrorContains(t, a.Validate(), "webhook[0].lockKey contains invalid characters")
+
+	a = newAlert(&types.Webhook{SingleFlight: true, ButtonType: types.WebhookButtonTypeLink})
+	a.Clean()
+	require.ErrorContains(t, a.Validate(), "webhook[0].singleFlight is not supported for link buttons")
+}
+
+func TestWebhookInvocationLockKey(t *testing.T) {
+	t.Parallel()
+
+	hook := &types.Webhook{ID: "rollback"}
+	assert.Equal(t, types.WebhookClickKey("C1", "123.456", "rollback"), hook.InvocationLockKey("C1", "123.456"))
+
+	hook.LockKey = "rollback:payments-api"
+	assert.Equal(t, "rollback:payments-api", hook.InvocationLockKey("C1", "123.456"))
+}
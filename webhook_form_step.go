@@ -0,0 +1,101 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxWebhookFormStepCount is the maximum number of form steps per webhook.
+	MaxWebhookFormStepCount = 5
+	// MaxWebhookFormStepIDLength is the maximum length of a form step ID.
+	MaxWebhookFormStepIDLength = 100
+	// MaxWebhookFormStepTitleLength is the maximum length of a form step title (Slack modal title limit: 24 characters).
+	MaxWebhookFormStepTitleLength = 24
+)
+
+// WebhookFormStep is an additional page in a webhook's modal dialog, with its own set of inputs.
+// Steps allow complex flows, such as multi-stage remediation, to be split over several modal pages.
+type WebhookFormStep struct {
+	// ID is the unique identifier for this step within the webhook.
+	// The ID is used as the key for the step inputs in the webhook callback.
+	// Maximum length: MaxWebhookFormStepIDLength characters.
+	ID string `json:"id"`
+
+	// Title is the modal title shown on the step page.
+	// This field is required.
+	// It is automatically truncated at MaxWebhookFormStepTitleLength characters.
+	Title string `json:"title"`
+
+	// PlainTextInput defines text input fields shown on the step page.
+	// Maximum of MaxWebhookPlainTextInputCount inputs.
+	PlainTextInput []*WebhookPlainTextInput `json:"plainTextInput"`
+
+	// CheckboxInput defines checkbox groups shown on the step page.
+	// Maximum of MaxWebhookCheckboxInputCount inputs.
+	CheckboxInput []*WebhookCheckboxInput `json:"checkboxInput"`
+}
+
+func cleanWebhookFormSteps(steps []*WebhookFormStep) {
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+
+		step.ID = strings.TrimSpace(step.ID)
+		step.Title = strings.ReplaceAll(strings.TrimSpace(step.Title), "\n", " ")
+
+		if utf8.RuneCountInString(step.Title) > MaxWebhookFormStepTitleLength {
+			step.Title = strings.TrimSpace(truncateString(step.Title, MaxWebhookFormStepTitleLength-3)) + "..."
+		}
+
+		cleanWebhookInputs(step.PlainTextInput, step.CheckboxInput)
+	}
+}
+
+func validateWebhookFormSteps(index int, steps []*WebhookFormStep, inputIDs map[string]struct{}) error {
+	if len(steps) > MaxWebhookFormStepCount {
+		return fmt.Errorf("webhook[%d].steps item count is too large, expected <=%d", index, MaxWebhookFormStepCount)
+	}
+
+	stepIDs := make(map[string]struct{}, len(steps))
+
+	for stepIndex, step := range steps {
+		if step == nil {
+			return fmt.Errorf("webhook[%d].steps[%d] is nil", index, stepIndex)
+		}
+
+		if step.ID == "" {
+			return fmt.Errorf("webhook[%d].steps[%d].id is required", index, stepIndex)
+		}
+
+		if len(step.ID) > MaxWebhookFormStepIDLength {
+			return fmt.Errorf("webhook[%d].steps[%d].id is too long, expected length <=%d", index, stepIndex, MaxWebhookFormStepIDLength)
+		}
+
+		if _, ok := stepIDs[step.ID]; ok {
+			return fmt.Errorf("webhook[%d].steps[%d].id must be unique", index, stepIndex)
+		}
+
+		stepIDs[step.ID] = struct{}{}
+
+		if step.Title == "" {
+			return fmt.Errorf("webhook[%d].steps[%d].title is required", index, stepIndex)
+		}
+
+		if utf8.RuneCountInString(step.Title) > MaxWebhookFormStepTitleLength {
+			return fmt.Errorf("webhook[%d].steps[%d].title is too long, expected length <=%d", index, stepIndex, MaxWebhookFormStepTitleLength)
+		}
+
+		if len(step.PlainTextInput) == 0 && len(step.CheckboxInput) == 0 {
+			return fmt.Errorf("webhook[%d].steps[%d] must have at least one input", index, stepIndex)
+		}
+
+		if err := validateWebhookInputs(fmt.Sprintf("webhook[%d].steps[%d]", index, stepIndex), step.PlainTextInput, step.CheckboxInput, inputIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
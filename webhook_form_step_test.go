@@ -0,0 +1,92 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookFormSteps(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(steps ...*types.WebhookFormStep) *types.Alert {
+		return &types.Alert{
+			Header:   "a",
+			RouteKey: "b",
+			Severity: types.AlertError,
+			Webhooks: []*types.Webhook{
+				{
+					ID:             "remediate",
+					URL:            "https://example.com/remediate",
+					ButtonText:     "Remediate",
+					PlainTextInput: []*types.WebhookPlainTextInput{{ID: "reason", MaxLength: 100}},
+					Steps:          steps,
+				},
+			},
+		}
+	}
+
+	newStep := func(id, inputID string) *types.WebhookFormStep {
+		return &types.WebhookFormStep{
+			ID:             id,
+			Title:          "Step " + id,
+			PlainTextInput: []*types.WebhookPlainTextInput{{ID: inputID, MaxLength: 100}},
+		}
+	}
+
+	t.Run("valid steps should pass", func(t *testing.T) {
+		t.Parallel()
+
+		a := newAlert(newStep("1", "target"), &types.WebhookFormStep{
+			ID:            " 2 ",
+			Title:         " A very long step title that is truncated ",
+			CheckboxInput: []*types.WebhookCheckboxInput{{ID: " confirm ", Options: []*types.WebhookCheckboxOption{{Value: "yes"}}}},
+		})
+		a.Clean()
+		require.NoError(t, a.Validate())
+
+		step := a.Webhooks[0].Steps[1]
+		assert.Equal(t, "2", step.ID)
+		assert.Equal(t, "A very long step titl...", step.Title)
+		assert.Equal(t, "confirm", step.CheckboxInput[0].ID)
+	})
+
+	t.Run("too many steps should fail", func(t *testing.T) {
+		t.Parallel()
+
+		steps := make([]*types.WebhookFormStep, types.MaxWebhookFormStepCount+1)
+		for i := range steps {
+			steps[i] = newStep(string(rune('a'+i)), string(rune('a'+i)))
+		}
+
+		require.ErrorContains(t, newAlert(steps...).Validate(), "webhook[0].steps item count is too large")
+	})
+
+	t.Run("step ids should be unique", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorContains(t, newAlert(newStep("1", "a"), newStep("1", "b")).Validate(), "webhook[0].steps[1].id must be unique")
+	})
+
+	t.Run("input ids should be unique across steps", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorContains(t, newAlert(newStep("1", "reason")).Validate(), "webhook[0].steps[0].plainTextInput[0].id must be unique among all inputs")
+		require.ErrorContains(t, newAlert(newStep("1", "a"), newStep("2", "a")).Validate(), "webhook[0].steps[1].plainTextInput[0].id must be unique among all inputs")
+	})
+
+	t.Run("invalid steps should fail", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorContains(t, newAlert(nil).Validate(), "webhook[0].steps[0] is nil")
+		require.ErrorContains(t, newAlert(&types.WebhookFormStep{Title: "x"}).Validate(), "webhook[0].steps[0].id is required")
+		require.ErrorContains(t, newAlert(&types.WebhookFormStep{ID: "1"}).Validate(), "webhook[0].steps[0].title is required")
+		require.ErrorContains(t, newAlert(&types.WebhookFormStep{ID: "1", Title: "x"}).Validate(), "webhook[0].steps[0] must have at least one input")
+
+		step := newStep("1", "a")
+		step.PlainTextInput[0].MinLength = -1
+		require.ErrorContains(t, newAlert(step).Validate(), "webhook[0].steps[0].plainTextInput[0].minLength must be >=0")
+	})
+}
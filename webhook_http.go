@@ -0,0 +1,131 @@
+package types
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// cleanWebhookHeaders trims header names and values, and canonicalizes the header names.
+// If two names are equal once cleaned (such as 'X-Token' and 'x-token'), the headers are returned unchanged,
+// so that validation rejects the duplicate instead of one of them being dropped at random.
+func cleanWebhookHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+
+	cleaned := make(map[string]string, len(headers))
+
+	for name, value := range headers {
+		key := http.CanonicalHeaderKey(strings.TrimSpace(name))
+
+		if _, ok := cleaned[key]; ok {
+			return headers
+		}
+
+		cleaned[key] = strings.TrimSpace(value)
+	}
+
+	return cleaned
+}
+
+// validateWebhookHTTPRequest validates the HTTP request customization fields of a webhook.
+func validateWebhookHTTPRequest(index int, hook *Webhook) error {
+	if hook.Method != "" && !WebhookMethodIsValid(hook.Method) {
+		return fmt.Errorf("webhook[%d].method '%s' is not valid, expected empty or one of [%s]", index, hook.Method, strings.Join(ValidWebhookMethods(), ", "))
+	}
+
+	if len(hook.ContentType) > MaxWebhookContentTypeLength {
+		return fmt.Errorf("webhook[%d].contentType is too long, expected length <=%d", index, MaxWebhookContentTypeLength)
+	}
+
+	if hook.ContentType != "" && !isValidASCII(hook.ContentType) {
+		return fmt.Errorf("webhook[%d].contentType contains invalid characters, expected printable ASCII", index)
+	}
+
+	if hook.HTTPTarget != "" && !HTTPTargetNameRegex.MatchString(hook.HTTPTarget) {
+		return fmt.Errorf("webhook[%d].httpTarget '%s' is not valid, expected letters, digits, '.', '_' and '-', with length <=%d", index, truncateString(hook.HTTPTarget, 50), MaxHTTPTargetNameLength)
+	}
+
+	if hook.TimeoutSeconds < 0 {
+		return fmt.Errorf("webhook[%d].timeoutSeconds must be >=0", index)
+	}
+
+	if hook.TimeoutSeconds > MaxWebhookTimeoutSeconds {
+		return fmt.Errorf("webhook[%d].timeoutSeconds is too high, expected value <=%d", index, MaxWebhookTimeoutSeconds)
+	}
+
+	if len(hook.Headers) > MaxWebhookHeaderCount {
+		return fmt.Errorf("webhook[%d].headers item count is too large, expected <=%d", index, MaxWebhookHeaderCount)
+	}
+
+	seen := make(map[string]string, len(hook.Headers))
+
+	for _, name := range slices.Sorted(maps.Keys(hook.Headers)) {
+		key := http.CanonicalHeaderKey(strings.TrimSpace(name))
+
+		if other, ok := seen[key]; ok {
+			return fmt.Errorf("webhook[%d].headers[%s] is a duplicate of headers[%s], header names are case-insensitive", index, name, other)
+		}
+
+		seen[key] = name
+	}
+
+	for name, value := range hook.Headers {
+		if name == "" {
+			return fmt.Errorf("webhook[%d].headers contains an empty header name", index)
+		}
+
+		if len(name) > MaxWebhookHeaderNameLength {
+			return fmt.Errorf("webhook[%d].headers[%s] name is too long, expected length <=%d", index, name, MaxWebhookHeaderNameLength)
+		}
+
+		if !isValidHeaderName(name) {
+			return fmt.Errorf("webhook[%d].headers[%s] name contains invalid characters", index, name)
+		}
+
+		if isDisallowedWebhookHeader(name) {
+			return fmt.Errorf("webhook[%d].headers[%s] is not allowed", index, name)
+		}
+
+		if len(value) > MaxWebhookHeaderValueLength {
+			return fmt.Errorf("webhook[%d].headers[%s] value is too long, expected length <=%d", index, name, MaxWebhookHeaderValueLength)
+		}
+
+		if !isValidASCII(value) {
+			return fmt.Errorf("webhook[%d].headers[%s] value contains invalid characters, expected printable ASCII", index, name)
+		}
+	}
+
+	return nil
+}
+
+// isDisallowedWebhookHeader returns true if the header cannot be set with Webhook.Headers.
+// Hop-by-hop headers are only meaningful for a single transport-level connection, and the remaining
+// headers are managed by the HTTP client (Content-Type is set with Webhook.ContentType).
+func isDisallowedWebhookHeader(name string) bool {
+	switch http.CanonicalHeaderKey(name) {
+	case "Connection", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization", "Proxy-Connection",
+		"Te", "Trailer", "Transfer-Encoding", "Upgrade", "Host", "Content-Length", "Content-Type":
+		return true
+	}
+	return false
+}
+
+// isValidHeaderName returns true if the string is a valid HTTP header field name (an RFC 7230 token).
+func isValidHeaderName(s string) bool {
+	for i := range len(s) {
+		c := s[i]
+
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,98 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookHTTPRequestClean(t *testing.T) {
+	t.Parallel()
+
+	a := &types.Alert{Header: "a", RouteKey: "b", Webhooks: []*types.Webhook{{
+		ID:          "foo",
+		URL:         "https://foo.bar",
+		ButtonText:  "press me",
+		Method:      " put ",
+		ContentType: " text/plain ",
+		HTTPTarget:  " payments-mtls ",
+		Headers:     map[string]string{" x-api-key ": " secret "},
+	}}}
+	a.Clean()
+	assert.Equal(t, types.WebhookMethodPut, a.Webhooks[0].Method)
+	assert.Equal(t, "text/plain", a.Webhooks[0].ContentType)
+	assert.Equal(t, "payments-mtls", a.Webhooks[0].HTTPTarget)
+	assert.Equal(t, map[string]string{"X-Api-Key": "secret"}, a.Webhooks[0].Headers)
+	require.NoError(t, a.Validate())
+
+	// Names differing only in case are not merged, and rejected by Validate.
+	a.Webhooks[0].Headers = map[string]string{"X-Token": "foo", "x-token": "bar"}
+	a.Clean()
+	assert.Equal(t, map[string]string{"X-Token": "foo", "x-token": "bar"}, a.Webhooks[0].Headers)
+	require.ErrorContains(t, a.Validate(), "is a duplicate of")
+}
+
+func TestWebhookHTTPRequestValidation(t *testing.T) {
+	t.Parallel()
+
+	newAlert := func(modify func(hook *types.Webhook)) *types.Alert {
+		hook := &types.Webhook{ID: "foo", URL: "https://foo.bar", ButtonText: "press me"}
+		modify(hook)
+		return &types.Alert{Header: "a", RouteKey: "b", Severity: types.AlertError, Webhooks: []*types.Webhook{hook}}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(hook *types.Webhook)
+		err    string
+	}{
+		{"valid request", func(h *types.Webhook) {
+			h.Method = types.WebhookMethodPatch
+			h.Headers = map[string]string{"Authorization": "Bearer foo"}
+			h.TimeoutSeconds = types.MaxWebhookTimeoutSeconds
+		}, ""},
+		{"invalid http target", func(h *types.Webhook) { h.HTTPTarget = "payments mtls" }, "webhook[0].httpTarget 'payments mtls' is not valid"},
+		{"invalid method", func(h *types.Webhook) { h.Method = "GET" }, "webhook[0].method 'GET' is not valid"},
+		{"negative timeout", func(h *types.Webhook) { h.TimeoutSeconds = -1 }, "webhook[0].timeoutSeconds must be >=0"},
+		{"too high timeout", func(h *types.Webhook) { h.TimeoutSeconds = types.MaxWebhookTimeoutSeconds + 1 }, "webhook[0].timeoutSeconds is too high"},
+		{"too long content type", func(h *types.Webhook) {
+			h.ContentType = strings.Repeat("x", types.MaxWebhookContentTypeLength+1)
+		}, "webhook[0].contentType is too long"},
+		{"too many headers", func(h *types.Webhook) {
+			h.Headers = map[string]string{}
+			for i := range types.MaxWebhookHeaderCount + 1 {
+				h.Headers["X-Header-"+strings.Repeat("a", i+1)] = "foo"
+			}
+		}, "webhook[0].headers item count is too large"},
+		{"too long header name", func(h *types.Webhook) {
+			h.Headers = map[string]string{strings.Repeat("x", types.MaxWebhookHeaderNameLength+1): "foo"}
+		}, "name is too long"},
+		{"invalid header name", func(h *types.Webhook) { h.Headers = map[string]string{"X Foo": "foo"} }, "name contains invalid characters"},
+		{"too long header value", func(h *types.Webhook) {
+			h.Headers = map[string]string{"X-Foo": strings.Repeat("x", types.MaxWebhookHeaderValueLength+1)}
+		}, "value is too long"},
+		{"invalid header value", func(h *types.Webhook) { h.Headers = map[string]string{"X-Foo": "foo\nbar"} }, "value contains invalid characters"},
+		{"hop-by-hop header", func(h *types.Webhook) { h.Headers = map[string]string{"Connection": "close"} }, "webhook[0].headers[Connection] is not allowed"},
+		{"managed header", func(h *types.Webhook) { h.Headers = map[string]string{"content-type": "text/plain"} }, "is not allowed"},
+		{"case duplicate header", func(h *types.Webhook) {
+			h.Headers = map[string]string{"X-Token": "foo", "x-token": "bar"}
+		}, "webhook[0].headers[x-token] is a duplicate of headers[X-Token], header names are case-insensitive"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newAlert(test.modify)
+
+			if test.err == "" {
+				require.NoError(t, a.Validate())
+			} else {
+				require.ErrorContains(t, a.Validate(), test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateWebhookLinkButton validates a webhook with ButtonType link. Link buttons only open the URL in the browser,
+// so the URL must be an HTTP(S) URL, and fields related to requests, callbacks and click handling must be empty.
+func validateWebhookLinkButton(index int, hook *Webhook) error {
+	lowerURL := strings.ToLower(hook.URL)
+
+	if !strings.HasPrefix(lowerURL, "http://") && !strings.HasPrefix(lowerURL, "https://") {
+		return fmt.Errorf("webhook[%d].url must be an http or https URL for link buttons", index)
+	}
+
+	var unsupported string
+
+	switch {
+	case hook.ConfirmationText != "":
+		unsupported = "confirmationText"
+	case hook.AccessLevel != "":
+		unsupported = "accessLevel"
+	case len(hook.AllowedUserIDs) > 0:
+		unsupported = "allowedUserIds"
+	case len(hook.AllowedGroupIDs) > 0:
+		unsupported = "allowedGroupIds"
+	case len(hook.Payload) > 0:
+		unsupported = "payload"
+	case len(hook.PlainTextInput) > 0:
+		unsupported = "plainTextInput"
+	case len(hook.CheckboxInput) > 0:
+		unsupported = "checkboxInput"
+	case len(hook.Steps) > 0:
+		unsupported = "steps"
+	case hook.Method != "":
+		unsupported = "method"
+	case len(hook.Headers) > 0:
+		unsupported = "headers"
+	case hook.ContentType != "":
+		unsupported = "contentType"
+	case hook.TimeoutSeconds != 0:
+		unsupported = "timeoutSeconds"
+	case hook.HTTPTarget != "":
+		unsupported = "httpTarget"
+	case hook.RetryPolicy != nil:
+		unsupported = "retryPolicy"
+	case hook.CooldownSeconds != 0:
+		unsupported = "cooldownSeconds"
+	case hook.MaxClicksPerHour != 0:
+		unsupported = "maxClicksPerHour"
+	case hook.SingleFlight:
+		unsupported = "singleFlight"
+	default:
+		return nil
+	}
+
+	return fmt.Errorf("webhook[%d].%s is not supported for link buttons", index, unsupported)
+}
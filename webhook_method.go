@@ -0,0 +1,33 @@
+package types
+
+// WebhookMethod represents the HTTP method used when invoking an HTTP webhook.
+type WebhookMethod string
+
+const (
+	// WebhookMethodPost represents HTTP method 'POST'. This is the default method.
+	WebhookMethodPost WebhookMethod = "POST"
+
+	// WebhookMethodPut represents HTTP method 'PUT'.
+	WebhookMethodPut WebhookMethod = "PUT"
+
+	// WebhookMethodPatch represents HTTP method 'PATCH'.
+	WebhookMethodPatch WebhookMethod = "PATCH"
+)
+
+// WebhookMethodIsValid returns true if the provided WebhookMethod is valid.
+func WebhookMethodIsValid(s WebhookMethod) bool {
+	switch s {
+	case WebhookMethodPost, WebhookMethodPut, WebhookMethodPatch:
+		return true
+	}
+	return false
+}
+
+// ValidWebhookMethods returns a slice of valid WebhookMethod values.
+func ValidWebhookMethods() []string {
+	return []string{
+		string(WebhookMethodPost),
+		string(WebhookMethodPut),
+		string(WebhookMethodPatch),
+	}
+}
@@ -0,0 +1,28 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookMethodValidation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.WebhookMethodIsValid(types.WebhookMethodPost))
+	assert.True(t, types.WebhookMethodIsValid(types.WebhookMethodPut))
+	assert.True(t, types.WebhookMethodIsValid(types.WebhookMethodPatch))
+	assert.False(t, types.WebhookMethodIsValid("GET"))
+	assert.False(t, types.WebhookMethodIsValid("post"))
+}
+
+func TestWebhookMethodString(t *testing.T) {
+	t.Parallel()
+
+	s := types.ValidWebhookMethods()
+	assert.Len(t, s, 3)
+	assert.Contains(t, s, "POST")
+	assert.Contains(t, s, "PUT")
+	assert.Contains(t, s, "PATCH")
+}
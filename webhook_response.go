@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxWebhookResponseMessageLength is the maximum length of a webhook response message (Slack section text limit).
+const MaxWebhookResponseMessageLength = 3000
+
+// WebhookResponse is the response returned by a webhook handler, describing the outcome of a button click.
+// The Slack Manager uses it to give feedback to the user, and optionally to update the Slack post.
+//
+// HTTP webhooks return the response as the JSON body. An empty body is treated as a successful response without a message.
+type WebhookResponse struct {
+	// Status is the outcome of the webhook action.
+	// Valid values are defined by WebhookResponseStatus constants.
+	// If empty, success is assumed.
+	Status WebhookResponseStatus `json:"status"`
+
+	// Message is an optional message shown to the user, or posted in the alert thread.
+	// It is automatically truncated at MaxWebhookResponseMessageLength characters.
+	Message string `json:"message"`
+
+	// UpdateAlert is an optional alert that replaces the current alert in the Slack post, for example with an updated
+	// text or a different severity. It must have the same correlation ID as the original alert.
+	UpdateAlert *Alert `json:"updateAlert"`
+
+	// Ephemeral indicates that the message should be shown only to the user who clicked the button.
+	// If false, the message is posted in the alert thread, visible to everyone in the channel.
+	Ephemeral bool `json:"ephemeral"`
+}
+
+// Clean normalizes the response fields, including the UpdateAlert (if any).
+func (r *WebhookResponse) Clean() {
+	if r == nil {
+		return
+	}
+
+	r.Status = WebhookResponseStatus(strings.ToLower(strings.TrimSpace(string(r.Status))))
+	r.Message = strings.TrimSpace(r.Message)
+
+	if r.Status == "" {
+		r.Status = WebhookResponseStatusSuccess
+	}
+
+	if utf8.RuneCountInString(r.Message) > MaxWebhookResponseMessageLength {
+		r.Message = strings.TrimSpace(truncateString(r.Message, MaxWebhookResponseMessageLength-3)) + "..."
+	}
+
+	if r.UpdateAlert != nil {
+		r.UpdateAlert.Clean()
+	}
+}
+
+// Validate returns an error if the response is invalid. Call Clean first.
+func (r *WebhookResponse) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	if !WebhookResponseStatusIsValid(r.Status) {
+		return fmt.Errorf("status '%s' is not valid, expected one of [%s]", r.Status, strings.Join(ValidWebhookResponseStatuses(), ", "))
+	}
+
+	if utf8.RuneCountInString(r.Message) > MaxWebhookResponseMessageLength {
+		return fmt.Errorf("message is too long, expected length <=%d", MaxWebhookResponseMessageLength)
+	}
+
+	if r.UpdateAlert != nil {
+		if err := r.UpdateAlert.Validate(); err != nil {
+			return fmt.Errorf("updateAlert is not valid: %w", err)
+		}
+	}
+
+	return nil
+}
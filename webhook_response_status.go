@@ -0,0 +1,33 @@
+package types
+
+// WebhookResponseStatus is the outcome of a webhook invocation, as reported by the webhook handler.
+type WebhookResponseStatus string
+
+const (
+	// WebhookResponseStatusSuccess indicates that the webhook action completed successfully.
+	WebhookResponseStatusSuccess WebhookResponseStatus = "success"
+
+	// WebhookResponseStatusFailure indicates that the webhook action failed.
+	WebhookResponseStatusFailure WebhookResponseStatus = "failure"
+
+	// WebhookResponseStatusPending indicates that the webhook action was accepted, but has not completed yet.
+	WebhookResponseStatusPending WebhookResponseStatus = "pending"
+)
+
+// WebhookResponseStatusIsValid returns true if the provided WebhookResponseStatus is valid.
+func WebhookResponseStatusIsValid(s WebhookResponseStatus) bool {
+	switch s {
+	case WebhookResponseStatusSuccess, WebhookResponseStatusFailure, WebhookResponseStatusPending:
+		return true
+	}
+	return false
+}
+
+// ValidWebhookResponseStatuses returns a slice of valid WebhookResponseStatus values.
+func ValidWebhookResponseStatuses() []string {
+	return []string{
+		string(WebhookResponseStatusSuccess),
+		string(WebhookResponseStatusFailure),
+		string(WebhookResponseStatusPending),
+	}
+}
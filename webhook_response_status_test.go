@@ -0,0 +1,18 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookResponseStatusValidation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, types.WebhookResponseStatusIsValid(types.WebhookResponseStatusSuccess))
+	assert.True(t, types.WebhookResponseStatusIsValid(types.WebhookResponseStatusFailure))
+	assert.True(t, types.WebhookResponseStatusIsValid(types.WebhookResponseStatusPending))
+	assert.False(t, types.WebhookResponseStatusIsValid("ok"))
+	assert.Len(t, types.ValidWebhookResponseStatuses(), 3)
+}
@@ -0,0 +1,66 @@
+package types_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty response should default to success", func(t *testing.T) {
+		t.Parallel()
+
+		r := &types.WebhookResponse{}
+		r.Clean()
+		require.NoError(t, r.Validate())
+		assert.Equal(t, types.WebhookResponseStatusSuccess, r.Status)
+
+		var nilResponse *types.WebhookResponse
+		nilResponse.Clean()
+		require.NoError(t, nilResponse.Validate())
+	})
+
+	t.Run("response should be decoded and cleaned", func(t *testing.T) {
+		t.Parallel()
+
+		r := &types.WebhookResponse{}
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"status": " FAILURE ",
+			"message": " Rollback failed ",
+			"ephemeral": true,
+			"updateAlert": {"header": " Rollback failed ", "routeKey": "deploys"}
+		}`), r))
+
+		r.Clean()
+		require.NoError(t, r.Validate())
+		assert.Equal(t, types.WebhookResponseStatusFailure, r.Status)
+		assert.Equal(t, "Rollback failed", r.Message)
+		assert.True(t, r.Ephemeral)
+		assert.Equal(t, "Rollback failed", r.UpdateAlert.Header)
+	})
+
+	t.Run("long message should be truncated", func(t *testing.T) {
+		t.Parallel()
+
+		r := &types.WebhookResponse{Message: strings.Repeat("a", types.MaxWebhookResponseMessageLength+1)}
+		require.ErrorContains(t, r.Validate(), "status '' is not valid")
+
+		r.Clean()
+		require.NoError(t, r.Validate())
+		assert.Len(t, r.Message, types.MaxWebhookResponseMessageLength)
+	})
+
+	t.Run("invalid update alert should fail", func(t *testing.T) {
+		t.Parallel()
+
+		r := &types.WebhookResponse{UpdateAlert: &types.Alert{Header: "a", RouteKey: "b", Severity: "fatal"}}
+		r.Clean()
+		require.ErrorContains(t, r.Validate(), "updateAlert is not valid")
+	})
+}
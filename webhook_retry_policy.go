@@ -0,0 +1,94 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	// MaxWebhookRetryAttempts is the maximum number of attempts (including the first) for a webhook with a retry policy.
+	MaxWebhookRetryAttempts = 5
+	// MaxWebhookRetryBackoffSeconds is the maximum initial backoff between webhook retry attempts.
+	MaxWebhookRetryBackoffSeconds = 60
+	// MaxWebhookRetryStatusCodeCount is the maximum number of status codes in a webhook retry policy.
+	MaxWebhookRetryStatusCodeCount = 10
+)
+
+// WebhookRetryPolicy defines how a failed HTTP webhook request is retried.
+// Network errors and timeouts are always retried, as long as attempts remain.
+type WebhookRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Must be between 1 and MaxWebhookRetryAttempts. A value of 1 disables retries.
+	MaxAttempts int `json:"maxAttempts"`
+
+	// BackoffSeconds is the delay before the first retry. The delay is doubled for each subsequent retry.
+	// Must be between 0 and MaxWebhookRetryBackoffSeconds.
+	BackoffSeconds int `json:"backoffSeconds"`
+
+	// RetryOnStatusCodes is the list of HTTP response status codes that should be retried.
+	// Each status code must be between 400 and 599.
+	// If empty, DefaultWebhookRetryStatusCodes are used.
+	// Maximum of MaxWebhookRetryStatusCodeCount items.
+	RetryOnStatusCodes []int `json:"retryOnStatusCodes"`
+}
+
+// DefaultWebhookRetryStatusCodes returns the status codes retried when a retry policy does not specify any.
+func DefaultWebhookRetryStatusCodes() []int {
+	return []int{429, 502, 503, 504}
+}
+
+// ShouldRetry returns true if a request that completed attempt number attempt (starting at 1) with the given
+// status code should be retried. Use statusCode 0 for network errors and timeouts.
+func (p *WebhookRetryPolicy) ShouldRetry(attempt, statusCode int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if statusCode == 0 {
+		return true
+	}
+
+	codes := p.RetryOnStatusCodes
+	if len(codes) == 0 {
+		codes = DefaultWebhookRetryStatusCodes()
+	}
+
+	return slices.Contains(codes, statusCode)
+}
+
+// Backoff returns the delay before the retry following attempt number attempt (starting at 1).
+func (p *WebhookRetryPolicy) Backoff(attempt int) time.Duration {
+	if p == nil || attempt < 1 {
+		return 0
+	}
+
+	return time.Duration(p.BackoffSeconds) * time.Second << min(attempt-1, MaxWebhookRetryAttempts)
+}
+
+// validateWebhookRetryPolicy validates the retry policy of a webhook, if set.
+func validateWebhookRetryPolicy(index int, p *WebhookRetryPolicy) error {
+	if p == nil {
+		return nil
+	}
+
+	if p.MaxAttempts < 1 || p.MaxAttempts > MaxWebhookRetryAttempts {
+		return fmt.Errorf("webhook[%d].retryPolicy.maxAttempts %d is not valid, expected value between 1 and %d", index, p.MaxAttempts, MaxWebhookRetryAttempts)
+	}
+
+	if p.BackoffSeconds < 0 || p.BackoffSeconds > MaxWebhookRetryBackoffSeconds {
+		return fmt.Errorf("webhook[%d].retryPolicy.backoffSeconds %d is not valid, expected value between 0 and %d", index, p.BackoffSeconds, MaxWebhookRetryBackoffSeconds)
+	}
+
+	if len(p.RetryOnStatusCodes) > MaxWebhookRetryStatusCodeCount {
+		return fmt.Errorf("webhook[%d].retryPolicy.retryOnStatusCodes item count is too large, expected <=%d", index, MaxWebhookRetryStatusCodeCount)
+	}
+
+	for i, code := range p.RetryOnStatusCodes {
+		if code < 400 || code > 599 {
+			return fmt.Errorf("webhook[%d].retryPolicy.retryOnStatusCodes[%d] %d is not valid, expected value between 400 and 599", index, i, code)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookRetryPolicyShouldRetry(t *testing.T) {
+	t.Parallel()
+
+	var p *types.WebhookRetryPolicy
+	assert.False(t, p.ShouldRetry(1, 503))
+
+	p = &types.WebhookRetryPolicy{MaxAttempts: 3}
+	assert.True(t, p.ShouldRetry(1, 503))
+	assert.True(t, p.ShouldRetry(2, 0))
+	assert.False(t, p.ShouldRetry(3, 503))
+	assert.False(t, p.ShouldRetry(1, 500))
+	assert.False(t, p.ShouldRetry(1, 400))
+
+	p = &types.WebhookRetryPolicy{MaxAttempts: 3, RetryOnStatusCodes: []int{500}}
+	assert.True(t, p.ShouldRetry(1, 500))
+	assert.False(t, p.ShouldRetry(1, 503))
+}
+
+func TestWebhookRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	var p *types.WebhookRetryPolicy
+	assert.Equal(t, time.Duration(0), p.Backoff(1))
+
+	p = &types.WebhookRetryPolicy{MaxAttempts: 4, BackoffSeconds: 2}
+	assert.Equal(t, time.Duration(0), p.Backoff(0))
+	assert.Equal(t, 2*time.Second, p.Backoff(1))
+	assert.Equal(t, 4*time.Second, p.Backoff(2))
+	assert.Equal(t, 8*time.Second, p.Backoff(3))
+}
+
+func TestWebhookRetryPolicyValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy *types.WebhookRetryPolicy
+		err    string
+	}{
+		{"valid policy", &types.WebhookRetryPolicy{MaxAttempts: 3, BackoffSeconds: 5, RetryOnStatusCodes: []int{429, 503}}, ""},
+		{"zero attempts", &types.WebhookRetryPolicy{MaxAttempts: 0}, "webhook[0].retryPolicy.maxAttempts 0 is not valid"},
+		{"too many attempts", &types.WebhookRetryPolicy{MaxAttempts: types.MaxWebhookRetryAttempts + 1}, "retryPolicy.maxAttempts"},
+		{"negative backoff", &types.WebhookRetryPolicy{MaxAttempts: 2, BackoffSeconds: -1}, "retryPolicy.backoffSeconds -1 is not valid"},
+		{"too high backoff", &types.WebhookRetryPolicy{MaxAttempts: 2, BackoffSeconds: types.MaxWebhookRetryBackoffSeconds + 1}, "retryPolicy.backoffSeconds"},
+		{"too many status codes", &types.WebhookRetryPolicy{MaxAttempts: 2, RetryOnStatusCodes: make([]int, types.MaxWebhookRetryStatusCodeCount+1)}, "retryOnStatusCodes item count is too large"},
+		{"invalid status code", &types.WebhookRetryPolicy{MaxAttempts: 2, RetryOnStatusCodes: []int{503, 200}}, "retryOnStatusCodes[1] 200 is not valid"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := &types.Alert{Header: "a", RouteKey: "b", Severity: types.AlertError, Webhooks: []*types.Webhook{{
+				ID:          "foo",
+				URL:         "https://foo.bar",
+				ButtonText:  "press me",
+				RetryPolicy: test.policy,
+			}}}
+
+			if test.err == "" {
+				require.NoError(t, a.Validate())
+			} else {
+				require.ErrorContains(t, a.Validate(), test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Package wirecompat provides a corpus of versioned Alert and WebhookCallback JSON fixtures, and test helpers
+// verifying that every historical fixture can still be decoded with the current types.
+//
+// Field renames or type changes that would break existing alert producers or webhook consumers make the
+// verification fail. Downstream forks of the types package can run the same verification:
+//
+//	func TestWireCompatibility(t *testing.T) {
+//	    wirecompat.VerifyWireCompatibility(t)
+//	}
+//
+// A new fixture directory (fixtures/<version>) should be added for each release that changes the wire format.
+// Existing fixtures must never be modified.
+package wirecompat
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// FixtureKind is the type encoded in a fixture.
+type FixtureKind string
+
+const (
+	// FixtureKindAlert indicates a fixture holding a types.Alert.
+	FixtureKindAlert FixtureKind = "alert"
+
+	// FixtureKindWebhookCallback indicates a fixture holding a types.WebhookCallback.
+	FixtureKindWebhookCallback FixtureKind = "webhook_callback"
+)
+
+// Fixture is a single JSON document in the corpus.
+type Fixture struct {
+	// Version is the release in which the fixture was added, such as 'v0.3'.
+	Version string
+
+	// Name is the fixture file name, without the .json extension.
+	Name string
+
+	// Kind is the type encoded in the fixture, derived from the file name prefix.
+	Kind FixtureKind
+
+	// Data is the raw JSON document.
+	Data []byte
+}
+
+// Fixtures returns all fixtures in the corpus, sorted by version and name.
+func Fixtures() ([]*Fixture, error) {
+	var result []*Fixture
+
+	err := fs.WalkDir(fixtures, "fixtures", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || path.Ext(p) != ".json" {
+			return nil
+		}
+
+		data, err := fixtures.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSuffix(path.Base(p), ".json")
+
+		var kind FixtureKind
+
+		switch {
+		case strings.HasPrefix(name, string(FixtureKindWebhookCallback)):
+			kind = FixtureKindWebhookCallback
+		case strings.HasPrefix(name, string(FixtureKindAlert)):
+			kind = FixtureKindAlert
+		default:
+			return fmt.Errorf("fixture '%s' has an unknown kind", p)
+		}
+
+		result = append(result, &Fixture{
+			Version: path.Base(path.Dir(p)),
+			Name:    name,
+			Kind:    kind,
+			Data:    data,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Version != result[j].Version {
+			return result[i].Version < result[j].Version
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// VerifyWireCompatibility verifies all fixtures in the corpus, each in a separate subtest.
+func VerifyWireCompatibility(t *testing.T) {
+	t.Helper()
+
+	all, err := Fixtures()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all) == 0 {
+		t.Fatal("no fixtures found")
+	}
+
+	for _, f := range all {
+		t.Run(f.Version+"/"+f.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := VerifyFixture(f); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// VerifyFixture verifies a single fixture. An error is returned if any of the following fails:
+//   - The fixture decodes into the current type, without unknown fields.
+//   - All values in the fixture are preserved when the decoded value is encoded again.
+//   - Alert fixtures pass Clean and Validate.
+func VerifyFixture(f *Fixture) error {
+	switch f.Kind {
+	case FixtureKindAlert:
+		alert := &types.Alert{}
+
+		if err := roundTrip(f.Data, alert); err != nil {
+			return err
+		}
+
+		alert.Clean()
+
+		if err := alert.Validate(); err != nil {
+			return fmt.Errorf("alert is not valid: %w", err)
+		}
+
+		return nil
+	case FixtureKindWebhookCallback:
+		return roundTrip(f.Data, &types.WebhookCallback{})
+	default:
+		return fmt.Errorf("unknown fixture kind '%s'", f.Kind)
+	}
+}
+
+func roundTrip(data []byte, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("failed to decode fixture: %w", err)
+	}
+
+	encoded, err := json.Marshal(target)
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	var original, current any
+
+	if err := json.Unmarshal(data, &original); err != nil {
+		return fmt.Errorf("failed to decode fixture: %w", err)
+	}
+
+	if err := json.Unmarshal(encoded, &current); err != nil {
+		return fmt.Errorf("failed to decode encoded fixture: %w", err)
+	}
+
+	return compareValues("", original, current)
+}
+
+// compareValues returns an error if any value in original is missing or different in current.
+// Keys only present in current (new fields) are ignored.
+func compareValues(p string, original, current any) error {
+	switch o := original.(type) {
+	case map[string]any:
+		c, ok := current.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an object, got %T", displayPath(p), current)
+		}
+
+		for key, value := range o {
+			currentValue, ok := c[key]
+			if !ok {
+				return fmt.Errorf("%s: field is missing after round trip", displayPath(p+"."+key))
+			}
+
+			if err := compareValues(p+"."+key, value, currentValue); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case []any:
+		c, ok := current.([]any)
+		if !ok || len(c) != len(o) {
+			return fmt.Errorf("%s: expected a list of %d items, got %v", displayPath(p), len(o), current)
+		}
+
+		for i := range o {
+			if err := compareValues(fmt.Sprintf("%s[%d]", p, i), o[i], c[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		if !reflect.DeepEqual(original, current) {
+			return fmt.Errorf("%s: expected %v, got %v", displayPath(p), original, current)
+		}
+
+		return nil
+	}
+}
+
+func displayPath(p string) string {
+	if p == "" {
+		return "<root>"
+	}
+	return strings.TrimPrefix(p, ".")
+}
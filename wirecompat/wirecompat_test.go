@@ -0,0 +1,66 @@
+package wirecompat_test
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types/wirecompat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWireCompatibility(t *testing.T) {
+	t.Parallel()
+
+	wirecompat.VerifyWireCompatibility(t)
+}
+
+func TestVerifyFixture(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown fields should fail", func(t *testing.T) {
+		t.Parallel()
+
+		err := wirecompat.VerifyFixture(&wirecompat.Fixture{Kind: wirecompat.FixtureKindAlert, Data: []byte(`{"header":"a","routeKey":"b","renamed":1}`)})
+		require.ErrorContains(t, err, `unknown field "renamed"`)
+	})
+
+	t.Run("type changes should fail", func(t *testing.T) {
+		t.Parallel()
+
+		err := wirecompat.VerifyFixture(&wirecompat.Fixture{Kind: wirecompat.FixtureKindAlert, Data: []byte(`{"header":"a","routeKey":"b","autoResolveSeconds":"3600"}`)})
+		require.ErrorContains(t, err, "failed to decode fixture")
+	})
+
+	t.Run("invalid alerts should fail", func(t *testing.T) {
+		t.Parallel()
+
+		err := wirecompat.VerifyFixture(&wirecompat.Fixture{Kind: wirecompat.FixtureKindAlert, Data: []byte(`{"header":"a","routeKey":"b","severity":"fatal"}`)})
+		require.ErrorContains(t, err, "alert is not valid")
+	})
+
+	t.Run("webhook callbacks should be verified", func(t *testing.T) {
+		t.Parallel()
+
+		err := wirecompat.VerifyFixture(&wirecompat.Fixture{Kind: wirecompat.FixtureKindWebhookCallback, Data: []byte(`{"id":"a","input":{"x":"y"}}`)})
+		require.NoError(t, err)
+
+		err = wirecompat.VerifyFixture(&wirecompat.Fixture{Kind: wirecompat.FixtureKindWebhookCallback, Data: []byte(`{"id":"a","input":{"x":1}}`)})
+		require.Error(t, err)
+	})
+}
+
+func TestFixtures(t *testing.T) {
+	t.Parallel()
+
+	fixtures, err := wirecompat.Fixtures()
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+
+	kinds := make(map[wirecompat.FixtureKind]int)
+	for _, f := range fixtures {
+		kinds[f.Kind]++
+	}
+
+	assert.Positive(t, kinds[wirecompat.FixtureKindAlert])
+	assert.Positive(t, kinds[wirecompat.FixtureKindWebhookCallback])
+}